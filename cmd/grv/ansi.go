@@ -0,0 +1,287 @@
+package main
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+const (
+	ansiEscape        = '\x1b'
+	ansiBell          = '\x07'
+	ansiGreyThreshold = 32
+)
+
+// ansiCubeLevels are the channel intensities of the 6x6x6 color cube of the 256 color palette
+var ansiCubeLevels = []int{0, 95, 135, 175, 215, 255}
+
+// ansiSegment is a run of text which is displayed using a single theme component
+type ansiSegment struct {
+	text             string
+	themeComponentID ThemeComponentID
+}
+
+// ansiStyle is the graphic rendition state set by SGR escape sequences
+type ansiStyle struct {
+	fgcolor ThemeColor
+	bgcolor ThemeColor
+	reverse bool
+}
+
+// themeComponentID returns the theme component text with this style is displayed with
+// The background color takes precedence as it usually distinguishes the type of a line, e.g. an added line
+// Reversed text is displayed with its foreground color as the background color
+func (style ansiStyle) themeComponentID() ThemeComponentID {
+	fgcolor, bgcolor := style.fgcolor, style.bgcolor
+	if style.reverse {
+		fgcolor, bgcolor = bgcolor, fgcolor
+	}
+
+	switch {
+	case bgcolor != ColorNone:
+		return CmpAllviewAnsiBlackBackground + ThemeComponentID(bgcolor-ColorBlack)
+	case fgcolor != ColorNone:
+		return CmpAllviewAnsiBlack + ThemeComponentID(fgcolor-ColorBlack)
+	}
+
+	return CmpNone
+}
+
+// ParseANSIText removes escape sequences from the provided text and returns the plain text along with
+// segments of the text styled by the theme components the SGR colors translate to
+func ParseANSIText(text string) (plainText string, segments []ansiSegment) {
+	var plainTextBuffer, segmentBuffer bytes.Buffer
+	var style ansiStyle
+
+	flushSegment := func() {
+		if segmentBuffer.Len() > 0 {
+			segments = append(segments, ansiSegment{
+				text:             segmentBuffer.String(),
+				themeComponentID: style.themeComponentID(),
+			})
+			segmentBuffer.Reset()
+		}
+	}
+
+	for index := 0; index < len(text); {
+		if text[index] != ansiEscape {
+			plainTextBuffer.WriteByte(text[index])
+			segmentBuffer.WriteByte(text[index])
+			index++
+			continue
+		}
+
+		sequenceEnd, parameters, isSGR := parseEscapeSequence(text, index)
+		if isSGR {
+			flushSegment()
+			style = style.apply(parameters)
+		}
+
+		index = sequenceEnd
+	}
+
+	flushSegment()
+
+	return plainTextBuffer.String(), segments
+}
+
+// parseEscapeSequence returns the index after the escape sequence starting at startIndex
+// If the sequence is an SGR sequence then its parameters are returned
+func parseEscapeSequence(text string, startIndex int) (endIndex int, parameters []int, isSGR bool) {
+	index := startIndex + 1
+	if index >= len(text) {
+		return index, nil, false
+	}
+
+	switch text[index] {
+	case '[':
+		paramStart := index + 1
+		index = paramStart
+
+		for index < len(text) && (text[index] < 0x40 || text[index] > 0x7E) {
+			index++
+		}
+
+		if index >= len(text) {
+			return index, nil, false
+		}
+
+		if text[index] == 'm' {
+			return index + 1, parseSGRParameters(text[paramStart:index]), true
+		}
+
+		return index + 1, nil, false
+	case ']':
+		for index++; index < len(text); index++ {
+			if text[index] == ansiBell {
+				return index + 1, nil, false
+			} else if text[index] == ansiEscape && index+1 < len(text) && text[index+1] == '\\' {
+				return index + 2, nil, false
+			}
+		}
+
+		return index, nil, false
+	}
+
+	return index + 1, nil, false
+}
+
+func parseSGRParameters(parameterText string) (parameters []int) {
+	if parameterText == "" {
+		return []int{0}
+	}
+
+	for _, parameter := range strings.FieldsFunc(parameterText, func(char rune) bool {
+		return char == ';' || char == ':'
+	}) {
+		value, err := strconv.Atoi(parameter)
+		if err != nil {
+			value = 0
+		}
+
+		parameters = append(parameters, value)
+	}
+
+	return
+}
+
+// apply returns the style after applying the provided SGR parameters
+// Only colors and reverse video are supported, all other attributes are ignored
+func (style ansiStyle) apply(parameters []int) ansiStyle {
+	for index := 0; index < len(parameters); index++ {
+		parameter := parameters[index]
+
+		switch {
+		case parameter == 0:
+			style = ansiStyle{}
+		case parameter == 7:
+			style.reverse = true
+		case parameter == 27:
+			style.reverse = false
+		case parameter >= 30 && parameter <= 37:
+			style.fgcolor = ColorBlack + ThemeColor(parameter-30)
+		case parameter == 39:
+			style.fgcolor = ColorNone
+		case parameter >= 40 && parameter <= 47:
+			style.bgcolor = ColorBlack + ThemeColor(parameter-40)
+		case parameter == 49:
+			style.bgcolor = ColorNone
+		case parameter >= 90 && parameter <= 97:
+			style.fgcolor = ColorBlack + ThemeColor(parameter-90)
+		case parameter >= 100 && parameter <= 107:
+			style.bgcolor = ColorBlack + ThemeColor(parameter-100)
+		case parameter == 38 || parameter == 48:
+			var color ThemeColor
+			color, index = extendedColor(parameters, index+1)
+
+			if parameter == 38 {
+				style.fgcolor = color
+			} else {
+				style.bgcolor = color
+			}
+		}
+	}
+
+	return style
+}
+
+// extendedColor parses a 256 color or 24-bit color starting at parameterIndex
+// The index of the last parameter consumed is returned
+func extendedColor(parameters []int, parameterIndex int) (color ThemeColor, lastIndex int) {
+	if parameterIndex >= len(parameters) {
+		return ColorNone, parameterIndex
+	}
+
+	switch parameters[parameterIndex] {
+	case 5:
+		if parameterIndex+1 < len(parameters) {
+			return ansi256Color(parameters[parameterIndex+1]), parameterIndex + 1
+		}
+	case 2:
+		if parameterIndex+3 < len(parameters) {
+			return rgbColor(parameters[parameterIndex+1], parameters[parameterIndex+2], parameters[parameterIndex+3]), parameterIndex + 3
+		}
+	}
+
+	return ColorNone, len(parameters)
+}
+
+// ansi256Color returns the closest display color to the provided color of the 256 color palette
+func ansi256Color(index int) ThemeColor {
+	switch {
+	case index < 0 || index > 255:
+		return ColorNone
+	case index < 8:
+		return ColorBlack + ThemeColor(index)
+	case index < 16:
+		return ColorBlack + ThemeColor(index-8)
+	case index < 232:
+		index -= 16
+		return rgbColor(ansiCubeLevels[index/36], ansiCubeLevels[(index/6)%6], ansiCubeLevels[index%6])
+	}
+
+	level := 8 + 10*(index-232)
+	return rgbColor(level, level, level)
+}
+
+// rgbColor returns the closest display color to the provided 24-bit color
+// Colors are matched by hue rather than brightness, so that dark backgrounds retain their hue
+func rgbColor(red, green, blue int) ThemeColor {
+	maxLevel, minLevel := red, red
+	for _, level := range []int{green, blue} {
+		if level > maxLevel {
+			maxLevel = level
+		} else if level < minLevel {
+			minLevel = level
+		}
+	}
+
+	if maxLevel-minLevel < ansiGreyThreshold {
+		if maxLevel < 128 {
+			return ColorBlack
+		}
+
+		return ColorWhite
+	}
+
+	var colorIndex ThemeColor
+	for bit, level := range []int{red, green, blue} {
+		if level*2 >= maxLevel {
+			colorIndex |= 1 << uint(bit)
+		}
+	}
+
+	return ColorBlack + colorIndex
+}
+
+// addANSIThemeComponents sets the components ANSI colors are translated to so that they display as the original colors
+func addANSIThemeComponents(theme MutableTheme) {
+	for color := ColorBlack; color <= ColorWhite; color++ {
+		offset := ThemeComponentID(color - ColorBlack)
+
+		theme.CreateOrGetComponent(CmpAllviewAnsiBlack + offset).fgcolor = color
+		theme.CreateOrGetComponent(CmpAllviewAnsiBlackBackground + offset).bgcolor = color
+	}
+}
+
+// AppendANSI adds text containing ANSI escape sequences to the end of the line
+// Text is displayed with the theme components its colors translate to and uncolored text uses the provided theme component
+func (lineBuilder *LineBuilder) AppendANSI(themeComponentID ThemeComponentID, text string) *LineBuilder {
+	_, segments := ParseANSIText(text)
+	return lineBuilder.appendSegments(themeComponentID, segments)
+}
+
+// appendSegments adds the provided segments to the end of the line
+// Segments without a theme component are displayed using the provided theme component
+func (lineBuilder *LineBuilder) appendSegments(themeComponentID ThemeComponentID, segments []ansiSegment) *LineBuilder {
+	for _, segment := range segments {
+		segmentThemeComponentID := segment.themeComponentID
+		if segmentThemeComponentID == CmpNone {
+			segmentThemeComponentID = themeComponentID
+		}
+
+		lineBuilder.AppendWithStyle(segmentThemeComponentID, "%v", segment.text)
+	}
+
+	return lineBuilder
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseANSIText(t *testing.T) {
+	var parseTests = []struct {
+		text              string
+		expectedPlainText string
+		expectedSegments  []ansiSegment
+	}{
+		{
+			text:              "func main() {",
+			expectedPlainText: "func main() {",
+			expectedSegments:  []ansiSegment{{text: "func main() {", themeComponentID: CmpNone}},
+		},
+		{
+			text:              "\x1b[31m-old\x1b[0m",
+			expectedPlainText: "-old",
+			expectedSegments:  []ansiSegment{{text: "-old", themeComponentID: CmpAllviewAnsiRed}},
+		},
+		{
+			text:              "\x1b[1;92m+\x1b[m new",
+			expectedPlainText: "+ new",
+			expectedSegments: []ansiSegment{
+				{text: "+", themeComponentID: CmpAllviewAnsiGreen},
+				{text: " new", themeComponentID: CmpNone},
+			},
+		},
+		{
+			text:              "\x1b[48;2;0;40;0m\x1b[38;5;208mreturn\x1b[39m nil\x1b[0K\x1b[0m",
+			expectedPlainText: "return nil",
+			expectedSegments: []ansiSegment{
+				{text: "return", themeComponentID: CmpAllviewAnsiGreenBackground},
+				{text: " nil", themeComponentID: CmpAllviewAnsiGreenBackground},
+			},
+		},
+		{
+			text:              "\x1b]8;;file:///main.go\x1b\\main.go\x1b]8;;\x1b\\",
+			expectedPlainText: "main.go",
+			expectedSegments:  []ansiSegment{{text: "main.go", themeComponentID: CmpNone}},
+		},
+		{
+			text:              "",
+			expectedPlainText: "",
+		},
+	}
+
+	for _, parseTest := range parseTests {
+		plainText, segments := ParseANSIText(parseTest.text)
+
+		if plainText != parseTest.expectedPlainText {
+			t.Errorf("Plain text does not match expected value for text %q. Expected: %q, Actual: %q",
+				parseTest.text, parseTest.expectedPlainText, plainText)
+		}
+
+		if !reflect.DeepEqual(segments, parseTest.expectedSegments) {
+			t.Errorf("Segments do not match expected value for text %q. Expected: %v, Actual: %v",
+				parseTest.text, parseTest.expectedSegments, segments)
+		}
+	}
+}
+
+func TestANSIColorsAreMappedToClosestDisplayColor(t *testing.T) {
+	var colorTests = []struct {
+		description   string
+		color         ThemeColor
+		expectedColor ThemeColor
+	}{
+		{description: "palette blue", color: ansi256Color(4), expectedColor: ColorBlue},
+		{description: "palette bright cyan", color: ansi256Color(14), expectedColor: ColorCyan},
+		{description: "cube orange", color: ansi256Color(208), expectedColor: ColorYellow},
+		{description: "grayscale light grey", color: ansi256Color(250), expectedColor: ColorWhite},
+		{description: "dark red background", color: rgbColor(0x3f, 0x00, 0x01), expectedColor: ColorRed},
+		{description: "dark green background", color: rgbColor(0x00, 0x28, 0x00), expectedColor: ColorGreen},
+		{description: "purple", color: rgbColor(0x80, 0x00, 0x80), expectedColor: ColorMagenta},
+		{description: "dark grey", color: rgbColor(0x30, 0x30, 0x30), expectedColor: ColorBlack},
+	}
+
+	for _, colorTest := range colorTests {
+		if colorTest.color != colorTest.expectedColor {
+			t.Errorf("Color does not match expected value for %v. Expected: %v, Actual: %v",
+				colorTest.description, colorTest.expectedColor, colorTest.color)
+		}
+	}
+}
+
+func TestReversedTextUsesForegroundColorAsBackground(t *testing.T) {
+	_, segments := ParseANSIText("\x1b[33;7mWARNING\x1b[27m check")
+
+	expectedSegments := []ansiSegment{
+		{text: "WARNING", themeComponentID: CmpAllviewAnsiYellowBackground},
+		{text: " check", themeComponentID: CmpAllviewAnsiYellow},
+	}
+
+	if !reflect.DeepEqual(segments, expectedSegments) {
+		t.Errorf("Segments do not match expected value. Expected: %v, Actual: %v", expectedSegments, segments)
+	}
+}
+
+func TestAppendANSIUsesProvidedThemeComponentForUncoloredText(t *testing.T) {
+	config := NewConfiguration(NewKeyBindingManager(), nil)
+	win := NewWindow("ansi", config)
+	win.Resize(ViewDimension{rows: 3, cols: 20})
+	win.Clear()
+
+	lineBuilder, err := win.LineBuilder(1, 1)
+	if err != nil {
+		t.Fatalf("Unable to create line builder: %v", err)
+	}
+
+	lineBuilder.AppendANSI(CmpTextviewLine, "ok \x1b[31mfail\x1b[0m")
+
+	expectedThemeComponentIDs := []ThemeComponentID{
+		CmpTextviewLine, CmpTextviewLine, CmpTextviewLine,
+		CmpAllviewAnsiRed, CmpAllviewAnsiRed, CmpAllviewAnsiRed, CmpAllviewAnsiRed,
+	}
+
+	if line := strings.TrimRight(win.Line(1), " "); line != "ok fail" {
+		t.Errorf("Line does not match expected value. Expected: %v, Actual: %v", "ok fail", line)
+	}
+
+	for cellIndex, expectedThemeComponentID := range expectedThemeComponentIDs {
+		if themeComponentID := win.lines[1].cells[cellIndex].style.themeComponentID; themeComponentID != expectedThemeComponentID {
+			t.Errorf("Theme component at column %v does not match expected value. Expected: %v, Actual: %v",
+				cellIndex, expectedThemeComponentID, themeComponentID)
+		}
+	}
+}
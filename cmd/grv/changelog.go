@@ -0,0 +1,170 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	slice "github.com/bradfitz/slice"
+)
+
+// The set of changelog groupings
+const (
+	ChangelogGroupByType = "type"
+	ChangelogGroupByPath = "path"
+)
+
+const (
+	clOtherGroup = "other"
+	clRootGroup  = "/"
+)
+
+// changelogEntry is a single commit listed in a changelog group
+type changelogEntry struct {
+	group   string
+	summary string
+	shortID string
+}
+
+// GenerateChangelog lists the commits reachable from toRevision and not from fromRevision as markdown.
+// Commits are grouped by their Conventional Commit type or by the top level directory they modify
+func GenerateChangelog(repoData RepoData, fromRevision, toRevision, groupBy string) (changelog string, err error) {
+	if groupBy != ChangelogGroupByType && groupBy != ChangelogGroupByPath {
+		return "", fmt.Errorf("Invalid changelog grouping: %v. Expected %v or %v", groupBy, ChangelogGroupByType, ChangelogGroupByPath)
+	}
+
+	fromCommit, err := repoData.CommitByRevision(fromRevision)
+	if err != nil {
+		return
+	}
+
+	toCommit, err := repoData.CommitByRevision(toRevision)
+	if err != nil {
+		return
+	}
+
+	commits, err := repoData.CommitRange(fromCommit, toCommit)
+	if err != nil {
+		return
+	}
+
+	var entries []changelogEntry
+
+	for _, commit := range commits {
+		entry := changelogEntry{
+			summary: commit.commit.Summary(),
+			shortID: repoData.ShortID(commit.oid),
+		}
+
+		if groupBy == ChangelogGroupByType {
+			entry.group, entry.summary = changelogTypeGroup(entry.summary)
+		} else {
+			var diff *Diff
+			if diff, err = repoData.Diff(context.Background(), commit, DiffOptions{}); err != nil {
+				return
+			}
+
+			var paths []string
+			for _, diffFile := range diff.files {
+				paths = append(paths, diffFile.newPath)
+			}
+
+			entry.group = changelogPathGroup(paths)
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return formatChangelog(fromRevision, toRevision, entries), nil
+}
+
+// changelogTypeGroup returns the Conventional Commit type of the summary and the summary to list.
+// Summaries which are not Conventional Commits are placed in the other group
+func changelogTypeGroup(summary string) (group, entrySummary string) {
+	conventionalCommit, isConventionalCommit := ParseConventionalCommit(summary)
+	if !isConventionalCommit {
+		return clOtherGroup, summary
+	}
+
+	entrySummary = conventionalCommit.description
+
+	if conventionalCommit.scope != "" {
+		entrySummary = fmt.Sprintf("**%v:** %v", conventionalCommit.scope, entrySummary)
+	}
+
+	if conventionalCommit.breaking {
+		entrySummary = "**BREAKING:** " + entrySummary
+	}
+
+	return strings.ToLower(conventionalCommit.commitType), entrySummary
+}
+
+// changelogPathGroup returns the top level directory containing the most modified paths
+// Files in the root of the repository are grouped under /
+func changelogPathGroup(paths []string) string {
+	if len(paths) == 0 {
+		return clOtherGroup
+	}
+
+	directoryCounts := map[string]int{}
+
+	for _, filePath := range paths {
+		directory := clRootGroup
+		if separatorIndex := strings.Index(filePath, "/"); separatorIndex != -1 {
+			directory = path.Clean(filePath[:separatorIndex])
+		}
+
+		directoryCounts[directory]++
+	}
+
+	var group string
+	for directory, count := range directoryCounts {
+		if count > directoryCounts[group] || (count == directoryCounts[group] && directory < group) {
+			group = directory
+		}
+	}
+
+	return group
+}
+
+// formatChangelog generates a markdown changelog with groups listed alphabetically
+// The other group is always listed last. Entries retain their commit order within each group
+func formatChangelog(fromRevision, toRevision string, entries []changelogEntry) string {
+	groupEntries := map[string][]changelogEntry{}
+	var groups []string
+
+	for _, entry := range entries {
+		if _, exists := groupEntries[entry.group]; !exists {
+			groups = append(groups, entry.group)
+		}
+
+		groupEntries[entry.group] = append(groupEntries[entry.group], entry)
+	}
+
+	slice.Sort(groups, func(i, j int) bool {
+		if groups[i] == clOtherGroup || groups[j] == clOtherGroup {
+			return groups[j] == clOtherGroup && groups[i] != clOtherGroup
+		}
+
+		return groups[i] < groups[j]
+	})
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, "# Changes from %v to %v\n", fromRevision, toRevision)
+
+	if len(entries) == 0 {
+		buffer.WriteString("\nNo changes\n")
+	}
+
+	for _, group := range groups {
+		fmt.Fprintf(&buffer, "\n## %v\n\n", group)
+
+		for _, entry := range groupEntries[group] {
+			fmt.Fprintf(&buffer, "- %v (%v)\n", entry.summary, entry.shortID)
+		}
+	}
+
+	return buffer.String()
+}
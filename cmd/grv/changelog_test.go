@@ -0,0 +1,87 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestChangelogTypeGroup(t *testing.T) {
+	var typeGroupTests = []struct {
+		summary              string
+		expectedGroup        string
+		expectedEntrySummary string
+	}{
+		{
+			summary:              "feat: add changelog command",
+			expectedGroup:        "feat",
+			expectedEntrySummary: "add changelog command",
+		},
+		{
+			summary:              "Fix(diff)!: handle renamed files",
+			expectedGroup:        "fix",
+			expectedEntrySummary: "**BREAKING:** **diff:** handle renamed files",
+		},
+		{
+			summary:              "Update README",
+			expectedGroup:        clOtherGroup,
+			expectedEntrySummary: "Update README",
+		},
+	}
+
+	for _, typeGroupTest := range typeGroupTests {
+		group, entrySummary := changelogTypeGroup(typeGroupTest.summary)
+
+		if group != typeGroupTest.expectedGroup || entrySummary != typeGroupTest.expectedEntrySummary {
+			t.Errorf("Changelog entry does not match expected value for summary %q. Expected: %v %q, Actual: %v %q",
+				typeGroupTest.summary, typeGroupTest.expectedGroup, typeGroupTest.expectedEntrySummary, group, entrySummary)
+		}
+	}
+}
+
+func TestChangelogPathGroup(t *testing.T) {
+	var pathGroupTests = []struct {
+		paths         []string
+		expectedGroup string
+	}{
+		{
+			paths:         []string{"cmd/grv/view.go", "cmd/grv/view_test.go", "doc/documentation.md"},
+			expectedGroup: "cmd",
+		},
+		{
+			paths:         []string{"README.md", "Makefile"},
+			expectedGroup: clRootGroup,
+		},
+		{
+			paths:         []string{"doc/documentation.md", "cmd/grv/view.go"},
+			expectedGroup: "cmd",
+		},
+		{
+			paths:         nil,
+			expectedGroup: clOtherGroup,
+		},
+	}
+
+	for _, pathGroupTest := range pathGroupTests {
+		if group := changelogPathGroup(pathGroupTest.paths); group != pathGroupTest.expectedGroup {
+			t.Errorf("Changelog group does not match expected value for paths %v. Expected: %v, Actual: %v",
+				pathGroupTest.paths, pathGroupTest.expectedGroup, group)
+		}
+	}
+}
+
+func TestFormatChangelogListsOtherGroupLast(t *testing.T) {
+	entries := []changelogEntry{
+		{group: clOtherGroup, summary: "Update README", shortID: "aaaaaaa"},
+		{group: "fix", summary: "handle renamed files", shortID: "bbbbbbb"},
+		{group: "feat", summary: "add changelog command", shortID: "ccccccc"},
+		{group: "fix", summary: "correct typo", shortID: "ddddddd"},
+	}
+
+	expectedChangelog := "# Changes from v1.0 to v1.1\n" +
+		"\n## feat\n\n- add changelog command (ccccccc)\n" +
+		"\n## fix\n\n- handle renamed files (bbbbbbb)\n- correct typo (ddddddd)\n" +
+		"\n## other\n\n- Update README (aaaaaaa)\n"
+
+	if changelog := formatChangelog("v1.0", "v1.1", entries); changelog != expectedChangelog {
+		t.Errorf("Changelog does not match expected value. Expected: %q, Actual: %q", expectedChangelog, changelog)
+	}
+}
@@ -0,0 +1,163 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+const (
+	clRemoteName            = "origin"
+	clGitSuffix             = ".git"
+	clDefaultCloneDirectory = "repository"
+	clProgressRefreshRate   = 100 * time.Millisecond
+	clProgressBarWidth      = 30
+)
+
+// cloneDirectory determines the directory a repository is cloned into when none is specified
+// in the same way as git: the last component of the url without any .git suffix
+func cloneDirectory(url string) string {
+	url = strings.TrimRight(url, "/")
+	url = strings.TrimSuffix(url, "/"+clGitSuffix)
+
+	if index := strings.LastIndexAny(url, "/:"); index != -1 {
+		url = url[index+1:]
+	}
+
+	url = strings.TrimSuffix(url, clGitSuffix)
+
+	if url == "" {
+		return clDefaultCloneDirectory
+	}
+
+	return url
+}
+
+// CloneRepository clones the repository at the url into the provided directory
+// The proxy and CA certificates are determined from the global git configuration
+func CloneRepository(ctx context.Context, url, directory string, remoteOptions RemoteOptions) (err error) {
+	log.Infof("Cloning %v into %v", url, directory)
+
+	config, configErr := git.OpenDefault()
+	if configErr != nil {
+		log.Debugf("Unable to open git configuration: %v", configErr)
+		config = nil
+	} else {
+		defer config.Free()
+	}
+
+	fetchOptions, session, err := newFetchOptions(ctx, config, clRemoteName, url, remoteOptions)
+	if err != nil {
+		return
+	}
+
+	repo, err := git.Clone(url, directory, &git.CloneOptions{
+		FetchOptions: fetchOptions,
+		CheckoutOpts: &git.CheckoutOpts{
+			Strategy: git.CheckoutSafe,
+		},
+	})
+	session.complete(err)
+
+	if err != nil {
+		return
+	}
+
+	repo.Free()
+
+	return
+}
+
+// CloneFromCommandLine clones a repository before the UI has started, writing progress to stderr
+// The path of the cloned repository is returned
+func CloneFromCommandLine(url, directory string) (repoPath string, err error) {
+	if directory == "" {
+		directory = cloneDirectory(url)
+	}
+
+	var lastUpdate time.Time
+	var lock sync.Mutex
+
+	err = CloneRepository(context.Background(), url, directory, RemoteOptions{
+		onTransferProgress: func(progress TransferProgress) {
+			lock.Lock()
+			defer lock.Unlock()
+
+			if time.Since(lastUpdate) >= clProgressRefreshRate {
+				fmt.Fprintf(os.Stderr, "\rCloning into %v: %v", directory, TransferProgressBar(progress, clProgressBarWidth))
+				lastUpdate = time.Now()
+			}
+		},
+	})
+
+	if !lastUpdate.IsZero() {
+		fmt.Fprintln(os.Stderr)
+	}
+
+	if err != nil {
+		return
+	}
+
+	return filepath.Abs(directory)
+}
+
+// clone clones a repository in the background while displaying progress in the status bar
+// Once the clone is complete GRV is restarted with the cloned repository
+func (grv *GRV) clone(action Action) (err error) {
+	if len(action.Args) < 2 {
+		return fmt.Errorf("Expected url and directory arguments")
+	}
+
+	url, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected url argument to have type string")
+	}
+
+	directory, ok := action.Args[1].(string)
+	if !ok {
+		return fmt.Errorf("Expected directory argument to have type string")
+	}
+
+	if directory == "" {
+		directory = cloneDirectory(url)
+	}
+
+	channels := grv.channels.Channels()
+	channels.ReportStatus("Cloning %v into %v", url, directory)
+
+	ctx, done := grv.view.canceller.Start("clone")
+
+	go func() {
+		defer done()
+
+		err := grv.repoData.Clone(ctx, url, directory, RemoteOptions{
+			proxyURL:  grv.config.GetString(CfHTTPProxy),
+			sslCAInfo: grv.config.GetString(CfSSLCAInfo),
+		})
+
+		if ctx.Err() != nil {
+			channels.ReportStatus("Clone of %v was cancelled", url)
+			return
+		} else if err != nil {
+			channels.ReportError(fmt.Errorf("Failed to clone %v: %v", url, err))
+			return
+		}
+
+		repoPath, err := filepath.Abs(directory)
+		if err != nil {
+			channels.ReportError(err)
+			return
+		}
+
+		grv.Restart(repoPath)
+	}()
+
+	return
+}
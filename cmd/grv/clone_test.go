@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCloneDirectoryIsDeterminedFromURL(t *testing.T) {
+	var cloneDirectoryTests = []struct {
+		url               string
+		expectedDirectory string
+	}{
+		{url: "https://github.com/rgburke/grv.git", expectedDirectory: "grv"},
+		{url: "https://github.com/rgburke/grv", expectedDirectory: "grv"},
+		{url: "https://github.com/rgburke/grv/", expectedDirectory: "grv"},
+		{url: "git@github.com:rgburke/grv.git", expectedDirectory: "grv"},
+		{url: "host:grv.git", expectedDirectory: "grv"},
+		{url: "/srv/git/grv/.git", expectedDirectory: "grv"},
+		{url: "../grv", expectedDirectory: "grv"},
+		{url: "/", expectedDirectory: "repository"},
+	}
+
+	for _, cloneDirectoryTest := range cloneDirectoryTests {
+		if directory := cloneDirectory(cloneDirectoryTest.url); directory != cloneDirectoryTest.expectedDirectory {
+			t.Errorf("Directory does not match expected value for url %v. Expected: %v, Actual: %v",
+				cloneDirectoryTest.url, cloneDirectoryTest.expectedDirectory, directory)
+		}
+	}
+}
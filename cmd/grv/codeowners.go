@@ -0,0 +1,241 @@
+package main
+
+import (
+	"bufio"
+	"path"
+	"sort"
+	"strings"
+)
+
+// codeOwnersPaths are the locations a CODEOWNERS file is read from, in order of precedence
+var codeOwnersPaths = []string{".github/CODEOWNERS", ".gitlab/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// codeOwnersRule assigns owners to the files matching a pattern
+type codeOwnersRule struct {
+	pattern string
+	owners  []string
+}
+
+// codeOwnersSection is a group of rules. GitHub CODEOWNERS files contain a single section,
+// GitLab files can contain named sections which each have their own default owners
+type codeOwnersSection struct {
+	name          string
+	defaultOwners []string
+	rules         []codeOwnersRule
+}
+
+// CodeOwners contains the parsed rules of a CODEOWNERS file
+type CodeOwners struct {
+	sections []*codeOwnersSection
+}
+
+// ParseCodeOwners parses the contents of a CODEOWNERS file in either GitHub or GitLab format
+func ParseCodeOwners(contents string) *CodeOwners {
+	section := &codeOwnersSection{}
+	codeOwners := &CodeOwners{
+		sections: []*codeOwnersSection{section},
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(contents))
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if name, defaultOwners, ok := parseCodeOwnersSectionHeader(line); ok {
+			section = &codeOwnersSection{
+				name:          name,
+				defaultOwners: defaultOwners,
+			}
+
+			codeOwners.sections = append(codeOwners.sections, section)
+			continue
+		}
+
+		fields := strings.Fields(line)
+		pattern := strings.TrimPrefix(fields[0], "\\")
+		owners := fields[1:]
+
+		for index, owner := range owners {
+			if strings.HasPrefix(owner, "#") {
+				owners = owners[:index]
+				break
+			}
+		}
+
+		section.rules = append(section.rules, codeOwnersRule{
+			pattern: pattern,
+			owners:  owners,
+		})
+	}
+
+	return codeOwners
+}
+
+// parseCodeOwnersSectionHeader parses GitLab section headers of the form
+// [Section name][approvals] @default @owners, optionally prefixed with ^ to mark the section optional
+func parseCodeOwnersSectionHeader(line string) (name string, defaultOwners []string, ok bool) {
+	line = strings.TrimPrefix(line, "^")
+	if !strings.HasPrefix(line, "[") {
+		return
+	}
+
+	endIndex := strings.Index(line, "]")
+	if endIndex == -1 {
+		return
+	}
+
+	name = line[1:endIndex]
+	rest := line[endIndex+1:]
+
+	if strings.HasPrefix(rest, "[") {
+		if approvalsEndIndex := strings.Index(rest, "]"); approvalsEndIndex != -1 {
+			rest = rest[approvalsEndIndex+1:]
+		}
+	}
+
+	return name, strings.Fields(rest), true
+}
+
+// Owners returns the owners of the file at the provided path
+// Within each section the last matching rule applies. The owners of all sections are combined
+func (codeOwners *CodeOwners) Owners(filePath string) (owners []string) {
+	if codeOwners == nil {
+		return
+	}
+
+	seen := make(map[string]bool)
+
+	for _, section := range codeOwners.sections {
+		for ruleIndex := len(section.rules) - 1; ruleIndex >= 0; ruleIndex-- {
+			rule := section.rules[ruleIndex]
+			if !matchesCodeOwnersPattern(rule.pattern, filePath) {
+				continue
+			}
+
+			ruleOwners := rule.owners
+			if len(ruleOwners) == 0 {
+				ruleOwners = section.defaultOwners
+			}
+
+			for _, owner := range ruleOwners {
+				if key := strings.ToLower(owner); !seen[key] {
+					seen[key] = true
+					owners = append(owners, owner)
+				}
+			}
+
+			break
+		}
+	}
+
+	return
+}
+
+// AllOwners returns every owner named in the file in sorted order
+func (codeOwners *CodeOwners) AllOwners() (owners []string) {
+	if codeOwners == nil {
+		return
+	}
+
+	seen := make(map[string]bool)
+	addOwners := func(ruleOwners []string) {
+		for _, owner := range ruleOwners {
+			if !seen[owner] {
+				seen[owner] = true
+				owners = append(owners, owner)
+			}
+		}
+	}
+
+	for _, section := range codeOwners.sections {
+		addOwners(section.defaultOwners)
+
+		for _, rule := range section.rules {
+			addOwners(rule.owners)
+		}
+	}
+
+	sort.Strings(owners)
+
+	return
+}
+
+// IsOwnedBy returns true if the owner is one of the owners of the file
+func (codeOwners *CodeOwners) IsOwnedBy(filePath, owner string) bool {
+	for _, fileOwner := range codeOwners.Owners(filePath) {
+		if strings.EqualFold(fileOwner, owner) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesCodeOwnersPattern returns true if the file path matches the pattern
+// Patterns follow the .gitignore rules used by CODEOWNERS:
+// A pattern without a slash (other than a trailing slash) matches at any depth.
+// A pattern matching a directory matches every file below it, except that a
+// trailing /* only matches the files directly in the directory.
+// A trailing slash restricts the pattern to directories and ** matches any number of directories
+func matchesCodeOwnersPattern(pattern, filePath string) bool {
+	directoryOnly := strings.HasSuffix(pattern, "/")
+	filesOnly := strings.HasSuffix(pattern, "/*")
+	pattern = strings.TrimSuffix(pattern, "/")
+
+	if pattern == "" {
+		return false
+	}
+
+	if strings.HasPrefix(pattern, "/") {
+		pattern = pattern[1:]
+	} else if !strings.Contains(pattern, "/") {
+		pattern = "**/" + pattern
+	}
+
+	patternSegments := strings.Split(pattern, "/")
+	pathSegments := strings.Split(strings.Trim(filePath, "/"), "/")
+
+	if !directoryOnly && matchPathSegments(patternSegments, pathSegments) {
+		return true
+	} else if filesOnly {
+		return false
+	}
+
+	for length := len(pathSegments) - 1; length > 0; length-- {
+		if matchPathSegments(patternSegments, pathSegments[:length]) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchPathSegments(patternSegments, pathSegments []string) bool {
+	if len(patternSegments) == 0 {
+		return len(pathSegments) == 0
+	}
+
+	if patternSegments[0] == "**" {
+		for skip := 0; skip <= len(pathSegments); skip++ {
+			if matchPathSegments(patternSegments[1:], pathSegments[skip:]) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if len(pathSegments) == 0 {
+		return false
+	}
+
+	if matched, _ := path.Match(patternSegments[0], pathSegments[0]); !matched {
+		return false
+	}
+
+	return matchPathSegments(patternSegments[1:], pathSegments[1:])
+}
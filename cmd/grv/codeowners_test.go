@@ -0,0 +1,100 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMatchesCodeOwnersPattern(t *testing.T) {
+	var patternTests = []struct {
+		pattern  string
+		filePath string
+		expected bool
+	}{
+		{pattern: "*", filePath: "cmd/grv/main.go", expected: true},
+		{pattern: "*.js", filePath: "web/app.js", expected: true},
+		{pattern: "*.js", filePath: "web/app.go", expected: false},
+		{pattern: "/build/logs/", filePath: "build/logs/a/b.log", expected: true},
+		{pattern: "/build/logs/", filePath: "src/build/logs/b.log", expected: false},
+		{pattern: "docs/*", filePath: "docs/intro.md", expected: true},
+		{pattern: "docs/*", filePath: "docs/api/intro.md", expected: false},
+		{pattern: "apps/", filePath: "src/apps/main.go", expected: true},
+		{pattern: "/apps/github", filePath: "apps/github/main.go", expected: true},
+		{pattern: "**/logs", filePath: "deeply/nested/logs/a.log", expected: true},
+		{pattern: "/scripts/**/*.sh", filePath: "scripts/ci/build.sh", expected: true},
+		{pattern: "/scripts/**/*.sh", filePath: "scripts/build.sh", expected: true},
+		{pattern: "README.md", filePath: "doc/README.md", expected: true},
+	}
+
+	for _, patternTest := range patternTests {
+		if matched := matchesCodeOwnersPattern(patternTest.pattern, patternTest.filePath); matched != patternTest.expected {
+			t.Errorf("Match does not match expected value for pattern %v and path %v. Expected: %v, Actual: %v",
+				patternTest.pattern, patternTest.filePath, patternTest.expected, matched)
+		}
+	}
+}
+
+func TestCodeOwnersLastMatchingRuleApplies(t *testing.T) {
+	codeOwners := ParseCodeOwners(`
+# Default owners
+*               @org/core
+/docs/          @org/docs docs@example.com # inline comment
+/docs/api/
+*.go            @gopher
+`)
+
+	var ownerTests = []struct {
+		filePath       string
+		expectedOwners []string
+	}{
+		{filePath: "README.md", expectedOwners: []string{"@org/core"}},
+		{filePath: "docs/intro.md", expectedOwners: []string{"@org/docs", "docs@example.com"}},
+		{filePath: "docs/api/index.md", expectedOwners: nil},
+		{filePath: "cmd/grv/main.go", expectedOwners: []string{"@gopher"}},
+	}
+
+	for _, ownerTest := range ownerTests {
+		if owners := codeOwners.Owners(ownerTest.filePath); !reflect.DeepEqual(ownerTest.expectedOwners, owners) {
+			t.Errorf("Owners do not match expected value for path %v. Expected: %v, Actual: %v",
+				ownerTest.filePath, ownerTest.expectedOwners, owners)
+		}
+	}
+}
+
+func TestCodeOwnersGitLabSectionsAreCombined(t *testing.T) {
+	codeOwners := ParseCodeOwners(`
+*.rb @ruby
+
+[Documentation] @docs-team
+docs/
+README.md @writer
+
+^[Database][2] @dba
+*.sql
+`)
+
+	var ownerTests = []struct {
+		filePath       string
+		expectedOwners []string
+	}{
+		{filePath: "docs/setup.rb", expectedOwners: []string{"@ruby", "@docs-team"}},
+		{filePath: "README.md", expectedOwners: []string{"@writer"}},
+		{filePath: "db/schema.sql", expectedOwners: []string{"@dba"}},
+	}
+
+	for _, ownerTest := range ownerTests {
+		if owners := codeOwners.Owners(ownerTest.filePath); !reflect.DeepEqual(ownerTest.expectedOwners, owners) {
+			t.Errorf("Owners do not match expected value for path %v. Expected: %v, Actual: %v",
+				ownerTest.filePath, ownerTest.expectedOwners, owners)
+		}
+	}
+
+	expectedAllOwners := []string{"@dba", "@docs-team", "@ruby", "@writer"}
+	if allOwners := codeOwners.AllOwners(); !reflect.DeepEqual(expectedAllOwners, allOwners) {
+		t.Errorf("All owners do not match expected value. Expected: %v, Actual: %v", expectedAllOwners, allOwners)
+	}
+
+	if !codeOwners.IsOwnedBy("db/schema.sql", "@DBA") {
+		t.Errorf("Expected db/schema.sql to be owned by @DBA")
+	}
+}
@@ -2,6 +2,12 @@ package main
 
 import (
 	"strings"
+
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+const (
+	cfTrailerFieldPrefix = "trailer."
 )
 
 // CreateCommitFilter constructs a commit filter from the provided query
@@ -15,6 +21,59 @@ func CreateCommitFilter(query string) (commitFilter *CommitFilter, errors []erro
 	return
 }
 
+// CreateCommitPathFilter constructs a commit filter which matches commits
+// that modified content at or beneath the provided path
+func CreateCommitPathFilter(path string) *CommitFilter {
+	path = strings.Trim(path, "/")
+
+	return NewCommitFilter(func(inputValue interface{}) bool {
+		commit := inputValue.(*Commit)
+		return commitModifiesPath(commit.commit, path)
+	})
+}
+
+// commitModifiesPath returns true if the tree entry for the provided path
+// differs between the commit and each of its parents
+func commitModifiesPath(rawCommit *git.Commit, path string) bool {
+	entryID := treeEntryID(rawCommit, path)
+	parentCount := rawCommit.ParentCount()
+
+	if parentCount == 0 {
+		return entryID != nil
+	}
+
+	for parentIndex := uint(0); parentIndex < parentCount; parentIndex++ {
+		parentEntryID := treeEntryID(rawCommit.Parent(parentIndex), path)
+
+		if entryID == nil && parentEntryID == nil {
+			return false
+		} else if entryID != nil && parentEntryID != nil && entryID.Equal(parentEntryID) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func treeEntryID(rawCommit *git.Commit, path string) *git.Oid {
+	if rawCommit == nil {
+		return nil
+	}
+
+	tree, err := rawCommit.Tree()
+	if err != nil {
+		return nil
+	}
+	defer tree.Free()
+
+	entry, err := tree.EntryByPath(path)
+	if err != nil {
+		return nil
+	}
+
+	return entry.Id
+}
+
 // CommitFilter is a wrapper around the raw commit filter
 // Used for filter argument type safety
 type CommitFilter struct {
@@ -37,7 +96,12 @@ func (commitFilter *CommitFilter) MatchesFilter(commit *Commit) bool {
 type CommitFieldDescriptor struct{}
 
 // FieldType returns the type of the provided field (if it exists)
+// Trailer fields (e.g. trailer.reviewed-by) exist for any trailer key
 func (commitFieldDescriptor *CommitFieldDescriptor) FieldType(fieldName string) (fieldType FieldType, fieldExists bool) {
+	if trailerKey(fieldName) != "" {
+		return FtString, true
+	}
+
 	if commitField, ok := commitFields[strings.ToLower(fieldName)]; ok {
 		fieldType = commitField.fieldType
 		fieldExists = true
@@ -49,11 +113,26 @@ func (commitFieldDescriptor *CommitFieldDescriptor) FieldType(fieldName string)
 // FieldValue extracts a field value from a commit object
 func (commitFieldDescriptor *CommitFieldDescriptor) FieldValue(inputValue interface{}, fieldName string) interface{} {
 	commit := inputValue.(*Commit)
+
+	if key := trailerKey(fieldName); key != "" {
+		return TrailerValues(ParseCommitTrailers(commit.commit.Message()), key)
+	}
+
 	commitField := commitFields[strings.ToLower(fieldName)]
 
 	return commitField.value(commit)
 }
 
+// trailerKey returns the trailer key referenced by a trailer field name
+// An empty string is returned if the field name does not reference a trailer
+func trailerKey(fieldName string) string {
+	if len(fieldName) > len(cfTrailerFieldPrefix) && strings.HasPrefix(strings.ToLower(fieldName), cfTrailerFieldPrefix) {
+		return fieldName[len(cfTrailerFieldPrefix):]
+	}
+
+	return ""
+}
+
 // CommitFieldValue accepts a commit and returns a field value of that commit
 type CommitFieldValue func(*Commit) interface{}
 
@@ -112,6 +191,20 @@ var commitFields = map[string]CommitField{
 			return commit.commit.Summary()
 		},
 	},
+	"cctype": {
+		fieldType: FtString,
+		value: func(commit *Commit) interface{} {
+			conventionalCommit, _ := ParseConventionalCommit(commit.commit.Summary())
+			return conventionalCommit.commitType
+		},
+	},
+	"ccscope": {
+		fieldType: FtString,
+		value: func(commit *Commit) interface{} {
+			conventionalCommit, _ := ParseConventionalCommit(commit.commit.Summary())
+			return conventionalCommit.scope
+		},
+	},
 	"parentcount": {
 		fieldType: FtNumber,
 		value: func(commit *Commit) interface{} {
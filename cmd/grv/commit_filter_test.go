@@ -29,6 +29,18 @@ func TestCommitFieldExistence(t *testing.T) {
 			fieldName:      "comittername",
 			expectedExists: false,
 		},
+		{
+			fieldName:      "cctype",
+			expectedExists: true,
+		},
+		{
+			fieldName:      "trailer.reviewed-by",
+			expectedExists: true,
+		},
+		{
+			fieldName:      "trailer.",
+			expectedExists: false,
+		},
 	}
 
 	commitFieldDescriptor := &CommitFieldDescriptor{}
@@ -0,0 +1,147 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// The set of commit message lint rules
+const (
+	LintSubjectLength = "subjectlength"
+	LintImperative    = "imperative"
+	LintBlankLine     = "blankline"
+)
+
+var commitLintRules = []string{LintSubjectLength, LintImperative, LintBlankLine}
+
+// nonImperativeSuffixExceptions are word endings which look like a non-imperative suffix but are not
+var nonImperativeSuffixExceptions = []string{"ss", "us", "is", "eed"}
+
+// CommitLintViolation describes a line of a commit message which breaks a lint rule
+// lineIndex is the zero based index of the offending line in the message
+type CommitLintViolation struct {
+	rule      string
+	lineIndex uint
+	message   string
+}
+
+// CommitLinter checks commit messages against the enabled rules
+type CommitLinter struct {
+	rules            []string
+	subjectMaxLength int
+}
+
+// NewCommitLinter creates a linter from the configured rules and maximum subject length
+func NewCommitLinter(config Config) *CommitLinter {
+	rules, _ := parseCommitLintRules(config.GetString(CfCommitLint))
+
+	return &CommitLinter{
+		rules:            rules,
+		subjectMaxLength: config.GetInt(CfCommitLintSubjectLength),
+	}
+}
+
+// parseCommitLintRules parses a space separated list of lint rule names
+func parseCommitLintRules(value string) (rules []string, err error) {
+	for _, rule := range strings.Fields(value) {
+		if !isCommitLintRule(rule) {
+			return nil, fmt.Errorf("Invalid commit lint rule %v. Valid rules are: %v", rule, strings.Join(commitLintRules, ", "))
+		}
+
+		rules = append(rules, rule)
+	}
+
+	return
+}
+
+func isCommitLintRule(rule string) bool {
+	for _, commitLintRule := range commitLintRules {
+		if rule == commitLintRule {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Enabled returns true if at least one lint rule is enabled
+func (commitLinter *CommitLinter) Enabled() bool {
+	return len(commitLinter.rules) > 0
+}
+
+// Lint returns the violations of the enabled rules in the provided commit message
+func (commitLinter *CommitLinter) Lint(message string) (violations []CommitLintViolation) {
+	lines := strings.Split(strings.TrimRight(message, "\n"), "\n")
+	subject := lines[0]
+
+	for _, rule := range commitLinter.rules {
+		switch rule {
+		case LintSubjectLength:
+			if length := utf8.RuneCountInString(subject); length > commitLinter.subjectMaxLength {
+				violations = append(violations, CommitLintViolation{
+					rule:    rule,
+					message: fmt.Sprintf("Subject is %v characters long (maximum %v)", length, commitLinter.subjectMaxLength),
+				})
+			}
+		case LintImperative:
+			if word, isImperative := subjectIsImperative(subject); !isImperative {
+				violations = append(violations, CommitLintViolation{
+					rule:    rule,
+					message: fmt.Sprintf("Subject should use the imperative mood (\"%v\")", word),
+				})
+			}
+		case LintBlankLine:
+			if len(lines) > 1 && strings.TrimSpace(lines[1]) != "" {
+				violations = append(violations, CommitLintViolation{
+					rule:      rule,
+					lineIndex: 1,
+					message:   "Subject should be followed by a blank line",
+				})
+			}
+		}
+	}
+
+	return
+}
+
+// subjectIsImperative uses the first word of the subject to guess whether it is written in the imperative mood.
+// Words ending in ed, ing or s (e.g. Added, Adding, Adds) are assumed not to be imperative.
+// The type and scope of Conventional Commit subjects are ignored
+func subjectIsImperative(subject string) (word string, isImperative bool) {
+	if conventionalCommit, isConventionalCommit := ParseConventionalCommit(subject); isConventionalCommit {
+		subject = conventionalCommit.description
+	}
+
+	fields := strings.Fields(subject)
+	if len(fields) == 0 {
+		return "", true
+	}
+
+	word = fields[0]
+	lowerWord := strings.ToLower(word)
+
+	for _, exception := range nonImperativeSuffixExceptions {
+		if strings.HasSuffix(lowerWord, exception) {
+			return word, true
+		}
+	}
+
+	for _, suffix := range []string{"ed", "ing", "s"} {
+		if len(lowerWord) > len(suffix)+1 && strings.HasSuffix(lowerWord, suffix) {
+			return word, false
+		}
+	}
+
+	return word, true
+}
+
+// commitLintMessage generates a description of the violations for display in a dialog
+func commitLintMessage(violations []CommitLintViolation) string {
+	var lines []string
+	for _, violation := range violations {
+		lines = append(lines, fmt.Sprintf("Line %v: %v", violation.lineIndex+1, violation.message))
+	}
+
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCommitLintRules(t *testing.T) {
+	rules, err := parseCommitLintRules(" subjectlength  blankline ")
+	if err != nil {
+		t.Fatalf("Unexpected error when parsing commit lint rules: %v", err)
+	}
+
+	expectedRules := []string{LintSubjectLength, LintBlankLine}
+
+	if !reflect.DeepEqual(rules, expectedRules) {
+		t.Errorf("Commit lint rules do not match expected value. Expected: %v, Actual: %v", expectedRules, rules)
+	}
+}
+
+func TestParseCommitLintRulesRejectsUnknownRules(t *testing.T) {
+	if _, err := parseCommitLintRules("subjectlength spelling"); err == nil {
+		t.Errorf("Expected error when parsing unknown commit lint rule")
+	}
+}
+
+func TestCommitLintViolations(t *testing.T) {
+	commitLinter := &CommitLinter{
+		rules:            commitLintRules,
+		subjectMaxLength: 20,
+	}
+
+	var lintTests = []struct {
+		message       string
+		expectedRules []string
+	}{
+		{
+			message: "Fix scrolling\n\nDetails\n",
+		},
+		{
+			message:       "Fix scrolling in the commit view\n",
+			expectedRules: []string{LintSubjectLength},
+		},
+		{
+			message:       "Fixed scrolling\n",
+			expectedRules: []string{LintImperative},
+		},
+		{
+			message:       "fix(ui): adding tabs",
+			expectedRules: []string{LintImperative},
+		},
+		{
+			message: "Address review\n",
+		},
+		{
+			message:       "Fix scrolling\nDetails\n",
+			expectedRules: []string{LintBlankLine},
+		},
+		{
+			message:       "Updates scrolling in all views\nDetails",
+			expectedRules: []string{LintSubjectLength, LintImperative, LintBlankLine},
+		},
+	}
+
+	for _, lintTest := range lintTests {
+		var rules []string
+		for _, violation := range commitLinter.Lint(lintTest.message) {
+			rules = append(rules, violation.rule)
+		}
+
+		if !reflect.DeepEqual(rules, lintTest.expectedRules) {
+			t.Errorf("Violated rules do not match expected value for message %q. Expected: %v, Actual: %v", lintTest.message, lintTest.expectedRules, rules)
+		}
+	}
+}
+
+func TestCommitLintOnlyChecksEnabledRules(t *testing.T) {
+	commitLinter := &CommitLinter{
+		rules:            []string{LintBlankLine},
+		subjectMaxLength: 10,
+	}
+
+	if violations := commitLinter.Lint("Updated scrolling in all views\n\nDetails"); len(violations) != 0 {
+		t.Errorf("Expected no violations but found: %v", violations)
+	}
+}
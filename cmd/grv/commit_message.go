@@ -0,0 +1,181 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+const (
+	cmCommentPrefix = "#"
+)
+
+var conventionalCommitRegex = regexp.MustCompile(`^([[:alpha:]]+)(\(([^()]*)\))?(!)?: (.*)$`)
+
+// ConventionalCommit contains the components of a Conventional Commit summary
+// For example: feat(parser)!: support arrays
+type ConventionalCommit struct {
+	commitType  string
+	scope       string
+	breaking    bool
+	description string
+}
+
+// ParseConventionalCommit parses a commit summary in the Conventional Commits format.
+// False is returned if the summary does not follow the format
+func ParseConventionalCommit(summary string) (conventionalCommit ConventionalCommit, isConventionalCommit bool) {
+	matches := conventionalCommitRegex.FindStringSubmatch(summary)
+	if matches == nil {
+		return
+	}
+
+	return ConventionalCommit{
+		commitType:  matches[1],
+		scope:       matches[3],
+		breaking:    matches[4] != "",
+		description: matches[5],
+	}, true
+}
+
+// The set of prefixes which mark a commit to be squashed into an earlier commit by an autosquash rebase
+const (
+	FixupPrefix  = "fixup! "
+	SquashPrefix = "squash! "
+	AmendPrefix  = "amend! "
+)
+
+var autosquashPrefixes = []string{FixupPrefix, SquashPrefix, AmendPrefix}
+
+// AutosquashTarget returns the summary of the commit targeted by a fixup!, squash! or amend! summary.
+// Repeated prefixes (e.g. fixup! fixup! Add feature) are removed
+func AutosquashTarget(summary string) (targetSummary string, isAutosquash bool) {
+	targetSummary = summary
+
+	for prefixFound := true; prefixFound; {
+		prefixFound = false
+
+		for _, prefix := range autosquashPrefixes {
+			if strings.HasPrefix(targetSummary, prefix) {
+				targetSummary = strings.TrimPrefix(targetSummary, prefix)
+				prefixFound = true
+				isAutosquash = true
+			}
+		}
+	}
+
+	return
+}
+
+// CommitTrailer is a key value pair from the trailer block at the end of a commit message
+// For example: Signed-off-by: Alice <alice@example.com>
+type CommitTrailer struct {
+	key   string
+	value string
+}
+
+// ParseCommitTrailers returns the trailers in the last paragraph of the commit message.
+// The last paragraph is only treated as a trailer block if it follows the summary and every
+// line in it is either a trailer or the indented continuation of the previous trailer
+func ParseCommitTrailers(message string) (trailers []CommitTrailer) {
+	paragraphs := strings.Split(strings.TrimSpace(strings.Replace(message, "\r\n", "\n", -1)), "\n\n")
+	if len(paragraphs) < 2 {
+		return
+	}
+
+	for _, line := range strings.Split(strings.Trim(paragraphs[len(paragraphs)-1], "\n"), "\n") {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(trailers) > 0 {
+			trailers[len(trailers)-1].value += " " + strings.TrimSpace(line)
+			continue
+		}
+
+		separatorIndex := strings.Index(line, ":")
+		if separatorIndex < 1 || !isTrailerKey(line[:separatorIndex]) {
+			return nil
+		}
+
+		trailers = append(trailers, CommitTrailer{
+			key:   line[:separatorIndex],
+			value: strings.TrimSpace(line[separatorIndex+1:]),
+		})
+	}
+
+	return
+}
+
+// isTrailerKey returns true if the key consists only of letters, numbers and hyphens
+func isTrailerKey(key string) bool {
+	for _, char := range key {
+		if !isLetterOrNumber(char) && char != '-' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TrailerValues returns the values of the trailers with the provided key
+// Keys are compared case insensitively
+func TrailerValues(trailers []CommitTrailer, key string) (values []string) {
+	for _, trailer := range trailers {
+		if strings.EqualFold(trailer.key, key) {
+			values = append(values, trailer.value)
+		}
+	}
+
+	return
+}
+
+// CleanupCommitMessage removes comment lines, trailing whitespace and
+// leading and trailing blank lines from a commit message
+func CleanupCommitMessage(message string) string {
+	var lines []string
+
+	for _, line := range strings.Split(message, "\n") {
+		if strings.HasPrefix(line, cmCommentPrefix) {
+			continue
+		}
+
+		line = strings.TrimRight(line, " \t\r")
+
+		if line == "" && (len(lines) == 0 || lines[len(lines)-1] == "") {
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	if len(lines) == 0 {
+		return ""
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// ApplyCommitTemplate appends the content of a commit template to the provided commit message.
+// The template is used as the message body as the summary is entered at the prompt
+func ApplyCommitTemplate(message, template string) string {
+	if CleanupCommitMessage(template) == "" {
+		return message
+	}
+
+	return strings.TrimRight(message, "\n") + "\n\n" + template
+}
+
+func readCommitTemplate(templatePath string) (template string, err error) {
+	if strings.HasPrefix(templatePath, "~/") {
+		templatePath = filepath.Join(os.Getenv("HOME"), templatePath[2:])
+	}
+
+	content, err := ioutil.ReadFile(templatePath)
+	if err != nil {
+		return
+	}
+
+	return string(content), nil
+}
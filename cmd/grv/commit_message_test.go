@@ -0,0 +1,205 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCleanupCommitMessage(t *testing.T) {
+	var cleanupTests = []struct {
+		message         string
+		expectedMessage string
+	}{
+		{
+			message:         "Fix bug",
+			expectedMessage: "Fix bug\n",
+		},
+		{
+			message:         "\n\nFix bug  \n\n\n\nDetails\t\n\n",
+			expectedMessage: "Fix bug\n\nDetails\n",
+		},
+		{
+			message:         "Fix bug\n# Please enter the commit message\n#\nDetails\n",
+			expectedMessage: "Fix bug\nDetails\n",
+		},
+		{
+			message:         "# Only comments\n\n#",
+			expectedMessage: "",
+		},
+	}
+
+	for _, cleanupTest := range cleanupTests {
+		message := CleanupCommitMessage(cleanupTest.message)
+
+		if message != cleanupTest.expectedMessage {
+			t.Errorf("Cleaned up message does not match expected value. Expected: %q, Actual: %q", cleanupTest.expectedMessage, message)
+		}
+	}
+}
+
+func TestApplyCommitTemplate(t *testing.T) {
+	var templateTests = []struct {
+		message         string
+		template        string
+		expectedMessage string
+	}{
+		{
+			message:         "Fix bug",
+			template:        "",
+			expectedMessage: "Fix bug",
+		},
+		{
+			message:         "Fix bug",
+			template:        "# Comment only template\n",
+			expectedMessage: "Fix bug",
+		},
+		{
+			message:         "Fix bug\n",
+			template:        "Issue:\n# Reference the issue above\n",
+			expectedMessage: "Fix bug\n\nIssue:\n# Reference the issue above\n",
+		},
+	}
+
+	for _, templateTest := range templateTests {
+		message := ApplyCommitTemplate(templateTest.message, templateTest.template)
+
+		if message != templateTest.expectedMessage {
+			t.Errorf("Message does not match expected value. Expected: %q, Actual: %q", templateTest.expectedMessage, message)
+		}
+	}
+}
+
+func TestParseCommitTrailers(t *testing.T) {
+	var trailerTests = []struct {
+		message          string
+		expectedTrailers []CommitTrailer
+	}{
+		{
+			message:          "Fix bug\n",
+			expectedTrailers: nil,
+		},
+		{
+			message:          "Signed-off-by: Alice <alice@example.com>\n",
+			expectedTrailers: nil,
+		},
+		{
+			message: "Fix bug\n\nDetails\n\nSigned-off-by: Alice <alice@example.com>\nReviewed-by: Bob <bob@example.com>\nFixes: #12\n",
+			expectedTrailers: []CommitTrailer{
+				{key: "Signed-off-by", value: "Alice <alice@example.com>"},
+				{key: "Reviewed-by", value: "Bob <bob@example.com>"},
+				{key: "Fixes", value: "#12"},
+			},
+		},
+		{
+			message: "Fix bug\n\nCo-authored-by: Carol\n  <carol@example.com>\n",
+			expectedTrailers: []CommitTrailer{
+				{key: "Co-authored-by", value: "Carol <carol@example.com>"},
+			},
+		},
+		{
+			message:          "Fix bug\n\nNote: this is prose\nnot a trailer block\n",
+			expectedTrailers: nil,
+		},
+	}
+
+	for _, trailerTest := range trailerTests {
+		trailers := ParseCommitTrailers(trailerTest.message)
+
+		if !reflect.DeepEqual(trailers, trailerTest.expectedTrailers) {
+			t.Errorf("Trailers do not match expected value for message %q. Expected: %v, Actual: %v", trailerTest.message, trailerTest.expectedTrailers, trailers)
+		}
+	}
+}
+
+func TestTrailerValuesAreCaseInsensitive(t *testing.T) {
+	trailers := []CommitTrailer{
+		{key: "Reviewed-by", value: "Alice"},
+		{key: "Signed-off-by", value: "Bob"},
+		{key: "reviewed-by", value: "Carol"},
+	}
+	expectedValues := []string{"Alice", "Carol"}
+
+	if values := TrailerValues(trailers, "REVIEWED-BY"); !reflect.DeepEqual(values, expectedValues) {
+		t.Errorf("Trailer values do not match expected value. Expected: %v, Actual: %v", expectedValues, values)
+	}
+}
+
+func TestParseConventionalCommit(t *testing.T) {
+	var conventionalCommitTests = []struct {
+		summary                      string
+		expectedConventionalCommit   ConventionalCommit
+		expectedIsConventionalCommit bool
+	}{
+		{
+			summary: "feat: add changelog command",
+			expectedConventionalCommit: ConventionalCommit{
+				commitType:  "feat",
+				description: "add changelog command",
+			},
+			expectedIsConventionalCommit: true,
+		},
+		{
+			summary: "fix(diff)!: handle renamed files",
+			expectedConventionalCommit: ConventionalCommit{
+				commitType:  "fix",
+				scope:       "diff",
+				breaking:    true,
+				description: "handle renamed files",
+			},
+			expectedIsConventionalCommit: true,
+		},
+		{
+			summary:                      "Fix bug in diff view",
+			expectedIsConventionalCommit: false,
+		},
+		{
+			summary:                      "feat:missing space",
+			expectedIsConventionalCommit: false,
+		},
+	}
+
+	for _, conventionalCommitTest := range conventionalCommitTests {
+		conventionalCommit, isConventionalCommit := ParseConventionalCommit(conventionalCommitTest.summary)
+
+		if isConventionalCommit != conventionalCommitTest.expectedIsConventionalCommit {
+			t.Errorf("Conventional commit detection does not match expected value for summary %q. Expected: %v, Actual: %v",
+				conventionalCommitTest.summary, conventionalCommitTest.expectedIsConventionalCommit, isConventionalCommit)
+		} else if conventionalCommit != conventionalCommitTest.expectedConventionalCommit {
+			t.Errorf("Conventional commit does not match expected value for summary %q. Expected: %v, Actual: %v",
+				conventionalCommitTest.summary, conventionalCommitTest.expectedConventionalCommit, conventionalCommit)
+		}
+	}
+}
+
+func TestAutosquashTarget(t *testing.T) {
+	var autosquashTests = []struct {
+		summary               string
+		expectedTargetSummary string
+		expectedIsAutosquash  bool
+	}{
+		{
+			summary:               "fixup! Add changelog command",
+			expectedTargetSummary: "Add changelog command",
+			expectedIsAutosquash:  true,
+		},
+		{
+			summary:               "squash! fixup! Add changelog command",
+			expectedTargetSummary: "Add changelog command",
+			expectedIsAutosquash:  true,
+		},
+		{
+			summary:               "Add changelog command",
+			expectedTargetSummary: "Add changelog command",
+			expectedIsAutosquash:  false,
+		},
+	}
+
+	for _, autosquashTest := range autosquashTests {
+		targetSummary, isAutosquash := AutosquashTarget(autosquashTest.summary)
+
+		if targetSummary != autosquashTest.expectedTargetSummary || isAutosquash != autosquashTest.expectedIsAutosquash {
+			t.Errorf("Autosquash target does not match expected value for summary %q. Expected: %q %v, Actual: %q %v",
+				autosquashTest.summary, autosquashTest.expectedTargetSummary, autosquashTest.expectedIsAutosquash, targetSummary, isAutosquash)
+		}
+	}
+}
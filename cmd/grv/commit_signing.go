@@ -0,0 +1,194 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+const (
+	csFormatOpenPGP   = "openpgp"
+	csFormatSSH       = "ssh"
+	csSSHKeyPrefix    = "key::"
+	csSignatureHeader = "gpgsig"
+)
+
+// CommitSigner signs commits using gpg or ssh-keygen as configured by
+// gpg.format, gpg.program, gpg.ssh.program and user.signingKey
+type CommitSigner struct {
+	format     string
+	program    string
+	signingKey string
+}
+
+// commitSigner returns a signer if commit.gpgSign is enabled
+func (repoDataLoader *RepoDataLoader) commitSigner(committer *git.Signature) (signer *CommitSigner, err error) {
+	config, err := repoDataLoader.repo.Config()
+	if err != nil {
+		return
+	}
+	defer config.Free()
+
+	if gpgSign, err := config.LookupBool("commit.gpgSign"); err != nil || !gpgSign {
+		return nil, nil
+	}
+
+	signer = &CommitSigner{
+		format: csFormatOpenPGP,
+	}
+
+	if format, err := config.LookupString("gpg.format"); err == nil && format != "" {
+		signer.format = format
+	}
+
+	switch signer.format {
+	case csFormatOpenPGP:
+		signer.program = lookupStringWithDefault(config, "gpg.program", "gpg")
+		signer.signingKey = lookupStringWithDefault(config, "user.signingKey", fmt.Sprintf("%v <%v>", committer.Name, committer.Email))
+	case csFormatSSH:
+		signer.program = lookupStringWithDefault(config, "gpg.ssh.program", "ssh-keygen")
+		signer.signingKey = lookupStringWithDefault(config, "user.signingKey", "")
+
+		if signer.signingKey == "" {
+			return nil, fmt.Errorf("user.signingKey must be set to sign commits with ssh")
+		}
+	default:
+		return nil, fmt.Errorf("Unsupported signing format: %v", signer.format)
+	}
+
+	return
+}
+
+func lookupStringWithDefault(config *git.Config, name, defaultValue string) string {
+	if value, err := config.LookupString(name); err == nil && value != "" {
+		return value
+	}
+
+	return defaultValue
+}
+
+// Sign generates a detached armored signature for the provided content.
+// Passphrases are expected to be provided by gpg-agent or ssh-agent
+func (signer *CommitSigner) Sign(content []byte) (signature string, err error) {
+	var args []string
+
+	switch signer.format {
+	case csFormatOpenPGP:
+		args = []string{"--status-fd=2", "-bsau", signer.signingKey}
+	case csFormatSSH:
+		var keyFile string
+		var cleanup func()
+
+		if keyFile, cleanup, err = sshSigningKeyFile(signer.signingKey); err != nil {
+			return
+		}
+		defer cleanup()
+
+		args = []string{"-Y", "sign", "-n", "git", "-f", keyFile}
+	}
+
+	log.Debugf("Signing commit with %v %v", signer.program, args)
+
+	var stdout, stderr bytes.Buffer
+	cmd := exec.Command(signer.program, args...)
+	cmd.Stdin = bytes.NewReader(content)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err = cmd.Run(); err != nil {
+		return "", fmt.Errorf("Failed to sign commit: %v: %v", err, strings.TrimSpace(stderr.String()))
+	}
+
+	if signature = stdout.String(); signature == "" {
+		return "", fmt.Errorf("Failed to sign commit: %v produced no signature", signer.program)
+	}
+
+	return
+}
+
+// sshSigningKeyFile returns a file path for the signing key, writing literal keys to a temporary file
+func sshSigningKeyFile(signingKey string) (keyFile string, cleanup func(), err error) {
+	cleanup = func() {}
+
+	if !strings.HasPrefix(signingKey, csSSHKeyPrefix) && !strings.HasPrefix(signingKey, "ssh-") {
+		return signingKey, cleanup, nil
+	}
+
+	file, err := ioutil.TempFile("", "grv-signing-key")
+	if err != nil {
+		return
+	}
+
+	cleanup = func() {
+		if err := os.Remove(file.Name()); err != nil {
+			log.Errorf("Unable to remove temporary signing key file: %v", err)
+		}
+	}
+
+	_, err = file.WriteString(strings.TrimPrefix(signingKey, csSSHKeyPrefix) + "\n")
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		cleanup()
+		return
+	}
+
+	return file.Name(), cleanup, nil
+}
+
+// CommitBuffer generates the raw content of a commit object
+func CommitBuffer(treeOid *git.Oid, parentOids []*git.Oid, author, committer *git.Signature, message string) []byte {
+	var buffer bytes.Buffer
+
+	buffer.WriteString(fmt.Sprintf("tree %v\n", treeOid))
+
+	for _, parentOid := range parentOids {
+		buffer.WriteString(fmt.Sprintf("parent %v\n", parentOid))
+	}
+
+	buffer.WriteString(fmt.Sprintf("author %v\n", formatCommitSignature(author)))
+	buffer.WriteString(fmt.Sprintf("committer %v\n", formatCommitSignature(committer)))
+	buffer.WriteString("\n")
+	buffer.WriteString(message)
+
+	return buffer.Bytes()
+}
+
+func formatCommitSignature(signature *git.Signature) string {
+	_, offset := signature.When.Zone()
+	sign := '+'
+
+	if offset < 0 {
+		sign = '-'
+		offset = -offset
+	}
+
+	return fmt.Sprintf("%v <%v> %v %c%02d%02d", signature.Name, signature.Email, signature.When.Unix(),
+		sign, offset/3600, (offset%3600)/60)
+}
+
+// AddCommitSignature inserts the signature header into the raw content of a commit object
+func AddCommitSignature(commitBuffer []byte, signature string) []byte {
+	headerEnd := bytes.Index(commitBuffer, []byte("\n\n"))
+	if headerEnd == -1 {
+		headerEnd = len(commitBuffer) - 1
+	}
+
+	signatureLines := strings.Split(strings.TrimRight(signature, "\n"), "\n")
+	signatureHeader := fmt.Sprintf("%v %v\n", csSignatureHeader, strings.Join(signatureLines, "\n "))
+
+	var buffer bytes.Buffer
+	buffer.Write(commitBuffer[:headerEnd+1])
+	buffer.WriteString(signatureHeader)
+	buffer.Write(commitBuffer[headerEnd+1:])
+
+	return buffer.Bytes()
+}
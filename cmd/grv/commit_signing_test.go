@@ -0,0 +1,58 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+func TestCommitBufferIsGenerated(t *testing.T) {
+	treeOid, _ := git.NewOid("4b825dc642cb6eb9a060e54bf8d69288fbee4904")
+	parentOid, _ := git.NewOid("3b18e512dba79e4c8300dd08aeb37f8e728b8dad")
+
+	signature := &git.Signature{
+		Name:  "Test User",
+		Email: "test@example.com",
+		When:  time.Unix(1500000000, 0).In(time.FixedZone("", -(5*3600 + 30*60))),
+	}
+
+	commitBuffer := CommitBuffer(treeOid, []*git.Oid{parentOid}, signature, signature, "Summary\n")
+
+	expectedCommitBuffer := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"parent 3b18e512dba79e4c8300dd08aeb37f8e728b8dad\n" +
+		"author Test User <test@example.com> 1500000000 -0530\n" +
+		"committer Test User <test@example.com> 1500000000 -0530\n" +
+		"\n" +
+		"Summary\n"
+
+	if string(commitBuffer) != expectedCommitBuffer {
+		t.Errorf("Commit buffer does not match expected value. Expected: %q, Actual: %q", expectedCommitBuffer, commitBuffer)
+	}
+}
+
+func TestCommitSignatureIsAddedAfterHeaders(t *testing.T) {
+	commitBuffer := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author Test User <test@example.com> 1500000000 +0000\n" +
+		"committer Test User <test@example.com> 1500000000 +0000\n" +
+		"\n" +
+		"Summary\n\nBody\n"
+
+	signature := "-----BEGIN PGP SIGNATURE-----\n\niQEzBAABCAAdFiEE\n-----END PGP SIGNATURE-----\n"
+
+	signedCommitBuffer := AddCommitSignature([]byte(commitBuffer), signature)
+
+	expectedSignedCommitBuffer := "tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\n" +
+		"author Test User <test@example.com> 1500000000 +0000\n" +
+		"committer Test User <test@example.com> 1500000000 +0000\n" +
+		"gpgsig -----BEGIN PGP SIGNATURE-----\n" +
+		" \n" +
+		" iQEzBAABCAAdFiEE\n" +
+		" -----END PGP SIGNATURE-----\n" +
+		"\n" +
+		"Summary\n\nBody\n"
+
+	if string(signedCommitBuffer) != expectedSignedCommitBuffer {
+		t.Errorf("Signed commit buffer does not match expected value. Expected: %q, Actual: %q", expectedSignedCommitBuffer, signedCommitBuffer)
+	}
+}
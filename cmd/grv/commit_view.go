@@ -1,8 +1,10 @@
 package main
 
 import (
-	"bytes"
+	"context"
 	"fmt"
+	"regexp"
+	"strings"
 	"sync"
 	"time"
 
@@ -10,23 +12,17 @@ import (
 )
 
 const (
-	cvLoadRefreshMs = 500
-	cvColumnNum     = 4
-	cvDateFormat    = "2006-01-02 15:04"
+	cvDateFormat                 = "2006-01-02 15:04"
+	cvDecoratedFilterDescription = "decorated"
+	cvLoadedCommitPollInterval   = 100 * time.Millisecond
 )
 
 type commitViewHandler func(*CommitView, Action) error
 
-type loadingCommitsRefreshTask struct {
-	refreshRate time.Duration
-	ticker      *time.Ticker
-	channels    *Channels
-	cancelCh    chan<- bool
-}
-
 type referenceViewData struct {
 	viewPos        ViewPos
 	tableFormatter *TableFormatter
+	filters        []string
 }
 
 // CommitListener is notified when a commit is selected
@@ -38,43 +34,129 @@ type CommitListener interface {
 type CommitView struct {
 	channels        *Channels
 	repoData        RepoData
+	config          Config
 	activeRef       *Oid
 	activeRefName   string
 	active          bool
 	refViewData     map[*Oid]*referenceViewData
+	columns         []commitViewColumn
 	handlers        map[ActionType]commitViewHandler
-	refreshTask     *loadingCommitsRefreshTask
+	progress        *ProgressTracker
 	commitListeners []CommitListener
 	viewDimension   ViewDimension
 	viewSearch      *ViewSearch
+	scrollBarMarks  scrollBarMarks
 	lock            sync.Mutex
 }
 
 // NewCommitView creates a new instance of the commit view
-func NewCommitView(repoData RepoData, channels *Channels) *CommitView {
+func NewCommitView(repoData RepoData, channels *Channels, config Config, canceller *OperationCanceller) *CommitView {
 	commitView := &CommitView{
 		channels:    channels,
 		repoData:    repoData,
+		config:      config,
 		refViewData: make(map[*Oid]*referenceViewData),
+		progress:    NewProgressTracker(channels),
 		handlers: map[ActionType]commitViewHandler{
-			ActionPrevLine:     moveUpCommit,
-			ActionNextLine:     moveDownCommit,
-			ActionPrevPage:     moveUpCommitPage,
-			ActionNextPage:     moveDownCommitPage,
-			ActionScrollRight:  scrollCommitViewRight,
-			ActionScrollLeft:   scrollCommitViewLeft,
-			ActionFirstLine:    moveToFirstCommit,
-			ActionLastLine:     moveToLastCommit,
-			ActionAddFilter:    addCommitFilter,
-			ActionRemoveFilter: removeCommitFilter,
+			ActionPrevLine:             moveUpCommit,
+			ActionNextLine:             moveDownCommit,
+			ActionPrevPage:             moveUpCommitPage,
+			ActionNextPage:             moveDownCommitPage,
+			ActionScrollHalfPageUp:     moveUpCommitHalfPage,
+			ActionScrollHalfPageDown:   moveDownCommitHalfPage,
+			ActionCenterView:           centerCommitView,
+			ActionScrollCursorTop:      scrollCommitViewCursorTop,
+			ActionScrollCursorBottom:   scrollCommitViewCursorBottom,
+			ActionScrollRight:          scrollCommitViewRight,
+			ActionScrollLeft:           scrollCommitViewLeft,
+			ActionFirstLine:            moveToFirstCommit,
+			ActionLastLine:             moveToLastCommit,
+			ActionAddFilter:            addCommitFilter,
+			ActionRemoveFilter:         removeCommitFilter,
+			ActionSimplifyByDecoration: simplifyByDecoration,
+			ActionFilterOwner:          filterCommitsByOwner,
+			ActionGotoCommit:           gotoCommit,
+			ActionShowContainingRefs:   showContainingRefs,
+			ActionYankDescribe:         yankDescribe,
+			ActionCreateFixupCommit:    createFixupCommit,
+			ActionCreateSquashCommit:   createSquashCommit,
+			ActionAutosquashRebase:     autosquashRebase,
+			ActionShowView:             showCommitViewTarget,
 		},
 	}
 
-	commitView.viewSearch = NewViewSearch(commitView, channels)
+	commitView.viewSearch = NewViewSearch(commitView, channels, canceller)
+	commitView.columns = commitView.configuredColumns()
+
+	config.AddOnChangeListener(CfCommitViewColumns, commitView)
+	config.AddOnChangeListener(CfCommitOrder, commitView)
 
 	return commitView
 }
 
+// configuredColumns returns the columns configured to be displayed
+func (commitView *CommitView) configuredColumns() []commitViewColumn {
+	columns, err := parseCommitViewColumns(commitView.config.GetString(CfCommitViewColumns))
+	if err != nil {
+		log.Errorf("Invalid commit view columns: %v", err)
+		columns, _ = parseCommitViewColumns(cfCommitViewColumnsDefaultValue)
+	}
+
+	return columns
+}
+
+// newTableFormatter creates a table formatter for the configured columns
+func (commitView *CommitView) newTableFormatter() *TableFormatter {
+	tableFormatter := NewTableFormatter(uint(len(commitView.columns)))
+
+	for colIndex, column := range commitView.columns {
+		tableFormatter.SetColWidthLimit(uint(colIndex), column.width)
+	}
+
+	return tableFormatter
+}
+
+// onConfigVariableChange updates the columns displayed or reloads commits in the new order
+func (commitView *CommitView) onConfigVariableChange(configVariable ConfigVariable) {
+	if configVariable == CfCommitOrder {
+		commitView.channels.ReportError(commitView.reloadCommits())
+		return
+	}
+
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	commitView.columns = commitView.configuredColumns()
+
+	for _, refViewData := range commitView.refViewData {
+		refViewData.tableFormatter = commitView.newTableFormatter()
+	}
+
+	commitView.channels.UpdateDisplay()
+}
+
+// reloadCommits discards all loaded commits and reloads the commits of the active ref
+func (commitView *CommitView) reloadCommits() (err error) {
+	commitView.lock.Lock()
+	refName, oid := commitView.activeRefName, commitView.activeRef
+	commitView.refViewData = make(map[*Oid]*referenceViewData)
+	commitView.lock.Unlock()
+
+	commitView.repoData.ClearCommitSets()
+
+	if oid == nil {
+		return
+	}
+
+	if err = commitView.OnRefSelect(refName, oid); err != nil {
+		return
+	}
+
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
 // Initialise currently does nothing
 func (commitView *CommitView) Initialise() (err error) {
 	log.Info("Initialising CommitView")
@@ -89,6 +171,10 @@ func (commitView *CommitView) Render(win RenderWindow) (err error) {
 
 	commitView.viewDimension = win.ViewDimensions()
 
+	if commitView.activeRef == nil {
+		return commitView.renderUnbornBranch(win)
+	}
+
 	refViewData, ok := commitView.refViewData[commitView.activeRef]
 	if !ok {
 		return fmt.Errorf("No RefViewData exists for oid %v", commitView.activeRef)
@@ -96,9 +182,13 @@ func (commitView *CommitView) Render(win RenderWindow) (err error) {
 
 	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
 
+	if commitSetState.loading {
+		commitView.progress.Update(PgLoadCommits, commitSetState.commitNum, 0)
+	}
+
 	rows := win.Rows() - 2
 	viewPos := refViewData.viewPos
-	viewPos.DetermineViewStartRow(rows, commitSetState.commitNum)
+	viewPos.DetermineViewStartRow(rows, commitSetState.commitNum, uint(commitView.config.GetInt(CfScrollOff)))
 
 	commitCh, err := commitView.repoData.Commits(commitView.activeRef, viewPos.ViewStartRowIndex(), rows)
 	if err != nil {
@@ -108,6 +198,8 @@ func (commitView *CommitView) Render(win RenderWindow) (err error) {
 	tableFormatter := refViewData.tableFormatter
 	tableFormatter.Resize(rows)
 	tableFormatter.Clear()
+	tableFormatter.SetLineNumberGutter(NewLineNumberGutter(commitView.config.GetBool(CfNumber),
+		commitView.config.GetBool(CfRelativeNumber), viewPos.ActiveRowIndex(), commitSetState.commitNum), viewPos.ViewStartRowIndex())
 
 	rowIndex := uint(0)
 
@@ -129,97 +221,178 @@ func (commitView *CommitView) Render(win RenderWindow) (err error) {
 		}
 	}
 
-	if err = win.SetTitle(CmpCommitviewTitle, "Commits for %v", commitView.activeRefName); err != nil {
+	if err = commitView.renderTitle(win); err != nil {
 		return
 	}
 
-	var selectedCommit uint
-	if commitSetState.commitNum == 0 {
-		selectedCommit = 0
+	if err = commitView.renderFooter(win); err != nil {
+		return
+	}
+
+	searchActive, searchPattern, lastSearchFoundMatch := commitView.viewSearch.SearchActive()
+	if searchActive && lastSearchFoundMatch {
+		if err = win.Highlight(searchPattern, CmpAllviewSearchMatch); err != nil {
+			return
+		}
 	} else {
-		selectedCommit = viewPos.ActiveRowIndex() + 1
+		searchPattern = ""
 	}
 
-	var footerText bytes.Buffer
+	if commitView.config.GetBool(CfScrollBar) {
+		marks := commitView.scrollBarMarks.Update(commitView.activeRef, searchPattern, commitSetState.commitNum, func(lineIndex uint, searchRegex *regexp.Regexp) ScrollBarMarkType {
+			if searchRegex != nil && searchRegex.MatchString(commitView.line(lineIndex)) {
+				return SbmSearchMatch
+			}
 
-	footerText.WriteString(fmt.Sprintf("Commit %v of %v", selectedCommit, commitSetState.commitNum))
+			return SbmNone
+		})
 
-	if commitSetState.filterState != nil {
-		filtersApplied := commitSetState.filterState.filtersApplied
-		filtersTextSuffix := ""
+		err = NewScrollBar(commitSetState.commitNum, viewPos.ViewStartRowIndex(), rows, marks).Render(win, commitView.config)
+	}
 
-		if filtersApplied > 1 {
-			filtersTextSuffix = "s"
+	return err
+}
+
+// renderUnbornBranch displays a placeholder for a branch which has no commits yet
+func (commitView *CommitView) renderUnbornBranch(win RenderWindow) (err error) {
+	if commitView.activeRefName != "" {
+		if err = win.SetRow(1, 0, CmpNone, " No commits yet on branch %v", commitView.activeRefName); err != nil {
+			return
 		}
 
-		footerText.WriteString(fmt.Sprintf(" (%v filter%v applied)", commitSetState.filterState.filtersApplied, filtersTextSuffix))
+		if err = win.SetRow(3, 0, CmpNone, " Commits created on this branch will be displayed here"); err != nil {
+			return
+		}
 	}
 
-	if err = win.SetFooter(CmpCommitviewFooter, "%v", footerText.String()); err != nil {
+	win.DrawBorder()
+
+	if err = commitView.renderTitle(win); err != nil {
 		return
 	}
 
-	if searchActive, searchPattern, lastSearchFoundMatch := commitView.viewSearch.SearchActive(); searchActive && lastSearchFoundMatch {
-		if err = win.Highlight(searchPattern, CmpAllviewSearchMatch); err != nil {
+	return commitView.renderFooter(win)
+}
+
+func (commitView *CommitView) renderTitle(win RenderWindow) error {
+	titleBuilder := NewTitleBuilder("Commits").Breadcrumb("%v", commitView.activeRefName)
+
+	if refViewData, ok := commitView.refViewData[commitView.activeRef]; ok && len(refViewData.filters) > 0 {
+		titleBuilder.Detail("filtered: %v", strings.Join(refViewData.filters, ", "))
+	}
+
+	return win.SetTitle(CmpCommitviewTitle, "%v", titleBuilder)
+}
+
+func (commitView *CommitView) renderFooter(win RenderWindow) error {
+	values := make(StatuslineValues)
+	commitView.statuslineValues(values)
+
+	return win.SetFooter(CmpCommitviewFooter, "%v", expandFooter(commitView.config.GetString(CfCommitViewFooter), values))
+}
+
+func (commitView *CommitView) renderCommit(tableFormatter *TableFormatter, rowIndex uint, commit *Commit) (err error) {
+	identity := commitIdentity(commit, commitView.config.GetString(CfCommitIdentity))
+	identityFormat := commitView.config.GetString(CfCommitIdentityFormat)
+	refsColumnDisplayed := commitView.columnDisplayed(cvcRefs)
+
+	for colIndex, column := range commitView.columns {
+		colIndex := uint(colIndex)
+
+		switch column.columnType {
+		case cvcShortHash:
+			err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewShortOid, "%v", commitView.repoData.ShortID(commit.oid))
+		case cvcHash:
+			err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewShortOid, "%v", commit.oid)
+		case cvcDate:
+			err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewDate, "%v", identity.When.Format(cvDateFormat))
+		case cvcAuthor:
+			err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewAuthor, "%v", formatIdentity(identity, identityFormat))
+		case cvcRefs:
+			err = commitView.renderCommitRefs(tableFormatter, rowIndex, colIndex, commit)
+		case cvcSummary:
+			// Refs are displayed before the summary unless they have their own column
+			if !refsColumnDisplayed {
+				if err = commitView.renderCommitRefs(tableFormatter, rowIndex, colIndex, commit); err != nil {
+					return
+				}
+			}
+
+			err = renderCommitSummary(tableFormatter, rowIndex, colIndex, commit.commit.Summary())
+		}
+
+		if err != nil {
 			return
 		}
 	}
 
-	return err
+	return
 }
 
-func (commitView *CommitView) renderCommit(tableFormatter *TableFormatter, rowIndex uint, commit *Commit) (err error) {
-	author := commit.commit.Author()
-	commitRefs := commitView.repoData.RefsForCommit(commit)
-	colIndex := uint(0)
+// renderCommitSummary renders the summary with the type, scope and breaking change
+// marker highlighted if the summary follows the Conventional Commits format
+func renderCommitSummary(tableFormatter *TableFormatter, rowIndex, colIndex uint, summary string) (err error) {
+	conventionalCommit, isConventionalCommit := ParseConventionalCommit(summary)
+	if !isConventionalCommit {
+		return tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewSummary, "%v", summary)
+	}
 
-	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewShortOid, "%v", commit.oid.ShortID()); err != nil {
+	if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewConventionalType, "%v", conventionalCommit.commitType); err != nil {
 		return
 	}
 
-	colIndex++
-	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewDate, "%v", author.When.Format(cvDateFormat)); err != nil {
-		return
+	if conventionalCommit.scope != "" {
+		if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewConventionalScope, "(%v)", conventionalCommit.scope); err != nil {
+			return
+		}
 	}
 
-	colIndex++
-	if err = tableFormatter.SetCellWithStyle(rowIndex, colIndex, CmpCommitviewAuthor, "%v", author.Name); err != nil {
-		return
+	if conventionalCommit.breaking {
+		if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewConventionalBreaking, "!"); err != nil {
+			return
+		}
 	}
 
-	colIndex++
-	if len(commitRefs.tags) > 0 {
-		for _, tag := range commitRefs.tags {
-			if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewTag, "<%v>", tag.name); err != nil {
-				return
-			}
+	return tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewSummary, ": %v", conventionalCommit.description)
+}
 
-			if err = tableFormatter.AppendToCell(rowIndex, colIndex, " "); err != nil {
-				return
-			}
+func (commitView *CommitView) columnDisplayed(columnType commitViewColumnType) bool {
+	for _, column := range commitView.columns {
+		if column.columnType == columnType {
+			return true
 		}
 	}
 
-	if len(commitRefs.branches) > 0 {
-		for _, branch := range commitRefs.branches {
-			if branch.isRemote {
-				if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewLocalBranch, "{%v}", branch.name); err != nil {
-					return
-				}
-			} else {
-				if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewRemoteBranch, "[%v]", branch.name); err != nil {
-					return
-				}
-			}
+	return false
+}
+
+func (commitView *CommitView) renderCommitRefs(tableFormatter *TableFormatter, rowIndex, colIndex uint, commit *Commit) (err error) {
+	commitRefs := commitView.repoData.RefsForCommit(commit)
+
+	for _, tag := range commitRefs.tags {
+		if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewTag, "<%v>", tag.name); err != nil {
+			return
+		}
 
-			if err = tableFormatter.AppendToCell(rowIndex, colIndex, " "); err != nil {
+		if err = tableFormatter.AppendToCell(rowIndex, colIndex, " "); err != nil {
+			return
+		}
+	}
+
+	for _, branch := range commitRefs.branches {
+		if branch.isRemote {
+			if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewLocalBranch, "{%v}", branch.name); err != nil {
+				return
+			}
+		} else {
+			if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewRemoteBranch, "[%v]", branch.name); err != nil {
 				return
 			}
 		}
-	}
 
-	if err = tableFormatter.AppendToCellWithStyle(rowIndex, colIndex, CmpCommitviewSummary, "%v", commit.commit.Summary()); err != nil {
-		return
+		if err = tableFormatter.AppendToCell(rowIndex, colIndex, " "); err != nil {
+			return
+		}
 	}
 
 	return
@@ -230,69 +403,70 @@ func (commitView *CommitView) RenderStatusBar(lineBuilder *LineBuilder) (err err
 	return
 }
 
-// RenderHelpBar shows key bindings custom to the commit view
-func (commitView *CommitView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
-	RenderKeyBindingHelp(commitView.ViewID(), lineBuilder, []ActionMessage{
-		{action: ActionFilterPrompt, message: "Add Filter"},
-		{action: ActionRemoveFilter, message: "Remove Filter"},
-	})
+// StatuslineValues adds placeholder values describing the selected commit
+func (commitView *CommitView) StatuslineValues(values StatuslineValues) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
 
-	return
+	commitView.statuslineValues(values)
 }
 
-func newLoadingCommitsRefreshTask(refreshRate time.Duration, channels *Channels) *loadingCommitsRefreshTask {
-	return &loadingCommitsRefreshTask{
-		refreshRate: refreshRate,
-		channels:    channels,
+func (commitView *CommitView) statuslineValues(values StatuslineValues) {
+	values["view"] = "Commits"
+	values["ref"] = commitView.activeRefName
+	values["progress"] = commitView.progress.String()
+
+	refViewData, ok := commitView.refViewData[commitView.activeRef]
+	if commitView.activeRef == nil || !ok {
+		positionStatuslineValues("Commit", 0, 0, values)
+		return
 	}
-}
 
-func (refreshTask *loadingCommitsRefreshTask) start() {
-	refreshTask.ticker = time.NewTicker(refreshTask.refreshRate)
-	cancelCh := make(chan bool)
-	refreshTask.cancelCh = cancelCh
+	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
 
-	go func(cancelCh <-chan bool) {
-		for {
-			select {
-			case <-refreshTask.ticker.C:
-				log.Debug("Updating display with newly loaded commits")
-				refreshTask.channels.UpdateDisplay()
-			case <-cancelCh:
-				refreshTask.channels.UpdateDisplay()
-				return
-			}
-		}
-	}(cancelCh)
-}
+	var selectedCommit uint
+	if commitSetState.commitNum > 0 {
+		selectedCommit = refViewData.viewPos.ActiveRowIndex() + 1
+	}
 
-func (refreshTask *loadingCommitsRefreshTask) stop() {
-	if refreshTask.ticker != nil {
-		refreshTask.ticker.Stop()
-		refreshTask.cancelCh <- true
-		close(refreshTask.cancelCh)
-		refreshTask.ticker = nil
+	positionStatuslineValues("Commit", selectedCommit, commitSetState.commitNum, values)
+
+	if commitSetState.filterState != nil {
+		values["filters"] = filtersText(commitSetState.filterState.filtersApplied)
 	}
 }
 
+// RenderHelpBar shows key bindings custom to the commit view
+func (commitView *CommitView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(commitView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionFilterPrompt, message: "Add Filter"},
+		{action: ActionRemoveFilter, message: "Remove Filter"},
+	})
+
+	return
+}
+
 // OnRefSelect handles a new ref being selected and fetches/loads the relevant commits to display
 func (commitView *CommitView) OnRefSelect(refName string, oid *Oid) (err error) {
 	log.Debugf("CommitView loading commits for selected oid %v", oid)
 	commitView.lock.Lock()
 	defer commitView.lock.Unlock()
 
-	if commitView.refreshTask != nil {
-		commitView.refreshTask.stop()
+	if oid == nil {
+		commitView.progress.Stop(PgLoadCommits)
+		commitView.activeRef = nil
+		commitView.activeRefName = refName
+		commitView.channels.ReportStatus("Branch %v has no commits yet", refName)
+		return
 	}
 
-	refreshTask := newLoadingCommitsRefreshTask(time.Millisecond*cvLoadRefreshMs, commitView.channels)
-	commitView.refreshTask = refreshTask
-
 	if err = commitView.repoData.LoadCommits(oid, func(oid *Oid) error {
 		commitView.lock.Lock()
 		defer commitView.lock.Unlock()
 
-		refreshTask.stop()
+		if oid == commitView.activeRef {
+			commitView.progress.Stop(PgLoadCommits)
+		}
 
 		commitSetState := commitView.repoData.CommitSetState(oid)
 		commitView.channels.ReportStatus("Loaded %v commits for ref %v", commitSetState.commitNum, refName)
@@ -309,7 +483,7 @@ func (commitView *CommitView) OnRefSelect(refName string, oid *Oid) (err error)
 	if !refViewDataExists {
 		refViewData = &referenceViewData{
 			viewPos:        NewViewPosition(),
-			tableFormatter: NewTableFormatter(cvColumnNum),
+			tableFormatter: commitView.newTableFormatter(),
 		}
 
 		commitView.refViewData[oid] = refViewData
@@ -318,10 +492,10 @@ func (commitView *CommitView) OnRefSelect(refName string, oid *Oid) (err error)
 	commitSetState := commitView.repoData.CommitSetState(oid)
 
 	if commitSetState.loading {
-		commitView.refreshTask.start()
+		commitView.progress.Start(PgLoadCommits, "Loading commits")
 		commitView.channels.ReportStatus("Loading commits for ref %v", refName)
 	} else {
-		commitView.refreshTask.stop()
+		commitView.progress.Stop(PgLoadCommits)
 	}
 
 	var commit *Commit
@@ -355,6 +529,42 @@ func (commitView *CommitView) ViewID() ViewID {
 	return ViewCommit
 }
 
+// OnDirectorySelect adds a filter to the active ref restricting commits to those which modify the selected directory
+func (commitView *CommitView) OnDirectorySelect(directory string) (err error) {
+	log.Debugf("CommitView filtering commits by directory %v", directory)
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	if commitView.activeRef == nil {
+		return fmt.Errorf("No ref selected to filter")
+	}
+
+	if err = commitView.applyCommitFilter(CreateCommitPathFilter(directory), fmt.Sprintf("directory=%v", directory)); err != nil {
+		return
+	}
+
+	commitView.channels.ReportStatus("Filtering commits modifying directory %v", directory)
+
+	return
+}
+
+// SelectedCommit returns the active ref and the currently selected commit
+func (commitView *CommitView) SelectedCommit() (refName string, ref *Oid, commit *Commit) {
+	commitView.lock.Lock()
+	defer commitView.lock.Unlock()
+
+	if commitView.activeRef == nil {
+		return
+	}
+
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		log.Debugf("Unable to determine selected commit: %v", err)
+	}
+
+	return commitView.activeRefName, commitView.activeRef, commit
+}
+
 // RegisterCommitListner accepts a listener to be notified when a commit is selected
 func (commitView *CommitView) RegisterCommitListner(commitListener CommitListener) {
 	commitView.commitListeners = append(commitView.commitListeners, commitListener)
@@ -417,6 +627,10 @@ func (commitView *CommitView) Line(lineIndex uint) (line string) {
 	commitView.lock.Lock()
 	defer commitView.lock.Unlock()
 
+	return commitView.line(lineIndex)
+}
+
+func (commitView *CommitView) line(lineIndex uint) (line string) {
 	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
 
 	if lineIndex >= commitSetState.commitNum {
@@ -475,6 +689,11 @@ func (commitView *CommitView) HandleAction(action Action) (err error) {
 	commitView.lock.Lock()
 	defer commitView.lock.Unlock()
 
+	if commitView.activeRef == nil {
+		log.Debugf("No commits to act upon")
+		return
+	}
+
 	if handler, ok := commitView.handlers[action.ActionType]; ok {
 		err = handler(commitView, action)
 	} else {
@@ -487,8 +706,8 @@ func (commitView *CommitView) HandleAction(action Action) (err error) {
 func moveUpCommit(commitView *CommitView, action Action) (err error) {
 	viewPos := commitView.ViewPos()
 
-	if viewPos.MoveLineUp() {
-		log.Debug("Moving up one commit")
+	if viewPos.MoveLinesUp(action.RepeatCount()) {
+		log.Debugf("Moving up %v commits", action.RepeatCount())
 		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
 			return
 		}
@@ -502,8 +721,8 @@ func moveDownCommit(commitView *CommitView, action Action) (err error) {
 	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
 	viewPos := commitView.ViewPos()
 
-	if viewPos.MoveLineDown(commitSetState.commitNum) {
-		log.Debug("Moving down one commit")
+	if viewPos.MoveLinesDown(action.RepeatCount(), commitSetState.commitNum) {
+		log.Debugf("Moving down %v commits", action.RepeatCount())
 		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
 			return
 		}
@@ -516,8 +735,8 @@ func moveDownCommit(commitView *CommitView, action Action) (err error) {
 func moveUpCommitPage(commitView *CommitView, action Action) (err error) {
 	viewPos := commitView.ViewPos()
 
-	if viewPos.MovePageUp(commitView.viewDimension.rows - 2) {
-		log.Debug("Moving up one page")
+	if viewPos.MovePageUp((commitView.viewDimension.rows - 2) * action.RepeatCount()) {
+		log.Debugf("Moving up %v pages", action.RepeatCount())
 		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
 			return
 		}
@@ -531,8 +750,37 @@ func moveDownCommitPage(commitView *CommitView, action Action) (err error) {
 	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
 	viewPos := commitView.ViewPos()
 
-	if viewPos.MovePageDown(commitView.viewDimension.rows-2, commitSetState.commitNum) {
-		log.Debug("Moving down one page")
+	if viewPos.MovePageDown((commitView.viewDimension.rows-2)*action.RepeatCount(), commitSetState.commitNum) {
+		log.Debugf("Moving down %v pages", action.RepeatCount())
+		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
+			return
+		}
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpCommitHalfPage(commitView *CommitView, action Action) (err error) {
+	viewPos := commitView.ViewPos()
+
+	if viewPos.MoveHalfPageUp((commitView.viewDimension.rows - 2) * action.RepeatCount()) {
+		log.Debugf("Moving up %v half pages", action.RepeatCount())
+		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
+			return
+		}
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownCommitHalfPage(commitView *CommitView, action Action) (err error) {
+	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
+	viewPos := commitView.ViewPos()
+
+	if viewPos.MoveHalfPageDown((commitView.viewDimension.rows-2)*action.RepeatCount(), commitSetState.commitNum) {
+		log.Debugf("Moving down %v half pages", action.RepeatCount())
 		if err = commitView.selectCommit(viewPos.ActiveRowIndex()); err != nil {
 			return
 		}
@@ -542,6 +790,33 @@ func moveDownCommitPage(commitView *CommitView, action Action) (err error) {
 	return
 }
 
+func centerCommitView(commitView *CommitView, action Action) (err error) {
+	if commitView.ViewPos().CenterActiveRow(commitView.viewDimension.rows - 2) {
+		log.Debug("Centering selected commit")
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollCommitViewCursorTop(commitView *CommitView, action Action) (err error) {
+	if commitView.ViewPos().ScrollActiveRowToTop() {
+		log.Debug("Scrolling selected commit to top of view")
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollCommitViewCursorBottom(commitView *CommitView, action Action) (err error) {
+	if commitView.ViewPos().ScrollActiveRowToBottom(commitView.viewDimension.rows - 2) {
+		log.Debug("Scrolling selected commit to bottom of view")
+		commitView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
 func scrollCommitViewRight(commitView *CommitView, action Action) (err error) {
 	viewPos := commitView.ViewPos()
 	viewPos.MovePageRight(commitView.viewDimension.cols)
@@ -607,10 +882,18 @@ func addCommitFilter(commitView *CommitView, action Action) (err error) {
 		return
 	}
 
+	return commitView.applyCommitFilter(commitFilter, query)
+}
+
+func (commitView *CommitView) applyCommitFilter(commitFilter *CommitFilter, description string) (err error) {
 	if err = commitView.repoData.AddCommitFilter(commitView.activeRef, commitFilter); err != nil {
 		return
 	}
 
+	if refViewData, ok := commitView.refViewData[commitView.activeRef]; ok {
+		refViewData.filters = append(refViewData.filters, description)
+	}
+
 	commitView.ViewPos().SetActiveRowIndex(0)
 
 	go func() {
@@ -636,6 +919,10 @@ func removeCommitFilter(commitView *CommitView, action Action) (err error) {
 		return
 	}
 
+	if refViewData, ok := commitView.refViewData[commitView.activeRef]; ok && len(refViewData.filters) > 0 {
+		refViewData.filters = refViewData.filters[:len(refViewData.filters)-1]
+	}
+
 	commitView.ViewPos().SetActiveRowIndex(0)
 
 	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
@@ -649,3 +936,365 @@ func removeCommitFilter(commitView *CommitView, action Action) (err error) {
 
 	return
 }
+
+// simplifyByDecoration toggles displaying only commits pointed to by refs
+// It is applied as a filter so it can be combined with other filters
+func simplifyByDecoration(commitView *CommitView, action Action) (err error) {
+	if refViewData, ok := commitView.refViewData[commitView.activeRef]; ok && len(refViewData.filters) > 0 &&
+		refViewData.filters[len(refViewData.filters)-1] == cvDecoratedFilterDescription {
+		return removeCommitFilter(commitView, action)
+	}
+
+	if err = commitView.applyCommitFilter(commitView.repoData.DecoratedCommitFilter(), cvDecoratedFilterDescription); err != nil {
+		return
+	}
+
+	commitView.channels.ReportStatus("Displaying only commits pointed to by refs")
+
+	return
+}
+
+// filterCommitsByOwner adds a filter restricting commits to those which modify files owned by the provided owner
+func filterCommitsByOwner(commitView *CommitView, action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected owner argument")
+	}
+
+	owner, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected owner argument to have type string")
+	}
+
+	if commitView.activeRef == nil {
+		return fmt.Errorf("No ref selected to filter")
+	}
+
+	commitFilter, err := commitView.repoData.OwnerCommitFilter(owner)
+	if err != nil {
+		return
+	}
+
+	if err = commitView.applyCommitFilter(commitFilter, fmt.Sprintf("owner=%v", owner)); err != nil {
+		return
+	}
+
+	commitView.channels.ReportStatus("Filtering commits modifying files owned by %v", owner)
+
+	return
+}
+
+// showContainingRefs lists the branches and tags the selected commit is reachable from
+// A ref can then be chosen from the list to select it in the ref view
+func showContainingRefs(commitView *CommitView, action Action) (err error) {
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	commitView.channels.ReportStatus("Finding refs containing commit %v", commitView.repoData.ShortID(commit.oid))
+
+	go func() {
+		branches, tags, err := commitView.repoData.RefsContainingCommit(commit.oid)
+		if err != nil {
+			commitView.channels.ReportError(err)
+			return
+		}
+
+		targets := containingRefTargets(branches, tags)
+		if len(targets) == 0 {
+			commitView.channels.ReportStatus("No branches or tags contain commit %v", commitView.repoData.ShortID(commit.oid))
+			return
+		}
+
+		commitView.channels.DoAction(Action{
+			ActionType: ActionShowDialog,
+			Args: []interface{}{DialogRequest{
+				dialogType:   DtInput,
+				title:        fmt.Sprintf("Refs containing %v", commitView.repoData.ShortID(commit.oid)),
+				message:      containingRefsMessage(targets),
+				initialInput: "1",
+				action:       Action{ActionType: ActionSelectContainingRef, Args: []interface{}{targets}},
+			}},
+		})
+	}()
+
+	return
+}
+
+// yankDescribe copies the git describe output of the selected commit to the clipboard
+func yankDescribe(commitView *CommitView, action Action) (err error) {
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	description, found, err := commitView.repoData.Describe(context.Background(), commit)
+	if err != nil {
+		return
+	} else if !found {
+		return fmt.Errorf("No tags can describe commit %v", commitView.repoData.ShortID(commit.oid))
+	}
+
+	if err = Yank(commitView.config, description); err != nil {
+		return
+	}
+
+	commitView.channels.ReportStatus("Yanked %v", description)
+
+	return
+}
+
+// createFixupCommit commits the index as a fixup! commit targeting the selected commit
+func createFixupCommit(commitView *CommitView, action Action) (err error) {
+	return commitView.createAutosquashCommit(FixupPrefix)
+}
+
+// createSquashCommit commits the index as a squash! commit targeting the selected commit
+func createSquashCommit(commitView *CommitView, action Action) (err error) {
+	return commitView.createAutosquashCommit(SquashPrefix)
+}
+
+// createAutosquashCommit commits the index with the summary of the selected commit and the provided prefix
+// The commit is created by the ref view so that it is displayed in the same way as any other new commit
+func (commitView *CommitView) createAutosquashCommit(prefix string) (err error) {
+	commit, err := commitView.repoData.CommitByIndex(commitView.activeRef, commitView.ViewPos().ActiveRowIndex())
+	if err != nil {
+		return
+	}
+
+	commitView.channels.DoAction(Action{
+		ActionType: ActionCreateCommit,
+		Args:       []interface{}{prefix + commit.commit.Summary()},
+	})
+
+	return
+}
+
+// autosquashRebase asks for confirmation and then rebases HEAD so that fixup! and squash! commits
+// are squashed into the commits they target
+func autosquashRebase(commitView *CommitView, action Action) (err error) {
+	commitView.channels.ReportStatus("Finding commits targeted by fixup and squash commits")
+
+	go func() {
+		target, err := commitView.repoData.OldestAutosquashTarget()
+		if err != nil {
+			commitView.channels.ReportError(err)
+			return
+		}
+
+		request := CommandRequest{
+			cmd:         commitView.repoData.AutosquashRebaseCommand(target),
+			description: "Autosquash rebase",
+			onComplete: func(err error) {
+				if err != nil {
+					commitView.channels.ReportError(err)
+				} else {
+					commitView.channels.ReportStatus("Autosquash rebase complete")
+				}
+
+				commitView.channels.DoAction(Action{ActionType: ActionReloadRefs})
+			},
+		}
+
+		_, headBranch := commitView.repoData.Head()
+		dialog := autosquashRebaseDialog(commitView.config, headBranch, commitView.repoData.ShortID(target.oid),
+			target.commit.Summary(), Action{ActionType: ActionRunCommand, Args: []interface{}{request}})
+
+		commitView.channels.DoAction(Action{
+			ActionType: ActionShowDialog,
+			Args:       []interface{}{dialog},
+		})
+	}()
+
+	return
+}
+
+// autosquashRebaseDialog creates the dialog confirming an autosquash rebase from the target commit.
+// The name of HEAD's branch must be typed to confirm the rebase when the branch is protected
+func autosquashRebaseDialog(config Config, headBranch *Branch, shortID, summary string, action Action) DialogRequest {
+	if headBranch != nil && IsProtectedRef(config, headBranch.name) {
+		return protectedRefConfirmation(headBranch.name, fmt.Sprintf("autosquash rebase it from %v", shortID), action)
+	}
+
+	return DialogRequest{
+		dialogType: DtYesNo,
+		title:      "Autosquash Rebase",
+		message:    fmt.Sprintf("Rebase HEAD from %v \"%v\" squashing fixup and squash commits into their targets?", shortID, summary),
+		action:     action,
+	}
+}
+
+// containingRefTargets generates a ref view target for each of the provided branches and tags
+func containingRefTargets(branches []*Branch, tags []*Tag) (targets []ViewTarget) {
+	for _, branch := range branches {
+		refGroup := RvLocalBranchGroup
+		if branch.isRemote {
+			refGroup = RvRemoteBranchGroup
+		}
+
+		targets = append(targets, ViewTarget{viewID: ViewRef, refGroup: refGroup, refName: branch.name, oid: branch.oid})
+	}
+
+	for _, tag := range tags {
+		targets = append(targets, ViewTarget{viewID: ViewRef, refGroup: RvTagGroup, refName: tag.name, oid: tag.oid})
+	}
+
+	return
+}
+
+// containingRefsMessage generates a numbered list of the refs for display in a dialog
+func containingRefsMessage(targets []ViewTarget) string {
+	var lines []string
+
+	for targetIndex, target := range targets {
+		refType := "branch"
+		switch target.refGroup {
+		case RvRemoteBranchGroup:
+			refType = "remote"
+		case RvTagGroup:
+			refType = "tag"
+		}
+
+		lines = append(lines, fmt.Sprintf("%v. %v (%v)", targetIndex+1, target.refName, refType))
+	}
+
+	lines = append(lines, "Enter the number of a ref to select it")
+
+	return strings.Join(lines, "\n")
+}
+
+// showCommitViewTarget selects the commit or filters by the path specified by the view target
+func showCommitViewTarget(commitView *CommitView, action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected view target argument")
+	}
+
+	viewTarget, ok := action.Args[0].(ViewTarget)
+	if !ok {
+		return fmt.Errorf("Expected view target argument to have type ViewTarget")
+	}
+
+	if commitView.activeRef == nil {
+		return
+	}
+
+	if viewTarget.path != "" {
+		if err = commitView.applyCommitFilter(CreateCommitPathFilter(viewTarget.path), fmt.Sprintf("file=%v", viewTarget.path)); err != nil {
+			return
+		}
+
+		commitView.channels.ReportStatus("Filtering commits modifying file %v", viewTarget.path)
+	}
+
+	if viewTarget.commit != nil {
+		err = commitView.selectTargetCommit(viewTarget.commit)
+	}
+
+	return
+}
+
+func (commitView *CommitView) selectTargetCommit(targetCommit *Commit) (err error) {
+	commitIndex, found, err := commitView.loadedCommitIndex(targetCommit)
+	if err != nil {
+		return
+	} else if !found {
+		return fmt.Errorf("Commit %v is not loaded for ref %v", commitView.repoData.ShortID(targetCommit.oid), commitView.activeRefName)
+	}
+
+	commitView.ViewPos().SetActiveRowIndex(commitIndex)
+
+	if err = commitView.selectCommit(commitIndex); err != nil {
+		return
+	}
+
+	commitView.channels.UpdateDisplay()
+
+	return
+}
+
+// loadedCommitIndex returns the index of the target commit among the commits loaded for the active ref
+func (commitView *CommitView) loadedCommitIndex(targetCommit *Commit) (commitIndex uint, found bool, err error) {
+	commitSetState := commitView.repoData.CommitSetState(commitView.activeRef)
+
+	commitCh, err := commitView.repoData.Commits(commitView.activeRef, 0, commitSetState.commitNum)
+	if err != nil {
+		return
+	}
+
+	for commit := range commitCh {
+		if found {
+			continue
+		} else if commit.oid == targetCommit.oid {
+			found = true
+		} else {
+			commitIndex++
+		}
+	}
+
+	return
+}
+
+func gotoCommit(commitView *CommitView, action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected revision argument")
+	}
+
+	revision, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected revision argument to have type string")
+	}
+
+	targetCommit, err := commitView.repoData.CommitByRevision(revision)
+	if err != nil {
+		return fmt.Errorf("Unable to resolve revision %v: %v", revision, err)
+	}
+
+	commitView.channels.ReportStatus("Searching for commit %v", commitView.repoData.ShortID(targetCommit.oid))
+	go commitView.selectCommitWhenLoaded(revision, targetCommit)
+
+	return
+}
+
+// selectCommitWhenLoaded selects the target commit once it has been loaded for the active ref.
+// If the commits of the active ref have finished loading and do not contain the target commit
+// then the history of the target commit is loaded instead
+func (commitView *CommitView) selectCommitWhenLoaded(revision string, targetCommit *Commit) {
+	commitView.lock.Lock()
+	activeRef := commitView.activeRef
+	commitView.lock.Unlock()
+
+	for activeRef != nil {
+		commitView.lock.Lock()
+
+		if commitView.activeRef != activeRef {
+			commitView.lock.Unlock()
+			log.Debugf("Active ref changed while searching for commit %v", targetCommit.oid)
+			return
+		}
+
+		loading := commitView.repoData.CommitSetState(activeRef).loading
+		commitIndex, found, err := commitView.loadedCommitIndex(targetCommit)
+
+		if err == nil && found {
+			commitView.ViewPos().SetActiveRowIndex(commitIndex)
+			err = commitView.selectCommit(commitIndex)
+			commitView.channels.UpdateDisplay()
+		}
+
+		commitView.lock.Unlock()
+
+		if err != nil || found {
+			commitView.channels.ReportError(err)
+			return
+		} else if !loading {
+			break
+		}
+
+		time.Sleep(cvLoadedCommitPollInterval)
+	}
+
+	commitView.channels.ReportStatus("Commit %v is not reachable from %v. Displaying its history",
+		commitView.repoData.ShortID(targetCommit.oid), commitView.activeRefName)
+	commitView.channels.ReportError(commitView.OnRefSelect(revision, targetCommit.oid))
+	commitView.channels.UpdateDisplay()
+}
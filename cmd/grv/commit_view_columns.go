@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+type commitViewColumnType int
+
+const (
+	cvcShortHash commitViewColumnType = iota
+	cvcHash
+	cvcDate
+	cvcAuthor
+	cvcSummary
+	cvcRefs
+)
+
+var commitViewColumnNames = map[string]commitViewColumnType{
+	"shorthash": cvcShortHash,
+	"hash":      cvcHash,
+	"date":      cvcDate,
+	"author":    cvcAuthor,
+	"summary":   cvcSummary,
+	"refs":      cvcRefs,
+}
+
+// commitViewColumn describes a column displayed in the commit view
+// A width of 0 means the column is as wide as its widest value
+type commitViewColumn struct {
+	columnType commitViewColumnType
+	width      uint
+}
+
+// parseCommitViewColumns parses a whitespace separated list of column names
+// Each column name can optionally be followed by a colon and a maximum width e.g. author:20
+func parseCommitViewColumns(columnsSpec string) (columns []commitViewColumn, err error) {
+	seen := make(map[commitViewColumnType]bool)
+
+	for _, columnSpec := range strings.Fields(columnsSpec) {
+		nameAndWidth := strings.SplitN(columnSpec, ":", 2)
+
+		columnType, ok := commitViewColumnNames[nameAndWidth[0]]
+		if !ok {
+			return nil, fmt.Errorf("Invalid commit view column: %v", nameAndWidth[0])
+		} else if seen[columnType] {
+			return nil, fmt.Errorf("Commit view column %v specified more than once", nameAndWidth[0])
+		}
+
+		seen[columnType] = true
+		column := commitViewColumn{columnType: columnType}
+
+		if len(nameAndWidth) == 2 {
+			width, err := strconv.ParseUint(nameAndWidth[1], 10, 32)
+			if err != nil || width == 0 {
+				return nil, fmt.Errorf("Invalid width for commit view column %v: %v", nameAndWidth[0], nameAndWidth[1])
+			}
+
+			column.width = uint(width)
+		}
+
+		columns = append(columns, column)
+	}
+
+	if len(columns) == 0 {
+		err = fmt.Errorf("At least one commit view column must be specified")
+	}
+
+	return
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCommitViewColumns(t *testing.T) {
+	var columnTests = []struct {
+		columnsSpec     string
+		expectedColumns []commitViewColumn
+		expectedError   bool
+	}{
+		{
+			columnsSpec: "shorthash date author:20 summary refs",
+			expectedColumns: []commitViewColumn{
+				{columnType: cvcShortHash},
+				{columnType: cvcDate},
+				{columnType: cvcAuthor, width: 20},
+				{columnType: cvcSummary},
+				{columnType: cvcRefs},
+			},
+		},
+		{
+			columnsSpec: "  summary:50   hash ",
+			expectedColumns: []commitViewColumn{
+				{columnType: cvcSummary, width: 50},
+				{columnType: cvcHash},
+			},
+		},
+		{columnsSpec: "", expectedError: true},
+		{columnsSpec: "shorthash committer", expectedError: true},
+		{columnsSpec: "author:0", expectedError: true},
+		{columnsSpec: "author:-5", expectedError: true},
+		{columnsSpec: "author:wide", expectedError: true},
+		{columnsSpec: "date date", expectedError: true},
+	}
+
+	for _, columnTest := range columnTests {
+		columns, err := parseCommitViewColumns(columnTest.columnsSpec)
+
+		if columnTest.expectedError {
+			if err == nil {
+				t.Errorf("Expected error for columns %q", columnTest.columnsSpec)
+			}
+		} else if err != nil {
+			t.Errorf("Unexpected error for columns %q: %v", columnTest.columnsSpec, err)
+		} else if !reflect.DeepEqual(columns, columnTest.expectedColumns) {
+			t.Errorf("Columns do not match expected value for %q. Expected: %v, Actual: %v", columnTest.columnsSpec, columnTest.expectedColumns, columns)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestContainingRefTargets(t *testing.T) {
+	branches := []*Branch{{name: "master"}, {name: "origin/master", isRemote: true}}
+	tags := []*Tag{{name: "v1.0"}}
+
+	expectedTargets := []ViewTarget{
+		{viewID: ViewRef, refGroup: RvLocalBranchGroup, refName: "master"},
+		{viewID: ViewRef, refGroup: RvRemoteBranchGroup, refName: "origin/master"},
+		{viewID: ViewRef, refGroup: RvTagGroup, refName: "v1.0"},
+	}
+
+	targets := containingRefTargets(branches, tags)
+
+	if len(targets) != len(expectedTargets) {
+		t.Fatalf("Target count does not match expected value. Expected: %v, Actual: %v", len(expectedTargets), len(targets))
+	}
+
+	for targetIndex, expectedTarget := range expectedTargets {
+		if target := targets[targetIndex]; target != expectedTarget {
+			t.Errorf("Target does not match expected value. Expected: %v, Actual: %v", expectedTarget, target)
+		}
+	}
+}
+
+func TestContainingRefsMessageNumbersRefs(t *testing.T) {
+	targets := containingRefTargets([]*Branch{{name: "feature"}}, []*Tag{{name: "v2.0"}})
+	expectedMessage := "1. feature (branch)\n2. v2.0 (tag)\nEnter the number of a ref to select it"
+
+	if message := containingRefsMessage(targets); message != expectedMessage {
+		t.Errorf("Message does not match expected value. Expected: %q, Actual: %q", expectedMessage, message)
+	}
+}
+
+func TestAutosquashRebaseDialogRequiresTypedConfirmationForProtectedBranch(t *testing.T) {
+	config := NewConfiguration(NewKeyBindingManager(), nil)
+	if errs := config.Evaluate("set protectedrefs \"master release/*\""); len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+
+	var dialogTests = []struct {
+		headBranch               *Branch
+		expectedDialogType       DialogType
+		expectedConfirmationText string
+	}{
+		{headBranch: &Branch{name: "master"}, expectedDialogType: DtTypedConfirmation, expectedConfirmationText: "master"},
+		{headBranch: &Branch{name: "release/1.0"}, expectedDialogType: DtTypedConfirmation, expectedConfirmationText: "release/1.0"},
+		{headBranch: &Branch{name: "feature"}, expectedDialogType: DtYesNo},
+		{headBranch: nil, expectedDialogType: DtYesNo},
+	}
+
+	action := Action{ActionType: ActionRunCommand}
+
+	for _, dialogTest := range dialogTests {
+		dialog := autosquashRebaseDialog(config, dialogTest.headBranch, "abc1234", "Add feature", action)
+
+		if dialog.dialogType != dialogTest.expectedDialogType || dialog.confirmationText != dialogTest.expectedConfirmationText {
+			t.Errorf("Dialog does not match expected value for HEAD branch %v. Expected: %v %q, Actual: %v %q",
+				dialogTest.headBranch, dialogTest.expectedDialogType, dialogTest.expectedConfirmationText,
+				dialog.dialogType, dialog.confirmationText)
+		}
+
+		if dialog.action.ActionType != action.ActionType {
+			t.Errorf("Dialog action does not match expected value. Expected: %v, Actual: %v", action.ActionType, dialog.action.ActionType)
+		}
+	}
+}
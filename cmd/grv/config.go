@@ -1,33 +1,126 @@
 package main
 
 import (
+	"bytes"
 	"fmt"
 	"os"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 
 	log "github.com/Sirupsen/logrus"
+	slice "github.com/bradfitz/slice"
 )
 
 const (
-	cfDefaultConfigHomeDir = "/.config"
-	cfGrvConfigDir         = "/grv"
-	cfGrvrcFile            = "/grvrc"
-	cfTabWidthMinValue     = 1
-	cfTabWidthDefaultValue = 8
-	cfThemeDefaultValue    = "default"
-	cfColdThemeName        = "cold"
-
-	cfAllView       = "All"
-	cfHistoryView   = "HistoryView"
-	cfStatusView    = "StatusView"
-	cfRefView       = "RefView"
-	cfCommitView    = "CommitView"
-	cfDiffView      = "DiffView"
-	cfStatusBarView = "StatusBarView"
-	cfHelpBarView   = "HelpBarView"
-	cfErrorView     = "ErrorView"
+	cfDefaultConfigHomeDir  = "/.config"
+	cfGrvConfigDir          = "/grv"
+	cfGrvrcFile             = "/grvrc"
+	cfTabWidthMinValue      = 1
+	cfTabWidthDefaultValue  = 8
+	cfThemeDefaultValue     = "default"
+	cfColdThemeName         = "cold"
+	cfDeuteranopiaThemeName = "deuteranopia"
+	cfProtanopiaThemeName   = "protanopia"
+
+	cfDiffRenamesDefaultValue             = true
+	cfDiffCopiesDefaultValue              = false
+	cfDiffSimilarityThresholdMinValue     = 0
+	cfDiffSimilarityThresholdMaxValue     = 100
+	cfDiffSimilarityThresholdDefaultValue = 50
+	cfDiffContextDefaultValue             = 3
+	cfDiffExcludeDefaultValue             = ""
+	cfDiffTextconvDefaultValue            = true
+	cfWhitespaceHighlightDefaultValue     = true
+	cfInvisiblesDefaultValue              = false
+	cfPagerDefaultValue                   = ""
+	cfDiffRendererDefaultValue            = ""
+	cfGraphicsDefaultValue                = "auto"
+	cfHTTPProxyDefaultValue               = ""
+	cfSSLCAInfoDefaultValue               = ""
+	cfFetchIntervalDefaultValue           = 0
+	cfNotifyFetchCommandDefaultValue      = ""
+	cfNotifyDiffCommandDefaultValue       = ""
+	cfNotifyCommitCommandDefaultValue     = ""
+	cfYankCommandDefaultValue             = ""
+	cfStatuslineDefaultValue              = "%status"
+	cfRefViewFooterDefaultValue           = "%position %description %progress"
+	cfCommitViewFooterDefaultValue        = "%position %filters %progress"
+	cfDiffViewFooterDefaultValue          = "%position %owners %progress"
+	cfNumberDefaultValue                  = false
+	cfRelativeNumberDefaultValue          = false
+	cfScrollOffDefaultValue               = 0
+	cfScrollBarDefaultValue               = false
+	cfDiffStickyFileHeaderDefaultValue    = true
+	cfDiffStickyHunkHeaderDefaultValue    = false
+	cfCommitViewColumnsDefaultValue       = "shorthash date author summary"
+	cfCommitIdentityDefaultValue          = IdentityAuthor
+	cfCommitIdentityFormatDefaultValue    = IdentityFormatName
+	cfDiffIdentityFormatDefaultValue      = IdentityFormatBoth
+	cfAbbrevLengthDefaultValue            = "7"
+	cfMinAbbrevLength                     = 4
+	cfMaxAbbrevLength                     = 40
+	cfRecentBranchesDefaultValue          = 5
+	cfCheckoutTrackingBranchDefaultValue  = false
+	cfProtectedRefsDefaultValue           = ""
+	cfReadOnlyDefaultValue                = false
+	cfLayoutDefaultValue                  = LayoutStacked
+	cfKeymapDefaultValue                  = KeymapVim
+	cfLogLevelDefaultValue                = "none"
+	cfDebugOverlayDefaultValue            = false
+	cfMemoryBudgetDefaultValue            = 256
+	cfScreenReaderDefaultValue            = false
+	cfBorderCharsDefaultValue             = BorderCharsUnicode
+	cfCommitOrderDefaultValue             = CommitOrderDate
+	cfMessagePreviewDefaultValue          = false
+	cfCommitLintDefaultValue              = ""
+	cfCommitLintSubjectLengthDefaultValue = 50
+	cfRepoPreferencesDefaultValue         = true
+
+	cfAllView             = "All"
+	cfHistoryView         = "HistoryView"
+	cfStatusView          = "StatusView"
+	cfRefView             = "RefView"
+	cfCommitView          = "CommitView"
+	cfDiffView            = "DiffView"
+	cfStatusBarView       = "StatusBarView"
+	cfHelpBarView         = "HelpBarView"
+	cfErrorView           = "ErrorView"
+	cfSummaryView         = "SummaryView"
+	cfFuzzyFinderView     = "FuzzyFinderView"
+	cfDialogView          = "DialogView"
+	cfConfigVariablesView = "ConfigVariablesView"
+	cfLogView             = "LogView"
+	cfTextView            = "TextView"
+	cfFileView            = "FileView"
+	cfQuickfixView        = "QuickfixView"
+)
+
+// AbbrevLengthAuto is the abbrevlength value which selects the shortest unambiguous abbreviation
+const AbbrevLengthAuto = "auto"
+
+// The set of history view layouts
+const (
+	LayoutStacked = "stacked"
+	LayoutColumns = "columns"
+)
+
+// The set of orders commits can be displayed in
+const (
+	CommitOrderDate       = "date"
+	CommitOrderTopo       = "topo"
+	CommitOrderAuthorDate = "author-date"
+	CommitOrderReverse    = "reverse"
+)
+
+// commitOrders lists the commit orders in the order they are cycled through
+var commitOrders = []string{CommitOrderDate, CommitOrderTopo, CommitOrderAuthorDate, CommitOrderReverse}
+
+// The set of character sets used to draw borders and markers
+const (
+	BorderCharsUnicode = "unicode"
+	BorderCharsASCII   = "ascii"
 )
 
 // ConfigVariable stores a config variable name
@@ -35,11 +128,114 @@ type ConfigVariable string
 
 const (
 	// CfTabWidth stores the tab width variable name
-	CfTabWidth ConfigVariable = "tabWidth"
+	CfTabWidth ConfigVariable = "tabwidth"
 	// CfTheme stores the theme variable name
 	CfTheme ConfigVariable = "theme"
+	// CfDiffRenames stores the diff rename detection variable name
+	CfDiffRenames ConfigVariable = "diffRenames"
+	// CfDiffCopies stores the diff copy detection variable name
+	CfDiffCopies ConfigVariable = "diffCopies"
+	// CfDiffSimilarityThreshold stores the diff rename/copy similarity threshold variable name
+	CfDiffSimilarityThreshold ConfigVariable = "diffSimilarityThreshold"
+	// CfDiffContext stores the number of context lines displayed around changes in diffs variable name
+	CfDiffContext ConfigVariable = "diffContext"
+	// CfDiffExclude stores the path patterns of files omitted from diffs variable name
+	CfDiffExclude ConfigVariable = "diffexclude"
+	// CfDiffTextconv stores whether textconv commands configured for diff drivers are applied variable name
+	CfDiffTextconv ConfigVariable = "diffTextconv"
+	// CfWhitespaceHighlight stores whether whitespace errors in added lines are highlighted variable name
+	CfWhitespaceHighlight ConfigVariable = "whitespacehl"
+	// CfInvisibles stores whether tabs, trailing spaces and non-breaking spaces are displayed as placeholder characters variable name
+	CfInvisibles ConfigVariable = "invisibles"
+	// CfPager stores the command diffs are paged with variable name
+	CfPager ConfigVariable = "pager"
+	// CfDiffRenderer stores the command diffs are formatted with variable name
+	CfDiffRenderer ConfigVariable = "diffrenderer"
+	// CfGraphics stores the graphics protocol images are previewed with variable name
+	CfGraphics ConfigVariable = "graphics"
+	// CfHTTPProxy stores the proxy used for remote operations variable name
+	CfHTTPProxy ConfigVariable = "httpProxy"
+	// CfSSLCAInfo stores the CA certificate file used for remote operations variable name
+	CfSSLCAInfo ConfigVariable = "sslCAInfo"
+	// CfFetchInterval stores the background fetch interval variable name
+	CfFetchInterval ConfigVariable = "fetchinterval"
+	// CfNotifyFetchCommand stores the command run when a fetch completes variable name
+	CfNotifyFetchCommand ConfigVariable = "notifyFetchCommand"
+	// CfNotifyDiffCommand stores the command run when a slow diff has been generated variable name
+	CfNotifyDiffCommand ConfigVariable = "notifyDiffCommand"
+	// CfNotifyCommitCommand stores the command run when a commit has been created variable name
+	CfNotifyCommitCommand ConfigVariable = "notifyCommitCommand"
+	// CfYankCommand stores the command text is piped to when yanked variable name
+	CfYankCommand ConfigVariable = "yankCommand"
+	// CfStatusline stores the status bar format string variable name
+	CfStatusline ConfigVariable = "statusline"
+	// CfRefViewFooter stores the ref view footer format string variable name
+	CfRefViewFooter ConfigVariable = "refViewFooter"
+	// CfCommitViewFooter stores the commit view footer format string variable name
+	CfCommitViewFooter ConfigVariable = "commitViewFooter"
+	// CfDiffViewFooter stores the diff view footer format string variable name
+	CfDiffViewFooter ConfigVariable = "diffViewFooter"
+	// CfNumber stores whether line numbers are displayed variable name
+	CfNumber ConfigVariable = "number"
+	// CfRelativeNumber stores whether relative line numbers are displayed variable name
+	CfRelativeNumber ConfigVariable = "relativenumber"
+	// CfScrollOff stores the number of context lines kept above and below the cursor variable name
+	CfScrollOff ConfigVariable = "scrolloff"
+	// CfScrollBar stores whether a scroll bar is displayed in the Diff View and Commit View variable name
+	CfScrollBar ConfigVariable = "scrollbar"
+	// CfDiffStickyFileHeader stores whether the current file header is pinned to the top of the diff view variable name
+	CfDiffStickyFileHeader ConfigVariable = "diffStickyFileHeader"
+	// CfDiffStickyHunkHeader stores whether the current hunk header is pinned to the top of the diff view variable name
+	CfDiffStickyHunkHeader ConfigVariable = "diffStickyHunkHeader"
+	// CfCommitViewColumns stores the columns displayed in the commit view variable name
+	CfCommitViewColumns ConfigVariable = "commitview.columns"
+	// CfCommitIdentity stores whether the author or committer is displayed in the commit view variable name
+	CfCommitIdentity ConfigVariable = "commitIdentity"
+	// CfCommitIdentityFormat stores the format identities are displayed in in the commit view variable name
+	CfCommitIdentityFormat ConfigVariable = "commitIdentityFormat"
+	// CfDiffIdentityFormat stores the format identities are displayed in in the diff view variable name
+	CfDiffIdentityFormat ConfigVariable = "diffIdentityFormat"
+	// CfAbbrevLength stores the length of abbreviated commit ids variable name
+	CfAbbrevLength ConfigVariable = "abbrevlength"
+	// CfRecentBranches stores the number of recently checked out branches displayed in the ref view variable name
+	CfRecentBranches ConfigVariable = "recentBranches"
+	// CfCheckoutTrackingBranch stores the checkout tracking branch on creation variable name
+	CfCheckoutTrackingBranch ConfigVariable = "checkoutTrackingBranch"
+	// CfProtectedRefs stores the protected ref patterns variable name
+	CfProtectedRefs ConfigVariable = "protectedrefs"
+	// CfReadOnly stores the read only mode variable name
+	CfReadOnly ConfigVariable = "readonly"
+	// CfLayout stores the history view layout variable name
+	CfLayout ConfigVariable = "layout"
+	// CfKeymap stores the key binding preset variable name
+	CfKeymap ConfigVariable = "keymap"
+	// CfLogLevel stores the log level variable name
+	CfLogLevel ConfigVariable = "loglevel"
+	// CfDebugOverlay stores whether render timings are displayed variable name
+	CfDebugOverlay ConfigVariable = "debugoverlay"
+	// CfMemoryBudget stores the number of megabytes of cached diffs retained variable name
+	CfMemoryBudget ConfigVariable = "memorybudget"
+	// CfScreenReader stores whether output is adapted for screen readers variable name
+	CfScreenReader ConfigVariable = "screenreader"
+	// CfBorderChars stores the character set used to draw borders and markers variable name
+	CfBorderChars ConfigVariable = "borderchars"
+	// CfCommitOrder stores the order commits are displayed in variable name
+	CfCommitOrder ConfigVariable = "commitorder"
+	// CfMessagePreview stores whether the diff view displays commit messages in place of diffs variable name
+	CfMessagePreview ConfigVariable = "messagepreview"
+	// CfCommitLint stores the enabled commit message lint rules variable name
+	CfCommitLint ConfigVariable = "commitlint"
+	// CfCommitLintSubjectLength stores the maximum commit subject length allowed by the lint variable name
+	CfCommitLintSubjectLength ConfigVariable = "commitlintsubjectlength"
+	// CfRepoPreferences stores whether view preferences are remembered for each repository variable name
+	CfRepoPreferences ConfigVariable = "repopreferences"
 )
 
+// renamedConfigVariables maps previous variable names, which are still accepted, to their current names
+var renamedConfigVariables = map[string]ConfigVariable{
+	"tabWidth": CfTabWidth,
+}
+
 var themeColors = map[string]ThemeColor{
 	"None":    ColorNone,
 	"Black":   ColorBlack,
@@ -53,55 +249,103 @@ var themeColors = map[string]ThemeColor{
 }
 
 var viewIDNames = map[string]ViewID{
-	cfAllView:       ViewAll,
-	cfHistoryView:   ViewHistory,
-	cfStatusView:    ViewStatus,
-	cfRefView:       ViewRef,
-	cfCommitView:    ViewCommit,
-	cfDiffView:      ViewDiff,
-	cfStatusBarView: ViewStatusBar,
-	cfHelpBarView:   ViewHelpBar,
-	cfErrorView:     ViewError,
+	cfAllView:             ViewAll,
+	cfHistoryView:         ViewHistory,
+	cfStatusView:          ViewStatus,
+	cfRefView:             ViewRef,
+	cfCommitView:          ViewCommit,
+	cfDiffView:            ViewDiff,
+	cfStatusBarView:       ViewStatusBar,
+	cfHelpBarView:         ViewHelpBar,
+	cfErrorView:           ViewError,
+	cfSummaryView:         ViewSummary,
+	cfFuzzyFinderView:     ViewFuzzyFinder,
+	cfDialogView:          ViewDialog,
+	cfConfigVariablesView: ViewConfigVariables,
+	cfLogView:             ViewLog,
+	cfTextView:            ViewText,
+	cfFileView:            ViewFile,
+	cfQuickfixView:        ViewQuickfix,
 }
 
 var themeComponents = map[string]ThemeComponentID{
-	cfAllView + ".SearchMatch": CmpAllviewSearchMatch,
-
-	cfRefView + ".Title":                CmpRefviewTitle,
-	cfRefView + ".Footer":               CmpRefviewFooter,
-	cfRefView + ".LocalBranchesHeader":  CmpRefviewLocalBranchesHeader,
-	cfRefView + ".RemoteBranchesHeader": CmpRefviewRemoteBranchesHeader,
-	cfRefView + ".LocalBranch":          CmpRefviewLocalBranch,
-	cfRefView + ".RemoteBranch":         CmpRefviewRemoteBranch,
-	cfRefView + ".TagsHeader":           CmpRefviewTagsHeader,
-	cfRefView + ".Tag":                  CmpRefviewTag,
-
-	cfCommitView + ".Title":        CmpCommitviewTitle,
-	cfCommitView + ".Footer":       CmpCommitviewFooter,
-	cfCommitView + ".ShortOid":     CmpCommitviewShortOid,
-	cfCommitView + ".Date":         CmpCommitviewDate,
-	cfCommitView + ".Author":       CmpCommitviewAuthor,
-	cfCommitView + ".Summary":      CmpCommitviewSummary,
-	cfCommitView + ".Tag":          CmpCommitviewTag,
-	cfCommitView + ".LocalBranch":  CmpCommitviewLocalBranch,
-	cfCommitView + ".RemoteBranch": CmpCommitviewRemoteBranch,
+	cfAllView + ".SearchMatch":           CmpAllviewSearchMatch,
+	cfAllView + ".LineNumber":            CmpAllviewLineNumber,
+	cfAllView + ".Invisible":             CmpAllviewInvisible,
+	cfAllView + ".ScrollBarThumb":        CmpAllviewScrollBarThumb,
+	cfAllView + ".ScrollBarHunk":         CmpAllviewScrollBarHunk,
+	cfAllView + ".ScrollBarSearchMatch":  CmpAllviewScrollBarSearchMatch,
+	cfAllView + ".AnsiBlack":             CmpAllviewAnsiBlack,
+	cfAllView + ".AnsiRed":               CmpAllviewAnsiRed,
+	cfAllView + ".AnsiGreen":             CmpAllviewAnsiGreen,
+	cfAllView + ".AnsiYellow":            CmpAllviewAnsiYellow,
+	cfAllView + ".AnsiBlue":              CmpAllviewAnsiBlue,
+	cfAllView + ".AnsiMagenta":           CmpAllviewAnsiMagenta,
+	cfAllView + ".AnsiCyan":              CmpAllviewAnsiCyan,
+	cfAllView + ".AnsiWhite":             CmpAllviewAnsiWhite,
+	cfAllView + ".AnsiBlackBackground":   CmpAllviewAnsiBlackBackground,
+	cfAllView + ".AnsiRedBackground":     CmpAllviewAnsiRedBackground,
+	cfAllView + ".AnsiGreenBackground":   CmpAllviewAnsiGreenBackground,
+	cfAllView + ".AnsiYellowBackground":  CmpAllviewAnsiYellowBackground,
+	cfAllView + ".AnsiBlueBackground":    CmpAllviewAnsiBlueBackground,
+	cfAllView + ".AnsiMagentaBackground": CmpAllviewAnsiMagentaBackground,
+	cfAllView + ".AnsiCyanBackground":    CmpAllviewAnsiCyanBackground,
+	cfAllView + ".AnsiWhiteBackground":   CmpAllviewAnsiWhiteBackground,
+
+	cfRefView + ".Title":                    CmpRefviewTitle,
+	cfRefView + ".Footer":                   CmpRefviewFooter,
+	cfRefView + ".LocalBranchesHeader":      CmpRefviewLocalBranchesHeader,
+	cfRefView + ".RemoteBranchesHeader":     CmpRefviewRemoteBranchesHeader,
+	cfRefView + ".LocalBranch":              CmpRefviewLocalBranch,
+	cfRefView + ".RemoteBranch":             CmpRefviewRemoteBranch,
+	cfRefView + ".TagsHeader":               CmpRefviewTagsHeader,
+	cfRefView + ".Tag":                      CmpRefviewTag,
+	cfRefView + ".StashesHeader":            CmpRefviewStashesHeader,
+	cfRefView + ".Stash":                    CmpRefviewStash,
+	cfRefView + ".UnreachableCommitsHeader": CmpRefviewUnreachableCommitsHeader,
+	cfRefView + ".UnreachableCommit":        CmpRefviewUnreachableCommit,
+	cfRefView + ".RecentBranchesHeader":     CmpRefviewRecentBranchesHeader,
+	cfRefView + ".RecentBranch":             CmpRefviewRecentBranch,
+	cfRefView + ".PinnedRefsHeader":         CmpRefviewPinnedRefsHeader,
+	cfRefView + ".PinnedRef":                CmpRefviewPinnedRef,
+	cfRefView + ".Comparison":               CmpRefviewComparison,
+
+	cfCommitView + ".Title":                CmpCommitviewTitle,
+	cfCommitView + ".Footer":               CmpCommitviewFooter,
+	cfCommitView + ".ShortOid":             CmpCommitviewShortOid,
+	cfCommitView + ".Date":                 CmpCommitviewDate,
+	cfCommitView + ".Author":               CmpCommitviewAuthor,
+	cfCommitView + ".Summary":              CmpCommitviewSummary,
+	cfCommitView + ".ConventionalType":     CmpCommitviewConventionalType,
+	cfCommitView + ".ConventionalScope":    CmpCommitviewConventionalScope,
+	cfCommitView + ".ConventionalBreaking": CmpCommitviewConventionalBreaking,
+	cfCommitView + ".Tag":                  CmpCommitviewTag,
+	cfCommitView + ".LocalBranch":          CmpCommitviewLocalBranch,
+	cfCommitView + ".RemoteBranch":         CmpCommitviewRemoteBranch,
 
 	cfDiffView + ".Normal":                CmpDiffviewDifflineNormal,
 	cfDiffView + ".CommitAuthor":          CmpDiffviewDifflineDiffCommitAuthor,
 	cfDiffView + ".CommitAuthorDate":      CmpDiffviewDifflineDiffCommitAuthorDate,
 	cfDiffView + ".CommitCommitter":       CmpDiffviewDifflineDiffCommitCommitter,
 	cfDiffView + ".CommitCommitterDate":   CmpDiffviewDifflineDiffCommitCommitterDate,
+	cfDiffView + ".CommitDescribe":        CmpDiffviewDifflineDiffCommitDescribe,
+	cfDiffView + ".CommitTrailer":         CmpDiffviewDifflineDiffCommitTrailer,
+	cfDiffView + ".CommitLint":            CmpDiffviewDifflineDiffCommitLint,
 	cfDiffView + ".CommitSummary":         CmpDiffviewDifflineDiffCommitSummary,
 	cfDiffView + ".StatsFile":             CmpDiffviewDifflineDiffStatsFile,
 	cfDiffView + ".GitDiffHeader":         CmpDiffviewDifflineGitDiffHeader,
 	cfDiffView + ".GitDiffExtendedHeader": CmpDiffviewDifflineGitDiffExtendedHeader,
+	cfDiffView + ".ModeChange":            CmpDiffviewDifflineModeChange,
+	cfDiffView + ".WhitespaceError":       CmpDiffviewDifflineWhitespaceError,
+	cfDiffView + ".Selection":             CmpDiffviewSelection,
 	cfDiffView + ".UnifiedDiffHeader":     CmpDiffviewDifflineUnifiedDiffHeader,
 	cfDiffView + ".HunkStart":             CmpDiffviewDifflineHunkStart,
 	cfDiffView + ".HunkHeader":            CmpDiffviewDifflineHunkHeader,
 	cfDiffView + ".AddedLine":             CmpDiffviewDifflineLineAdded,
 	cfDiffView + ".RemovedLine":           CmpDiffviewDifflineLineRemoved,
 
-	cfStatusBarView + ".Normal": CmpStatusbarviewNormal,
+	cfStatusBarView + ".Normal":      CmpStatusbarviewNormal,
+	cfStatusBarView + ".ProgressBar": CmpStatusbarviewProgressBar,
 
 	cfHelpBarView + ".Special": CmpHelpbarviewSpecial,
 	cfHelpBarView + ".Normal":  CmpHelpbarviewNormal,
@@ -109,6 +353,47 @@ var themeComponents = map[string]ThemeComponentID{
 	cfErrorView + ".Title":  CmpErrorViewTitle,
 	cfErrorView + ".Footer": CmpErrorViewFooter,
 	cfErrorView + ".Errors": CmpErrorViewErrors,
+
+	cfSummaryView + ".Title":  CmpSummaryviewTitle,
+	cfSummaryView + ".Label":  CmpSummaryviewLabel,
+	cfSummaryView + ".Header": CmpSummaryviewHeader,
+
+	cfFuzzyFinderView + ".Title":  CmpFuzzyfinderviewTitle,
+	cfFuzzyFinderView + ".Footer": CmpFuzzyfinderviewFooter,
+	cfFuzzyFinderView + ".Type":   CmpFuzzyfinderviewType,
+
+	cfDialogView + ".Title":   CmpDialogviewTitle,
+	cfDialogView + ".Footer":  CmpDialogviewFooter,
+	cfDialogView + ".Message": CmpDialogviewMessage,
+	cfDialogView + ".Input":   CmpDialogviewInput,
+
+	cfConfigVariablesView + ".Title":  CmpConfigvariablesviewTitle,
+	cfConfigVariablesView + ".Name":   CmpConfigvariablesviewName,
+	cfConfigVariablesView + ".Value":  CmpConfigvariablesviewValue,
+	cfConfigVariablesView + ".Source": CmpConfigvariablesviewSource,
+
+	cfLogView + ".Title":   CmpLogviewTitle,
+	cfLogView + ".Time":    CmpLogviewTime,
+	cfLogView + ".Level":   CmpLogviewLevel,
+	cfLogView + ".File":    CmpLogviewFile,
+	cfLogView + ".Message": CmpLogviewMessage,
+	cfLogView + ".Fields":  CmpLogviewFields,
+
+	cfTextView + ".Title": CmpTextviewTitle,
+	cfTextView + ".Line":  CmpTextviewLine,
+
+	cfFileView + ".Title":   CmpFileviewTitle,
+	cfFileView + ".Line":    CmpFileviewLine,
+	cfFileView + ".Keyword": CmpFileviewKeyword,
+	cfFileView + ".String":  CmpFileviewString,
+	cfFileView + ".Comment": CmpFileviewComment,
+	cfFileView + ".Number":  CmpFileviewNumber,
+	cfFileView + ".Fold":    CmpFileviewFold,
+	cfFileView + ".Blame":   CmpFileviewBlame,
+
+	cfQuickfixView + ".Title":    CmpQuickfixviewTitle,
+	cfQuickfixView + ".Location": CmpQuickfixviewLocation,
+	cfQuickfixView + ".Line":     CmpQuickfixviewLine,
 }
 
 // Config exposes a read only interface for configuration
@@ -119,7 +404,9 @@ type Config interface {
 	GetFloat(ConfigVariable) float64
 	GetTheme() Theme
 	AddOnChangeListener(ConfigVariable, ConfigVariableOnChangeListener)
+	AddOnAnyChangeListener(ConfigVariableOnChangeListener)
 	ConfigDir() string
+	Variables() []ConfigVariableValue
 }
 
 // ConfigSetter extends the config interface and exposes the ability to set config values
@@ -134,35 +421,63 @@ type ConfigVariableValidator interface {
 }
 
 // ConfigVariableOnChangeListener is notified when a config variable changes value
+// Listeners are notified on the goroutine processing the config command
 type ConfigVariableOnChangeListener interface {
 	onConfigVariableChange(ConfigVariable)
 }
 
+// ConfigVariableSource identifies where the current value of a config variable was set
+type ConfigVariableSource int
+
+// The set of config variable sources
+const (
+	CvsDefault ConfigVariableSource = iota
+	CvsGrvrc
+	CvsRuntime
+)
+
+var configVariableSourceNames = map[ConfigVariableSource]string{
+	CvsDefault: "default",
+	CvsGrvrc:   "grvrc",
+	CvsRuntime: "runtime",
+}
+
+func (source ConfigVariableSource) String() string {
+	return configVariableSourceNames[source]
+}
+
+// ConfigVariableValue describes the current value of a config variable and where it was set
+type ConfigVariableValue struct {
+	variable ConfigVariable
+	value    string
+	source   ConfigVariableSource
+}
+
 // ConfigurationVariable represents a config variable
 type ConfigurationVariable struct {
 	value             interface{}
+	source            ConfigVariableSource
 	validator         ConfigVariableValidator
 	onChangeListeners []ConfigVariableOnChangeListener
 }
 
 // Configuration contains all configuration state
 type Configuration struct {
-	variables    map[ConfigVariable]*ConfigurationVariable
-	themes       map[string]MutableTheme
-	keyBindings  KeyBindings
-	grvConfigDir string
-	channels     *Channels
+	variables            map[ConfigVariable]*ConfigurationVariable
+	onAnyChangeListeners []ConfigVariableOnChangeListener
+	themes               map[string]MutableTheme
+	keyBindings          KeyBindings
+	grvConfigDir         string
+	grvrcMissing         bool
+	channels             *Channels
 }
 
 // NewConfiguration creates a Configuration instance with default values
 func NewConfiguration(keyBindings KeyBindings, channels *Channels) *Configuration {
 	config := &Configuration{
 		keyBindings: keyBindings,
-		themes: map[string]MutableTheme{
-			cfThemeDefaultValue: NewDefaultTheme(),
-			cfColdThemeName:     NewColdTheme(),
-		},
-		channels: channels,
+		themes:      NewBuiltinThemes(),
+		channels:    channels,
 	}
 
 	config.variables = map[ConfigVariable]*ConfigurationVariable{
@@ -176,8 +491,203 @@ func NewConfiguration(keyBindings KeyBindings, channels *Channels) *Configuratio
 				config: config,
 			},
 		},
+		CfDiffRenames: {
+			value:     cfDiffRenamesDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfDiffCopies: {
+			value:     cfDiffCopiesDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfDiffSimilarityThreshold: {
+			value:     cfDiffSimilarityThresholdDefaultValue,
+			validator: similarityThresholdValidator{},
+		},
+		CfDiffContext: {
+			value:     cfDiffContextDefaultValue,
+			validator: diffContextValidator{},
+		},
+		CfDiffExclude: {
+			value:     cfDiffExcludeDefaultValue,
+			validator: diffExcludeValidator{},
+		},
+		CfDiffTextconv: {
+			value:     cfDiffTextconvDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfWhitespaceHighlight: {
+			value:     cfWhitespaceHighlightDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfInvisibles: {
+			value:     cfInvisiblesDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfPager: {
+			value: cfPagerDefaultValue,
+		},
+		CfDiffRenderer: {
+			value: cfDiffRendererDefaultValue,
+		},
+		CfGraphics: {
+			value:     cfGraphicsDefaultValue,
+			validator: graphicsValidator{},
+		},
+		CfHTTPProxy: {
+			value: cfHTTPProxyDefaultValue,
+		},
+		CfSSLCAInfo: {
+			value: cfSSLCAInfoDefaultValue,
+		},
+		CfFetchInterval: {
+			value:     cfFetchIntervalDefaultValue,
+			validator: fetchIntervalValidator{},
+		},
+		CfNotifyFetchCommand: {
+			value: cfNotifyFetchCommandDefaultValue,
+		},
+		CfNotifyDiffCommand: {
+			value: cfNotifyDiffCommandDefaultValue,
+		},
+		CfNotifyCommitCommand: {
+			value: cfNotifyCommitCommandDefaultValue,
+		},
+		CfYankCommand: {
+			value: cfYankCommandDefaultValue,
+		},
+		CfStatusline: {
+			value: cfStatuslineDefaultValue,
+		},
+		CfRefViewFooter: {
+			value: cfRefViewFooterDefaultValue,
+		},
+		CfCommitViewFooter: {
+			value: cfCommitViewFooterDefaultValue,
+		},
+		CfDiffViewFooter: {
+			value: cfDiffViewFooterDefaultValue,
+		},
+		CfNumber: {
+			value:     cfNumberDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfRelativeNumber: {
+			value:     cfRelativeNumberDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfScrollOff: {
+			value:     cfScrollOffDefaultValue,
+			validator: scrollOffValidator{},
+		},
+		CfScrollBar: {
+			value:     cfScrollBarDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfDiffStickyFileHeader: {
+			value:     cfDiffStickyFileHeaderDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfDiffStickyHunkHeader: {
+			value:     cfDiffStickyHunkHeaderDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfCommitViewColumns: {
+			value:     cfCommitViewColumnsDefaultValue,
+			validator: commitViewColumnsValidator{},
+		},
+		CfCommitIdentity: {
+			value: cfCommitIdentityDefaultValue,
+			validator: stringSetValidator{
+				values: []string{IdentityAuthor, IdentityCommitter},
+			},
+		},
+		CfCommitIdentityFormat: {
+			value: cfCommitIdentityFormatDefaultValue,
+			validator: stringSetValidator{
+				values: []string{IdentityFormatName, IdentityFormatEmail, IdentityFormatBoth},
+			},
+		},
+		CfAbbrevLength: {
+			value:     cfAbbrevLengthDefaultValue,
+			validator: abbrevLengthValidator{},
+		},
+		CfReadOnly: {
+			value:     cfReadOnlyDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfLayout: {
+			value:     cfLayoutDefaultValue,
+			validator: layoutValidator{},
+		},
+		CfKeymap: {
+			value: cfKeymapDefaultValue,
+			validator: stringSetValidator{
+				values: []string{KeymapVim, KeymapEmacs},
+			},
+		},
+		CfProtectedRefs: {
+			value:     cfProtectedRefsDefaultValue,
+			validator: protectedRefsValidator{},
+		},
+		CfCheckoutTrackingBranch: {
+			value:     cfCheckoutTrackingBranchDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfRecentBranches: {
+			value:     cfRecentBranchesDefaultValue,
+			validator: recentBranchesValidator{},
+		},
+		CfDiffIdentityFormat: {
+			value: cfDiffIdentityFormatDefaultValue,
+			validator: stringSetValidator{
+				values: []string{IdentityFormatName, IdentityFormatEmail, IdentityFormatBoth},
+			},
+		},
+		CfLogLevel: {
+			value:     cfLogLevelDefaultValue,
+			validator: logLevelValidator{},
+		},
+		CfDebugOverlay: {
+			value:     cfDebugOverlayDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfMemoryBudget: {
+			value:     cfMemoryBudgetDefaultValue,
+			validator: memoryBudgetValidator{},
+		},
+		CfScreenReader: {
+			value:     cfScreenReaderDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfBorderChars: {
+			value:     cfBorderCharsDefaultValue,
+			validator: borderCharsValidator{},
+		},
+		CfCommitOrder: {
+			value:     cfCommitOrderDefaultValue,
+			validator: commitOrderValidator{},
+		},
+		CfMessagePreview: {
+			value:     cfMessagePreviewDefaultValue,
+			validator: booleanValidator{},
+		},
+		CfCommitLint: {
+			value:     cfCommitLintDefaultValue,
+			validator: commitLintValidator{},
+		},
+		CfCommitLintSubjectLength: {
+			value:     cfCommitLintSubjectLengthDefaultValue,
+			validator: commitLintSubjectLengthValidator{},
+		},
+		CfRepoPreferences: {
+			value:     cfRepoPreferencesDefaultValue,
+			validator: booleanValidator{},
+		},
 	}
 
+	config.AddOnChangeListener(CfKeymap, config)
+	config.AddOnChangeListener(CfLogLevel, config)
+
 	return config
 }
 
@@ -213,6 +723,7 @@ func (config *Configuration) Initialise() []error {
 
 	if _, err := os.Stat(grvConfig); os.IsNotExist(err) {
 		log.Infof("No config file found at: %v", grvConfig)
+		config.grvrcMissing = true
 		return nil
 	}
 
@@ -230,6 +741,31 @@ func (config *Configuration) ConfigDir() string {
 	return config.grvConfigDir
 }
 
+// GrvrcPath returns the path of the grvrc file or an empty string if the config directory is unknown
+func (config *Configuration) GrvrcPath() string {
+	if config.grvConfigDir == "" {
+		return ""
+	}
+
+	return config.grvConfigDir + cfGrvrcFile
+}
+
+// GrvrcMissing returns true if no grvrc file existed when the configuration was initialised
+func (config *Configuration) GrvrcMissing() bool {
+	return config.grvrcMissing
+}
+
+// ThemeNames returns the names of all defined themes in alphabetical order
+func (config *Configuration) ThemeNames() (themeNames []string) {
+	for themeName := range config.themes {
+		themeNames = append(themeNames, themeName)
+	}
+
+	sort.Strings(themeNames)
+
+	return
+}
+
 // LoadFile loads the configuration file at by the provided file path
 func (config *Configuration) LoadFile(filePath string) []error {
 	file, err := os.Open(filePath)
@@ -289,6 +825,26 @@ func (config *Configuration) processCommand(command ConfigCommand, inputSource s
 		err = config.processMapCommand(command, inputSource)
 	case *QuitCommand:
 		err = config.processQuitCommand()
+	case *QuickfixCommand:
+		err = config.processQuickfixCommand(command)
+	case *DiffCommand:
+		err = config.processDiffCommand(command)
+	case *CloneCommand:
+		err = config.processCloneCommand(command)
+	case *WorktreeCommand:
+		err = config.processWorktreeCommand(command)
+	case *SetQueryCommand:
+		err = config.processSetQueryCommand(command, inputSource)
+	case *SetAllCommand:
+		err = config.processSetAllCommand()
+	case *GotoCommand:
+		err = config.processGotoCommand(command)
+	case *LogCommand:
+		err = config.processLogCommand(command)
+	case *ProfileCommand:
+		err = config.processProfileCommand(command)
+	case *ChangelogCommand:
+		err = config.processChangelogCommand(command)
 	default:
 		log.Errorf("Unknown command type %T", command)
 	}
@@ -297,7 +853,7 @@ func (config *Configuration) processCommand(command ConfigCommand, inputSource s
 }
 
 func (config *Configuration) processSetCommand(setCommand *SetCommand, inputSource string) error {
-	configVariable := ConfigVariable(setCommand.variable.value)
+	configVariable := resolveConfigVariable(setCommand.variable.value)
 	variable, ok := config.variables[configVariable]
 	if !ok {
 		return generateConfigError(inputSource, setCommand.variable, "Invalid variable %v", setCommand.variable.value)
@@ -324,17 +880,120 @@ func (config *Configuration) processSetCommand(setCommand *SetCommand, inputSour
 
 	log.Infof("Setting %v = %v", configVariable, value)
 	variable.value = value
+	variable.source = configVariableSource(inputSource)
+
+	config.notifyOnChangeListeners(configVariable)
 
-	if len(variable.onChangeListeners) > 0 {
+	return nil
+}
+
+// notifyOnChangeListeners notifies the listeners of the config variable and then
+// the listeners registered for all config variables
+func (config *Configuration) notifyOnChangeListeners(configVariable ConfigVariable) {
+	variable := config.getVariable(configVariable)
+
+	if len(variable.onChangeListeners) > 0 || len(config.onAnyChangeListeners) > 0 {
 		log.Debugf("Firing on change listeners for config variable %v", configVariable)
-		for _, listener := range variable.onChangeListeners {
-			listener.onConfigVariableChange(configVariable)
-		}
 	}
 
+	for _, listener := range variable.onChangeListeners {
+		listener.onConfigVariableChange(configVariable)
+	}
+
+	for _, listener := range config.onAnyChangeListeners {
+		listener.onConfigVariableChange(configVariable)
+	}
+}
+
+// configVariableSource determines the source of values set by commands from the provided input source
+// Commands entered at the prompt have no input source
+// resolveConfigVariable returns the config variable with the provided name
+// Previous names of renamed variables are resolved to the current name
+func resolveConfigVariable(name string) ConfigVariable {
+	if configVariable, ok := renamedConfigVariables[name]; ok {
+		return configVariable
+	}
+
+	return ConfigVariable(name)
+}
+
+func configVariableSource(inputSource string) ConfigVariableSource {
+	if inputSource == "" {
+		return CvsRuntime
+	}
+
+	return CvsGrvrc
+}
+
+func (config *Configuration) processSetQueryCommand(setQueryCommand *SetQueryCommand, inputSource string) error {
+	configVariable := resolveConfigVariable(setQueryCommand.variable.value)
+	variable, ok := config.variables[configVariable]
+	if !ok {
+		return generateConfigError(inputSource, setQueryCommand.variable, "Invalid variable %v", setQueryCommand.variable.value)
+	}
+
+	config.channels.ReportStatus("%v = %v (%v)", configVariable, configValueString(variable.value), variable.source)
+
 	return nil
 }
 
+func (config *Configuration) processSetAllCommand() (err error) {
+	log.Info("Processed set all command")
+	config.channels.DoAction(Action{ActionType: ActionShowConfigVariables})
+	return
+}
+
+// Variables returns the current value and source of every config variable ordered by name
+func (config *Configuration) Variables() (values []ConfigVariableValue) {
+	for configVariable, variable := range config.variables {
+		values = append(values, ConfigVariableValue{
+			variable: configVariable,
+			value:    configValueString(variable.value),
+			source:   variable.source,
+		})
+	}
+
+	slice.Sort(values, func(i, j int) bool {
+		return strings.ToLower(string(values[i].variable)) < strings.ToLower(string(values[j].variable))
+	})
+
+	return
+}
+
+// configValueString formats a config variable value in the form it would be entered in a set command
+// String values which would not be read back unchanged as a single word are quoted
+func configValueString(value interface{}) string {
+	str, isString := value.(string)
+	if !isString {
+		return fmt.Sprintf("%v", value)
+	}
+
+	if str != "" && !strings.ContainsAny(str, " \t\n\"\\$#") && !strings.HasPrefix(str, "~") && !strings.HasPrefix(str, "-") {
+		return str
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteRune('"')
+
+	for _, char := range str {
+		switch char {
+		case '\n':
+			buffer.WriteString("\\n")
+		case '\t':
+			buffer.WriteString("\\t")
+		case '"', '\\', '$', '~':
+			buffer.WriteRune('\\')
+			buffer.WriteRune(char)
+		default:
+			buffer.WriteRune(char)
+		}
+	}
+
+	buffer.WriteRune('"')
+
+	return buffer.String()
+}
+
 func (config *Configuration) processThemeCommand(themeCommand *ThemeCommand, inputSource string) (err error) {
 	themeComponentID, componentIDExists := themeComponents[themeCommand.component.value]
 
@@ -355,6 +1014,7 @@ func (config *Configuration) processThemeCommand(themeCommand *ThemeCommand, inp
 
 	if !themeExists {
 		theme = NewTheme()
+		addANSIThemeComponents(theme)
 		config.themes[themeCommand.name.value] = theme
 	}
 
@@ -366,6 +1026,12 @@ func (config *Configuration) processThemeCommand(themeCommand *ThemeCommand, inp
 	themeComponent.bgcolor = bgThemeColor
 	themeComponent.fgcolor = fgThemeColor
 
+	// Listeners are notified as though the theme variable was set so that
+	// changes to the active theme are displayed immediately
+	if themeCommand.name.value == config.GetString(CfTheme) {
+		config.notifyOnChangeListeners(CfTheme)
+	}
+
 	return
 }
 
@@ -412,12 +1078,150 @@ func (config *Configuration) processQuitCommand() (err error) {
 	return
 }
 
+var quickfixCommandActions = map[string]ActionType{
+	QuickfixOpen:  ActionOpenQuickfix,
+	QuickfixClose: ActionCloseQuickfix,
+	QuickfixNext:  ActionQuickfixNext,
+	QuickfixPrev:  ActionQuickfixPrev,
+}
+
+func (config *Configuration) processQuickfixCommand(quickfixCommand *QuickfixCommand) (err error) {
+	log.Infof("Processed %v command", quickfixCommand.operation)
+	config.channels.DoAction(Action{ActionType: quickfixCommandActions[quickfixCommand.operation]})
+	return
+}
+
+func (config *Configuration) processGotoCommand(gotoCommand *GotoCommand) (err error) {
+	log.Infof("Processed goto command for revision %v", gotoCommand.revision.value)
+	config.channels.DoAction(Action{
+		ActionType: ActionGotoCommit,
+		Args:       []interface{}{gotoCommand.revision.value},
+	})
+	return
+}
+
+func (config *Configuration) processLogCommand(logCommand *LogCommand) (err error) {
+	if len(logCommand.revisions) == 0 {
+		log.Info("Processed log command")
+		config.channels.DoAction(Action{ActionType: ActionShowLog})
+		return
+	}
+
+	var revisions []interface{}
+	for _, revision := range logCommand.revisions {
+		revisions = append(revisions, revision.value)
+	}
+
+	log.Infof("Processed log command for revisions %v", revisions)
+	config.channels.DoAction(Action{
+		ActionType: ActionLogRevisions,
+		Args:       revisions,
+	})
+	return
+}
+
+func (config *Configuration) processProfileCommand(profileCommand *ProfileCommand) (err error) {
+	log.Infof("Processed profile %v command", profileCommand.operation.value)
+
+	if profileCommand.operation.value == ProfileStop {
+		var filePath string
+		if filePath, err = cpuProfiler.Stop(); err == nil {
+			config.channels.ReportStatus("CPU profile written to %v", filePath)
+		}
+
+		return
+	}
+
+	filePath := pfCPUProfileDefaultPath
+	if profileCommand.file != nil {
+		filePath = profileCommand.file.value
+	}
+
+	if err = cpuProfiler.Start(filePath); err == nil {
+		config.channels.ReportStatus("CPU profiling started. Run \"profile stop\" to write %v", filePath)
+	}
+
+	return
+}
+
+func (config *Configuration) processDiffCommand(diffCommand *DiffCommand) (err error) {
+	log.Infof("Processed diff command for %v and %v", diffCommand.fromRevision.value, diffCommand.toRevision.value)
+	config.channels.DoAction(Action{
+		ActionType: ActionDiffRevisions,
+		Args:       []interface{}{diffCommand.fromRevision.value, diffCommand.toRevision.value},
+	})
+	return
+}
+
+func (config *Configuration) processChangelogCommand(changelogCommand *ChangelogCommand) (err error) {
+	groupBy := ChangelogGroupByType
+	if changelogCommand.groupBy != nil {
+		groupBy = changelogCommand.groupBy.value
+	}
+
+	var file string
+	if changelogCommand.file != nil {
+		file = changelogCommand.file.value
+	}
+
+	log.Infof("Processed changelog command for %v and %v", changelogCommand.fromRevision.value, changelogCommand.toRevision.value)
+	config.channels.DoAction(Action{
+		ActionType: ActionChangelog,
+		Args:       []interface{}{changelogCommand.fromRevision.value, changelogCommand.toRevision.value, groupBy, file},
+	})
+	return
+}
+
+func (config *Configuration) processCloneCommand(cloneCommand *CloneCommand) (err error) {
+	var directory string
+	if cloneCommand.directory != nil {
+		directory = cloneCommand.directory.value
+	}
+
+	log.Infof("Processed clone command for %v", cloneCommand.url.value)
+	config.channels.DoAction(Action{
+		ActionType: ActionClone,
+		Args:       []interface{}{cloneCommand.url.value, directory},
+	})
+	return
+}
+
+func (config *Configuration) processWorktreeCommand(worktreeCommand *WorktreeCommand) (err error) {
+	log.Infof("Processed worktree command for %v", worktreeCommand.worktree.value)
+	config.channels.DoAction(Action{
+		ActionType: ActionOpenWorktree,
+		Args:       []interface{}{worktreeCommand.worktree.value},
+	})
+	return
+}
+
 // AddOnChangeListener adds a listener to be notified when a configuration variable changes value
 func (config *Configuration) AddOnChangeListener(configVariable ConfigVariable, listener ConfigVariableOnChangeListener) {
 	variable := config.getVariable(configVariable)
 	variable.onChangeListeners = append(variable.onChangeListeners, listener)
 }
 
+// onConfigVariableChange applies the selected keymap to the key bindings and the selected log level to logging
+func (config *Configuration) onConfigVariableChange(configVariable ConfigVariable) {
+	var err error
+
+	switch configVariable {
+	case CfKeymap:
+		err = config.keyBindings.SetKeymap(config.GetString(CfKeymap))
+	case CfLogLevel:
+		err = SetLogLevel(config.GetString(CfLogLevel))
+	}
+
+	if err != nil {
+		config.channels.ReportError(err)
+	}
+}
+
+// AddOnAnyChangeListener adds a listener to be notified when any configuration variable changes value
+func (config *Configuration) AddOnAnyChangeListener(listener ConfigVariableOnChangeListener) {
+	config.onAnyChangeListeners = append(config.onAnyChangeListeners, listener)
+}
+
 // GetBool returns the boolean value of the specified configuration variable
 func (config *Configuration) GetBool(configVariable ConfigVariable) bool {
 	switch value := config.getVariable(configVariable).value.(type) {
@@ -499,3 +1303,243 @@ func (themeValidator themeValidator) validate(value string) (processedValue inte
 
 	return
 }
+
+type stringSetValidator struct {
+	values []string
+}
+
+func (stringSetValidator stringSetValidator) validate(value string) (processedValue interface{}, err error) {
+	for _, validValue := range stringSetValidator.values {
+		if value == validValue {
+			return value, nil
+		}
+	}
+
+	return nil, fmt.Errorf("Expected one of %v but received %v", strings.Join(stringSetValidator.values, ", "), value)
+}
+
+type booleanValidator struct{}
+
+func (booleanValidator booleanValidator) validate(value string) (processedValue interface{}, err error) {
+	if processedValue, err = strconv.ParseBool(value); err != nil {
+		err = fmt.Errorf("Expected boolean value (true or false) but received %v", value)
+	}
+
+	return
+}
+
+type commitViewColumnsValidator struct{}
+
+func (commitViewColumnsValidator commitViewColumnsValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = parseCommitViewColumns(value); err == nil {
+		processedValue = value
+	}
+
+	return
+}
+
+type protectedRefsValidator struct{}
+
+func (protectedRefsValidator protectedRefsValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = parseProtectedRefPatterns(value); err == nil {
+		processedValue = value
+	}
+
+	return
+}
+
+type commitLintValidator struct{}
+
+func (commitLintValidator commitLintValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = parseCommitLintRules(value); err == nil {
+		processedValue = value
+	}
+
+	return
+}
+
+type commitLintSubjectLengthValidator struct{}
+
+func (commitLintSubjectLengthValidator commitLintSubjectLengthValidator) validate(value string) (processedValue interface{}, err error) {
+	var subjectLength int
+
+	if subjectLength, err = strconv.Atoi(value); err != nil || subjectLength < 1 {
+		err = fmt.Errorf("%v must be an integer value greater than 0", CfCommitLintSubjectLength)
+	} else {
+		processedValue = subjectLength
+	}
+
+	return
+}
+
+type abbrevLengthValidator struct{}
+
+func (abbrevLengthValidator abbrevLengthValidator) validate(value string) (processedValue interface{}, err error) {
+	if value == AbbrevLengthAuto {
+		return value, nil
+	}
+
+	if abbrevLength, err := strconv.Atoi(value); err != nil || abbrevLength < cfMinAbbrevLength || abbrevLength > cfMaxAbbrevLength {
+		return nil, fmt.Errorf("%v must be %v or an integer value between %v and %v", CfAbbrevLength, AbbrevLengthAuto, cfMinAbbrevLength, cfMaxAbbrevLength)
+	}
+
+	return value, nil
+}
+
+type logLevelValidator struct{}
+
+func (logLevelValidator logLevelValidator) validate(value string) (processedValue interface{}, err error) {
+	if !IsValidLogLevel(value) {
+		return nil, fmt.Errorf("%v must be one of none, panic, fatal, error, warn, info or debug", CfLogLevel)
+	}
+
+	return strings.ToLower(value), nil
+}
+
+type layoutValidator struct{}
+
+func (layoutValidator layoutValidator) validate(value string) (processedValue interface{}, err error) {
+	if value != LayoutStacked && value != LayoutColumns {
+		return nil, fmt.Errorf("%v must be %v or %v", CfLayout, LayoutStacked, LayoutColumns)
+	}
+
+	return value, nil
+}
+
+type borderCharsValidator struct{}
+
+func (borderCharsValidator borderCharsValidator) validate(value string) (processedValue interface{}, err error) {
+	if value != BorderCharsUnicode && value != BorderCharsASCII {
+		return nil, fmt.Errorf("%v must be %v or %v", CfBorderChars, BorderCharsUnicode, BorderCharsASCII)
+	}
+
+	return value, nil
+}
+
+type commitOrderValidator struct{}
+
+func (commitOrderValidator commitOrderValidator) validate(value string) (processedValue interface{}, err error) {
+	for _, commitOrder := range commitOrders {
+		if value == commitOrder {
+			return value, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%v must be one of %v", CfCommitOrder, strings.Join(commitOrders, ", "))
+}
+
+// nextCommitOrder returns the commit order which follows the provided commit order when cycling through them
+func nextCommitOrder(commitOrder string) string {
+	for commitOrderIndex, order := range commitOrders {
+		if order == commitOrder {
+			return commitOrders[(commitOrderIndex+1)%len(commitOrders)]
+		}
+	}
+
+	return commitOrders[0]
+}
+
+type diffContextValidator struct{}
+
+func (diffContextValidator diffContextValidator) validate(value string) (processedValue interface{}, err error) {
+	var diffContext int
+
+	if diffContext, err = strconv.Atoi(value); err != nil || diffContext < 0 {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", CfDiffContext)
+	} else {
+		processedValue = diffContext
+	}
+
+	return
+}
+
+type diffExcludeValidator struct{}
+
+func (diffExcludeValidator diffExcludeValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, err = parseDiffExcludePatterns(value); err == nil {
+		processedValue = value
+	}
+
+	return
+}
+
+type fetchIntervalValidator struct{}
+
+func (fetchIntervalValidator fetchIntervalValidator) validate(value string) (processedValue interface{}, err error) {
+	var fetchInterval int
+
+	if fetchInterval, err = strconv.Atoi(value); err != nil || fetchInterval < 0 {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", CfFetchInterval)
+	} else {
+		processedValue = fetchInterval
+	}
+
+	return
+}
+
+type memoryBudgetValidator struct{}
+
+func (memoryBudgetValidator memoryBudgetValidator) validate(value string) (processedValue interface{}, err error) {
+	var memoryBudget int
+
+	if memoryBudget, err = strconv.Atoi(value); err != nil || memoryBudget < 0 {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", CfMemoryBudget)
+	} else {
+		processedValue = memoryBudget
+	}
+
+	return
+}
+
+type scrollOffValidator struct{}
+
+func (scrollOffValidator scrollOffValidator) validate(value string) (processedValue interface{}, err error) {
+	var scrollOff int
+
+	if scrollOff, err = strconv.Atoi(value); err != nil || scrollOff < 0 {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", CfScrollOff)
+	} else {
+		processedValue = scrollOff
+	}
+
+	return
+}
+
+type recentBranchesValidator struct{}
+
+func (recentBranchesValidator recentBranchesValidator) validate(value string) (processedValue interface{}, err error) {
+	var recentBranches int
+
+	if recentBranches, err = strconv.Atoi(value); err != nil || recentBranches < 0 {
+		err = fmt.Errorf("%v must be an integer value greater than or equal to 0", CfRecentBranches)
+	} else {
+		processedValue = recentBranches
+	}
+
+	return
+}
+
+type similarityThresholdValidator struct{}
+
+func (similarityThresholdValidator similarityThresholdValidator) validate(value string) (processedValue interface{}, err error) {
+	var threshold int
+
+	if threshold, err = strconv.Atoi(value); err != nil || threshold < cfDiffSimilarityThresholdMinValue || threshold > cfDiffSimilarityThresholdMaxValue {
+		err = fmt.Errorf("%v must be an integer value between %v and %v", CfDiffSimilarityThreshold,
+			cfDiffSimilarityThresholdMinValue, cfDiffSimilarityThresholdMaxValue)
+	} else {
+		processedValue = threshold
+	}
+
+	return
+}
+
+type graphicsValidator struct{}
+
+func (graphicsValidator graphicsValidator) validate(value string) (processedValue interface{}, err error) {
+	if _, ok := graphicsProtocolNames[value]; ok || value == cfGraphicsDefaultValue {
+		return value, nil
+	}
+
+	return nil, fmt.Errorf("%v must be one of %v, kitty, sixel, none", CfGraphics, cfGraphicsDefaultValue)
+}
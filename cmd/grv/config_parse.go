@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 )
 
 type commandConstructor func(*ConfigParser, []*ConfigToken) (ConfigCommand, error)
@@ -89,14 +90,210 @@ func (quitCommand *QuitCommand) Equal(command ConfigCommand) bool {
 	return ok
 }
 
+// DiffCommand contains state for displaying a diff between two revisions
+type DiffCommand struct {
+	fromRevision *ConfigToken
+	toRevision   *ConfigToken
+}
+
+// Equal returns true if the provided command is equal
+func (diffCommand *DiffCommand) Equal(command ConfigCommand) bool {
+	other, ok := command.(*DiffCommand)
+	if !ok {
+		return false
+	}
+
+	return ((diffCommand.fromRevision != nil && diffCommand.fromRevision.Equal(other.fromRevision)) ||
+		(diffCommand.fromRevision == nil && other.fromRevision == nil)) &&
+		((diffCommand.toRevision != nil && diffCommand.toRevision.Equal(other.toRevision)) ||
+			(diffCommand.toRevision == nil && other.toRevision == nil))
+}
+
+// CloneCommand contains state for cloning a repository
+// directory is nil if no directory was specified
+type CloneCommand struct {
+	url       *ConfigToken
+	directory *ConfigToken
+}
+
+// Equal returns true if the provided command is equal
+func (cloneCommand *CloneCommand) Equal(command ConfigCommand) bool {
+	other, ok := command.(*CloneCommand)
+	if !ok {
+		return false
+	}
+
+	return ((cloneCommand.url != nil && cloneCommand.url.Equal(other.url)) ||
+		(cloneCommand.url == nil && other.url == nil)) &&
+		((cloneCommand.directory != nil && cloneCommand.directory.Equal(other.directory)) ||
+			(cloneCommand.directory == nil && other.directory == nil))
+}
+
+// WorktreeCommand contains state for opening a worktree
+type WorktreeCommand struct {
+	worktree *ConfigToken
+}
+
+// Equal returns true if the provided command is equal
+func (worktreeCommand *WorktreeCommand) Equal(command ConfigCommand) bool {
+	other, ok := command.(*WorktreeCommand)
+	if !ok {
+		return false
+	}
+
+	return (worktreeCommand.worktree != nil && worktreeCommand.worktree.Equal(other.worktree)) ||
+		(worktreeCommand.worktree == nil && other.worktree == nil)
+}
+
+// GotoCommand contains state for selecting the commit a revision resolves to
+type GotoCommand struct {
+	revision *ConfigToken
+}
+
+// Equal returns true if the provided command is equal
+func (gotoCommand *GotoCommand) Equal(command ConfigCommand) bool {
+	other, ok := command.(*GotoCommand)
+	if !ok {
+		return false
+	}
+
+	return (gotoCommand.revision != nil && gotoCommand.revision.Equal(other.revision)) ||
+		(gotoCommand.revision == nil && other.revision == nil)
+}
+
+// SetQueryCommand contains state for displaying the value of a config variable
+type SetQueryCommand struct {
+	variable *ConfigToken
+}
+
+// Equal returns true if the provided command is equal
+func (setQueryCommand *SetQueryCommand) Equal(command ConfigCommand) bool {
+	other, ok := command.(*SetQueryCommand)
+	if !ok {
+		return false
+	}
+
+	return (setQueryCommand.variable != nil && setQueryCommand.variable.Equal(other.variable)) ||
+		(setQueryCommand.variable == nil && other.variable == nil)
+}
+
+// SetAllCommand represents the command to display all config variables
+type SetAllCommand struct{}
+
+// Equal returns true if the provided command is equal
+func (setAllCommand *SetAllCommand) Equal(command ConfigCommand) bool {
+	_, ok := command.(*SetAllCommand)
+	return ok
+}
+
+// LogCommand represents the command to display recent log entries
+// If revisions are provided then the history of the revisions is displayed instead
+type LogCommand struct {
+	revisions []*ConfigToken
+}
+
+// Equal returns true if the provided command is equal
+func (logCommand *LogCommand) Equal(command ConfigCommand) bool {
+	other, ok := command.(*LogCommand)
+	if !ok || len(logCommand.revisions) != len(other.revisions) {
+		return false
+	}
+
+	for revisionIndex, revision := range logCommand.revisions {
+		if !revision.Equal(other.revisions[revisionIndex]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// The set of profile command operations
+const (
+	ProfileStart = "start"
+	ProfileStop  = "stop"
+)
+
+// ProfileCommand contains state for starting or stopping CPU profiling
+// file is nil if no file was specified
+type ProfileCommand struct {
+	operation *ConfigToken
+	file      *ConfigToken
+}
+
+// Equal returns true if the provided command is equal
+func (profileCommand *ProfileCommand) Equal(command ConfigCommand) bool {
+	other, ok := command.(*ProfileCommand)
+	if !ok {
+		return false
+	}
+
+	return ((profileCommand.operation != nil && profileCommand.operation.Equal(other.operation)) ||
+		(profileCommand.operation == nil && other.operation == nil)) &&
+		((profileCommand.file != nil && profileCommand.file.Equal(other.file)) ||
+			(profileCommand.file == nil && other.file == nil))
+}
+
+// ChangelogCommand contains state for generating a changelog between two revisions
+// groupBy and file are nil if they were not specified
+type ChangelogCommand struct {
+	fromRevision *ConfigToken
+	toRevision   *ConfigToken
+	groupBy      *ConfigToken
+	file         *ConfigToken
+}
+
+// Equal returns true if the provided command is equal
+func (changelogCommand *ChangelogCommand) Equal(command ConfigCommand) bool {
+	other, ok := command.(*ChangelogCommand)
+	if !ok {
+		return false
+	}
+
+	return ((changelogCommand.fromRevision != nil && changelogCommand.fromRevision.Equal(other.fromRevision)) ||
+		(changelogCommand.fromRevision == nil && other.fromRevision == nil)) &&
+		((changelogCommand.toRevision != nil && changelogCommand.toRevision.Equal(other.toRevision)) ||
+			(changelogCommand.toRevision == nil && other.toRevision == nil)) &&
+		((changelogCommand.groupBy != nil && changelogCommand.groupBy.Equal(other.groupBy)) ||
+			(changelogCommand.groupBy == nil && other.groupBy == nil)) &&
+		((changelogCommand.file != nil && changelogCommand.file.Equal(other.file)) ||
+			(changelogCommand.file == nil && other.file == nil))
+}
+
+// The set of quickfix command operations
+const (
+	QuickfixOpen  = "copen"
+	QuickfixClose = "cclose"
+	QuickfixNext  = "cnext"
+	QuickfixPrev  = "cprev"
+)
+
+// QuickfixCommand represents a command which opens, closes or moves through the quickfix list
+type QuickfixCommand struct {
+	operation string
+}
+
+// Equal returns true if the provided command is equal
+func (quickfixCommand *QuickfixCommand) Equal(command ConfigCommand) bool {
+	other, ok := command.(*QuickfixCommand)
+	return ok && quickfixCommand.operation == other.operation
+}
+
 type commandDescriptor struct {
-	tokenTypes  []ConfigTokenType
+	tokenTypes         []ConfigTokenType
+	optionalTokenTypes []ConfigTokenType
+	// repeatOptionalTokens allows the last optional token type to be repeated until the end of the command
+	repeatOptionalTokens bool
+	// isComplete reports whether the tokens read so far form a complete command
+	// before all token types have been read. It is nil for commands with no shorter form
+	isComplete  func(tokens []*ConfigToken) bool
 	constructor commandConstructor
 }
 
 var commandDescriptors = map[string]*commandDescriptor{
 	"set": {
 		tokenTypes:  []ConfigTokenType{CtkWord, CtkWord},
+		isComplete:  isSetQueryCommand,
 		constructor: setCommandConstructor,
 	},
 	"theme": {
@@ -111,6 +308,55 @@ var commandDescriptors = map[string]*commandDescriptor{
 		tokenTypes:  []ConfigTokenType{},
 		constructor: quitCommandConstructor,
 	},
+	"diff": {
+		tokenTypes:  []ConfigTokenType{CtkWord, CtkWord},
+		constructor: diffCommandConstructor,
+	},
+	"clone": {
+		tokenTypes:         []ConfigTokenType{CtkWord},
+		optionalTokenTypes: []ConfigTokenType{CtkWord},
+		constructor:        cloneCommandConstructor,
+	},
+	"worktree": {
+		tokenTypes:  []ConfigTokenType{CtkWord},
+		constructor: worktreeCommandConstructor,
+	},
+	"goto": {
+		tokenTypes:  []ConfigTokenType{CtkWord},
+		constructor: gotoCommandConstructor,
+	},
+	"log": {
+		tokenTypes:           []ConfigTokenType{},
+		optionalTokenTypes:   []ConfigTokenType{CtkWord},
+		repeatOptionalTokens: true,
+		constructor:          logCommandConstructor,
+	},
+	"profile": {
+		tokenTypes:         []ConfigTokenType{CtkWord},
+		optionalTokenTypes: []ConfigTokenType{CtkWord},
+		constructor:        profileCommandConstructor,
+	},
+	"changelog": {
+		tokenTypes:         []ConfigTokenType{CtkWord, CtkWord},
+		optionalTokenTypes: []ConfigTokenType{CtkWord, CtkWord},
+		constructor:        changelogCommandConstructor,
+	},
+	QuickfixOpen: {
+		tokenTypes:  []ConfigTokenType{},
+		constructor: quickfixCommandConstructor(QuickfixOpen),
+	},
+	QuickfixClose: {
+		tokenTypes:  []ConfigTokenType{},
+		constructor: quickfixCommandConstructor(QuickfixClose),
+	},
+	QuickfixNext: {
+		tokenTypes:  []ConfigTokenType{},
+		constructor: quickfixCommandConstructor(QuickfixNext),
+	},
+	QuickfixPrev: {
+		tokenTypes:  []ConfigTokenType{},
+		constructor: quickfixCommandConstructor(QuickfixPrev),
+	},
 }
 
 // ConfigParser is a component capable of parsing config into commands
@@ -248,6 +494,40 @@ func (parser *ConfigParser) parseCommand(token *ConfigToken) (command ConfigComm
 		}
 
 		tokens = append(tokens, token)
+
+		if commandDescriptor.isComplete != nil && commandDescriptor.isComplete(tokens) {
+			break
+		}
+	}
+
+	// Optional tokens are read until the end of the command. EOF is left to be
+	// returned by the next call to Parse so the command is not discarded
+	optionalTokenTypes := commandDescriptor.optionalTokenTypes
+
+OptionalTokenLoop:
+	for i := 0; i < len(optionalTokenTypes); i++ {
+		expectedConfigTokenType := optionalTokenTypes[i]
+		if commandDescriptor.repeatOptionalTokens && i == len(optionalTokenTypes)-1 {
+			i--
+		}
+
+		token, err = parser.scan()
+
+		switch {
+		case err != nil:
+			return
+		case token.tokenType == CtkTerminator, token.tokenType == CtkEOF:
+			break OptionalTokenLoop
+		case token.err != nil:
+			err = parser.generateParseError(token, "Syntax Error")
+			return
+		case token.tokenType != expectedConfigTokenType:
+			err = parser.generateParseError(token, "Expected %v but got %v: \"%v\"",
+				ConfigTokenName(expectedConfigTokenType), ConfigTokenName(token.tokenType), token.value)
+			return
+		}
+
+		tokens = append(tokens, token)
 	}
 
 	command, err = commandDescriptor.constructor(parser, tokens)
@@ -255,7 +535,25 @@ func (parser *ConfigParser) parseCommand(token *ConfigToken) (command ConfigComm
 	return
 }
 
+// isSetQueryCommand returns true if the tokens are of the form "set all" or "set variable?"
+func isSetQueryCommand(tokens []*ConfigToken) bool {
+	return len(tokens) == 1 && (tokens[0].value == "all" || strings.HasSuffix(tokens[0].value, "?"))
+}
+
 func setCommandConstructor(parser *ConfigParser, tokens []*ConfigToken) (ConfigCommand, error) {
+	if len(tokens) == 1 {
+		if tokens[0].value == "all" {
+			return &SetAllCommand{}, nil
+		}
+
+		variable := *tokens[0]
+		variable.value = strings.TrimSuffix(variable.value, "?")
+
+		return &SetQueryCommand{
+			variable: &variable,
+		}, nil
+	}
+
 	return &SetCommand{
 		variable: tokens[0],
 		value:    tokens[1],
@@ -298,3 +596,91 @@ func mapCommandConstructor(parser *ConfigParser, tokens []*ConfigToken) (ConfigC
 func quitCommandConstructor(parser *ConfigParser, tokens []*ConfigToken) (ConfigCommand, error) {
 	return &QuitCommand{}, nil
 }
+
+func quickfixCommandConstructor(operation string) commandConstructor {
+	return func(parser *ConfigParser, tokens []*ConfigToken) (ConfigCommand, error) {
+		return &QuickfixCommand{
+			operation: operation,
+		}, nil
+	}
+}
+
+func gotoCommandConstructor(parser *ConfigParser, tokens []*ConfigToken) (ConfigCommand, error) {
+	return &GotoCommand{
+		revision: tokens[0],
+	}, nil
+}
+
+func logCommandConstructor(parser *ConfigParser, tokens []*ConfigToken) (ConfigCommand, error) {
+	return &LogCommand{
+		revisions: tokens,
+	}, nil
+}
+
+func profileCommandConstructor(parser *ConfigParser, tokens []*ConfigToken) (ConfigCommand, error) {
+	operation := tokens[0]
+
+	switch {
+	case operation.value != ProfileStart && operation.value != ProfileStop:
+		return nil, parser.generateParseError(operation, "Invalid profile operation: \"%v\"", operation.value)
+	case operation.value == ProfileStop && len(tokens) > 1:
+		return nil, parser.generateParseError(tokens[1], "Unexpected file for profile stop: \"%v\"", tokens[1].value)
+	}
+
+	profileCommand := &ProfileCommand{
+		operation: operation,
+	}
+
+	if len(tokens) > 1 {
+		profileCommand.file = tokens[1]
+	}
+
+	return profileCommand, nil
+}
+
+func changelogCommandConstructor(parser *ConfigParser, tokens []*ConfigToken) (ConfigCommand, error) {
+	changelogCommand := &ChangelogCommand{
+		fromRevision: tokens[0],
+		toRevision:   tokens[1],
+	}
+
+	if len(tokens) > 2 {
+		groupBy := tokens[2]
+		if groupBy.value != ChangelogGroupByType && groupBy.value != ChangelogGroupByPath {
+			return nil, parser.generateParseError(groupBy, "Invalid changelog grouping: \"%v\"", groupBy.value)
+		}
+
+		changelogCommand.groupBy = groupBy
+	}
+
+	if len(tokens) > 3 {
+		changelogCommand.file = tokens[3]
+	}
+
+	return changelogCommand, nil
+}
+
+func diffCommandConstructor(parser *ConfigParser, tokens []*ConfigToken) (ConfigCommand, error) {
+	return &DiffCommand{
+		fromRevision: tokens[0],
+		toRevision:   tokens[1],
+	}, nil
+}
+
+func cloneCommandConstructor(parser *ConfigParser, tokens []*ConfigToken) (ConfigCommand, error) {
+	cloneCommand := &CloneCommand{
+		url: tokens[0],
+	}
+
+	if len(tokens) > 1 {
+		cloneCommand.directory = tokens[1]
+	}
+
+	return cloneCommand, nil
+}
+
+func worktreeCommandConstructor(parser *ConfigParser, tokens []*ConfigToken) (ConfigCommand, error) {
+	return &WorktreeCommand{
+		worktree: tokens[0],
+	}, nil
+}
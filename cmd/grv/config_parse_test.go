@@ -39,6 +39,178 @@ type ThemeCommandValues struct {
 	fgcolour  string
 }
 
+type CloneCommandValues struct {
+	url       string
+	directory string
+}
+
+func (cloneCommandValues *CloneCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*CloneCommand)
+	if !ok || other.url == nil {
+		return false
+	}
+
+	if other.directory == nil {
+		return cloneCommandValues.url == other.url.value && cloneCommandValues.directory == ""
+	}
+
+	return cloneCommandValues.url == other.url.value && cloneCommandValues.directory == other.directory.value
+}
+
+type ProfileCommandValues struct {
+	operation string
+	file      string
+}
+
+func (profileCommandValues *ProfileCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*ProfileCommand)
+	if !ok || other.operation == nil {
+		return false
+	}
+
+	if other.file == nil {
+		return profileCommandValues.operation == other.operation.value && profileCommandValues.file == ""
+	}
+
+	return profileCommandValues.operation == other.operation.value && profileCommandValues.file == other.file.value
+}
+
+type ChangelogCommandValues struct {
+	fromRevision string
+	toRevision   string
+	groupBy      string
+	file         string
+}
+
+func (changelogCommandValues *ChangelogCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*ChangelogCommand)
+	if !ok || other.fromRevision == nil || other.toRevision == nil {
+		return false
+	}
+
+	var groupBy, file string
+	if other.groupBy != nil {
+		groupBy = other.groupBy.value
+	}
+	if other.file != nil {
+		file = other.file.value
+	}
+
+	return changelogCommandValues.fromRevision == other.fromRevision.value &&
+		changelogCommandValues.toRevision == other.toRevision.value &&
+		changelogCommandValues.groupBy == groupBy &&
+		changelogCommandValues.file == file
+}
+
+type SetQueryCommandValues struct {
+	variable string
+}
+
+func (setQueryCommandValues *SetQueryCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*SetQueryCommand)
+	if !ok || other.variable == nil {
+		return false
+	}
+
+	return setQueryCommandValues.variable == other.variable.value
+}
+
+type WorktreeCommandValues struct {
+	worktree string
+}
+
+func (worktreeCommandValues *WorktreeCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*WorktreeCommand)
+	if !ok || other.worktree == nil {
+		return false
+	}
+
+	return worktreeCommandValues.worktree == other.worktree.value
+}
+
+type GotoCommandValues struct {
+	revision string
+}
+
+func (gotoCommandValues *GotoCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*GotoCommand)
+	if !ok || other.revision == nil {
+		return false
+	}
+
+	return gotoCommandValues.revision == other.revision.value
+}
+
+type LogCommandValues struct {
+	revisions []string
+}
+
+func (logCommandValues *LogCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*LogCommand)
+	if !ok || len(other.revisions) != len(logCommandValues.revisions) {
+		return false
+	}
+
+	for revisionIndex, revision := range logCommandValues.revisions {
+		if other.revisions[revisionIndex].value != revision {
+			return false
+		}
+	}
+
+	return true
+}
+
+type DiffCommandValues struct {
+	fromRevision string
+	toRevision   string
+}
+
+func (diffCommandValues *DiffCommandValues) Equal(command ConfigCommand) bool {
+	if command == nil {
+		return false
+	}
+
+	other, ok := command.(*DiffCommand)
+	if !ok {
+		return false
+	}
+
+	if other.fromRevision == nil || other.toRevision == nil {
+		return false
+	}
+
+	return diffCommandValues.fromRevision == other.fromRevision.value &&
+		diffCommandValues.toRevision == other.toRevision.value
+}
+
 func (themeCommandValues *ThemeCommandValues) Equal(command ConfigCommand) bool {
 	if command == nil {
 		return false
@@ -71,6 +243,13 @@ func TestParseSingleCommand(t *testing.T) {
 				value:    "mytheme",
 			},
 		},
+		{
+			input: `set notifyFetchCommand "notify-send GRV \"\$GRV_NOTIFY_MESSAGE\""`,
+			expectedCommand: &SetCommandValues{
+				variable: "notifyFetchCommand",
+				value:    `notify-send GRV "$GRV_NOTIFY_MESSAGE"`,
+			},
+		},
 		{
 			input: "theme --name mytheme --component CommitView.CommitDate --bgcolor NONE --fgcolor YELLOW\n",
 			expectedCommand: &ThemeCommandValues{
@@ -80,6 +259,117 @@ func TestParseSingleCommand(t *testing.T) {
 				fgcolour:  "YELLOW",
 			},
 		},
+		{
+			input: "diff master origin/master",
+			expectedCommand: &DiffCommandValues{
+				fromRevision: "master",
+				toRevision:   "origin/master",
+			},
+		},
+		{
+			input: "clone https://github.com/rgburke/grv.git",
+			expectedCommand: &CloneCommandValues{
+				url: "https://github.com/rgburke/grv.git",
+			},
+		},
+		{
+			input: "clone https://github.com/rgburke/grv.git src/grv\n",
+			expectedCommand: &CloneCommandValues{
+				url:       "https://github.com/rgburke/grv.git",
+				directory: "src/grv",
+			},
+		},
+		{
+			input: "set tabWidth?\n",
+			expectedCommand: &SetQueryCommandValues{
+				variable: "tabWidth",
+			},
+		},
+		{
+			input:           "set all",
+			expectedCommand: &SetAllCommand{},
+		},
+		{
+			input: "worktree feature\n",
+			expectedCommand: &WorktreeCommandValues{
+				worktree: "feature",
+			},
+		},
+		{
+			input: "goto master~2\n",
+			expectedCommand: &GotoCommandValues{
+				revision: "master~2",
+			},
+		},
+		{
+			input: "goto HEAD^",
+			expectedCommand: &GotoCommandValues{
+				revision: "HEAD^",
+			},
+		},
+		{
+			input:           "log",
+			expectedCommand: &LogCommand{},
+		},
+		{
+			input: "copen",
+			expectedCommand: &QuickfixCommand{
+				operation: QuickfixOpen,
+			},
+		},
+		{
+			input: "cnext\n",
+			expectedCommand: &QuickfixCommand{
+				operation: QuickfixNext,
+			},
+		},
+		{
+			input: "cprev",
+			expectedCommand: &QuickfixCommand{
+				operation: QuickfixPrev,
+			},
+		},
+		{
+			input: "log feature ^master\n",
+			expectedCommand: &LogCommandValues{
+				revisions: []string{"feature", "^master"},
+			},
+		},
+		{
+			input: "log a b c ^d",
+			expectedCommand: &LogCommandValues{
+				revisions: []string{"a", "b", "c", "^d"},
+			},
+		},
+		{
+			input: "profile start grv.prof\n",
+			expectedCommand: &ProfileCommandValues{
+				operation: ProfileStart,
+				file:      "grv.prof",
+			},
+		},
+		{
+			input: "profile stop",
+			expectedCommand: &ProfileCommandValues{
+				operation: ProfileStop,
+			},
+		},
+		{
+			input: "changelog v1.0 v1.1\n",
+			expectedCommand: &ChangelogCommandValues{
+				fromRevision: "v1.0",
+				toRevision:   "v1.1",
+			},
+		},
+		{
+			input: "changelog v1.0 v1.1 path CHANGELOG.md",
+			expectedCommand: &ChangelogCommandValues{
+				fromRevision: "v1.0",
+				toRevision:   "v1.1",
+				groupBy:      ChangelogGroupByPath,
+				file:         "CHANGELOG.md",
+			},
+		},
 	}
 
 	for _, singleCommandTest := range singleCommandTests {
@@ -108,6 +398,14 @@ func TestEOFIsSetByConfigParser(t *testing.T) {
 			input: "set theme mytheme\nset theme mytheme2",
 			eof:   false,
 		},
+		{
+			input: "clone https://github.com/rgburke/grv.git",
+			eof:   true,
+		},
+		{
+			input: "clone https://github.com/rgburke/grv.git\nset theme mytheme",
+			eof:   false,
+		},
 	}
 
 	for _, eofTest := range eofTests {
@@ -212,6 +510,14 @@ func TestErrorsAreReceivedForInvalidConfigTokenSequences(t *testing.T) {
 			input:                "theme --name mytheme --component CommitView.CommitDate --bgcolour NONE --fgcolour YELLOW\n",
 			expectedErrorMessage: ConfigFile + ":1:56 Invalid option for theme command: \"--bgcolour\"",
 		},
+		{
+			input:                "profile pause",
+			expectedErrorMessage: ConfigFile + ":1:9 Invalid profile operation: \"pause\"",
+		},
+		{
+			input:                "changelog v1.0 v1.1 author",
+			expectedErrorMessage: ConfigFile + ":1:21 Invalid changelog grouping: \"author\"",
+		},
 	}
 
 	for _, errorTest := range errorTests {
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"os"
 	"unicode"
 )
 
@@ -55,6 +56,14 @@ type ConfigScanner struct {
 	pos             ConfigScannerPos
 	lastCharLineEnd bool
 	lastLineEndCol  uint
+	getenv          func(string) string
+}
+
+// configWord accumulates the characters of a word along with whether each character was escaped
+// Escaped characters are not subject to expansion
+type configWord struct {
+	chars   []rune
+	escaped []bool
 }
 
 // Equal returns true if the other token is equal
@@ -85,6 +94,7 @@ func NewConfigScanner(reader io.Reader) *ConfigScanner {
 			line: 1,
 			col:  0,
 		},
+		getenv: os.Getenv,
 	}
 }
 
@@ -304,7 +314,7 @@ OuterLoop:
 }
 
 func (scanner *ConfigScanner) scanWord() (token *ConfigToken, err error) {
-	var buffer bytes.Buffer
+	var word configWord
 	var char rune
 	var eof bool
 
@@ -323,20 +333,32 @@ OuterLoop:
 			}
 
 			break OuterLoop
-		default:
-			if _, err = buffer.WriteRune(char); err != nil {
+		case char == '\\':
+			// Only $ and ~ can be escaped outside of a string so that
+			// existing words containing backslashes are unaffected
+			var nextBytes []byte
+			if nextBytes, err = scanner.reader.Peek(1); err != nil && err != io.EOF {
 				return
 			}
-		}
-	}
 
-	token = &ConfigToken{
-		tokenType: CtkWord,
-		value:     buffer.String(),
-		endPos:    scanner.pos,
+			err = nil
+
+			if len(nextBytes) == 1 && (nextBytes[0] == '$' || nextBytes[0] == '~') {
+				if char, _, err = scanner.read(); err != nil {
+					return
+				}
+
+				word.add(char, true)
+				continue
+			}
+
+			word.add(char, false)
+		default:
+			word.add(char, false)
+		}
 	}
 
-	return
+	return scanner.expandedWordToken(word, string(word.chars)), nil
 }
 
 func (scanner *ConfigScanner) scanStringWord() (token *ConfigToken, err error) {
@@ -393,17 +415,13 @@ OuterLoop:
 	}
 
 	if closingQuoteFound {
-		var word string
+		var word configWord
 		word, err = scanner.processStringWord(buffer.String())
 		if err != nil {
 			return
 		}
 
-		token = &ConfigToken{
-			tokenType: CtkWord,
-			value:     word,
-			endPos:    scanner.pos,
-		}
+		token = scanner.expandedWordToken(word, buffer.String())
 	} else {
 		token = &ConfigToken{
 			tokenType: CtkInvalid,
@@ -416,12 +434,12 @@ OuterLoop:
 	return
 }
 
-func (scanner *ConfigScanner) processStringWord(str string) (string, error) {
-	var buffer bytes.Buffer
+func (scanner *ConfigScanner) processStringWord(str string) (word configWord, err error) {
 	chars := []rune(str)
 
 	if len(chars) < 2 || chars[0] != '"' || chars[len(chars)-1] != '"' {
-		return str, fmt.Errorf("Invalid string word: %v", str)
+		err = fmt.Errorf("Invalid string word: %v", str)
+		return
 	}
 
 	chars = chars[1 : len(chars)-1]
@@ -432,16 +450,77 @@ func (scanner *ConfigScanner) processStringWord(str string) (string, error) {
 		case escape:
 			switch char {
 			case 'n':
-				buffer.WriteRune('\n')
+				word.add('\n', true)
 			case 't':
-				buffer.WriteRune('\t')
+				word.add('\t', true)
 			default:
-				buffer.WriteRune(char)
+				word.add(char, true)
 			}
 
 			escape = false
 		case char == '\\':
 			escape = true
+		default:
+			word.add(char, false)
+		}
+	}
+
+	return
+}
+
+// expandedWordToken creates a word token with environment variables and ~ expanded
+// If the word cannot be expanded an invalid token containing the raw value is returned
+func (scanner *ConfigScanner) expandedWordToken(word configWord, rawValue string) *ConfigToken {
+	value, err := word.expand(scanner.getenv)
+	if err != nil {
+		return &ConfigToken{
+			tokenType: CtkInvalid,
+			value:     rawValue,
+			endPos:    scanner.pos,
+			err:       err,
+		}
+	}
+
+	return &ConfigToken{
+		tokenType: CtkWord,
+		value:     value,
+		endPos:    scanner.pos,
+	}
+}
+
+func (word *configWord) add(char rune, escaped bool) {
+	word.chars = append(word.chars, char)
+	word.escaped = append(word.escaped, escaped)
+}
+
+// expand replaces a leading ~ with the home directory and $NAME and ${NAME}
+// with the value of the environment variable NAME. Unset variables expand to nothing
+// and a $ which does not start a variable reference is left as it is
+func (word *configWord) expand(getenv func(string) string) (string, error) {
+	var buffer bytes.Buffer
+	chars := word.chars
+
+	for index := 0; index < len(chars); index++ {
+		char := chars[index]
+
+		switch {
+		case word.escaped[index]:
+			buffer.WriteRune(char)
+		case char == '~' && index == 0 && (len(chars) == 1 || chars[1] == '/'):
+			home := getenv("HOME")
+			if home == "" {
+				return "", errors.New("Unable to expand ~ as HOME is not set. Use \\~ for a literal ~")
+			}
+
+			buffer.WriteString(home)
+		case char == '$' && word.isVariableReference(index):
+			name, length, err := word.variableName(index)
+			if err != nil {
+				return "", err
+			}
+
+			buffer.WriteString(getenv(name))
+			index += length - 1
 		default:
 			buffer.WriteRune(char)
 		}
@@ -449,3 +528,58 @@ func (scanner *ConfigScanner) processStringWord(str string) (string, error) {
 
 	return buffer.String(), nil
 }
+
+// isVariableReference returns true if the $ at the provided index is followed by
+// a variable name or an opening brace
+func (word *configWord) isVariableReference(index int) bool {
+	next := index + 1
+	if next >= len(word.chars) || word.escaped[next] {
+		return false
+	}
+
+	return word.chars[next] == '{' || isVariableNameChar(word.chars[next], true)
+}
+
+// variableName returns the name of the variable referenced at the provided index
+// and the number of characters the reference spans
+func (word *configWord) variableName(index int) (name string, length int, err error) {
+	chars := word.chars
+	start := index + 1
+	braced := start < len(chars) && chars[start] == '{' && !word.escaped[start]
+
+	if braced {
+		start++
+	}
+
+	end := start
+	for end < len(chars) && !word.escaped[end] && isVariableNameChar(chars[end], end == start) {
+		end++
+	}
+
+	name = string(chars[start:end])
+
+	switch {
+	case braced && (end >= len(chars) || chars[end] != '}'):
+		err = fmt.Errorf("Unterminated or invalid variable reference \"%v\". Use ${NAME} or \\$ for a literal $",
+			string(chars[index:end]))
+	case name == "":
+		referenceEnd := end + 1
+		if referenceEnd > len(chars) {
+			referenceEnd = len(chars)
+		}
+
+		reference := string(chars[index:referenceEnd])
+		err = fmt.Errorf("Invalid variable reference \"%v\". Use $NAME, ${NAME} or \\$ for a literal $", reference)
+	case braced:
+		length = end + 1 - index
+	default:
+		length = end - index
+	}
+
+	return
+}
+
+func isVariableNameChar(char rune, first bool) bool {
+	return char == '_' || (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') ||
+		(!first && char >= '0' && char <= '9')
+}
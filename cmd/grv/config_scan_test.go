@@ -811,3 +811,74 @@ func TestScanMultipleConfigTokens(t *testing.T) {
 		}
 	}
 }
+
+func TestConfigWordsAreExpanded(t *testing.T) {
+	environment := map[string]string{
+		"HOME":    "/home/grv",
+		"EDITOR":  "vim",
+		"GRV_DIR": "/opt/grv",
+	}
+
+	var expansionTests = []struct {
+		input         string
+		expectedValue string
+	}{
+		{input: "~", expectedValue: "/home/grv"},
+		{input: "~/.config/grv", expectedValue: "/home/grv/.config/grv"},
+		{input: "a~/b", expectedValue: "a~/b"},
+		{input: "~grv", expectedValue: "~grv"},
+		{input: "\\~/grv", expectedValue: "~/grv"},
+		{input: "$HOME/grvrc", expectedValue: "/home/grv/grvrc"},
+		{input: "${GRV_DIR}rc", expectedValue: "/opt/grvrc"},
+		{input: "$UNSET/grvrc", expectedValue: "/grvrc"},
+		{input: "\\$HOME", expectedValue: "$HOME"},
+		{input: "a\\b", expectedValue: "a\\b"},
+		{input: "\"$EDITOR ~/file\"", expectedValue: "vim ~/file"},
+		{input: "\"~/file\"", expectedValue: "/home/grv/file"},
+		{input: "\"\\$EDITOR \\~\"", expectedValue: "$EDITOR ~"},
+		{input: "$", expectedValue: "$"},
+		{input: "\"100$!\"", expectedValue: "100$!"},
+		{input: "\"$ 1\"", expectedValue: "$ 1"},
+	}
+
+	for _, expansionTest := range expansionTests {
+		scanner := NewConfigScanner(strings.NewReader(expansionTest.input))
+		scanner.getenv = func(name string) string { return environment[name] }
+		token, err := scanner.Scan()
+
+		if err != nil {
+			t.Errorf("Scan failed with error %v", err)
+		} else if token.tokenType != CtkWord || token.value != expansionTest.expectedValue {
+			t.Errorf("Expanded value does not match expected value for input %v. Expected %v, Actual %v",
+				expansionTest.input, expansionTest.expectedValue, *token)
+		}
+	}
+}
+
+func TestInvalidVariableReferencesGenerateInvalidTokens(t *testing.T) {
+	var invalidReferenceTests = []struct {
+		input         string
+		expectedError string
+	}{
+		{
+			input:         "${HOME",
+			expectedError: "Unterminated or invalid variable reference \"${HOME\". Use ${NAME} or \\$ for a literal $",
+		},
+		{
+			input:         "${}",
+			expectedError: "Invalid variable reference \"${}\". Use $NAME, ${NAME} or \\$ for a literal $",
+		},
+	}
+
+	for _, invalidReferenceTest := range invalidReferenceTests {
+		scanner := NewConfigScanner(strings.NewReader(invalidReferenceTest.input))
+		token, err := scanner.Scan()
+
+		if err != nil {
+			t.Errorf("Scan failed with error %v", err)
+		} else if token.tokenType != CtkInvalid || token.value != invalidReferenceTest.input ||
+			token.err == nil || token.err.Error() != invalidReferenceTest.expectedError {
+			t.Errorf("Token does not match expected invalid token for input %v. Actual %v", invalidReferenceTest.input, *token)
+		}
+	}
+}
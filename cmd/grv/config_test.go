@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestAbbrevLengthValidator(t *testing.T) {
+	var abbrevLengthTests = []struct {
+		value       string
+		expectError bool
+	}{
+		{value: AbbrevLengthAuto},
+		{value: "4"},
+		{value: "12"},
+		{value: "40"},
+		{value: "3", expectError: true},
+		{value: "41", expectError: true},
+		{value: "-7", expectError: true},
+		{value: "short", expectError: true},
+		{value: "", expectError: true},
+	}
+
+	validator := abbrevLengthValidator{}
+
+	for _, abbrevLengthTest := range abbrevLengthTests {
+		processedValue, err := validator.validate(abbrevLengthTest.value)
+
+		if abbrevLengthTest.expectError {
+			if err == nil {
+				t.Errorf("Expected error for value %v but none was returned", abbrevLengthTest.value)
+			}
+		} else if err != nil {
+			t.Errorf("Unexpected error for value %v: %v", abbrevLengthTest.value, err)
+		} else if processedValue != abbrevLengthTest.value {
+			t.Errorf("Processed value does not match expected value. Expected: %v, Actual: %v", abbrevLengthTest.value, processedValue)
+		}
+	}
+}
+
+func TestNextCommitOrderCyclesThroughAllOrders(t *testing.T) {
+	commitOrder := cfCommitOrderDefaultValue
+	var visitedOrders []string
+
+	for range commitOrders {
+		if _, err := (commitOrderValidator{}).validate(commitOrder); err != nil {
+			t.Errorf("Unexpected error for commit order %v: %v", commitOrder, err)
+		}
+
+		visitedOrders = append(visitedOrders, commitOrder)
+		commitOrder = nextCommitOrder(commitOrder)
+	}
+
+	if commitOrder != cfCommitOrderDefaultValue {
+		t.Errorf("Expected cycling through all commit orders to return to %v but got %v", cfCommitOrderDefaultValue, commitOrder)
+	}
+
+	if !reflect.DeepEqual(visitedOrders, commitOrders) {
+		t.Errorf("Visited commit orders do not match expected value. Expected: %v, Actual: %v", commitOrders, visitedOrders)
+	}
+
+	if _, err := (commitOrderValidator{}).validate("random"); err == nil {
+		t.Errorf("Expected error for invalid commit order")
+	}
+}
+
+func TestConfigValueStringCanBeReadBackBySetCommand(t *testing.T) {
+	var valueTests = []struct {
+		value          interface{}
+		expectedString string
+	}{
+		{value: 8, expectedString: "8"},
+		{value: true, expectedString: "true"},
+		{value: "default", expectedString: "default"},
+		{value: "", expectedString: "\"\""},
+		{value: "%repo [%branch] %status", expectedString: "\"%repo [%branch] %status\""},
+		{value: "~/ca.pem", expectedString: "\"\\~/ca.pem\""},
+		{value: "echo \"$USER\"", expectedString: "\"echo \\\"\\$USER\\\"\""},
+	}
+
+	for _, valueTest := range valueTests {
+		valueString := configValueString(valueTest.value)
+
+		if valueString != valueTest.expectedString {
+			t.Errorf("Value string does not match expected value. Expected: %v, Actual: %v", valueTest.expectedString, valueString)
+			continue
+		}
+
+		scanner := NewConfigScanner(strings.NewReader(valueString))
+		token, err := scanner.Scan()
+
+		if err != nil {
+			t.Errorf("Scan failed with error %v", err)
+		} else if token.value != fmt.Sprintf("%v", valueTest.value) {
+			t.Errorf("Scanned value does not match original value. Expected: %v, Actual: %v", valueTest.value, token.value)
+		}
+	}
+}
+
+func TestSetCommandRecordsVariableSource(t *testing.T) {
+	config := NewConfiguration(NewKeyBindingManager(), nil)
+
+	parser := NewConfigParser(strings.NewReader("set tabwidth 4\nset theme cold"), ConfigFile)
+	if errs := config.processCommands(parser); len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+
+	if errs := config.Evaluate("set tabwidth 2"); len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+
+	expectedSources := map[ConfigVariable]ConfigVariableSource{
+		CfTabWidth:    CvsRuntime,
+		CfTheme:       CvsGrvrc,
+		CfScrollOff:   CvsDefault,
+		CfStatusline:  CvsDefault,
+		CfDiffRenames: CvsDefault,
+	}
+
+	for _, variable := range config.Variables() {
+		if expectedSource, ok := expectedSources[variable.variable]; ok && variable.source != expectedSource {
+			t.Errorf("Source of %v does not match expected value. Expected: %v, Actual: %v", variable.variable, expectedSource, variable.source)
+		}
+	}
+}
+
+func TestRenamedVariablesCanBeSetUsingTheirPreviousName(t *testing.T) {
+	config := NewConfiguration(NewKeyBindingManager(), nil)
+
+	if errs := config.Evaluate("set tabWidth 4"); len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+
+	if tabWidth := config.GetInt(CfTabWidth); tabWidth != 4 {
+		t.Errorf("%v does not match expected value. Expected: %v, Actual: %v", CfTabWidth, 4, tabWidth)
+	}
+}
+
+type recordingConfigListener struct {
+	changedVariables []ConfigVariable
+}
+
+func (listener *recordingConfigListener) onConfigVariableChange(configVariable ConfigVariable) {
+	listener.changedVariables = append(listener.changedVariables, configVariable)
+}
+
+func TestOnChangeListenersAreNotified(t *testing.T) {
+	config := NewConfiguration(NewKeyBindingManager(), nil)
+	tabWidthListener := &recordingConfigListener{}
+	anyChangeListener := &recordingConfigListener{}
+
+	config.AddOnChangeListener(CfTabWidth, tabWidthListener)
+	config.AddOnAnyChangeListener(anyChangeListener)
+
+	commands := "set tabwidth 4\nset scrolloff 2\n" +
+		"theme --name cold --component CommitView.Date --bgcolor None --fgcolor Red\n" +
+		"theme --name default --component CommitView.Date --bgcolor None --fgcolor Red\n"
+
+	if errs := config.Evaluate(commands); len(errs) > 0 {
+		t.Fatalf("Unexpected errors: %v", errs)
+	}
+
+	expectedTabWidthChanges := []ConfigVariable{CfTabWidth}
+	expectedAnyChanges := []ConfigVariable{CfTabWidth, CfScrollOff, CfTheme}
+
+	if !reflect.DeepEqual(tabWidthListener.changedVariables, expectedTabWidthChanges) {
+		t.Errorf("Variable listener notifications do not match expected value. Expected: %v, Actual: %v",
+			expectedTabWidthChanges, tabWidthListener.changedVariables)
+	}
+
+	if !reflect.DeepEqual(anyChangeListener.changedVariables, expectedAnyChanges) {
+		t.Errorf("Any change listener notifications do not match expected value. Expected: %v, Actual: %v",
+			expectedAnyChanges, anyChangeListener.changedVariables)
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+type configVariablesViewHandler func(*ConfigVariablesView, Action) error
+
+// ConfigVariablesView lists every config variable along with its value and where it was set
+type ConfigVariablesView struct {
+	channels      *Channels
+	config        Config
+	variables     []ConfigVariableValue
+	active        bool
+	viewPos       ViewPos
+	viewDimension ViewDimension
+	handlers      map[ActionType]configVariablesViewHandler
+	lock          sync.Mutex
+}
+
+// NewConfigVariablesView creates a new instance
+func NewConfigVariablesView(channels *Channels, config Config) *ConfigVariablesView {
+	configVariablesView := &ConfigVariablesView{
+		channels: channels,
+		config:   config,
+		viewPos:  NewViewPosition(),
+		handlers: map[ActionType]configVariablesViewHandler{
+			ActionPrevLine:  moveUpConfigVariable,
+			ActionNextLine:  moveDownConfigVariable,
+			ActionFirstLine: moveToFirstConfigVariable,
+			ActionLastLine:  moveToLastConfigVariable,
+		},
+	}
+
+	config.AddOnAnyChangeListener(configVariablesView)
+
+	return configVariablesView
+}
+
+// Initialise loads the config variables
+func (configVariablesView *ConfigVariablesView) Initialise() (err error) {
+	log.Info("Initialising ConfigVariablesView")
+	configVariablesView.LoadVariables()
+	return
+}
+
+// LoadVariables refreshes the displayed config variables with their current values
+func (configVariablesView *ConfigVariablesView) LoadVariables() {
+	variables := configVariablesView.config.Variables()
+
+	configVariablesView.lock.Lock()
+	defer configVariablesView.lock.Unlock()
+
+	configVariablesView.variables = variables
+
+	if variableNum := uint(len(variables)); variableNum > 0 && configVariablesView.viewPos.ActiveRowIndex() >= variableNum {
+		configVariablesView.viewPos.SetActiveRowIndex(variableNum - 1)
+	}
+
+	configVariablesView.channels.UpdateDisplay()
+}
+
+// Render generates and writes the config variables view to the provided window
+func (configVariablesView *ConfigVariablesView) Render(win RenderWindow) (err error) {
+	log.Debug("Rendering ConfigVariablesView")
+	configVariablesView.lock.Lock()
+	defer configVariablesView.lock.Unlock()
+
+	configVariablesView.viewDimension = win.ViewDimensions()
+
+	variableNum := uint(len(configVariablesView.variables))
+	rows := win.Rows() - 2
+	viewPos := configVariablesView.viewPos
+	viewPos.DetermineViewStartRow(rows, variableNum, uint(configVariablesView.config.GetInt(CfScrollOff)))
+	variableIndex := viewPos.ViewStartRowIndex()
+	startColumn := viewPos.ViewStartColumn()
+	nameWidth, valueWidth := configVariableColumnWidths(configVariablesView.variables)
+
+	for winRowIndex := uint(0); winRowIndex < rows && variableIndex < variableNum; winRowIndex++ {
+		variable := configVariablesView.variables[variableIndex]
+
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(winRowIndex+1, startColumn); err != nil {
+			return
+		}
+
+		lineBuilder.AppendWithStyle(CmpConfigvariablesviewName, " %-*v ", nameWidth, variable.variable).
+			AppendWithStyle(CmpConfigvariablesviewValue, " %-*v ", valueWidth, variable.value).
+			AppendWithStyle(CmpConfigvariablesviewSource, " %v", variable.source)
+
+		variableIndex++
+	}
+
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, configVariablesView.active); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	return win.SetTitle(CmpConfigvariablesviewTitle, "%v", NewTitleBuilder("Config Variables"))
+}
+
+// configVariableColumnWidths returns the width of the longest variable name and value
+func configVariableColumnWidths(variables []ConfigVariableValue) (nameWidth, valueWidth int) {
+	for _, variable := range variables {
+		if width := int(StringWidth(string(variable.variable))); width > nameWidth {
+			nameWidth = width
+		}
+
+		if width := int(StringWidth(variable.value)); width > valueWidth {
+			valueWidth = width
+		}
+	}
+
+	return
+}
+
+// RenderStatusBar does nothing
+func (configVariablesView *ConfigVariablesView) RenderStatusBar(lineBuilder *LineBuilder) (err error) {
+	return
+}
+
+// RenderHelpBar generates key binding help info for the config variables view
+func (configVariablesView *ConfigVariablesView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(configVariablesView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionPrompt, message: "Set Variable"},
+		{action: ActionNextTab, message: "Next Tab"},
+	})
+
+	return
+}
+
+// HandleKeyPress does nothing
+func (configVariablesView *ConfigVariablesView) HandleKeyPress(keystring string) (err error) {
+	log.Debugf("ConfigVariablesView handling key %v - NOP", keystring)
+	return
+}
+
+// HandleAction checks if the config variables view supports the provided action and executes it if so
+func (configVariablesView *ConfigVariablesView) HandleAction(action Action) (err error) {
+	log.Debugf("ConfigVariablesView handling action %v", action)
+	configVariablesView.lock.Lock()
+	defer configVariablesView.lock.Unlock()
+
+	if handler, ok := configVariablesView.handlers[action.ActionType]; ok {
+		err = handler(configVariablesView, action)
+	}
+
+	return
+}
+
+// OnActiveChange updates whether the config variables view is active and reloads the variables when it becomes active
+func (configVariablesView *ConfigVariablesView) OnActiveChange(active bool) {
+	log.Debugf("ConfigVariablesView active: %v", active)
+	configVariablesView.lock.Lock()
+	becameActive := active && !configVariablesView.active
+	configVariablesView.active = active
+	configVariablesView.lock.Unlock()
+
+	if becameActive {
+		configVariablesView.LoadVariables()
+	}
+}
+
+// onConfigVariableChange reloads the variables so the displayed values remain current
+func (configVariablesView *ConfigVariablesView) onConfigVariableChange(configVariable ConfigVariable) {
+	configVariablesView.LoadVariables()
+}
+
+// ViewID returns the view ID of the config variables view
+func (configVariablesView *ConfigVariablesView) ViewID() ViewID {
+	return ViewConfigVariables
+}
+
+func moveUpConfigVariable(configVariablesView *ConfigVariablesView, action Action) (err error) {
+	if configVariablesView.viewPos.MoveLinesUp(action.RepeatCount()) {
+		configVariablesView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownConfigVariable(configVariablesView *ConfigVariablesView, action Action) (err error) {
+	if configVariablesView.viewPos.MoveLinesDown(action.RepeatCount(), uint(len(configVariablesView.variables))) {
+		configVariablesView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToFirstConfigVariable(configVariablesView *ConfigVariablesView, action Action) (err error) {
+	if configVariablesView.viewPos.MoveToFirstLine() {
+		configVariablesView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToLastConfigVariable(configVariablesView *ConfigVariablesView, action Action) (err error) {
+	if configVariablesView.viewPos.MoveToLastLine(uint(len(configVariablesView.variables))) {
+		configVariablesView.channels.UpdateDisplay()
+	}
+
+	return
+}
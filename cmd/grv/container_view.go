@@ -0,0 +1,83 @@
+package main
+
+import (
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// ContainerView is a view collection which displays a single child view using all available space
+type ContainerView struct {
+	child WindowView
+	win   *Window
+}
+
+// NewContainerView creates a new instance which displays the child view in the provided window
+func NewContainerView(child WindowView, win *Window) *ContainerView {
+	return &ContainerView{
+		child: child,
+		win:   win,
+	}
+}
+
+// Initialise initialises the child view
+func (containerView *ContainerView) Initialise() error {
+	return containerView.child.Initialise()
+}
+
+// Render renders the child view to a window filling the provided dimensions
+func (containerView *ContainerView) Render(viewDimension ViewDimension) (wins []*Window, err error) {
+	log.Debugf("Rendering ContainerView for view %v", containerView.child.ViewID())
+
+	win := containerView.win
+	win.Resize(viewDimension)
+	win.Clear()
+	win.SetPosition(0, 0)
+
+	renderStart := time.Now()
+
+	if err = containerView.child.Render(win); err != nil {
+		return
+	}
+
+	renderTimings.Record(win.ID(), renderStart)
+
+	wins = append(wins, win)
+
+	return
+}
+
+// RenderStatusBar does nothing
+func (containerView *ContainerView) RenderStatusBar(lineBuilder *LineBuilder) (err error) {
+	return
+}
+
+// RenderHelpBar does nothing as help is rendered by the child view
+func (containerView *ContainerView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	return
+}
+
+// HandleKeyPress passes the key press on to the child view
+func (containerView *ContainerView) HandleKeyPress(keystring string) error {
+	return containerView.child.HandleKeyPress(keystring)
+}
+
+// HandleAction passes the action on to the child view
+func (containerView *ContainerView) HandleAction(action Action) error {
+	return containerView.child.HandleAction(action)
+}
+
+// OnActiveChange updates the active state of the child view
+func (containerView *ContainerView) OnActiveChange(active bool) {
+	containerView.child.OnActiveChange(active)
+}
+
+// ViewID returns the view ID of the child view
+func (containerView *ContainerView) ViewID() ViewID {
+	return containerView.child.ViewID()
+}
+
+// ActiveView returns the child view
+func (containerView *ContainerView) ActiveView() AbstractView {
+	return containerView.child
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	crRecentActionNum     = 50
+	crReportFilePrefix    = "crash-"
+	crReportFileExt       = ".log"
+	crReportTimeFormat    = "20060102-150405"
+	crSessionFile         = "crash-session"
+	crSessionRepository   = "repository"
+	crSessionRef          = "ref"
+	crSessionCommit       = "commit"
+	crSessionReport       = "report"
+	crActionTimeFormat    = "15:04:05.000"
+	crReportHeaderMessage = "GRV crashed at %v\n\nPanic: %v\n\n"
+)
+
+// SessionState describes the ref and commit displayed
+// It is saved when GRV crashes so it can be restored on the next launch
+type SessionState struct {
+	repoPath   string
+	refName    string
+	commitID   string
+	reportPath string
+}
+
+// CrashReporter records recent activity and writes a crash report when GRV panics
+type CrashReporter struct {
+	recentActions []string
+	session       SessionState
+	lock          sync.Mutex
+}
+
+// NewCrashReporter creates a new instance
+func NewCrashReporter() *CrashReporter {
+	return &CrashReporter{}
+}
+
+// RecordAction adds the action to the list of recent actions included in crash reports
+func (crashReporter *CrashReporter) RecordAction(action Action) {
+	crashReporter.lock.Lock()
+	defer crashReporter.lock.Unlock()
+
+	entry := fmt.Sprintf("%v %v", time.Now().Format(crActionTimeFormat), actionKey(action.ActionType))
+	crashReporter.recentActions = append(crashReporter.recentActions, entry)
+
+	if len(crashReporter.recentActions) > crRecentActionNum {
+		crashReporter.recentActions = crashReporter.recentActions[1:]
+	}
+}
+
+// RecordSession stores the session state which will be saved if GRV crashes
+func (crashReporter *CrashReporter) RecordSession(session SessionState) {
+	crashReporter.lock.Lock()
+	defer crashReporter.lock.Unlock()
+
+	crashReporter.session = session
+}
+
+// WriteReport writes a crash report containing the panic, stack trace and recent actions
+// to the provided directory and saves the session state alongside it.
+// The path of the crash report is returned
+func (crashReporter *CrashReporter) WriteReport(dir string, panicValue interface{}, stack []byte) (reportPath string, err error) {
+	crashReporter.lock.Lock()
+	defer crashReporter.lock.Unlock()
+
+	if dir == "" {
+		dir = os.TempDir()
+	}
+
+	now := time.Now()
+
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, crReportHeaderMessage, now.Format(time.RFC3339), panicValue)
+	buffer.Write(stack)
+	buffer.WriteString("\nRecent actions:\n")
+
+	for _, recentAction := range crashReporter.recentActions {
+		buffer.WriteString(recentAction + "\n")
+	}
+
+	reportPath = filepath.Join(dir, crReportFilePrefix+now.Format(crReportTimeFormat)+crReportFileExt)
+
+	if err = ioutil.WriteFile(reportPath, buffer.Bytes(), 0644); err != nil {
+		return
+	}
+
+	session := crashReporter.session
+	session.reportPath = reportPath
+
+	err = writeSessionState(filepath.Join(dir, crSessionFile), session)
+
+	return
+}
+
+// writeSessionState writes the session state to the provided file
+func writeSessionState(sessionPath string, session SessionState) error {
+	var buffer bytes.Buffer
+
+	for _, field := range []struct {
+		name  string
+		value string
+	}{
+		{name: crSessionRepository, value: session.repoPath},
+		{name: crSessionRef, value: session.refName},
+		{name: crSessionCommit, value: session.commitID},
+		{name: crSessionReport, value: session.reportPath},
+	} {
+		if field.value != "" {
+			fmt.Fprintf(&buffer, "%v %v\n", field.name, field.value)
+		}
+	}
+
+	return ioutil.WriteFile(sessionPath, buffer.Bytes(), 0644)
+}
+
+// TakeSavedSession returns the session saved in the provided directory by the last crash
+// The saved session is removed so it is only offered once
+func TakeSavedSession(dir string) (session SessionState, found bool) {
+	if dir == "" {
+		return
+	}
+
+	sessionPath := filepath.Join(dir, crSessionFile)
+
+	file, err := os.Open(sessionPath)
+	if err != nil {
+		return
+	}
+
+	defer os.Remove(sessionPath)
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), " ", 2)
+		if len(fields) != 2 {
+			continue
+		}
+
+		switch fields[0] {
+		case crSessionRepository:
+			session.repoPath = fields[1]
+		case crSessionRef:
+			session.refName = fields[1]
+		case crSessionCommit:
+			session.commitID = fields[1]
+		case crSessionReport:
+			session.reportPath = fields[1]
+		}
+	}
+
+	return session, scanner.Err() == nil
+}
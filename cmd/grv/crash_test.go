@@ -0,0 +1,84 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestCrashReportContainsPanicAndRecentActions(t *testing.T) {
+	dir, err := ioutil.TempDir("", "grv-crash")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	crashReporter := NewCrashReporter()
+
+	for i := 0; i < crRecentActionNum; i++ {
+		crashReporter.RecordAction(Action{ActionType: ActionNextLine})
+	}
+
+	crashReporter.RecordAction(Action{ActionType: ActionSelect})
+
+	reportPath, err := crashReporter.WriteReport(dir, "index out of range", []byte("goroutine 1 [running]:\n"))
+	if err != nil {
+		t.Fatalf("WriteReport failed with error %v", err)
+	}
+
+	content, err := ioutil.ReadFile(reportPath)
+	if err != nil {
+		t.Fatalf("Unable to read crash report: %v", err)
+	}
+
+	report := string(content)
+
+	for _, expectedText := range []string{"Panic: index out of range", "goroutine 1 [running]:", "<grv-select>"} {
+		if !strings.Contains(report, expectedText) {
+			t.Errorf("Crash report does not contain expected text %q. Report: %v", expectedText, report)
+		}
+	}
+
+	if actionNum := strings.Count(report, "<grv-next-line>") + strings.Count(report, "<grv-select>"); actionNum != crRecentActionNum {
+		t.Errorf("Number of recent actions does not match expected value. Expected %v, Actual %v", crRecentActionNum, actionNum)
+	}
+}
+
+func TestSavedSessionIsRestoredOnce(t *testing.T) {
+	dir, err := ioutil.TempDir("", "grv-crash")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	crashReporter := NewCrashReporter()
+	crashReporter.RecordSession(SessionState{
+		repoPath: "/src/grv/.git/",
+		refName:  "origin/master",
+		commitID: "4f9d8b3a2c1e0f9d8b3a2c1e0f9d8b3a2c1e0f9d",
+	})
+
+	reportPath, err := crashReporter.WriteReport(dir, "panic", nil)
+	if err != nil {
+		t.Fatalf("WriteReport failed with error %v", err)
+	}
+
+	expectedSession := SessionState{
+		repoPath:   "/src/grv/.git/",
+		refName:    "origin/master",
+		commitID:   "4f9d8b3a2c1e0f9d8b3a2c1e0f9d8b3a2c1e0f9d",
+		reportPath: reportPath,
+	}
+
+	session, found := TakeSavedSession(dir)
+	if !found {
+		t.Fatalf("Expected saved session to be found")
+	} else if session != expectedSession {
+		t.Errorf("Session does not match expected value. Expected %v, Actual %v", expectedSession, session)
+	}
+
+	if _, found = TakeSavedSession(dir); found {
+		t.Errorf("Expected saved session to be removed once taken")
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+const (
+	crDefaultSSHUsername = "git"
+)
+
+// Credential is a set of attributes describing a credential as used by git credential helpers
+type Credential map[string]string
+
+// credentialSession tracks the credentials provided during a single network operation
+// so that each method of authentication is only attempted once
+type credentialSession struct {
+	sshAgentAttempted bool
+	credential        Credential
+}
+
+// ParseCredential parses the key=value output of git credential
+func ParseCredential(output string) Credential {
+	credential := make(Credential)
+	scanner := bufio.NewScanner(strings.NewReader(output))
+
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			break
+		}
+
+		if separatorIndex := strings.Index(line, "="); separatorIndex > 0 {
+			credential[line[:separatorIndex]] = line[separatorIndex+1:]
+		}
+	}
+
+	return credential
+}
+
+// String formats the credential as input for git credential
+func (credential Credential) String() string {
+	var buffer bytes.Buffer
+
+	for _, key := range []string{"protocol", "host", "path", "username", "password", "url"} {
+		if value, ok := credential[key]; ok {
+			buffer.WriteString(fmt.Sprintf("%v=%v\n", key, value))
+		}
+	}
+
+	buffer.WriteString("\n")
+
+	return buffer.String()
+}
+
+// runCredentialHelper executes git credential with the provided operation (fill, approve or reject)
+// Terminal prompting is disabled as the terminal is in use by GRV
+func runCredentialHelper(operation string, credential Credential) (output string, err error) {
+	var stdout, stderr bytes.Buffer
+
+	cmd := exec.Command("git", "credential", operation)
+	cmd.Stdin = strings.NewReader(credential.String())
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+
+	if err = cmd.Run(); err != nil {
+		return "", fmt.Errorf("git credential %v failed: %v: %v", operation, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return stdout.String(), nil
+}
+
+func (session *credentialSession) credentialsCallback(url string, usernameFromURL string, allowedTypes git.CredType) (git.ErrorCode, *git.Cred) {
+	if allowedTypes&git.CredTypeSshKey != 0 && !session.sshAgentAttempted {
+		session.sshAgentAttempted = true
+
+		username := usernameFromURL
+		if username == "" {
+			username = crDefaultSSHUsername
+		}
+
+		log.Debugf("Using ssh agent for %v", url)
+		ret, cred := git.NewCredSshKeyFromAgent(username)
+		return git.ErrorCode(ret), &cred
+	}
+
+	if allowedTypes&git.CredTypeUserpassPlaintext != 0 && session.credential == nil {
+		request := Credential{"url": url}
+		if usernameFromURL != "" {
+			request["username"] = usernameFromURL
+		}
+
+		output, err := runCredentialHelper("fill", request)
+		if err != nil {
+			log.Errorf("Unable to obtain credentials for %v: %v", url, err)
+			return git.ErrUser, nil
+		}
+
+		session.credential = ParseCredential(output)
+
+		log.Debugf("Using credential helper for %v", url)
+		ret, cred := git.NewCredUserpassPlaintext(session.credential["username"], session.credential["password"])
+		return git.ErrorCode(ret), &cred
+	}
+
+	return git.ErrUser, nil
+}
+
+// complete informs the credential helpers whether the credential obtained (if any) was accepted
+func (session *credentialSession) complete(operationErr error) {
+	if session.credential == nil {
+		return
+	}
+
+	operation := "approve"
+	if operationErr != nil {
+		operation = "reject"
+	}
+
+	if _, err := runCredentialHelper(operation, session.credential); err != nil {
+		log.Errorf("Unable to %v credential: %v", operation, err)
+	}
+}
+
+func (session *credentialSession) remoteCallbacks() git.RemoteCallbacks {
+	return git.RemoteCallbacks{
+		CredentialsCallback: session.credentialsCallback,
+	}
+}
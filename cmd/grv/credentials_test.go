@@ -0,0 +1,36 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseCredential(t *testing.T) {
+	output := "protocol=https\nhost=github.com\nusername=user\npassword=pass=word\n\nignored=value\n"
+
+	expectedCredential := Credential{
+		"protocol": "https",
+		"host":     "github.com",
+		"username": "user",
+		"password": "pass=word",
+	}
+
+	if credential := ParseCredential(output); !reflect.DeepEqual(credential, expectedCredential) {
+		t.Errorf("Credential does not match expected value. Expected: %v, Actual: %v", expectedCredential, credential)
+	}
+}
+
+func TestCredentialIsFormattedAsHelperInput(t *testing.T) {
+	credential := Credential{
+		"username": "user",
+		"url":      "https://github.com/rgburke/grv.git",
+		"protocol": "https",
+		"host":     "github.com",
+	}
+
+	expectedInput := "protocol=https\nhost=github.com\nusername=user\nurl=https://github.com/rgburke/grv.git\n\n"
+
+	if input := credential.String(); input != expectedInput {
+		t.Errorf("Credential helper input does not match expected value. Expected: %q, Actual: %q", expectedInput, input)
+	}
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+const (
+	dvMinCols      = 40
+	dvInputPrefix  = "> "
+	dvYesNoOptions = "[y]es / [n]o"
+)
+
+// DialogType identifies the response a dialog requires
+type DialogType int
+
+// The set of dialog types
+const (
+	DtYesNo DialogType = iota
+	DtTypedConfirmation
+	DtInput
+)
+
+// DialogRequest describes a modal dialog. The action is performed when the dialog
+// is confirmed. For input dialogs the input entered is appended to the action arguments.
+// The cancel action (if set) is performed when the dialog is not confirmed
+type DialogRequest struct {
+	dialogType       DialogType
+	title            string
+	message          string
+	confirmationText string
+	initialInput     string
+	action           Action
+	cancelAction     Action
+}
+
+// dialogRequestArg extracts the dialog request from the arguments of the action
+func dialogRequestArg(action Action) (request DialogRequest, err error) {
+	if len(action.Args) == 0 {
+		return request, fmt.Errorf("Expected dialog request argument")
+	}
+
+	request, ok := action.Args[0].(DialogRequest)
+	if !ok {
+		err = fmt.Errorf("Expected dialog request argument to have type DialogRequest")
+	}
+
+	return
+}
+
+// dialogResponseAction determines whether the input entered confirms the dialog
+// and if so returns the action to perform
+func dialogResponseAction(request DialogRequest, input string) (action Action, confirmed bool) {
+	switch request.dialogType {
+	case DtYesNo:
+		confirmed = input == "y" || input == "Y"
+	case DtTypedConfirmation:
+		confirmed = input == request.confirmationText
+	case DtInput:
+		confirmed = input != ""
+	}
+
+	if !confirmed {
+		return
+	}
+
+	action = request.action
+
+	if request.dialogType == DtInput {
+		action.Args = append(append([]interface{}{}, request.action.Args...), input)
+	}
+
+	return
+}
+
+// wrapDialogMessage splits the message into lines no wider than the provided width
+// Words wider than the width are placed on a line of their own
+func wrapDialogMessage(message string, width uint) (lines []string) {
+	for _, paragraph := range strings.Split(message, "\n") {
+		var line string
+		var lineWidth uint
+
+		for _, word := range strings.Fields(paragraph) {
+			wordWidth := StringWidth(word)
+
+			if lineWidth > 0 && lineWidth+1+wordWidth > width {
+				lines = append(lines, line)
+				line, lineWidth = "", 0
+			}
+
+			if lineWidth > 0 {
+				line += " "
+				lineWidth++
+			}
+
+			line += word
+			lineWidth += wordWidth
+		}
+
+		lines = append(lines, line)
+	}
+
+	return
+}
+
+// DialogView displays a modal dialog over the active view
+type DialogView struct {
+	request DialogRequest
+	lock    sync.Mutex
+}
+
+// NewDialogView creates a new instance
+func NewDialogView() *DialogView {
+	return &DialogView{}
+}
+
+// SetRequest sets the dialog to be displayed
+func (dialogView *DialogView) SetRequest(request DialogRequest) {
+	dialogView.lock.Lock()
+	defer dialogView.lock.Unlock()
+
+	dialogView.request = request
+}
+
+// DialogDimensions returns the size of the dialog when displayed over a view of the provided size
+func (dialogView *DialogView) DialogDimensions(viewDimension ViewDimension) ViewDimension {
+	dialogView.lock.Lock()
+	defer dialogView.lock.Unlock()
+
+	cols := viewDimension.cols * 2 / 3
+	if cols < dvMinCols {
+		cols = Min(dvMinCols, viewDimension.cols)
+	}
+
+	lines := wrapDialogMessage(dialogView.request.message, dialogView.messageWidth(cols))
+
+	return ViewDimension{
+		rows: Min(uint(len(lines))+4, viewDimension.rows),
+		cols: cols,
+	}
+}
+
+func (dialogView *DialogView) messageWidth(cols uint) uint {
+	if cols > 4 {
+		return cols - 4
+	}
+
+	return 1
+}
+
+// Render draws the dialog message and the current input to the provided window
+func (dialogView *DialogView) Render(win RenderWindow, input string, point int) (err error) {
+	dialogView.lock.Lock()
+	defer dialogView.lock.Unlock()
+
+	request := dialogView.request
+	lines := wrapDialogMessage(request.message, dialogView.messageWidth(win.Cols()))
+	rows := win.Rows()
+
+	if rows < 4 {
+		return
+	}
+
+	for lineIndex, line := range lines {
+		rowIndex := uint(lineIndex) + 1
+		if rowIndex >= rows-3 {
+			break
+		}
+
+		if err = win.SetRow(rowIndex, 2, CmpDialogviewMessage, "%v", line); err != nil {
+			return
+		}
+	}
+
+	inputRowIndex := rows - 2
+	var footer string
+
+	switch request.dialogType {
+	case DtYesNo:
+		if err = win.SetRow(inputRowIndex, 2, CmpDialogviewInput, "%v", dvYesNoOptions); err != nil {
+			return
+		}
+
+		footer = "Press y to confirm"
+	default:
+		if err = win.SetRow(inputRowIndex, 2, CmpDialogviewInput, "%v%v", dvInputPrefix, input); err != nil {
+			return
+		}
+
+		cursorCol := uint(2) + StringWidth(dvInputPrefix) + StringWidth(prefixOfByteLength(input, point))
+		if err = win.SetCursor(inputRowIndex, Min(cursorCol, win.Cols()-2)); err != nil {
+			return
+		}
+
+		if request.dialogType == DtTypedConfirmation {
+			footer = "Type " + request.confirmationText + " and press <Enter> to confirm"
+		} else {
+			footer = "Press <Enter> to confirm"
+		}
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpDialogviewTitle, "%v", request.title); err != nil {
+		return
+	}
+
+	return win.SetFooter(CmpDialogviewFooter, "%v", footer)
+}
+
+// prefixOfByteLength returns the longest prefix of the string which is no longer than the provided number of bytes
+func prefixOfByteLength(str string, length int) string {
+	bytes := 0
+
+	for index, char := range str {
+		bytes += utf8.RuneLen(char)
+
+		if bytes > length {
+			return str[:index]
+		}
+	}
+
+	return str
+}
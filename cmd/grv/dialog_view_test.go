@@ -0,0 +1,86 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDialogResponseActionDeterminesWhetherDialogIsConfirmed(t *testing.T) {
+	action := Action{ActionType: ActionDropStash}
+
+	var responseTests = []struct {
+		request           DialogRequest
+		input             string
+		expectedConfirmed bool
+	}{
+		{request: DialogRequest{dialogType: DtYesNo, action: action}, input: "y", expectedConfirmed: true},
+		{request: DialogRequest{dialogType: DtYesNo, action: action}, input: "Y", expectedConfirmed: true},
+		{request: DialogRequest{dialogType: DtYesNo, action: action}, input: "n", expectedConfirmed: false},
+		{request: DialogRequest{dialogType: DtYesNo, action: action}, input: "", expectedConfirmed: false},
+		{request: DialogRequest{dialogType: DtTypedConfirmation, confirmationText: "master", action: action}, input: "master", expectedConfirmed: true},
+		{request: DialogRequest{dialogType: DtTypedConfirmation, confirmationText: "master", action: action}, input: "maste", expectedConfirmed: false},
+		{request: DialogRequest{dialogType: DtInput, action: action}, input: "feature", expectedConfirmed: true},
+		{request: DialogRequest{dialogType: DtInput, action: action}, input: "", expectedConfirmed: false},
+	}
+
+	for _, responseTest := range responseTests {
+		responseAction, confirmed := dialogResponseAction(responseTest.request, responseTest.input)
+
+		if confirmed != responseTest.expectedConfirmed {
+			t.Errorf("Confirmed does not match expected value for dialog type %v and input %q. Expected: %v, Actual: %v",
+				responseTest.request.dialogType, responseTest.input, responseTest.expectedConfirmed, confirmed)
+		} else if confirmed && responseAction.ActionType != action.ActionType {
+			t.Errorf("Action does not match expected value. Expected: %v, Actual: %v", action.ActionType, responseAction.ActionType)
+		}
+	}
+}
+
+func TestDialogResponseActionAppendsInputToActionArgs(t *testing.T) {
+	request := DialogRequest{
+		dialogType: DtInput,
+		action:     Action{ActionType: ActionRenameRef, Args: []interface{}{"master"}},
+	}
+
+	responseAction, confirmed := dialogResponseAction(request, "main")
+	if !confirmed {
+		t.Fatalf("Expected input dialog to be confirmed")
+	}
+
+	if len(responseAction.Args) != 2 || responseAction.Args[0] != "master" || responseAction.Args[1] != "main" {
+		t.Errorf("Action args do not match expected value. Expected: [master main], Actual: %v", responseAction.Args)
+	}
+
+	if len(request.action.Args) != 1 {
+		t.Errorf("Expected request action args to be unchanged but found: %v", request.action.Args)
+	}
+}
+
+func TestWrapDialogMessage(t *testing.T) {
+	var wrapTests = []struct {
+		message       string
+		width         uint
+		expectedLines []string
+	}{
+		{message: "", width: 10, expectedLines: []string{""}},
+		{message: "Drop stash", width: 10, expectedLines: []string{"Drop stash"}},
+		{message: "Drop the selected stash", width: 10, expectedLines: []string{"Drop the", "selected", "stash"}},
+		{message: "Unreachable commits", width: 5, expectedLines: []string{"Unreachable", "commits"}},
+		{message: "First line\nSecond line", width: 20, expectedLines: []string{"First line", "Second line"}},
+	}
+
+	for _, wrapTest := range wrapTests {
+		lines := wrapDialogMessage(wrapTest.message, wrapTest.width)
+
+		if len(lines) != len(wrapTest.expectedLines) {
+			t.Errorf("Lines do not match expected value for message %q. Expected: %q, Actual: %q",
+				wrapTest.message, wrapTest.expectedLines, lines)
+			continue
+		}
+
+		for lineIndex, line := range lines {
+			if line != wrapTest.expectedLines[lineIndex] {
+				t.Errorf("Line at index %v does not match expected value. Expected: %q, Actual: %q",
+					lineIndex, wrapTest.expectedLines[lineIndex], line)
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// parseDiffExcludePatterns splits the space separated diffexclude value into patterns
+// and checks each pattern is well formed
+func parseDiffExcludePatterns(value string) (patterns []string, err error) {
+	for _, pattern := range strings.Fields(value) {
+		if _, err = path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("Invalid diff exclude pattern %v: %v", pattern, err)
+		}
+
+		patterns = append(patterns, strings.TrimSuffix(pattern, "/"))
+	}
+
+	return
+}
+
+// matchesDiffExcludePattern returns true if the file path matches any of the patterns.
+// As in .gitignore, patterns without a slash are matched against the name of the file and of
+// each directory containing it. Other patterns are matched against the file path and the path
+// of each directory containing it, so vendor/* excludes every file below the vendor directory
+func matchesDiffExcludePattern(patterns []string, filePath string) bool {
+	for _, pattern := range patterns {
+		matchName := !strings.Contains(pattern, "/")
+
+		for prefix := filePath; prefix != "." && prefix != "/"; prefix = path.Dir(prefix) {
+			name := prefix
+			if matchName {
+				name = path.Base(prefix)
+			}
+
+			if matched, _ := path.Match(pattern, name); matched {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// excludedFilesDescription describes the number of files omitted from a diff by diffexclude
+func excludedFilesDescription(excludedFiles uint) string {
+	if excludedFiles == 1 {
+		return fmt.Sprintf("1 file hidden by %v", CfDiffExclude)
+	}
+
+	return fmt.Sprintf("%v files hidden by %v", excludedFiles, CfDiffExclude)
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDiffExcludePatterns(t *testing.T) {
+	var parseTests = []struct {
+		value            string
+		expectedPatterns []string
+		expectError      bool
+	}{
+		{value: "", expectedPatterns: nil},
+		{value: "vendor/* *.pb.go", expectedPatterns: []string{"vendor/*", "*.pb.go"}},
+		{value: "  node_modules/  *.min.js ", expectedPatterns: []string{"node_modules", "*.min.js"}},
+		{value: "vendor/* [", expectError: true},
+	}
+
+	for _, parseTest := range parseTests {
+		patterns, err := parseDiffExcludePatterns(parseTest.value)
+
+		if parseTest.expectError {
+			if err == nil {
+				t.Errorf("Expected error for value %q but none was returned", parseTest.value)
+			}
+		} else if err != nil {
+			t.Errorf("Unexpected error for value %q: %v", parseTest.value, err)
+		} else if !reflect.DeepEqual(patterns, parseTest.expectedPatterns) {
+			t.Errorf("Patterns do not match expected value for value %q. Expected: %v, Actual: %v",
+				parseTest.value, parseTest.expectedPatterns, patterns)
+		}
+	}
+}
+
+func TestMatchesDiffExcludePattern(t *testing.T) {
+	patterns := []string{"vendor/*", "*.pb.go", "node_modules"}
+
+	var matchTests = []struct {
+		filePath      string
+		expectedMatch bool
+	}{
+		{filePath: "vendor/modules.txt", expectedMatch: true},
+		{filePath: "vendor/github.com/pkg/errors/errors.go", expectedMatch: true},
+		{filePath: "cmd/vendor/lib.go", expectedMatch: false},
+		{filePath: "api.pb.go", expectedMatch: true},
+		{filePath: "proto/api/api.pb.go", expectedMatch: true},
+		{filePath: "proto/api/api.go", expectedMatch: false},
+		{filePath: "web/node_modules/react/index.js", expectedMatch: true},
+		{filePath: "main.go", expectedMatch: false},
+	}
+
+	for _, matchTest := range matchTests {
+		if matched := matchesDiffExcludePattern(patterns, matchTest.filePath); matched != matchTest.expectedMatch {
+			t.Errorf("Match result does not match expected value for path %v. Expected: %v, Actual: %v",
+				matchTest.filePath, matchTest.expectedMatch, matched)
+		}
+	}
+}
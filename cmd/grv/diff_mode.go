@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	dmOldModePrefix     = "old mode "
+	dmNewModePrefix     = "new mode "
+	dmNewFilePrefix     = "new file mode "
+	dmDeletedFilePrefix = "deleted file mode "
+	dmIndexPrefix       = "index "
+	dmSymlinkMode       = "120000"
+)
+
+var fileModeDescriptions = map[string]string{
+	"100644":      "regular file",
+	"100755":      "executable file",
+	dmSymlinkMode: "symlink",
+	"160000":      "submodule",
+}
+
+// fileModeDescription returns a human readable description of a git file mode
+func fileModeDescription(mode string) string {
+	if description, ok := fileModeDescriptions[mode]; ok {
+		return description
+	}
+
+	return "file with mode " + mode
+}
+
+// describeFileModes replaces the file mode extended headers of a single file patch with human readable lines.
+// If the file is a symlink then a line describing the change to its target is added after the extended headers
+func describeFileModes(lines []*diffLineData) []*diffLineData {
+	var describedLines []*diffLineData
+	var oldMode string
+	var oldTarget, newTarget string
+	isSymlink := false
+	headerEndIndex := -1
+
+	for _, diffLine := range lines {
+		line := diffLine.line
+
+		switch {
+		case headerEndIndex != -1:
+			if strings.HasPrefix(line, "-") && !strings.HasPrefix(line, "---") {
+				oldTarget = line[1:]
+			} else if strings.HasPrefix(line, "+") && !strings.HasPrefix(line, "+++") {
+				newTarget = line[1:]
+			}
+		case strings.HasPrefix(line, "---") || strings.HasPrefix(line, "@@"):
+			headerEndIndex = len(describedLines)
+		case strings.HasPrefix(line, dmOldModePrefix):
+			oldMode = strings.TrimPrefix(line, dmOldModePrefix)
+			isSymlink = isSymlink || oldMode == dmSymlinkMode
+			continue
+		case strings.HasPrefix(line, dmNewModePrefix):
+			newMode := strings.TrimPrefix(line, dmNewModePrefix)
+			isSymlink = isSymlink || newMode == dmSymlinkMode
+			diffLine = modeChangeLine("Mode changed from %v to %v (%v → %v)",
+				fileModeDescription(oldMode), fileModeDescription(newMode), oldMode, newMode)
+		case strings.HasPrefix(line, dmNewFilePrefix):
+			mode := strings.TrimPrefix(line, dmNewFilePrefix)
+			isSymlink = mode == dmSymlinkMode
+			diffLine = modeChangeLine("New %v (%v)", fileModeDescription(mode), mode)
+		case strings.HasPrefix(line, dmDeletedFilePrefix):
+			mode := strings.TrimPrefix(line, dmDeletedFilePrefix)
+			isSymlink = mode == dmSymlinkMode
+			diffLine = modeChangeLine("Deleted %v (%v)", fileModeDescription(mode), mode)
+		case strings.HasPrefix(line, dmIndexPrefix):
+			isSymlink = isSymlink || strings.HasSuffix(line, " "+dmSymlinkMode)
+		}
+
+		describedLines = append(describedLines, diffLine)
+	}
+
+	if !isSymlink || (oldTarget == "" && newTarget == "") {
+		return describedLines
+	}
+
+	var targetLine *diffLineData
+	switch {
+	case oldTarget == "":
+		targetLine = modeChangeLine("Symlink target: %v", newTarget)
+	case newTarget == "":
+		targetLine = modeChangeLine("Symlink target was: %v", oldTarget)
+	default:
+		targetLine = modeChangeLine("Symlink target changed from %v to %v", oldTarget, newTarget)
+	}
+
+	if headerEndIndex == -1 {
+		return append(describedLines, targetLine)
+	}
+
+	describedLines = append(describedLines, nil)
+	copy(describedLines[headerEndIndex+1:], describedLines[headerEndIndex:])
+	describedLines[headerEndIndex] = targetLine
+
+	return describedLines
+}
+
+func modeChangeLine(format string, args ...interface{}) *diffLineData {
+	return &diffLineData{
+		line:     fmt.Sprintf(format, args...),
+		lineType: dltDiffModeChange,
+	}
+}
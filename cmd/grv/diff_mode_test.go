@@ -0,0 +1,122 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDescribeFileModes(t *testing.T) {
+	var describeTests = []struct {
+		patch         []string
+		expectedLines []string
+	}{
+		{
+			patch: []string{
+				"diff --git a/build.sh b/build.sh",
+				"old mode 100644",
+				"new mode 100755",
+			},
+			expectedLines: []string{
+				"diff --git a/build.sh b/build.sh",
+				"Mode changed from regular file to executable file (100644 → 100755)",
+			},
+		},
+		{
+			patch: []string{
+				"diff --git a/latest b/latest",
+				"index 3b18e51..a9c2f1d 120000",
+				"--- a/latest",
+				"+++ b/latest",
+				"@@ -1 +1 @@",
+				"-v1.0",
+				"\\ No newline at end of file",
+				"+v2.0",
+				"\\ No newline at end of file",
+			},
+			expectedLines: []string{
+				"diff --git a/latest b/latest",
+				"index 3b18e51..a9c2f1d 120000",
+				"Symlink target changed from v1.0 to v2.0",
+				"--- a/latest",
+				"+++ b/latest",
+				"@@ -1 +1 @@",
+				"-v1.0",
+				"\\ No newline at end of file",
+				"+v2.0",
+				"\\ No newline at end of file",
+			},
+		},
+		{
+			patch: []string{
+				"diff --git a/current b/current",
+				"new file mode 120000",
+				"index 0000000..a9c2f1d",
+				"--- /dev/null",
+				"+++ b/current",
+				"@@ -0,0 +1 @@",
+				"+releases/v2.0",
+			},
+			expectedLines: []string{
+				"diff --git a/current b/current",
+				"New symlink (120000)",
+				"index 0000000..a9c2f1d",
+				"Symlink target: releases/v2.0",
+				"--- /dev/null",
+				"+++ b/current",
+				"@@ -0,0 +1 @@",
+				"+releases/v2.0",
+			},
+		},
+		{
+			patch: []string{
+				"diff --git a/main.go b/main.go",
+				"deleted file mode 100644",
+				"index 3b18e51..0000000",
+				"--- a/main.go",
+				"+++ /dev/null",
+				"@@ -1 +0,0 @@",
+				"-package main",
+			},
+			expectedLines: []string{
+				"diff --git a/main.go b/main.go",
+				"Deleted regular file (100644)",
+				"index 3b18e51..0000000",
+				"--- a/main.go",
+				"+++ /dev/null",
+				"@@ -1 +0,0 @@",
+				"-package main",
+			},
+		},
+	}
+
+	for _, describeTest := range describeTests {
+		var lines []*diffLineData
+		for _, line := range describeTest.patch {
+			lines = append(lines, &diffLineData{line: line})
+		}
+
+		describedLines := describeFileModes(lines)
+
+		if len(describedLines) != len(describeTest.expectedLines) {
+			t.Errorf("Line count does not match expected value for patch %v. Expected: %v, Actual: %v",
+				describeTest.patch, len(describeTest.expectedLines), len(describedLines))
+			continue
+		}
+
+		for lineIndex, describedLine := range describedLines {
+			if describedLine.line != describeTest.expectedLines[lineIndex] {
+				t.Errorf("Line does not match expected value. Expected: %v, Actual: %v", describeTest.expectedLines[lineIndex], describedLine.line)
+			}
+		}
+	}
+}
+
+func TestModeChangeLinesHaveModeChangeLineType(t *testing.T) {
+	lines := describeFileModes([]*diffLineData{
+		{line: "diff --git a/build.sh b/build.sh"},
+		{line: "new file mode 100755"},
+	})
+
+	if lineType := lines[1].lineType; lineType != dltDiffModeChange {
+		t.Errorf("Line type does not match expected value. Expected: %v, Actual: %v", dltDiffModeChange, lineType)
+	}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// renderPatch formats the provided patch using the diff renderer command
+// The renderer is run by the shell with the patch on its standard input and each line of its output
+// is returned with the colors of any ANSI escape sequences translated to theme components
+func renderPatch(ctx context.Context, renderer string, patch []byte) (lines []*diffLineData, err error) {
+	cmd := exec.CommandContext(ctx, "sh", "-c", renderer)
+	cmd.Stdin = bytes.NewReader(patch)
+
+	output, err := cmd.Output()
+	if err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok && len(exitErr.Stderr) > 0 {
+			err = fmt.Errorf("%v: %v", err, strings.TrimSpace(string(exitErr.Stderr)))
+		}
+
+		return nil, fmt.Errorf("Diff renderer \"%v\" failed: %v", renderer, err)
+	}
+
+	return renderedDiffLines(output), nil
+}
+
+// renderedDiffLines splits the output of a diff renderer into diff lines
+func renderedDiffLines(output []byte) (lines []*diffLineData) {
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+
+	for scanner.Scan() {
+		line, segments := ParseANSIText(scanner.Text())
+
+		lines = append(lines, &diffLineData{
+			line:     line,
+			segments: segments,
+			rendered: true,
+		})
+	}
+
+	return
+}
+
+// appendPatchLines appends the lines of the provided file patches, formatted by the diff renderer if one is set
+// The built-in formatting is used if no renderer is set or the renderer fails.
+// The diff view lock is not required to be held when calling this method
+func (diffView *DiffView) appendPatchLines(ctx context.Context, lines []*diffLineData, patches ...*bytes.Buffer) []*diffLineData {
+	if renderer := diffView.config.GetString(CfDiffRenderer); renderer != "" {
+		var patch bytes.Buffer
+		for _, filePatch := range patches {
+			patch.Write(filePatch.Bytes())
+		}
+
+		renderedLines, err := renderPatch(ctx, renderer, patch.Bytes())
+		if err == nil {
+			return append(lines, renderedLines...)
+		} else if ctx.Err() == nil {
+			log.Errorf("%v", err)
+			diffView.channels.ReportError(err)
+		}
+	}
+
+	for _, patch := range patches {
+		lines = appendDiffTextLines(lines, patch)
+	}
+
+	return lines
+}
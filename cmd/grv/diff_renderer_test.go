@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"testing"
+)
+
+func TestRenderPatchReturnsRendererOutputLines(t *testing.T) {
+	patch := "@@ -1 +1 @@\n-old\n+new\n"
+
+	lines, err := renderPatch(context.Background(), `while IFS= read -r line; do case "$line" in +*) printf '\033[32m%s\n' "$line";; *) printf '%s\n' "$line";; esac; done`, []byte(patch))
+	if err != nil {
+		t.Fatalf("Render patch failed: %v", err)
+	}
+
+	expectedLines := []string{"@@ -1 +1 @@", "-old", "+new"}
+
+	if len(lines) != len(expectedLines) {
+		t.Fatalf("Line count does not match expected value. Expected: %v, Actual: %v", len(expectedLines), len(lines))
+	}
+
+	for lineIndex, line := range lines {
+		if line.line != expectedLines[lineIndex] {
+			t.Errorf("Line does not match expected value. Expected: %v, Actual: %v", expectedLines[lineIndex], line.line)
+		}
+
+		if !line.rendered {
+			t.Errorf("Expected line %v to be marked as rendered", line.line)
+		}
+	}
+
+	if segments := lines[2].segments; len(segments) != 1 || segments[0].themeComponentID != CmpAllviewAnsiGreen {
+		t.Errorf("Expected added line to be displayed with the green ANSI theme component but found segments: %v", segments)
+	}
+}
+
+func TestRenderPatchReturnsErrorWhenRendererFails(t *testing.T) {
+	if _, err := renderPatch(context.Background(), "echo failure >&2; exit 1", []byte("-old\n")); err == nil {
+		t.Errorf("Expected error when renderer fails")
+	}
+}
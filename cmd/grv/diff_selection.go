@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// diffSelection is a range of lines selected in visual selection mode
+// The selection extends from the anchor line to the active line of the diff lines it was started on
+type diffSelection struct {
+	lines       *diffLines
+	anchorIndex uint
+}
+
+// lineRange returns the indexes of the first and last selected lines
+func (selection *diffSelection) lineRange(activeRowIndex uint) (startIndex, endIndex uint) {
+	if activeRowIndex < selection.anchorIndex {
+		return activeRowIndex, selection.anchorIndex
+	}
+
+	return selection.anchorIndex, activeRowIndex
+}
+
+// selectedLineRange returns the range of lines selected in the displayed diff
+// The diff view lock must be held when calling this method
+func (diffView *DiffView) selectedLineRange() (startIndex, endIndex uint, isSelected bool) {
+	selection := diffView.selection
+	if selection == nil || selection.lines != diffView.activeDiffLines() {
+		return
+	}
+
+	startIndex, endIndex = selection.lineRange(diffView.viewPos.ActiveRowIndex())
+	return startIndex, endIndex, true
+}
+
+// selectedText returns the text of the selected lines, or the active line if no lines are selected
+// The selection is cleared. The diff view lock must be held when calling this method
+func (diffView *DiffView) selectedText() (text string, lineCount uint, err error) {
+	diffLines := diffView.activeDiffLines()
+	if diffLines == nil || len(diffLines.lines) == 0 {
+		return "", 0, fmt.Errorf("No lines to select")
+	}
+
+	startIndex, endIndex, isSelected := diffView.selectedLineRange()
+	if !isSelected {
+		startIndex = diffView.viewPos.ActiveRowIndex()
+		endIndex = startIndex
+	}
+
+	diffView.selection = nil
+
+	return diffLinesText(diffLines.lines, startIndex, endIndex), endIndex - startIndex + 1, nil
+}
+
+// diffLinesText joins the lines in the range [startIndex, endIndex] with a trailing newline
+func diffLinesText(lines []*diffLineData, startIndex, endIndex uint) string {
+	var text []string
+
+	for lineIndex := startIndex; lineIndex <= endIndex && lineIndex < uint(len(lines)); lineIndex++ {
+		text = append(text, lines[lineIndex].line)
+	}
+
+	return strings.Join(text, "\n") + "\n"
+}
+
+func toggleVisualSelection(diffView *DiffView, action Action) (err error) {
+	if _, _, isSelected := diffView.selectedLineRange(); isSelected {
+		diffView.selection = nil
+		diffView.channels.ReportStatus("Selection cleared")
+	} else if diffLines := diffView.activeDiffLines(); diffLines != nil {
+		diffView.selection = &diffSelection{
+			lines:       diffLines,
+			anchorIndex: diffView.viewPos.ActiveRowIndex(),
+		}
+
+		diffView.channels.ReportStatus("Visual selection started")
+	}
+
+	diffView.channels.UpdateDisplay()
+
+	return
+}
+
+func yankDiffSelection(diffView *DiffView, action Action) (err error) {
+	text, lineCount, err := diffView.selectedText()
+	if err != nil {
+		return
+	}
+
+	diffView.channels.UpdateDisplay()
+
+	if err = Yank(diffView.config, text); err != nil {
+		return
+	}
+
+	diffView.channels.ReportStatus("Yanked %v lines", lineCount)
+
+	return
+}
+
+func pipeDiffSelection(diffView *DiffView, action Action) (err error) {
+	text, lineCount, err := diffView.selectedText()
+	if err != nil {
+		return
+	}
+
+	diffView.channels.UpdateDisplay()
+	diffView.channels.DoAction(Action{
+		ActionType: ActionShowDialog,
+		Args: []interface{}{DialogRequest{
+			dialogType: DtInput,
+			title:      "Pipe Selection",
+			message:    fmt.Sprintf("Enter a command to pipe %v lines to:", lineCount),
+			action:     Action{ActionType: ActionPipeText, Args: []interface{}{text}},
+		}},
+	})
+
+	return
+}
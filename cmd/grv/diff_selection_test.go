@@ -0,0 +1,49 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDiffSelectionLineRangeIsOrdered(t *testing.T) {
+	selection := &diffSelection{anchorIndex: 5}
+
+	var lineRangeTests = []struct {
+		activeRowIndex     uint
+		expectedStartIndex uint
+		expectedEndIndex   uint
+	}{
+		{activeRowIndex: 5, expectedStartIndex: 5, expectedEndIndex: 5},
+		{activeRowIndex: 8, expectedStartIndex: 5, expectedEndIndex: 8},
+		{activeRowIndex: 2, expectedStartIndex: 2, expectedEndIndex: 5},
+	}
+
+	for _, lineRangeTest := range lineRangeTests {
+		startIndex, endIndex := selection.lineRange(lineRangeTest.activeRowIndex)
+
+		if startIndex != lineRangeTest.expectedStartIndex || endIndex != lineRangeTest.expectedEndIndex {
+			t.Errorf("Line range does not match expected value for active row %v. Expected: %v-%v, Actual: %v-%v",
+				lineRangeTest.activeRowIndex, lineRangeTest.expectedStartIndex, lineRangeTest.expectedEndIndex, startIndex, endIndex)
+		}
+	}
+}
+
+func TestDiffLinesText(t *testing.T) {
+	lines := []*diffLineData{
+		{line: "@@ -1,2 +1,2 @@"},
+		{line: " context"},
+		{line: "-removed"},
+		{line: "+added"},
+	}
+
+	expectedText := " context\n-removed\n+added\n"
+
+	if text := diffLinesText(lines, 1, 3); text != expectedText {
+		t.Errorf("Text does not match expected value. Expected: %q, Actual: %q", expectedText, text)
+	}
+
+	expectedText = "+added\n"
+
+	if text := diffLinesText(lines, 3, 10); text != expectedText {
+		t.Errorf("Text does not match expected value. Expected: %q, Actual: %q", expectedText, text)
+	}
+}
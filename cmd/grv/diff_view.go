@@ -3,11 +3,16 @@ package main
 import (
 	"bufio"
 	"bytes"
+	"context"
 	"fmt"
+	"path"
+	"regexp"
 	"strings"
 	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
+	slice "github.com/bradfitz/slice"
 )
 
 type diffViewHandler func(*DiffView, Action) error
@@ -21,39 +26,77 @@ const (
 	dltDiffCommitAuthorDate
 	dltDiffCommitCommitter
 	dltDiffCommitCommitterDate
+	dltDiffCommitDescribe
+	dltDiffCommitTrailer
+	dltDiffCommitLint
 	dltDiffCommitSummary
 	dltDiffStatsFile
 	dltGitDiffHeader
 	dltGitDiffExtendedHeader
+	dltDiffModeChange
 	dltUnifiedDiffHeader
 	dltHunkStart
 	dltLineAdded
 	dltLineRemoved
+	dltDiffFile
 )
 
 const (
-	dvDateFormat = "Mon Jan 2 15:04:05 2006 -0700"
+	dvDateFormat            = "Mon Jan 2 15:04:05 2006 -0700"
+	dvDiffNotifyMinDuration = 2 * time.Second
+	dvDiffLineSizeOverhead  = 64
+	dvBytesPerMegabyte      = 1024 * 1024
+	dvDescribePending       = "..."
+	dvDescribeFailed        = "Unable to describe commit"
 )
 
+var diffExtendedHeaderPrefixes = []string{
+	"index",
+	"similarity index",
+	"dissimilarity index",
+	"rename from",
+	"rename to",
+	"copy from",
+	"copy to",
+	"old mode",
+	"new mode",
+	"new file mode",
+	"deleted file mode",
+	"Submodule ",
+	"LFS object ",
+	"Image before: ",
+	"Image after: ",
+}
+
 var diffLineThemeComponentID = map[diffLineType]ThemeComponentID{
 	dltNormal:                  CmpDiffviewDifflineNormal,
 	dltDiffCommitAuthor:        CmpDiffviewDifflineDiffCommitAuthor,
 	dltDiffCommitAuthorDate:    CmpDiffviewDifflineDiffCommitAuthorDate,
 	dltDiffCommitCommitter:     CmpDiffviewDifflineDiffCommitCommitter,
 	dltDiffCommitCommitterDate: CmpDiffviewDifflineDiffCommitCommitterDate,
+	dltDiffCommitDescribe:      CmpDiffviewDifflineDiffCommitDescribe,
+	dltDiffCommitTrailer:       CmpDiffviewDifflineDiffCommitTrailer,
+	dltDiffCommitLint:          CmpDiffviewDifflineDiffCommitLint,
 	dltDiffCommitSummary:       CmpDiffviewDifflineDiffCommitSummary,
 	dltDiffStatsFile:           CmpDiffviewDifflineDiffStatsFile,
 	dltGitDiffHeader:           CmpDiffviewDifflineGitDiffHeader,
 	dltGitDiffExtendedHeader:   CmpDiffviewDifflineGitDiffExtendedHeader,
+	dltDiffModeChange:          CmpDiffviewDifflineModeChange,
 	dltUnifiedDiffHeader:       CmpDiffviewDifflineUnifiedDiffHeader,
 	dltHunkStart:               CmpDiffviewDifflineHunkStart,
 	dltLineAdded:               CmpDiffviewDifflineLineAdded,
 	dltLineRemoved:             CmpDiffviewDifflineLineRemoved,
+	dltDiffFile:                CmpDiffviewDifflineDiffStatsFile,
 }
 
+// diffLineData is a line of a diff
+// Lines output by a diff renderer are displayed using their segments rather than formatted by line type
 type diffLineData struct {
 	line     string
 	lineType diffLineType
+	diffFile *DiffFile
+	segments []ansiSegment
+	rendered bool
 }
 
 func (diffLine *diffLineData) getThemeComponentID() ThemeComponentID {
@@ -72,7 +115,7 @@ func (diffLine *diffLineData) determineDiffLineType() {
 	switch {
 	case strings.HasPrefix(line, "diff --git"):
 		lineType = dltGitDiffHeader
-	case strings.HasPrefix(line, "index"):
+	case isDiffExtendedHeader(line):
 		lineType = dltGitDiffExtendedHeader
 	case strings.HasPrefix(line, "---") || strings.HasPrefix(line, "+++"):
 		lineType = dltUnifiedDiffHeader
@@ -89,51 +132,198 @@ func (diffLine *diffLineData) determineDiffLineType() {
 	diffLine.lineType = lineType
 }
 
+// DirectoryListener is notified when a directory is selected
+type DirectoryListener interface {
+	OnDirectorySelect(directory string) error
+}
+
+func isDiffExtendedHeader(line string) bool {
+	for _, prefix := range diffExtendedHeaderPrefixes {
+		if strings.HasPrefix(line, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// diffHeaderFilePaths extracts the old and new file paths from a git diff header line
+func diffHeaderFilePaths(line string) (oldFilePath, newFilePath string, ok bool) {
+	line = strings.TrimPrefix(line, "diff --git ")
+	pathIndex := strings.LastIndex(line, " b/")
+
+	if !strings.HasPrefix(line, "a/") || pathIndex == -1 {
+		return
+	}
+
+	return line[2:pathIndex], line[pathIndex+3:], true
+}
+
 type diffLines struct {
 	lines   []*diffLineData
 	viewPos ViewPos
+	size    uint64
+}
+
+// estimateDiffLinesSize returns the approximate number of bytes used by the provided diff lines
+func estimateDiffLinesSize(lines []*diffLineData) (size uint64) {
+	for _, line := range lines {
+		size += uint64(len(line.line)) + dvDiffLineSizeOverhead
+
+		if line.rendered {
+			size += uint64(len(line.line))
+		}
+	}
+
+	return
+}
+
+// diffEvictionCandidate is a cached commit diff which can be discarded to free memory
+type diffEvictionCandidate struct {
+	commit   *Commit
+	size     uint64
+	distance time.Duration
+}
+
+// selectDiffEvictions returns the commits whose diffs should be discarded to reduce totalSize to within budget
+// Diffs for commits furthest from the active commit are discarded first
+func selectDiffEvictions(candidates []diffEvictionCandidate, totalSize, budget uint64) (evicted []*Commit) {
+	slice.Sort(candidates, func(i, j int) bool {
+		return candidates[i].distance > candidates[j].distance
+	})
+
+	for _, candidate := range candidates {
+		if totalSize <= budget {
+			break
+		}
+
+		evicted = append(evicted, candidate.commit)
+		totalSize -= candidate.size
+	}
+
+	return
+}
+
+// revisionDiff contains the state of a comparison between two revisions
+type revisionDiff struct {
+	fromRevision string
+	toRevision   string
+	toCommit     *Commit
+	fileList     *diffLines
+	selectedFile *DiffFile
+	fileDiff     *diffLines
+}
+
+// lineHistory contains the commits which modified a line range of a file
+type lineHistory struct {
+	filePath  string
+	startLine uint
+	endLine   uint
+	lines     *diffLines
 }
 
 // DiffView contains all state for the diff view
 type DiffView struct {
-	channels      *Channels
-	repoData      RepoData
-	activeCommit  *Commit
-	commitDiffs   map[*Commit]*diffLines
-	viewPos       ViewPos
-	viewDimension ViewDimension
-	handlers      map[ActionType]diffViewHandler
-	active        bool
-	viewSearch    *ViewSearch
-	lock          sync.Mutex
+	channels           *Channels
+	repoData           RepoData
+	config             Config
+	activeCommit       *Commit
+	commitDiffs        map[*Commit]*diffLines
+	pendingDiffs       map[*Commit]bool
+	partialDiffs       map[*Commit]*diffLines
+	diffGeneration     uint
+	revisionDiffID     uint
+	cancelRevisionDiff func()
+	descriptions       map[*Commit]string
+	tagsLoaded         bool
+	describingCommit   *Commit
+	cancelDescribe     context.CancelFunc
+	whitespaceRules    WhitespaceRules
+	revisionDiff       *revisionDiff
+	lineHistory        *lineHistory
+	messageLines       *diffLines
+	restorePosition    *diffLinePosition
+	selection          *diffSelection
+	viewPos            ViewPos
+	viewDimension      ViewDimension
+	handlers           map[ActionType]diffViewHandler
+	active             bool
+	viewSearch         *ViewSearch
+	scrollBarMarks     scrollBarMarks
+	directoryListeners []DirectoryListener
+	notifier           *Notifier
+	progress           *ProgressTracker
+	canceller          *OperationCanceller
+	lock               sync.Mutex
 }
 
 // NewDiffView creates a new diff view instance
-func NewDiffView(repoData RepoData, channels *Channels) *DiffView {
+func NewDiffView(repoData RepoData, channels *Channels, config Config, canceller *OperationCanceller) *DiffView {
 	diffView := &DiffView{
-		repoData:    repoData,
-		channels:    channels,
-		viewPos:     NewViewPosition(),
-		commitDiffs: make(map[*Commit]*diffLines),
+		repoData:     repoData,
+		channels:     channels,
+		config:       config,
+		viewPos:      NewViewPosition(),
+		commitDiffs:  make(map[*Commit]*diffLines),
+		pendingDiffs: make(map[*Commit]bool),
+		partialDiffs: make(map[*Commit]*diffLines),
+		descriptions: make(map[*Commit]string),
+		notifier:     NewNotifier(config),
+		progress:     NewProgressTracker(channels),
+		canceller:    canceller,
 		handlers: map[ActionType]diffViewHandler{
-			ActionPrevLine:    moveUpDiffLine,
-			ActionNextLine:    moveDownDiffLine,
-			ActionPrevPage:    moveUpDiffPage,
-			ActionNextPage:    moveDownDiffPage,
-			ActionScrollRight: scrollDiffViewRight,
-			ActionScrollLeft:  scrollDiffViewLeft,
-			ActionFirstLine:   moveToFirstDiffLine,
-			ActionLastLine:    moveToLastDiffLine,
+			ActionPrevLine:              moveUpDiffLine,
+			ActionNextLine:              moveDownDiffLine,
+			ActionPrevPage:              moveUpDiffPage,
+			ActionNextPage:              moveDownDiffPage,
+			ActionScrollHalfPageUp:      moveUpDiffHalfPage,
+			ActionScrollHalfPageDown:    moveDownDiffHalfPage,
+			ActionCenterView:            centerDiffView,
+			ActionScrollCursorTop:       scrollDiffViewCursorTop,
+			ActionScrollCursorBottom:    scrollDiffViewCursorBottom,
+			ActionScrollRight:           scrollDiffViewRight,
+			ActionScrollLeft:            scrollDiffViewLeft,
+			ActionFirstLine:             moveToFirstDiffLine,
+			ActionLastLine:              moveToLastDiffLine,
+			ActionFilterDirectory:       filterCommitsByDirectory,
+			ActionDiffRevisions:         diffRevisions,
+			ActionSelect:                selectDiffFile,
+			ActionBack:                  moveBackDiffView,
+			ActionLineHistory:           showLineHistory,
+			ActionVisualSelection:       toggleVisualSelection,
+			ActionYankSelection:         yankDiffSelection,
+			ActionPipeSelection:         pipeDiffSelection,
+			ActionPageDiff:              pageFullDiff,
+			ActionPageDiffFile:          pageSelectedFileDiff,
+			ActionPreviewImage:          previewImage,
+			ActionShowFile:              showDiffFile,
+			ActionShowLineHistory:       showRequestedLineHistory,
+			ActionDirectoryOwnership:    showDiffDirectoryOwnership,
+			ActionQuickfixHunks:         showQuickfixHunks,
+			ActionQuickfixSearchMatches: showQuickfixSearchMatches,
+			ActionJumpToQuickfixEntry:   jumpToQuickfixEntry,
 		},
 	}
 
-	diffView.viewSearch = NewViewSearch(diffView, channels)
+	diffView.viewSearch = NewViewSearch(diffView, channels, canceller)
+
+	for _, configVariable := range []ConfigVariable{CfDiffRenames, CfDiffCopies, CfDiffSimilarityThreshold, CfDiffContext, CfDiffExclude, CfDiffTextconv, CfDiffIdentityFormat, CfCommitLint, CfCommitLintSubjectLength, CfDiffRenderer} {
+		config.AddOnChangeListener(configVariable, diffView)
+	}
+
+	config.AddOnChangeListener(CfMessagePreview, diffView)
+	config.AddOnChangeListener(CfMemoryBudget, diffView)
 
 	return diffView
 }
 
-// Initialise does nothing
+// Initialise loads the whitespace rules used to highlight whitespace errors
 func (diffView *DiffView) Initialise() (err error) {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	diffView.whitespaceRules = diffView.repoData.WhitespaceRules()
+
 	return
 }
 
@@ -144,384 +334,1873 @@ func (diffView *DiffView) Render(win RenderWindow) (err error) {
 
 	diffView.viewDimension = win.ViewDimensions()
 
-	if diffView.activeCommit == nil {
+	diffLines := diffView.activeDiffLines()
+	if diffLines == nil {
+		if diffView.activeCommit != nil {
+			err = diffView.renderPendingDiff(win)
+		}
+
 		return
 	}
 
 	rows := win.Rows() - 2
 	viewPos := diffView.viewPos
-	diffLines := diffView.commitDiffs[diffView.activeCommit]
 	lineNum := uint(len(diffLines.lines))
-	viewPos.DetermineViewStartRow(rows, lineNum)
+	scrollOff := uint(diffView.config.GetInt(CfScrollOff))
+	stickyFileHeader := diffView.config.GetBool(CfDiffStickyFileHeader)
+	stickyHunkHeader := diffView.config.GetBool(CfDiffStickyHunkHeader)
+
+	// The rows occupied by sticky headers depend on the first line displayed, so the
+	// view start row is recalculated if pinning headers changed the number available
+	stickyLineIndexes := stickyHeaderLineIndexes(diffLines.lines, viewPos.ViewStartRowIndex(), stickyFileHeader, stickyHunkHeader)
+	for attempt := 0; attempt < 3; attempt++ {
+		stickyRows := Min(uint(len(stickyLineIndexes)), rows)
+		viewPos.DetermineViewStartRow(rows-stickyRows, lineNum, scrollOff)
+
+		stickyLineIndexes = stickyHeaderLineIndexes(diffLines.lines, viewPos.ViewStartRowIndex(), stickyFileHeader, stickyHunkHeader)
+		if uint(len(stickyLineIndexes)) == stickyRows {
+			break
+		}
+	}
 
+	if uint(len(stickyLineIndexes)) >= rows {
+		stickyLineIndexes = nil
+		viewPos.DetermineViewStartRow(rows, lineNum, scrollOff)
+	}
+
+	stickyRows := uint(len(stickyLineIndexes))
 	lineIndex := viewPos.ViewStartRowIndex()
 	startColumn := viewPos.ViewStartColumn()
+	lineNumberGutter := NewLineNumberGutter(diffView.config.GetBool(CfNumber),
+		diffView.config.GetBool(CfRelativeNumber), viewPos.ActiveRowIndex(), lineNum)
 
-	for rowIndex := uint(0); rowIndex < rows && lineIndex < lineNum; rowIndex++ {
-		diffLine := diffLines.lines[lineIndex]
-		themeComponentID := diffLine.getThemeComponentID()
-
-		if diffLine.lineType == dltHunkStart {
-			lineParts := strings.SplitAfter(diffLine.line, "@@")
-
-			if len(lineParts) != 3 {
-				return fmt.Errorf("Unable to display hunk header line: %v", diffLine.line)
-			}
-
-			var lineBuilder *LineBuilder
-			if lineBuilder, err = win.LineBuilder(rowIndex+1, startColumn); err != nil {
-				return
-			}
+	for rowIndex, stickyLineIndex := range stickyLineIndexes {
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(uint(rowIndex)+1, startColumn); err != nil {
+			return
+		}
 
-			lineBuilder.
-				AppendWithStyle(themeComponentID, " %v", strings.Join(lineParts[:2], "")).
-				AppendWithStyle(CmpDiffviewDifflineHunkHeader, "%v", lineParts[2])
+		lineNumberGutter.Render(lineBuilder, stickyLineIndex)
 
-		} else if diffLine.lineType == dltDiffStatsFile {
-			sepIndex := strings.LastIndex(diffLine.line, "|")
+		if err = renderDiffLine(lineBuilder, diffLines.lines[stickyLineIndex], nil); err != nil {
+			return
+		}
+	}
 
-			if sepIndex == -1 || sepIndex >= len(diffLine.line)-1 {
-				return fmt.Errorf("Unable to display diff stats file line: %v", diffLine.line)
-			}
+	var whitespaceRules *WhitespaceRules
+	if diffView.config.GetBool(CfWhitespaceHighlight) {
+		whitespaceRules = &diffView.whitespaceRules
+	}
 
-			filePart := diffLine.line[0:sepIndex]
-			changePart := diffLine.line[sepIndex+1:]
+	selectionStartIndex, selectionEndIndex, isSelected := diffView.selectedLineRange()
 
-			var lineBuilder *LineBuilder
-			if lineBuilder, err = win.LineBuilder(rowIndex+1, startColumn); err != nil {
-				return
-			}
+	for rowIndex := stickyRows; rowIndex < rows && lineIndex < lineNum; rowIndex++ {
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(rowIndex+1, startColumn); err != nil {
+			return
+		}
 
-			lineBuilder.AppendWithStyle(CmpDiffviewDifflineDiffStatsFile, " %v |", filePart)
+		lineNumberGutter.Render(lineBuilder, lineIndex)
 
-			for _, char := range changePart {
-				switch char {
-				case '+':
-					lineBuilder.AppendWithStyle(CmpDiffviewDifflineLineAdded, "%c", char)
-				case '-':
-					lineBuilder.AppendWithStyle(CmpDiffviewDifflineLineRemoved, "%c", char)
-				default:
-					lineBuilder.Append("%c", char)
-				}
-			}
-		} else if err = win.SetRow(rowIndex+1, startColumn, themeComponentID, " %v", diffLines.lines[lineIndex].line); err != nil {
+		if isSelected && lineIndex >= selectionStartIndex && lineIndex <= selectionEndIndex {
+			lineBuilder.AppendWithStyle(CmpDiffviewSelection, " %v", diffLines.lines[lineIndex].line)
+		} else if err = renderDiffLine(lineBuilder, diffLines.lines[lineIndex], whitespaceRules); err != nil {
 			return
 		}
 
 		lineIndex++
 	}
 
-	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, diffView.active); err != nil {
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+stickyRows+1, diffView.active); err != nil {
 		return
 	}
 
 	win.DrawBorder()
 
-	if err = win.SetTitle(CmpCommitviewTitle, "Diff for commit %v", diffView.activeCommit.commit.Id().String()); err != nil {
+	if err = diffView.renderTitle(win); err != nil {
 		return
 	}
 
-	if err = win.SetFooter(CmpCommitviewFooter, "Line %v of %v", viewPos.ActiveRowIndex()+1, lineNum); err != nil {
+	if err = diffView.renderFooter(win); err != nil {
 		return
 	}
 
-	if searchActive, searchPattern, lastSearchFoundMatch := diffView.viewSearch.SearchActive(); searchActive && lastSearchFoundMatch {
+	searchActive, searchPattern, lastSearchFoundMatch := diffView.viewSearch.SearchActive()
+	if searchActive && lastSearchFoundMatch {
 		if err = win.Highlight(searchPattern, CmpAllviewSearchMatch); err != nil {
 			return
 		}
+	} else {
+		searchPattern = ""
 	}
 
-	return
-}
+	if diffView.config.GetBool(CfScrollBar) {
+		marks := diffView.scrollBarMarks.Update(diffLines, searchPattern, lineNum, func(lineIndex uint, searchRegex *regexp.Regexp) ScrollBarMarkType {
+			return diffLineScrollBarMark(diffLines.lines[lineIndex], searchRegex)
+		})
 
-// RenderStatusBar does nothing
-func (diffView *DiffView) RenderStatusBar(lineBuilder *LineBuilder) (err error) {
-	return
-}
+		err = NewScrollBar(lineNum, viewPos.ViewStartRowIndex(), rows, marks).Render(win, diffView.config)
+	}
 
-// RenderHelpBar does nothing
-func (diffView *DiffView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
 	return
 }
 
-// OnActiveChange sets whether the diff view is the active view or not
-func (diffView *DiffView) OnActiveChange(active bool) {
-	log.Debugf("DiffView active: %v", active)
-	diffView.lock.Lock()
-	defer diffView.lock.Unlock()
+// diffLineScrollBarMark returns the type of mark displayed in the scroll bar for the diff line
+func diffLineScrollBarMark(diffLine *diffLineData, searchRegex *regexp.Regexp) ScrollBarMarkType {
+	if searchRegex != nil && searchRegex.MatchString(diffLine.line) {
+		return SbmSearchMatch
+	}
 
-	diffView.active = active
-}
+	if diffLine.determineDiffLineType(); diffLine.lineType == dltHunkStart {
+		return SbmHunk
+	}
 
-// ViewID returns the diff views ID
-func (diffView *DiffView) ViewID() ViewID {
-	return ViewDiff
+	return SbmNone
 }
 
-// OnCommitSelect loads/fetches the diff for the selected commit and refreshes the display
-func (diffView *DiffView) OnCommitSelect(commit *Commit) (err error) {
-	log.Debugf("DiffView loading diff for selected commit %v", commit.commit.Id())
-
-	diffView.lock.Lock()
-	defer diffView.lock.Unlock()
-
-	if diffLines, ok := diffView.commitDiffs[diffView.activeCommit]; ok {
-		diffLines.viewPos = diffView.viewPos
-	}
-
-	if diffLines, ok := diffView.commitDiffs[commit]; ok {
-		diffView.activeCommit = commit
-		diffView.viewPos = diffLines.viewPos
-		diffView.channels.UpdateDisplay()
-		return
-	}
+// renderPendingDiff draws the border, title and footer of the view while the diff is generated
+func (diffView *DiffView) renderPendingDiff(win RenderWindow) (err error) {
+	win.DrawBorder()
 
-	if err = diffView.generateDiffLines(commit); err != nil {
+	if err = diffView.renderTitle(win); err != nil {
 		return
 	}
 
-	diffView.activeCommit = commit
-	diffView.viewPos = NewViewPosition()
-	diffView.channels.UpdateDisplay()
-
-	return
+	return diffView.renderFooter(win)
 }
 
-func (diffView *DiffView) generateDiffLines(commit *Commit) (err error) {
-	var lines []*diffLineData
-
-	author := commit.commit.Author()
-	committer := commit.commit.Committer()
+func (diffView *DiffView) renderFooter(win RenderWindow) error {
+	values := make(StatuslineValues)
+	diffView.statuslineValues(values)
 
-	lines = append(lines,
-		&diffLineData{
-			line:     fmt.Sprintf("Author:\t%v <%v>", author.Name, author.Email),
-			lineType: dltDiffCommitAuthor,
-		},
-		&diffLineData{
-			line:     fmt.Sprintf("AuthorDate:\t%v", author.When.Format(dvDateFormat)),
-			lineType: dltDiffCommitAuthorDate,
-		},
-		&diffLineData{
-			line:     fmt.Sprintf("Committer:\t%v <%v>", committer.Name, committer.Email),
-			lineType: dltDiffCommitCommitter,
-		},
-		&diffLineData{
-			line:     fmt.Sprintf("CommitterDate:\t%v", committer.When.Format(dvDateFormat)),
-			lineType: dltDiffCommitCommitterDate,
-		},
-		&diffLineData{
-			lineType: dltNormal,
-		},
-		&diffLineData{
-			line:     commit.commit.Summary(),
-			lineType: dltDiffCommitSummary,
-		},
-		&diffLineData{
-			lineType: dltNormal,
-		},
-	)
+	return win.SetFooter(CmpCommitviewFooter, "%v", expandFooter(diffView.config.GetString(CfDiffViewFooter), values))
+}
 
-	diff, err := diffView.repoData.Diff(commit)
-	if err != nil {
+// renderDiffLine writes the diff line to the line builder
+// Whitespace errors in added lines are highlighted if whitespace rules are provided
+func renderDiffLine(lineBuilder *LineBuilder, diffLine *diffLineData, whitespaceRules *WhitespaceRules) (err error) {
+	if diffLine.rendered {
+		lineBuilder.Append(" ").appendSegments(CmpDiffviewDifflineNormal, diffLine.segments)
 		return
 	}
 
-	scanner := bufio.NewScanner(bytes.NewReader(diff.stats.Bytes()))
+	themeComponentID := diffLine.getThemeComponentID()
 
-	for scanner.Scan() {
-		lines = append(lines, &diffLineData{
-			line:     strings.TrimPrefix(scanner.Text(), " "),
-			lineType: dltDiffStatsFile,
-		})
-	}
+	if diffLine.lineType == dltHunkStart {
+		lineParts := strings.SplitAfter(diffLine.line, "@@")
 
-	if len(lines) > 0 {
-		prevLine := lines[len(lines)-1]
+		if len(lineParts) != 3 {
+			return fmt.Errorf("Unable to display hunk header line: %v", diffLine.line)
+		}
 
-		if prevLine.lineType == dltDiffStatsFile {
-			prevLine.lineType = dltNormal
+		lineBuilder.
+			AppendWithStyle(themeComponentID, " %v", strings.Join(lineParts[:2], "")).
+			AppendWithStyle(CmpDiffviewDifflineHunkHeader, "%v", lineParts[2])
+
+	} else if diffLine.lineType == dltGitDiffHeader {
+		line := diffLine.line
+
+		if oldFilePath, newFilePath, ok := diffHeaderFilePaths(line); ok && oldFilePath != newFilePath {
+			line = fmt.Sprintf("diff --git %v → %v", oldFilePath, newFilePath)
 		}
-	}
 
-	lines = append(lines, &diffLineData{
-		lineType: dltNormal,
-	})
+		lineBuilder.AppendWithStyle(themeComponentID, " %v", line)
+	} else if diffLine.lineType == dltDiffStatsFile {
+		sepIndex := strings.LastIndex(diffLine.line, "|")
 
-	scanner = bufio.NewScanner(bytes.NewReader(diff.diffText.Bytes()))
+		if sepIndex == -1 || sepIndex >= len(diffLine.line)-1 {
+			return fmt.Errorf("Unable to display diff stats file line: %v", diffLine.line)
+		}
 
-	for scanner.Scan() {
-		lines = append(lines, &diffLineData{
-			line: scanner.Text(),
-		})
-	}
+		filePart := diffLine.line[0:sepIndex]
+		changePart := diffLine.line[sepIndex+1:]
 
-	diffView.commitDiffs[commit] = &diffLines{
-		lines: lines,
+		lineBuilder.AppendWithStyle(CmpDiffviewDifflineDiffStatsFile, " %v |", filePart)
+
+		for _, char := range changePart {
+			switch char {
+			case '+':
+				lineBuilder.AppendWithStyle(CmpDiffviewDifflineLineAdded, "%c", char)
+			case '-':
+				lineBuilder.AppendWithStyle(CmpDiffviewDifflineLineRemoved, "%c", char)
+			default:
+				lineBuilder.Append("%c", char)
+			}
+		}
+	} else if diffLine.lineType == dltLineAdded && whitespaceRules != nil {
+		renderAddedLine(lineBuilder, diffLine.line, whitespaceRules)
+	} else if isDiffContentLine(diffLine) {
+		lineBuilder.
+			AppendWithStyle(themeComponentID, " %v", diffLine.line[:1]).
+			StartInvisibles().
+			AppendWithStyle(themeComponentID, "%v", diffLine.line[1:]).
+			EndInvisibles()
+	} else {
+		lineBuilder.AppendWithStyle(themeComponentID, " %v", diffLine.line)
 	}
 
 	return
 }
 
-// HandleKeyPress does nothing
-func (diffView *DiffView) HandleKeyPress(keystring string) (err error) {
-	log.Debugf("DiffView handling key %v - NOP", keystring)
-	return
-}
+// isDiffContentLine returns true if the line is an added, removed or context line of a hunk
+func isDiffContentLine(diffLine *diffLineData) bool {
+	switch diffLine.lineType {
+	case dltLineAdded, dltLineRemoved:
+		return true
+	case dltNormal:
+		return strings.HasPrefix(diffLine.line, " ")
+	}
 
-// ViewPos returns the current view position
-func (diffView *DiffView) ViewPos() ViewPos {
-	return diffView.viewPos
+	return false
 }
 
-// OnSearchMatch sets the current view position to the search match position
-func (diffView *DiffView) OnSearchMatch(startPos ViewPos, matchLineIndex uint) {
-	diffView.lock.Lock()
-	defer diffView.lock.Unlock()
+// renderAddedLine writes an added line to the line builder with any whitespace errors highlighted
+func renderAddedLine(lineBuilder *LineBuilder, line string, whitespaceRules *WhitespaceRules) {
+	content := strings.TrimPrefix(line, "+")
+	whitespaceErrors, found := whitespaceRules.WhitespaceErrors(content)
 
-	viewPos := diffView.ViewPos()
+	lineBuilder.AppendWithStyle(CmpDiffviewDifflineLineAdded, " +").StartInvisibles()
+	defer lineBuilder.EndInvisibles()
 
-	if viewPos != startPos {
-		log.Debugf("Selected ref has changed since search started")
+	if !found {
+		lineBuilder.AppendWithStyle(CmpDiffviewDifflineLineAdded, "%v", content)
 		return
 	}
 
-	viewPos.SetActiveRowIndex(matchLineIndex)
-}
+	for start := 0; start < len(content); {
+		end := start + 1
+		for end < len(content) && whitespaceErrors[end] == whitespaceErrors[start] {
+			end++
+		}
 
-// HandleAction checks if the diff view supports the provided action and executes it if so
-func (diffView *DiffView) HandleAction(action Action) (err error) {
-	log.Debugf("DiffView handling action %v", action)
-	diffView.lock.Lock()
-	defer diffView.lock.Unlock()
+		themeComponentID := CmpDiffviewDifflineLineAdded
+		if whitespaceErrors[start] {
+			themeComponentID = CmpDiffviewDifflineWhitespaceError
+		}
 
-	if handler, ok := diffView.handlers[action.ActionType]; ok {
-		err = handler(diffView, action)
-	} else {
-		_, err = diffView.viewSearch.HandleAction(action)
+		lineBuilder.AppendWithStyle(themeComponentID, "%v", content[start:end])
+		start = end
 	}
-
-	return
 }
 
-// Line returns the rendered line from the diff view at the specified line index
-func (diffView *DiffView) Line(lineIndex uint) (line string) {
-	diffView.lock.Lock()
-	defer diffView.lock.Unlock()
+// stickyHeaderLineIndexes returns the indexes of the file and hunk header lines the line at
+// viewStartRowIndex belongs to which have scrolled out of view and should be pinned to the top
+func stickyHeaderLineIndexes(lines []*diffLineData, viewStartRowIndex uint, fileHeader, hunkHeader bool) (lineIndexes []uint) {
+	if (!fileHeader && !hunkHeader) || viewStartRowIndex >= uint(len(lines)) {
+		return
+	}
 
-	diffLines := diffView.commitDiffs[diffView.activeCommit]
-	lineNum := uint(len(diffLines.lines))
+	fileHeaderIndex := -1
+	hunkHeaderIndex := -1
 
-	if lineIndex >= lineNum {
-		log.Errorf("Invalid lineIndex: %v", lineIndex)
+	for lineIndex := int(viewStartRowIndex); lineIndex >= 0; lineIndex-- {
+		diffLine := lines[lineIndex]
+		diffLine.determineDiffLineType()
+
+		if diffLine.lineType == dltGitDiffHeader {
+			fileHeaderIndex = lineIndex
+			break
+		} else if diffLine.lineType == dltHunkStart && hunkHeaderIndex == -1 {
+			hunkHeaderIndex = lineIndex
+		}
+	}
+
+	if fileHeaderIndex == -1 {
 		return
 	}
 
-	diffLine := diffLines.lines[lineIndex]
-	line = diffLine.line
+	if fileHeader && fileHeaderIndex < int(viewStartRowIndex) {
+		lineIndexes = append(lineIndexes, uint(fileHeaderIndex))
+	}
+
+	if hunkHeader && hunkHeaderIndex != -1 && hunkHeaderIndex < int(viewStartRowIndex) {
+		lineIndexes = append(lineIndexes, uint(hunkHeaderIndex))
+	}
 
 	return
 }
 
-// LineNumber returns the number of lines the diff view currently has
-func (diffView *DiffView) LineNumber() (lineNumber uint) {
-	diffView.lock.Lock()
-	defer diffView.lock.Unlock()
+func (diffView *DiffView) renderTitle(win RenderWindow) error {
+	revisionDiff := diffView.revisionDiff
 
-	diffLines := diffView.commitDiffs[diffView.activeCommit]
-	lineNum := uint(len(diffLines.lines))
-
-	return lineNum
-}
+	titleBuilder := NewTitleBuilder("Diff")
 
-func moveDownDiffLine(diffView *DiffView, action Action) (err error) {
-	diffLines := diffView.commitDiffs[diffView.activeCommit]
-	lineNum := uint(len(diffLines.lines))
-	viewPos := diffView.viewPos
+	switch {
+	case diffView.lineHistory != nil:
+		lineHistory := diffView.lineHistory
+		titleBuilder.
+			Breadcrumb("%v", lineHistory.filePath).
+			Detail("history of lines %v-%v", lineHistory.startLine, lineHistory.endLine)
+	case revisionDiff == nil:
+		titleBuilder.Breadcrumb("%v", diffView.repoData.ShortID(diffView.activeCommit.oid))
+
+		if diffView.config.GetBool(CfMessagePreview) {
+			titleBuilder.Detail("message")
+		}
+	default:
+		titleBuilder.Breadcrumb("%v..%v", revisionDiff.fromRevision, revisionDiff.toRevision)
 
-	if viewPos.MoveLineDown(lineNum) {
-		log.Debugf("Moving down one line in diff view")
-		diffView.channels.UpdateDisplay()
+		if revisionDiff.selectedFile != nil {
+			titleBuilder.Breadcrumb("%v", revisionDiff.selectedFile.newPath)
+		}
 	}
 
-	return
+	return win.SetTitle(CmpCommitviewTitle, "%v", titleBuilder)
 }
 
-func moveUpDiffLine(diffView *DiffView, action Action) (err error) {
-	viewPos := diffView.viewPos
-
-	if viewPos.MoveLineUp() {
-		log.Debugf("Moving up one line in diff view")
-		diffView.channels.UpdateDisplay()
+// activeDiffLines returns the lines currently being displayed (if any)
+func (diffView *DiffView) activeDiffLines() *diffLines {
+	if diffView.lineHistory != nil {
+		return diffView.lineHistory.lines
 	}
 
-	return
-}
+	if revisionDiff := diffView.revisionDiff; revisionDiff != nil {
+		if revisionDiff.fileDiff != nil {
+			return revisionDiff.fileDiff
+		}
 
-func moveDownDiffPage(diffView *DiffView, action Action) (err error) {
-	diffLines := diffView.commitDiffs[diffView.activeCommit]
-	lineNum := uint(len(diffLines.lines))
-	viewPos := diffView.viewPos
+		return revisionDiff.fileList
+	}
 
-	if viewPos.MovePageDown(diffView.viewDimension.rows-2, lineNum) {
-		log.Debugf("Moving down one page in diff view")
-		diffView.channels.UpdateDisplay()
+	if diffView.config.GetBool(CfMessagePreview) {
+		return diffView.messageLines
 	}
 
-	return
+	if diffLines, ok := diffView.commitDiffs[diffView.activeCommit]; ok {
+		return diffLines
+	}
+
+	return diffView.partialDiffs[diffView.activeCommit]
+}
+
+// RenderStatusBar does nothing
+func (diffView *DiffView) RenderStatusBar(lineBuilder *LineBuilder) (err error) {
+	return
+}
+
+// StatuslineValues adds placeholder values describing the selected diff line
+func (diffView *DiffView) StatuslineValues(values StatuslineValues) {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	diffView.statuslineValues(values)
+}
+
+func (diffView *DiffView) statuslineValues(values StatuslineValues) {
+	values["view"] = "Diff"
+	values["progress"] = diffView.progress.String()
+
+	if diffView.activeCommit != nil {
+		values["commit"] = diffView.repoData.ShortID(diffView.activeCommit.oid)
+	}
+
+	var selectedLine uint
+	lineNum := diffView.lineNumber()
+	if lineNum > 0 {
+		selectedLine = diffView.viewPos.ActiveRowIndex() + 1
+	}
+
+	positionStatuslineValues("Line", selectedLine, lineNum, values)
+
+	if owners := diffView.selectedFileOwners(); len(owners) > 0 {
+		values["owners"] = strings.Join(owners, " ")
+	}
+}
+
+// selectedFileOwners returns the owners of the selected file according to the CODEOWNERS file of the displayed commit
+func (diffView *DiffView) selectedFileOwners() []string {
+	if diffView.lineHistory != nil {
+		return nil
+	}
+
+	commit := diffView.activeCommit
+	if diffView.revisionDiff != nil {
+		commit = diffView.revisionDiff.toCommit
+	}
+
+	if commit == nil {
+		return nil
+	}
+
+	filePath, err := diffView.selectedFilePath()
+	if err != nil {
+		return nil
+	}
+
+	codeOwners, err := diffView.repoData.CodeOwners(commit)
+	if err != nil {
+		log.Debugf("Unable to load CODEOWNERS: %v", err)
+		return nil
+	}
+
+	return codeOwners.Owners(filePath)
+}
+
+// RenderHelpBar shows key bindings custom to the diff view
+func (diffView *DiffView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(diffView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionFilterDirectory, message: "Filter Commits By Directory"},
+		{action: ActionLineHistory, message: "Line History"},
+		{action: ActionBack, message: "Back"},
+	})
+
+	return
+}
+
+// OnActiveChange sets whether the diff view is the active view or not
+func (diffView *DiffView) OnActiveChange(active bool) {
+	log.Debugf("DiffView active: %v", active)
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	diffView.active = active
+}
+
+// ViewID returns the diff views ID
+func (diffView *DiffView) ViewID() ViewID {
+	return ViewDiff
+}
+
+// RegisterDirectoryListener accepts a listener to be notified when a directory is selected
+func (diffView *DiffView) RegisterDirectoryListener(directoryListener DirectoryListener) {
+	diffView.directoryListeners = append(diffView.directoryListeners, directoryListener)
+}
+
+func (diffView *DiffView) notifyDirectoryListeners(directory string) {
+	log.Debugf("Notifying directory listeners of selected directory %v", directory)
+
+	// Listeners are notified asynchronously as they may attempt to acquire locks
+	// held by views which are in turn waiting on the diff view lock
+	go func() {
+		for _, directoryListener := range diffView.directoryListeners {
+			if err := directoryListener.OnDirectorySelect(directory); err != nil {
+				diffView.channels.ReportError(err)
+			}
+		}
+	}()
+}
+
+// OnCommitSelect loads/fetches the diff for the selected commit and refreshes the display
+func (diffView *DiffView) OnCommitSelect(commit *Commit) (err error) {
+	log.Debugf("DiffView loading diff for selected commit %v", commit.commit.Id())
+
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	var previousCommit *Commit
+	messagePreview := diffView.config.GetBool(CfMessagePreview)
+
+	if diffView.revisionDiff == nil && diffView.lineHistory == nil && !messagePreview {
+		if diffLines, ok := diffView.commitDiffs[diffView.activeCommit]; ok {
+			diffLines.viewPos = diffView.viewPos
+			previousCommit = diffView.activeCommit
+		}
+	}
+
+	diffView.revisionDiff = nil
+	diffView.lineHistory = nil
+
+	if messagePreview {
+		diffView.showCommitMessage(commit)
+		diffView.channels.UpdateDisplay()
+		return
+	}
+
+	if diffLines, ok := diffView.commitDiffs[commit]; ok {
+		diffView.activeCommit = commit
+		diffView.viewPos = diffLines.viewPos
+		diffView.describeCommit(commit)
+		diffView.channels.UpdateDisplay()
+		return
+	}
+
+	diffView.activeCommit = commit
+	diffView.viewPos = NewViewPosition()
+	diffView.loadCommitDiff(commit, previousCommit)
+	diffView.channels.UpdateDisplay()
+
+	return
+}
+
+// describeCommit determines the git describe output of the commit in the background if it is not already known.
+// Commits are not described until tags have loaded. Determining the description of the previously
+// selected commit is cancelled if it is still in progress. An empty description is recorded for
+// commits with no reachable tag so the Describe line is not displayed for them.
+// The diff view lock must be held when calling this method
+func (diffView *DiffView) describeCommit(commit *Commit) {
+	if !diffView.tagsLoaded {
+		return
+	} else if _, ok := diffView.descriptions[commit]; ok || diffView.describingCommit == commit {
+		return
+	}
+
+	if diffView.cancelDescribe != nil {
+		diffView.cancelDescribe()
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	diffView.describingCommit = commit
+	diffView.cancelDescribe = cancel
+
+	go func() {
+		defer cancel()
+
+		description, found, err := diffView.repoData.Describe(ctx, commit)
+		if ctx.Err() != nil {
+			return
+		} else if err != nil {
+			log.Errorf("Unable to describe commit %v: %v", commit.oid, err)
+			description = dvDescribeFailed
+		} else if !found {
+			description = ""
+		}
+
+		diffView.lock.Lock()
+		defer diffView.lock.Unlock()
+
+		if diffView.describingCommit != commit {
+			return
+		}
+
+		diffView.describingCommit = nil
+		diffView.cancelDescribe = nil
+		diffView.descriptions[commit] = description
+
+		for _, diffLines := range []*diffLines{diffView.commitDiffs[commit], diffView.partialDiffs[commit]} {
+			if diffLines != nil {
+				diffLines.lines = setDescribeLine(diffLines.lines, description)
+			}
+		}
+
+		if commit == diffView.activeCommit {
+			diffView.channels.UpdateDisplay()
+		}
+	}()
+}
+
+// OnTagsLoaded discards the descriptions determined using the previously loaded tags
+// and describes the active commit using the loaded tags
+func (diffView *DiffView) OnTagsLoaded() (err error) {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	log.Debug("DiffView describing commits using the loaded tags")
+
+	if diffView.cancelDescribe != nil {
+		diffView.cancelDescribe()
+	}
+
+	diffView.describingCommit = nil
+	diffView.cancelDescribe = nil
+	diffView.descriptions = make(map[*Commit]string)
+	diffView.tagsLoaded = true
+
+	if commit := diffView.activeCommit; commit != nil && (diffView.commitDiffs[commit] != nil || diffView.pendingDiffs[commit]) {
+		diffView.describeCommit(commit)
+	}
+
+	return
+}
+
+// setDescribeLine displays the description on the Describe line of the commit header and returns the updated lines.
+// The Describe line is removed if the description is empty and added back if a description later becomes available.
+// A new slice is returned when a line is added or removed as the provided lines may still be being appended to
+func setDescribeLine(lines []*diffLineData, description string) []*diffLineData {
+	insertIndex := -1
+
+	for lineIndex, line := range lines {
+		if line.lineType == dltDiffCommitDescribe {
+			if description != "" {
+				line.line = fmt.Sprintf("Describe:\t%v", description)
+				return lines
+			}
+
+			updatedLines := make([]*diffLineData, 0, len(lines)-1)
+			updatedLines = append(updatedLines, lines[:lineIndex]...)
+			return append(updatedLines, lines[lineIndex+1:]...)
+		} else if line.lineType == dltDiffCommitSummary {
+			insertIndex = lineIndex
+			if lineIndex > 0 && lines[lineIndex-1].lineType == dltNormal {
+				insertIndex--
+			}
+
+			break
+		}
+	}
+
+	if description == "" || insertIndex < 0 {
+		return lines
+	}
+
+	updatedLines := make([]*diffLineData, 0, len(lines)+1)
+	updatedLines = append(updatedLines, lines[:insertIndex]...)
+	updatedLines = append(updatedLines, &diffLineData{
+		line:     fmt.Sprintf("Describe:\t%v", description),
+		lineType: dltDiffCommitDescribe,
+	})
+
+	return append(updatedLines, lines[insertIndex:]...)
+}
+
+// showCommitMessage displays the header and full message of the provided commit
+// The diff view lock must be held when calling this method
+func (diffView *DiffView) showCommitMessage(commit *Commit) {
+	lines := diffView.commitHeaderLines(commit)
+	lines = append(lines, &diffLineData{
+		lineType: dltNormal,
+	})
+
+	messageLines := strings.Split(strings.TrimRight(commit.commit.Message(), "\n"), "\n")
+	for lineIndex, messageLine := range messageLines {
+		lineType := dltNormal
+		if lineIndex == 0 {
+			lineType = dltDiffCommitSummary
+		}
+
+		lines = append(lines, &diffLineData{
+			line:     messageLine,
+			lineType: lineType,
+		})
+	}
+
+	diffView.activeCommit = commit
+	diffView.messageLines = &diffLines{
+		lines: lines,
+	}
+	diffView.viewPos = NewViewPosition()
+}
+
+// onMessagePreviewChange switches the active commit between displaying its message and its diff
+// The diff view lock must be held when calling this method
+func (diffView *DiffView) onMessagePreviewChange() {
+	commit := diffView.activeCommit
+	if commit == nil || diffView.revisionDiff != nil || diffView.lineHistory != nil {
+		return
+	}
+
+	if diffView.config.GetBool(CfMessagePreview) {
+		if diffLines, ok := diffView.commitDiffs[commit]; ok {
+			diffLines.viewPos = diffView.viewPos
+		}
+
+		diffView.showCommitMessage(commit)
+	} else if diffLines, ok := diffView.commitDiffs[commit]; ok {
+		diffView.messageLines = nil
+		diffView.viewPos = diffLines.viewPos
+	} else {
+		diffView.messageLines = nil
+		diffView.viewPos = NewViewPosition()
+		diffView.loadCommitDiff(commit, nil)
+	}
+
+	diffView.channels.UpdateDisplay()
+}
+
+// loadCommitDiff generates the diff for the provided commit in the background
+// The view displays the progress of the diff generation until it is complete.
+// If the diff generation is cancelled the diff for previousCommit (if any) is displayed again
+func (diffView *DiffView) loadCommitDiff(commit, previousCommit *Commit) {
+	if diffView.pendingDiffs[commit] {
+		return
+	}
+
+	diffView.pendingDiffs[commit] = true
+	diffView.describeCommit(commit)
+	diffView.progress.Start(PgGenerateDiff, "Generating diff")
+	diffGeneration := diffView.diffGeneration
+	ctx, done := diffView.canceller.Start("diff generation")
+
+	go func() {
+		defer done()
+		lines, err := diffView.generateDiffLines(ctx, commit, func(lines []*diffLineData) {
+			diffView.updatePartialDiff(commit, diffGeneration, lines)
+		})
+
+		diffView.lock.Lock()
+		defer diffView.lock.Unlock()
+
+		if diffGeneration != diffView.diffGeneration {
+			log.Debugf("Discarding diff for commit %v generated with outdated options", commit.oid)
+			return
+		}
+
+		delete(diffView.pendingDiffs, commit)
+		delete(diffView.partialDiffs, commit)
+		if len(diffView.pendingDiffs) == 0 {
+			diffView.progress.Stop(PgGenerateDiff)
+		}
+
+		if ctx.Err() != nil {
+			log.Debugf("Diff generation for commit %v was cancelled", commit.oid)
+			diffView.restoreCommitDiff(commit, previousCommit)
+			return
+		} else if err != nil {
+			diffView.channels.ReportError(err)
+			return
+		}
+
+		var viewPos ViewPos
+		if commit == diffView.activeCommit && diffView.revisionDiff == nil && diffView.lineHistory == nil {
+			viewPos = diffView.viewPos
+
+			if diffView.restorePosition != nil {
+				if lineIndex, found := findDiffLinePosition(lines, *diffView.restorePosition); found {
+					viewPos.SetActiveRowIndex(lineIndex)
+				}
+
+				diffView.restorePosition = nil
+			}
+		} else {
+			viewPos = NewViewPosition()
+		}
+
+		if description, ok := diffView.descriptions[commit]; ok {
+			lines = setDescribeLine(lines, description)
+		}
+
+		diffView.commitDiffs[commit] = &diffLines{
+			lines:   lines,
+			viewPos: viewPos,
+			size:    estimateDiffLinesSize(lines),
+		}
+
+		diffView.enforceMemoryBudget()
+		diffView.channels.UpdateDisplay()
+	}()
+}
+
+// updatePartialDiff stores the lines generated so far for a diff which is still being generated
+// so the start of large diffs can be displayed before the whole diff has been generated
+func (diffView *DiffView) updatePartialDiff(commit *Commit, diffGeneration uint, lines []*diffLineData) {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	if diffGeneration != diffView.diffGeneration || !diffView.pendingDiffs[commit] {
+		return
+	}
+
+	if description, ok := diffView.descriptions[commit]; ok {
+		lines = setDescribeLine(lines, description)
+	}
+
+	diffView.partialDiffs[commit] = &diffLines{
+		lines: lines,
+	}
+
+	if commit == diffView.activeCommit {
+		diffView.channels.UpdateDisplay()
+	}
+}
+
+// enforceMemoryBudget discards cached commit diffs until their estimated size is within the memory budget
+// Distance from the active commit is measured by commit time, which approximates the distance in the commit view.
+// The diff for the active commit is never discarded and discarded diffs are generated again when selected.
+// The diff view lock must be held when calling this method
+func (diffView *DiffView) enforceMemoryBudget() {
+	budget := uint64(diffView.config.GetInt(CfMemoryBudget)) * dvBytesPerMegabyte
+	if budget == 0 {
+		return
+	}
+
+	var totalSize uint64
+	for _, diffLines := range diffView.commitDiffs {
+		totalSize += diffLines.size
+	}
+
+	if totalSize <= budget {
+		return
+	}
+
+	var activeTime time.Time
+	if diffView.activeCommit != nil {
+		activeTime = diffView.activeCommit.commit.Committer().When
+	}
+
+	var candidates []diffEvictionCandidate
+	for commit, diffLines := range diffView.commitDiffs {
+		if commit == diffView.activeCommit {
+			continue
+		}
+
+		distance := commit.commit.Committer().When.Sub(activeTime)
+		if distance < 0 {
+			distance = -distance
+		}
+
+		candidates = append(candidates, diffEvictionCandidate{
+			commit:   commit,
+			size:     diffLines.size,
+			distance: distance,
+		})
+	}
+
+	evicted := selectDiffEvictions(candidates, totalSize, budget)
+	for _, commit := range evicted {
+		delete(diffView.commitDiffs, commit)
+		delete(diffView.descriptions, commit)
+	}
+
+	log.Debugf("DiffView discarded %v cached diffs to remain within the %v of %vMB",
+		len(evicted), CfMemoryBudget, budget/dvBytesPerMegabyte)
+}
+
+// restoreCommitDiff displays the diff for previousCommit again if the diff for the
+// cancelled commit is still being displayed
+func (diffView *DiffView) restoreCommitDiff(cancelledCommit, previousCommit *Commit) {
+	if diffView.activeCommit != cancelledCommit || diffView.revisionDiff != nil || diffView.lineHistory != nil {
+		return
+	}
+
+	if diffLines, ok := diffView.commitDiffs[previousCommit]; ok {
+		diffView.activeCommit = previousCommit
+		diffView.viewPos = diffLines.viewPos
+	}
+
+	diffView.channels.UpdateDisplay()
+}
+
+// generateDiffLines generates the lines displayed for the diff of the provided commit
+// onLinesGenerated is called with the lines generated so far as the patch for each file becomes available.
+// The diff view lock is not required to be held when calling this method
+func (diffView *DiffView) generateDiffLines(ctx context.Context, commit *Commit, onLinesGenerated func([]*diffLineData)) (lines []*diffLineData, err error) {
+	startTime := time.Now()
+	lines = diffView.commitHeaderLines(commit)
+
+	// The description is determined in the background and displayed once known
+	lines = append(lines,
+		&diffLineData{
+			line:     fmt.Sprintf("Describe:\t%v", dvDescribePending),
+			lineType: dltDiffCommitDescribe,
+		},
+		&diffLineData{
+			lineType: dltNormal,
+		},
+		&diffLineData{
+			line:     commit.commit.Summary(),
+			lineType: dltDiffCommitSummary,
+		},
+		&diffLineData{
+			lineType: dltNormal,
+		},
+	)
+
+	statsAdded := false
+	diffOptions := diffView.diffOptions()
+	diffOptions.onFilesGenerated = func(diff *Diff, files []*DiffFile) {
+		if !statsAdded {
+			lines = appendDiffStatsLines(lines, diff)
+			statsAdded = true
+		}
+
+		var patches []*bytes.Buffer
+		for _, file := range files {
+			patches = append(patches, &file.patch)
+		}
+
+		lines = diffView.appendPatchLines(ctx, lines, patches...)
+
+		if ctx.Err() == nil {
+			onLinesGenerated(lines[:len(lines):len(lines)])
+		}
+	}
+
+	diff, err := diffView.repoData.Diff(ctx, commit, diffOptions)
+	if err != nil {
+		return
+	} else if err = ctx.Err(); err != nil {
+		return
+	}
+
+	if !statsAdded {
+		lines = appendDiffStatsLines(lines, diff)
+	}
+
+	diffView.notifyDiffGenerated(startTime, diffView.repoData.ShortID(commit.oid))
+
+	return
+}
+
+// commitHeaderLines generates the lines displaying the author and committer of the commit
+// followed by its trailers and any commit message lint violations
+func (diffView *DiffView) commitHeaderLines(commit *Commit) []*diffLineData {
+	author := commit.commit.Author()
+	committer := commit.commit.Committer()
+	identityFormat := diffView.config.GetString(CfDiffIdentityFormat)
+
+	lines := []*diffLineData{
+		{
+			line:     fmt.Sprintf("Author:\t%v", formatIdentity(author, identityFormat)),
+			lineType: dltDiffCommitAuthor,
+		},
+		{
+			line:     fmt.Sprintf("AuthorDate:\t%v", author.When.Format(dvDateFormat)),
+			lineType: dltDiffCommitAuthorDate,
+		},
+		{
+			line:     fmt.Sprintf("Committer:\t%v", formatIdentity(committer, identityFormat)),
+			lineType: dltDiffCommitCommitter,
+		},
+		{
+			line:     fmt.Sprintf("CommitterDate:\t%v", committer.When.Format(dvDateFormat)),
+			lineType: dltDiffCommitCommitterDate,
+		},
+	}
+
+	for _, trailer := range ParseCommitTrailers(commit.commit.Message()) {
+		lines = append(lines, &diffLineData{
+			line:     fmt.Sprintf("%v:\t%v", trailer.key, trailer.value),
+			lineType: dltDiffCommitTrailer,
+		})
+	}
+
+	for _, violation := range NewCommitLinter(diffView.config).Lint(commit.commit.Message()) {
+		lines = append(lines, &diffLineData{
+			line:     fmt.Sprintf("Lint:\t%v", violation.message),
+			lineType: dltDiffCommitLint,
+		})
+	}
+
+	return lines
+}
+
+// appendDiffStatsLines appends the lines of the diff stats and the number of excluded files followed by a blank line
+func appendDiffStatsLines(lines []*diffLineData, diff *Diff) []*diffLineData {
+	scanner := bufio.NewScanner(bytes.NewReader(diff.stats.Bytes()))
+
+	for scanner.Scan() {
+		lines = append(lines, &diffLineData{
+			line:     strings.TrimPrefix(scanner.Text(), " "),
+			lineType: dltDiffStatsFile,
+		})
+	}
+
+	if len(lines) > 0 {
+		prevLine := lines[len(lines)-1]
+
+		if prevLine.lineType == dltDiffStatsFile {
+			prevLine.lineType = dltNormal
+		}
+	}
+
+	if diff.excludedFiles > 0 {
+		lines = append(lines, &diffLineData{
+			line:     excludedFilesDescription(diff.excludedFiles),
+			lineType: dltNormal,
+		})
+	}
+
+	return append(lines, &diffLineData{
+		lineType: dltNormal,
+	})
+}
+
+// appendDiffTextLines appends the lines of the provided file patch
+// File mode changes are described in place of the mode extended headers
+func appendDiffTextLines(lines []*diffLineData, patch *bytes.Buffer) []*diffLineData {
+	var fileLines []*diffLineData
+	scanner := bufio.NewScanner(bytes.NewReader(patch.Bytes()))
+
+	for scanner.Scan() {
+		fileLines = append(fileLines, &diffLineData{
+			line: scanner.Text(),
+		})
+	}
+
+	return append(lines, describeFileModes(fileLines)...)
+}
+
+func (diffView *DiffView) diffOptions() DiffOptions {
+	excludePatterns, _ := parseDiffExcludePatterns(diffView.config.GetString(CfDiffExclude))
+
+	return DiffOptions{
+		detectRenames:       diffView.config.GetBool(CfDiffRenames),
+		detectCopies:        diffView.config.GetBool(CfDiffCopies),
+		similarityThreshold: uint16(diffView.config.GetInt(CfDiffSimilarityThreshold)),
+		contextLines:        uint32(diffView.config.GetInt(CfDiffContext)),
+		excludePatterns:     excludePatterns,
+		textconv:            diffView.config.GetBool(CfDiffTextconv),
+	}
+}
+
+// onConfigVariableChange discards all generated diffs and regenerates the diff for the active commit
+// The selected line is restored once the diff has been regenerated, or the start of its hunk if it is no longer displayed.
+// If message preview has been toggled then the active commit is displayed in the new mode instead.
+// If the memory budget has changed then cached diffs are discarded until they are within the new budget
+func (diffView *DiffView) onConfigVariableChange(configVariable ConfigVariable) {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	switch configVariable {
+	case CfMessagePreview:
+		diffView.onMessagePreviewChange()
+		return
+	case CfMemoryBudget:
+		diffView.enforceMemoryBudget()
+		return
+	}
+
+	log.Debugf("DiffView regenerating diffs as %v has changed", configVariable)
+
+	diffView.restorePosition = nil
+	if diffLines, ok := diffView.commitDiffs[diffView.activeCommit]; ok && diffView.revisionDiff == nil && diffView.lineHistory == nil &&
+		!diffView.config.GetBool(CfMessagePreview) {
+		if position, found := determineDiffLinePosition(diffLines.lines, diffView.viewPos.ActiveRowIndex()); found {
+			diffView.restorePosition = &position
+		}
+	}
+
+	diffView.commitDiffs = make(map[*Commit]*diffLines)
+	diffView.pendingDiffs = make(map[*Commit]bool)
+	diffView.partialDiffs = make(map[*Commit]*diffLines)
+	diffView.progress.Stop(PgGenerateDiff)
+	diffView.diffGeneration++
+
+	if revisionDiff := diffView.revisionDiff; revisionDiff != nil {
+		if err := diffView.generateRevisionDiff(revisionDiff.fromRevision, revisionDiff.toRevision); err != nil {
+			diffView.channels.ReportError(err)
+		}
+
+		return
+	} else if diffView.activeCommit == nil {
+		return
+	} else if diffView.config.GetBool(CfMessagePreview) {
+		diffView.showCommitMessage(diffView.activeCommit)
+		diffView.channels.UpdateDisplay()
+		return
+	}
+
+	diffView.viewPos = NewViewPosition()
+	diffView.loadCommitDiff(diffView.activeCommit, nil)
+	diffView.channels.UpdateDisplay()
+}
+
+// HandleKeyPress does nothing
+func (diffView *DiffView) HandleKeyPress(keystring string) (err error) {
+	log.Debugf("DiffView handling key %v - NOP", keystring)
+	return
+}
+
+// ViewPos returns the current view position
+func (diffView *DiffView) ViewPos() ViewPos {
+	return diffView.viewPos
+}
+
+// OnSearchMatch sets the current view position to the search match position
+func (diffView *DiffView) OnSearchMatch(startPos ViewPos, matchLineIndex uint) {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	viewPos := diffView.ViewPos()
+
+	if viewPos != startPos {
+		log.Debugf("Selected ref has changed since search started")
+		return
+	}
+
+	viewPos.SetActiveRowIndex(matchLineIndex)
+}
+
+// HandleAction checks if the diff view supports the provided action and executes it if so
+func (diffView *DiffView) HandleAction(action Action) (err error) {
+	log.Debugf("DiffView handling action %v", action)
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	if handler, ok := diffView.handlers[action.ActionType]; ok {
+		err = handler(diffView, action)
+	} else {
+		_, err = diffView.viewSearch.HandleAction(action)
+	}
+
+	return
+}
+
+// Line returns the rendered line from the diff view at the specified line index
+func (diffView *DiffView) Line(lineIndex uint) (line string) {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	diffLines := diffView.activeDiffLines()
+	if diffLines == nil {
+		return
+	}
+
+	lineNum := uint(len(diffLines.lines))
+
+	if lineIndex >= lineNum {
+		log.Errorf("Invalid lineIndex: %v", lineIndex)
+		return
+	}
+
+	diffLine := diffLines.lines[lineIndex]
+	line = diffLine.line
+
+	return
+}
+
+// LineNumber returns the number of lines the diff view currently has
+func (diffView *DiffView) LineNumber() (lineNumber uint) {
+	diffView.lock.Lock()
+	defer diffView.lock.Unlock()
+
+	if diffLines := diffView.activeDiffLines(); diffLines != nil {
+		lineNumber = uint(len(diffLines.lines))
+	}
+
+	return
+}
+
+func (diffView *DiffView) lineNumber() uint {
+	if diffLines := diffView.activeDiffLines(); diffLines != nil {
+		return uint(len(diffLines.lines))
+	}
+
+	return 0
+}
+
+func moveDownDiffLine(diffView *DiffView, action Action) (err error) {
+	lineNum := diffView.lineNumber()
+	viewPos := diffView.viewPos
+
+	if viewPos.MoveLinesDown(action.RepeatCount(), lineNum) {
+		log.Debugf("Moving down %v lines in diff view", action.RepeatCount())
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpDiffLine(diffView *DiffView, action Action) (err error) {
+	viewPos := diffView.viewPos
+
+	if viewPos.MoveLinesUp(action.RepeatCount()) {
+		log.Debugf("Moving up %v lines in diff view", action.RepeatCount())
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownDiffPage(diffView *DiffView, action Action) (err error) {
+	lineNum := diffView.lineNumber()
+	viewPos := diffView.viewPos
+
+	if viewPos.MovePageDown((diffView.viewDimension.rows-2)*action.RepeatCount(), lineNum) {
+		log.Debugf("Moving down %v pages in diff view", action.RepeatCount())
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
 }
 
 func moveUpDiffPage(diffView *DiffView, action Action) (err error) {
 	viewPos := diffView.viewPos
 
-	if viewPos.MovePageUp(diffView.viewDimension.rows - 2) {
-		log.Debugf("Moving up one page in diff view")
+	if viewPos.MovePageUp((diffView.viewDimension.rows - 2) * action.RepeatCount()) {
+		log.Debugf("Moving up %v pages in diff view", action.RepeatCount())
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownDiffHalfPage(diffView *DiffView, action Action) (err error) {
+	lineNum := diffView.lineNumber()
+	viewPos := diffView.viewPos
+
+	if viewPos.MoveHalfPageDown((diffView.viewDimension.rows-2)*action.RepeatCount(), lineNum) {
+		log.Debugf("Moving down %v half pages in diff view", action.RepeatCount())
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpDiffHalfPage(diffView *DiffView, action Action) (err error) {
+	viewPos := diffView.viewPos
+
+	if viewPos.MoveHalfPageUp((diffView.viewDimension.rows - 2) * action.RepeatCount()) {
+		log.Debugf("Moving up %v half pages in diff view", action.RepeatCount())
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func centerDiffView(diffView *DiffView, action Action) (err error) {
+	if diffView.viewPos.CenterActiveRow(diffView.viewDimension.rows - 2) {
+		log.Debug("Centering selected line in diff view")
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollDiffViewCursorTop(diffView *DiffView, action Action) (err error) {
+	if diffView.viewPos.ScrollActiveRowToTop() {
+		log.Debug("Scrolling selected line to top of diff view")
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollDiffViewCursorBottom(diffView *DiffView, action Action) (err error) {
+	if diffView.viewPos.ScrollActiveRowToBottom(diffView.viewDimension.rows - 2) {
+		log.Debug("Scrolling selected line to bottom of diff view")
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollDiffViewRight(diffView *DiffView, action Action) (err error) {
+	viewPos := diffView.viewPos
+	viewPos.MovePageRight(diffView.viewDimension.cols)
+	log.Debugf("Scrolling right. View starts at column %v", viewPos.ViewStartColumn())
+	diffView.channels.UpdateDisplay()
+
+	return
+}
+
+func scrollDiffViewLeft(diffView *DiffView, action Action) (err error) {
+	viewPos := diffView.viewPos
+
+	if viewPos.MovePageLeft(diffView.viewDimension.cols) {
+		log.Debugf("Scrolling left. View starts at column %v", viewPos.ViewStartColumn())
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToFirstDiffLine(diffView *DiffView, action Action) (err error) {
+	viewPos := diffView.viewPos
+
+	if viewPos.MoveToFirstLine() {
+		log.Debugf("Moving to first line in diff view")
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToLastDiffLine(diffView *DiffView, action Action) (err error) {
+	lineNum := diffView.lineNumber()
+	viewPos := diffView.viewPos
+
+	if viewPos.MoveToLastLine(lineNum) {
+		log.Debugf("Moving to last line in diff view")
+		diffView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func (diffView *DiffView) selectedFilePath() (filePath string, err error) {
+	diffLines := diffView.activeDiffLines()
+	if diffLines == nil {
+		return "", fmt.Errorf("No diff loaded")
+	}
+
+	lineIndex := int(diffView.viewPos.ActiveRowIndex())
+	if lineIndex >= len(diffLines.lines) {
+		return "", fmt.Errorf("Invalid line index: %v", lineIndex)
+	}
+
+	if diffLine := diffLines.lines[lineIndex]; diffLine.diffFile != nil {
+		return diffLine.diffFile.newPath, nil
+	} else if diffLine.lineType == dltDiffStatsFile {
+		if sepIndex := strings.LastIndex(diffLine.line, "|"); sepIndex != -1 {
+			filePath = strings.TrimSpace(diffLine.line[0:sepIndex])
+
+			if renameIndex := strings.Index(filePath, " => "); renameIndex != -1 {
+				filePath = filePath[renameIndex+4:]
+			}
+
+			if !strings.HasPrefix(filePath, "...") {
+				return
+			}
+		}
+
+		return "", fmt.Errorf("Unable to determine file path from line: %v", diffLine.line)
+	}
+
+	for ; lineIndex >= 0; lineIndex-- {
+		diffLine := diffLines.lines[lineIndex]
+		diffLine.determineDiffLineType()
+
+		if diffLine.lineType == dltGitDiffHeader {
+			if _, newFilePath, ok := diffHeaderFilePaths(diffLine.line); ok {
+				return newFilePath, nil
+			}
+
+			return "", fmt.Errorf("Unable to determine file path from line: %v", diffLine.line)
+		}
+	}
+
+	return "", fmt.Errorf("No file selected")
+}
+
+func filterCommitsByDirectory(diffView *DiffView, action Action) (err error) {
+	filePath, err := diffView.selectedFilePath()
+	if err != nil {
+		return
+	}
+
+	directory := path.Dir(filePath)
+	if directory == "." {
+		return fmt.Errorf("File %v is in the repository root directory", filePath)
+	}
+
+	diffView.notifyDirectoryListeners(directory)
+
+	return
+}
+
+// generateRevisionDiff generates the diff between the provided revisions in the background and
+// displays it once it is complete. Any revision diff still being generated is cancelled.
+// The diff view lock must be held when calling this method
+func (diffView *DiffView) generateRevisionDiff(fromRevision, toRevision string) (err error) {
+	fromCommit, err := diffView.repoData.CommitByRevision(fromRevision)
+	if err != nil {
+		return
+	}
+
+	toCommit, err := diffView.repoData.CommitByRevision(toRevision)
+	if err != nil {
+		return
+	}
+
+	if diffView.cancelRevisionDiff != nil {
+		diffView.cancelRevisionDiff()
+	}
+
+	diffView.revisionDiffID++
+	revisionDiffID := diffView.revisionDiffID
+	diffOptions := diffView.diffOptions()
+	ctx, done := diffView.canceller.Start("revision diff generation")
+	diffView.cancelRevisionDiff = done
+	diffView.progress.Start(PgGenerateRevisionDiff, fmt.Sprintf("Generating diff for %v..%v", fromRevision, toRevision))
+
+	go func() {
+		defer done()
+		startTime := time.Now()
+		diff, err := diffView.repoData.DiffCommits(ctx, fromCommit, toCommit, diffOptions)
+
+		diffView.lock.Lock()
+		defer diffView.lock.Unlock()
+
+		if revisionDiffID != diffView.revisionDiffID {
+			log.Debugf("Discarding outdated diff for %v..%v", fromRevision, toRevision)
+			return
+		}
+
+		diffView.cancelRevisionDiff = nil
+		diffView.progress.Stop(PgGenerateRevisionDiff)
+
+		if ctx.Err() != nil {
+			log.Debugf("Diff generation for %v..%v was cancelled", fromRevision, toRevision)
+			return
+		} else if err != nil {
+			diffView.channels.ReportError(err)
+			return
+		}
+
+		diffView.showRevisionDiff(fromRevision, toRevision, fromCommit, toCommit, diff)
+		diffView.notifyDiffGenerated(startTime, fromRevision+".."+toRevision)
 		diffView.channels.UpdateDisplay()
+	}()
+
+	return
+}
+
+// showRevisionDiff displays the list of files changed between the provided revisions
+// The diff view lock must be held when calling this method
+func (diffView *DiffView) showRevisionDiff(fromRevision, toRevision string, fromCommit, toCommit *Commit, diff *Diff) {
+	lines := []*diffLineData{
+		{
+			line: fmt.Sprintf("Diff between %v (%v) and %v (%v)", fromRevision, diffView.repoData.ShortID(fromCommit.oid),
+				toRevision, diffView.repoData.ShortID(toCommit.oid)),
+			lineType: dltDiffCommitSummary,
+		},
+		{
+			lineType: dltNormal,
+		},
+	}
+
+	statsLines := strings.Split(strings.TrimSpace(diff.stats.String()), "\n")
+	if summary := strings.TrimSpace(statsLines[len(statsLines)-1]); summary != "" {
+		lines = append(lines, &diffLineData{
+			line:     summary,
+			lineType: dltNormal,
+		}, &diffLineData{
+			lineType: dltNormal,
+		})
+	}
+
+	if diff.excludedFiles > 0 {
+		lines = append(lines, &diffLineData{
+			line:     excludedFilesDescription(diff.excludedFiles),
+			lineType: dltNormal,
+		}, &diffLineData{
+			lineType: dltNormal,
+		})
+	}
+
+	for _, diffFile := range diff.files {
+		line := fmt.Sprintf("%v  %v", diffFile.StatusCode(), diffFile.newPath)
+		if diffFile.oldPath != diffFile.newPath {
+			line = fmt.Sprintf("%v  %v → %v", diffFile.StatusCode(), diffFile.oldPath, diffFile.newPath)
+		}
+
+		lines = append(lines, &diffLineData{
+			line:     line,
+			lineType: dltDiffFile,
+			diffFile: diffFile,
+		})
+	}
+
+	if diffView.revisionDiff == nil && diffView.lineHistory == nil {
+		if diffLines, ok := diffView.commitDiffs[diffView.activeCommit]; ok {
+			diffLines.viewPos = diffView.viewPos
+		}
+	}
+
+	diffView.lineHistory = nil
+	diffView.revisionDiff = &revisionDiff{
+		fromRevision: fromRevision,
+		toRevision:   toRevision,
+		toCommit:     toCommit,
+		fileList: &diffLines{
+			lines: lines,
+		},
+	}
+
+	diffView.viewPos = NewViewPosition()
+}
+
+// notifyDiffGenerated sends a notification when generating a diff took long enough
+// that the user may no longer be watching
+func (diffView *DiffView) notifyDiffGenerated(startTime time.Time, description string) {
+	if duration := time.Since(startTime); duration >= dvDiffNotifyMinDuration {
+		diffView.notifier.Notify(NeDiffGenerated, "Generated diff for %v in %v", description, duration)
+	}
+}
+
+func diffRevisions(diffView *DiffView, action Action) (err error) {
+	if len(action.Args) != 2 {
+		return fmt.Errorf("Expected from and to revision arguments")
+	}
+
+	fromRevision, fromOk := action.Args[0].(string)
+	toRevision, toOk := action.Args[1].(string)
+	if !(fromOk && toOk) {
+		return fmt.Errorf("Expected revision arguments to have type string")
+	}
+
+	return diffView.generateRevisionDiff(fromRevision, toRevision)
+}
+
+func selectDiffFile(diffView *DiffView, action Action) (err error) {
+	revisionDiff := diffView.revisionDiff
+	if revisionDiff == nil || revisionDiff.fileDiff != nil {
+		return
+	}
+
+	diffLine := revisionDiff.fileList.lines[diffView.viewPos.ActiveRowIndex()]
+	if diffLine.diffFile == nil {
+		return
+	}
+
+	lines := diffView.appendPatchLines(context.Background(), nil, &diffLine.diffFile.patch)
+
+	revisionDiff.fileList.viewPos = diffView.viewPos
+	revisionDiff.selectedFile = diffLine.diffFile
+	revisionDiff.fileDiff = &diffLines{
+		lines: lines,
 	}
 
+	diffView.viewPos = NewViewPosition()
+	diffView.channels.UpdateDisplay()
+
 	return
 }
 
-func scrollDiffViewRight(diffView *DiffView, action Action) (err error) {
-	viewPos := diffView.viewPos
-	viewPos.MovePageRight(diffView.viewDimension.cols)
-	log.Debugf("Scrolling right. View starts at column %v", viewPos.ViewStartColumn())
+func moveBackDiffView(diffView *DiffView, action Action) (err error) {
+	revisionDiff := diffView.revisionDiff
+
+	switch {
+	case diffView.lineHistory != nil:
+		diffView.lineHistory = nil
+
+		if diffLines := diffView.activeDiffLines(); diffLines != nil {
+			diffView.viewPos = diffLines.viewPos
+		} else {
+			diffView.viewPos = NewViewPosition()
+		}
+	case revisionDiff == nil:
+		return
+	case revisionDiff.fileDiff != nil:
+		revisionDiff.fileDiff = nil
+		revisionDiff.selectedFile = nil
+		diffView.viewPos = revisionDiff.fileList.viewPos
+	default:
+		diffView.revisionDiff = nil
+
+		if diffLines, ok := diffView.commitDiffs[diffView.activeCommit]; ok {
+			diffView.viewPos = diffLines.viewPos
+		} else {
+			diffView.viewPos = NewViewPosition()
+
+			if diffView.activeCommit != nil {
+				diffView.loadCommitDiff(diffView.activeCommit, nil)
+			}
+		}
+	}
+
 	diffView.channels.UpdateDisplay()
 
 	return
 }
 
-func scrollDiffViewLeft(diffView *DiffView, action Action) (err error) {
-	viewPos := diffView.viewPos
+// selectedDiffLineRange determines the range of lines in the new version of a file
+// represented by the selected diff line. A hunk header selects every line of the hunk
+func selectedDiffLineRange(lines []*diffLineData, lineIndex int) (startLine, endLine uint, err error) {
+	if lineIndex < 0 || lineIndex >= len(lines) {
+		return 0, 0, fmt.Errorf("Invalid line index: %v", lineIndex)
+	}
 
-	if viewPos.MovePageLeft(diffView.viewDimension.cols) {
-		log.Debugf("Scrolling left. View starts at column %v", viewPos.ViewStartColumn())
-		diffView.channels.UpdateDisplay()
+	if selectedLine := lines[lineIndex]; strings.HasPrefix(selectedLine.line, "-") {
+		return 0, 0, fmt.Errorf("Line history is not available for removed lines")
+	}
+
+	lineOffset := uint(0)
+
+	for index := lineIndex; index >= 0; index-- {
+		diffLine := lines[index]
+		diffLine.determineDiffLineType()
+
+		switch diffLine.lineType {
+		case dltHunkStart:
+			hunkHeader, ok := parseDiffHunkHeader(diffLine.line)
+			if !ok {
+				return 0, 0, fmt.Errorf("Unable to parse hunk header: %v", diffLine.line)
+			}
+
+			if index != lineIndex {
+				startLine = hunkHeader.newStart + lineOffset - 1
+				return startLine, startLine, nil
+			} else if hunkHeader.newLines == 0 {
+				return 0, 0, fmt.Errorf("Hunk contains no lines from the new version of the file")
+			}
+
+			return hunkHeader.newStart, hunkHeader.newStart + hunkHeader.newLines - 1, nil
+		case dltLineAdded, dltNormal:
+			if !strings.HasPrefix(diffLine.line, "\\") {
+				lineOffset++
+			}
+		case dltLineRemoved:
+		default:
+			return 0, 0, fmt.Errorf("No diff line selected")
+		}
+	}
+
+	return 0, 0, fmt.Errorf("No diff line selected")
+}
+
+// diffLinePosition identifies a diff line independently of the options the diff was generated with
+// by the header of the file it belongs to and its line number in the new version of the file.
+// A line number of 0 identifies the file header
+type diffLinePosition struct {
+	fileHeader string
+	lineNumber uint
+	hunkStart  bool
+}
+
+// determineDiffLinePosition returns the position of the line at the provided index
+func determineDiffLinePosition(lines []*diffLineData, lineIndex uint) (position diffLinePosition, found bool) {
+	if lineIndex >= uint(len(lines)) {
+		return
+	}
+
+	hunkFound := false
+	lineOffset := uint(0)
+
+	for index := int(lineIndex); index >= 0; index-- {
+		diffLine := lines[index]
+		diffLine.determineDiffLineType()
+
+		switch diffLine.lineType {
+		case dltGitDiffHeader:
+			position.fileHeader = diffLine.line
+			return position, true
+		case dltHunkStart:
+			if hunkFound {
+				continue
+			}
+
+			hunkHeader, ok := parseDiffHunkHeader(diffLine.line)
+			if !ok {
+				return position, false
+			}
+
+			position.lineNumber = hunkHeader.newStart + lineOffset
+			position.hunkStart = index == int(lineIndex)
+			hunkFound = true
+		case dltLineAdded, dltNormal:
+			if !hunkFound && index != int(lineIndex) && !strings.HasPrefix(diffLine.line, "\\") {
+				lineOffset++
+			}
+		}
+	}
+
+	return position, false
+}
+
+// findDiffLinePosition returns the index of the line at the provided position.
+// If the line is no longer part of the diff then the index of the start of the hunk
+// preceding it is returned, or the index of the file header if there is no such hunk
+func findDiffLinePosition(lines []*diffLineData, position diffLinePosition) (lineIndex uint, found bool) {
+	fileHeaderIndex := -1
+	hunkStartIndex := -1
+	lineNumber := uint(0)
+
+	for index, diffLine := range lines {
+		diffLine.determineDiffLineType()
+
+		if diffLine.lineType == dltGitDiffHeader {
+			if fileHeaderIndex != -1 {
+				break
+			} else if diffLine.line == position.fileHeader {
+				fileHeaderIndex = index
+
+				if position.lineNumber == 0 {
+					return uint(index), true
+				}
+			}
+
+			continue
+		} else if fileHeaderIndex == -1 {
+			continue
+		}
+
+		switch diffLine.lineType {
+		case dltHunkStart:
+			hunkHeader, ok := parseDiffHunkHeader(diffLine.line)
+			if !ok {
+				return
+			}
+
+			if hunkHeader.newStart > position.lineNumber && hunkStartIndex != -1 {
+				return uint(hunkStartIndex), true
+			}
+
+			hunkStartIndex = index
+			lineNumber = hunkHeader.newStart
+
+			if position.hunkStart && position.lineNumber < hunkHeader.newStart+hunkHeader.newLines {
+				return uint(index), true
+			}
+		case dltLineAdded, dltNormal:
+			if hunkStartIndex == -1 || strings.HasPrefix(diffLine.line, "\\") {
+				continue
+			}
+
+			if lineNumber == position.lineNumber && !position.hunkStart {
+				return uint(index), true
+			}
+
+			lineNumber++
+		}
+	}
+
+	if hunkStartIndex != -1 {
+		return uint(hunkStartIndex), true
+	} else if fileHeaderIndex != -1 {
+		return uint(fileHeaderIndex), true
 	}
 
 	return
 }
 
-func moveToFirstDiffLine(diffView *DiffView, action Action) (err error) {
-	viewPos := diffView.viewPos
+func showLineHistory(diffView *DiffView, action Action) (err error) {
+	activeLines := diffView.activeDiffLines()
+	if activeLines == nil || diffView.lineHistory != nil {
+		return
+	}
 
-	if viewPos.MoveToFirstLine() {
-		log.Debugf("Moving to first line in diff view")
-		diffView.channels.UpdateDisplay()
+	commit := diffView.activeCommit
+	if diffView.revisionDiff != nil {
+		commit = diffView.revisionDiff.toCommit
+	}
+
+	filePath, err := diffView.selectedFilePath()
+	if err != nil {
+		return
+	}
+
+	startLine, endLine, err := selectedDiffLineRange(activeLines.lines, int(diffView.viewPos.ActiveRowIndex()))
+	if err != nil {
+		return
+	}
+
+	return diffView.loadLineHistory(commit, filePath, startLine, endLine)
+}
+
+// showRequestedLineHistory displays the history of the line range provided by another view
+func showRequestedLineHistory(diffView *DiffView, action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected LineHistoryRequest argument")
+	}
+
+	request, ok := action.Args[0].(LineHistoryRequest)
+	if !ok {
+		return fmt.Errorf("Expected argument of type LineHistoryRequest but found type %T", action.Args[0])
+	}
+
+	return diffView.loadLineHistory(request.commit, request.filePath, request.startLine, request.endLine)
+}
+
+// showQuickfixHunks lists the hunks of the displayed diff in the quickfix view
+func showQuickfixHunks(diffView *DiffView, action Action) (err error) {
+	return diffView.showQuickfixList("Hunks", nil)
+}
+
+// showQuickfixSearchMatches lists the lines of the displayed diff matching the current search in the quickfix view
+func showQuickfixSearchMatches(diffView *DiffView, action Action) (err error) {
+	searchActive, searchPattern, _ := diffView.viewSearch.SearchActive()
+	if !searchActive {
+		return fmt.Errorf("No search has been performed")
+	}
+
+	searchRegex, err := regexp.Compile(searchPattern)
+	if err != nil {
+		return
+	}
+
+	return diffView.showQuickfixList(fmt.Sprintf("Matches for %v", searchPattern), searchRegex)
+}
+
+func (diffView *DiffView) showQuickfixList(title string, searchRegex *regexp.Regexp) (err error) {
+	diffLines := diffView.activeDiffLines()
+	if diffLines == nil {
+		return
+	}
+
+	entries := diffQuickfixEntries(diffLines, searchRegex)
+	if len(entries) == 0 {
+		if searchRegex != nil {
+			return fmt.Errorf("No lines match %v", searchRegex)
+		}
+
+		return fmt.Errorf("No hunks to list")
 	}
 
+	diffView.channels.DoAction(Action{
+		ActionType: ActionShowQuickfix,
+		Args: []interface{}{&QuickfixList{
+			title:   title,
+			entries: entries,
+		}},
+	})
+
 	return
 }
 
-func moveToLastDiffLine(diffView *DiffView, action Action) (err error) {
-	diffLines := diffView.commitDiffs[diffView.activeCommit]
-	lineNum := uint(len(diffLines.lines))
-	viewPos := diffView.viewPos
+// jumpToQuickfixEntry selects the line a quickfix entry refers to
+// The entry must refer to the lines currently displayed
+func jumpToQuickfixEntry(diffView *DiffView, action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected QuickfixEntry argument")
+	}
 
-	if viewPos.MoveToLastLine(lineNum) {
-		log.Debugf("Moving to last line in diff view")
-		diffView.channels.UpdateDisplay()
+	entry, ok := action.Args[0].(QuickfixEntry)
+	if !ok {
+		return fmt.Errorf("Expected argument of type QuickfixEntry but found type %T", action.Args[0])
+	}
+
+	diffLines := diffView.activeDiffLines()
+	if diffLines == nil || diffLines != entry.source || entry.lineIndex >= uint(len(diffLines.lines)) {
+		return fmt.Errorf("Quickfix list does not refer to the displayed diff")
+	}
+
+	diffView.viewPos.SetActiveRowIndex(entry.lineIndex)
+	diffView.channels.UpdateDisplay()
+
+	return
+}
+
+// loadLineHistory replaces the displayed diff with the commits which modified the line range
+// The position in the diff is restored when moving back from the line history
+func (diffView *DiffView) loadLineHistory(commit *Commit, filePath string, startLine, endLine uint) (err error) {
+	entries, err := diffView.repoData.LineHistory(commit, filePath, startLine, endLine)
+	if err != nil {
+		return
+	}
+
+	var lines []*diffLineData
+	identityFormat := diffView.config.GetString(CfDiffIdentityFormat)
+
+	for _, entry := range entries {
+		author := entry.commit.commit.Author()
+
+		lines = append(lines,
+			&diffLineData{
+				line:     fmt.Sprintf("%v %v", diffView.repoData.ShortID(entry.commit.oid), entry.commit.commit.Summary()),
+				lineType: dltDiffCommitSummary,
+			},
+			&diffLineData{
+				line:     fmt.Sprintf("Author:\t%v", formatIdentity(author, identityFormat)),
+				lineType: dltDiffCommitAuthor,
+			},
+			&diffLineData{
+				line:     fmt.Sprintf("AuthorDate:\t%v", author.When.Format(dvDateFormat)),
+				lineType: dltDiffCommitAuthorDate,
+			},
+			&diffLineData{
+				lineType: dltNormal,
+			},
+		)
+
+		scanner := bufio.NewScanner(bytes.NewReader(entry.patch.Bytes()))
+
+		for scanner.Scan() {
+			lines = append(lines, &diffLineData{
+				line: scanner.Text(),
+			})
+		}
+
+		lines = append(lines, &diffLineData{
+			lineType: dltNormal,
+		})
+	}
+
+	if len(lines) == 0 {
+		lines = append(lines, &diffLineData{
+			line:     fmt.Sprintf("No commits modify lines %v-%v of %v", startLine, endLine, filePath),
+			lineType: dltNormal,
+		})
+	}
+
+	if diffView.lineHistory == nil {
+		if activeLines := diffView.activeDiffLines(); activeLines != nil {
+			activeLines.viewPos = diffView.viewPos
+		}
+	}
+
+	diffView.lineHistory = &lineHistory{
+		filePath:  filePath,
+		startLine: startLine,
+		endLine:   endLine,
+		lines: &diffLines{
+			lines: lines,
+		},
 	}
 
+	diffView.viewPos = NewViewPosition()
+	diffView.channels.ReportStatus("Loaded %v commits modifying lines %v-%v of %v", len(entries), startLine, endLine, filePath)
+	diffView.channels.UpdateDisplay()
+
 	return
 }
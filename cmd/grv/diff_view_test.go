@@ -0,0 +1,395 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestDiffHeaderFilePaths(t *testing.T) {
+	var diffHeaderTests = []struct {
+		line                string
+		expectedOldFilePath string
+		expectedNewFilePath string
+		expectedOk          bool
+	}{
+		{
+			line:                "diff --git a/cmd/grv/main.go b/cmd/grv/main.go",
+			expectedOldFilePath: "cmd/grv/main.go",
+			expectedNewFilePath: "cmd/grv/main.go",
+			expectedOk:          true,
+		},
+		{
+			line:                "diff --git a/doc/old.md b/doc/new.md",
+			expectedOldFilePath: "doc/old.md",
+			expectedNewFilePath: "doc/new.md",
+			expectedOk:          true,
+		},
+		{
+			line:                "diff --git a/file with spaces b/file with spaces",
+			expectedOldFilePath: "file with spaces",
+			expectedNewFilePath: "file with spaces",
+			expectedOk:          true,
+		},
+		{
+			line:       "index 3b18e51..a9c2f1d 100644",
+			expectedOk: false,
+		},
+	}
+
+	for _, diffHeaderTest := range diffHeaderTests {
+		oldFilePath, newFilePath, ok := diffHeaderFilePaths(diffHeaderTest.line)
+
+		if ok != diffHeaderTest.expectedOk {
+			t.Errorf("Parse result does not match expected value for line %v. Expected: %v, Actual: %v", diffHeaderTest.line, diffHeaderTest.expectedOk, ok)
+		} else if oldFilePath != diffHeaderTest.expectedOldFilePath || newFilePath != diffHeaderTest.expectedNewFilePath {
+			t.Errorf("File paths do not match expected values for line %v. Expected: %v -> %v, Actual: %v -> %v", diffHeaderTest.line,
+				diffHeaderTest.expectedOldFilePath, diffHeaderTest.expectedNewFilePath, oldFilePath, newFilePath)
+		}
+	}
+}
+
+func TestDiffExtendedHeadersAreDetected(t *testing.T) {
+	lines := []string{
+		"index 3b18e51..a9c2f1d 100644",
+		"similarity index 92%",
+		"rename from doc/old.md",
+		"rename to doc/new.md",
+		"copy from cmd/grv/a.go",
+		"copy to cmd/grv/b.go",
+		"new file mode 100644",
+		"deleted file mode 100644",
+		"Submodule vendor/lib 3b18e51..a9c2f1d",
+		"LFS object assets/logo.png",
+	}
+
+	for _, line := range lines {
+		diffLine := &diffLineData{line: line}
+		diffLine.determineDiffLineType()
+
+		if diffLine.lineType != dltGitDiffExtendedHeader {
+			t.Errorf("Expected line to be detected as an extended header: %v", line)
+		}
+	}
+}
+
+func TestSelectedDiffLineRange(t *testing.T) {
+	var lines []*diffLineData
+	for _, line := range []string{
+		"diff --git a/main.go b/main.go",
+		"index 3b18e51..a9c2f1d 100644",
+		"--- a/main.go",
+		"+++ b/main.go",
+		"@@ -10,4 +10,5 @@ func main() {",
+		" context",
+		"-removed",
+		"+added",
+		"+added",
+		" context",
+		"@@ -30,2 +31,0 @@",
+		"-removed",
+		"-removed",
+	} {
+		lines = append(lines, &diffLineData{line: line})
+	}
+
+	var lineRangeTests = []struct {
+		lineIndex         int
+		expectedStartLine uint
+		expectedEndLine   uint
+		expectedError     bool
+	}{
+		{lineIndex: 0, expectedError: true},
+		{lineIndex: 3, expectedError: true},
+		{lineIndex: 4, expectedStartLine: 10, expectedEndLine: 14},
+		{lineIndex: 5, expectedStartLine: 10, expectedEndLine: 10},
+		{lineIndex: 6, expectedError: true},
+		{lineIndex: 8, expectedStartLine: 12, expectedEndLine: 12},
+		{lineIndex: 9, expectedStartLine: 13, expectedEndLine: 13},
+		{lineIndex: 10, expectedError: true},
+		{lineIndex: 13, expectedError: true},
+	}
+
+	for _, lineRangeTest := range lineRangeTests {
+		startLine, endLine, err := selectedDiffLineRange(lines, lineRangeTest.lineIndex)
+
+		if lineRangeTest.expectedError {
+			if err == nil {
+				t.Errorf("Expected error for line index %v", lineRangeTest.lineIndex)
+			}
+		} else if err != nil {
+			t.Errorf("Unexpected error for line index %v: %v", lineRangeTest.lineIndex, err)
+		} else if startLine != lineRangeTest.expectedStartLine || endLine != lineRangeTest.expectedEndLine {
+			t.Errorf("Line range does not match expected value for line index %v. Expected: %v-%v, Actual: %v-%v",
+				lineRangeTest.lineIndex, lineRangeTest.expectedStartLine, lineRangeTest.expectedEndLine, startLine, endLine)
+		}
+	}
+}
+
+func TestStickyHeaderLineIndexes(t *testing.T) {
+	var lines []*diffLineData
+	for _, line := range []string{
+		"Author:\tme <me@example.com>",
+		"diff --git a/main.go b/main.go",
+		"index 3b18e51..a9c2f1d 100644",
+		"--- a/main.go",
+		"+++ b/main.go",
+		"@@ -10,4 +10,5 @@ func main() {",
+		" context",
+		"+added",
+		"@@ -30,2 +31,0 @@",
+		"-removed",
+		"diff --git a/util.go b/util.go",
+		"@@ -1,1 +1,1 @@",
+		"+added",
+	} {
+		lines = append(lines, &diffLineData{line: line})
+	}
+
+	var stickyHeaderTests = []struct {
+		viewStartRowIndex   uint
+		fileHeader          bool
+		hunkHeader          bool
+		expectedLineIndexes []uint
+	}{
+		{viewStartRowIndex: 0, fileHeader: true, hunkHeader: true},
+		{viewStartRowIndex: 1, fileHeader: true, hunkHeader: true},
+		{viewStartRowIndex: 3, fileHeader: true, hunkHeader: true, expectedLineIndexes: []uint{1}},
+		{viewStartRowIndex: 7, fileHeader: true, hunkHeader: false, expectedLineIndexes: []uint{1}},
+		{viewStartRowIndex: 7, fileHeader: true, hunkHeader: true, expectedLineIndexes: []uint{1, 5}},
+		{viewStartRowIndex: 8, fileHeader: true, hunkHeader: true, expectedLineIndexes: []uint{1}},
+		{viewStartRowIndex: 9, fileHeader: false, hunkHeader: true, expectedLineIndexes: []uint{8}},
+		{viewStartRowIndex: 10, fileHeader: true, hunkHeader: true},
+		{viewStartRowIndex: 12, fileHeader: true, hunkHeader: true, expectedLineIndexes: []uint{10, 11}},
+		{viewStartRowIndex: 12, fileHeader: false, hunkHeader: false},
+		{viewStartRowIndex: 13, fileHeader: true, hunkHeader: true},
+	}
+
+	for _, stickyHeaderTest := range stickyHeaderTests {
+		lineIndexes := stickyHeaderLineIndexes(lines, stickyHeaderTest.viewStartRowIndex, stickyHeaderTest.fileHeader, stickyHeaderTest.hunkHeader)
+
+		if fmt.Sprint(lineIndexes) != fmt.Sprint(stickyHeaderTest.expectedLineIndexes) {
+			t.Errorf("Sticky header line indexes do not match expected value for view start row %v. Expected: %v, Actual: %v",
+				stickyHeaderTest.viewStartRowIndex, stickyHeaderTest.expectedLineIndexes, lineIndexes)
+		}
+	}
+}
+
+func TestDiffLinePositionIsRestoredAfterContextChange(t *testing.T) {
+	toDiffLines := func(lines []string) (diffLines []*diffLineData) {
+		for _, line := range lines {
+			diffLines = append(diffLines, &diffLineData{line: line})
+		}
+
+		return
+	}
+
+	oneLineContext := toDiffLines([]string{
+		"diff --git a/main.go b/main.go",
+		"index 3b18e51..a9c2f1d 100644",
+		"--- a/main.go",
+		"+++ b/main.go",
+		"@@ -9,2 +9,3 @@",
+		" context 9",
+		"+added 10",
+		" context 11",
+		"@@ -18,2 +19,3 @@",
+		" context 19",
+		"+added 20",
+		" context 21",
+		"diff --git a/util.go b/util.go",
+		"@@ -1,1 +1,1 @@",
+		"-removed",
+		"+added 1",
+	})
+
+	threeLineContext := toDiffLines([]string{
+		"diff --git a/main.go b/main.go",
+		"index 3b18e51..a9c2f1d 100644",
+		"--- a/main.go",
+		"+++ b/main.go",
+		"@@ -7,6 +7,7 @@",
+		" context 7",
+		" context 8",
+		" context 9",
+		"+added 10",
+		" context 11",
+		" context 12",
+		" context 13",
+		"@@ -16,6 +17,7 @@",
+		" context 17",
+		" context 18",
+		" context 19",
+		"+added 20",
+		" context 21",
+		" context 22",
+		" context 23",
+		"diff --git a/util.go b/util.go",
+		"@@ -1,1 +1,1 @@",
+		"-removed",
+		"+added 1",
+	})
+
+	var positionTests = []struct {
+		lines             []*diffLineData
+		newLines          []*diffLineData
+		lineIndex         uint
+		expectedLineIndex uint
+	}{
+		{lines: oneLineContext, newLines: threeLineContext, lineIndex: 0, expectedLineIndex: 0},
+		{lines: oneLineContext, newLines: threeLineContext, lineIndex: 6, expectedLineIndex: 8},
+		{lines: oneLineContext, newLines: threeLineContext, lineIndex: 8, expectedLineIndex: 12},
+		{lines: oneLineContext, newLines: threeLineContext, lineIndex: 14, expectedLineIndex: 23},
+		{lines: oneLineContext, newLines: threeLineContext, lineIndex: 15, expectedLineIndex: 23},
+		{lines: threeLineContext, newLines: oneLineContext, lineIndex: 5, expectedLineIndex: 4},
+		{lines: threeLineContext, newLines: oneLineContext, lineIndex: 14, expectedLineIndex: 4},
+		{lines: threeLineContext, newLines: oneLineContext, lineIndex: 17, expectedLineIndex: 11},
+		{lines: threeLineContext, newLines: oneLineContext, lineIndex: 18, expectedLineIndex: 8},
+		{lines: threeLineContext, newLines: oneLineContext, lineIndex: 21, expectedLineIndex: 13},
+	}
+
+	for _, positionTest := range positionTests {
+		position, found := determineDiffLinePosition(positionTest.lines, positionTest.lineIndex)
+		if !found {
+			t.Errorf("Expected position to be found for line index %v", positionTest.lineIndex)
+			continue
+		}
+
+		lineIndex, found := findDiffLinePosition(positionTest.newLines, position)
+		if !found {
+			t.Errorf("Expected line to be found for position %v", position)
+		} else if lineIndex != positionTest.expectedLineIndex {
+			t.Errorf("Line index does not match expected value for position %v. Expected: %v, Actual: %v",
+				position, positionTest.expectedLineIndex, lineIndex)
+		}
+	}
+}
+
+func TestDiffLinePositionIsNotFoundOutsideOfFileDiffs(t *testing.T) {
+	lines := []*diffLineData{
+		{line: "Author:\tme <me@example.com>", lineType: dltDiffCommitAuthor},
+		{line: "diff --git a/main.go b/main.go"},
+	}
+
+	if position, found := determineDiffLinePosition(lines, 0); found {
+		t.Errorf("Expected no position to be found but found: %v", position)
+	}
+
+	if lineIndex, found := findDiffLinePosition(lines, diffLinePosition{fileHeader: "diff --git a/util.go b/util.go"}); found {
+		t.Errorf("Expected no line to be found but found line index: %v", lineIndex)
+	}
+}
+
+func TestEstimateDiffLinesSize(t *testing.T) {
+	lines := []*diffLineData{
+		{line: "+added"},
+		{line: ""},
+	}
+
+	expectedSize := uint64(6 + 2*dvDiffLineSizeOverhead)
+
+	if size := estimateDiffLinesSize(lines); size != expectedSize {
+		t.Errorf("Diff lines size does not match expected value. Expected: %v, Actual: %v", expectedSize, size)
+	}
+}
+
+func TestSelectDiffEvictions(t *testing.T) {
+	near := &Commit{}
+	middle := &Commit{}
+	far := &Commit{}
+
+	candidates := func() []diffEvictionCandidate {
+		return []diffEvictionCandidate{
+			{commit: near, size: 10, distance: time.Minute},
+			{commit: far, size: 10, distance: time.Hour},
+			{commit: middle, size: 10, distance: 10 * time.Minute},
+		}
+	}
+
+	var diffEvictionTests = []struct {
+		totalSize       uint64
+		budget          uint64
+		expectedEvicted []*Commit
+	}{
+		{totalSize: 40, budget: 40},
+		{totalSize: 40, budget: 35, expectedEvicted: []*Commit{far}},
+		{totalSize: 40, budget: 20, expectedEvicted: []*Commit{far, middle}},
+		{totalSize: 40, budget: 5, expectedEvicted: []*Commit{far, middle, near}},
+	}
+
+	for _, diffEvictionTest := range diffEvictionTests {
+		evicted := selectDiffEvictions(candidates(), diffEvictionTest.totalSize, diffEvictionTest.budget)
+
+		if !commitsEqual(evicted, diffEvictionTest.expectedEvicted) {
+			t.Errorf("Evicted diffs do not match expected value for budget %v. Expected: %v, Actual: %v",
+				diffEvictionTest.budget, diffEvictionTest.expectedEvicted, evicted)
+		}
+	}
+}
+
+func commitsEqual(commits, otherCommits []*Commit) bool {
+	if len(commits) != len(otherCommits) {
+		return false
+	}
+
+	for index, commit := range commits {
+		if commit != otherCommits[index] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func TestSetDescribeLineUpdatesCommitHeader(t *testing.T) {
+	headerLines := func(describeLine bool) []*diffLineData {
+		lines := []*diffLineData{{line: "Author:\tGRV", lineType: dltDiffCommitAuthor}}
+		if describeLine {
+			lines = append(lines, &diffLineData{line: "Describe:\t" + dvDescribePending, lineType: dltDiffCommitDescribe})
+		}
+
+		return append(lines, &diffLineData{lineType: dltNormal}, &diffLineData{line: "Add feature", lineType: dltDiffCommitSummary})
+	}
+
+	var describeLineTests = []struct {
+		lines         []*diffLineData
+		description   string
+		expectedLines []string
+	}{
+		{
+			lines:         headerLines(true),
+			description:   "v1.0-3-gabc1234",
+			expectedLines: []string{"Author:\tGRV", "Describe:\tv1.0-3-gabc1234", "", "Add feature"},
+		},
+		{
+			lines:         headerLines(true),
+			description:   "",
+			expectedLines: []string{"Author:\tGRV", "", "Add feature"},
+		},
+		{
+			lines:         headerLines(false),
+			description:   "v1.0",
+			expectedLines: []string{"Author:\tGRV", "Describe:\tv1.0", "", "Add feature"},
+		},
+		{
+			lines:         headerLines(false),
+			description:   "",
+			expectedLines: []string{"Author:\tGRV", "", "Add feature"},
+		},
+	}
+
+	for _, describeLineTest := range describeLineTests {
+		lines := setDescribeLine(describeLineTest.lines, describeLineTest.description)
+
+		var actualLines []string
+		for _, line := range lines {
+			actualLines = append(actualLines, line.line)
+		}
+
+		if !reflect.DeepEqual(actualLines, describeLineTest.expectedLines) {
+			t.Errorf("Lines do not match expected value for description %q. Expected: %q, Actual: %q",
+				describeLineTest.description, describeLineTest.expectedLines, actualLines)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	edDefaultEditor = "vi"
+)
+
+// EditorRequest specifies a file to be edited in the user's editor
+// onComplete is called once the editor has exited
+type EditorRequest struct {
+	filePath   string
+	onComplete func(error)
+}
+
+// ResolveEditor determines the editor command in the same order of precedence as git:
+// GIT_EDITOR, core.editor, VISUAL and then EDITOR, falling back to vi
+func ResolveEditor(coreEditor string, getenv func(string) string) string {
+	if editor := getenv("GIT_EDITOR"); editor != "" {
+		return editor
+	}
+
+	if coreEditor != "" {
+		return coreEditor
+	}
+
+	for _, name := range []string{"VISUAL", "EDITOR"} {
+		if editor := getenv(name); editor != "" {
+			return editor
+		}
+	}
+
+	return edDefaultEditor
+}
+
+// editorCommand creates a command which runs the editor on the provided file
+// The editor is run by the shell as it may contain arguments
+func editorCommand(editor, filePath string) *exec.Cmd {
+	return exec.Command("sh", "-c", editor+` "$@"`, editor, filePath)
+}
+
+// runEditor suspends the display while the user edits the requested file
+func (grv *GRV) runEditor(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected editor request argument")
+	}
+
+	request, ok := action.Args[0].(EditorRequest)
+	if !ok {
+		return fmt.Errorf("Expected editor request argument to have type EditorRequest")
+	}
+
+	editor := grv.repoData.Editor()
+	log.Infof("Running editor %v on file %v", editor, request.filePath)
+
+	err = grv.ui.RunExternalProgram(editorCommand(editor, request.filePath))
+	if err != nil {
+		err = fmt.Errorf("Editor %v failed: %v", editor, err)
+	}
+
+	grv.channels.Channels().UpdateDisplay()
+	request.onComplete(err)
+
+	return nil
+}
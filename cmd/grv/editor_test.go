@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestResolveEditor(t *testing.T) {
+	var editorTests = []struct {
+		environment    map[string]string
+		coreEditor     string
+		expectedEditor string
+	}{
+		{
+			environment:    map[string]string{"GIT_EDITOR": "nano", "VISUAL": "emacs", "EDITOR": "vim"},
+			coreEditor:     "code --wait",
+			expectedEditor: "nano",
+		},
+		{
+			environment:    map[string]string{"VISUAL": "emacs", "EDITOR": "vim"},
+			coreEditor:     "code --wait",
+			expectedEditor: "code --wait",
+		},
+		{
+			environment:    map[string]string{"VISUAL": "emacs", "EDITOR": "vim"},
+			expectedEditor: "emacs",
+		},
+		{
+			environment:    map[string]string{"EDITOR": "vim"},
+			expectedEditor: "vim",
+		},
+		{
+			environment:    map[string]string{},
+			expectedEditor: "vi",
+		},
+	}
+
+	for _, editorTest := range editorTests {
+		getenv := func(name string) string {
+			return editorTest.environment[name]
+		}
+
+		if editor := ResolveEditor(editorTest.coreEditor, getenv); editor != editorTest.expectedEditor {
+			t.Errorf("Editor does not match expected value. Expected: %v, Actual: %v", editorTest.expectedEditor, editor)
+		}
+	}
+}
@@ -41,7 +41,7 @@ func (errorView *ErrorView) Render(win RenderWindow) (err error) {
 		}
 
 		err = errorView.errors[i-1]
-		lineBuilder.AppendWithStyle(CmpErrorViewErrors, " %v", err)
+		lineBuilder.AppendWithStyle(CmpErrorViewErrors, " ").AppendANSI(CmpErrorViewErrors, err.Error())
 	}
 
 	win.DrawBorder()
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// CommandRequest specifies an external command to run in the terminal
+// onComplete is called once the command has exited
+type CommandRequest struct {
+	cmd         *exec.Cmd
+	description string
+	onComplete  func(error)
+}
+
+// runCommand suspends the display while the requested command runs in the terminal
+func (grv *GRV) runCommand(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected command request argument")
+	}
+
+	request, ok := action.Args[0].(CommandRequest)
+	if !ok {
+		return fmt.Errorf("Expected command request argument to have type CommandRequest")
+	}
+
+	log.Infof("Running %v: %v", request.description, request.cmd.Args)
+
+	err = grv.ui.RunExternalProgram(request.cmd)
+	if err != nil {
+		err = fmt.Errorf("%v failed: %v", request.description, err)
+	}
+
+	grv.channels.Channels().UpdateDisplay()
+	request.onComplete(err)
+
+	return nil
+}
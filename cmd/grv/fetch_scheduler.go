@@ -0,0 +1,86 @@
+package main
+
+import (
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// FetchScheduler periodically invokes a fetch function in the background
+type FetchScheduler struct {
+	fetch    func()
+	interval time.Duration
+	stopCh   chan bool
+	lock     sync.Mutex
+}
+
+// NewFetchScheduler creates a new scheduler which is initially stopped
+func NewFetchScheduler(fetch func()) *FetchScheduler {
+	return &FetchScheduler{
+		fetch: fetch,
+	}
+}
+
+// SetInterval restarts the scheduler with the provided interval
+// An interval of zero stops the scheduler
+func (fetchScheduler *FetchScheduler) SetInterval(interval time.Duration) {
+	fetchScheduler.lock.Lock()
+	defer fetchScheduler.lock.Unlock()
+
+	if interval == fetchScheduler.interval {
+		return
+	}
+
+	fetchScheduler.stop()
+	fetchScheduler.interval = interval
+
+	if interval <= 0 {
+		log.Info("Background fetching disabled")
+		return
+	}
+
+	log.Infof("Fetching in the background every %v", interval)
+
+	stopCh := make(chan bool)
+	fetchScheduler.stopCh = stopCh
+	ticker := time.NewTicker(interval)
+
+	go func() {
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				fetchScheduler.fetch()
+			case <-stopCh:
+				return
+			}
+		}
+	}()
+}
+
+func (fetchScheduler *FetchScheduler) stop() {
+	if fetchScheduler.stopCh != nil {
+		close(fetchScheduler.stopCh)
+		fetchScheduler.stopCh = nil
+	}
+}
+
+// UpdatedBranches returns the names of branches which are new or point to a different commit
+// than they did previously
+func UpdatedBranches(previousBranches, branches []*Branch) (updatedBranches []string) {
+	previousOids := make(map[string]string)
+
+	for _, branch := range previousBranches {
+		previousOids[branch.name] = branch.oid.String()
+	}
+
+	for _, branch := range branches {
+		if previousOid, ok := previousOids[branch.name]; !ok || previousOid != branch.oid.String() {
+			updatedBranches = append(updatedBranches, branch.name)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+func newTestBranch(t *testing.T, name, oidStr string) *Branch {
+	rawOid, err := git.NewOid(oidStr)
+	if err != nil {
+		t.Fatalf("Unable to create oid %v: %v", oidStr, err)
+	}
+
+	return &Branch{
+		name:     name,
+		oid:      &Oid{oid: rawOid},
+		isRemote: true,
+	}
+}
+
+func TestUpdatedBranchesAreDetected(t *testing.T) {
+	previousBranches := []*Branch{
+		newTestBranch(t, "origin/master", "3b18e512dba79e4c8300dd08aeb37f8e728b8dad"),
+		newTestBranch(t, "origin/develop", "4b825dc642cb6eb9a060e54bf8d69288fbee4904"),
+		newTestBranch(t, "origin/removed", "4b825dc642cb6eb9a060e54bf8d69288fbee4904"),
+	}
+
+	branches := []*Branch{
+		newTestBranch(t, "origin/master", "3b18e512dba79e4c8300dd08aeb37f8e728b8dad"),
+		newTestBranch(t, "origin/develop", "a9c2f1d3b18e512dba79e4c8300dd08aeb37f8ed"),
+		newTestBranch(t, "origin/feature", "4b825dc642cb6eb9a060e54bf8d69288fbee4904"),
+	}
+
+	expectedUpdatedBranches := []string{"origin/develop", "origin/feature"}
+
+	if updatedBranches := UpdatedBranches(previousBranches, branches); !reflect.DeepEqual(updatedBranches, expectedUpdatedBranches) {
+		t.Errorf("Updated branches do not match expected value. Expected: %v, Actual: %v", expectedUpdatedBranches, updatedBranches)
+	}
+}
@@ -0,0 +1,672 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	fvBinaryCheckLength = 8000
+	fvBlameAuthorWidth  = 16
+)
+
+type fileViewHandler func(*FileView, Action) error
+
+// FileRequest contains the file to display in the file view
+// lineNumber is the line to select once the file has loaded (1 based, 0 selects the first line)
+type FileRequest struct {
+	commit     *Commit
+	filePath   string
+	lineNumber uint
+}
+
+// LineHistoryRequest contains the line range to display the history of in the diff view
+type LineHistoryRequest struct {
+	commit    *Commit
+	filePath  string
+	startLine uint
+	endLine   uint
+}
+
+// FileView displays the contents of a file at a commit
+// Regions of the file can be folded and each line can be annotated with the commit which last modified it
+type FileView struct {
+	channels       *Channels
+	repoData       RepoData
+	config         Config
+	commit         *Commit
+	filePath       string
+	lines          []string
+	tokens         [][]syntaxToken
+	folds          []foldRegion
+	closedFolds    map[uint]bool
+	visibleLines   []uint
+	blameHunks     []*BlameHunk
+	showBlame      bool
+	loadingBlame   bool
+	loading        bool
+	loadGeneration uint
+	active         bool
+	viewPos        ViewPos
+	viewDimension  ViewDimension
+	handlers       map[ActionType]fileViewHandler
+	lock           sync.Mutex
+}
+
+// NewFileView creates a new instance
+func NewFileView(repoData RepoData, channels *Channels, config Config) *FileView {
+	return &FileView{
+		repoData:    repoData,
+		channels:    channels,
+		config:      config,
+		closedFolds: make(map[uint]bool),
+		viewPos:     NewViewPosition(),
+		handlers: map[ActionType]fileViewHandler{
+			ActionPrevLine:           moveUpFileLine,
+			ActionNextLine:           moveDownFileLine,
+			ActionPrevPage:           moveUpFilePage,
+			ActionNextPage:           moveDownFilePage,
+			ActionScrollHalfPageUp:   moveUpFileHalfPage,
+			ActionScrollHalfPageDown: moveDownFileHalfPage,
+			ActionCenterView:         centerFileView,
+			ActionScrollRight:        scrollFileViewRight,
+			ActionScrollLeft:         scrollFileViewLeft,
+			ActionFirstLine:          moveToFirstFileLine,
+			ActionLastLine:           moveToLastFileLine,
+			ActionGotoLine:           gotoFileLine,
+			ActionToggleFold:         toggleFold,
+			ActionOpenAllFolds:       openAllFolds,
+			ActionCloseAllFolds:      closeAllFolds,
+			ActionToggleBlame:        toggleFileBlame,
+			ActionLineHistory:        showFileLineHistory,
+			ActionDirectoryOwnership: showFileDirectoryOwnership,
+		},
+	}
+}
+
+// Initialise does nothing
+func (fileView *FileView) Initialise() (err error) {
+	log.Info("Initialising FileView")
+	return
+}
+
+// LoadFile replaces the displayed file with the requested file
+// The file is loaded asynchronously and the requested line is selected once it has loaded
+func (fileView *FileView) LoadFile(request FileRequest) {
+	fileView.lock.Lock()
+	fileView.commit = request.commit
+	fileView.filePath = request.filePath
+	fileView.lines = nil
+	fileView.tokens = nil
+	fileView.folds = nil
+	fileView.closedFolds = make(map[uint]bool)
+	fileView.visibleLines = nil
+	fileView.blameHunks = nil
+	fileView.loadingBlame = false
+	fileView.loading = true
+	fileView.loadGeneration++
+	fileView.viewPos = NewViewPosition()
+	loadGeneration := fileView.loadGeneration
+	showBlame := fileView.showBlame
+	fileView.lock.Unlock()
+
+	fileView.channels.UpdateDisplay()
+
+	go func() {
+		lines, err := fileView.fileLines(request.commit, request.filePath)
+
+		fileView.lock.Lock()
+		defer fileView.lock.Unlock()
+
+		if loadGeneration != fileView.loadGeneration {
+			return
+		}
+
+		fileView.loading = false
+
+		if err != nil {
+			fileView.channels.ReportError(err)
+			fileView.channels.UpdateDisplay()
+			return
+		}
+
+		language := syntaxLanguageForPath(request.filePath)
+		indentFolding := language == nil || language.indentFolding
+
+		fileView.lines = lines
+		fileView.tokens = highlightLines(language, lines)
+		fileView.folds = detectFolds(lines, indentFolding)
+		fileView.updateVisibleLines()
+
+		if request.lineNumber > 0 {
+			fileView.selectLine(request.lineNumber - 1)
+		}
+
+		if showBlame {
+			fileView.loadBlame()
+		}
+
+		fileView.channels.ReportStatus("Loaded %v lines of %v", len(lines), request.filePath)
+		fileView.channels.UpdateDisplay()
+	}()
+}
+
+func (fileView *FileView) fileLines(commit *Commit, filePath string) (lines []string, err error) {
+	data, err := fileView.repoData.FileContents(commit, filePath)
+	if err != nil {
+		return
+	}
+
+	checkLength := len(data)
+	if checkLength > fvBinaryCheckLength {
+		checkLength = fvBinaryCheckLength
+	}
+
+	if bytes.IndexByte(data[:checkLength], 0) != -1 {
+		return nil, fmt.Errorf("%v is a binary file", filePath)
+	}
+
+	return strings.Split(strings.TrimSuffix(string(data), "\n"), "\n"), nil
+}
+
+// loadBlame determines the commit which last modified each line of the file in the background
+func (fileView *FileView) loadBlame() {
+	if fileView.blameHunks != nil || fileView.loadingBlame || fileView.commit == nil {
+		return
+	}
+
+	fileView.loadingBlame = true
+	commit := fileView.commit
+	filePath := fileView.filePath
+	loadGeneration := fileView.loadGeneration
+
+	go func() {
+		hunks, err := fileView.repoData.Blame(commit, filePath)
+
+		fileView.lock.Lock()
+		defer fileView.lock.Unlock()
+
+		if loadGeneration != fileView.loadGeneration {
+			return
+		}
+
+		fileView.loadingBlame = false
+
+		if err != nil {
+			fileView.channels.ReportError(err)
+			return
+		}
+
+		fileView.blameHunks = blameLineHunks(hunks, uint(len(fileView.lines)))
+		fileView.channels.UpdateDisplay()
+	}()
+}
+
+// blameLineHunks returns the hunk each line of the file belongs to
+func blameLineHunks(hunks []*BlameHunk, lineCount uint) []*BlameHunk {
+	lineHunks := make([]*BlameHunk, lineCount)
+
+	for _, hunk := range hunks {
+		for lineIndex := hunk.startLine - 1; lineIndex < hunk.startLine-1+hunk.lineCount && lineIndex < lineCount; lineIndex++ {
+			lineHunks[lineIndex] = hunk
+		}
+	}
+
+	return lineHunks
+}
+
+func (fileView *FileView) updateVisibleLines() {
+	fileView.visibleLines = visibleLineIndexes(uint(len(fileView.lines)), fileView.folds, fileView.closedFolds)
+}
+
+// selectedLineIndex returns the index of the file line displayed on the active row
+func (fileView *FileView) selectedLineIndex() (lineIndex uint, ok bool) {
+	activeRowIndex := fileView.viewPos.ActiveRowIndex()
+	if activeRowIndex >= uint(len(fileView.visibleLines)) {
+		return
+	}
+
+	return fileView.visibleLines[activeRowIndex], true
+}
+
+// selectLine moves the active row to the provided file line, opening any closed folds which hide it
+func (fileView *FileView) selectLine(lineIndex uint) {
+	if len(fileView.lines) == 0 {
+		return
+	} else if lineIndex >= uint(len(fileView.lines)) {
+		lineIndex = uint(len(fileView.lines)) - 1
+	}
+
+	for _, region := range fileView.folds {
+		if region.startLine < lineIndex && region.endLine >= lineIndex {
+			delete(fileView.closedFolds, region.startLine)
+		}
+	}
+
+	fileView.updateVisibleLines()
+
+	for rowIndex, visibleLineIndex := range fileView.visibleLines {
+		if visibleLineIndex == lineIndex {
+			fileView.viewPos.SetActiveRowIndex(uint(rowIndex))
+			break
+		}
+	}
+}
+
+// Render generates and writes the file view to the provided window
+func (fileView *FileView) Render(win RenderWindow) (err error) {
+	log.Debug("Rendering FileView")
+	fileView.lock.Lock()
+	defer fileView.lock.Unlock()
+
+	fileView.viewDimension = win.ViewDimensions()
+
+	rows := win.Rows() - 2
+	rowNum := uint(len(fileView.visibleLines))
+	viewPos := fileView.viewPos
+	viewPos.DetermineViewStartRow(rows, rowNum, uint(fileView.config.GetInt(CfScrollOff)))
+	rowIndex := viewPos.ViewStartRowIndex()
+	startColumn := viewPos.ViewStartColumn()
+	lineNumberWidth := len(fmt.Sprintf("%v", len(fileView.lines)))
+	showBlame := fileView.showBlame && fileView.blameHunks != nil
+	blameIDWidth := fileView.blameIDWidth()
+
+	for winRowIndex := uint(0); winRowIndex < rows && rowIndex < rowNum; winRowIndex++ {
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(winRowIndex+1, startColumn); err != nil {
+			return
+		}
+
+		lineIndex := fileView.visibleLines[rowIndex]
+		lineBuilder.AppendGutter(CmpAllviewLineNumber, " %*v ", lineNumberWidth, lineIndex+1)
+
+		if showBlame {
+			lineBuilder.AppendGutter(CmpFileviewBlame, "%v", fileView.blameColumn(lineIndex, blameIDWidth))
+		}
+
+		lineBuilder.Append(" ").StartInvisibles()
+
+		for _, token := range fileView.tokens[lineIndex] {
+			lineBuilder.AppendWithStyle(syntaxTokenThemeComponentID[token.tokenType], "%v", token.text)
+		}
+
+		lineBuilder.EndInvisibles()
+
+		if fileView.closedFolds[lineIndex] {
+			if region, found := outermostFold(fileView.folds, lineIndex); found {
+				lineBuilder.AppendWithStyle(CmpFileviewFold, " ··· %v lines", region.hiddenLineCount())
+			}
+		}
+
+		rowIndex++
+	}
+
+	if fileView.loading {
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(1, 1); err != nil {
+			return
+		}
+
+		lineBuilder.Append(" Loading %v...", fileView.filePath)
+	}
+
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, fileView.active); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	return fileView.renderTitle(win)
+}
+
+// blameIDWidth returns the width of the abbreviated commit ids displayed in the blame column
+func (fileView *FileView) blameIDWidth() (width int) {
+	for _, hunk := range fileView.blameHunks {
+		if hunk != nil {
+			if idWidth := len(fileView.repoData.ShortID(hunk.oid)); idWidth > width {
+				width = idWidth
+			}
+		}
+	}
+
+	return
+}
+
+// blameColumn returns the abbreviated commit id and author of the commit which last modified the line
+// The annotation is only displayed on the first line of each hunk to keep the view uncluttered
+func (fileView *FileView) blameColumn(lineIndex uint, idWidth int) string {
+	hunk := fileView.blameHunks[lineIndex]
+	if hunk == nil || hunk.startLine-1 != lineIndex {
+		return fmt.Sprintf(" %*v %-*v", idWidth, "", fvBlameAuthorWidth, "")
+	}
+
+	author := []rune(hunk.author)
+	if len(author) > fvBlameAuthorWidth {
+		author = author[:fvBlameAuthorWidth]
+	}
+
+	return fmt.Sprintf(" %-*v %-*v", idWidth, fileView.repoData.ShortID(hunk.oid), fvBlameAuthorWidth, string(author))
+}
+
+func (fileView *FileView) renderTitle(win RenderWindow) error {
+	if fileView.commit == nil {
+		return win.SetTitle(CmpFileviewTitle, "File")
+	}
+
+	titleBuilder := NewTitleBuilder("File").
+		Breadcrumb("%v", fileView.repoData.ShortID(fileView.commit.oid)).
+		Breadcrumb("%v", fileView.filePath)
+
+	if lineIndex, ok := fileView.selectedLineIndex(); ok {
+		titleBuilder.Detail("Line %v of %v", lineIndex+1, len(fileView.lines))
+	}
+
+	return win.SetTitle(CmpFileviewTitle, "%v", titleBuilder)
+}
+
+// RenderStatusBar does nothing
+func (fileView *FileView) RenderStatusBar(lineBuilder *LineBuilder) (err error) {
+	return
+}
+
+// RenderHelpBar generates key binding help info for the file view
+func (fileView *FileView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(fileView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionToggleFold, message: "Toggle Fold"},
+		{action: ActionToggleBlame, message: "Toggle Blame"},
+		{action: ActionLineHistory, message: "Line History"},
+		{action: ActionPrompt, message: "Command Prompt"},
+	})
+
+	return
+}
+
+// HandleKeyPress does nothing
+func (fileView *FileView) HandleKeyPress(keystring string) (err error) {
+	log.Debugf("FileView handling key %v - NOP", keystring)
+	return
+}
+
+// HandleAction checks if the file view supports the provided action and executes it if so
+func (fileView *FileView) HandleAction(action Action) (err error) {
+	log.Debugf("FileView handling action %v", action)
+	fileView.lock.Lock()
+	defer fileView.lock.Unlock()
+
+	if handler, ok := fileView.handlers[action.ActionType]; ok {
+		err = handler(fileView, action)
+	}
+
+	return
+}
+
+// OnActiveChange updates whether the file view is active
+func (fileView *FileView) OnActiveChange(active bool) {
+	log.Debugf("FileView active: %v", active)
+	fileView.lock.Lock()
+	defer fileView.lock.Unlock()
+
+	fileView.active = active
+}
+
+// ViewID returns the view ID of the file view
+func (fileView *FileView) ViewID() ViewID {
+	return ViewFile
+}
+
+func (fileView *FileView) rows() uint {
+	return uint(len(fileView.visibleLines))
+}
+
+func moveUpFileLine(fileView *FileView, action Action) (err error) {
+	if fileView.viewPos.MoveLinesUp(action.RepeatCount()) {
+		fileView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownFileLine(fileView *FileView, action Action) (err error) {
+	if fileView.viewPos.MoveLinesDown(action.RepeatCount(), fileView.rows()) {
+		fileView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpFilePage(fileView *FileView, action Action) (err error) {
+	if fileView.viewPos.MovePageUp((fileView.viewDimension.rows - 2) * action.RepeatCount()) {
+		fileView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownFilePage(fileView *FileView, action Action) (err error) {
+	if fileView.viewPos.MovePageDown((fileView.viewDimension.rows-2)*action.RepeatCount(), fileView.rows()) {
+		fileView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveUpFileHalfPage(fileView *FileView, action Action) (err error) {
+	if fileView.viewPos.MoveHalfPageUp((fileView.viewDimension.rows - 2) * action.RepeatCount()) {
+		fileView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownFileHalfPage(fileView *FileView, action Action) (err error) {
+	if fileView.viewPos.MoveHalfPageDown((fileView.viewDimension.rows-2)*action.RepeatCount(), fileView.rows()) {
+		fileView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func centerFileView(fileView *FileView, action Action) (err error) {
+	if fileView.viewPos.CenterActiveRow(fileView.viewDimension.rows - 2) {
+		fileView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func scrollFileViewRight(fileView *FileView, action Action) (err error) {
+	fileView.viewPos.MovePageRight(fileView.viewDimension.cols)
+	fileView.channels.UpdateDisplay()
+
+	return
+}
+
+func scrollFileViewLeft(fileView *FileView, action Action) (err error) {
+	if fileView.viewPos.MovePageLeft(fileView.viewDimension.cols) {
+		fileView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToFirstFileLine(fileView *FileView, action Action) (err error) {
+	if fileView.viewPos.MoveToFirstLine() {
+		fileView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToLastFileLine(fileView *FileView, action Action) (err error) {
+	if fileView.viewPos.MoveToLastLine(fileView.rows()) {
+		fileView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func gotoFileLine(fileView *FileView, action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected line number argument")
+	}
+
+	lineNumber, ok := action.Args[0].(uint)
+	if !ok {
+		return fmt.Errorf("Expected line number argument to have type uint")
+	}
+
+	if len(fileView.lines) == 0 {
+		return
+	} else if lineNumber == 0 || lineNumber > uint(len(fileView.lines)) {
+		return fmt.Errorf("Line %v is outside of the range 1-%v", lineNumber, len(fileView.lines))
+	}
+
+	fileView.selectLine(lineNumber - 1)
+	fileView.channels.UpdateDisplay()
+
+	return
+}
+
+// toggleFold opens the fold starting on the selected line if it is closed
+// and otherwise closes the innermost fold containing the selected line
+func toggleFold(fileView *FileView, action Action) (err error) {
+	lineIndex, ok := fileView.selectedLineIndex()
+	if !ok {
+		return
+	}
+
+	if fileView.closedFolds[lineIndex] {
+		delete(fileView.closedFolds, lineIndex)
+	} else if region, found := innermostFoldContaining(fileView.folds, lineIndex); found {
+		fileView.closedFolds[region.startLine] = true
+		lineIndex = region.startLine
+	} else {
+		return fmt.Errorf("No fold at line %v", lineIndex+1)
+	}
+
+	fileView.updateVisibleLines()
+	fileView.selectLine(lineIndex)
+	fileView.channels.UpdateDisplay()
+
+	return
+}
+
+func openAllFolds(fileView *FileView, action Action) (err error) {
+	lineIndex, ok := fileView.selectedLineIndex()
+	if !ok {
+		return
+	}
+
+	fileView.closedFolds = make(map[uint]bool)
+	fileView.selectLine(lineIndex)
+	fileView.channels.UpdateDisplay()
+
+	return
+}
+
+func closeAllFolds(fileView *FileView, action Action) (err error) {
+	lineIndex, ok := fileView.selectedLineIndex()
+	if !ok {
+		return
+	}
+
+	for _, region := range fileView.folds {
+		fileView.closedFolds[region.startLine] = true
+	}
+
+	fileView.updateVisibleLines()
+
+	for _, visibleLineIndex := range fileView.visibleLines {
+		if visibleLineIndex > lineIndex {
+			break
+		}
+
+		lineIndex = visibleLineIndex
+	}
+
+	fileView.selectLine(lineIndex)
+	fileView.channels.UpdateDisplay()
+
+	return
+}
+
+func toggleFileBlame(fileView *FileView, action Action) (err error) {
+	fileView.showBlame = !fileView.showBlame
+
+	if fileView.showBlame && !fileView.loading {
+		fileView.loadBlame()
+	}
+
+	fileView.channels.UpdateDisplay()
+
+	return
+}
+
+// showFileLineHistory displays the history of the selected line in the diff view
+// If the selected line is the start of a closed fold then the history of the folded region is displayed
+func showFileLineHistory(fileView *FileView, action Action) (err error) {
+	lineIndex, ok := fileView.selectedLineIndex()
+	if !ok {
+		return
+	}
+
+	endLineIndex := lineIndex
+	if fileView.closedFolds[lineIndex] {
+		if region, found := outermostFold(fileView.folds, lineIndex); found {
+			endLineIndex = region.endLine
+		}
+	}
+
+	fileView.channels.DoAction(Action{
+		ActionType: ActionShowLineHistory,
+		Args: []interface{}{LineHistoryRequest{
+			commit:    fileView.commit,
+			filePath:  fileView.filePath,
+			startLine: lineIndex + 1,
+			endLine:   endLineIndex + 1,
+		}},
+	})
+
+	return
+}
+
+// showDiffFile opens the selected file of the diff in the file view at the selected line
+func showDiffFile(diffView *DiffView, action Action) (err error) {
+	if diffView.lineHistory != nil {
+		return fmt.Errorf("Files cannot be opened from line history")
+	}
+
+	filePath, err := diffView.selectedFilePath()
+	if err != nil {
+		return
+	}
+
+	commit := diffView.activeCommit
+	if diffView.revisionDiff != nil {
+		commit = diffView.revisionDiff.toCommit
+	}
+
+	if commit == nil {
+		return fmt.Errorf("No diff loaded")
+	}
+
+	var lineNumber uint
+	if activeLines := diffView.activeDiffLines(); activeLines != nil {
+		lineNumber, _, _ = selectedDiffLineRange(activeLines.lines, int(diffView.viewPos.ActiveRowIndex()))
+	}
+
+	diffView.channels.DoAction(Action{
+		ActionType: ActionShowFile,
+		Args: []interface{}{FileRequest{
+			commit:     commit,
+			filePath:   filePath,
+			lineNumber: lineNumber,
+		}},
+	})
+
+	return
+}
@@ -135,7 +135,21 @@ func (binaryExpression *BinaryExpression) generateFilter(fieldDescriptor FieldDe
 	}
 
 	return func(inputValue interface{}) bool {
-		return comparator(lhs.getValue(inputValue, fieldDescriptor), rhs.getValue(inputValue, fieldDescriptor))
+		lhsValue := lhs.getValue(inputValue, fieldDescriptor)
+		rhsValue := rhs.getValue(inputValue, fieldDescriptor)
+
+		// Fields with multiple values match if any of their values match
+		if values, ok := lhsValue.([]string); ok {
+			for _, value := range values {
+				if comparator(value, rhsValue) {
+					return true
+				}
+			}
+
+			return false
+		}
+
+		return comparator(lhsValue, rhsValue)
 	}
 }
 
@@ -10,6 +10,7 @@ type TestRecord struct {
 	id          int
 	name        string
 	lastUpdated time.Time
+	labels      []string
 }
 
 type TestRecordFieldDescriptor struct{}
@@ -24,6 +25,8 @@ func (testRecordFieldDescriptor *TestRecordFieldDescriptor) FieldValue(inputValu
 		return testRecord.name
 	case "lastupdated":
 		return testRecord.lastUpdated
+	case "labels":
+		return testRecord.labels
 	}
 
 	panic("Invalid field")
@@ -39,6 +42,8 @@ func (testRecordFieldDescriptor *TestRecordFieldDescriptor) FieldType(fieldName
 		fieldType = FtString
 	case "lastupdated":
 		fieldType = FtDate
+	case "labels":
+		fieldType = FtString
 	default:
 		fieldExists = false
 	}
@@ -466,3 +471,44 @@ func TestFieldValuesAreRetrievedFromInput(t *testing.T) {
 		}
 	}
 }
+
+func TestMultiValueFieldsMatchIfAnyValueMatches(t *testing.T) {
+	var multiValueTests = []struct {
+		inputQuery           string
+		expectedFilterOutput bool
+	}{
+		{
+			inputQuery:           `Labels = "bug"`,
+			expectedFilterOutput: true,
+		},
+		{
+			inputQuery:           `Labels = "feature"`,
+			expectedFilterOutput: false,
+		},
+		{
+			inputQuery:           `Labels GLOB "ui*"`,
+			expectedFilterOutput: true,
+		},
+		{
+			inputQuery:           `Labels != "bug"`,
+			expectedFilterOutput: true,
+		},
+	}
+
+	testRecord := &TestRecord{
+		labels: []string{"bug", "ui-regression"},
+	}
+
+	for _, multiValueTest := range multiValueTests {
+		inputQuery := multiValueTest.inputQuery
+		expectedFilterOutput := multiValueTest.expectedFilterOutput
+
+		filter, errors := CreateFilter(inputQuery, &TestRecordFieldDescriptor{})
+
+		if len(errors) > 0 {
+			t.Errorf("CreateFilter failed with errors %v", errors)
+		} else if actualFilterOutput := filter(testRecord); expectedFilterOutput != actualFilterOutput {
+			t.Errorf("Filter output does not match expected value for query \"%v\". Expected: %v, Actual: %v", inputQuery, expectedFilterOutput, actualFilterOutput)
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package main
+
+import (
+	"strings"
+
+	slice "github.com/bradfitz/slice"
+)
+
+var (
+	foldRegionStartMarkers = []string{"#region", "region", "{{{"}
+	foldRegionEndMarkers   = []string{"#endregion", "endregion", "}}}"}
+)
+
+// foldRegion is a range of lines which can be folded
+// The start line remains visible when the region is folded
+type foldRegion struct {
+	startLine uint
+	endLine   uint
+}
+
+func (region foldRegion) hiddenLineCount() uint {
+	return region.endLine - region.startLine
+}
+
+// detectFolds determines the regions of the file which can be folded using simple heuristics.
+// Regions are created for braces which are opened on one line and closed on a later line,
+// comments containing region/endregion markers and, when indentFolding is set, increases in indentation.
+// The returned regions are ordered by start line with outer regions before inner regions
+func detectFolds(lines []string, indentFolding bool) (regions []foldRegion) {
+	regions = append(regions, detectBraceFolds(lines)...)
+	regions = append(regions, detectMarkerFolds(lines)...)
+
+	if indentFolding {
+		regions = append(regions, detectIndentFolds(lines)...)
+	}
+
+	slice.Sort(regions, func(i, j int) bool {
+		if regions[i].startLine == regions[j].startLine {
+			return regions[i].endLine > regions[j].endLine
+		}
+
+		return regions[i].startLine < regions[j].startLine
+	})
+
+	return uniqueFoldRegions(regions)
+}
+
+func detectBraceFolds(lines []string) (regions []foldRegion) {
+	var openLines []uint
+
+	for lineIndex, line := range lines {
+		for _, char := range line {
+			switch char {
+			case '{':
+				openLines = append(openLines, uint(lineIndex))
+			case '}':
+				if len(openLines) == 0 {
+					continue
+				}
+
+				startLine := openLines[len(openLines)-1]
+				openLines = openLines[:len(openLines)-1]
+
+				if uint(lineIndex) > startLine {
+					regions = append(regions, foldRegion{startLine: startLine, endLine: uint(lineIndex)})
+				}
+			}
+		}
+	}
+
+	return
+}
+
+func detectMarkerFolds(lines []string) (regions []foldRegion) {
+	var openLines []uint
+
+	for lineIndex, line := range lines {
+		switch {
+		case containsFoldMarker(line, foldRegionEndMarkers):
+			if len(openLines) > 0 {
+				startLine := openLines[len(openLines)-1]
+				openLines = openLines[:len(openLines)-1]
+				regions = append(regions, foldRegion{startLine: startLine, endLine: uint(lineIndex)})
+			}
+		case containsFoldMarker(line, foldRegionStartMarkers):
+			openLines = append(openLines, uint(lineIndex))
+		}
+	}
+
+	return
+}
+
+func containsFoldMarker(line string, markers []string) bool {
+	fields := strings.Fields(strings.TrimLeft(strings.TrimSpace(line), "/#*-;<!"))
+	if len(fields) == 0 {
+		return false
+	}
+
+	for _, marker := range markers {
+		if strings.EqualFold(fields[0], marker) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func detectIndentFolds(lines []string) (regions []foldRegion) {
+	type indentedLine struct {
+		lineIndex uint
+		indent    int
+	}
+
+	var openLines []indentedLine
+	var lastLine uint
+
+	closeRegions := func(indent int) {
+		for len(openLines) > 0 && openLines[len(openLines)-1].indent >= indent {
+			startLine := openLines[len(openLines)-1].lineIndex
+			openLines = openLines[:len(openLines)-1]
+
+			if lastLine > startLine {
+				regions = append(regions, foldRegion{startLine: startLine, endLine: lastLine})
+			}
+		}
+	}
+
+	for lineIndex, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		closeRegions(indent)
+		openLines = append(openLines, indentedLine{lineIndex: uint(lineIndex), indent: indent})
+		lastLine = uint(lineIndex)
+	}
+
+	closeRegions(0)
+
+	return
+}
+
+func uniqueFoldRegions(regions []foldRegion) (uniqueRegions []foldRegion) {
+	for index, region := range regions {
+		if index == 0 || region != regions[index-1] {
+			uniqueRegions = append(uniqueRegions, region)
+		}
+	}
+
+	return
+}
+
+// visibleLineIndexes returns the indexes of the lines which are not hidden by a closed fold
+// closedFolds is keyed by the start line of each closed region
+func visibleLineIndexes(lineCount uint, regions []foldRegion, closedFolds map[uint]bool) (lineIndexes []uint) {
+	hiddenUntil := -1
+
+	regionIndex := 0
+	for lineIndex := uint(0); lineIndex < lineCount; lineIndex++ {
+		if int(lineIndex) <= hiddenUntil {
+			continue
+		}
+
+		lineIndexes = append(lineIndexes, lineIndex)
+
+		for ; regionIndex < len(regions) && regions[regionIndex].startLine <= lineIndex; regionIndex++ {
+			region := regions[regionIndex]
+
+			if region.startLine == lineIndex && closedFolds[lineIndex] && int(region.endLine) > hiddenUntil {
+				hiddenUntil = int(region.endLine)
+			}
+		}
+	}
+
+	return
+}
+
+// outermostFold returns the largest region starting on the line
+func outermostFold(regions []foldRegion, lineIndex uint) (region foldRegion, found bool) {
+	for _, region := range regions {
+		if region.startLine == lineIndex {
+			return region, true
+		}
+	}
+
+	return
+}
+
+// innermostFoldContaining returns the smallest region containing the line
+func innermostFoldContaining(regions []foldRegion, lineIndex uint) (containingRegion foldRegion, found bool) {
+	for _, region := range regions {
+		if region.startLine <= lineIndex && region.endLine >= lineIndex {
+			containingRegion = region
+			found = true
+		}
+	}
+
+	return
+}
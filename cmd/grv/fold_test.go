@@ -0,0 +1,106 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDetectFolds(t *testing.T) {
+	var foldTests = []struct {
+		lines           []string
+		indentFolding   bool
+		expectedRegions []foldRegion
+	}{
+		{
+			lines: []string{
+				"func main() {",
+				"	if true {",
+				"		run()",
+				"	}",
+				"	call({})",
+				"}",
+			},
+			expectedRegions: []foldRegion{
+				{startLine: 0, endLine: 5},
+				{startLine: 1, endLine: 3},
+			},
+		},
+		{
+			lines: []string{
+				"// region setup",
+				"x := 1",
+				"// endregion",
+			},
+			expectedRegions: []foldRegion{
+				{startLine: 0, endLine: 2},
+			},
+		},
+		{
+			lines: []string{
+				"def a():",
+				"    if x:",
+				"        pass",
+				"",
+				"    return",
+				"b = 1",
+			},
+			indentFolding: true,
+			expectedRegions: []foldRegion{
+				{startLine: 0, endLine: 4},
+				{startLine: 1, endLine: 2},
+			},
+		},
+	}
+
+	for _, foldTest := range foldTests {
+		regions := detectFolds(foldTest.lines, foldTest.indentFolding)
+
+		if !reflect.DeepEqual(foldTest.expectedRegions, regions) {
+			t.Errorf("Fold regions do not match expected value for lines %q. Expected: %v, Actual: %v",
+				foldTest.lines, foldTest.expectedRegions, regions)
+		}
+	}
+}
+
+func TestVisibleLineIndexes(t *testing.T) {
+	regions := []foldRegion{
+		{startLine: 0, endLine: 5},
+		{startLine: 1, endLine: 3},
+	}
+
+	var visibleTests = []struct {
+		closedFolds         map[uint]bool
+		expectedLineIndexes []uint
+	}{
+		{
+			closedFolds:         map[uint]bool{},
+			expectedLineIndexes: []uint{0, 1, 2, 3, 4, 5, 6},
+		},
+		{
+			closedFolds:         map[uint]bool{1: true},
+			expectedLineIndexes: []uint{0, 1, 4, 5, 6},
+		},
+		{
+			closedFolds:         map[uint]bool{0: true, 1: true},
+			expectedLineIndexes: []uint{0, 6},
+		},
+	}
+
+	for _, visibleTest := range visibleTests {
+		lineIndexes := visibleLineIndexes(7, regions, visibleTest.closedFolds)
+
+		if !reflect.DeepEqual(visibleTest.expectedLineIndexes, lineIndexes) {
+			t.Errorf("Visible lines do not match expected value for closed folds %v. Expected: %v, Actual: %v",
+				visibleTest.closedFolds, visibleTest.expectedLineIndexes, lineIndexes)
+		}
+	}
+}
+
+func TestInnermostFoldContaining(t *testing.T) {
+	regions := detectFolds([]string{"a {", "b {", "c", "}", "}"}, false)
+	expectedRegion := foldRegion{startLine: 1, endLine: 3}
+
+	if region, found := innermostFoldContaining(regions, 2); !found || region != expectedRegion {
+		t.Errorf("Fold region does not match expected value. Expected: %v, Actual: %v", expectedRegion, region)
+	}
+}
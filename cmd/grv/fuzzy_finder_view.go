@@ -0,0 +1,370 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"unicode"
+
+	log "github.com/Sirupsen/logrus"
+	slice "github.com/bradfitz/slice"
+)
+
+const (
+	ffRecentCommitsMax    = 500
+	ffScoreMatch          = 16
+	ffBonusConsecutive    = 8
+	ffBonusBoundary       = 8
+	ffBonusStart          = 10
+	ffPenaltyGapStart     = 3
+	ffPenaltyGapExtension = 1
+)
+
+type fuzzyCandidateType int
+
+const (
+	fctBranch fuzzyCandidateType = iota
+	fctRemoteBranch
+	fctTag
+	fctCommit
+	fctFile
+)
+
+var fuzzyCandidateTypeNames = map[fuzzyCandidateType]string{
+	fctBranch:       "branch",
+	fctRemoteBranch: "remote",
+	fctTag:          "tag",
+	fctCommit:       "commit",
+	fctFile:         "file",
+}
+
+// SelectedCommitProvider provides the ref and commit currently selected
+type SelectedCommitProvider interface {
+	SelectedCommit() (refName string, ref *Oid, commit *Commit)
+}
+
+// fuzzyCandidate is an item which can be found using the fuzzy finder
+type fuzzyCandidate struct {
+	candidateType fuzzyCandidateType
+	text          string
+	target        ViewTarget
+}
+
+type fuzzyMatch struct {
+	candidate *fuzzyCandidate
+	index     int
+	score     int
+}
+
+// FuzzyFinderView displays refs, commits and files ranked by how well they match the entered pattern
+type FuzzyFinderView struct {
+	repoData               RepoData
+	channels               *Channels
+	selectedCommitProvider SelectedCommitProvider
+	candidates             []*fuzzyCandidate
+	loading                bool
+	lock                   sync.Mutex
+}
+
+// NewFuzzyFinderView creates a new instance
+func NewFuzzyFinderView(repoData RepoData, channels *Channels, selectedCommitProvider SelectedCommitProvider) *FuzzyFinderView {
+	return &FuzzyFinderView{
+		repoData:               repoData,
+		channels:               channels,
+		selectedCommitProvider: selectedCommitProvider,
+	}
+}
+
+// Initialise does nothing
+func (fuzzyFinderView *FuzzyFinderView) Initialise() (err error) {
+	return
+}
+
+// LoadCandidates asynchronously gathers the refs, recent commits and files that can be found
+// Recent commits and files are taken from the selected ref and commit respectively
+func (fuzzyFinderView *FuzzyFinderView) LoadCandidates() {
+	fuzzyFinderView.lock.Lock()
+	fuzzyFinderView.candidates = nil
+	fuzzyFinderView.loading = true
+	fuzzyFinderView.lock.Unlock()
+
+	go func() {
+		candidates := fuzzyFinderView.generateCandidates()
+
+		fuzzyFinderView.lock.Lock()
+		fuzzyFinderView.candidates = candidates
+		fuzzyFinderView.loading = false
+		fuzzyFinderView.lock.Unlock()
+
+		fuzzyFinderView.channels.UpdateDisplay()
+	}()
+}
+
+func (fuzzyFinderView *FuzzyFinderView) generateCandidates() (candidates []*fuzzyCandidate) {
+	repoData := fuzzyFinderView.repoData
+	refName, ref, selectedCommit := fuzzyFinderView.selectedCommitProvider.SelectedCommit()
+
+	localBranches, remoteBranches, _ := repoData.Branches()
+
+	for _, branches := range [][]*Branch{localBranches, remoteBranches} {
+		for _, branch := range branches {
+			candidateType := fctBranch
+			if branch.isRemote {
+				candidateType = fctRemoteBranch
+			}
+
+			candidates = append(candidates, &fuzzyCandidate{
+				candidateType: candidateType,
+				text:          branch.name,
+				target:        ViewTarget{viewID: ViewCommit, refName: branch.name, oid: branch.oid},
+			})
+		}
+	}
+
+	tags, _ := repoData.LocalTags()
+
+	for _, tag := range tags {
+		candidates = append(candidates, &fuzzyCandidate{
+			candidateType: fctTag,
+			text:          tag.name,
+			target:        ViewTarget{viewID: ViewCommit, refName: tag.name, oid: tag.oid},
+		})
+	}
+
+	if ref == nil {
+		return
+	}
+
+	if commitCh, err := repoData.Commits(ref, 0, ffRecentCommitsMax); err != nil {
+		log.Errorf("Unable to load recent commits for fuzzy finder: %v", err)
+	} else {
+		for commit := range commitCh {
+			candidates = append(candidates, &fuzzyCandidate{
+				candidateType: fctCommit,
+				text:          fmt.Sprintf("%v %v", repoData.ShortID(commit.oid), commit.commit.Summary()),
+				target:        ViewTarget{viewID: ViewCommit, refName: refName, oid: ref, commit: commit},
+			})
+		}
+	}
+
+	if selectedCommit == nil {
+		return
+	}
+
+	paths, err := repoData.TreePaths(selectedCommit)
+	if err != nil {
+		log.Errorf("Unable to load files for fuzzy finder: %v", err)
+		return
+	}
+
+	for _, path := range paths {
+		candidates = append(candidates, &fuzzyCandidate{
+			candidateType: fctFile,
+			text:          path,
+			target:        ViewTarget{viewID: ViewCommit, refName: refName, oid: ref, path: path},
+		})
+	}
+
+	return
+}
+
+// BestMatch returns the target of the highest ranked candidate matching the pattern
+func (fuzzyFinderView *FuzzyFinderView) BestMatch(pattern string) (target ViewTarget, found bool) {
+	fuzzyFinderView.lock.Lock()
+	defer fuzzyFinderView.lock.Unlock()
+
+	if matches := rankFuzzyCandidates(pattern, fuzzyFinderView.candidates); len(matches) > 0 {
+		return matches[0].candidate.target, true
+	}
+
+	return
+}
+
+// Render ranks the candidates against the pattern and draws the matches to the provided window
+func (fuzzyFinderView *FuzzyFinderView) Render(win RenderWindow, pattern string) (err error) {
+	fuzzyFinderView.lock.Lock()
+	defer fuzzyFinderView.lock.Unlock()
+
+	matches := rankFuzzyCandidates(pattern, fuzzyFinderView.candidates)
+	matchNum := uint(len(matches))
+
+	for rowIndex := uint(1); rowIndex < win.Rows()-1 && rowIndex-1 < matchNum; rowIndex++ {
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(rowIndex, 1); err != nil {
+			return
+		}
+
+		candidate := matches[rowIndex-1].candidate
+		lineBuilder.AppendWithStyle(CmpFuzzyfinderviewType, " %-6v ", fuzzyCandidateTypeNames[candidate.candidateType]).
+			Append("%v", candidate.text)
+	}
+
+	if matchNum > 0 {
+		if err = win.SetSelectedRow(1, true); err != nil {
+			return
+		}
+	}
+
+	win.DrawBorder()
+
+	if err = win.SetTitle(CmpFuzzyfinderviewTitle, "Find"); err != nil {
+		return
+	}
+
+	if fuzzyFinderView.loading {
+		err = win.SetFooter(CmpFuzzyfinderviewFooter, "Loading...")
+	} else {
+		err = win.SetFooter(CmpFuzzyfinderviewFooter, "%v of %v", matchNum, len(fuzzyFinderView.candidates))
+	}
+
+	return
+}
+
+// rankFuzzyCandidates returns the candidates matching the pattern ordered by descending score
+// Shorter candidates are ranked first when scores are equal, followed by the order of the candidates
+func rankFuzzyCandidates(pattern string, candidates []*fuzzyCandidate) (matches []*fuzzyMatch) {
+	for index, candidate := range candidates {
+		if score, matched := fuzzyMatchScore(pattern, candidate.text); matched {
+			matches = append(matches, &fuzzyMatch{
+				candidate: candidate,
+				index:     index,
+				score:     score,
+			})
+		}
+	}
+
+	slice.Sort(matches, func(i, j int) bool {
+		if matches[i].score != matches[j].score {
+			return matches[i].score > matches[j].score
+		} else if len(matches[i].candidate.text) != len(matches[j].candidate.text) {
+			return len(matches[i].candidate.text) < len(matches[j].candidate.text)
+		}
+
+		return matches[i].index < matches[j].index
+	})
+
+	return
+}
+
+// fuzzyMatchScore determines whether the characters of the pattern appear in order in the candidate
+// and scores the match. Matching is case insensitive. Consecutive matches and matches at the start of
+// the candidate or of a word are rewarded, while gaps between matched characters are penalised
+func fuzzyMatchScore(pattern, candidate string) (score int, matched bool) {
+	patternRunes := toLowerRunes(pattern)
+	candidateRunes := toLowerRunes(candidate)
+
+	if len(patternRunes) == 0 {
+		return 0, true
+	}
+
+	// Find the end of the first occurrence of the pattern, then search backwards
+	// from there to find the shortest window containing the pattern
+	patternIndex := 0
+	end := -1
+
+	for candidateIndex, char := range candidateRunes {
+		if char == patternRunes[patternIndex] {
+			patternIndex++
+
+			if patternIndex == len(patternRunes) {
+				end = candidateIndex
+				break
+			}
+		}
+	}
+
+	if end == -1 {
+		return 0, false
+	}
+
+	start := end
+	patternIndex = len(patternRunes) - 1
+
+	for candidateIndex := end; candidateIndex >= 0; candidateIndex-- {
+		if candidateRunes[candidateIndex] == patternRunes[patternIndex] {
+			patternIndex--
+
+			if patternIndex < 0 {
+				start = candidateIndex
+				break
+			}
+		}
+	}
+
+	patternIndex = 0
+	previousMatch := -1
+	inGap := false
+
+	for candidateIndex := start; candidateIndex <= end; candidateIndex++ {
+		if patternIndex < len(patternRunes) && candidateRunes[candidateIndex] == patternRunes[patternIndex] {
+			score += ffScoreMatch
+
+			if patternIndex > 0 && previousMatch == candidateIndex-1 {
+				score += ffBonusConsecutive
+			}
+
+			if candidateIndex == 0 {
+				score += ffBonusStart
+			} else if isFuzzyWordBoundary(candidateRunes[candidateIndex-1]) {
+				score += ffBonusBoundary
+			}
+
+			previousMatch = candidateIndex
+			patternIndex++
+			inGap = false
+		} else if inGap {
+			score -= ffPenaltyGapExtension
+		} else {
+			score -= ffPenaltyGapStart
+			inGap = true
+		}
+	}
+
+	return score, true
+}
+
+func toLowerRunes(str string) (runes []rune) {
+	for _, char := range str {
+		runes = append(runes, unicode.ToLower(char))
+	}
+
+	return
+}
+
+func isFuzzyWordBoundary(char rune) bool {
+	switch char {
+	case '/', '_', '-', '.', ' ':
+		return true
+	}
+
+	return false
+}
+
+// HandleKeyPress does nothing
+func (fuzzyFinderView *FuzzyFinderView) HandleKeyPress(keystring string) (err error) {
+	return
+}
+
+// HandleAction does nothing
+func (fuzzyFinderView *FuzzyFinderView) HandleAction(Action) (err error) {
+	return
+}
+
+// OnActiveChange does nothing
+func (fuzzyFinderView *FuzzyFinderView) OnActiveChange(bool) {
+
+}
+
+// ViewID returns the view ID of the fuzzy finder view
+func (fuzzyFinderView *FuzzyFinderView) ViewID() ViewID {
+	return ViewFuzzyFinder
+}
+
+// RenderStatusBar does nothing
+func (fuzzyFinderView *FuzzyFinderView) RenderStatusBar(*LineBuilder) (err error) {
+	return
+}
+
+// RenderHelpBar does nothing
+func (fuzzyFinderView *FuzzyFinderView) RenderHelpBar(*LineBuilder) (err error) {
+	return
+}
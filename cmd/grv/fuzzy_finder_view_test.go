@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestFuzzyMatchScoreDeterminesWhetherPatternMatches(t *testing.T) {
+	var matchTests = []struct {
+		pattern         string
+		candidate       string
+		expectedMatched bool
+	}{
+		{pattern: "", candidate: "master", expectedMatched: true},
+		{pattern: "mst", candidate: "master", expectedMatched: true},
+		{pattern: "MST", candidate: "master", expectedMatched: true},
+		{pattern: "cvg", candidate: "cmd/grv/commit_view.go", expectedMatched: true},
+		{pattern: "tsm", candidate: "master", expectedMatched: false},
+		{pattern: "masters", candidate: "master", expectedMatched: false},
+	}
+
+	for _, matchTest := range matchTests {
+		if _, matched := fuzzyMatchScore(matchTest.pattern, matchTest.candidate); matched != matchTest.expectedMatched {
+			t.Errorf("Match result differs from expected value for pattern %v and candidate %v. Expected: %v, Actual: %v",
+				matchTest.pattern, matchTest.candidate, matchTest.expectedMatched, matched)
+		}
+	}
+}
+
+func TestFuzzyMatchScoreRanksBetterMatchesHigher(t *testing.T) {
+	var scoreTests = []struct {
+		pattern         string
+		betterCandidate string
+		worseCandidate  string
+	}{
+		{pattern: "view", betterCandidate: "view.go", worseCandidate: "vi_e_w.go"},
+		{pattern: "cv", betterCandidate: "commit_view.go", worseCandidate: "recovery.go"},
+		{pattern: "main", betterCandidate: "main", worseCandidate: "origin/main"},
+		{pattern: "fix", betterCandidate: "abc1234 Fix crash", worseCandidate: "abc1234 Add prefix"},
+	}
+
+	for _, scoreTest := range scoreTests {
+		betterScore, _ := fuzzyMatchScore(scoreTest.pattern, scoreTest.betterCandidate)
+		worseScore, _ := fuzzyMatchScore(scoreTest.pattern, scoreTest.worseCandidate)
+
+		if betterScore <= worseScore {
+			t.Errorf("Expected %v (score: %v) to score higher than %v (score: %v) for pattern %v",
+				scoreTest.betterCandidate, betterScore, scoreTest.worseCandidate, worseScore, scoreTest.pattern)
+		}
+	}
+}
+
+func TestRankFuzzyCandidatesOrdersMatchesByScore(t *testing.T) {
+	candidates := []*fuzzyCandidate{
+		{candidateType: fctFile, text: "doc/documentation.md"},
+		{candidateType: fctRemoteBranch, text: "origin/master"},
+		{candidateType: fctTag, text: "v0.1"},
+		{candidateType: fctBranch, text: "master"},
+	}
+
+	matches := rankFuzzyCandidates("mast", candidates)
+	expectedTexts := []string{"master", "origin/master"}
+
+	if len(matches) != len(expectedTexts) {
+		t.Fatalf("Match count differs from expected value. Expected: %v, Actual: %v", len(expectedTexts), len(matches))
+	}
+
+	for matchIndex, match := range matches {
+		if match.candidate.text != expectedTexts[matchIndex] {
+			t.Errorf("Match at index %v differs from expected value. Expected: %v, Actual: %v",
+				matchIndex, expectedTexts[matchIndex], match.candidate.text)
+		}
+	}
+}
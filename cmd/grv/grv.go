@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"os/signal"
+	"runtime/debug"
+	"strings"
 	"sync"
 	"syscall"
 	"time"
@@ -47,13 +49,20 @@ type Channels struct {
 
 // GRV is the top level structure containing all state in the program
 type GRV struct {
-	repoData    *RepositoryData
-	view        *View
-	ui          UI
-	channels    gRVChannels
-	config      *Configuration
-	inputBuffer *InputBuffer
-	input       *InputKeyMapper
+	repoData        *RepositoryData
+	view            *View
+	ui              UI
+	channels        gRVChannels
+	config          *Configuration
+	inputBuffer     *InputBuffer
+	input           *InputKeyMapper
+	restartPath     string
+	restartLock     sync.Mutex
+	setupWizard     *SetupWizard
+	signalCh        chan os.Signal
+	crashReporter   *CrashReporter
+	crashOnce       sync.Once
+	repoPreferences *RepoPreferences
 }
 
 // UpdateDisplay sends a request to update the display
@@ -128,9 +137,9 @@ func NewGRV() *GRV {
 	channels := grvChannels.Channels()
 
 	repoDataLoader := NewRepoDataLoader(channels)
-	repoData := NewRepositoryData(repoDataLoader, channels)
 	keyBindings := NewKeyBindingManager()
 	config := NewConfiguration(keyBindings, channels)
+	repoData := NewRepositoryData(repoDataLoader, channels, config)
 	ui := NewNCursesDisplay(config)
 
 	return &GRV{
@@ -141,14 +150,20 @@ func NewGRV() *GRV {
 		config:      config,
 		inputBuffer: NewInputBuffer(keyBindings),
 		input:       NewInputKeyMapper(ui),
+		setupWizard: NewSetupWizard(config, channels),
+		signalCh:    make(chan os.Signal, 1),
+
+		crashReporter: NewCrashReporter(),
 	}
 }
 
 // Initialise sets up all the components of GRV
-func (grv *GRV) Initialise(repoPath string) (err error) {
+// If readOnly is true read-only mode is enabled after the config files have been loaded
+// A log level other than NONE overrides any log level set in the config files
+func (grv *GRV) Initialise(repoPath, workTreePath string, readOnly bool, logLevel string) (err error) {
 	log.Info("Initialising GRV")
 
-	if err = grv.repoData.Initialise(repoPath); err != nil {
+	if err = grv.repoData.Initialise(repoPath, workTreePath); err != nil {
 		return
 	}
 
@@ -166,28 +181,196 @@ func (grv *GRV) Initialise(repoPath string) (err error) {
 		}
 	}
 
+	if readOnly {
+		if configErrors := grv.config.Evaluate(fmt.Sprintf("set %v true", CfReadOnly)); configErrors != nil {
+			return configErrors[0]
+		}
+	}
+
+	if logLevel != MnLogLevelDefault {
+		if configErrors := grv.config.Evaluate(fmt.Sprintf("set %v %v", CfLogLevel, logLevel)); configErrors != nil {
+			return configErrors[0]
+		}
+	}
+
 	channels := grv.channels.Channels()
 	InitReadLine(channels, grv.ui, grv.config)
 
+	if grv.config.GrvrcMissing() {
+		grv.setupWizard.Start()
+		grv.restoreRepoPreferences(true)
+	} else if session, found := TakeSavedSession(grv.config.ConfigDir()); found && session.repoPath == grv.repoData.Path() {
+		grv.restoreRepoPreferences(false)
+		grv.offerSessionRestore(session)
+	} else {
+		grv.restoreRepoPreferences(true)
+	}
+
+	return
+}
+
+// restoreRepoPreferences applies the preferences remembered for the repository
+// The remembered branch is only selected if restoreBranch is true
+func (grv *GRV) restoreRepoPreferences(restoreBranch bool) {
+	if !grv.config.GetBool(CfRepoPreferences) {
+		return
+	}
+
+	dir, ok := repoPreferencesDir()
+	if !ok {
+		log.Info("Unable to determine repository preferences directory")
+		return
+	}
+
+	preferences, err := LoadRepoPreferences(dir, grv.repoData.Path())
+	if err != nil {
+		log.Errorf("Unable to load repository preferences: %v", err)
+		return
+	}
+
+	grv.repoPreferences = preferences
+
+	grv.channels.Channels().ReportErrors(grv.config.Evaluate(preferences.ConfigCommands()))
+
+	grv.view.SetExpandedRefLists(preferences.expandedRefLists)
+	grv.view.SetPinnedRefs(preferences.pinnedRefs)
+
+	if !restoreBranch || preferences.branch == "" {
+		return
+	}
+
+	if _, branch := grv.repoData.Head(); branch != nil && branch.name == preferences.branch {
+		return
+	}
+
+	if _, err := grv.repoData.RevisionsOid(strings.Fields(preferences.branch)); err != nil {
+		log.Infof("Unable to restore branch %v: %v", preferences.branch, err)
+		return
+	}
+
+	log.Infof("Restoring branch %v", preferences.branch)
+
+	grv.channels.Channels().DoAction(Action{
+		ActionType: ActionRestoreSession,
+		Args: []interface{}{SessionState{
+			repoPath: grv.repoData.Path(),
+			refName:  preferences.branch,
+		}},
+	})
+}
+
+// SaveRepoPreferences remembers the selected branch, the state of the ref lists, the pinned refs and
+// the values of the preference variables changed while GRV was running
+func (grv *GRV) SaveRepoPreferences() {
+	preferences := grv.repoPreferences
+	if preferences == nil || !grv.config.GetBool(CfRepoPreferences) {
+		return
+	}
+
+	dir, ok := repoPreferencesDir()
+	if !ok {
+		return
+	}
+
+	preferences.branch = ""
+	if refName, _, _ := grv.view.SelectedCommit(); refName != "" {
+		if _, err := grv.repoData.RevisionsOid(strings.Fields(refName)); err == nil {
+			preferences.branch = refName
+		}
+	}
+
+	preferences.expandedRefLists = grv.view.ExpandedRefLists()
+	preferences.pinnedRefs = grv.view.PinnedRefs()
+	preferences.RecordVariables(grv.config.Variables())
+
+	if err := preferences.Save(dir); err != nil {
+		log.Errorf("Unable to save repository preferences: %v", err)
+	}
+}
+
+// sessionState returns the ref and commit currently displayed
+func (grv *GRV) sessionState() (session SessionState) {
+	session.repoPath = grv.repoData.Path()
+
+	refName, _, commit := grv.view.SelectedCommit()
+	session.refName = refName
+
+	if commit != nil {
+		session.commitID = commit.oid.String()
+	}
+
 	return
 }
 
+// offerSessionRestore asks whether the session saved when GRV last crashed should be restored
+func (grv *GRV) offerSessionRestore(session SessionState) {
+	log.Infof("Offering to restore session %v", session)
+
+	grv.channels.Channels().DoAction(Action{
+		ActionType: ActionShowDialog,
+		Args: []interface{}{DialogRequest{
+			dialogType: DtYesNo,
+			title:      "Restore Session",
+			message: fmt.Sprintf("GRV exited unexpectedly. A crash report was written to %v. Restore the previous session (%v)?",
+				session.reportPath, session.refName),
+			action: Action{ActionType: ActionRestoreSession, Args: []interface{}{session}},
+		}},
+	})
+}
+
+// recoverFromPanic restores the terminal and writes a crash report if the calling goroutine is panicking
+// GRV then exits as its state can no longer be relied upon
+func (grv *GRV) recoverFromPanic() {
+	panicValue := recover()
+	if panicValue == nil {
+		return
+	}
+
+	stack := debug.Stack()
+
+	grv.crashOnce.Do(func() {
+		log.Errorf("Recovered from panic: %v\n%s", panicValue, stack)
+
+		grv.ui.Free()
+
+		fmt.Fprintf(os.Stderr, "GRV crashed: %v\n", panicValue)
+
+		if reportPath, err := grv.crashReporter.WriteReport(grv.config.ConfigDir(), panicValue, stack); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to write crash report: %v\n", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "A crash report has been written to %v\n", reportPath)
+		}
+
+		os.Exit(1)
+	})
+}
+
 // Free closes and frees any resources used by GRV
 func (grv *GRV) Free() {
 	log.Info("Freeing GRV")
 
 	FreeReadLine()
 	grv.ui.Free()
+	grv.view.Free()
 	grv.repoData.Free()
 }
 
 // Suspend prepares GRV to be suspended and sends a SIGTSTP
 // to every process in the process group
 func (grv *GRV) Suspend() {
+	grv.suspend(0)
+}
+
+// suspend restores the terminal and sends a SIGTSTP to the provided pid
+// The default action of SIGTSTP is restored first so that GRV is stopped
+// by the signal. It is caught again once GRV is resumed
+func (grv *GRV) suspend(pid int) {
 	log.Info("Suspending GRV")
 
 	grv.ui.Suspend()
-	if err := syscall.Kill(0, syscall.SIGTSTP); err != nil {
+	signal.Reset(syscall.SIGTSTP)
+
+	if err := syscall.Kill(pid, syscall.SIGTSTP); err != nil {
 		log.Errorf("Error when attempting to suspend GRV: %v", err)
 	}
 }
@@ -196,6 +379,8 @@ func (grv *GRV) Suspend() {
 func (grv *GRV) Resume() {
 	log.Info("Resuming GRV")
 
+	signal.Notify(grv.signalCh, syscall.SIGTSTP)
+
 	if err := grv.ui.Resume(); err != nil {
 		log.Errorf("Error when attempting to resume GRV: %v", err)
 	}
@@ -203,6 +388,25 @@ func (grv *GRV) Resume() {
 	grv.channels.displayCh <- true
 }
 
+// Restart stops GRV and records the repository it should be restarted with
+func (grv *GRV) Restart(repoPath string) {
+	log.Infof("Restarting GRV with repository %v", repoPath)
+
+	grv.restartLock.Lock()
+	grv.restartPath = repoPath
+	grv.restartLock.Unlock()
+
+	grv.End()
+}
+
+// RestartPath returns the path of the repository GRV should be restarted with, if any
+func (grv *GRV) RestartPath() string {
+	grv.restartLock.Lock()
+	defer grv.restartLock.Unlock()
+
+	return grv.restartPath
+}
+
 // End signals GRV to stop
 func (grv *GRV) End() {
 	log.Info("Stopping GRV")
@@ -238,6 +442,7 @@ func (grv *GRV) Run() {
 
 func (grv *GRV) runInputLoop(waitGroup *sync.WaitGroup, exitCh chan bool, inputKeyCh chan<- string, errorCh chan<- error) {
 	defer waitGroup.Done()
+	defer grv.recoverFromPanic()
 	defer log.Info("Input loop stopping")
 	log.Info("Starting input loop")
 
@@ -267,6 +472,7 @@ func (grv *GRV) runInputLoop(waitGroup *sync.WaitGroup, exitCh chan bool, inputK
 
 func (grv *GRV) runDisplayLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, displayCh <-chan bool, errorCh chan error) {
 	defer waitGroup.Done()
+	defer grv.recoverFromPanic()
 	defer log.Info("Display loop stopping")
 	log.Info("Starting display loop")
 
@@ -337,6 +543,7 @@ func (grv *GRV) runDisplayLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, di
 
 func (grv *GRV) runHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, inputKeyCh <-chan string, actionCh chan Action, errorCh chan<- error) {
 	defer waitGroup.Done()
+	defer grv.recoverFromPanic()
 	defer log.Info("Handler loop stopping")
 	log.Info("Starting handler loop")
 
@@ -360,16 +567,50 @@ func (grv *GRV) runHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bool, in
 				}
 			}
 		case action := <-actionCh:
+			log.WithFields(log.Fields{
+				"action": actionKey(action.ActionType),
+				"args":   len(action.Args),
+			}).Debug("Processing action")
+
+			grv.crashReporter.RecordAction(action)
+
+			if grv.config.GetBool(CfReadOnly) && IsMutatingAction(action.ActionType) {
+				grv.channels.Channels().ReportStatus("%v is disabled in read-only mode", actionKey(action.ActionType))
+				continue
+			}
+
 			switch action.ActionType {
 			case ActionExit:
 				grv.End()
 			case ActionSuspend:
 				grv.Suspend()
+			case ActionRunEditor:
+				if err := grv.runEditor(action); err != nil {
+					errorCh <- err
+				}
+			case ActionRunCommand:
+				if err := grv.runCommand(action); err != nil {
+					errorCh <- err
+				}
+			case ActionShowImage:
+				if err := grv.showImage(action); err != nil {
+					errorCh <- err
+				}
+			case ActionClone:
+				if err := grv.clone(action); err != nil {
+					errorCh <- err
+				}
+			case ActionSetupWizard:
+				if err := grv.setupWizard.HandleAction(action); err != nil {
+					errorCh <- err
+				}
 			default:
 				if err := grv.view.HandleAction(action); err != nil {
 					errorCh <- err
 				}
 			}
+
+			grv.crashReporter.RecordSession(grv.sessionState())
 		case _, ok := <-exitCh:
 			if !ok {
 				return
@@ -383,19 +624,20 @@ func (grv *GRV) runSignalHandlerLoop(waitGroup *sync.WaitGroup, exitCh <-chan bo
 	defer log.Info("Signal handler loop stopping")
 	log.Info("Signal handler loop starting")
 
-	signalCh := make(chan os.Signal, 1)
-
-	signal.Notify(signalCh, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGHUP, syscall.SIGWINCH, syscall.SIGCONT)
+	signal.Notify(grv.signalCh, syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGHUP, syscall.SIGWINCH, syscall.SIGCONT, syscall.SIGTSTP)
 
 	for {
 		select {
-		case signal := <-signalCh:
+		case signal := <-grv.signalCh:
 			log.Debugf("Caught signal: %v", signal)
 
 			switch signal {
 			case syscall.SIGTERM, syscall.SIGINT, syscall.SIGQUIT, syscall.SIGHUP:
 				grv.End()
 				return
+			case syscall.SIGTSTP:
+				// The signal has already been delivered to the rest of the process group
+				grv.suspend(os.Getpid())
 			case syscall.SIGCONT:
 				grv.Resume()
 			case syscall.SIGWINCH:
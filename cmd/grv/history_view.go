@@ -1,13 +1,18 @@
 package main
 
 import (
+	"fmt"
 	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 )
 
 const (
 	hvBranchViewWidth = 35
+	hvCommitViewPos   = 1
+	hvDiffViewPos     = 2
+	hvQuickfixViewPos = 3
 )
 
 type viewOrientation int
@@ -15,21 +20,25 @@ type viewOrientation int
 const (
 	voDefault viewOrientation = iota
 	voColumn
-	voCount
 )
 
 // HistoryView manages the history view and it's child views
 type HistoryView struct {
+	repoData             RepoData
 	channels             *Channels
+	config               Config
 	refView              WindowView
 	commitView           WindowView
 	diffView             WindowView
+	quickfixView         *QuickfixView
 	views                []WindowView
 	viewWins             map[WindowView]*Window
 	activeViewPos        uint
 	active               bool
 	fullScreenActiveView bool
+	quickfixOpen         bool
 	orientation          viewOrientation
+	worktree             string
 	lock                 sync.Mutex
 }
 
@@ -40,32 +49,62 @@ type viewLayout struct {
 }
 
 // NewHistoryView creates a new instance of the history view
-func NewHistoryView(repoData RepoData, channels *Channels, config Config) *HistoryView {
-	refView := NewRefView(repoData, channels)
-	commitView := NewCommitView(repoData, channels)
-	diffView := NewDiffView(repoData, channels)
+func NewHistoryView(repoData RepoData, channels *Channels, config Config, canceller *OperationCanceller) *HistoryView {
+	refView := NewRefView(repoData, channels, config, canceller)
+	commitView := NewCommitView(repoData, channels, config, canceller)
+	diffView := NewDiffView(repoData, channels, config, canceller)
+	quickfixView := NewQuickfixView(channels, config)
 
 	refViewWin := NewWindow("refView", config)
 	commitViewWin := NewWindow("commitView", config)
 	diffViewWin := NewWindow("diffView", config)
+	quickfixViewWin := NewWindow("quickfixView", config)
 
 	refView.RegisterRefListener(commitView)
+	refView.RegisterTagListener(diffView)
 	commitView.RegisterCommitListner(diffView)
-
-	return &HistoryView{
-		channels:    channels,
-		refView:     refView,
-		commitView:  commitView,
-		diffView:    diffView,
-		views:       []WindowView{refView, commitView, diffView},
-		orientation: voDefault,
+	diffView.RegisterDirectoryListener(commitView)
+
+	historyView := &HistoryView{
+		repoData:     repoData,
+		channels:     channels,
+		config:       config,
+		refView:      refView,
+		commitView:   commitView,
+		diffView:     diffView,
+		quickfixView: quickfixView,
+		views:        []WindowView{refView, commitView, diffView},
+		orientation:  layoutOrientation(config.GetString(CfLayout)),
 		viewWins: map[WindowView]*Window{
-			refView:    refViewWin,
-			commitView: commitViewWin,
-			diffView:   diffViewWin,
+			refView:      refViewWin,
+			commitView:   commitViewWin,
+			diffView:     diffViewWin,
+			quickfixView: quickfixViewWin,
 		},
 		activeViewPos: 1,
 	}
+
+	config.AddOnChangeListener(CfLayout, historyView)
+
+	return historyView
+}
+
+// layoutOrientation returns the orientation of the child views for the provided layout
+func layoutOrientation(layout string) viewOrientation {
+	if layout == LayoutColumns {
+		return voColumn
+	}
+
+	return voDefault
+}
+
+// onConfigVariableChange updates the layout of the child views
+func (historyView *HistoryView) onConfigVariableChange(configVariable ConfigVariable) {
+	historyView.lock.Lock()
+	defer historyView.lock.Unlock()
+
+	historyView.orientation = layoutOrientation(historyView.config.GetString(CfLayout))
+	historyView.channels.UpdateDisplay()
 }
 
 // Initialise sets up the history view and calls initialise on its child views
@@ -79,6 +118,24 @@ func (historyView *HistoryView) Initialise() (err error) {
 	return
 }
 
+// SetWorktree sets the name of the worktree the history view displays
+func (historyView *HistoryView) SetWorktree(worktree string) {
+	historyView.lock.Lock()
+	defer historyView.lock.Unlock()
+
+	historyView.worktree = worktree
+}
+
+// StatuslineValues adds placeholder values describing the repository
+// and worktree displayed by the history view
+func (historyView *HistoryView) StatuslineValues(values StatuslineValues) {
+	repositoryStatuslineValues(historyView.repoData, values)
+
+	historyView.lock.Lock()
+	values["worktree"] = historyView.worktree
+	historyView.lock.Unlock()
+}
+
 // Render generates the history view and returns windows (one for each child view) representing the view as a whole
 func (historyView *HistoryView) Render(viewDimension ViewDimension) (wins []*Window, err error) {
 	log.Debug("Rendering HistoryView")
@@ -97,10 +154,14 @@ func (historyView *HistoryView) Render(viewDimension ViewDimension) (wins []*Win
 		win.Clear()
 		win.SetPosition(viewLayout.startRow, viewLayout.startCol)
 
+		renderStart := time.Now()
+
 		if err = view.Render(win); err != nil {
 			return
 		}
 
+		renderTimings.Record(win.ID(), renderStart)
+
 		wins = append(wins, win)
 	}
 
@@ -108,6 +169,16 @@ func (historyView *HistoryView) Render(viewDimension ViewDimension) (wins []*Win
 }
 
 func (historyView *HistoryView) determineViewDimensions(viewDimension ViewDimension) map[WindowView]viewLayout {
+	var quickfixViewLayout viewLayout
+
+	if historyView.quickfixOpen {
+		quickfixViewLayout.viewDimension = viewDimension
+		quickfixViewLayout.viewDimension.rows = Min(qvMaxRows, viewDimension.rows/3)
+		quickfixViewLayout.startRow = viewDimension.rows - quickfixViewLayout.viewDimension.rows
+
+		viewDimension.rows -= quickfixViewLayout.viewDimension.rows
+	}
+
 	refViewLayout := viewLayout{viewDimension: viewDimension}
 	commitViewLayout := viewLayout{viewDimension: viewDimension}
 	diffViewLayout := viewLayout{viewDimension: viewDimension}
@@ -137,11 +208,18 @@ func (historyView *HistoryView) determineViewDimensions(viewDimension ViewDimens
 	log.Debugf("CommitView layout: %v", commitViewLayout)
 	log.Debugf("DiffView layout: %v", diffViewLayout)
 
-	return map[WindowView]viewLayout{
+	viewLayouts := map[WindowView]viewLayout{
 		historyView.refView:    refViewLayout,
 		historyView.commitView: commitViewLayout,
 		historyView.diffView:   diffViewLayout,
 	}
+
+	if historyView.quickfixOpen {
+		log.Debugf("QuickfixView layout: %v", quickfixViewLayout)
+		viewLayouts[historyView.quickfixView] = quickfixViewLayout
+	}
+
+	return viewLayouts
 }
 
 func (historyView *HistoryView) renderActiveViewFullScreen(viewDimension ViewDimension) (wins []*Window, err error) {
@@ -152,10 +230,14 @@ func (historyView *HistoryView) renderActiveViewFullScreen(viewDimension ViewDim
 	win.Clear()
 	win.SetPosition(0, 0)
 
+	renderStart := time.Now()
+
 	if err = view.Render(win); err != nil {
 		return
 	}
 
+	renderTimings.Record(win.ID(), renderStart)
+
 	wins = append(wins, win)
 
 	return
@@ -218,19 +300,167 @@ func (historyView *HistoryView) HandleAction(action Action) (err error) {
 		historyView.fullScreenActiveView = !historyView.fullScreenActiveView
 		historyView.channels.UpdateDisplay()
 		return
-	case ActionToggleViewLayout:
+	case ActionShowView:
+		return historyView.showView(action)
+	case ActionCreateCommit, ActionReloadRefs:
+		return historyView.refView.HandleAction(action)
+	case ActionGotoCommit:
+		if err = historyView.commitView.HandleAction(action); err != nil {
+			return
+		}
+
 		historyView.lock.Lock()
-		defer historyView.lock.Unlock()
+		historyView.activeViewPos = hvCommitViewPos
+		historyView.lock.Unlock()
+		historyView.OnActiveChange(true)
+		historyView.channels.UpdateDisplay()
+		return
+	case ActionDiffRevisions, ActionShowLineHistory, ActionJumpToQuickfixEntry:
+		if err = historyView.diffView.HandleAction(action); err != nil {
+			return
+		}
 
-		historyView.orientation = (historyView.orientation + 1) % voCount
+		historyView.lock.Lock()
+		historyView.activeViewPos = hvDiffViewPos
+		historyView.lock.Unlock()
+		historyView.OnActiveChange(true)
 		historyView.channels.UpdateDisplay()
 		return
+	case ActionShowQuickfix:
+		return historyView.showQuickfix(action)
+	case ActionOpenQuickfix:
+		return historyView.openQuickfix()
+	case ActionCloseQuickfix:
+		historyView.closeQuickfix()
+		return
+	case ActionQuickfixNext, ActionQuickfixPrev:
+		return historyView.moveQuickfixEntry(action)
 	}
 
 	activeChildView := historyView.ActiveView()
 	return activeChildView.HandleAction(action)
 }
 
+// showView makes the child view identified by the view target active
+// If the target specifies a commit then its commits are loaded in the commit view
+func (historyView *HistoryView) showView(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected view target argument")
+	}
+
+	viewTarget, ok := action.Args[0].(ViewTarget)
+	if !ok {
+		return fmt.Errorf("Expected view target argument to have type ViewTarget")
+	}
+
+	if viewTarget.oid != nil {
+		if refListener, ok := historyView.commitView.(RefListener); ok {
+			if err = refListener.OnRefSelect(viewTarget.refName, viewTarget.oid); err != nil {
+				return
+			}
+		}
+	}
+
+	historyView.lock.Lock()
+	for viewPos, childView := range historyView.views {
+		if childView.ViewID() == viewTarget.viewID {
+			historyView.activeViewPos = uint(viewPos)
+			historyView.fullScreenActiveView = false
+			break
+		}
+	}
+	historyView.lock.Unlock()
+
+	historyView.OnActiveChange(true)
+
+	if err = historyView.ActiveView().HandleAction(action); err != nil {
+		return
+	}
+
+	historyView.channels.UpdateDisplay()
+
+	return
+}
+
+// showQuickfix displays the provided list in the quickfix view
+func (historyView *HistoryView) showQuickfix(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected QuickfixList argument")
+	}
+
+	list, ok := action.Args[0].(*QuickfixList)
+	if !ok {
+		return fmt.Errorf("Expected argument of type *QuickfixList but found type %T", action.Args[0])
+	}
+
+	historyView.quickfixView.SetList(list)
+
+	return historyView.openQuickfix()
+}
+
+// openQuickfix displays the quickfix view below the other child views and makes it active
+func (historyView *HistoryView) openQuickfix() (err error) {
+	if !historyView.quickfixView.HasList() {
+		return fmt.Errorf("No quickfix list")
+	}
+
+	historyView.lock.Lock()
+	if !historyView.quickfixOpen {
+		historyView.quickfixOpen = true
+		historyView.views = append(historyView.views, historyView.quickfixView)
+	}
+
+	historyView.activeViewPos = hvQuickfixViewPos
+	historyView.fullScreenActiveView = false
+	historyView.lock.Unlock()
+
+	historyView.OnActiveChange(true)
+	historyView.channels.UpdateDisplay()
+
+	return
+}
+
+// closeQuickfix removes the quickfix view. The diff view is made active if the quickfix view was active
+func (historyView *HistoryView) closeQuickfix() {
+	historyView.lock.Lock()
+	if !historyView.quickfixOpen {
+		historyView.lock.Unlock()
+		return
+	}
+
+	historyView.quickfixOpen = false
+	historyView.views = historyView.views[:hvQuickfixViewPos]
+
+	if historyView.activeViewPos == hvQuickfixViewPos {
+		historyView.activeViewPos = hvDiffViewPos
+		historyView.fullScreenActiveView = false
+	}
+
+	historyView.lock.Unlock()
+
+	historyView.quickfixView.OnActiveChange(false)
+	historyView.OnActiveChange(true)
+	historyView.channels.UpdateDisplay()
+}
+
+// moveQuickfixEntry selects the next or previous entry of the quickfix list and jumps to it
+func (historyView *HistoryView) moveQuickfixEntry(action Action) (err error) {
+	delta := int(action.RepeatCount())
+	if action.ActionType == ActionQuickfixPrev {
+		delta = -delta
+	}
+
+	entry, err := historyView.quickfixView.MoveEntry(delta)
+	if err != nil {
+		return
+	}
+
+	return historyView.HandleAction(Action{
+		ActionType: ActionJumpToQuickfixEntry,
+		Args:       []interface{}{entry},
+	})
+}
+
 // OnActiveChange updates whether this view (and it's active child view) are active
 func (historyView *HistoryView) OnActiveChange(active bool) {
 	log.Debugf("History active set to %v", active)
@@ -253,6 +483,47 @@ func (historyView *HistoryView) ViewID() ViewID {
 	return ViewHistory
 }
 
+// SelectedCommit returns the active ref and selected commit of the commit view
+func (historyView *HistoryView) SelectedCommit() (refName string, ref *Oid, commit *Commit) {
+	if selectedCommitProvider, ok := historyView.commitView.(SelectedCommitProvider); ok {
+		return selectedCommitProvider.SelectedCommit()
+	}
+
+	return
+}
+
+// ExpandedRefLists returns whether each list of refs in the ref view is expanded
+func (historyView *HistoryView) ExpandedRefLists() map[string]bool {
+	if refListStateProvider, ok := historyView.refView.(RefListStateProvider); ok {
+		return refListStateProvider.ExpandedRefLists()
+	}
+
+	return nil
+}
+
+// SetExpandedRefLists expands or collapses the named lists of refs in the ref view
+func (historyView *HistoryView) SetExpandedRefLists(expandedRefLists map[string]bool) {
+	if refListStateProvider, ok := historyView.refView.(RefListStateProvider); ok {
+		refListStateProvider.SetExpandedRefLists(expandedRefLists)
+	}
+}
+
+// PinnedRefs returns the names of the refs pinned in the ref view
+func (historyView *HistoryView) PinnedRefs() []string {
+	if refListStateProvider, ok := historyView.refView.(RefListStateProvider); ok {
+		return refListStateProvider.PinnedRefs()
+	}
+
+	return nil
+}
+
+// SetPinnedRefs replaces the refs pinned in the ref view
+func (historyView *HistoryView) SetPinnedRefs(refNames []string) {
+	if refListStateProvider, ok := historyView.refView.(RefListStateProvider); ok {
+		refListStateProvider.SetPinnedRefs(refNames)
+	}
+}
+
 // ActiveView returns the active child view
 func (historyView *HistoryView) ActiveView() AbstractView {
 	historyView.lock.Lock()
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// HookError is returned when a git hook exits with a non-zero status
+type HookError struct {
+	hook   string
+	err    error
+	output string
+}
+
+// Error returns a summary of the hook failure
+func (hookError *HookError) Error() string {
+	return fmt.Sprintf("%v hook failed: %v", hookError.hook, hookError.err)
+}
+
+// Errors returns the hook failure followed by each line of output the hook produced
+func (hookError *HookError) Errors() (errors []error) {
+	errors = append(errors, hookError)
+
+	scanner := bufio.NewScanner(strings.NewReader(hookError.output))
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), " \t\r"); line != "" {
+			errors = append(errors, fmt.Errorf("%v: %v", hookError.hook, line))
+		}
+	}
+
+	return
+}
+
+// hooksDirectory returns the directory hooks are loaded from, honouring core.hooksPath
+func (repoDataLoader *RepoDataLoader) hooksDirectory() string {
+	if config, err := repoDataLoader.repo.Config(); err == nil {
+		defer config.Free()
+
+		if hooksPath, err := config.LookupString("core.hooksPath"); err == nil && hooksPath != "" {
+			if !filepath.IsAbs(hooksPath) {
+				hooksPath = filepath.Join(repoDataLoader.repo.Workdir(), hooksPath)
+			}
+
+			return hooksPath
+		}
+	}
+
+	return filepath.Join(repoDataLoader.repo.Path(), "hooks")
+}
+
+// runHook executes the named hook with the provided arguments if it exists and is executable
+func (repoDataLoader *RepoDataLoader) runHook(hook string, args ...string) error {
+	hookPath := filepath.Join(repoDataLoader.hooksDirectory(), hook)
+
+	fileInfo, err := os.Stat(hookPath)
+	if err != nil || fileInfo.IsDir() || fileInfo.Mode()&0111 == 0 {
+		return nil
+	}
+
+	log.Infof("Running %v hook", hook)
+
+	cmd := exec.Command(hookPath, args...)
+	cmd.Dir = repoDataLoader.repo.Workdir()
+	cmd.Env = append(os.Environ(),
+		"GIT_DIR="+repoDataLoader.repo.Path(),
+		"GIT_INDEX_FILE="+filepath.Join(repoDataLoader.repo.Path(), "index"),
+	)
+
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return &HookError{
+			hook:   hook,
+			err:    err,
+			output: string(output),
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+// The identities of a commit which can be displayed
+const (
+	IdentityAuthor    = "author"
+	IdentityCommitter = "committer"
+)
+
+// The formats an identity can be displayed in
+const (
+	IdentityFormatName  = "name"
+	IdentityFormatEmail = "email"
+	IdentityFormatBoth  = "both"
+)
+
+// commitIdentity returns the signature of the author or committer of the commit
+func commitIdentity(commit *Commit, identity string) *git.Signature {
+	if identity == IdentityCommitter {
+		return commit.commit.Committer()
+	}
+
+	return commit.commit.Author()
+}
+
+// formatIdentity formats the signature as a name, an email address or both
+func formatIdentity(signature *git.Signature, identityFormat string) string {
+	switch identityFormat {
+	case IdentityFormatEmail:
+		return signature.Email
+	case IdentityFormatBoth:
+		return fmt.Sprintf("%v <%v>", signature.Name, signature.Email)
+	default:
+		return signature.Name
+	}
+}
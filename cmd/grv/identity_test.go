@@ -0,0 +1,30 @@
+package main
+
+import (
+	"testing"
+
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+func TestFormatIdentity(t *testing.T) {
+	signature := &git.Signature{
+		Name:  "Jane Doe",
+		Email: "jane@example.com",
+	}
+
+	var identityTests = []struct {
+		identityFormat   string
+		expectedIdentity string
+	}{
+		{identityFormat: IdentityFormatName, expectedIdentity: "Jane Doe"},
+		{identityFormat: IdentityFormatEmail, expectedIdentity: "jane@example.com"},
+		{identityFormat: IdentityFormatBoth, expectedIdentity: "Jane Doe <jane@example.com>"},
+	}
+
+	for _, identityTest := range identityTests {
+		if identity := formatIdentity(signature, identityTest.identityFormat); identity != identityTest.expectedIdentity {
+			t.Errorf("Identity does not match expected value for format %v. Expected: %v, Actual: %v",
+				identityTest.identityFormat, identityTest.expectedIdentity, identity)
+		}
+	}
+}
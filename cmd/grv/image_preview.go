@@ -0,0 +1,371 @@
+package main
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	// Register the image formats which can be previewed
+	_ "image/gif"
+	_ "image/jpeg"
+	"image/png"
+	"os/exec"
+	"path"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// GraphicsProtocol is a protocol a terminal supports for displaying images
+type GraphicsProtocol int
+
+// The set of graphics protocols grv can display images with
+const (
+	GpNone GraphicsProtocol = iota
+	GpKitty
+	GpSixel
+)
+
+const (
+	ipKittyChunkSize   = 4096
+	ipSixelMaxWidth    = 800
+	ipSixelLevels      = 6
+	ipSixelBandHeight  = 6
+	ipBytesPerKilobyte = 1024
+)
+
+var graphicsProtocolNames = map[string]GraphicsProtocol{
+	"none":  GpNone,
+	"kitty": GpKitty,
+	"sixel": GpSixel,
+}
+
+var imageFileExtensions = map[string]bool{
+	".png":  true,
+	".gif":  true,
+	".jpg":  true,
+	".jpeg": true,
+}
+
+// ImageRequest specifies an image to be previewed in the terminal
+type ImageRequest struct {
+	filePath string
+	data     []byte
+}
+
+// ImageInfo describes the format, dimensions and size of an image
+type ImageInfo struct {
+	format string
+	width  int
+	height int
+	size   int
+}
+
+// DecodeImageInfo determines the format and dimensions of the provided image data
+func DecodeImageInfo(data []byte) (info ImageInfo, err error) {
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+
+	return ImageInfo{
+		format: strings.ToUpper(format),
+		width:  config.Width,
+		height: config.Height,
+		size:   len(data),
+	}, nil
+}
+
+// String returns a description of the image, e.g. 640x480 PNG, 12.5 KB
+func (info ImageInfo) String() string {
+	return fmt.Sprintf("%vx%v %v, %.1f KB", info.width, info.height, info.format, float64(info.size)/ipBytesPerKilobyte)
+}
+
+// isImagePath returns true if the file has the extension of an image format which can be previewed
+func isImagePath(filePath string) bool {
+	return imageFileExtensions[strings.ToLower(path.Ext(filePath))]
+}
+
+// DetectGraphicsProtocol determines the graphics protocol supported by the terminal from its environment
+func DetectGraphicsProtocol(getenv func(string) string) GraphicsProtocol {
+	term := getenv("TERM")
+	termProgram := getenv("TERM_PROGRAM")
+
+	switch {
+	case term == "xterm-kitty" || getenv("KITTY_WINDOW_ID") != "" || termProgram == "WezTerm" || termProgram == "ghostty":
+		return GpKitty
+	case strings.Contains(term, "sixel") || term == "mlterm" || strings.HasPrefix(term, "foot") || termProgram == "iTerm.app":
+		return GpSixel
+	}
+
+	return GpNone
+}
+
+// imagePreviewSequence encodes the image as the escape sequence which displays it using the provided graphics protocol
+func imagePreviewSequence(protocol GraphicsProtocol, data []byte) ([]byte, error) {
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+
+	switch protocol {
+	case GpKitty:
+		return kittySequence(img)
+	case GpSixel:
+		return sixelSequence(scaleImage(img, ipSixelMaxWidth)), nil
+	}
+
+	return nil, fmt.Errorf("The terminal does not support displaying images")
+}
+
+// kittySequence encodes the image as PNG data transmitted in chunks using the kitty graphics protocol
+func kittySequence(img image.Image) ([]byte, error) {
+	var pngData bytes.Buffer
+	if err := png.Encode(&pngData, img); err != nil {
+		return nil, err
+	}
+
+	payload := base64.StdEncoding.EncodeToString(pngData.Bytes())
+	var sequence bytes.Buffer
+
+	for start := 0; start < len(payload); start += ipKittyChunkSize {
+		end := start + ipKittyChunkSize
+		more := 1
+
+		if end >= len(payload) {
+			end = len(payload)
+			more = 0
+		}
+
+		if start == 0 {
+			fmt.Fprintf(&sequence, "\x1b_Ga=T,f=100,m=%v;%v\x1b\\", more, payload[start:end])
+		} else {
+			fmt.Fprintf(&sequence, "\x1b_Gm=%v;%v\x1b\\", more, payload[start:end])
+		}
+	}
+
+	return sequence.Bytes(), nil
+}
+
+// sixelSequence encodes the image as sixel data using a 6x6x6 color cube palette
+// Transparent pixels are not drawn
+func sixelSequence(img image.Image) []byte {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	paletteSize := ipSixelLevels * ipSixelLevels * ipSixelLevels
+
+	var sequence bytes.Buffer
+	fmt.Fprintf(&sequence, "\x1bPq\"1;1;%v;%v", width, height)
+
+	for colorIndex := 0; colorIndex < paletteSize; colorIndex++ {
+		red, green, blue := colorIndex/36, (colorIndex/6)%6, colorIndex%6
+		fmt.Fprintf(&sequence, "#%v;2;%v;%v;%v", colorIndex, red*20, green*20, blue*20)
+	}
+
+	pixelColors := make([]int, width*ipSixelBandHeight)
+
+	for bandY := 0; bandY < height; bandY += ipSixelBandHeight {
+		colorsUsed := make([]bool, paletteSize)
+
+		for bandRow := 0; bandRow < ipSixelBandHeight; bandRow++ {
+			for x := 0; x < width; x++ {
+				colorIndex := -1
+
+				if y := bandY + bandRow; y < height {
+					red, green, blue, alpha := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+
+					if alpha >= 0x8000 {
+						colorIndex = sixelLevel(red)*36 + sixelLevel(green)*6 + sixelLevel(blue)
+						colorsUsed[colorIndex] = true
+					}
+				}
+
+				pixelColors[bandRow*width+x] = colorIndex
+			}
+		}
+
+		for colorIndex, used := range colorsUsed {
+			if !used {
+				continue
+			}
+
+			fmt.Fprintf(&sequence, "#%v", colorIndex)
+
+			writeSixelRun := func(sixel byte, count int) {
+				if count > 3 {
+					fmt.Fprintf(&sequence, "!%v%c", count, sixel)
+				} else {
+					sequence.Write(bytes.Repeat([]byte{sixel}, count))
+				}
+			}
+
+			var runSixel byte
+			runLength := 0
+
+			for x := 0; x < width; x++ {
+				var bits byte
+				for bandRow := 0; bandRow < ipSixelBandHeight; bandRow++ {
+					if pixelColors[bandRow*width+x] == colorIndex {
+						bits |= 1 << uint(bandRow)
+					}
+				}
+
+				sixel := '?' + bits
+
+				if runLength > 0 && sixel != runSixel {
+					writeSixelRun(runSixel, runLength)
+					runLength = 0
+				}
+
+				runSixel = sixel
+				runLength++
+			}
+
+			writeSixelRun(runSixel, runLength)
+			sequence.WriteByte('$')
+		}
+
+		sequence.WriteByte('-')
+	}
+
+	sequence.WriteString("\x1b\\")
+
+	return sequence.Bytes()
+}
+
+// sixelLevel maps a 16 bit color channel to the closest level of the sixel color cube
+func sixelLevel(channel uint32) int {
+	return int((channel*(ipSixelLevels-1) + 0x7FFF) / 0xFFFF)
+}
+
+// scaleImage reduces the image to the maximum width using nearest neighbour sampling
+func scaleImage(img image.Image, maxWidth int) image.Image {
+	bounds := img.Bounds()
+	if bounds.Dx() <= maxWidth {
+		return img
+	}
+
+	height := bounds.Dy() * maxWidth / bounds.Dx()
+	if height < 1 {
+		height = 1
+	}
+
+	scaled := image.NewRGBA(image.Rect(0, 0, maxWidth, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < maxWidth; x++ {
+			scaled.Set(x, y, img.At(bounds.Min.X+x*bounds.Dx()/maxWidth, bounds.Min.Y+y*bounds.Dy()/height))
+		}
+	}
+
+	return scaled
+}
+
+// imageSummary describes the images before and after a change to an image file
+// Sides of the change which are absent or cannot be decoded are omitted
+func imageSummary(oldData, newData []byte) (lines []string) {
+	if oldData != nil {
+		if info, err := DecodeImageInfo(oldData); err == nil {
+			lines = append(lines, fmt.Sprintf("Image before: %v", info))
+		}
+	}
+
+	if newData != nil {
+		if info, err := DecodeImageInfo(newData); err == nil {
+			lines = append(lines, fmt.Sprintf("Image after: %v", info))
+		}
+	}
+
+	return
+}
+
+// imagePreviewCommand creates a command which writes the image preview sequence to the terminal
+// and waits for the user to press enter
+func imagePreviewCommand(sequence []byte, description string) *exec.Cmd {
+	cmd := exec.Command("sh", "-c", `clear; cat; printf '\n%s\n' "$1"; read -r _ < /dev/tty`, "sh", description)
+	cmd.Stdin = bytes.NewReader(sequence)
+
+	return cmd
+}
+
+func previewImage(diffView *DiffView, action Action) (err error) {
+	if diffView.lineHistory != nil {
+		return fmt.Errorf("Images cannot be previewed from line history")
+	}
+
+	filePath, err := diffView.selectedFilePath()
+	if err != nil {
+		return
+	} else if !isImagePath(filePath) {
+		return fmt.Errorf("%v is not a PNG, GIF or JPEG image", filePath)
+	}
+
+	commit := diffView.activeCommit
+	if diffView.revisionDiff != nil {
+		commit = diffView.revisionDiff.toCommit
+	}
+
+	if commit == nil {
+		return fmt.Errorf("No diff loaded")
+	}
+
+	go func() {
+		data, err := diffView.repoData.FileContents(commit, filePath)
+		if err != nil {
+			diffView.channels.ReportError(err)
+			return
+		}
+
+		diffView.channels.DoAction(Action{
+			ActionType: ActionShowImage,
+			Args: []interface{}{ImageRequest{
+				filePath: filePath,
+				data:     data,
+			}},
+		})
+	}()
+
+	return
+}
+
+// showImage previews the requested image in the terminal if it supports a graphics protocol
+// Otherwise the dimensions and size of the image are displayed in the status bar
+func (grv *GRV) showImage(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected image request argument")
+	}
+
+	request, ok := action.Args[0].(ImageRequest)
+	if !ok {
+		return fmt.Errorf("Expected image request argument to have type ImageRequest")
+	}
+
+	info, err := DecodeImageInfo(request.data)
+	if err != nil {
+		return fmt.Errorf("Unable to decode image %v: %v", request.filePath, err)
+	}
+
+	description := fmt.Sprintf("%v: %v", request.filePath, info)
+	protocol := grv.ui.GraphicsProtocol()
+
+	if protocol == GpNone {
+		grv.channels.Channels().ReportStatus("%v", description)
+		return
+	}
+
+	sequence, err := imagePreviewSequence(protocol, request.data)
+	if err != nil {
+		return fmt.Errorf("Unable to preview image %v: %v", request.filePath, err)
+	}
+
+	log.Infof("Previewing image %v", request.filePath)
+
+	err = grv.ui.RunExternalProgram(imagePreviewCommand(sequence, description+" - Press enter to continue"))
+	if err != nil {
+		err = fmt.Errorf("Image preview failed: %v", err)
+	}
+
+	grv.channels.Channels().UpdateDisplay()
+
+	return
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func encodeTestImage(t *testing.T, width, height int) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for x := 0; x < width; x++ {
+		img.Set(x, 0, color.RGBA{R: 0xFF, A: 0xFF})
+	}
+
+	var data bytes.Buffer
+	if err := png.Encode(&data, img); err != nil {
+		t.Fatalf("Unable to encode test image: %v", err)
+	}
+
+	return data.Bytes()
+}
+
+func TestDecodeImageInfo(t *testing.T) {
+	data := encodeTestImage(t, 64, 32)
+
+	info, err := DecodeImageInfo(data)
+	if err != nil {
+		t.Fatalf("Unable to decode image info: %v", err)
+	}
+
+	expectedInfo := ImageInfo{format: "PNG", width: 64, height: 32, size: len(data)}
+
+	if info != expectedInfo {
+		t.Errorf("Image info does not match expected value. Expected: %v, Actual: %v", expectedInfo, info)
+	}
+}
+
+func TestImageSummaryDescribesEachSideOfChange(t *testing.T) {
+	oldData := encodeTestImage(t, 16, 16)
+	newData := encodeTestImage(t, 32, 8)
+
+	lines := imageSummary(oldData, newData)
+
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], "Image before: 16x16 PNG") || !strings.HasPrefix(lines[1], "Image after: 32x8 PNG") {
+		t.Errorf("Image summary does not match expected value: %v", lines)
+	}
+
+	if lines := imageSummary(nil, []byte("not an image")); len(lines) != 0 {
+		t.Errorf("Expected no summary lines for data which is not an image but found: %v", lines)
+	}
+}
+
+func TestDetectGraphicsProtocol(t *testing.T) {
+	var detectTests = []struct {
+		environment      map[string]string
+		expectedProtocol GraphicsProtocol
+	}{
+		{environment: map[string]string{"TERM": "xterm-kitty"}, expectedProtocol: GpKitty},
+		{environment: map[string]string{"TERM": "xterm-256color", "TERM_PROGRAM": "WezTerm"}, expectedProtocol: GpKitty},
+		{environment: map[string]string{"TERM": "foot"}, expectedProtocol: GpSixel},
+		{environment: map[string]string{"TERM": "xterm-sixel"}, expectedProtocol: GpSixel},
+		{environment: map[string]string{"TERM": "xterm-256color"}, expectedProtocol: GpNone},
+	}
+
+	for _, detectTest := range detectTests {
+		getenv := func(name string) string {
+			return detectTest.environment[name]
+		}
+
+		if protocol := DetectGraphicsProtocol(getenv); protocol != detectTest.expectedProtocol {
+			t.Errorf("Graphics protocol does not match expected value for environment %v. Expected: %v, Actual: %v",
+				detectTest.environment, detectTest.expectedProtocol, protocol)
+		}
+	}
+}
+
+func TestKittySequenceIsTransmittedInChunks(t *testing.T) {
+	sequence, err := imagePreviewSequence(GpKitty, encodeTestImage(t, 200, 200))
+	if err != nil {
+		t.Fatalf("Unable to create kitty sequence: %v", err)
+	}
+
+	chunks := strings.SplitAfter(string(sequence), "\x1b\\")
+	chunks = chunks[:len(chunks)-1]
+
+	if !strings.HasPrefix(chunks[0], "\x1b_Ga=T,f=100,") {
+		t.Errorf("Expected first chunk to transmit and display PNG data but found: %.20q", chunks[0])
+	}
+
+	if lastChunk := chunks[len(chunks)-1]; !strings.Contains(lastChunk, "m=0;") {
+		t.Errorf("Expected last chunk to mark the end of the data but found: %.20q", lastChunk)
+	}
+}
+
+func TestSixelSequenceContainsOneBandPerSixRows(t *testing.T) {
+	sequence := string(sixelSequence(image.NewRGBA(image.Rect(0, 0, 10, 13))))
+
+	if !strings.HasPrefix(sequence, "\x1bPq\"1;1;10;13") || !strings.HasSuffix(sequence, "\x1b\\") {
+		t.Errorf("Sixel sequence does not have the expected introducer and terminator: %.30q", sequence)
+	}
+
+	if bands := strings.Count(sequence, "-"); bands != 3 {
+		t.Errorf("Band count does not match expected value. Expected: %v, Actual: %v", 3, bands)
+	}
+}
@@ -1,9 +1,14 @@
 package main
 
 import (
+	"strconv"
 	"strings"
 )
 
+const (
+	ibMaxCount = 100000
+)
+
 // InputBuffer buffers input and maps it to configured actions or key sequences
 type InputBuffer struct {
 	buffer      []string
@@ -46,6 +51,12 @@ func (inputBuffer *InputBuffer) Process(viewHierarchy ViewHierarchy) (action Act
 		return
 	}
 
+	countKeys, count := inputBuffer.countPrefix(viewHierarchy)
+	if len(countKeys) > 0 && !inputBuffer.hasInput() {
+		inputBuffer.prepend(countKeys)
+		return
+	}
+
 	keyBuffer := make([]string, 0)
 	keyBindings := inputBuffer.keyBindings
 	isPrefix := false
@@ -58,14 +69,14 @@ OuterLoop:
 		switch {
 		case prefix:
 			if len(inputBuffer.buffer) == 0 {
-				inputBuffer.prepend(keyBuffer)
+				inputBuffer.prepend(append(countKeys, keyBuffer...))
 				return
 			}
 
 			isPrefix = true
 		case binding.bindingType == BtAction:
 			if binding.actionType != ActionNone {
-				action = Action{ActionType: binding.actionType}
+				action = Action{ActionType: binding.actionType, Count: count}
 			} else if isPrefix {
 				inputBuffer.prepend(keyBuffer[1:])
 				keyBuffer = keyBuffer[0:1]
@@ -83,3 +94,32 @@ OuterLoop:
 
 	return
 }
+
+// countPrefix removes any numeric prefix from the start of the buffer and returns its value
+// As with vim a count cannot start with 0. Digits bound in the current view hierarchy are
+// not treated as the start of a count
+func (inputBuffer *InputBuffer) countPrefix(viewHierarchy ViewHierarchy) (countKeys []string, count uint) {
+	for inputBuffer.hasInput() {
+		key := inputBuffer.buffer[0]
+
+		digit, err := strconv.Atoi(key)
+		if err != nil || len(key) != 1 || (digit == 0 && len(countKeys) == 0) {
+			break
+		}
+
+		if len(countKeys) == 0 {
+			if binding, isPrefix := inputBuffer.keyBindings.Binding(viewHierarchy, key); isPrefix ||
+				binding.bindingType != BtAction || binding.actionType != ActionNone {
+				break
+			}
+		}
+
+		countKeys = append(countKeys, inputBuffer.pop())
+
+		if count = count*10 + uint(digit); count > ibMaxCount {
+			count = ibMaxCount
+		}
+	}
+
+	return
+}
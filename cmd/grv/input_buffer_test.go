@@ -24,6 +24,11 @@ func (keyBindings *MockKeyBindings) SetKeystringBinding(viewID ViewID, keystring
 	keyBindings.Called(viewID, keystring, mappedKeystring)
 }
 
+func (keyBindings *MockKeyBindings) SetKeymap(keymap string) error {
+	args := keyBindings.Called(keymap)
+	return args.Error(0)
+}
+
 func checkProcessResult(expectedAction Action, expectedKeystring string, actualAction Action, actualKeystring string, t *testing.T) {
 	if !reflect.DeepEqual(expectedAction, actualAction) {
 		t.Errorf("Returned action does not match expected value. Expected: %v, Actual: %v", expectedAction, actualAction)
@@ -115,3 +120,52 @@ func TestPotentialPrefixMatchIsReturnedAsSeparateKeysWhenFullInputDoesNotMatchBi
 	action, keyString = inputBuffer.Process(viewHierarchy)
 	checkProcessResult(Action{ActionType: ActionNone}, "b", action, keyString, t)
 }
+
+func TestCountPrefixIsAddedToAction(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "1").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "j").Return(newActionBinding(ActionNextLine), false)
+
+	inputBuffer.Append("10j")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNextLine, Count: 10}, "j", action, keyString, t)
+}
+
+func TestCountPrefixWaitsForFurtherInput(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "2").Return(newActionBinding(ActionNone), false)
+	keyBindings.On("Binding", viewHierarchy, "k").Return(newActionBinding(ActionPrevLine), false)
+
+	inputBuffer.Append("2")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "", action, keyString, t)
+
+	inputBuffer.Append("5")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionNone}, "", action, keyString, t)
+
+	inputBuffer.Append("k")
+	action, keyString = inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionPrevLine, Count: 25}, "k", action, keyString, t)
+}
+
+func TestBoundDigitIsNotTreatedAsCount(t *testing.T) {
+	keyBindings := &MockKeyBindings{}
+	inputBuffer := NewInputBuffer(keyBindings)
+
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	keyBindings.On("Binding", viewHierarchy, "1").Return(newActionBinding(ActionFirstLine), false)
+
+	inputBuffer.Append("1")
+	action, keyString := inputBuffer.Process(viewHierarchy)
+	checkProcessResult(Action{ActionType: ActionFirstLine}, "1", action, keyString, t)
+}
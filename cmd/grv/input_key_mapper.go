@@ -13,6 +13,10 @@ import (
 const (
 	ikmEscapeKey = 0x1B
 	ikmCtrlMask  = 0x1F
+	// The sequences surrounding pasted text when bracketed paste is enabled
+	// The leading escape of the start sequence has already been read when it is matched
+	ikmBracketedPasteStart = "[200~"
+	ikmBracketedPasteEnd   = "\x1b[201~"
 )
 
 var keyMap = map[gc.Key]string{
@@ -125,6 +129,7 @@ type InputKeyMapper struct {
 	ui               InputUI
 	char             bytes.Buffer
 	expectedCharSize int
+	pendingInput     []Key
 }
 
 // NewInputKeyMapper creates a new instance
@@ -137,19 +142,31 @@ func NewInputKeyMapper(ui InputUI) *InputKeyMapper {
 // GetKeyInput fetches the next character or key string returned from the UI
 func (inputKeyMapper *InputKeyMapper) GetKeyInput() (key string, err error) {
 	for {
-		keyPressEvent, err := inputKeyMapper.ui.GetInput(false)
+		keyPressEvent, err := inputKeyMapper.getInput(false)
 		mappedKey, isMappedKey := keyMap[gc.Key(keyPressEvent)]
 
+		if err == nil && inputKeyMapper.isProcessingUTF8Char() && !isUTF8ContinuationByte(keyPressEvent) {
+			// The character was interrupted (e.g. by IME composition output), so
+			// discard it and process the new input as the start of the next key
+			log.Errorf("Discarding incomplete UTF-8 character: %v", inputKeyMapper.char.Bytes())
+			inputKeyMapper.clearChar()
+		}
+
 		switch {
 		case err != nil:
 			return key, err
 		case keyPressEvent == UINoKey:
 			return key, err
 		case inputKeyMapper.isProcessingUTF8Char():
-			err = inputKeyMapper.processUTF8ContinuationByte(keyPressEvent)
+			inputKeyMapper.char.WriteByte(byte(keyPressEvent))
 		case isMappedKey:
 			return mappedKey, err
 		case keyPressEvent == ikmEscapeKey:
+			if inputKeyMapper.isBracketedPasteStart() {
+				inputKeyMapper.discardPastedText()
+				continue
+			}
+
 			return inputKeyMapper.metaKeyString(), err
 		case isControlKey(keyPressEvent):
 			return controlKeyString(keyPressEvent), err
@@ -166,6 +183,59 @@ func (inputKeyMapper *InputKeyMapper) GetKeyInput() (key string, err error) {
 	}
 }
 
+// getInput returns input which was read ahead and not consumed before fetching new input from the UI
+func (inputKeyMapper *InputKeyMapper) getInput(force bool) (Key, error) {
+	if len(inputKeyMapper.pendingInput) > 0 {
+		keyPressEvent := inputKeyMapper.pendingInput[0]
+		inputKeyMapper.pendingInput = inputKeyMapper.pendingInput[1:]
+		return keyPressEvent, nil
+	}
+
+	return inputKeyMapper.ui.GetInput(force)
+}
+
+// isBracketedPasteStart determines whether the input following an escape is
+// the start of pasted text. If not the input read is left to be processed
+func (inputKeyMapper *InputKeyMapper) isBracketedPasteStart() bool {
+	var input []Key
+
+	for _, char := range ikmBracketedPasteStart {
+		keyPressEvent, err := inputKeyMapper.getInput(true)
+		if err == nil && keyPressEvent != UINoKey {
+			input = append(input, keyPressEvent)
+		}
+
+		if err != nil || keyPressEvent != Key(char) {
+			inputKeyMapper.pendingInput = append(input, inputKeyMapper.pendingInput...)
+			return false
+		}
+	}
+
+	return true
+}
+
+// discardPastedText consumes input until the end of the pasted text so that
+// text pasted outside of a prompt is not processed as key presses
+func (inputKeyMapper *InputKeyMapper) discardPastedText() {
+	log.Info("Discarding text pasted outside of a prompt")
+	matched := 0
+
+	for matched < len(ikmBracketedPasteEnd) {
+		keyPressEvent, err := inputKeyMapper.getInput(false)
+
+		switch {
+		case err != nil || keyPressEvent == UINoKey:
+			return
+		case keyPressEvent == Key(ikmBracketedPasteEnd[matched]):
+			matched++
+		case keyPressEvent == Key(ikmBracketedPasteEnd[0]):
+			matched = 1
+		default:
+			matched = 0
+		}
+	}
+}
+
 func (inputKeyMapper *InputKeyMapper) isProcessingUTF8Char() bool {
 	return inputKeyMapper.expectedCharSize > 0
 }
@@ -185,45 +255,65 @@ func (inputKeyMapper *InputKeyMapper) getAndClearChar() (char string) {
 	return
 }
 
-func (inputKeyMapper *InputKeyMapper) processUTF8ContinuationByte(keyPressEvent Key) (err error) {
-	if keyPressEvent>>6 != 0x02 {
-		err = fmt.Errorf("Invalid UTF-8 continuation byte: %b", keyPressEvent)
-	} else {
-		inputKeyMapper.char.WriteByte(byte(keyPressEvent))
+func (inputKeyMapper *InputKeyMapper) processUTF8Char(keyPressEvent Key) (err error) {
+	charSize := utf8CharSize(keyPressEvent)
+
+	if charSize == 0 {
+		return fmt.Errorf("Invalid UTF-8 starting byte: %b", keyPressEvent)
 	}
 
+	inputKeyMapper.expectedCharSize = charSize
+	inputKeyMapper.char.WriteByte(byte(keyPressEvent))
+
 	return
 }
 
-func (inputKeyMapper *InputKeyMapper) processUTF8Char(keyPressEvent Key) (err error) {
+// metaKeyString reads the key following an escape. Multi-byte UTF-8 characters are read in full
+func (inputKeyMapper *InputKeyMapper) metaKeyString() string {
+	keyPressEvent, err := inputKeyMapper.getInput(true)
+
+	if err != nil || keyPressEvent == 0 {
+		return "<Escape>"
+	}
+
+	charSize := utf8CharSize(keyPressEvent)
+	if charSize <= 1 {
+		return fmt.Sprintf("<M-%c>", keyPressEvent)
+	}
+
+	char := []byte{byte(keyPressEvent)}
+
+	for len(char) < charSize {
+		if keyPressEvent, err = inputKeyMapper.getInput(true); err != nil || !isUTF8ContinuationByte(keyPressEvent) {
+			log.Errorf("Discarding incomplete UTF-8 character following escape: %v", char)
+			return "<Escape>"
+		}
+
+		char = append(char, byte(keyPressEvent))
+	}
+
+	return fmt.Sprintf("<M-%v>", string(char))
+}
+
+// utf8CharSize returns the number of bytes in the UTF-8 character started by the provided byte
+// 0 is returned if the byte cannot start a UTF-8 character
+func utf8CharSize(keyPressEvent Key) int {
 	switch {
 	case keyPressEvent < 0x80:
-		inputKeyMapper.expectedCharSize = 1
-		inputKeyMapper.char.WriteByte(byte(keyPressEvent))
+		return 1
 	case keyPressEvent>>5 == 0x06:
-		inputKeyMapper.expectedCharSize = 2
-		inputKeyMapper.char.WriteByte(byte(keyPressEvent))
+		return 2
 	case keyPressEvent>>4 == 0x0E:
-		inputKeyMapper.expectedCharSize = 3
-		inputKeyMapper.char.WriteByte(byte(keyPressEvent))
+		return 3
 	case keyPressEvent>>3 == 0x1E:
-		inputKeyMapper.expectedCharSize = 4
-		inputKeyMapper.char.WriteByte(byte(keyPressEvent))
-	default:
-		err = fmt.Errorf("Invalid UTF-8 starting byte: %b", keyPressEvent)
+		return 4
 	}
 
-	return
+	return 0
 }
 
-func (inputKeyMapper *InputKeyMapper) metaKeyString() string {
-	keyPressEvent, err := inputKeyMapper.ui.GetInput(true)
-
-	if err != nil || keyPressEvent == 0 {
-		return "<Escape>"
-	}
-
-	return fmt.Sprintf("<M-%c>", keyPressEvent)
+func isUTF8ContinuationByte(keyPressEvent Key) bool {
+	return keyPressEvent>>6 == 0x02
 }
 
 func isControlKey(keyPressEvent Key) bool {
@@ -71,6 +71,18 @@ func TestInputKeyMapperDiscardsInvalidUTF8Bytes(t *testing.T) {
 	checkOutput(key, err, "a", nil, t)
 }
 
+func TestInputKeyMapperProcessesCharacterFollowingIncompleteUTF8Char(t *testing.T) {
+	inputUI := &MockInputUI{}
+	inputKeyMapper := NewInputKeyMapper(inputUI)
+
+	inputUI.On("GetInput", false).Return(Key(0xE4), nil).Once().
+		On("GetInput", false).Return(Key(0xC3), nil).Once().
+		On("GetInput", false).Return(Key(0xA9), nil).Once()
+
+	key, err := inputKeyMapper.GetKeyInput()
+	checkOutput(key, err, "é", nil, t)
+}
+
 func TestInputKeyMapperMapsNCursesKeysToStringKeys(t *testing.T) {
 	inputUI := &MockInputUI{}
 	inputKeyMapper := NewInputKeyMapper(inputUI)
@@ -106,6 +118,55 @@ func TestInputKeyMapperMapsMetaKeyComboToString(t *testing.T) {
 	checkOutput(key, err, "<M-c>", nil, t)
 }
 
+func TestInputKeyMapperMapsMetaMultiByteUTF8KeyComboToString(t *testing.T) {
+	inputUI := &MockInputUI{}
+	inputKeyMapper := NewInputKeyMapper(inputUI)
+
+	inputUI.On("GetInput", false).Return(Key(0x1B), nil).Once().
+		On("GetInput", true).Return(Key(0xC3), nil).Once().
+		On("GetInput", true).Return(Key(0xA9), nil).Once()
+
+	key, err := inputKeyMapper.GetKeyInput()
+	checkOutput(key, err, "<M-é>", nil, t)
+}
+
+func TestInputKeyMapperDiscardsBracketedPaste(t *testing.T) {
+	inputUI := &MockInputUI{}
+	inputKeyMapper := NewInputKeyMapper(inputUI)
+
+	inputUI.On("GetInput", false).Return(Key(0x1B), nil).Once()
+
+	for _, char := range "[200~" {
+		inputUI.On("GetInput", true).Return(Key(char), nil).Once()
+	}
+
+	for _, char := range "gg\x1b[A\nq\x1b[201~j" {
+		inputUI.On("GetInput", false).Return(Key(char), nil).Once()
+	}
+
+	key, err := inputKeyMapper.GetKeyInput()
+	checkOutput(key, err, "j", nil, t)
+}
+
+func TestInputKeyMapperProcessesInputFollowingEscapeWhichIsNotBracketedPaste(t *testing.T) {
+	inputUI := &MockInputUI{}
+	inputKeyMapper := NewInputKeyMapper(inputUI)
+
+	inputUI.On("GetInput", false).Return(Key(0x1B), nil).Once().
+		On("GetInput", true).Return(Key('['), nil).Once().
+		On("GetInput", true).Return(Key('2'), nil).Once().
+		On("GetInput", true).Return(Key('a'), nil).Once()
+
+	key, err := inputKeyMapper.GetKeyInput()
+	checkOutput(key, err, "<M-[>", nil, t)
+
+	key, err = inputKeyMapper.GetKeyInput()
+	checkOutput(key, err, "2", nil, t)
+
+	key, err = inputKeyMapper.GetKeyInput()
+	checkOutput(key, err, "a", nil, t)
+}
+
 func TestInputKeyMapperReturnsEscapeIfNoMoreInputAvailbleAfterEscapeReturned(t *testing.T) {
 	inputUI := &MockInputUI{}
 	inputKeyMapper := NewInputKeyMapper(inputUI)
@@ -139,9 +200,9 @@ func TestEmptyStringIsReturnedIfErrorReturnedFromInputUI(t *testing.T) {
 }
 
 func TestTokeniseKeysBreaksDownKeys(t *testing.T) {
-	keysString := "abc123<C-a>!<C-c><<C-b>>世<M-a>"
+	keysString := "abc123<C-a>!<C-c><<C-b>>世<M-a><M-é>"
 	expected := []string{
-		"a", "b", "c", "1", "2", "3", "<C-a>", "!", "<C-c>", "<", "<C-b>", ">", "世", "<M-a>",
+		"a", "b", "c", "1", "2", "3", "<C-a>", "!", "<C-c>", "<", "<C-b>", ">", "世", "<M-a>", "<M-é>",
 	}
 
 	actual := TokeniseKeys(keysString)
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+
 	pt "github.com/tchap/go-patricia/patricia"
 )
 
@@ -16,6 +18,9 @@ const (
 	ActionSearchPrompt
 	ActionReverseSearchPrompt
 	ActionFilterPrompt
+	ActionStashPrompt
+	ActionCommitPrompt
+	ActionBranchPrompt
 	ActionSearch
 	ActionReverseSearch
 	ActionSearchFindNext
@@ -26,6 +31,11 @@ const (
 	ActionPrevLine
 	ActionNextPage
 	ActionPrevPage
+	ActionScrollHalfPageDown
+	ActionScrollHalfPageUp
+	ActionCenterView
+	ActionScrollCursorTop
+	ActionScrollCursorBottom
 	ActionScrollRight
 	ActionScrollLeft
 	ActionFirstLine
@@ -37,43 +47,220 @@ const (
 	ActionToggleViewLayout
 	ActionAddFilter
 	ActionRemoveFilter
+	ActionFilterDirectory
+	ActionCreateStash
+	ActionApplyStash
+	ActionPopStash
+	ActionDropStash
+	ActionDiffRevisions
+	ActionBack
+	ActionLineHistory
+	ActionCreateCommit
+	ActionFetch
+	ActionCancel
+	ActionCreateBranch
+	ActionShowView
+	ActionNextTab
+	ActionPrevTab
+	ActionFuzzyFind
+	ActionFuzzyFindSelect
+	ActionEditBranchDescription
+	ActionRunEditor
+	ActionUpstreamPrompt
+	ActionSetUpstream
+	ActionRenameRef
+	ActionRenameRefPrompt
+	ActionTrackBranch
+	ActionTrackBranchPrompt
+	ActionShowDialog
+	ActionClone
+	ActionOpenWorktree
+	ActionShowConfigVariables
+	ActionShowLog
+	ActionRestoreSession
+	ActionSetupWizard
+	ActionMarkRef
+	ActionExcludeRef
+	ActionLogRevisions
+	ActionCycleCommitOrder
+	ActionSimplifyByDecoration
+	ActionGotoCommit
+	ActionShowContainingRefs
+	ActionSelectContainingRef
+	ActionYankDescribe
+	ActionToggleMessagePreview
+	ActionChangelog
+	ActionCreateFixupCommit
+	ActionCreateSquashCommit
+	ActionAutosquashRebase
+	ActionRunCommand
+	ActionReloadRefs
+	ActionIncreaseDiffContext
+	ActionDecreaseDiffContext
+	ActionVisualSelection
+	ActionYankSelection
+	ActionPipeSelection
+	ActionPipeText
+	ActionPageDiff
+	ActionPageDiffFile
+	ActionPreviewImage
+	ActionShowImage
+	ActionShowFile
+	ActionGotoLine
+	ActionToggleFold
+	ActionOpenAllFolds
+	ActionCloseAllFolds
+	ActionToggleBlame
+	ActionShowLineHistory
+	ActionDirectoryOwnership
+	ActionShowOwnership
+	ActionOwnerFilterPrompt
+	ActionFilterOwner
+	ActionToggleInvisibles
+	ActionQuickfixHunks
+	ActionQuickfixSearchMatches
+	ActionShowQuickfix
+	ActionOpenQuickfix
+	ActionCloseQuickfix
+	ActionQuickfixNext
+	ActionQuickfixPrev
+	ActionJumpToQuickfixEntry
+	ActionPinRef
+	ActionSetCompareBase
 )
 
 // Action represents a type of actions and its arguments to be executed
+// Count is the numeric prefix entered before the action (0 if none was entered)
 type Action struct {
 	ActionType ActionType
 	Args       []interface{}
+	Count      uint
+}
+
+// RepeatCount returns the number of times the action should be applied
+func (action Action) RepeatCount() uint {
+	if action.Count == 0 {
+		return 1
+	}
+
+	return action.Count
 }
 
 var actionKeys = map[string]ActionType{
-	"<grv-nop>":                   ActionNone,
-	"<grv-exit>":                  ActionExit,
-	"<grv-suspend>":               ActionSuspend,
-	"<grv-prompt>":                ActionPrompt,
-	"<grv-search-prompt>":         ActionSearchPrompt,
-	"<grv-reverse-search-prompt>": ActionReverseSearchPrompt,
-	"<grv-filter-prompt>":         ActionFilterPrompt,
-	"<grv-search>":                ActionSearch,
-	"<grv-reverse-search>":        ActionReverseSearch,
-	"<grv-search-find-next>":      ActionSearchFindNext,
-	"<grv-search-find-prev>":      ActionSearchFindPrev,
-	"<grv-clear-search>":          ActionClearSearch,
-	"<grv-show-status>":           ActionShowStatus,
-	"<grv-next-line>":             ActionNextLine,
-	"<grv-prev-line>":             ActionPrevLine,
-	"<grv-next-page>":             ActionNextPage,
-	"<grv-prev-page>":             ActionPrevPage,
-	"<grv-scroll-right>":          ActionScrollRight,
-	"<grv-scroll-left>":           ActionScrollLeft,
-	"<grv-first-line>":            ActionFirstLine,
-	"<grv-last-line>":             ActionLastLine,
-	"<grv-select>":                ActionSelect,
-	"<grv-next-view>":             ActionNextView,
-	"<grv-prev-view>":             ActionPrevView,
-	"<grv-full-screen-view>":      ActionFullScreenView,
-	"<grv-toggle-view-layout>":    ActionToggleViewLayout,
-	"<grv-add-filter>":            ActionAddFilter,
-	"<grv-remove-filter>":         ActionRemoveFilter,
+	"<grv-nop>":                     ActionNone,
+	"<grv-exit>":                    ActionExit,
+	"<grv-suspend>":                 ActionSuspend,
+	"<grv-prompt>":                  ActionPrompt,
+	"<grv-search-prompt>":           ActionSearchPrompt,
+	"<grv-reverse-search-prompt>":   ActionReverseSearchPrompt,
+	"<grv-filter-prompt>":           ActionFilterPrompt,
+	"<grv-stash-prompt>":            ActionStashPrompt,
+	"<grv-clone>":                   ActionClone,
+	"<grv-open-worktree>":           ActionOpenWorktree,
+	"<grv-show-config-variables>":   ActionShowConfigVariables,
+	"<grv-show-log>":                ActionShowLog,
+	"<grv-restore-session>":         ActionRestoreSession,
+	"<grv-setup-wizard>":            ActionSetupWizard,
+	"<grv-commit-prompt>":           ActionCommitPrompt,
+	"<grv-branch-prompt>":           ActionBranchPrompt,
+	"<grv-search>":                  ActionSearch,
+	"<grv-reverse-search>":          ActionReverseSearch,
+	"<grv-search-find-next>":        ActionSearchFindNext,
+	"<grv-search-find-prev>":        ActionSearchFindPrev,
+	"<grv-clear-search>":            ActionClearSearch,
+	"<grv-show-status>":             ActionShowStatus,
+	"<grv-next-line>":               ActionNextLine,
+	"<grv-prev-line>":               ActionPrevLine,
+	"<grv-next-page>":               ActionNextPage,
+	"<grv-prev-page>":               ActionPrevPage,
+	"<grv-scroll-half-page-down>":   ActionScrollHalfPageDown,
+	"<grv-scroll-half-page-up>":     ActionScrollHalfPageUp,
+	"<grv-center-view>":             ActionCenterView,
+	"<grv-scroll-cursor-top>":       ActionScrollCursorTop,
+	"<grv-scroll-cursor-bottom>":    ActionScrollCursorBottom,
+	"<grv-scroll-right>":            ActionScrollRight,
+	"<grv-scroll-left>":             ActionScrollLeft,
+	"<grv-first-line>":              ActionFirstLine,
+	"<grv-last-line>":               ActionLastLine,
+	"<grv-select>":                  ActionSelect,
+	"<grv-next-view>":               ActionNextView,
+	"<grv-prev-view>":               ActionPrevView,
+	"<grv-full-screen-view>":        ActionFullScreenView,
+	"<grv-track-branch>":            ActionTrackBranch,
+	"<grv-track-branch-prompt>":     ActionTrackBranchPrompt,
+	"<grv-toggle-view-layout>":      ActionToggleViewLayout,
+	"<grv-add-filter>":              ActionAddFilter,
+	"<grv-remove-filter>":           ActionRemoveFilter,
+	"<grv-filter-directory>":        ActionFilterDirectory,
+	"<grv-create-stash>":            ActionCreateStash,
+	"<grv-apply-stash>":             ActionApplyStash,
+	"<grv-pop-stash>":               ActionPopStash,
+	"<grv-drop-stash>":              ActionDropStash,
+	"<grv-diff-revisions>":          ActionDiffRevisions,
+	"<grv-back>":                    ActionBack,
+	"<grv-line-history>":            ActionLineHistory,
+	"<grv-create-commit>":           ActionCreateCommit,
+	"<grv-fetch>":                   ActionFetch,
+	"<grv-cancel>":                  ActionCancel,
+	"<grv-show-dialog>":             ActionShowDialog,
+	"<grv-create-branch>":           ActionCreateBranch,
+	"<grv-show-view>":               ActionShowView,
+	"<grv-next-tab>":                ActionNextTab,
+	"<grv-prev-tab>":                ActionPrevTab,
+	"<grv-fuzzy-find>":              ActionFuzzyFind,
+	"<grv-fuzzy-find-select>":       ActionFuzzyFindSelect,
+	"<grv-edit-branch-description>": ActionEditBranchDescription,
+	"<grv-upstream-prompt>":         ActionUpstreamPrompt,
+	"<grv-set-upstream>":            ActionSetUpstream,
+	"<grv-rename-ref>":              ActionRenameRef,
+	"<grv-rename-ref-prompt>":       ActionRenameRefPrompt,
+	"<grv-run-editor>":              ActionRunEditor,
+	"<grv-mark-ref>":                ActionMarkRef,
+	"<grv-exclude-ref>":             ActionExcludeRef,
+	"<grv-log-revisions>":           ActionLogRevisions,
+	"<grv-cycle-commit-order>":      ActionCycleCommitOrder,
+	"<grv-simplify-by-decoration>":  ActionSimplifyByDecoration,
+	"<grv-goto-commit>":             ActionGotoCommit,
+	"<grv-show-containing-refs>":    ActionShowContainingRefs,
+	"<grv-select-containing-ref>":   ActionSelectContainingRef,
+	"<grv-yank-describe>":           ActionYankDescribe,
+	"<grv-toggle-message-preview>":  ActionToggleMessagePreview,
+	"<grv-changelog>":               ActionChangelog,
+	"<grv-create-fixup-commit>":     ActionCreateFixupCommit,
+	"<grv-create-squash-commit>":    ActionCreateSquashCommit,
+	"<grv-autosquash-rebase>":       ActionAutosquashRebase,
+	"<grv-run-command>":             ActionRunCommand,
+	"<grv-reload-refs>":             ActionReloadRefs,
+	"<grv-increase-diff-context>":   ActionIncreaseDiffContext,
+	"<grv-decrease-diff-context>":   ActionDecreaseDiffContext,
+	"<grv-visual-selection>":        ActionVisualSelection,
+	"<grv-yank-selection>":          ActionYankSelection,
+	"<grv-pipe-selection>":          ActionPipeSelection,
+	"<grv-pipe-text>":               ActionPipeText,
+	"<grv-page-diff>":               ActionPageDiff,
+	"<grv-page-diff-file>":          ActionPageDiffFile,
+	"<grv-preview-image>":           ActionPreviewImage,
+	"<grv-show-image>":              ActionShowImage,
+	"<grv-show-file>":               ActionShowFile,
+	"<grv-goto-line>":               ActionGotoLine,
+	"<grv-toggle-fold>":             ActionToggleFold,
+	"<grv-open-all-folds>":          ActionOpenAllFolds,
+	"<grv-close-all-folds>":         ActionCloseAllFolds,
+	"<grv-toggle-blame>":            ActionToggleBlame,
+	"<grv-show-line-history>":       ActionShowLineHistory,
+	"<grv-directory-ownership>":     ActionDirectoryOwnership,
+	"<grv-show-ownership>":          ActionShowOwnership,
+	"<grv-owner-filter-prompt>":     ActionOwnerFilterPrompt,
+	"<grv-filter-owner>":            ActionFilterOwner,
+	"<grv-toggle-invisibles>":       ActionToggleInvisibles,
+	"<grv-quickfix-hunks>":          ActionQuickfixHunks,
+	"<grv-quickfix-search-matches>": ActionQuickfixSearchMatches,
+	"<grv-open-quickfix>":           ActionOpenQuickfix,
+	"<grv-close-quickfix>":          ActionCloseQuickfix,
+	"<grv-quickfix-next>":           ActionQuickfixNext,
+	"<grv-quickfix-prev>":           ActionQuickfixPrev,
+	"<grv-pin-ref>":                 ActionPinRef,
+	"<grv-set-compare-base>":        ActionSetCompareBase,
 }
 
 var defaultKeyBindings = map[ActionType]map[ViewID][]string{
@@ -89,6 +276,18 @@ var defaultKeyBindings = map[ActionType]map[ViewID][]string{
 	ActionSuspend: {
 		ViewAll: {"<C-z>"},
 	},
+	ActionCancel: {
+		ViewAll: {"<C-c>"},
+	},
+	ActionNextTab: {
+		ViewMain: {"gt"},
+	},
+	ActionPrevTab: {
+		ViewMain: {"gT"},
+	},
+	ActionFuzzyFind: {
+		ViewMain: {"<C-p>"},
+	},
 	ActionSearchFindNext: {
 		ViewAll: {"n"},
 	},
@@ -107,6 +306,21 @@ var defaultKeyBindings = map[ActionType]map[ViewID][]string{
 	ActionPrevPage: {
 		ViewAll: {"<PageUp>", "<C-b>"},
 	},
+	ActionScrollHalfPageDown: {
+		ViewAll: {"<C-d>"},
+	},
+	ActionScrollHalfPageUp: {
+		ViewAll: {"<C-u>"},
+	},
+	ActionCenterView: {
+		ViewAll: {"zz"},
+	},
+	ActionScrollCursorTop: {
+		ViewAll: {"zt"},
+	},
+	ActionScrollCursorBottom: {
+		ViewAll: {"zb"},
+	},
 	ActionScrollRight: {
 		ViewAll: {"<Right>", "l"},
 	},
@@ -142,6 +356,174 @@ var defaultKeyBindings = map[ActionType]map[ViewID][]string{
 		ViewCommit: {"<C-r>"},
 		ViewRef:    {"<C-r>"},
 	},
+	ActionFilterDirectory: {
+		ViewDiff: {"d"},
+	},
+	ActionStashPrompt: {
+		ViewRef: {"S"},
+	},
+	ActionCommitPrompt: {
+		ViewRef: {"C"},
+	},
+	ActionBranchPrompt: {
+		ViewRef: {"B"},
+	},
+	ActionFetch: {
+		ViewRef: {"F"},
+	},
+	ActionEditBranchDescription: {
+		ViewRef: {"E"},
+	},
+	ActionUpstreamPrompt: {
+		ViewRef: {"U"},
+	},
+	ActionRenameRef: {
+		ViewRef: {"R"},
+	},
+	ActionTrackBranch: {
+		ViewRef: {"T"},
+	},
+	ActionApplyStash: {
+		ViewRef: {"A"},
+	},
+	ActionPopStash: {
+		ViewRef: {"P"},
+	},
+	ActionDropStash: {
+		ViewRef: {"D"},
+	},
+	ActionMarkRef: {
+		ViewRef: {"m"},
+	},
+	ActionExcludeRef: {
+		ViewRef: {"x"},
+	},
+	ActionCycleCommitOrder: {
+		ViewCommit: {"o"},
+	},
+	ActionSimplifyByDecoration: {
+		ViewCommit: {"D"},
+	},
+	ActionShowContainingRefs: {
+		ViewCommit: {"c"},
+	},
+	ActionYankDescribe: {
+		ViewCommit: {"yd"},
+	},
+	ActionToggleMessagePreview: {
+		ViewCommit: {"M"},
+		ViewDiff:   {"M"},
+	},
+	ActionCreateFixupCommit: {
+		ViewCommit: {"F"},
+	},
+	ActionCreateSquashCommit: {
+		ViewCommit: {"S"},
+	},
+	ActionAutosquashRebase: {
+		ViewCommit: {"A"},
+	},
+	ActionBack: {
+		ViewDiff: {"<Backspace>", "<C-h>"},
+	},
+	ActionLineHistory: {
+		ViewDiff: {"L"},
+		ViewFile: {"L"},
+	},
+	ActionIncreaseDiffContext: {
+		ViewDiff: {"]"},
+	},
+	ActionDecreaseDiffContext: {
+		ViewDiff: {"["},
+	},
+	ActionVisualSelection: {
+		ViewDiff: {"v"},
+	},
+	ActionYankSelection: {
+		ViewDiff: {"y"},
+	},
+	ActionPipeSelection: {
+		ViewDiff: {"|"},
+	},
+	ActionPageDiff: {
+		ViewDiff: {"P"},
+	},
+	ActionPageDiffFile: {
+		ViewDiff: {"p"},
+	},
+	ActionPreviewImage: {
+		ViewDiff: {"I"},
+	},
+	ActionShowFile: {
+		ViewDiff: {"o"},
+	},
+	ActionToggleFold: {
+		ViewFile: {"za"},
+	},
+	ActionOpenAllFolds: {
+		ViewFile: {"zR"},
+	},
+	ActionCloseAllFolds: {
+		ViewFile: {"zM"},
+	},
+	ActionToggleBlame: {
+		ViewFile: {"B"},
+	},
+	ActionDirectoryOwnership: {
+		ViewDiff: {"O"},
+		ViewFile: {"O"},
+	},
+	ActionOwnerFilterPrompt: {
+		ViewCommit: {"T"},
+	},
+	ActionToggleInvisibles: {
+		ViewDiff: {"W"},
+		ViewFile: {"W"},
+	},
+	ActionQuickfixHunks: {
+		ViewDiff: {"Q"},
+	},
+	ActionQuickfixSearchMatches: {
+		ViewDiff: {"gQ"},
+	},
+	ActionCloseQuickfix: {
+		ViewQuickfix: {"q"},
+	},
+	ActionPinRef: {
+		ViewRef: {"p"},
+	},
+	ActionSetCompareBase: {
+		ViewRef: {"b"},
+	},
+}
+
+// The set of key binding presets
+const (
+	KeymapVim   = "vim"
+	KeymapEmacs = "emacs"
+)
+
+// keymapBinding is a key sequence bound to an action by a keymap
+type keymapBinding struct {
+	viewID     ViewID
+	keystring  string
+	actionType ActionType
+}
+
+// keymapBindings contains the bindings each keymap applies on top of the default key bindings
+// The default key bindings are vim style so the vim keymap applies no additional bindings
+// Bindings which override a default binding for a parent view (e.g. <C-p> for ViewMain) are set on that view
+var keymapBindings = map[string][]keymapBinding{
+	KeymapVim: {},
+	KeymapEmacs: {
+		{viewID: ViewAll, keystring: "<C-n>", actionType: ActionNextLine},
+		{viewID: ViewMain, keystring: "<C-p>", actionType: ActionPrevLine},
+		{viewID: ViewAll, keystring: "<C-v>", actionType: ActionNextPage},
+		{viewID: ViewAll, keystring: "<M-v>", actionType: ActionPrevPage},
+		{viewID: ViewMain, keystring: "<C-s>", actionType: ActionSearchPrompt},
+		{viewID: ViewAll, keystring: "<C-g>", actionType: ActionCancel},
+		{viewID: ViewMain, keystring: "<C-x><C-f>", actionType: ActionFuzzyFind},
+	},
 }
 
 // ViewHierarchy is a list of views parent to child
@@ -184,17 +566,20 @@ type KeyBindings interface {
 	Binding(viewHierarchy ViewHierarchy, keystring string) (binding Binding, isPrefix bool)
 	SetActionBinding(viewID ViewID, keystring string, actionType ActionType)
 	SetKeystringBinding(viewID ViewID, keystring, mappedKeystring string)
+	SetKeymap(keymap string) error
 }
 
 // KeyBindingManager manages key bindings in grv
 type KeyBindingManager struct {
 	bindings map[ViewID]*pt.Trie
+	keymap   string
 }
 
 // NewKeyBindingManager creates a new instance
 func NewKeyBindingManager() KeyBindings {
 	keyBindingManager := &KeyBindingManager{
 		bindings: make(map[ViewID]*pt.Trie),
+		keymap:   KeymapVim,
 	}
 
 	keyBindingManager.setDefaultKeyBindings()
@@ -233,6 +618,41 @@ func (keyBindingManager *KeyBindingManager) SetKeystringBinding(viewID ViewID, k
 	viewBindings.Set(pt.Prefix(keystring), newKeystringBinding(mappedKeystring))
 }
 
+// SetKeymap replaces the bindings of the current keymap with those of the provided keymap
+// Key sequences bound by the previous keymap are restored to their default bindings
+func (keyBindingManager *KeyBindingManager) SetKeymap(keymap string) (err error) {
+	bindings, ok := keymapBindings[keymap]
+	if !ok {
+		return fmt.Errorf("Invalid keymap: %v", keymap)
+	}
+
+	for _, binding := range keymapBindings[keyBindingManager.keymap] {
+		keyBindingManager.restoreDefaultKeyBinding(binding.viewID, binding.keystring)
+	}
+
+	for _, binding := range bindings {
+		keyBindingManager.SetActionBinding(binding.viewID, binding.keystring, binding.actionType)
+	}
+
+	keyBindingManager.keymap = keymap
+
+	return
+}
+
+// restoreDefaultKeyBinding removes any binding for the key sequence and view and re-applies the default binding if one exists
+func (keyBindingManager *KeyBindingManager) restoreDefaultKeyBinding(viewID ViewID, keystring string) {
+	viewBindings := keyBindingManager.getOrCreateViewBindings(viewID)
+	viewBindings.Delete(pt.Prefix(keystring))
+
+	for actionType, viewKeys := range defaultKeyBindings {
+		for _, key := range viewKeys[viewID] {
+			if key == keystring {
+				viewBindings.Set(pt.Prefix(keystring), newActionBinding(actionType))
+			}
+		}
+	}
+}
+
 func (keyBindingManager *KeyBindingManager) getOrCreateViewBindings(viewID ViewID) *pt.Trie {
 	viewBindings, ok := keyBindingManager.bindings[viewID]
 	if ok {
@@ -109,3 +109,36 @@ func TestDefaultKeyBindingsReturnsBindings(t *testing.T) {
 		}
 	}
 }
+
+func TestKeymapBindingsAreAppliedAndRestored(t *testing.T) {
+	keyBindings := NewKeyBindingManager()
+	viewHierarchy := ViewHierarchy([]ViewID{ViewMain, ViewHistory, ViewCommit})
+
+	if err := keyBindings.SetKeymap(KeymapEmacs); err != nil {
+		t.Fatalf("SetKeymap failed with error %v", err)
+	}
+
+	binding, isPrefix := keyBindings.Binding(viewHierarchy, "<C-p>")
+	checkBinding(binding, isPrefix, newActionBinding(ActionPrevLine), false, t)
+
+	binding, isPrefix = keyBindings.Binding(viewHierarchy, "<C-n>")
+	checkBinding(binding, isPrefix, newActionBinding(ActionNextLine), false, t)
+
+	if err := keyBindings.SetKeymap(KeymapVim); err != nil {
+		t.Fatalf("SetKeymap failed with error %v", err)
+	}
+
+	binding, isPrefix = keyBindings.Binding(viewHierarchy, "<C-p>")
+	checkBinding(binding, isPrefix, newActionBinding(ActionFuzzyFind), false, t)
+
+	binding, isPrefix = keyBindings.Binding(viewHierarchy, "<C-n>")
+	checkBinding(binding, isPrefix, newActionBinding(ActionNone), false, t)
+}
+
+func TestInvalidKeymapReturnsError(t *testing.T) {
+	keyBindings := NewKeyBindingManager()
+
+	if err := keyBindings.SetKeymap("invalid"); err == nil {
+		t.Errorf("Expected SetKeymap to return an error for an invalid keymap")
+	}
+}
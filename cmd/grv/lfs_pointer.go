@@ -0,0 +1,69 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"strconv"
+	"strings"
+)
+
+const (
+	lfsPointerMaxSize = 1024
+	lfsVersionPrefix  = "version https://git-lfs.github.com/spec/"
+)
+
+// LfsPointer is the content of a file stored in place of an object managed by Git LFS
+type LfsPointer struct {
+	oid  string
+	size int64
+}
+
+// ParseLfsPointer attempts to parse the provided file content as a Git LFS pointer
+// The returned bool is false if the content is not a valid pointer
+func ParseLfsPointer(content []byte) (lfsPointer *LfsPointer, isPointer bool) {
+	if len(content) == 0 || len(content) >= lfsPointerMaxSize {
+		return
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+
+	if !scanner.Scan() || !strings.HasPrefix(scanner.Text(), lfsVersionPrefix) {
+		return
+	}
+
+	pointer := &LfsPointer{size: -1}
+
+	for scanner.Scan() {
+		key, value, ok := splitLfsPointerLine(scanner.Text())
+		if !ok {
+			return
+		}
+
+		switch key {
+		case "oid":
+			pointer.oid = value
+		case "size":
+			size, err := strconv.ParseInt(value, 10, 64)
+			if err != nil || size < 0 {
+				return
+			}
+
+			pointer.size = size
+		}
+	}
+
+	if pointer.oid == "" || pointer.size < 0 {
+		return
+	}
+
+	return pointer, true
+}
+
+func splitLfsPointerLine(line string) (key, value string, ok bool) {
+	separatorIndex := strings.Index(line, " ")
+	if separatorIndex < 1 {
+		return
+	}
+
+	return line[:separatorIndex], line[separatorIndex+1:], true
+}
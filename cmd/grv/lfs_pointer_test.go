@@ -0,0 +1,59 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidLfsPointersAreParsed(t *testing.T) {
+	var lfsPointerTests = []struct {
+		content      string
+		expectedOid  string
+		expectedSize int64
+	}{
+		{
+			content: "version https://git-lfs.github.com/spec/v1\n" +
+				"oid sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393\n" +
+				"size 12345\n",
+			expectedOid:  "sha256:4d7a214614ab2935c943f9e0ff69d22eadbb8f32b1258daaa5e2ca24d17e2393",
+			expectedSize: 12345,
+		},
+		{
+			content: "version https://git-lfs.github.com/spec/v1\n" +
+				"ext-0-foo sha256:ffff\n" +
+				"oid sha256:0000\n" +
+				"size 0",
+			expectedOid:  "sha256:0000",
+			expectedSize: 0,
+		},
+	}
+
+	for _, lfsPointerTest := range lfsPointerTests {
+		lfsPointer, isPointer := ParseLfsPointer([]byte(lfsPointerTest.content))
+
+		if !isPointer {
+			t.Errorf("Expected content to be parsed as LFS pointer: %v", lfsPointerTest.content)
+		} else if lfsPointer.oid != lfsPointerTest.expectedOid || lfsPointer.size != lfsPointerTest.expectedSize {
+			t.Errorf("LFS pointer does not match expected value. Expected: oid %v size %v, Actual: oid %v size %v",
+				lfsPointerTest.expectedOid, lfsPointerTest.expectedSize, lfsPointer.oid, lfsPointer.size)
+		}
+	}
+}
+
+func TestInvalidLfsPointersAreRejected(t *testing.T) {
+	var contents = []string{
+		"",
+		"package main\n",
+		"version https://git-lfs.github.com/spec/v1\nsize 12345\n",
+		"version https://git-lfs.github.com/spec/v1\noid sha256:0000\n",
+		"version https://git-lfs.github.com/spec/v1\noid sha256:0000\nsize -1\n",
+		"version https://git-lfs.github.com/spec/v1\noid sha256:0000\nsize abc\n",
+		"version https://git-lfs.github.com/spec/v1\noid sha256:0000\nsize 1\n" + strings.Repeat("a", lfsPointerMaxSize),
+	}
+
+	for _, content := range contents {
+		if _, isPointer := ParseLfsPointer([]byte(content)); isPointer {
+			t.Errorf("Expected content not to be parsed as LFS pointer: %v", content)
+		}
+	}
+}
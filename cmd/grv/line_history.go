@@ -0,0 +1,144 @@
+package main
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var diffHunkHeaderRegex = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// diffHunkHeader contains the line ranges of a hunk in the old and new versions of a file
+type diffHunkHeader struct {
+	oldStart uint
+	oldLines uint
+	newStart uint
+	newLines uint
+}
+
+// lineRangeTrace describes how a line range in a file maps onto the previous version of the file
+type lineRangeTrace struct {
+	oldStartLine uint
+	oldEndLine   uint
+	existsInOld  bool
+	hunkIndexes  []int
+}
+
+func parseDiffHunkHeader(line string) (hunkHeader diffHunkHeader, ok bool) {
+	matches := diffHunkHeaderRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return
+	}
+
+	values := make([]uint, 4)
+
+	for index, match := range matches[1:] {
+		if match == "" {
+			values[index] = 1
+			continue
+		}
+
+		value, err := strconv.ParseUint(match, 10, 32)
+		if err != nil {
+			return
+		}
+
+		values[index] = uint(value)
+	}
+
+	return diffHunkHeader{
+		oldStart: values[0],
+		oldLines: values[1],
+		newStart: values[2],
+		newLines: values[3],
+	}, true
+}
+
+// splitPatch separates the file header of a single file patch from its hunks
+func splitPatch(patch string) (header string, hunks []string) {
+	lines := strings.SplitAfter(patch, "\n")
+	var hunk []string
+
+	for _, line := range lines {
+		if strings.HasPrefix(line, "@@") {
+			if hunk != nil {
+				hunks = append(hunks, strings.Join(hunk, ""))
+			}
+
+			hunk = []string{line}
+		} else if hunk != nil {
+			hunk = append(hunk, line)
+		} else {
+			header += line
+		}
+	}
+
+	if hunk != nil {
+		hunks = append(hunks, strings.Join(hunk, ""))
+	}
+
+	return
+}
+
+// traceLineRange determines which hunks modify the line range [startLine, endLine] of
+// the new version of a file and the corresponding line range in the old version
+func traceLineRange(hunkHeaders []diffHunkHeader, startLine, endLine uint) (trace lineRangeTrace) {
+	start, end := int(startLine), int(endLine)
+	oldStart, oldEnd := start, end
+	startMapped, endMapped := false, false
+
+	for hunkIndex, hunkHeader := range hunkHeaders {
+		hunkNewStart, hunkNewLines := int(hunkHeader.newStart), int(hunkHeader.newLines)
+		hunkOldStart, hunkOldLines := int(hunkHeader.oldStart), int(hunkHeader.oldLines)
+
+		// A hunk with no lines on one side refers to the line after which the change occurs
+		if hunkNewLines == 0 {
+			hunkNewStart++
+		}
+		if hunkOldLines == 0 {
+			hunkOldStart++
+		}
+
+		hunkNewEnd := hunkNewStart + hunkNewLines - 1
+		hunkOldEnd := hunkOldStart + hunkOldLines - 1
+		offset := (hunkOldEnd + 1) - (hunkNewEnd + 1)
+
+		if hunkNewLines > 0 && hunkNewStart <= end && hunkNewEnd >= start {
+			trace.hunkIndexes = append(trace.hunkIndexes, hunkIndex)
+		} else if hunkNewLines == 0 && start < hunkNewStart && hunkNewStart <= end {
+			trace.hunkIndexes = append(trace.hunkIndexes, hunkIndex)
+		}
+
+		if !startMapped {
+			switch {
+			case start < hunkNewStart:
+				startMapped = true
+			case start <= hunkNewEnd:
+				oldStart = hunkOldStart
+				startMapped = true
+			default:
+				oldStart = start + offset
+			}
+		}
+
+		if !endMapped {
+			switch {
+			case end < hunkNewStart:
+				endMapped = true
+			case end <= hunkNewEnd:
+				oldEnd = hunkOldEnd
+				endMapped = true
+			default:
+				oldEnd = end + offset
+			}
+		}
+	}
+
+	if oldStart <= oldEnd && oldEnd > 0 {
+		trace.oldStartLine = uint(oldStart)
+		trace.oldEndLine = uint(oldEnd)
+		trace.existsInOld = true
+	}
+
+	return
+}
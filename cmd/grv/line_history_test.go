@@ -0,0 +1,132 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDiffHunkHeader(t *testing.T) {
+	var hunkHeaderTests = []struct {
+		line               string
+		expectedHunkHeader diffHunkHeader
+		expectedOk         bool
+	}{
+		{
+			line:               "@@ -12,7 +12,9 @@ func main() {",
+			expectedHunkHeader: diffHunkHeader{oldStart: 12, oldLines: 7, newStart: 12, newLines: 9},
+			expectedOk:         true,
+		},
+		{
+			line:               "@@ -1 +1,2 @@",
+			expectedHunkHeader: diffHunkHeader{oldStart: 1, oldLines: 1, newStart: 1, newLines: 2},
+			expectedOk:         true,
+		},
+		{
+			line:               "@@ -0,0 +1,3 @@",
+			expectedHunkHeader: diffHunkHeader{oldStart: 0, oldLines: 0, newStart: 1, newLines: 3},
+			expectedOk:         true,
+		},
+		{
+			line:       "+@@ -1 +1 @@",
+			expectedOk: false,
+		},
+	}
+
+	for _, hunkHeaderTest := range hunkHeaderTests {
+		hunkHeader, ok := parseDiffHunkHeader(hunkHeaderTest.line)
+
+		if ok != hunkHeaderTest.expectedOk {
+			t.Errorf("Ok value does not match expected value for line %v. Expected: %v, Actual: %v", hunkHeaderTest.line, hunkHeaderTest.expectedOk, ok)
+		} else if ok && hunkHeader != hunkHeaderTest.expectedHunkHeader {
+			t.Errorf("Hunk header does not match expected value for line %v. Expected: %v, Actual: %v", hunkHeaderTest.line, hunkHeaderTest.expectedHunkHeader, hunkHeader)
+		}
+	}
+}
+
+func TestSplitPatch(t *testing.T) {
+	patch := "diff --git a/main.go b/main.go\n" +
+		"--- a/main.go\n" +
+		"+++ b/main.go\n" +
+		"@@ -1,2 +1,2 @@\n" +
+		"-a\n" +
+		"+b\n" +
+		"@@ -10 +10 @@\n" +
+		"-c\n" +
+		"+d\n"
+
+	header, hunks := splitPatch(patch)
+
+	expectedHeader := "diff --git a/main.go b/main.go\n--- a/main.go\n+++ b/main.go\n"
+	expectedHunks := []string{"@@ -1,2 +1,2 @@\n-a\n+b\n", "@@ -10 +10 @@\n-c\n+d\n"}
+
+	if header != expectedHeader {
+		t.Errorf("Header does not match expected value. Expected: %q, Actual: %q", expectedHeader, header)
+	}
+
+	if !reflect.DeepEqual(hunks, expectedHunks) {
+		t.Errorf("Hunks do not match expected value. Expected: %q, Actual: %q", expectedHunks, hunks)
+	}
+}
+
+func TestTraceLineRange(t *testing.T) {
+	var traceLineRangeTests = []struct {
+		hunkHeaders   []diffHunkHeader
+		startLine     uint
+		endLine       uint
+		expectedTrace lineRangeTrace
+	}{
+		{
+			startLine:     5,
+			endLine:       10,
+			expectedTrace: lineRangeTrace{oldStartLine: 5, oldEndLine: 10, existsInOld: true},
+		},
+		{
+			hunkHeaders:   []diffHunkHeader{{oldStart: 1, oldLines: 3, newStart: 1, newLines: 5}},
+			startLine:     10,
+			endLine:       12,
+			expectedTrace: lineRangeTrace{oldStartLine: 8, oldEndLine: 10, existsInOld: true},
+		},
+		{
+			hunkHeaders:   []diffHunkHeader{{oldStart: 1, oldLines: 3, newStart: 1, newLines: 5}},
+			startLine:     2,
+			endLine:       3,
+			expectedTrace: lineRangeTrace{oldStartLine: 1, oldEndLine: 3, existsInOld: true, hunkIndexes: []int{0}},
+		},
+		{
+			hunkHeaders:   []diffHunkHeader{{oldStart: 4, oldLines: 0, newStart: 5, newLines: 3}},
+			startLine:     5,
+			endLine:       7,
+			expectedTrace: lineRangeTrace{hunkIndexes: []int{0}},
+		},
+		{
+			hunkHeaders:   []diffHunkHeader{{oldStart: 5, oldLines: 2, newStart: 4, newLines: 0}},
+			startLine:     3,
+			endLine:       6,
+			expectedTrace: lineRangeTrace{oldStartLine: 3, oldEndLine: 8, existsInOld: true, hunkIndexes: []int{0}},
+		},
+		{
+			hunkHeaders: []diffHunkHeader{
+				{oldStart: 2, oldLines: 1, newStart: 2, newLines: 2},
+				{oldStart: 20, oldLines: 4, newStart: 21, newLines: 1},
+			},
+			startLine:     30,
+			endLine:       31,
+			expectedTrace: lineRangeTrace{oldStartLine: 32, oldEndLine: 33, existsInOld: true},
+		},
+		{
+			hunkHeaders:   []diffHunkHeader{{oldStart: 0, oldLines: 0, newStart: 1, newLines: 10}},
+			startLine:     1,
+			endLine:       4,
+			expectedTrace: lineRangeTrace{hunkIndexes: []int{0}},
+		},
+	}
+
+	for _, traceLineRangeTest := range traceLineRangeTests {
+		trace := traceLineRange(traceLineRangeTest.hunkHeaders, traceLineRangeTest.startLine, traceLineRangeTest.endLine)
+
+		if !reflect.DeepEqual(trace, traceLineRangeTest.expectedTrace) {
+			t.Errorf("Trace does not match expected value for range %v-%v. Expected: %+v, Actual: %+v",
+				traceLineRangeTest.startLine, traceLineRangeTest.endLine, traceLineRangeTest.expectedTrace, trace)
+		}
+	}
+}
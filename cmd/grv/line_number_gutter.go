@@ -0,0 +1,66 @@
+package main
+
+import (
+	"fmt"
+)
+
+const (
+	lngMinWidth = 3
+)
+
+// LineNumberGutter formats the line numbers displayed at the start of each row of a view
+// Behaviour matches vim's number and relativenumber options. When both are set the
+// selected line displays its absolute line number and other lines their relative distance
+type LineNumberGutter struct {
+	number         bool
+	relativeNumber bool
+	activeRowIndex uint
+	lineNum        uint
+	width          int
+}
+
+// NewLineNumberGutter creates a gutter for a view with lineNum lines and the provided selected line
+func NewLineNumberGutter(number, relativeNumber bool, activeRowIndex, lineNum uint) *LineNumberGutter {
+	width := len(fmt.Sprintf("%v", lineNum))
+	if width < lngMinWidth {
+		width = lngMinWidth
+	}
+
+	return &LineNumberGutter{
+		number:         number,
+		relativeNumber: relativeNumber,
+		activeRowIndex: activeRowIndex,
+		lineNum:        lineNum,
+		width:          width,
+	}
+}
+
+// Enabled returns true if line numbers should be displayed
+func (lineNumberGutter *LineNumberGutter) Enabled() bool {
+	return lineNumberGutter.number || lineNumberGutter.relativeNumber
+}
+
+// LineNumber returns the formatted line number to display for the provided row index
+func (lineNumberGutter *LineNumberGutter) LineNumber(rowIndex uint) string {
+	lineNumber := rowIndex + 1
+
+	if lineNumberGutter.relativeNumber {
+		if rowIndex > lineNumberGutter.activeRowIndex {
+			lineNumber = rowIndex - lineNumberGutter.activeRowIndex
+		} else if rowIndex < lineNumberGutter.activeRowIndex {
+			lineNumber = lineNumberGutter.activeRowIndex - rowIndex
+		} else if !lineNumberGutter.number {
+			lineNumber = 0
+		}
+	}
+
+	return fmt.Sprintf("%*v ", lineNumberGutter.width, lineNumber)
+}
+
+// Render draws the line number for the provided row index to the line builder
+// Nothing is drawn for rows beyond the last line
+func (lineNumberGutter *LineNumberGutter) Render(lineBuilder *LineBuilder, rowIndex uint) {
+	if lineNumberGutter.Enabled() && rowIndex < lineNumberGutter.lineNum {
+		lineBuilder.AppendGutter(CmpAllviewLineNumber, " %v", lineNumberGutter.LineNumber(rowIndex))
+	}
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestLineNumberGutter(t *testing.T) {
+	var gutterTests = []struct {
+		number              bool
+		relativeNumber      bool
+		expectedLineNumbers []string
+	}{
+		{
+			number:              true,
+			expectedLineNumbers: []string{"  1 ", "  2 ", "  3 ", "  4 "},
+		},
+		{
+			relativeNumber:      true,
+			expectedLineNumbers: []string{"  2 ", "  1 ", "  0 ", "  1 "},
+		},
+		{
+			number:              true,
+			relativeNumber:      true,
+			expectedLineNumbers: []string{"  2 ", "  1 ", "  3 ", "  1 "},
+		},
+	}
+
+	for _, gutterTest := range gutterTests {
+		lineNumberGutter := NewLineNumberGutter(gutterTest.number, gutterTest.relativeNumber, 2, 4)
+
+		for rowIndex, expectedLineNumber := range gutterTest.expectedLineNumbers {
+			if lineNumber := lineNumberGutter.LineNumber(uint(rowIndex)); lineNumber != expectedLineNumber {
+				t.Errorf("Line number does not match expected value for number: %v, relativenumber: %v. Expected: %q, Actual: %q",
+					gutterTest.number, gutterTest.relativeNumber, expectedLineNumber, lineNumber)
+			}
+		}
+	}
+}
+
+func TestLineNumberGutterWidthAdjustsToLineCount(t *testing.T) {
+	lineNumberGutter := NewLineNumberGutter(true, false, 0, 12345)
+	expectedLineNumber := "    7 "
+
+	if lineNumber := lineNumberGutter.LineNumber(6); lineNumber != expectedLineNumber {
+		t.Errorf("Line number does not match expected value. Expected: %q, Actual: %q", expectedLineNumber, lineNumber)
+	}
+}
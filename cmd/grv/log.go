@@ -7,7 +7,10 @@ import (
 	"os"
 	"path"
 	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 )
@@ -15,8 +18,24 @@ import (
 const (
 	logLogrusRepo     = "github.com/Sirupsen/logrus"
 	logFileDateFormat = "2006-01-02 15:04:05.000-0700"
+	logFileMaxSize    = 10 * 1024 * 1024
+	logFileBackupExt  = ".1"
+	logBufferSize     = 1000
+	logFieldFile      = "file"
 )
 
+var logLevels = map[string]log.Level{
+	"PANIC": log.PanicLevel,
+	"FATAL": log.FatalLevel,
+	"ERROR": log.ErrorLevel,
+	"WARN":  log.WarnLevel,
+	"INFO":  log.InfoLevel,
+	"DEBUG": log.DebugLevel,
+}
+
+var logFile = &rotatingLogFile{maxSize: logFileMaxSize}
+var logEntries = NewLogBuffer(logBufferSize)
+
 type fileHook struct{}
 
 func (hook fileHook) Fire(entry *log.Entry) (err error) {
@@ -29,7 +48,7 @@ func (hook fileHook) Fire(entry *log.Entry) (err error) {
 
 		if !strings.Contains(name, logLogrusRepo) {
 			file, line := fu.FileLine(pc[i] - 1)
-			entry.Data["file"] = fmt.Sprintf("%v:%v", path.Base(file), line)
+			entry.Data[logFieldFile] = fmt.Sprintf("%v:%v", path.Base(file), line)
 			break
 		}
 	}
@@ -45,15 +64,35 @@ type logFormatter struct{}
 
 func (formatter logFormatter) Format(entry *log.Entry) ([]byte, error) {
 	var buffer bytes.Buffer
-	file, _ := entry.Data["file"].(string)
+	file, _ := entry.Data[logFieldFile].(string)
 
 	formatter.formatBracketEntry(&buffer, entry.Time.Format(logFileDateFormat))
 	formatter.formatBracketEntry(&buffer, strings.ToUpper(entry.Level.String()))
 	formatter.formatBracketEntry(&buffer, file)
 
 	buffer.WriteString("- ")
+	buffer.WriteString(escapeLogMessage(entry.Message))
+
+	if fields := formatLogFields(entry.Data); fields != "" {
+		buffer.WriteRune(' ')
+		buffer.WriteString(fields)
+	}
+
+	buffer.WriteRune('\n')
+
+	return buffer.Bytes(), nil
+}
+
+func (formatter logFormatter) formatBracketEntry(buffer *bytes.Buffer, value string) {
+	buffer.WriteRune('[')
+	buffer.WriteString(value)
+	buffer.WriteString("] ")
+}
 
-	for _, char := range entry.Message {
+func escapeLogMessage(message string) string {
+	var buffer bytes.Buffer
+
+	for _, char := range message {
 		switch {
 		case char == '\n':
 			buffer.WriteString("\\n")
@@ -64,47 +103,210 @@ func (formatter logFormatter) Format(entry *log.Entry) ([]byte, error) {
 		}
 	}
 
-	buffer.WriteRune('\n')
+	return buffer.String()
+}
 
-	return buffer.Bytes(), nil
+// formatLogFields formats the fields of a log entry as key=value pairs ordered by key
+// The file field is omitted as it is displayed separately
+func formatLogFields(fields log.Fields) string {
+	var keys []string
+	for key := range fields {
+		if key != logFieldFile {
+			keys = append(keys, key)
+		}
+	}
+
+	sort.Strings(keys)
+
+	pairs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		pairs = append(pairs, fmt.Sprintf("%v=%v", key, escapeLogMessage(fmt.Sprint(fields[key]))))
+	}
+
+	return strings.Join(pairs, " ")
 }
 
-func (formatter logFormatter) formatBracketEntry(buffer *bytes.Buffer, value string) {
-	buffer.WriteRune('[')
-	buffer.WriteString(value)
-	buffer.WriteString("] ")
+// rotatingLogFile writes to a log file which is rotated once it exceeds its maximum size
+// The previous log file is kept with the extension .1
+type rotatingLogFile struct {
+	path    string
+	maxSize int64
+	file    *os.File
+	size    int64
+	lock    sync.Mutex
 }
 
-// InitialiseLogging sets up logging
-func InitialiseLogging(logLevel, logFilePath string) {
-	if logLevel == MnLogLevelDefault {
-		log.SetOutput(ioutil.Discard)
+// Open truncates and opens the log file if it is not already open
+func (logFile *rotatingLogFile) Open() (err error) {
+	logFile.lock.Lock()
+	defer logFile.lock.Unlock()
+
+	if logFile.file != nil {
+		return
+	}
+
+	return logFile.open()
+}
+
+func (logFile *rotatingLogFile) open() (err error) {
+	file, err := os.OpenFile(logFile.path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return fmt.Errorf("Unable to open log file %v for writing: %v", logFile.path, err)
+	}
+
+	logFile.file = file
+	logFile.size = 0
+
+	return
+}
+
+// Write writes the log entry to the log file, rotating it first if it would exceed its maximum size
+func (logFile *rotatingLogFile) Write(entry []byte) (written int, err error) {
+	logFile.lock.Lock()
+	defer logFile.lock.Unlock()
+
+	if logFile.file == nil {
+		return len(entry), nil
+	}
+
+	if logFile.size > 0 && logFile.size+int64(len(entry)) > logFile.maxSize {
+		if err = logFile.rotate(); err != nil {
+			return
+		}
+	}
+
+	written, err = logFile.file.Write(entry)
+	logFile.size += int64(written)
+
+	return
+}
+
+func (logFile *rotatingLogFile) rotate() (err error) {
+	logFile.file.Close()
+	logFile.file = nil
+
+	if err = os.Rename(logFile.path, logFile.path+logFileBackupExt); err != nil {
 		return
 	}
 
-	logLevels := map[string]log.Level{
-		"PANIC": log.PanicLevel,
-		"FATAL": log.FatalLevel,
-		"ERROR": log.ErrorLevel,
-		"WARN":  log.WarnLevel,
-		"INFO":  log.InfoLevel,
-		"DEBUG": log.DebugLevel,
+	return logFile.open()
+}
+
+// LogEntry is a log entry recorded for display in the log view
+type LogEntry struct {
+	time    time.Time
+	level   log.Level
+	file    string
+	message string
+	fields  string
+}
+
+// LogBuffer retains the most recent log entries
+type LogBuffer struct {
+	entries []LogEntry
+	start   int
+	size    int
+	lock    sync.Mutex
+}
+
+// NewLogBuffer creates a new instance which retains up to size entries
+func NewLogBuffer(size int) *LogBuffer {
+	return &LogBuffer{
+		entries: make([]LogEntry, size),
+	}
+}
+
+// Fire records the log entry, replacing the oldest entry if the buffer is full
+func (logBuffer *LogBuffer) Fire(entry *log.Entry) (err error) {
+	file, _ := entry.Data[logFieldFile].(string)
+
+	logEntry := LogEntry{
+		time:    entry.Time,
+		level:   entry.Level,
+		file:    file,
+		message: escapeLogMessage(entry.Message),
+		fields:  formatLogFields(entry.Data),
 	}
 
-	if level, ok := logLevels[logLevel]; ok {
-		log.SetLevel(level)
+	logBuffer.lock.Lock()
+	defer logBuffer.lock.Unlock()
+
+	capacity := len(logBuffer.entries)
+
+	if logBuffer.size < capacity {
+		logBuffer.entries[(logBuffer.start+logBuffer.size)%capacity] = logEntry
+		logBuffer.size++
 	} else {
-		log.Fatalf("Invalid logLevel: %v", logLevel)
+		logBuffer.entries[logBuffer.start] = logEntry
+		logBuffer.start = (logBuffer.start + 1) % capacity
 	}
 
-	file, err := os.OpenFile(logFilePath, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
-	if err != nil {
-		log.Fatalf("Unable to open log file %v for writing: %v", logFilePath, err)
+	return
+}
+
+// Levels returns the log levels recorded by the buffer
+func (logBuffer *LogBuffer) Levels() []log.Level {
+	return log.AllLevels
+}
+
+// Entries returns the retained log entries ordered from oldest to newest
+func (logBuffer *LogBuffer) Entries() []LogEntry {
+	logBuffer.lock.Lock()
+	defer logBuffer.lock.Unlock()
+
+	entries := make([]LogEntry, logBuffer.size)
+	capacity := len(logBuffer.entries)
+
+	for index := range entries {
+		entries[index] = logBuffer.entries[(logBuffer.start+index)%capacity]
 	}
 
-	log.SetOutput(file)
+	return entries
+}
 
-	log.SetFormatter(logFormatter{})
+// InitialiseLogging sets up logging
+func InitialiseLogging(logLevel, logFilePath string) {
+	logFile.path = logFilePath
 
+	log.SetFormatter(logFormatter{})
 	log.AddHook(fileHook{})
+	log.AddHook(logEntries)
+
+	if err := SetLogLevel(logLevel); err != nil {
+		log.SetOutput(os.Stderr)
+		log.Fatal(err)
+	}
+}
+
+// SetLogLevel changes the level entries are logged at
+// The log file is opened the first time a level other than NONE is set
+func SetLogLevel(logLevel string) (err error) {
+	logLevel = strings.ToUpper(logLevel)
+
+	if logLevel == MnLogLevelDefault {
+		log.SetLevel(log.PanicLevel)
+		log.SetOutput(ioutil.Discard)
+		return
+	}
+
+	level, ok := logLevels[logLevel]
+	if !ok {
+		return fmt.Errorf("Invalid logLevel: %v", logLevel)
+	}
+
+	if err = logFile.Open(); err != nil {
+		return
+	}
+
+	log.SetOutput(logFile)
+	log.SetLevel(level)
+
+	return
+}
+
+// IsValidLogLevel returns true if the provided log level is recognised
+func IsValidLogLevel(logLevel string) bool {
+	logLevel = strings.ToUpper(logLevel)
+	_, ok := logLevels[logLevel]
+	return ok || logLevel == MnLogLevelDefault
 }
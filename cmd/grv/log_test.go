@@ -0,0 +1,102 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+func TestLogBufferRetainsMostRecentEntries(t *testing.T) {
+	logBuffer := NewLogBuffer(3)
+
+	for _, message := range []string{"one", "two", "three", "four", "five"} {
+		logBuffer.Fire(&log.Entry{Data: log.Fields{}, Message: message})
+	}
+
+	entries := logBuffer.Entries()
+	expectedMessages := []string{"three", "four", "five"}
+
+	if len(entries) != len(expectedMessages) {
+		t.Fatalf("Number of entries does not match expected value. Expected %v, Actual %v", len(expectedMessages), len(entries))
+	}
+
+	for index, expectedMessage := range expectedMessages {
+		if entries[index].message != expectedMessage {
+			t.Errorf("Entry message does not match expected value. Expected %v, Actual %v", expectedMessage, entries[index].message)
+		}
+	}
+}
+
+func TestLogFieldsAreFormattedInKeyOrder(t *testing.T) {
+	fields := log.Fields{
+		logFieldFile: "grv.go:10",
+		"view":       "RefView",
+		"action":     "<grv-next-line>",
+		"message":    "a\nb",
+	}
+
+	expectedFields := `action=<grv-next-line> message=a\nb view=RefView`
+
+	if formattedFields := formatLogFields(fields); formattedFields != expectedFields {
+		t.Errorf("Formatted fields do not match expected value. Expected %v, Actual %v", expectedFields, formattedFields)
+	}
+}
+
+func TestLogFileIsRotatedWhenMaxSizeIsExceeded(t *testing.T) {
+	dir, err := ioutil.TempDir("", "grv-log")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	logFilePath := filepath.Join(dir, "grv.log")
+	logFile := &rotatingLogFile{path: logFilePath, maxSize: 10}
+
+	if err = logFile.Open(); err != nil {
+		t.Fatalf("Unable to open log file: %v", err)
+	}
+
+	for _, entry := range []string{"first\n", "second\n"} {
+		if _, err = logFile.Write([]byte(entry)); err != nil {
+			t.Fatalf("Unable to write log entry: %v", err)
+		}
+	}
+
+	logFile.file.Close()
+
+	expectedContents := map[string]string{
+		logFilePath:                    "second\n",
+		logFilePath + logFileBackupExt: "first\n",
+	}
+
+	for path, expectedContent := range expectedContents {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			t.Errorf("Unable to read %v: %v", path, err)
+		} else if string(content) != expectedContent {
+			t.Errorf("Content of %v does not match expected value. Expected %q, Actual %q", path, expectedContent, string(content))
+		}
+	}
+}
+
+func TestIsValidLogLevel(t *testing.T) {
+	var logLevelTests = []struct {
+		logLevel string
+		valid    bool
+	}{
+		{logLevel: "NONE", valid: true},
+		{logLevel: "debug", valid: true},
+		{logLevel: "Warn", valid: true},
+		{logLevel: "trace", valid: false},
+		{logLevel: "", valid: false},
+	}
+
+	for _, logLevelTest := range logLevelTests {
+		if valid := IsValidLogLevel(logLevelTest.logLevel); valid != logLevelTest.valid {
+			t.Errorf("IsValidLogLevel returned unexpected value for %v. Expected %v, Actual %v", logLevelTest.logLevel, logLevelTest.valid, valid)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"strings"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	lvTimeFormat = "15:04:05.000"
+	lvLevelWidth = 7
+)
+
+type logViewHandler func(*LogView, Action) error
+
+// LogView lists the most recent log entries
+// The view follows new entries while the last entry is selected
+type LogView struct {
+	channels      *Channels
+	config        Config
+	logBuffer     *LogBuffer
+	entries       []LogEntry
+	follow        bool
+	active        bool
+	viewPos       ViewPos
+	viewDimension ViewDimension
+	handlers      map[ActionType]logViewHandler
+	lock          sync.Mutex
+}
+
+// NewLogView creates a new instance
+func NewLogView(channels *Channels, config Config, logBuffer *LogBuffer) *LogView {
+	return &LogView{
+		channels:  channels,
+		config:    config,
+		logBuffer: logBuffer,
+		follow:    true,
+		viewPos:   NewViewPosition(),
+		handlers: map[ActionType]logViewHandler{
+			ActionPrevLine:  moveUpLogEntry,
+			ActionNextLine:  moveDownLogEntry,
+			ActionFirstLine: moveToFirstLogEntry,
+			ActionLastLine:  moveToLastLogEntry,
+		},
+	}
+}
+
+// Initialise does nothing
+func (logView *LogView) Initialise() (err error) {
+	log.Info("Initialising LogView")
+	return
+}
+
+// Render generates and writes the log view to the provided window
+// The log entries are reloaded each time the view is rendered
+func (logView *LogView) Render(win RenderWindow) (err error) {
+	entries := logView.logBuffer.Entries()
+
+	logView.lock.Lock()
+	defer logView.lock.Unlock()
+
+	logView.viewDimension = win.ViewDimensions()
+	logView.entries = entries
+
+	entryNum := uint(len(entries))
+	viewPos := logView.viewPos
+
+	if entryNum > 0 && (logView.follow || viewPos.ActiveRowIndex() >= entryNum) {
+		viewPos.SetActiveRowIndex(entryNum - 1)
+	}
+
+	rows := win.Rows() - 2
+	viewPos.DetermineViewStartRow(rows, entryNum, uint(logView.config.GetInt(CfScrollOff)))
+	entryIndex := viewPos.ViewStartRowIndex()
+	startColumn := viewPos.ViewStartColumn()
+
+	for winRowIndex := uint(0); winRowIndex < rows && entryIndex < entryNum; winRowIndex++ {
+		entry := entries[entryIndex]
+
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(winRowIndex+1, startColumn); err != nil {
+			return
+		}
+
+		lineBuilder.AppendWithStyle(CmpLogviewTime, " %v ", entry.time.Format(lvTimeFormat)).
+			AppendWithStyle(CmpLogviewLevel, " %-*v ", lvLevelWidth, strings.ToUpper(entry.level.String())).
+			AppendWithStyle(CmpLogviewFile, " %v ", entry.file).
+			AppendWithStyle(CmpLogviewMessage, " ").
+			AppendANSI(CmpLogviewMessage, entry.message)
+
+		if entry.fields != "" {
+			lineBuilder.AppendWithStyle(CmpLogviewFields, " %v", entry.fields)
+		}
+
+		entryIndex++
+	}
+
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, logView.active); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	return win.SetTitle(CmpLogviewTitle, "%v", NewTitleBuilder("Log").Detail("%v %v", CfLogLevel, logView.config.GetString(CfLogLevel)))
+}
+
+// RenderStatusBar does nothing
+func (logView *LogView) RenderStatusBar(lineBuilder *LineBuilder) (err error) {
+	return
+}
+
+// RenderHelpBar generates key binding help info for the log view
+func (logView *LogView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(logView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionPrompt, message: "Set Log Level"},
+		{action: ActionNextTab, message: "Next Tab"},
+	})
+
+	return
+}
+
+// HandleKeyPress does nothing
+func (logView *LogView) HandleKeyPress(keystring string) (err error) {
+	log.Debugf("LogView handling key %v - NOP", keystring)
+	return
+}
+
+// HandleAction checks if the log view supports the provided action and executes it if so
+func (logView *LogView) HandleAction(action Action) (err error) {
+	log.Debugf("LogView handling action %v", action)
+	logView.lock.Lock()
+	defer logView.lock.Unlock()
+
+	if handler, ok := logView.handlers[action.ActionType]; ok {
+		err = handler(logView, action)
+	}
+
+	return
+}
+
+// OnActiveChange updates whether the log view is active
+// The view follows new entries again when it becomes active
+func (logView *LogView) OnActiveChange(active bool) {
+	log.Debugf("LogView active: %v", active)
+	logView.lock.Lock()
+	defer logView.lock.Unlock()
+
+	if active && !logView.active {
+		logView.follow = true
+	}
+
+	logView.active = active
+}
+
+// ViewID returns the view ID of the log view
+func (logView *LogView) ViewID() ViewID {
+	return ViewLog
+}
+
+// updateFollow follows new entries if the last entry is selected
+func (logView *LogView) updateFollow() {
+	entryNum := uint(len(logView.entries))
+	logView.follow = entryNum == 0 || logView.viewPos.ActiveRowIndex() == entryNum-1
+}
+
+func moveUpLogEntry(logView *LogView, action Action) (err error) {
+	if logView.viewPos.MoveLinesUp(action.RepeatCount()) {
+		logView.updateFollow()
+		logView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownLogEntry(logView *LogView, action Action) (err error) {
+	if logView.viewPos.MoveLinesDown(action.RepeatCount(), uint(len(logView.entries))) {
+		logView.updateFollow()
+		logView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToFirstLogEntry(logView *LogView, action Action) (err error) {
+	if logView.viewPos.MoveToFirstLine() {
+		logView.updateFollow()
+		logView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToLastLogEntry(logView *LogView, action Action) (err error) {
+	logView.follow = true
+
+	if logView.viewPos.MoveToLastLine(uint(len(logView.entries))) {
+		logView.channels.UpdateDisplay()
+	}
+
+	return
+}
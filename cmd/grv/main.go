@@ -4,30 +4,71 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"syscall"
 
 	log "github.com/Sirupsen/logrus"
 )
 
 const (
 	mnRepoFilePathDefault = "."
+	mnGitDirEnv           = "GIT_DIR"
+	mnGitWorkTreeEnv      = "GIT_WORK_TREE"
 	mnLogFilePathDefault  = "grv.log"
+	mnCloneCommand        = "clone"
+	// mnSyntheticRepoCommand is deliberately not listed in the usage message
+	mnSyntheticRepoCommand = "synthetic-repo"
 	// MnLogLevelDefault is the default log level for grv
 	MnLogLevelDefault = "NONE"
 )
 
 type grvArgs struct {
-	repoFilePath string
-	logLevel     string
-	logFilePath  string
+	repoFilePath      string
+	workTreePath      string
+	logLevel          string
+	logFilePath       string
+	readOnly          bool
+	cpuProfile        string
+	memProfile        string
+	cloneURL          string
+	cloneDir          string
+	syntheticRepo     bool
+	syntheticRepoArgs []string
 }
 
 func main() {
 	args := parseArgs()
 	InitialiseLogging(args.logLevel, args.logFilePath)
 
+	if args.syntheticRepo {
+		if err := GenerateSyntheticRepoFromCommandLine(args.syntheticRepoArgs); err != nil {
+			fmt.Fprintf(os.Stderr, "FATAL: Unable to generate repository: %v\n", err)
+			log.Fatal(err)
+		}
+
+		return
+	}
+
+	if args.cpuProfile != "" {
+		if err := cpuProfiler.Start(args.cpuProfile); err != nil {
+			fmt.Fprintf(os.Stderr, "FATAL: %v\n", err)
+			log.Fatal(err)
+		}
+	}
+
+	if args.cloneURL != "" {
+		repoPath, err := CloneFromCommandLine(args.cloneURL, args.cloneDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "FATAL: Unable to clone %v: %v\n", args.cloneURL, err)
+			log.Fatal(err)
+		}
+
+		args.repoFilePath = repoPath
+		args.workTreePath = ""
+	}
+
 	grv := NewGRV()
 
-	if err := grv.Initialise(args.repoFilePath); err != nil {
+	if err := grv.Initialise(args.repoFilePath, args.workTreePath, args.readOnly, args.logLevel); err != nil {
 		fmt.Fprintf(os.Stderr, "FATAL: Unable to initialise grv: %v\n", err)
 		grv.Free()
 		log.Fatal(err)
@@ -35,21 +76,114 @@ func main() {
 
 	grv.Run()
 
+	grv.SaveRepoPreferences()
 	grv.Free()
 
+	writeProfiles(args)
+
+	if restartPath := grv.RestartPath(); restartPath != "" {
+		restart(args, restartPath)
+	}
+
 	log.Info("Exiting normally")
 }
 
+// writeProfiles stops CPU profiling and writes a memory profile if either were requested
+func writeProfiles(args *grvArgs) {
+	if cpuProfiler.Active() {
+		if _, err := cpuProfiler.Stop(); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to write CPU profile: %v\n", err)
+		}
+	}
+
+	if args.memProfile != "" {
+		if err := WriteMemProfile(args.memProfile); err != nil {
+			fmt.Fprintf(os.Stderr, "%v\n", err)
+		}
+	}
+}
+
+// restart replaces the running process with a new instance of GRV
+// which opens the repository at the provided path
+func restart(args *grvArgs, repoPath string) {
+	executable, err := os.Executable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "FATAL: Unable to restart grv: %v\n", err)
+		log.Fatal(err)
+	}
+
+	restartArgs := []string{
+		os.Args[0],
+		"-repoFilePath", repoPath,
+		"-gitDir", "",
+		"-workTree", "",
+		"-logLevel", args.logLevel,
+		"-logFile", args.logFilePath,
+	}
+
+	if args.readOnly {
+		restartArgs = append(restartArgs, "-read-only")
+	}
+
+	log.Infof("Restarting with arguments %v", restartArgs)
+
+	if err = syscall.Exec(executable, restartArgs, os.Environ()); err != nil {
+		fmt.Fprintf(os.Stderr, "FATAL: Unable to restart grv: %v\n", err)
+		log.Fatal(err)
+	}
+}
+
 func parseArgs() *grvArgs {
 	repoFilePathPtr := flag.String("repoFilePath", mnRepoFilePathDefault, "Repository file path")
 	logLevelPtr := flag.String("logLevel", MnLogLevelDefault, "Logging level [NONE|PANIC|FATAL|ERROR|WARN|INFO|DEBUG]")
 	logFilePathPtr := flag.String("logFile", mnLogFilePathDefault, "Log file path")
+	gitDirPtr := flag.String("gitDir", os.Getenv(mnGitDirEnv), "Git directory path (overrides repoFilePath)")
+	workTreePtr := flag.String("workTree", os.Getenv(mnGitWorkTreeEnv), "Work tree path")
+	readOnlyPtr := flag.Bool("read-only", false, "Disable all actions which modify the repository")
+	cpuProfilePtr := flag.String("cpuprofile", "", "Write a CPU profile to file")
+	memProfilePtr := flag.String("memprofile", "", "Write a memory profile to file on exit")
+
+	flag.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %v [options]\n       %v [options] %v <url> [directory]\n", os.Args[0], os.Args[0], mnCloneCommand)
+		flag.PrintDefaults()
+	}
 
 	flag.Parse()
 
+	repoFilePath := *repoFilePathPtr
+	if *gitDirPtr != "" {
+		repoFilePath = *gitDirPtr
+	}
+
+	var cloneURL, cloneDir string
+	if flag.Arg(0) == mnCloneCommand {
+		if flag.NArg() < 2 || flag.NArg() > 3 {
+			flag.Usage()
+			os.Exit(2)
+		}
+
+		cloneURL = flag.Arg(1)
+		cloneDir = flag.Arg(2)
+	}
+
+	syntheticRepo := flag.Arg(0) == mnSyntheticRepoCommand
+	var syntheticRepoArgs []string
+	if syntheticRepo {
+		syntheticRepoArgs = flag.Args()[1:]
+	}
+
 	return &grvArgs{
-		repoFilePath: *repoFilePathPtr,
+		repoFilePath: repoFilePath,
+		workTreePath: *workTreePtr,
 		logLevel:     *logLevelPtr,
 		logFilePath:  *logFilePathPtr,
+		readOnly:     *readOnlyPtr,
+		cpuProfile:   *cpuProfilePtr,
+		memProfile:   *memProfilePtr,
+		cloneURL:     cloneURL,
+		cloneDir:     cloneDir,
+
+		syntheticRepo:     syntheticRepo,
+		syntheticRepoArgs: syntheticRepoArgs,
 	}
 }
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// NotificationEvent identifies an operation which can trigger a notification
+type NotificationEvent int
+
+// The set of events notifications can be configured for
+const (
+	NeFetchComplete NotificationEvent = iota
+	NeDiffGenerated
+	NeCommitCreated
+)
+
+var notificationEventNames = map[NotificationEvent]string{
+	NeFetchComplete: "fetch",
+	NeDiffGenerated: "diff",
+	NeCommitCreated: "commit",
+}
+
+var notificationEventCommands = map[NotificationEvent]ConfigVariable{
+	NeFetchComplete: CfNotifyFetchCommand,
+	NeDiffGenerated: CfNotifyDiffCommand,
+	NeCommitCreated: CfNotifyCommitCommand,
+}
+
+// Notifier runs the user configured command for an event when an operation completes
+type Notifier struct {
+	config Config
+}
+
+// NewNotifier creates a new notifier which reads commands from the provided config
+func NewNotifier(config Config) *Notifier {
+	return &Notifier{
+		config: config,
+	}
+}
+
+// Notify runs the command configured for the event (if any) in the background
+func (notifier *Notifier) Notify(event NotificationEvent, format string, args ...interface{}) {
+	command := notifier.config.GetString(notificationEventCommands[event])
+	if command == "" {
+		return
+	}
+
+	cmd := notificationCommand(command, event, fmt.Sprintf(format, args...))
+
+	go func() {
+		log.Debugf("Running %v notification command: %v", notificationEventNames[event], command)
+
+		if output, err := cmd.CombinedOutput(); err != nil {
+			log.Errorf("%v notification command failed: %v: %s", notificationEventNames[event], err, output)
+		}
+	}()
+}
+
+// notificationCommand creates a shell command which has the event name and message
+// available in the GRV_NOTIFY_EVENT and GRV_NOTIFY_MESSAGE environment variables
+func notificationCommand(command string, event NotificationEvent, message string) *exec.Cmd {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Env = append(os.Environ(),
+		"GRV_NOTIFY_EVENT="+notificationEventNames[event],
+		"GRV_NOTIFY_MESSAGE="+message,
+	)
+
+	return cmd
+}
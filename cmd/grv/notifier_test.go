@@ -0,0 +1,20 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestNotificationCommandReceivesEventAndMessage(t *testing.T) {
+	cmd := notificationCommand(`printf "%s: %s" "$GRV_NOTIFY_EVENT" "$GRV_NOTIFY_MESSAGE"`, NeFetchComplete, "Fetched remotes")
+
+	output, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("Notification command failed: %v", err)
+	}
+
+	expectedOutput := "fetch: Fetched remotes"
+
+	if string(output) != expectedOutput {
+		t.Errorf("Notification command output does not match expected value. Expected: %v, Actual: %v", expectedOutput, string(output))
+	}
+}
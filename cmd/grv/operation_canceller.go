@@ -0,0 +1,77 @@
+package main
+
+import (
+	"context"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+type cancellableOperation struct {
+	description string
+	cancel      context.CancelFunc
+}
+
+// OperationCanceller tracks long running operations which can be cancelled by the user
+// Operations are cancelled in the reverse order they were started
+type OperationCanceller struct {
+	operations []*cancellableOperation
+	lock       sync.Mutex
+}
+
+// NewOperationCanceller creates a new instance
+func NewOperationCanceller() *OperationCanceller {
+	return &OperationCanceller{}
+}
+
+// Start registers a cancellable operation. The returned context is cancelled if the user cancels
+// the operation and the returned function must be called once the operation has completed
+func (operationCanceller *OperationCanceller) Start(description string) (ctx context.Context, done func()) {
+	ctx, cancel := context.WithCancel(context.Background())
+	operation := &cancellableOperation{
+		description: description,
+		cancel:      cancel,
+	}
+
+	operationCanceller.lock.Lock()
+	operationCanceller.operations = append(operationCanceller.operations, operation)
+	operationCanceller.lock.Unlock()
+
+	done = func() {
+		operationCanceller.remove(operation)
+		cancel()
+	}
+
+	return
+}
+
+// CancelLatest cancels the most recently started operation which is still in progress
+func (operationCanceller *OperationCanceller) CancelLatest() (description string, cancelled bool) {
+	operationCanceller.lock.Lock()
+	defer operationCanceller.lock.Unlock()
+
+	operationNum := len(operationCanceller.operations)
+	if operationNum == 0 {
+		return
+	}
+
+	operation := operationCanceller.operations[operationNum-1]
+	operationCanceller.operations = operationCanceller.operations[:operationNum-1]
+
+	log.Debugf("Cancelling operation: %v", operation.description)
+	operation.cancel()
+
+	return operation.description, true
+}
+
+func (operationCanceller *OperationCanceller) remove(operation *cancellableOperation) {
+	operationCanceller.lock.Lock()
+	defer operationCanceller.lock.Unlock()
+
+	for index, existingOperation := range operationCanceller.operations {
+		if existingOperation == operation {
+			operationCanceller.operations = append(operationCanceller.operations[:index], operationCanceller.operations[index+1:]...)
+			return
+		}
+	}
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCancelLatestCancelsMostRecentlyStartedOperation(t *testing.T) {
+	operationCanceller := NewOperationCanceller()
+
+	fetchCtx, _ := operationCanceller.Start("fetch")
+	searchCtx, _ := operationCanceller.Start("search")
+
+	if description, cancelled := operationCanceller.CancelLatest(); !cancelled || description != "search" {
+		t.Errorf("Expected search to be cancelled. Actual: %v, cancelled: %v", description, cancelled)
+	}
+
+	if searchCtx.Err() == nil {
+		t.Errorf("Expected search context to be cancelled")
+	} else if fetchCtx.Err() != nil {
+		t.Errorf("Expected fetch context to not be cancelled")
+	}
+
+	if description, cancelled := operationCanceller.CancelLatest(); !cancelled || description != "fetch" {
+		t.Errorf("Expected fetch to be cancelled. Actual: %v, cancelled: %v", description, cancelled)
+	}
+
+	if _, cancelled := operationCanceller.CancelLatest(); cancelled {
+		t.Errorf("Expected no operation to be cancelled")
+	}
+}
+
+func TestCompletedOperationsAreNotCancelled(t *testing.T) {
+	operationCanceller := NewOperationCanceller()
+
+	fetchCtx, _ := operationCanceller.Start("fetch")
+	_, searchDone := operationCanceller.Start("search")
+	searchDone()
+
+	if description, cancelled := operationCanceller.CancelLatest(); !cancelled || description != "fetch" {
+		t.Errorf("Expected fetch to be cancelled. Actual: %v, cancelled: %v", description, cancelled)
+	}
+
+	if fetchCtx.Err() == nil {
+		t.Errorf("Expected fetch context to be cancelled")
+	}
+}
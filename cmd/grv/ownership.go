@@ -0,0 +1,261 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	slice "github.com/bradfitz/slice"
+)
+
+const owUpdateInterval = 250 * time.Millisecond
+
+// OwnershipRequest contains the directory to calculate ownership of at a commit
+// An empty directory refers to the whole repository
+type OwnershipRequest struct {
+	commit    *Commit
+	directory string
+}
+
+// AuthorOwnership is the number of lines in a directory last modified by an author
+type AuthorOwnership struct {
+	name  string
+	email string
+	lines uint
+}
+
+// DirectoryOwnership aggregates blame across the files of a directory
+type DirectoryOwnership struct {
+	totalLines     uint
+	filesProcessed uint
+	fileCount      uint
+	authors        map[string]*AuthorOwnership
+}
+
+// NewDirectoryOwnership creates a new instance for a directory containing fileCount files
+func NewDirectoryOwnership(fileCount uint) *DirectoryOwnership {
+	return &DirectoryOwnership{
+		fileCount: fileCount,
+		authors:   make(map[string]*AuthorOwnership),
+	}
+}
+
+// AddFile records the lines of a file attributed to each author by blame
+// Authors are identified by email so name changes do not split their ownership
+func (ownership *DirectoryOwnership) AddFile(hunks []*BlameHunk) {
+	for _, hunk := range hunks {
+		key := strings.ToLower(hunk.email)
+		if key == "" {
+			key = hunk.author
+		}
+
+		author, ok := ownership.authors[key]
+		if !ok {
+			author = &AuthorOwnership{
+				name:  hunk.author,
+				email: hunk.email,
+			}
+
+			ownership.authors[key] = author
+		}
+
+		author.lines += hunk.lineCount
+		ownership.totalLines += hunk.lineCount
+	}
+
+	ownership.filesProcessed++
+}
+
+// RankedAuthors returns the authors ordered by the number of lines they own
+func (ownership *DirectoryOwnership) RankedAuthors() (authors []*AuthorOwnership) {
+	for _, author := range ownership.authors {
+		authors = append(authors, author)
+	}
+
+	slice.Sort(authors, func(i, j int) bool {
+		if authors[i].lines == authors[j].lines {
+			return authors[i].name < authors[j].name
+		}
+
+		return authors[i].lines > authors[j].lines
+	})
+
+	return
+}
+
+// Lines formats the ownership of the directory as text with one author per line
+func (ownership *DirectoryOwnership) Lines(cancelled bool) (lines []string) {
+	status := "Processed"
+	if cancelled {
+		status = "Cancelled after"
+	}
+
+	lines = append(lines,
+		fmt.Sprintf("%v %v of %v files containing %v lines", status, ownership.filesProcessed, ownership.fileCount, ownership.totalLines),
+		"",
+	)
+
+	for _, author := range ownership.RankedAuthors() {
+		percentage := float64(author.lines) * 100 / float64(ownership.totalLines)
+		lines = append(lines, fmt.Sprintf("%6.1f%% %8v  %v <%v>", percentage, author.lines, author.name, author.email))
+	}
+
+	return
+}
+
+// filesInDirectory returns the paths which are contained in the directory or any of its subdirectories
+func filesInDirectory(paths []string, directory string) (directoryPaths []string) {
+	if directory == "" {
+		return paths
+	}
+
+	prefix := strings.TrimSuffix(directory, "/") + "/"
+
+	for _, filePath := range paths {
+		if strings.HasPrefix(filePath, prefix) {
+			directoryPaths = append(directoryPaths, filePath)
+		}
+	}
+
+	return
+}
+
+func ownershipDirectory(filePath string) string {
+	if directory := path.Dir(filePath); directory != "." {
+		return directory
+	}
+
+	return ""
+}
+
+func showDiffDirectoryOwnership(diffView *DiffView, action Action) (err error) {
+	if diffView.lineHistory != nil {
+		return fmt.Errorf("Directory ownership cannot be calculated from line history")
+	}
+
+	filePath, err := diffView.selectedFilePath()
+	if err != nil {
+		return
+	}
+
+	commit := diffView.activeCommit
+	if diffView.revisionDiff != nil {
+		commit = diffView.revisionDiff.toCommit
+	}
+
+	if commit == nil {
+		return fmt.Errorf("No diff loaded")
+	}
+
+	diffView.channels.DoAction(Action{
+		ActionType: ActionShowOwnership,
+		Args: []interface{}{OwnershipRequest{
+			commit:    commit,
+			directory: ownershipDirectory(filePath),
+		}},
+	})
+
+	return
+}
+
+func showFileDirectoryOwnership(fileView *FileView, action Action) (err error) {
+	if fileView.commit == nil {
+		return fmt.Errorf("No file loaded")
+	}
+
+	fileView.channels.DoAction(Action{
+		ActionType: ActionShowOwnership,
+		Args: []interface{}{OwnershipRequest{
+			commit:    fileView.commit,
+			directory: ownershipDirectory(fileView.filePath),
+		}},
+	})
+
+	return
+}
+
+// showOwnership blames each file in the requested directory in the background and displays
+// the share of lines owned by each author in the text tab. The displayed ownership is updated
+// as files are processed and calculating ownership of a different directory cancels any
+// calculation in progress
+func (view *View) showOwnership(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected OwnershipRequest argument")
+	}
+
+	request, ok := action.Args[0].(OwnershipRequest)
+	if !ok {
+		return fmt.Errorf("Expected argument of type OwnershipRequest but found type %T", action.Args[0])
+	}
+
+	paths, err := view.repoData.TreePaths(request.commit)
+	if err != nil {
+		return
+	}
+
+	paths = filesInDirectory(paths, request.directory)
+	if len(paths) == 0 {
+		return fmt.Errorf("No files found in %v", request.directory)
+	}
+
+	directory := request.directory
+	if directory == "" {
+		directory = "/"
+	}
+
+	title := fmt.Sprintf("Ownership of %v at %v", directory, view.repoData.ShortID(request.commit.oid))
+	ctx, done := view.canceller.Start("directory ownership")
+
+	view.lock.Lock()
+	if view.ownershipDone != nil {
+		view.ownershipDone()
+	}
+	view.ownershipDone = done
+	view.ownershipGeneration++
+	generation := view.ownershipGeneration
+	view.lock.Unlock()
+
+	ownership := NewDirectoryOwnership(uint(len(paths)))
+	view.showText(title, ownership.Lines(false))
+
+	update := func(cancelled bool) {
+		view.lock.Lock()
+		current := generation == view.ownershipGeneration
+		view.lock.Unlock()
+
+		if current {
+			view.updateText(title, ownership.Lines(cancelled))
+		}
+	}
+
+	go func() {
+		defer done()
+		lastUpdate := time.Now()
+
+		for _, filePath := range paths {
+			if ctx.Err() != nil {
+				update(true)
+				return
+			}
+
+			hunks, err := view.repoData.Blame(request.commit, filePath)
+			if err != nil {
+				log.Debugf("Unable to blame %v: %v", filePath, err)
+			}
+
+			ownership.AddFile(hunks)
+
+			if time.Since(lastUpdate) >= owUpdateInterval {
+				update(false)
+				lastUpdate = time.Now()
+			}
+		}
+
+		update(false)
+		view.channels.ReportStatus("Calculated ownership of %v from %v files", directory, len(paths))
+	}()
+
+	return
+}
@@ -0,0 +1,59 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestDirectoryOwnershipRanksAuthorsByLines(t *testing.T) {
+	ownership := NewDirectoryOwnership(3)
+	ownership.AddFile([]*BlameHunk{
+		{author: "Alice", email: "alice@example.com", lineCount: 10},
+		{author: "Bob", email: "bob@example.com", lineCount: 5},
+	})
+	ownership.AddFile([]*BlameHunk{
+		{author: "Alice Smith", email: "Alice@example.com", lineCount: 5},
+		{author: "Carol", email: "carol@example.com", lineCount: 5},
+	})
+
+	expectedLines := []string{
+		"Processed 2 of 3 files containing 25 lines",
+		"",
+		"  60.0%       15  Alice <alice@example.com>",
+		"  20.0%        5  Bob <bob@example.com>",
+		"  20.0%        5  Carol <carol@example.com>",
+	}
+
+	if lines := ownership.Lines(false); !reflect.DeepEqual(expectedLines, lines) {
+		t.Errorf("Ownership lines do not match expected value. Expected: %q, Actual: %q", expectedLines, lines)
+	}
+}
+
+func TestFilesInDirectory(t *testing.T) {
+	paths := []string{"README.md", "cmd/grv/main.go", "cmd/grv/view.go", "cmd/grvx/main.go", "doc/documentation.md"}
+
+	var directoryTests = []struct {
+		directory     string
+		expectedPaths []string
+	}{
+		{
+			directory:     "cmd/grv",
+			expectedPaths: []string{"cmd/grv/main.go", "cmd/grv/view.go"},
+		},
+		{
+			directory:     "cmd",
+			expectedPaths: []string{"cmd/grv/main.go", "cmd/grv/view.go", "cmd/grvx/main.go"},
+		},
+		{
+			directory:     "",
+			expectedPaths: paths,
+		},
+	}
+
+	for _, directoryTest := range directoryTests {
+		if directoryPaths := filesInDirectory(paths, directoryTest.directory); !reflect.DeepEqual(directoryTest.expectedPaths, directoryPaths) {
+			t.Errorf("Paths do not match expected value for directory %q. Expected: %v, Actual: %v",
+				directoryTest.directory, directoryTest.expectedPaths, directoryPaths)
+		}
+	}
+}
@@ -0,0 +1,138 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	pgrDefaultPager = "less -R"
+)
+
+// ResolvePager determines the pager command in the same order of precedence as git:
+// GIT_PAGER, core.pager and then PAGER, falling back to less
+func ResolvePager(corePager string, getenv func(string) string) string {
+	if pager := getenv("GIT_PAGER"); pager != "" {
+		return pager
+	}
+
+	if corePager != "" {
+		return corePager
+	}
+
+	if pager := getenv("PAGER"); pager != "" {
+		return pager
+	}
+
+	return pgrDefaultPager
+}
+
+func pageFullDiff(diffView *DiffView, action Action) (err error) {
+	return diffView.pageDiff(false)
+}
+
+func pageSelectedFileDiff(diffView *DiffView, action Action) (err error) {
+	return diffView.pageDiff(true)
+}
+
+// pageDiff pipes the diff of the displayed commit or revisions to the pager, suspending the display while
+// the pager runs. If selectedFileOnly is true then only the diff of the selected file is paged.
+// The diff view lock must be held when calling this method
+func (diffView *DiffView) pageDiff(selectedFileOnly bool) (err error) {
+	if diffView.lineHistory != nil {
+		return fmt.Errorf("Line history cannot be paged")
+	}
+
+	var filePath string
+	if selectedFileOnly {
+		if filePath, err = diffView.selectedFilePath(); err != nil {
+			return
+		}
+	}
+
+	generateDiff, err := diffView.displayedDiffGenerator()
+	if err != nil {
+		return
+	}
+
+	pager := diffView.config.GetString(CfPager)
+	if pager == "" {
+		pager = diffView.repoData.Pager()
+	}
+
+	ctx, done := diffView.canceller.Start("diff paging")
+
+	go func() {
+		defer done()
+
+		diff, err := generateDiff(ctx)
+		if ctx.Err() != nil {
+			log.Debugf("Generating the diff to page was cancelled")
+			return
+		} else if err != nil {
+			diffView.channels.ReportError(err)
+			return
+		}
+
+		text := diff.diffText.String()
+
+		if filePath != "" {
+			text = ""
+
+			for _, diffFile := range diff.files {
+				if diffFile.newPath == filePath {
+					text = diffFile.patch.String()
+					break
+				}
+			}
+		}
+
+		if text == "" {
+			diffView.channels.ReportStatus("Diff is empty")
+			return
+		}
+
+		diffView.channels.DoAction(Action{
+			ActionType: ActionRunCommand,
+			Args: []interface{}{CommandRequest{
+				cmd:         yankCommand(pager, text),
+				description: "Pager",
+				onComplete: func(err error) {
+					if err != nil {
+						diffView.channels.ReportError(err)
+					}
+				},
+			}},
+		})
+	}()
+
+	return
+}
+
+// displayedDiffGenerator returns a function which generates the raw diff of the displayed commit or revisions
+// The diff view lock must be held when calling this method
+func (diffView *DiffView) displayedDiffGenerator() (generateDiff func(context.Context) (*Diff, error), err error) {
+	diffOptions := diffView.diffOptions()
+
+	if revisionDiff := diffView.revisionDiff; revisionDiff != nil {
+		var fromCommit *Commit
+		if fromCommit, err = diffView.repoData.CommitByRevision(revisionDiff.fromRevision); err != nil {
+			return
+		}
+
+		return func(ctx context.Context) (*Diff, error) {
+			return diffView.repoData.DiffCommits(ctx, fromCommit, revisionDiff.toCommit, diffOptions)
+		}, nil
+	}
+
+	commit := diffView.activeCommit
+	if commit == nil {
+		return nil, fmt.Errorf("No diff loaded")
+	}
+
+	return func(ctx context.Context) (*Diff, error) {
+		return diffView.repoData.Diff(ctx, commit, diffOptions)
+	}, nil
+}
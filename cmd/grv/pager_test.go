@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestResolvePager(t *testing.T) {
+	var resolveTests = []struct {
+		env           map[string]string
+		corePager     string
+		expectedPager string
+	}{
+		{expectedPager: pgrDefaultPager},
+		{env: map[string]string{"PAGER": "more"}, expectedPager: "more"},
+		{env: map[string]string{"PAGER": "more"}, corePager: "delta", expectedPager: "delta"},
+		{env: map[string]string{"PAGER": "more", "GIT_PAGER": "bat"}, corePager: "delta", expectedPager: "bat"},
+	}
+
+	for _, resolveTest := range resolveTests {
+		getenv := func(name string) string {
+			return resolveTest.env[name]
+		}
+
+		if pager := ResolvePager(resolveTest.corePager, getenv); pager != resolveTest.expectedPager {
+			t.Errorf("Pager does not match expected value. Expected: %v, Actual: %v", resolveTest.expectedPager, pager)
+		}
+	}
+}
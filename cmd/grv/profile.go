@@ -0,0 +1,148 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	slice "github.com/bradfitz/slice"
+)
+
+const (
+	pfCPUProfileDefaultPath = "grv-cpu.prof"
+	pfTotalRenderTiming     = "total"
+)
+
+var cpuProfiler = &CPUProfiler{}
+var renderTimings = NewRenderTimings()
+
+// CPUProfiler writes a CPU profile to a file between calls to Start and Stop
+type CPUProfiler struct {
+	file *os.File
+	lock sync.Mutex
+}
+
+// Start begins writing a CPU profile to the provided file
+func (cpuProfiler *CPUProfiler) Start(filePath string) (err error) {
+	cpuProfiler.lock.Lock()
+	defer cpuProfiler.lock.Unlock()
+
+	if cpuProfiler.file != nil {
+		return fmt.Errorf("CPU profiling is already writing to %v", cpuProfiler.file.Name())
+	}
+
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("Unable to create CPU profile %v: %v", filePath, err)
+	}
+
+	if err = pprof.StartCPUProfile(file); err != nil {
+		file.Close()
+		return fmt.Errorf("Unable to start CPU profiling: %v", err)
+	}
+
+	log.Infof("Started CPU profiling to %v", filePath)
+	cpuProfiler.file = file
+
+	return
+}
+
+// Stop finishes writing the CPU profile and returns the file it was written to
+func (cpuProfiler *CPUProfiler) Stop() (filePath string, err error) {
+	cpuProfiler.lock.Lock()
+	defer cpuProfiler.lock.Unlock()
+
+	if cpuProfiler.file == nil {
+		return "", fmt.Errorf("CPU profiling has not been started")
+	}
+
+	pprof.StopCPUProfile()
+
+	filePath = cpuProfiler.file.Name()
+	err = cpuProfiler.file.Close()
+	cpuProfiler.file = nil
+
+	log.Infof("Stopped CPU profiling to %v", filePath)
+
+	return
+}
+
+// Active returns true if a CPU profile is being written
+func (cpuProfiler *CPUProfiler) Active() bool {
+	cpuProfiler.lock.Lock()
+	defer cpuProfiler.lock.Unlock()
+
+	return cpuProfiler.file != nil
+}
+
+// WriteMemProfile writes a heap profile to the provided file
+func WriteMemProfile(filePath string) (err error) {
+	file, err := os.Create(filePath)
+	if err != nil {
+		return fmt.Errorf("Unable to create memory profile %v: %v", filePath, err)
+	}
+
+	runtime.GC()
+
+	if err = pprof.WriteHeapProfile(file); err != nil {
+		file.Close()
+		return fmt.Errorf("Unable to write memory profile: %v", err)
+	}
+
+	return file.Close()
+}
+
+// RenderTiming is the time taken to render a window
+type RenderTiming struct {
+	name     string
+	duration time.Duration
+}
+
+// RenderTimings records the time taken to render each window the last time it was rendered
+type RenderTimings struct {
+	durations map[string]time.Duration
+	lock      sync.Mutex
+}
+
+// NewRenderTimings creates a new instance
+func NewRenderTimings() *RenderTimings {
+	return &RenderTimings{
+		durations: make(map[string]time.Duration),
+	}
+}
+
+// Record stores the time taken to render the named window since the provided start time
+func (renderTimings *RenderTimings) Record(name string, start time.Time) {
+	duration := time.Since(start)
+
+	renderTimings.lock.Lock()
+	defer renderTimings.lock.Unlock()
+
+	renderTimings.durations[name] = duration
+}
+
+// Timings returns the recorded timings ordered by name with the total last
+func (renderTimings *RenderTimings) Timings() (timings []RenderTiming) {
+	renderTimings.lock.Lock()
+	defer renderTimings.lock.Unlock()
+
+	for name, duration := range renderTimings.durations {
+		if name != pfTotalRenderTiming {
+			timings = append(timings, RenderTiming{name: name, duration: duration})
+		}
+	}
+
+	slice.Sort(timings, func(i, j int) bool {
+		return timings[i].name < timings[j].name
+	})
+
+	if duration, ok := renderTimings.durations[pfTotalRenderTiming]; ok {
+		timings = append(timings, RenderTiming{name: pfTotalRenderTiming, duration: duration})
+	}
+
+	return
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRenderTimingsAreOrderedByNameWithTotalLast(t *testing.T) {
+	renderTimings := NewRenderTimings()
+	start := time.Now()
+
+	for _, name := range []string{pfTotalRenderTiming, "refView", "diffView", "commitView", "refView"} {
+		renderTimings.Record(name, start)
+	}
+
+	timings := renderTimings.Timings()
+	expectedNames := []string{"commitView", "diffView", "refView", pfTotalRenderTiming}
+
+	if len(timings) != len(expectedNames) {
+		t.Fatalf("Number of timings does not match expected value. Expected %v, Actual %v", len(expectedNames), len(timings))
+	}
+
+	for index, expectedName := range expectedNames {
+		if timings[index].name != expectedName {
+			t.Errorf("Timing name does not match expected value. Expected %v, Actual %v", expectedName, timings[index].name)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ProgressID identifies a long running operation
+type ProgressID int
+
+// The set of long running operations which report progress
+const (
+	PgLoadCommits ProgressID = iota
+	PgLoadBranches
+	PgLoadTags
+	PgFetch
+	PgGenerateDiff
+	PgFindUnreachable
+	PgCompareBranches
+	PgGenerateRevisionDiff
+)
+
+const (
+	pgRefreshRate = 250 * time.Millisecond
+	pgBarWidth    = 20
+)
+
+var byteCountUnits = []string{"KiB", "MiB", "GiB", "TiB"}
+
+var progressSpinnerFrames = []string{"|", "/", "-", "\\"}
+
+type progressState struct {
+	description string
+	current     uint
+	total       uint
+}
+
+// ProgressTracker records the progress of long running operations performed by a view.
+// While any operation is in progress the display is refreshed periodically to animate a spinner
+type ProgressTracker struct {
+	channels   *Channels
+	operations map[ProgressID]*progressState
+	frame      int
+	ticker     *time.Ticker
+	cancelCh   chan bool
+	lock       sync.Mutex
+}
+
+// NewProgressTracker creates a new instance
+func NewProgressTracker(channels *Channels) *ProgressTracker {
+	return &ProgressTracker{
+		channels:   channels,
+		operations: make(map[ProgressID]*progressState),
+	}
+}
+
+// Start records the operation as in progress
+// Starting an operation which is already in progress resets its progress
+func (progressTracker *ProgressTracker) Start(progressID ProgressID, description string) {
+	progressTracker.lock.Lock()
+	defer progressTracker.lock.Unlock()
+
+	progressTracker.operations[progressID] = &progressState{
+		description: description,
+	}
+
+	if progressTracker.ticker == nil {
+		progressTracker.startRefresh()
+	}
+
+	progressTracker.channels.UpdateDisplay()
+}
+
+// Update sets the progress of an operation. If total is 0 then the total amount of
+// work is unknown and current is displayed as a count rather than a percentage
+func (progressTracker *ProgressTracker) Update(progressID ProgressID, current, total uint) {
+	progressTracker.lock.Lock()
+	defer progressTracker.lock.Unlock()
+
+	if operation, ok := progressTracker.operations[progressID]; ok {
+		operation.current = current
+		operation.total = total
+	}
+}
+
+// Stop records the operation as complete
+func (progressTracker *ProgressTracker) Stop(progressID ProgressID) {
+	progressTracker.lock.Lock()
+	defer progressTracker.lock.Unlock()
+
+	if _, ok := progressTracker.operations[progressID]; !ok {
+		return
+	}
+
+	delete(progressTracker.operations, progressID)
+
+	if len(progressTracker.operations) == 0 {
+		progressTracker.stopRefresh()
+	}
+
+	progressTracker.channels.UpdateDisplay()
+}
+
+// Active returns true if the operation is in progress
+func (progressTracker *ProgressTracker) Active(progressID ProgressID) bool {
+	progressTracker.lock.Lock()
+	defer progressTracker.lock.Unlock()
+
+	_, ok := progressTracker.operations[progressID]
+	return ok
+}
+
+// String describes all operations in progress prefixed by a spinner
+// An empty string is returned when no operations are in progress
+func (progressTracker *ProgressTracker) String() string {
+	progressTracker.lock.Lock()
+	defer progressTracker.lock.Unlock()
+
+	if len(progressTracker.operations) == 0 {
+		return ""
+	}
+
+	var progressIDs []int
+	for progressID := range progressTracker.operations {
+		progressIDs = append(progressIDs, int(progressID))
+	}
+
+	sort.Ints(progressIDs)
+
+	var descriptions []string
+	for _, progressID := range progressIDs {
+		descriptions = append(descriptions, progressTracker.operations[ProgressID(progressID)].String())
+	}
+
+	spinner := progressSpinnerFrames[progressTracker.frame%len(progressSpinnerFrames)]
+
+	return fmt.Sprintf("%v %v", spinner, strings.Join(descriptions, ", "))
+}
+
+func (operation *progressState) String() string {
+	switch {
+	case operation.total > 0:
+		return fmt.Sprintf("%v %v%%", operation.description, Min(operation.current, operation.total)*100/operation.total)
+	case operation.current > 0:
+		return fmt.Sprintf("%v (%v)", operation.description, operation.current)
+	default:
+		return operation.description
+	}
+}
+
+func (progressTracker *ProgressTracker) startRefresh() {
+	ticker := time.NewTicker(pgRefreshRate)
+	cancelCh := make(chan bool)
+
+	progressTracker.ticker = ticker
+	progressTracker.cancelCh = cancelCh
+
+	go func() {
+		for {
+			select {
+			case <-ticker.C:
+				progressTracker.lock.Lock()
+				progressTracker.frame++
+				progressTracker.lock.Unlock()
+
+				progressTracker.channels.UpdateDisplay()
+			case <-cancelCh:
+				return
+			}
+		}
+	}()
+}
+
+func (progressTracker *ProgressTracker) stopRefresh() {
+	if progressTracker.ticker != nil {
+		progressTracker.ticker.Stop()
+		close(progressTracker.cancelCh)
+		progressTracker.ticker = nil
+	}
+}
+
+// TransferProgress describes the progress of transferring objects from a remote
+type TransferProgress struct {
+	remoteName      string
+	receivedObjects uint
+	indexedObjects  uint
+	totalObjects    uint
+	receivedBytes   uint
+}
+
+// TransferProgressBar renders the progress of a transfer as a progress bar followed by the
+// number of objects processed and the amount of data received. Objects are received and then
+// indexed, which includes resolving deltas, so the bar is filled once for each phase
+func TransferProgressBar(progress TransferProgress, barWidth uint) string {
+	phase := "receiving"
+	current := progress.receivedObjects
+
+	if progress.totalObjects > 0 && progress.receivedObjects >= progress.totalObjects {
+		phase = "resolving"
+		current = progress.indexedObjects
+	}
+
+	var filled, percent uint
+	if progress.totalObjects > 0 {
+		current = Min(current, progress.totalObjects)
+		filled = current * barWidth / progress.totalObjects
+		percent = current * 100 / progress.totalObjects
+	}
+
+	return fmt.Sprintf("%v %v [%v%v] %3v%% %v/%v objects %v", progress.remoteName, phase,
+		strings.Repeat("=", int(filled)), strings.Repeat(" ", int(barWidth-filled)),
+		percent, current, progress.totalObjects, formatByteCount(progress.receivedBytes))
+}
+
+// formatByteCount formats a number of bytes using binary units
+func formatByteCount(bytes uint) string {
+	if bytes < 1024 {
+		return fmt.Sprintf("%v B", bytes)
+	}
+
+	value := float64(bytes) / 1024
+	unitIndex := 0
+
+	for value >= 1024 && unitIndex < len(byteCountUnits)-1 {
+		value /= 1024
+		unitIndex++
+	}
+
+	return fmt.Sprintf("%.1f %v", value, byteCountUnits[unitIndex])
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestProgressTrackerDescribesOperationsInProgress(t *testing.T) {
+	progressTracker := NewProgressTracker(&Channels{})
+
+	if description := progressTracker.String(); description != "" {
+		t.Errorf("Expected no description when no operations are in progress. Actual: %v", description)
+	}
+
+	progressTracker.Start(PgFetch, "Fetching remotes")
+	progressTracker.Start(PgLoadCommits, "Loading commits")
+	progressTracker.Update(PgLoadCommits, 1500, 0)
+	progressTracker.Update(PgFetch, 45, 90)
+
+	expectedDescription := "| Loading commits (1500), Fetching remotes 50%"
+	if description := progressTracker.String(); description != expectedDescription {
+		t.Errorf("Description does not match expected value. Expected: %v, Actual: %v", expectedDescription, description)
+	}
+
+	progressTracker.Stop(PgLoadCommits)
+
+	if progressTracker.Active(PgLoadCommits) {
+		t.Errorf("Expected stopped operation to no longer be active")
+	}
+
+	expectedDescription = "| Fetching remotes 50%"
+	if description := progressTracker.String(); description != expectedDescription {
+		t.Errorf("Description does not match expected value. Expected: %v, Actual: %v", expectedDescription, description)
+	}
+
+	progressTracker.Stop(PgFetch)
+
+	if description := progressTracker.String(); description != "" {
+		t.Errorf("Expected no description once all operations are complete. Actual: %v", description)
+	}
+}
+
+func TestProgressTrackerUpdateIgnoresOperationsNotInProgress(t *testing.T) {
+	progressTracker := NewProgressTracker(&Channels{})
+	progressTracker.Update(PgGenerateDiff, 1, 2)
+
+	if progressTracker.Active(PgGenerateDiff) {
+		t.Errorf("Expected update to not start an operation")
+	}
+}
+
+func TestTransferProgressBar(t *testing.T) {
+	var progressBarTests = []struct {
+		progress            TransferProgress
+		expectedProgressBar string
+	}{
+		{
+			progress:            TransferProgress{remoteName: "origin"},
+			expectedProgressBar: "origin receiving [          ]   0% 0/0 objects 0 B",
+		},
+		{
+			progress:            TransferProgress{remoteName: "origin", receivedObjects: 50, totalObjects: 200, receivedBytes: 1536},
+			expectedProgressBar: "origin receiving [==        ]  25% 50/200 objects 1.5 KiB",
+		},
+		{
+			progress:            TransferProgress{remoteName: "origin", receivedObjects: 200, indexedObjects: 100, totalObjects: 200, receivedBytes: 3 * 1024 * 1024},
+			expectedProgressBar: "origin resolving [=====     ]  50% 100/200 objects 3.0 MiB",
+		},
+		{
+			progress:            TransferProgress{remoteName: "upstream", receivedObjects: 200, indexedObjects: 200, totalObjects: 200, receivedBytes: 512},
+			expectedProgressBar: "upstream resolving [==========] 100% 200/200 objects 512 B",
+		},
+	}
+
+	for _, progressBarTest := range progressBarTests {
+		if progressBar := TransferProgressBar(progressBarTest.progress, 10); progressBar != progressBarTest.expectedProgressBar {
+			t.Errorf("Progress bar does not match expected value. Expected: %q, Actual: %q", progressBarTest.expectedProgressBar, progressBar)
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// parseProtectedRefPatterns splits the space separated protectedrefs value into patterns
+// and checks each pattern is well formed
+func parseProtectedRefPatterns(value string) (patterns []string, err error) {
+	for _, pattern := range strings.Fields(value) {
+		if _, err = path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("Invalid protected ref pattern %v: %v", pattern, err)
+		}
+
+		patterns = append(patterns, pattern)
+	}
+
+	return
+}
+
+// matchesProtectedRefPattern returns true if the ref name matches any of the patterns
+func matchesProtectedRefPattern(patterns []string, refName string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, refName); matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsProtectedRef returns true if the ref name matches a pattern configured by protectedrefs
+func IsProtectedRef(config Config, refName string) bool {
+	patterns, _ := parseProtectedRefPatterns(config.GetString(CfProtectedRefs))
+	return matchesProtectedRefPattern(patterns, refName)
+}
+
+// protectedRefConfirmation creates a dialog which requires the name of the protected
+// ref to be typed before the action is performed
+func protectedRefConfirmation(refName, description string, action Action) DialogRequest {
+	return DialogRequest{
+		dialogType:       DtTypedConfirmation,
+		title:            "Protected Ref",
+		message:          fmt.Sprintf("%v matches protectedrefs. Are you sure you want to %v?", refName, description),
+		confirmationText: refName,
+		action:           action,
+	}
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestParseProtectedRefPatternsRejectsMalformedPatterns(t *testing.T) {
+	var parseTests = []struct {
+		value            string
+		expectedPatterns []string
+		expectError      bool
+	}{
+		{value: "", expectedPatterns: nil},
+		{value: "master main release/*", expectedPatterns: []string{"master", "main", "release/*"}},
+		{value: "  master   main ", expectedPatterns: []string{"master", "main"}},
+		{value: "master release/[", expectError: true},
+	}
+
+	for _, parseTest := range parseTests {
+		patterns, err := parseProtectedRefPatterns(parseTest.value)
+
+		if parseTest.expectError {
+			if err == nil {
+				t.Errorf("Expected error for value %q but none was returned", parseTest.value)
+			}
+
+			continue
+		} else if err != nil {
+			t.Errorf("Unexpected error for value %q: %v", parseTest.value, err)
+			continue
+		}
+
+		if len(patterns) != len(parseTest.expectedPatterns) {
+			t.Errorf("Pattern count does not match expected value for value %q. Expected: %v, Actual: %v",
+				parseTest.value, parseTest.expectedPatterns, patterns)
+			continue
+		}
+
+		for patternIndex, pattern := range patterns {
+			if pattern != parseTest.expectedPatterns[patternIndex] {
+				t.Errorf("Pattern at index %v does not match expected value. Expected: %v, Actual: %v",
+					patternIndex, parseTest.expectedPatterns[patternIndex], pattern)
+			}
+		}
+	}
+}
+
+func TestMatchesProtectedRefPattern(t *testing.T) {
+	patterns := []string{"master", "main", "release/*"}
+
+	var matchTests = []struct {
+		refName         string
+		expectedMatched bool
+	}{
+		{refName: "master", expectedMatched: true},
+		{refName: "main", expectedMatched: true},
+		{refName: "release/1.0", expectedMatched: true},
+		{refName: "release/1.0/hotfix", expectedMatched: false},
+		{refName: "feature/master", expectedMatched: false},
+		{refName: "mainline", expectedMatched: false},
+	}
+
+	for _, matchTest := range matchTests {
+		if matched := matchesProtectedRefPattern(patterns, matchTest.refName); matched != matchTest.expectedMatched {
+			t.Errorf("Match result does not match expected value for ref %v. Expected: %v, Actual: %v",
+				matchTest.refName, matchTest.expectedMatched, matched)
+		}
+	}
+}
@@ -332,12 +332,18 @@ func isLetterOrNumber(char rune) bool {
 	return unicode.IsLetter(char) || unicode.IsNumber(char)
 }
 
+// isIdentifierChar returns true if the character can follow the first letter of an identifier
+// Dots and hyphens are permitted so that fields such as trailer.reviewed-by can be referenced
+func isIdentifierChar(char rune) bool {
+	return isLetterOrNumber(char) || char == '.' || char == '-' || char == '_'
+}
+
 func (scanner *QueryScanner) scanWhiteSpace() (token *QueryToken, err error) {
 	return scanner.scanToken(QtkWhiteSpace, unicode.IsSpace)
 }
 
 func (scanner *QueryScanner) scanIdentifier() (token *QueryToken, err error) {
-	return scanner.scanToken(QtkIdentifier, isLetterOrNumber)
+	return scanner.scanToken(QtkIdentifier, isIdentifierChar)
 }
 
 func (scanner *QueryScanner) scanNumber() (token *QueryToken, err error) {
@@ -55,6 +55,21 @@ func TestScanSingleQueryToken(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: "trailer.reviewed-by",
+			expectedToken: QueryToken{
+				tokenType: QtkIdentifier,
+				value:     "trailer.reviewed-by",
+				startPos: QueryScannerPos{
+					line: 1,
+					col:  1,
+				},
+				endPos: QueryScannerPos{
+					line: 1,
+					col:  19,
+				},
+			},
+		},
 		{
 			input: "1234",
 			expectedToken: QueryToken{
@@ -0,0 +1,338 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	qvMaxRows = 10
+)
+
+type quickfixViewHandler func(*QuickfixView, Action) error
+
+// QuickfixEntry is a location in the lines of a view listed in the quickfix view
+type QuickfixEntry struct {
+	source    interface{}
+	lineIndex uint
+	location  string
+	text      string
+}
+
+// QuickfixList is a titled list of locations
+type QuickfixList struct {
+	title   string
+	entries []QuickfixEntry
+}
+
+// QuickfixView lists locations such as diff hunks or search matches.
+// Selecting an entry jumps to its location
+type QuickfixView struct {
+	channels      *Channels
+	config        Config
+	list          *QuickfixList
+	active        bool
+	viewPos       ViewPos
+	viewDimension ViewDimension
+	handlers      map[ActionType]quickfixViewHandler
+	lock          sync.Mutex
+}
+
+// NewQuickfixView creates a new instance
+func NewQuickfixView(channels *Channels, config Config) *QuickfixView {
+	return &QuickfixView{
+		channels: channels,
+		config:   config,
+		viewPos:  NewViewPosition(),
+		handlers: map[ActionType]quickfixViewHandler{
+			ActionPrevLine:  moveUpQuickfixEntry,
+			ActionNextLine:  moveDownQuickfixEntry,
+			ActionFirstLine: moveToFirstQuickfixEntry,
+			ActionLastLine:  moveToLastQuickfixEntry,
+			ActionSelect:    selectQuickfixEntry,
+		},
+	}
+}
+
+// Initialise does nothing
+func (quickfixView *QuickfixView) Initialise() (err error) {
+	log.Info("Initialising QuickfixView")
+	return
+}
+
+// SetList replaces the displayed list and selects its first entry
+func (quickfixView *QuickfixView) SetList(list *QuickfixList) {
+	quickfixView.lock.Lock()
+	defer quickfixView.lock.Unlock()
+
+	quickfixView.list = list
+	quickfixView.viewPos = NewViewPosition()
+
+	quickfixView.channels.UpdateDisplay()
+}
+
+// HasList returns true if a list has been set
+func (quickfixView *QuickfixView) HasList() bool {
+	quickfixView.lock.Lock()
+	defer quickfixView.lock.Unlock()
+
+	return quickfixView.list != nil
+}
+
+// MoveEntry selects the entry the provided number of entries after (or before if negative)
+// the selected entry and returns it
+func (quickfixView *QuickfixView) MoveEntry(delta int) (entry QuickfixEntry, err error) {
+	quickfixView.lock.Lock()
+	defer quickfixView.lock.Unlock()
+
+	if quickfixView.list == nil || len(quickfixView.list.entries) == 0 {
+		err = fmt.Errorf("No quickfix list")
+		return
+	}
+
+	lineNum := uint(len(quickfixView.list.entries))
+	viewPos := quickfixView.viewPos
+
+	var moved bool
+	if delta < 0 {
+		moved = viewPos.MoveLinesUp(uint(-delta))
+	} else {
+		moved = viewPos.MoveLinesDown(uint(delta), lineNum)
+	}
+
+	if !moved {
+		err = fmt.Errorf("No more items")
+		return
+	}
+
+	quickfixView.channels.UpdateDisplay()
+
+	return quickfixView.list.entries[viewPos.ActiveRowIndex()], nil
+}
+
+// Render generates and writes the quickfix view to the provided window
+func (quickfixView *QuickfixView) Render(win RenderWindow) (err error) {
+	log.Debug("Rendering QuickfixView")
+	quickfixView.lock.Lock()
+	defer quickfixView.lock.Unlock()
+
+	quickfixView.viewDimension = win.ViewDimensions()
+
+	var title string
+	var entries []QuickfixEntry
+	if quickfixView.list != nil {
+		title = quickfixView.list.title
+		entries = quickfixView.list.entries
+	}
+
+	lineNum := uint(len(entries))
+	rows := win.Rows() - 2
+	viewPos := quickfixView.viewPos
+	viewPos.DetermineViewStartRow(rows, lineNum, uint(quickfixView.config.GetInt(CfScrollOff)))
+	lineIndex := viewPos.ViewStartRowIndex()
+	startColumn := viewPos.ViewStartColumn()
+
+	for winRowIndex := uint(0); winRowIndex < rows && lineIndex < lineNum; winRowIndex++ {
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(winRowIndex+1, startColumn); err != nil {
+			return
+		}
+
+		entry := entries[lineIndex]
+		lineBuilder.
+			AppendWithStyle(CmpQuickfixviewLine, " ").
+			AppendWithStyle(CmpQuickfixviewLocation, "%v", entry.location).
+			AppendWithStyle(CmpQuickfixviewLine, " %v", entry.text)
+
+		lineIndex++
+	}
+
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, quickfixView.active); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	return win.SetTitle(CmpQuickfixviewTitle, "%v", NewTitleBuilder("Quickfix").
+		Breadcrumb("%v", title).
+		Detail("Entry %v of %v", viewPos.ActiveRowIndex()+1, lineNum))
+}
+
+// RenderStatusBar does nothing
+func (quickfixView *QuickfixView) RenderStatusBar(lineBuilder *LineBuilder) (err error) {
+	return
+}
+
+// RenderHelpBar generates key binding help info for the quickfix view
+func (quickfixView *QuickfixView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(quickfixView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionSelect, message: "Jump To Entry"},
+		{action: ActionCloseQuickfix, message: "Close"},
+	})
+
+	return
+}
+
+// HandleKeyPress does nothing
+func (quickfixView *QuickfixView) HandleKeyPress(keystring string) (err error) {
+	log.Debugf("QuickfixView handling key %v - NOP", keystring)
+	return
+}
+
+// HandleAction checks if the quickfix view supports the provided action and executes it if so
+func (quickfixView *QuickfixView) HandleAction(action Action) (err error) {
+	log.Debugf("QuickfixView handling action %v", action)
+	quickfixView.lock.Lock()
+	defer quickfixView.lock.Unlock()
+
+	if handler, ok := quickfixView.handlers[action.ActionType]; ok {
+		err = handler(quickfixView, action)
+	}
+
+	return
+}
+
+// OnActiveChange updates whether the quickfix view is active
+func (quickfixView *QuickfixView) OnActiveChange(active bool) {
+	log.Debugf("QuickfixView active: %v", active)
+	quickfixView.lock.Lock()
+	defer quickfixView.lock.Unlock()
+
+	quickfixView.active = active
+}
+
+// ViewID returns the view ID of the quickfix view
+func (quickfixView *QuickfixView) ViewID() ViewID {
+	return ViewQuickfix
+}
+
+func (quickfixView *QuickfixView) lineNumber() uint {
+	if quickfixView.list == nil {
+		return 0
+	}
+
+	return uint(len(quickfixView.list.entries))
+}
+
+func moveUpQuickfixEntry(quickfixView *QuickfixView, action Action) (err error) {
+	if quickfixView.viewPos.MoveLinesUp(action.RepeatCount()) {
+		quickfixView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownQuickfixEntry(quickfixView *QuickfixView, action Action) (err error) {
+	if quickfixView.viewPos.MoveLinesDown(action.RepeatCount(), quickfixView.lineNumber()) {
+		quickfixView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToFirstQuickfixEntry(quickfixView *QuickfixView, action Action) (err error) {
+	if quickfixView.viewPos.MoveToFirstLine() {
+		quickfixView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToLastQuickfixEntry(quickfixView *QuickfixView, action Action) (err error) {
+	if quickfixView.viewPos.MoveToLastLine(quickfixView.lineNumber()) {
+		quickfixView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func selectQuickfixEntry(quickfixView *QuickfixView, action Action) (err error) {
+	if quickfixView.lineNumber() == 0 {
+		return
+	}
+
+	quickfixView.channels.DoAction(Action{
+		ActionType: ActionJumpToQuickfixEntry,
+		Args:       []interface{}{quickfixView.list.entries[quickfixView.viewPos.ActiveRowIndex()]},
+	})
+
+	return
+}
+
+// diffQuickfixEntries returns an entry for each hunk of the diff lines, or for each line
+// matching the search pattern if one is provided. Hunk entries refer to the first changed line
+// of the hunk. Entries are located by file path and line number in the new version of the file
+func diffQuickfixEntries(lines *diffLines, searchRegex *regexp.Regexp) (entries []QuickfixEntry) {
+	var filePath string
+	var lineNumber uint
+	hunkLineIndex := -1
+
+	for lineIndex, diffLine := range lines.lines {
+		diffLine.determineDiffLineType()
+
+		switch diffLine.lineType {
+		case dltGitDiffHeader:
+			if _, newFilePath, ok := diffHeaderFilePaths(diffLine.line); ok {
+				filePath = newFilePath
+			}
+
+			lineNumber = 0
+			hunkLineIndex = -1
+		case dltHunkStart:
+			if hunkHeader, ok := parseDiffHunkHeader(diffLine.line); ok {
+				lineNumber = hunkHeader.newStart
+			}
+
+			if searchRegex == nil {
+				hunkLineIndex = len(entries)
+				entries = append(entries, QuickfixEntry{
+					source:    lines,
+					lineIndex: uint(lineIndex),
+					location:  quickfixLocation(filePath, lineNumber),
+					text:      strings.TrimSpace(diffLine.line),
+				})
+			}
+		}
+
+		if searchRegex != nil {
+			if searchRegex.MatchString(diffLine.line) {
+				entries = append(entries, QuickfixEntry{
+					source:    lines,
+					lineIndex: uint(lineIndex),
+					location:  quickfixLocation(filePath, lineNumber),
+					text:      strings.TrimSpace(diffLine.line),
+				})
+			}
+		} else if hunkLineIndex != -1 && (diffLine.lineType == dltLineAdded || diffLine.lineType == dltLineRemoved) {
+			entries[hunkLineIndex].lineIndex = uint(lineIndex)
+			entries[hunkLineIndex].location = quickfixLocation(filePath, lineNumber)
+			entries[hunkLineIndex].text = strings.TrimSpace(diffLine.line)
+			hunkLineIndex = -1
+		}
+
+		switch diffLine.lineType {
+		case dltLineAdded, dltNormal:
+			if lineNumber > 0 && !strings.HasPrefix(diffLine.line, "\\") {
+				lineNumber++
+			}
+		}
+	}
+
+	return
+}
+
+func quickfixLocation(filePath string, lineNumber uint) string {
+	if filePath == "" {
+		return ""
+	}
+
+	if lineNumber == 0 {
+		return filePath
+	}
+
+	return fmt.Sprintf("%v:%v", filePath, lineNumber)
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"regexp"
+	"testing"
+)
+
+func quickfixTestDiffLines() *diffLines {
+	var lines []*diffLineData
+	for _, line := range []string{
+		"diff --git a/main.go b/main.go",
+		"index 3b18e51..a9c2f1d 100644",
+		"--- a/main.go",
+		"+++ b/main.go",
+		"@@ -10,4 +10,5 @@ func main() {",
+		" \tconfig := load()",
+		" \tvalidate(config)",
+		"+\trun(config)",
+		" \tlog.Info(\"done\")",
+		"@@ -30 +31 @@ func run() {",
+		"-\treturn nil",
+		"+\treturn err",
+		"diff --git a/doc/README.md b/doc/README.md",
+		"--- a/doc/README.md",
+		"+++ b/doc/README.md",
+		"@@ -1,2 +1,2 @@",
+		" # grv",
+		"-run config",
+		"+run config validation",
+	} {
+		lines = append(lines, &diffLineData{line: line})
+	}
+
+	return &diffLines{lines: lines}
+}
+
+func TestDiffQuickfixEntriesListHunks(t *testing.T) {
+	lines := quickfixTestDiffLines()
+	entries := diffQuickfixEntries(lines, nil)
+
+	expectedEntries := []QuickfixEntry{
+		{source: lines, lineIndex: 7, location: "main.go:12", text: "+\trun(config)"},
+		{source: lines, lineIndex: 10, location: "main.go:31", text: "-\treturn nil"},
+		{source: lines, lineIndex: 17, location: "doc/README.md:2", text: "-run config"},
+	}
+
+	if len(entries) != len(expectedEntries) {
+		t.Fatalf("Expected %v entries but found %v: %v", len(expectedEntries), len(entries), entries)
+	}
+
+	for index, expectedEntry := range expectedEntries {
+		if entries[index] != expectedEntry {
+			t.Errorf("Entry %v does not match. Expected: %v, Actual: %v", index, expectedEntry, entries[index])
+		}
+	}
+}
+
+func TestDiffQuickfixEntriesListSearchMatches(t *testing.T) {
+	lines := quickfixTestDiffLines()
+	entries := diffQuickfixEntries(lines, regexp.MustCompile(`config\b`))
+
+	expectedEntries := []QuickfixEntry{
+		{source: lines, lineIndex: 5, location: "main.go:10", text: "config := load()"},
+		{source: lines, lineIndex: 6, location: "main.go:11", text: "validate(config)"},
+		{source: lines, lineIndex: 7, location: "main.go:12", text: "+\trun(config)"},
+		{source: lines, lineIndex: 17, location: "doc/README.md:2", text: "-run config"},
+		{source: lines, lineIndex: 18, location: "doc/README.md:2", text: "+run config validation"},
+	}
+
+	if len(entries) != len(expectedEntries) {
+		t.Fatalf("Expected %v entries but found %v: %v", len(expectedEntries), len(entries), entries)
+	}
+
+	for index, expectedEntry := range expectedEntries {
+		if entries[index] != expectedEntry {
+			t.Errorf("Entry %v does not match. Expected: %v, Actual: %v", index, expectedEntry, entries[index])
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+// mutatingActions are the actions which modify the repository
+// Prompts which lead to a mutating action are included so the user
+// is not asked for input which will then be discarded
+var mutatingActions = map[ActionType]bool{
+	ActionStashPrompt:           true,
+	ActionCommitPrompt:          true,
+	ActionBranchPrompt:          true,
+	ActionUpstreamPrompt:        true,
+	ActionRenameRefPrompt:       true,
+	ActionTrackBranchPrompt:     true,
+	ActionCreateStash:           true,
+	ActionApplyStash:            true,
+	ActionPopStash:              true,
+	ActionDropStash:             true,
+	ActionCreateCommit:          true,
+	ActionCreateBranch:          true,
+	ActionFetch:                 true,
+	ActionEditBranchDescription: true,
+	ActionSetUpstream:           true,
+	ActionRenameRef:             true,
+	ActionTrackBranch:           true,
+	ActionCreateFixupCommit:     true,
+	ActionCreateSquashCommit:    true,
+	ActionAutosquashRebase:      true,
+}
+
+// IsMutatingAction returns true if the action modifies the repository
+func IsMutatingAction(actionType ActionType) bool {
+	return mutatingActions[actionType]
+}
+
+// actionKey returns the text representation of the action
+func actionKey(actionType ActionType) string {
+	for key, keyActionType := range actionKeys {
+		if keyActionType == actionType {
+			return key
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestMutatingActionsHaveActionKeys(t *testing.T) {
+	for actionType := range mutatingActions {
+		if actionKey(actionType) == "" {
+			t.Errorf("Mutating action %v has no action key", actionType)
+		}
+	}
+}
+
+func TestNavigationActionsAreNotMutating(t *testing.T) {
+	for _, actionType := range []ActionType{ActionNextLine, ActionSelect, ActionSearchPrompt, ActionFilterPrompt, ActionFuzzyFind, ActionDiffRevisions} {
+		if IsMutatingAction(actionType) {
+			t.Errorf("Expected action %v not to be mutating", actionKey(actionType))
+		}
+	}
+}
@@ -14,6 +14,54 @@ package main
 // #include <readline/history.h>
 //
 // extern void grvReadlineUpdateDisplay(void);
+// extern char *grvReadlineCompletionGenerator(char *text, int state);
+//
+// static char *grv_completion_generator(const char *text, int state) {
+//	return grvReadlineCompletionGenerator((char *) text, state);
+// }
+//
+// static char **grv_attempted_completion(const char *text, int start, int end) {
+//	rl_attempted_completion_over = 1;
+//	rl_completion_append_character = '\0';
+//	return rl_completion_matches(text, grv_completion_generator);
+// }
+//
+// // Matches are cycled through in place as readline would otherwise
+// // write the list of matches directly to the terminal
+// static void grv_display_matches(char **matches, int num_matches, int max_length) {
+// }
+//
+// static void grv_set_completion_enabled(int enabled) {
+//	rl_bind_key('\t', enabled ? rl_menu_complete : NULL);
+// }
+//
+// static char *grv_initial_input;
+//
+// static int grv_insert_initial_input(void) {
+//	rl_insert_text(grv_initial_input);
+//	return 0;
+// }
+//
+// static void grv_set_initial_input(char *input) {
+//	grv_initial_input = input;
+//	rl_startup_hook = input != NULL ? grv_insert_initial_input : NULL;
+// }
+//
+// static void grv_set_num_chars_to_read(int num) {
+//	rl_num_chars_to_read = num;
+// }
+//
+// static int grv_bind_keyseq(const char *keyseq, const char *function) {
+//	rl_command_func_t *func = rl_named_function(function);
+//
+//	if (func == NULL ||
+//		rl_bind_keyseq_in_map(keyseq, func, emacs_standard_keymap) != 0 ||
+//		rl_bind_keyseq_in_map(keyseq, func, vi_insertion_keymap) != 0) {
+//		return -1;
+//	}
+//
+//	return 0;
+// }
 //
 // static void grv_init_readline(void) {
 // 	rl_redisplay_function = grvReadlineUpdateDisplay;
@@ -21,6 +69,20 @@ package main
 //	rl_catch_sigwinch = 0;
 //	rl_change_environment = 0;
 //	rl_bind_key('\t', NULL);
+//	rl_attempted_completion_function = grv_attempted_completion;
+//	rl_completion_display_matches_hook = grv_display_matches;
+//
+//	// Accept multi-byte UTF-8 input rather than treating bytes with the
+//	// eighth bit set as meta key combinations
+//	rl_variable_bind("input-meta", "on");
+//	rl_variable_bind("output-meta", "on");
+//	rl_variable_bind("convert-meta", "off");
+//
+//	// Read the inputrc file now so that the settings below take precedence.
+//	// Bracketed paste mode is enabled in the terminal by the UI for as long
+//	// as ncurses is active, so readline must not disable it when a prompt ends
+//	rl_initialize();
+//	rl_variable_bind("enable-bracketed-paste", "off");
 //
 //	history_write_timestamps = 1;
 //	history_comment_char = '#';
@@ -29,7 +91,9 @@ package main
 import "C"
 
 import (
+	"fmt"
 	"os"
+	"strings"
 	"sync"
 	"unsafe"
 
@@ -49,6 +113,26 @@ var historyFilePrompts = map[string]string{
 	FilterPromptText:        rlFilterHistoryFile,
 }
 
+// promptEditingBindings binds the key sequences terminals send for modified
+// arrow, backspace and delete keys to readline editing commands. Combined with
+// readline's emacs bindings (<C-a>, <C-e>, <C-u>, <C-k>, <M-f>, <M-b>, <C-w>,
+// <M-d> and the <C-y>/<M-y> kill ring) this provides word-wise movement and deletion.
+// Pasted text is inserted as is (including newlines) rather than processed as key presses
+var promptEditingBindings = []struct {
+	keyseq   string
+	function string
+}{
+	{keyseq: "\x1b[1;5C", function: "forward-word"},
+	{keyseq: "\x1b[1;5D", function: "backward-word"},
+	{keyseq: "\x1b[1;3C", function: "forward-word"},
+	{keyseq: "\x1b[1;3D", function: "backward-word"},
+	{keyseq: "\x1b[3;5~", function: "kill-word"},
+	{keyseq: "\x1b[3;3~", function: "kill-word"},
+	{keyseq: "\x1b\x7f", function: "backward-kill-word"},
+	{keyseq: "\x1b\b", function: "backward-kill-word"},
+	{keyseq: "\x1b[200~", function: "bracketed-paste-begin"},
+}
+
 var readLine ReadLine
 
 // ReadLine is a wrapper around the readline library
@@ -61,6 +145,8 @@ type ReadLine struct {
 	promptPoint    int
 	active         bool
 	lastPromptText string
+	completions    []string
+	matches        []string
 	lock           sync.Mutex
 }
 
@@ -73,6 +159,30 @@ func InitReadLine(channels *Channels, ui InputUI, config Config) {
 	}
 
 	C.grv_init_readline()
+
+	if err := readLineBindEditingKeys(); err != nil {
+		log.Errorf("Unable to bind prompt editing keys: %v", err)
+	}
+}
+
+func readLineBindEditingKeys() (err error) {
+	for _, binding := range promptEditingBindings {
+		cKeyseq := C.CString(binding.keyseq)
+		cFunction := C.CString(binding.function)
+
+		if C.grv_bind_keyseq(cKeyseq, cFunction) != 0 {
+			err = fmt.Errorf("Failed to bind %q to %v", binding.keyseq, binding.function)
+		}
+
+		C.free(unsafe.Pointer(cKeyseq))
+		C.free(unsafe.Pointer(cFunction))
+
+		if err != nil {
+			return
+		}
+	}
+
+	return
 }
 
 // FreeReadLine flushes any history to disk
@@ -125,6 +235,7 @@ func Prompt(prompt string) string {
 	cPrompt := C.CString(prompt)
 
 	readLineSetupPromptHistory(prompt)
+	readLineSetupEditingMode()
 	readLineSetActive(true)
 	cInput := C.readline(cPrompt)
 	readLineSetActive(false)
@@ -137,6 +248,47 @@ func Prompt(prompt string) string {
 	return input
 }
 
+// PromptWithCompletions shows a readline prompt in which <Tab> cycles
+// through the provided completions which match the entered text
+func PromptWithCompletions(prompt string, completions []string) string {
+	readLine.lock.Lock()
+	readLine.completions = completions
+	readLine.lock.Unlock()
+
+	C.grv_set_completion_enabled(1)
+	input := Prompt(prompt)
+	C.grv_set_completion_enabled(0)
+
+	readLine.lock.Lock()
+	readLine.completions = nil
+	readLine.matches = nil
+	readLine.lock.Unlock()
+
+	return input
+}
+
+// PromptWithInitialInput shows a readline prompt pre-filled with the provided input
+func PromptWithInitialInput(prompt, initialInput string) string {
+	cInitialInput := C.CString(initialInput)
+
+	C.grv_set_initial_input(cInitialInput)
+	input := Prompt(prompt)
+	C.grv_set_initial_input(nil)
+
+	C.free(unsafe.Pointer(cInitialInput))
+
+	return input
+}
+
+// PromptSingleCharacter shows a readline prompt which returns as soon as one character has been entered
+func PromptSingleCharacter(prompt string) string {
+	C.grv_set_num_chars_to_read(1)
+	input := Prompt(prompt)
+	C.grv_set_num_chars_to_read(0)
+
+	return input
+}
+
 // PromptState returns current prompt properties
 func PromptState() (string, string, int) {
 	readLine.lock.Lock()
@@ -160,6 +312,14 @@ func readLineSetActive(active bool) {
 	readLine.active = active
 }
 
+// readLineSetupEditingMode ensures prompts use emacs editing commands when the emacs keymap is selected
+// Otherwise the editing mode configured for readline (e.g. in inputrc) is used
+func readLineSetupEditingMode() {
+	if readLine.config.GetString(CfKeymap) == KeymapEmacs {
+		C.rl_emacs_editing_mode(1, 0)
+	}
+}
+
 func readLineSetupPromptHistory(prompt string) {
 	readLine.lock.Lock()
 	defer readLine.lock.Unlock()
@@ -216,3 +376,31 @@ func grvReadlineUpdateDisplay() {
 
 	readLine.channels.UpdateDisplay()
 }
+
+//export grvReadlineCompletionGenerator
+func grvReadlineCompletionGenerator(cText *C.char, state C.int) *C.char {
+	// Matches are determined when state is 0 and the returned string is freed by readline
+	readLine.lock.Lock()
+	defer readLine.lock.Unlock()
+
+	if state == 0 {
+		readLine.matches = completionMatches(C.GoString(cText), readLine.completions)
+	}
+
+	if int(state) >= len(readLine.matches) {
+		return nil
+	}
+
+	return C.CString(readLine.matches[state])
+}
+
+// completionMatches returns the completions which start with the provided text
+func completionMatches(text string, completions []string) (matches []string) {
+	for _, completion := range completions {
+		if strings.HasPrefix(completion, text) {
+			matches = append(matches, completion)
+		}
+	}
+
+	return
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompletionMatches(t *testing.T) {
+	completions := []string{"origin/master", "origin/feature", "upstream/master"}
+
+	var completionTests = []struct {
+		text            string
+		expectedMatches []string
+	}{
+		{text: "", expectedMatches: completions},
+		{text: "origin/", expectedMatches: []string{"origin/master", "origin/feature"}},
+		{text: "up", expectedMatches: []string{"upstream/master"}},
+		{text: "master"},
+	}
+
+	for _, completionTest := range completionTests {
+		if matches := completionMatches(completionTest.text, completions); !reflect.DeepEqual(matches, completionTest.expectedMatches) {
+			t.Errorf("Matches do not match expected value. Expected: %v, Actual: %v", completionTest.expectedMatches, matches)
+		}
+	}
+}
+
+func TestPromptEditingBindingsAreValid(t *testing.T) {
+	if err := readLineBindEditingKeys(); err != nil {
+		t.Errorf("Binding prompt editing keys failed with error %v", err)
+	}
+}
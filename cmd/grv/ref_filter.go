@@ -30,7 +30,7 @@ func NewRefFilter(filter Filter) *RefFilter {
 // MatchesFilter returns true if the ref matches the filter
 func (refFilter *RefFilter) MatchesFilter(renderedRef *RenderedRef) bool {
 	switch renderedRef.renderedRefType {
-	case RvLocalBranchGroup, RvRemoteBranchGroup, RvTagGroup, RvSpace, RvLoading:
+	case RvPinnedRefGroup, RvPinnedRef, RvRecentBranchGroup, RvLocalBranchGroup, RvRemoteBranchGroup, RvTagGroup, RvUnreachableCommitGroup, RvSpace, RvLoading:
 		return true
 	default:
 		return refFilter.filter(renderedRef)
@@ -134,6 +134,14 @@ func TestCertainRenderedRefTypesAlwaysMatchFilter(t *testing.T) {
 			renderedRefType:      RvLoading,
 			expectedFilterOutput: true,
 		},
+		{
+			renderedRefType:      RvPinnedRefGroup,
+			expectedFilterOutput: true,
+		},
+		{
+			renderedRefType:      RvPinnedRef,
+			expectedFilterOutput: true,
+		},
 		{
 			renderedRefType:      RvLocalBranch,
 			expectedFilterOutput: false,
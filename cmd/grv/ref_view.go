@@ -1,13 +1,22 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"io/ioutil"
+	"os"
 	"strings"
 	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 )
 
+const (
+	rvMarkedRefPrefix   = " * "
+	rvExcludedRefPrefix = " ^ "
+)
+
 type refViewHandler func(*RefView, Action) error
 
 // RenderedRefType is the type (branch, tag, etc...) of a rendered ref
@@ -21,17 +30,33 @@ const (
 	RvRemoteBranch
 	RvTagGroup
 	RvTag
+	RvStashGroup
+	RvStash
+	RvUnreachableCommitGroup
+	RvUnreachableCommit
+	RvRecentBranchGroup
+	RvRecentBranch
+	RvPinnedRefGroup
+	RvPinnedRef
 	RvSpace
 	RvLoading
 )
 
 var refToTheme = map[RenderedRefType]ThemeComponentID{
-	RvLocalBranchGroup:  CmpRefviewLocalBranchesHeader,
-	RvRemoteBranchGroup: CmpRefviewRemoteBranchesHeader,
-	RvLocalBranch:       CmpRefviewLocalBranch,
-	RvRemoteBranch:      CmpRefviewRemoteBranch,
-	RvTagGroup:          CmpRefviewTagsHeader,
-	RvTag:               CmpRefviewTag,
+	RvLocalBranchGroup:       CmpRefviewLocalBranchesHeader,
+	RvRemoteBranchGroup:      CmpRefviewRemoteBranchesHeader,
+	RvLocalBranch:            CmpRefviewLocalBranch,
+	RvRemoteBranch:           CmpRefviewRemoteBranch,
+	RvTagGroup:               CmpRefviewTagsHeader,
+	RvTag:                    CmpRefviewTag,
+	RvStashGroup:             CmpRefviewStashesHeader,
+	RvStash:                  CmpRefviewStash,
+	RvUnreachableCommitGroup: CmpRefviewUnreachableCommitsHeader,
+	RvUnreachableCommit:      CmpRefviewUnreachableCommit,
+	RvRecentBranchGroup:      CmpRefviewRecentBranchesHeader,
+	RvRecentBranch:           CmpRefviewRecentBranch,
+	RvPinnedRefGroup:         CmpRefviewPinnedRefsHeader,
+	RvPinnedRef:              CmpRefviewPinnedRef,
 }
 
 type renderedRefGenerator func(*RefView, *refList, renderedRefSet)
@@ -47,6 +72,7 @@ type refList struct {
 type RenderedRef struct {
 	value           string
 	oid             *Oid
+	stash           *Stash
 	renderedRefType RenderedRefType
 	refList         *refList
 	refNum          uint
@@ -156,30 +182,116 @@ func (renderedRefList *renderedRefList) Children() (children uint) {
 type RefView struct {
 	channels      *Channels
 	repoData      RepoData
+	config        Config
 	refLists      []*refList
 	refListeners  []RefListener
+	tagListeners  []TagListener
 	active        bool
 	renderedRefs  renderedRefSet
 	viewPos       ViewPos
 	viewDimension ViewDimension
 	handlers      map[ActionType]refViewHandler
 	viewSearch    *ViewSearch
+	fetching      bool
+	fetchSchedule *FetchScheduler
+	canceller     *OperationCanceller
+	notifier      *Notifier
+	progress      *ProgressTracker
+	detachedHead  *detachedHeadRefs
+	unreachable   *unreachableCommits
+	recent        []*Branch
+	pinned        []string
+	compareBase   string
+	comparisons   *refComparisons
+	descriptions  map[string]string
+	markedRefs    []*markedRef
 	lock          sync.Mutex
 }
 
+// pinnedRef is a branch or tag displayed in the pinned group
+type pinnedRef struct {
+	name string
+	oid  *Oid
+}
+
+// refComparison is the number of commits a branch is ahead and behind the compare base
+type refComparison struct {
+	ahead  int
+	behind int
+}
+
+// refComparisons stores how each branch compares to the compare base
+type refComparisons struct {
+	base       string
+	branches   map[string]refComparison
+	cancel     context.CancelFunc
+	generation uint
+}
+
+// markedRef is a ref whose history is included (or excluded if hidden) when a ref is selected
+type markedRef struct {
+	name   string
+	oid    *Oid
+	hidden bool
+}
+
+// unreachableCommits stores the tips of history which are not reachable from HEAD or any ref
+type unreachableCommits struct {
+	commits []*Commit
+	loading bool
+}
+
+// detachedHeadRefs records the refs which contain a detached HEAD commit
+type detachedHeadRefs struct {
+	head     *Oid
+	branches []*Branch
+	tags     []*Tag
+}
+
+// RefListStateProvider provides and restores whether each list of refs is expanded and which refs are pinned
+type RefListStateProvider interface {
+	ExpandedRefLists() map[string]bool
+	SetExpandedRefLists(expandedRefLists map[string]bool)
+	PinnedRefs() []string
+	SetPinnedRefs(refNames []string)
+}
+
 // RefListener is notified when a reference is selected
 type RefListener interface {
 	OnRefSelect(refName string, oid *Oid) error
 }
 
+// TagListener is notified when tags have been loaded or reloaded
+type TagListener interface {
+	OnTagsLoaded() error
+}
+
 // NewRefView creates a new instance
-func NewRefView(repoData RepoData, channels *Channels) *RefView {
+func NewRefView(repoData RepoData, channels *Channels, config Config, canceller *OperationCanceller) *RefView {
 	refView := &RefView{
 		channels:     channels,
 		repoData:     repoData,
+		config:       config,
 		viewPos:      NewViewPosition(),
 		renderedRefs: newRenderedRefList(),
+		notifier:     NewNotifier(config),
+		progress:     NewProgressTracker(channels),
+		canceller:    canceller,
+		unreachable:  &unreachableCommits{},
+		comparisons:  &refComparisons{},
 		refLists: []*refList{
+			{
+				name:            "Pinned",
+				renderer:        generatePinnedRefs,
+				expanded:        true,
+				renderedRefType: RvPinnedRefGroup,
+			},
+			{
+				name:            "Recent",
+				renderer:        generateRecentBranches,
+				expanded:        true,
+				renderedRefType: RvRecentBranchGroup,
+			},
 			{
 				name:            "Branches",
 				renderer:        generateBranches,
@@ -196,28 +308,85 @@ func NewRefView(repoData RepoData, channels *Channels) *RefView {
 				renderer:        generateTags,
 				renderedRefType: RvTagGroup,
 			},
+			{
+				name:            "Stashes",
+				renderer:        generateStashes,
+				renderedRefType: RvStashGroup,
+			},
+			{
+				name:            "Unreachable Commits",
+				renderer:        generateUnreachableCommits,
+				renderedRefType: RvUnreachableCommitGroup,
+			},
 		},
 		handlers: map[ActionType]refViewHandler{
-			ActionPrevLine:     moveUpRef,
-			ActionNextLine:     moveDownRef,
-			ActionPrevPage:     moveUpRefPage,
-			ActionNextPage:     moveDownRefPage,
-			ActionScrollRight:  scrollRefViewRight,
-			ActionScrollLeft:   scrollRefViewLeft,
-			ActionFirstLine:    moveToFirstRef,
-			ActionLastLine:     moveToLastRef,
-			ActionSelect:       selectRef,
-			ActionAddFilter:    addRefFilter,
-			ActionRemoveFilter: removeRefFilter,
+			ActionPrevLine:              moveUpRef,
+			ActionNextLine:              moveDownRef,
+			ActionPrevPage:              moveUpRefPage,
+			ActionNextPage:              moveDownRefPage,
+			ActionScrollHalfPageUp:      moveUpRefHalfPage,
+			ActionScrollHalfPageDown:    moveDownRefHalfPage,
+			ActionCenterView:            centerRefView,
+			ActionScrollCursorTop:       scrollRefViewCursorTop,
+			ActionScrollCursorBottom:    scrollRefViewCursorBottom,
+			ActionScrollRight:           scrollRefViewRight,
+			ActionScrollLeft:            scrollRefViewLeft,
+			ActionFirstLine:             moveToFirstRef,
+			ActionLastLine:              moveToLastRef,
+			ActionSelect:                selectRef,
+			ActionAddFilter:             addRefFilter,
+			ActionRemoveFilter:          removeRefFilter,
+			ActionCreateStash:           createStash,
+			ActionCreateCommit:          createCommit,
+			ActionReloadRefs:            reloadRefs,
+			ActionCreateBranch:          createBranch,
+			ActionShowView:              showRefGroup,
+			ActionFetch:                 fetchRemotes,
+			ActionApplyStash:            applyStash,
+			ActionPopStash:              popStash,
+			ActionDropStash:             dropStash,
+			ActionEditBranchDescription: editBranchDescription,
+			ActionSetUpstream:           setUpstream,
+			ActionRenameRef:             renameRef,
+			ActionTrackBranch:           trackBranch,
+			ActionMarkRef:               markRef,
+			ActionExcludeRef:            excludeRef,
+			ActionPinRef:                pinRef,
+			ActionSetCompareBase:        setCompareBase,
 		},
 	}
 
-	refView.viewSearch = NewViewSearch(refView, channels)
+	refView.viewSearch = NewViewSearch(refView, channels, canceller)
+	refView.fetchSchedule = NewFetchScheduler(func() {
+		if !refView.config.GetBool(CfReadOnly) {
+			refView.fetch(true)
+		}
+	})
+
+	config.AddOnChangeListener(CfFetchInterval, refView)
+	config.AddOnChangeListener(CfRecentBranches, refView)
 
 	return refView
 }
 
-// Initialise loads the HEAD reference along with branches and tags
+// onConfigVariableChange updates the background fetch interval or the number of recent branches displayed
+func (refView *RefView) onConfigVariableChange(configVariable ConfigVariable) {
+	switch configVariable {
+	case CfFetchInterval:
+		fetchInterval := time.Duration(refView.config.GetInt(CfFetchInterval)) * time.Minute
+		refView.fetchSchedule.SetInterval(fetchInterval)
+	case CfRecentBranches:
+		refView.lock.Lock()
+		defer refView.lock.Unlock()
+
+		refView.loadRecentBranches()
+		refView.generateRenderedRefs()
+		refView.channels.UpdateDisplay()
+	}
+}
+
+// Initialise loads and selects the HEAD reference so the first screen can be displayed quickly
+// Stashes, branches and tags are then loaded in the background
 func (refView *RefView) Initialise() (err error) {
 	log.Info("Initialising RefView")
 
@@ -225,65 +394,347 @@ func (refView *RefView) Initialise() (err error) {
 		return
 	}
 
+	refView.generateRenderedRefs()
+	head, branch := refView.repoData.Head()
+
+	var branchName string
+	if branch == nil {
+		branchName = getDetachedHeadDisplayValue(refView.repoData, head)
+	} else {
+		branchName = branch.name
+	}
+
+	// The commits for HEAD fill most of the display so they start loading before any other refs
+	if err = refView.notifyRefListeners(branchName, head); err != nil {
+		return
+	}
+
+	refView.progress.Start(PgLoadBranches, "Loading branches")
+	refView.progress.Start(PgLoadTags, "Loading tags")
+
+	go func() {
+		if err := refView.loadRefs(); err != nil {
+			refView.progress.Stop(PgLoadBranches)
+			refView.progress.Stop(PgLoadTags)
+			refView.channels.ReportError(err)
+		}
+	}()
+
+	return
+}
+
+// loadRefs loads the stashes, branches and tags displayed by the ref view
+// The ref view is updated as each set of refs becomes available
+func (refView *RefView) loadRefs() (err error) {
+	if err = refView.repoData.LoadStashes(); err != nil {
+		return
+	}
+
+	refView.lock.Lock()
+	refView.generateRenderedRefs()
+	refView.lock.Unlock()
+	refView.channels.UpdateDisplay()
+
 	if err = refView.repoData.LoadBranches(func(localBranches, remoteBranches []*Branch) error {
 		log.Debug("Branches loaded")
 		refView.lock.Lock()
 		defer refView.lock.Unlock()
 
+		refView.progress.Stop(PgLoadBranches)
+
+		refView.loadRecentBranches()
+		refView.loadBranchDescriptions()
 		refView.generateRenderedRefs()
+		refView.loadDetachedHeadRefs()
+		refView.loadRefComparisons()
+		refView.viewPos.SetActiveRowIndex(refView.headBranchRowIndex())
+		refView.channels.UpdateDisplay()
 
-		_, headBranch := refView.repoData.Head()
-		activeRowIndex := uint(1)
+		return nil
+	}); err != nil {
+		return
+	}
 
-		if headBranch != nil {
-			for _, branch := range localBranches {
-				if branch.name == headBranch.name {
-					log.Debugf("Setting branch %v as selected branch", branch.name)
-					break
-				}
+	return refView.loadTags()
+}
 
-				activeRowIndex++
-			}
-		}
+// loadTags loads the local tags and notifies tag listeners once they have loaded
+func (refView *RefView) loadTags() error {
+	return refView.repoData.LoadLocalTags(func(tags []*Tag) error {
+		log.Debug("Local tags loaded")
+		refView.lock.Lock()
+
+		refView.progress.Stop(PgLoadTags)
 
-		refView.viewPos.SetActiveRowIndex(activeRowIndex)
+		refView.generateRenderedRefs()
+		refView.loadDetachedHeadRefs()
 		refView.channels.UpdateDisplay()
 
-		return nil
-	}); err != nil {
+		refView.lock.Unlock()
+
+		return refView.notifyTagListeners()
+	})
+}
+
+// headBranchRowIndex returns the row index of HEAD in the branches group
+// The first row of the branches group is returned if HEAD is detached
+func (refView *RefView) headBranchRowIndex() uint {
+	_, headBranch := refView.repoData.Head()
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+
+	for rowIndex, renderedRef := range renderedRefs {
+		if renderedRef.renderedRefType == RvLocalBranch && (headBranch == nil ||
+			strings.TrimLeft(renderedRef.value, " ") == headBranch.name) {
+			log.Debugf("Setting branch %v as selected branch", renderedRef.value)
+			return uint(rowIndex)
+		}
+	}
+
+	return 0
+}
+
+// selectLocalBranch moves the selection to the local branch with the provided name
+func (refView *RefView) selectLocalBranch(branchName string) {
+	for rowIndex, renderedRef := range refView.renderedRefs.RenderedRefs() {
+		if renderedRef.renderedRefType == RvLocalBranch && strings.TrimLeft(renderedRef.value, " ") == branchName {
+			refView.viewPos.SetActiveRowIndex(uint(rowIndex))
+			return
+		}
+	}
+}
+
+// loadRecentBranches determines the local branches most recently checked out from the HEAD reflog
+func (refView *RefView) loadRecentBranches() {
+	branchNames, err := refView.repoData.CheckedOutBranches()
+	if err != nil {
+		log.Errorf("Unable to determine recently checked out branches: %v", err)
+	}
+
+	localBranches, _, _ := refView.repoData.Branches()
+	_, headBranch := refView.repoData.Head()
+
+	refView.recent = recentBranches(branchNames, localBranches, headBranch, uint(refView.config.GetInt(CfRecentBranches)))
+}
+
+// loadBranchDescriptions loads the configured descriptions of all local branches
+func (refView *RefView) loadBranchDescriptions() {
+	localBranches, _, _ := refView.repoData.Branches()
+
+	var branchNames []string
+	for _, branch := range localBranches {
+		branchNames = append(branchNames, branch.name)
+	}
+
+	descriptions, err := refView.repoData.BranchDescriptions(branchNames)
+	if err != nil {
+		log.Errorf("Unable to load branch descriptions: %v", err)
+	}
+
+	refView.descriptions = descriptions
+}
+
+// recentBranches returns up to limit of the local branches named, in the order they are named
+// Names which are not local branches and the HEAD branch are excluded
+func recentBranches(branchNames []string, localBranches []*Branch, headBranch *Branch, limit uint) (branches []*Branch) {
+	localBranchMap := make(map[string]*Branch)
+	for _, branch := range localBranches {
+		localBranchMap[branch.name] = branch
+	}
+
+	for _, branchName := range branchNames {
+		if uint(len(branches)) >= limit {
+			break
+		}
+
+		if branch, ok := localBranchMap[branchName]; ok && (headBranch == nil || branch.name != headBranch.name) {
+			branches = append(branches, branch)
+		}
+	}
+
+	return
+}
+
+func getDetachedHeadDisplayValue(repoData RepoData, oid *Oid) string {
+	return fmt.Sprintf("HEAD detached at %s", repoData.ShortID(oid))
+}
+
+// describeDetachedHeadRefs lists the refs which contain the detached HEAD commit
+// or warns that the commit is not reachable from any branch or tag
+func describeDetachedHeadRefs(branches []*Branch, tags []*Tag) string {
+	if len(branches) == 0 && len(tags) == 0 {
+		return "not contained in any branch or tag"
+	}
+
+	var refNames []string
+
+	for _, branch := range branches {
+		refNames = append(refNames, branch.name)
+	}
+
+	for _, tag := range tags {
+		refNames = append(refNames, tag.name)
+	}
+
+	return fmt.Sprintf("contained in %v", strings.Join(refNames, ", "))
+}
+
+// loadDetachedHeadRefs determines in the background which refs contain HEAD if it is detached
+// The displayed refs are regenerated once the refs have been determined
+func (refView *RefView) loadDetachedHeadRefs() {
+	head, headBranch := refView.repoData.Head()
+	if head == nil || headBranch != nil {
 		return
 	}
 
-	if err = refView.repoData.LoadLocalTags(func(tags []*Tag) error {
-		log.Debug("Local tags loaded")
+	go func() {
+		branches, tags, err := refView.repoData.RefsContainingCommit(head)
+		if err != nil {
+			log.Errorf("Unable to determine refs containing %v: %v", head, err)
+			return
+		}
+
 		refView.lock.Lock()
 		defer refView.lock.Unlock()
 
+		refView.detachedHead = &detachedHeadRefs{
+			head:     head,
+			branches: branches,
+			tags:     tags,
+		}
+
 		refView.generateRenderedRefs()
 		refView.channels.UpdateDisplay()
+	}()
+}
 
-		return nil
-	}); err != nil {
+// detachedHeadRefsDescription describes the refs containing the detached HEAD
+// An empty string is returned if the refs have not yet been determined
+func (refView *RefView) detachedHeadRefsDescription(head *Oid) string {
+	if detachedHead := refView.detachedHead; detachedHead != nil && detachedHead.head == head {
+		return describeDetachedHeadRefs(detachedHead.branches, detachedHead.tags)
+	}
+
+	return ""
+}
+
+// compareBaseBranch returns the name and oid of the branch other branches are compared with.
+// This is the selected compare base if it still exists, otherwise the HEAD branch or HEAD if it is detached
+func (refView *RefView) compareBaseBranch() (name string, oid *Oid) {
+	if refView.compareBase != "" {
+		localBranches, remoteBranches, _ := refView.repoData.Branches()
+
+		for _, branches := range [][]*Branch{localBranches, remoteBranches} {
+			for _, branch := range branches {
+				if branch.name == refView.compareBase {
+					return branch.name, branch.oid
+				}
+			}
+		}
+	}
+
+	head, headBranch := refView.repoData.Head()
+	if headBranch != nil {
+		return headBranch.name, head
+	}
+
+	return "HEAD", head
+}
+
+// loadRefComparisons determines in the background how many commits each branch is ahead and behind the compare base
+// Any comparison still in progress is cancelled. The display is updated once all branches have been compared
+func (refView *RefView) loadRefComparisons() {
+	comparisons := refView.comparisons
+	comparisons.generation++
+
+	if comparisons.cancel != nil {
+		comparisons.cancel()
+		comparisons.cancel = nil
+		refView.progress.Stop(PgCompareBranches)
+	}
+
+	localBranches, remoteBranches, loading := refView.repoData.Branches()
+	base, baseOid := refView.compareBaseBranch()
+
+	if loading || baseOid == nil {
 		return
 	}
 
-	refView.generateRenderedRefs()
-	head, branch := refView.repoData.Head()
+	branches := append(append([]*Branch(nil), localBranches...), remoteBranches...)
+	generation := comparisons.generation
+	ctx, done := refView.canceller.Start("branch comparison")
+	comparisons.cancel = done
+	refView.progress.Start(PgCompareBranches, "Comparing branches")
+
+	go func() {
+		defer done()
+		branchComparisons := make(map[string]refComparison)
+
+		for branchIndex, branch := range branches {
+			if ctx.Err() != nil {
+				break
+			} else if branch.oid == nil {
+				continue
+			}
 
-	var branchName string
-	if branch == nil {
-		branchName = getDetachedHeadDisplayValue(head)
-	} else {
-		branchName = branch.name
+			ahead, behind, err := refView.repoData.AheadBehind(branch.oid, baseOid)
+			if err != nil {
+				log.Errorf("Unable to compare branch %v with %v: %v", branch.name, base, err)
+				continue
+			}
+
+			branchComparisons[branch.name] = refComparison{
+				ahead:  ahead,
+				behind: behind,
+			}
+
+			refView.progress.Update(PgCompareBranches, uint(branchIndex+1), uint(len(branches)))
+		}
+
+		refView.lock.Lock()
+		defer refView.lock.Unlock()
+
+		if generation != comparisons.generation {
+			return
+		}
+
+		comparisons.cancel = nil
+		refView.progress.Stop(PgCompareBranches)
+
+		if ctx.Err() != nil {
+			log.Debugf("Comparing branches with %v was cancelled", base)
+			return
+		}
+
+		comparisons.base = base
+		comparisons.branches = branchComparisons
+		refView.channels.UpdateDisplay()
+	}()
+}
+
+// comparisonBadge describes how the provided branch compares to the compare base
+// An empty string is returned if the ref is not a branch or has not been compared
+func (refView *RefView) comparisonBadge(renderedRef *RenderedRef) string {
+	switch renderedRef.renderedRefType {
+	case RvPinnedRef, RvRecentBranch, RvLocalBranch, RvRemoteBranch:
+	default:
+		return ""
 	}
 
-	err = refView.notifyRefListeners(branchName, head)
+	branchName := strings.TrimLeft(renderedRef.value, " ")
+	if branchName == refView.comparisons.base {
+		return "base"
+	}
 
-	return
+	if comparison, ok := refView.comparisons.branches[branchName]; ok {
+		return describeRefComparison(comparison)
+	}
+
+	return ""
 }
 
-func getDetachedHeadDisplayValue(oid *Oid) string {
-	return fmt.Sprintf("HEAD detached at %s", oid.String()[0:7])
+// describeRefComparison describes the number of commits a branch is ahead and behind the compare base, e.g. +3/-1
+func describeRefComparison(comparison refComparison) string {
+	return fmt.Sprintf("+%v/-%v", comparison.ahead, comparison.behind)
 }
 
 func isSelectableRenderedRef(renderedRefType RenderedRefType) bool {
@@ -295,6 +746,23 @@ func (refView *RefView) RegisterRefListener(refListener RefListener) {
 	refView.refListeners = append(refView.refListeners, refListener)
 }
 
+// RegisterTagListener adds a tag listener to be notified when tags have been loaded
+func (refView *RefView) RegisterTagListener(tagListener TagListener) {
+	refView.tagListeners = append(refView.tagListeners, tagListener)
+}
+
+func (refView *RefView) notifyTagListeners() (err error) {
+	log.Debug("Notifying TagListeners of loaded tags")
+
+	for _, tagListener := range refView.tagListeners {
+		if err = tagListener.OnTagsLoaded(); err != nil {
+			break
+		}
+	}
+
+	return
+}
+
 func (refView *RefView) notifyRefListeners(refName string, oid *Oid) (err error) {
 	log.Debugf("Notifying RefListeners of selected oid %v", oid)
 
@@ -319,7 +787,7 @@ func (refView *RefView) Render(win RenderWindow) (err error) {
 	renderedRefNum := uint(len(renderedRefs))
 	rows := win.Rows() - 2
 	viewPos := refView.viewPos
-	viewPos.DetermineViewStartRow(rows, renderedRefNum)
+	viewPos.DetermineViewStartRow(rows, renderedRefNum, uint(refView.config.GetInt(CfScrollOff)))
 	refIndex := viewPos.ViewStartRowIndex()
 	startColumn := viewPos.ViewStartColumn()
 
@@ -331,10 +799,22 @@ func (refView *RefView) Render(win RenderWindow) (err error) {
 			themeComponentID = CmpNone
 		}
 
-		if err = win.SetRow(winRowIndex+1, startColumn, themeComponentID, "%v", renderedRef.value); err != nil {
+		value := renderedRef.value
+		if prefix, isMarked := refView.markedRefPrefix(renderedRef); isMarked {
+			value = prefix + strings.TrimLeft(value, " ")
+		}
+
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(winRowIndex+1, startColumn); err != nil {
 			return
 		}
 
+		lineBuilder.AppendWithStyle(themeComponentID, "%v", value)
+
+		if badge := refView.comparisonBadge(renderedRef); badge != "" {
+			lineBuilder.AppendWithStyle(CmpRefviewComparison, " %v", badge)
+		}
+
 		refIndex++
 	}
 
@@ -344,12 +824,11 @@ func (refView *RefView) Render(win RenderWindow) (err error) {
 
 	win.DrawBorder()
 
-	if err = win.SetTitle(CmpRefviewTitle, "Refs"); err != nil {
+	if err = refView.renderTitle(win); err != nil {
 		return
 	}
 
-	selectedRenderedRef := renderedRefs[viewPos.ActiveRowIndex()]
-	if err = refView.renderFooter(win, selectedRenderedRef); err != nil {
+	if err = refView.renderFooter(win); err != nil {
 		return
 	}
 
@@ -373,74 +852,219 @@ func (refView *RefView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
 		{action: ActionSelect, message: "Select"},
 		{action: ActionFilterPrompt, message: "Add Filter"},
 		{action: ActionRemoveFilter, message: "Remove Filter"},
+		{action: ActionStashPrompt, message: "Stash"},
+		{action: ActionCommitPrompt, message: "Commit"},
+		{action: ActionBranchPrompt, message: "Branch"},
+		{action: ActionFetch, message: "Fetch"},
+		{action: ActionPopStash, message: "Pop Stash"},
+		{action: ActionMarkRef, message: "Mark"},
 	})
 
 	return
 }
 
-func (refView *RefView) renderFooter(win RenderWindow, selectedRenderedRef *RenderedRef) (err error) {
-	var footer string
+func (refView *RefView) renderTitle(win RenderWindow) error {
+	titleBuilder := NewTitleBuilder("Refs")
 
-	if filters := refView.renderedRefs.Children(); filters > 0 {
-		plural := ""
-		if filters > 1 {
-			plural = "s"
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+	if activeRowIndex := refView.viewPos.ActiveRowIndex(); activeRowIndex < uint(len(renderedRefs)) {
+		selectedRenderedRef := renderedRefs[activeRowIndex]
+
+		if selectedRenderedRef.refList != nil {
+			titleBuilder.Breadcrumb(selectedRenderedRef.refList.name)
 		}
 
-		footer = fmt.Sprintf("%v filter%v applied", filters, plural)
-	} else {
-		switch selectedRenderedRef.renderedRefType {
-		case RvLocalBranchGroup:
-			if localBranches, _, loading := refView.repoData.Branches(); loading {
-				footer = "Branches: Loading..."
-			} else {
-				footer = fmt.Sprintf("Branches: %v", len(localBranches))
-			}
-		case RvRemoteBranchGroup:
-			if _, remoteBranches, loading := refView.repoData.Branches(); loading {
-				footer = "Remote Branches: Loading..."
-			} else {
-				footer = fmt.Sprintf("Remote Branches: %v", len(remoteBranches))
-			}
-		case RvLocalBranch:
-			localBranches, _, _ := refView.repoData.Branches()
-			footer = fmt.Sprintf("Branch %v of %v", selectedRenderedRef.refNum, len(localBranches))
-		case RvRemoteBranch:
-			_, remoteBranches, _ := refView.repoData.Branches()
-			footer = fmt.Sprintf("Remote Branch %v of %v", selectedRenderedRef.refNum, len(remoteBranches))
-		case RvTagGroup:
-			if tags, loading := refView.repoData.LocalTags(); loading {
-				footer = "Tags: Loading"
-			} else {
-				footer = fmt.Sprintf("Tags: %v", len(tags))
-			}
-		case RvTag:
-			tags, _ := refView.repoData.LocalTags()
-			footer = fmt.Sprintf("Tag %v of %v", selectedRenderedRef.refNum, len(tags))
+		if selectedRenderedRef.renderedRefType == RvRemoteBranch {
+			branchName := strings.TrimLeft(selectedRenderedRef.value, " ")
+			titleBuilder.Breadcrumb(strings.SplitN(branchName, "/", 2)[0])
 		}
 	}
 
-	if footer != "" {
+	if filters := refView.renderedRefs.Children(); filters > 0 {
+		titleBuilder.Detail("filtered")
+	}
+
+	if base := refView.comparisons.base; base != "" {
+		titleBuilder.Detail("compared with %v", base)
+	}
+
+	return win.SetTitle(CmpRefviewTitle, "%v", titleBuilder)
+}
+
+func (refView *RefView) renderFooter(win RenderWindow) (err error) {
+	values := make(StatuslineValues)
+	refView.statuslineValues(values)
+
+	if footer := expandFooter(refView.config.GetString(CfRefViewFooter), values); footer != "" {
 		err = win.SetFooter(CmpRefviewFooter, "%v", footer)
 	}
 
 	return
 }
 
-func (refView *RefView) generateRenderedRefs() {
-	log.Debug("Generating Rendered Refs")
-	refView.renderedRefs.Clear()
-	renderedRefs := refView.renderedRefs
+// StatuslineValues adds placeholder values describing the selected ref
+func (refView *RefView) StatuslineValues(values StatuslineValues) {
+	refView.lock.Lock()
+	defer refView.lock.Unlock()
 
-	for refIndex, refList := range refView.refLists {
-		expandChar := "+"
-		if refList.expanded {
-			expandChar = "-"
-		}
+	refView.statuslineValues(values)
+}
 
-		renderedRefs.Add(&RenderedRef{
-			value:           fmt.Sprintf("  [%v] %v", expandChar, refList.name),
-			refList:         refList,
+func (refView *RefView) statuslineValues(values StatuslineValues) {
+	values["view"] = "Refs"
+	values["filters"] = filtersText(refView.renderedRefs.Children())
+	values["progress"] = refView.progress.String()
+
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+	if activeRowIndex := refView.viewPos.ActiveRowIndex(); activeRowIndex < uint(len(renderedRefs)) {
+		values["position"] = refView.selectedRefPosition(renderedRefs[activeRowIndex])
+	}
+
+	if branch := refView.selectedLocalBranch(); branch != nil {
+		values["description"] = FirstLine(refView.descriptions[branch.name])
+	}
+}
+
+// selectedLocalBranch returns the selected branch if it is a local branch
+func (refView *RefView) selectedLocalBranch() *Branch {
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+	activeRowIndex := refView.viewPos.ActiveRowIndex()
+
+	if activeRowIndex >= uint(len(renderedRefs)) {
+		return nil
+	}
+
+	renderedRef := renderedRefs[activeRowIndex]
+	switch renderedRef.renderedRefType {
+	case RvLocalBranch, RvRecentBranch, RvPinnedRef:
+	default:
+		return nil
+	}
+
+	branchName := strings.TrimLeft(renderedRef.value, " ")
+	localBranches, _, _ := refView.repoData.Branches()
+
+	for _, branch := range localBranches {
+		if branch.name == branchName {
+			return branch
+		}
+	}
+
+	return nil
+}
+
+// selectedRemoteBranch returns the selected branch if it is a remote branch
+func (refView *RefView) selectedRemoteBranch() *Branch {
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+	activeRowIndex := refView.viewPos.ActiveRowIndex()
+
+	if activeRowIndex >= uint(len(renderedRefs)) {
+		return nil
+	}
+
+	switch renderedRefs[activeRowIndex].renderedRefType {
+	case RvRemoteBranch, RvPinnedRef:
+	default:
+		return nil
+	}
+
+	branchName := strings.TrimLeft(renderedRefs[activeRowIndex].value, " ")
+	_, remoteBranches, _ := refView.repoData.Branches()
+
+	for _, branch := range remoteBranches {
+		if branch.name == branchName {
+			return branch
+		}
+	}
+
+	return nil
+}
+
+// selectedRefPosition describes the position of the selected ref amongst refs of the same type
+// or the number of filters applied if the refs are filtered
+func (refView *RefView) selectedRefPosition(selectedRenderedRef *RenderedRef) (position string) {
+	if filters := refView.renderedRefs.Children(); filters > 0 {
+		plural := ""
+		if filters > 1 {
+			plural = "s"
+		}
+
+		position = fmt.Sprintf("%v filter%v applied", filters, plural)
+	} else {
+		switch selectedRenderedRef.renderedRefType {
+		case RvPinnedRefGroup:
+			position = fmt.Sprintf("Pinned Refs: %v", len(refView.pinned))
+		case RvPinnedRef:
+			position = fmt.Sprintf("Pinned Ref %v of %v", selectedRenderedRef.refNum, len(refView.pinned))
+		case RvRecentBranchGroup:
+			position = fmt.Sprintf("Recent Branches: %v", len(refView.recent))
+		case RvRecentBranch:
+			position = fmt.Sprintf("Recent Branch %v of %v", selectedRenderedRef.refNum, len(refView.recent))
+		case RvLocalBranchGroup:
+			if localBranches, _, loading := refView.repoData.Branches(); loading {
+				position = "Branches"
+			} else {
+				position = fmt.Sprintf("Branches: %v", len(localBranches))
+			}
+		case RvRemoteBranchGroup:
+			if _, remoteBranches, loading := refView.repoData.Branches(); loading {
+				position = "Remote Branches"
+			} else {
+				position = fmt.Sprintf("Remote Branches: %v", len(remoteBranches))
+			}
+		case RvLocalBranch:
+			localBranches, _, _ := refView.repoData.Branches()
+			position = fmt.Sprintf("Branch %v of %v", selectedRenderedRef.refNum, len(localBranches))
+		case RvRemoteBranch:
+			_, remoteBranches, _ := refView.repoData.Branches()
+			position = fmt.Sprintf("Remote Branch %v of %v", selectedRenderedRef.refNum, len(remoteBranches))
+		case RvTagGroup:
+			if tags, loading := refView.repoData.LocalTags(); loading {
+				position = "Tags"
+			} else {
+				position = fmt.Sprintf("Tags: %v", len(tags))
+			}
+		case RvTag:
+			tags, _ := refView.repoData.LocalTags()
+			position = fmt.Sprintf("Tag %v of %v", selectedRenderedRef.refNum, len(tags))
+		case RvStashGroup:
+			position = fmt.Sprintf("Stashes: %v", len(refView.repoData.Stashes()))
+		case RvStash:
+			position = fmt.Sprintf("Stash %v of %v", selectedRenderedRef.refNum, len(refView.repoData.Stashes()))
+		case RvUnreachableCommitGroup:
+			if refView.unreachable.loading {
+				position = "Unreachable Commits"
+			} else {
+				position = fmt.Sprintf("Unreachable Commits: %v", len(refView.unreachable.commits))
+			}
+		case RvUnreachableCommit:
+			position = fmt.Sprintf("Unreachable Commit %v of %v", selectedRenderedRef.refNum, len(refView.unreachable.commits))
+		}
+	}
+
+	return
+}
+
+// generateRenderedRefs generates the rows of each list of refs
+// The pinned group is omitted while no refs are pinned
+func (refView *RefView) generateRenderedRefs() {
+	log.Debug("Generating Rendered Refs")
+	refView.renderedRefs.Clear()
+	renderedRefs := refView.renderedRefs
+
+	for refIndex, refList := range refView.refLists {
+		if refList.renderedRefType == RvPinnedRefGroup && len(refView.pinned) == 0 {
+			continue
+		}
+
+		expandChar := "+"
+		if refList.expanded {
+			expandChar = "-"
+		}
+
+		renderedRefs.Add(&RenderedRef{
+			value:           fmt.Sprintf("  [%v] %v", expandChar, refList.name),
+			refList:         refList,
 			renderedRefType: refList.renderedRefType,
 		})
 
@@ -478,9 +1102,24 @@ func generateBranches(refView *RefView, refList *refList, renderedRefs renderedR
 		branches = localBranches
 
 		if head, headBranch := refView.repoData.Head(); headBranch == nil {
+			value := fmt.Sprintf("   %s", getDetachedHeadDisplayValue(refView.repoData, head))
+			if description := refView.detachedHeadRefsDescription(head); description != "" {
+				value = fmt.Sprintf("%v (%v)", value, description)
+			}
+
 			renderedRefs.Add(&RenderedRef{
-				value:           fmt.Sprintf("   %s", getDetachedHeadDisplayValue(head)),
+				value:           value,
 				oid:             head,
+				refList:         refList,
+				renderedRefType: branchRenderedRefType,
+				refNum:          branchNum,
+			})
+
+			branchNum++
+		} else if head == nil {
+			renderedRefs.Add(&RenderedRef{
+				value:           fmt.Sprintf("   %s", headBranch.name),
+				refList:         refList,
 				renderedRefType: branchRenderedRefType,
 				refNum:          branchNum,
 			})
@@ -496,6 +1135,7 @@ func generateBranches(refView *RefView, refList *refList, renderedRefs renderedR
 		renderedRefs.Add(&RenderedRef{
 			value:           fmt.Sprintf("   %s", branch.name),
 			oid:             branch.oid,
+			refList:         refList,
 			renderedRefType: branchRenderedRefType,
 			refNum:          branchNum,
 		})
@@ -504,6 +1144,71 @@ func generateBranches(refView *RefView, refList *refList, renderedRefs renderedR
 	}
 }
 
+func generatePinnedRefs(refView *RefView, refList *refList, renderedRefs renderedRefSet) {
+	localBranches, remoteBranches, loading := refView.repoData.Branches()
+
+	if loading {
+		renderedRefs.Add(&RenderedRef{
+			value:           "   Loading...",
+			renderedRefType: RvLoading,
+		})
+
+		return
+	}
+
+	tags, _ := refView.repoData.LocalTags()
+
+	for refIndex, ref := range resolvePinnedRefs(refView.pinned, localBranches, remoteBranches, tags) {
+		renderedRefs.Add(&RenderedRef{
+			value:           fmt.Sprintf("   %s", ref.name),
+			oid:             ref.oid,
+			refList:         refList,
+			renderedRefType: RvPinnedRef,
+			refNum:          uint(refIndex + 1),
+		})
+	}
+}
+
+// resolvePinnedRefs returns the branches and tags with the pinned names in the order they were pinned
+// Local branches take precedence over remote branches and tags with the same name.
+// Names which do not match any branch or tag are omitted
+func resolvePinnedRefs(refNames []string, localBranches, remoteBranches []*Branch, tags []*Tag) (refs []pinnedRef) {
+	refOids := make(map[string]*Oid)
+
+	for _, tag := range tags {
+		refOids[tag.name] = tag.oid
+	}
+
+	for _, branches := range [][]*Branch{remoteBranches, localBranches} {
+		for _, branch := range branches {
+			refOids[branch.name] = branch.oid
+		}
+	}
+
+	for _, refName := range refNames {
+		if oid, ok := refOids[refName]; ok {
+			refs = append(refs, pinnedRef{
+				name: refName,
+				oid:  oid,
+			})
+		}
+	}
+
+	return
+}
+
+func generateRecentBranches(refView *RefView, refList *refList, renderedRefs renderedRefSet) {
+	for branchIndex, branch := range refView.recent {
+		renderedRefs.Add(&RenderedRef{
+			value:           fmt.Sprintf("   %s", branch.name),
+			oid:             branch.oid,
+			refList:         refList,
+			renderedRefType: RvRecentBranch,
+			refNum:          uint(branchIndex + 1),
+		})
+	}
+}
+
 func generateTags(refView *RefView, refList *refList, renderedRefs renderedRefSet) {
 	tags, loading := refView.repoData.LocalTags()
 
@@ -520,12 +1225,98 @@ func generateTags(refView *RefView, refList *refList, renderedRefs renderedRefSe
 		renderedRefs.Add(&RenderedRef{
 			value:           fmt.Sprintf("   %s", tag.name),
 			oid:             tag.oid,
+			refList:         refList,
 			renderedRefType: RvTag,
 			refNum:          uint(tagIndex + 1),
 		})
 	}
 }
 
+func generateUnreachableCommits(refView *RefView, refList *refList, renderedRefs renderedRefSet) {
+	if refView.unreachable.loading {
+		renderedRefs.Add(&RenderedRef{
+			value:           "   Searching...",
+			renderedRefType: RvLoading,
+		})
+
+		return
+	}
+
+	for commitIndex, commit := range refView.unreachable.commits {
+		renderedRefs.Add(&RenderedRef{
+			value:           fmt.Sprintf("   %v %v", refView.repoData.ShortID(commit.oid), commit.commit.Summary()),
+			oid:             commit.oid,
+			refList:         refList,
+			renderedRefType: RvUnreachableCommit,
+			refNum:          uint(commitIndex + 1),
+		})
+	}
+}
+
+func generateStashes(refView *RefView, refList *refList, renderedRefs renderedRefSet) {
+	for stashIndex, stash := range refView.repoData.Stashes() {
+		renderedRefs.Add(&RenderedRef{
+			value:           fmt.Sprintf("   %s", stash),
+			oid:             stash.oid,
+			stash:           stash,
+			refList:         refList,
+			renderedRefType: RvStash,
+			refNum:          uint(stashIndex + 1),
+		})
+	}
+}
+
+// ExpandedRefLists returns whether each list of refs is expanded, keyed by list name
+// The unreachable commits list is omitted as expanding it starts a search of the repository
+func (refView *RefView) ExpandedRefLists() map[string]bool {
+	refView.lock.Lock()
+	defer refView.lock.Unlock()
+
+	expandedRefLists := make(map[string]bool)
+
+	for _, refList := range refView.refLists {
+		if refList.renderedRefType != RvUnreachableCommitGroup {
+			expandedRefLists[refList.name] = refList.expanded
+		}
+	}
+
+	return expandedRefLists
+}
+
+// SetExpandedRefLists expands or collapses the named lists of refs
+func (refView *RefView) SetExpandedRefLists(expandedRefLists map[string]bool) {
+	refView.lock.Lock()
+	defer refView.lock.Unlock()
+
+	for _, refList := range refView.refLists {
+		if expanded, ok := expandedRefLists[refList.name]; ok && refList.renderedRefType != RvUnreachableCommitGroup {
+			refList.expanded = expanded
+		}
+	}
+
+	refView.generateRenderedRefs()
+	refView.channels.UpdateDisplay()
+}
+
+// PinnedRefs returns the names of the pinned refs in the order they were pinned
+func (refView *RefView) PinnedRefs() []string {
+	refView.lock.Lock()
+	defer refView.lock.Unlock()
+
+	return append([]string(nil), refView.pinned...)
+}
+
+// SetPinnedRefs replaces the pinned refs with the named refs
+func (refView *RefView) SetPinnedRefs(refNames []string) {
+	refView.lock.Lock()
+	defer refView.lock.Unlock()
+
+	refView.pinned = append([]string(nil), refNames...)
+
+	refView.generateRenderedRefs()
+	refView.channels.UpdateDisplay()
+}
+
 // OnActiveChange updates whether the ref view is active or not
 func (refView *RefView) OnActiveChange(active bool) {
 	log.Debugf("RefView active: %v", active)
@@ -611,101 +1402,164 @@ func (refView *RefView) HandleAction(action Action) (err error) {
 }
 
 func moveUpRef(refView *RefView, action Action) (err error) {
-	viewPos := refView.viewPos
-
-	if viewPos.ActiveRowIndex() == 0 {
-		return
+	if refView.moveUpRefs(action.RepeatCount()) {
+		log.Debugf("Moved up %v refs", action.RepeatCount())
+		refView.channels.UpdateDisplay()
 	}
 
-	log.Debug("Moving up one ref")
-
-	renderedRefs := refView.renderedRefs.RenderedRefs()
-	startIndex := viewPos.ActiveRowIndex()
-	activeRowIndex := startIndex - 1
+	return
+}
 
-	for activeRowIndex > 0 {
-		renderedRef := renderedRefs[activeRowIndex]
+func moveDownRef(refView *RefView, action Action) (err error) {
+	if refView.moveDownRefs(action.RepeatCount()) {
+		log.Debugf("Moved down %v refs", action.RepeatCount())
+		refView.channels.UpdateDisplay()
+	}
 
-		if isSelectableRenderedRef(renderedRef.renderedRefType) {
-			break
-		}
+	return
+}
 
-		activeRowIndex--
+func moveUpRefPage(refView *RefView, action Action) (err error) {
+	if refView.moveUpRefs((refView.viewDimension.rows - 2) * action.RepeatCount()) {
+		log.Debugf("Moved up %v pages", action.RepeatCount())
+		refView.channels.UpdateDisplay()
 	}
 
-	renderedRef := renderedRefs[activeRowIndex]
-	if isSelectableRenderedRef(renderedRef.renderedRefType) {
-		viewPos.SetActiveRowIndex(activeRowIndex)
+	return
+}
+
+func moveDownRefPage(refView *RefView, action Action) (err error) {
+	if refView.moveDownRefs((refView.viewDimension.rows - 2) * action.RepeatCount()) {
+		log.Debugf("Moved down %v pages", action.RepeatCount())
 		refView.channels.UpdateDisplay()
-	} else {
-		log.Debug("No valid ref entry to move to")
 	}
 
 	return
 }
 
-func moveDownRef(refView *RefView, action Action) (err error) {
-	renderedRefs := refView.renderedRefs.RenderedRefs()
-	renderedRefNum := uint(len(renderedRefs))
-	viewPos := refView.viewPos
-
-	if renderedRefNum == 0 || !(viewPos.ActiveRowIndex() < renderedRefNum-1) {
-		return
+func moveUpRefHalfPage(refView *RefView, action Action) (err error) {
+	if refView.moveUpRefs(halfPageRows(refView.viewDimension.rows-2) * action.RepeatCount()) {
+		log.Debugf("Moved up %v half pages", action.RepeatCount())
+		refView.channels.UpdateDisplay()
 	}
 
-	log.Debug("Moving down one ref")
-
-	startIndex := viewPos.ActiveRowIndex()
-	activeRowIndex := startIndex + 1
+	return
+}
 
-	for activeRowIndex < renderedRefNum-1 {
-		renderedRef := renderedRefs[activeRowIndex]
+func moveDownRefHalfPage(refView *RefView, action Action) (err error) {
+	if refView.moveDownRefs(halfPageRows(refView.viewDimension.rows-2) * action.RepeatCount()) {
+		log.Debugf("Moved down %v half pages", action.RepeatCount())
+		refView.channels.UpdateDisplay()
+	}
 
-		if isSelectableRenderedRef(renderedRef.renderedRefType) {
-			break
-		}
+	return
+}
 
-		activeRowIndex++
+func centerRefView(refView *RefView, action Action) (err error) {
+	if refView.viewPos.CenterActiveRow(refView.viewDimension.rows - 2) {
+		log.Debug("Centering selected ref")
+		refView.channels.UpdateDisplay()
 	}
 
-	renderedRef := renderedRefs[activeRowIndex]
-	if isSelectableRenderedRef(renderedRef.renderedRefType) {
-		viewPos.SetActiveRowIndex(activeRowIndex)
+	return
+}
+
+func scrollRefViewCursorTop(refView *RefView, action Action) (err error) {
+	if refView.viewPos.ScrollActiveRowToTop() {
+		log.Debug("Scrolling selected ref to top of view")
 		refView.channels.UpdateDisplay()
-	} else {
-		log.Debug("No valid ref entry to move to")
 	}
 
 	return
 }
 
-func moveUpRefPage(refView *RefView, action Action) (err error) {
-	pageSize := refView.viewDimension.rows - 2
-	viewPos := refView.viewPos
+func scrollRefViewCursorBottom(refView *RefView, action Action) (err error) {
+	if refView.viewPos.ScrollActiveRowToBottom(refView.viewDimension.rows - 2) {
+		log.Debug("Scrolling selected ref to bottom of view")
+		refView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+// moveUpRefs moves the selection up by the specified number of selectable refs
+func (refView *RefView) moveUpRefs(refNum uint) (moved bool) {
+	for ; refNum > 0 && refView.selectPrevRef(); refNum-- {
+		moved = true
+	}
+
+	return
+}
+
+// moveDownRefs moves the selection down by the specified number of selectable refs
+func (refView *RefView) moveDownRefs(refNum uint) (moved bool) {
+	for ; refNum > 0 && refView.selectNextRef(); refNum-- {
+		moved = true
+	}
+
+	return
+}
+
+func (refView *RefView) selectPrevRef() (moved bool) {
+	viewPos := refView.viewPos
+
+	if viewPos.ActiveRowIndex() == 0 {
+		return
+	}
+
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+	startIndex := viewPos.ActiveRowIndex()
+	activeRowIndex := startIndex - 1
+
+	for activeRowIndex > 0 {
+		renderedRef := renderedRefs[activeRowIndex]
 
-	for viewPos.ActiveRowIndex() > 0 && pageSize > 0 {
-		if err = moveUpRef(refView, action); err != nil {
+		if isSelectableRenderedRef(renderedRef.renderedRefType) {
 			break
-		} else {
-			pageSize--
 		}
+
+		activeRowIndex--
+	}
+
+	renderedRef := renderedRefs[activeRowIndex]
+	if isSelectableRenderedRef(renderedRef.renderedRefType) {
+		viewPos.SetActiveRowIndex(activeRowIndex)
+		moved = true
+	} else {
+		log.Debug("No valid ref entry to move to")
 	}
 
 	return
 }
 
-func moveDownRefPage(refView *RefView, action Action) (err error) {
+func (refView *RefView) selectNextRef() (moved bool) {
 	renderedRefs := refView.renderedRefs.RenderedRefs()
 	renderedRefNum := uint(len(renderedRefs))
-	pageSize := refView.viewDimension.rows - 2
 	viewPos := refView.viewPos
 
-	for viewPos.ActiveRowIndex()+1 < renderedRefNum && pageSize > 0 {
-		if err = moveDownRef(refView, action); err != nil {
+	if renderedRefNum == 0 || !(viewPos.ActiveRowIndex() < renderedRefNum-1) {
+		return
+	}
+
+	startIndex := viewPos.ActiveRowIndex()
+	activeRowIndex := startIndex + 1
+
+	for activeRowIndex < renderedRefNum-1 {
+		renderedRef := renderedRefs[activeRowIndex]
+
+		if isSelectableRenderedRef(renderedRef.renderedRefType) {
 			break
-		} else {
-			pageSize--
 		}
+
+		activeRowIndex++
+	}
+
+	renderedRef := renderedRefs[activeRowIndex]
+	if isSelectableRenderedRef(renderedRef.renderedRefType) {
+		viewPos.SetActiveRowIndex(activeRowIndex)
+		moved = true
+	} else {
+		log.Debug("No valid ref entry to move to")
 	}
 
 	return
@@ -760,14 +1614,27 @@ func selectRef(refView *RefView, action Action) (err error) {
 	renderedRef := renderedRefs[refView.viewPos.ActiveRowIndex()]
 
 	switch renderedRef.renderedRefType {
-	case RvLocalBranchGroup, RvRemoteBranchGroup, RvTagGroup:
+	case RvPinnedRefGroup, RvRecentBranchGroup, RvLocalBranchGroup, RvRemoteBranchGroup, RvTagGroup, RvStashGroup, RvUnreachableCommitGroup:
 		renderedRef.refList.expanded = !renderedRef.refList.expanded
 		log.Debugf("Setting ref group %v to expanded %v", renderedRef.refList.name, renderedRef.refList.expanded)
+
+		if renderedRef.renderedRefType == RvUnreachableCommitGroup && renderedRef.refList.expanded {
+			refView.findUnreachableCommits()
+		}
+
 		refView.generateRenderedRefs()
 		refView.channels.UpdateDisplay()
-	case RvLocalBranch, RvRemoteBranch, RvTag:
+	case RvPinnedRef, RvRecentBranch, RvLocalBranch, RvRemoteBranch, RvTag, RvStash, RvUnreachableCommit:
 		log.Debugf("Selecting ref %v:%v", renderedRef.value, renderedRef.oid)
-		if err = refView.notifyRefListeners(strings.TrimLeft(renderedRef.value, " "), renderedRef.oid); err != nil {
+		refName, oid := strings.TrimLeft(renderedRef.value, " "), renderedRef.oid
+
+		if len(refView.markedRefs) > 0 && oid != nil {
+			if refName, oid, err = refView.markedRefsWalk(refName, oid); err != nil {
+				return
+			}
+		}
+
+		if err = refView.notifyRefListeners(refName, oid); err != nil {
 			return
 		}
 		refView.channels.UpdateDisplay()
@@ -778,6 +1645,260 @@ func selectRef(refView *RefView, action Action) (err error) {
 	return
 }
 
+// markRef toggles whether the history of the selected ref is included when a ref is selected
+func markRef(refView *RefView, action Action) (err error) {
+	return refView.toggleMarkedRef(false)
+}
+
+// excludeRef toggles whether commits reachable from the selected ref are excluded when a ref is selected
+func excludeRef(refView *RefView, action Action) (err error) {
+	return refView.toggleMarkedRef(true)
+}
+
+func (refView *RefView) toggleMarkedRef(hidden bool) (err error) {
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+	renderedRef := renderedRefs[refView.viewPos.ActiveRowIndex()]
+
+	switch renderedRef.renderedRefType {
+	case RvPinnedRef, RvRecentBranch, RvLocalBranch, RvRemoteBranch, RvTag, RvStash, RvUnreachableCommit:
+	default:
+		return fmt.Errorf("Only refs can be marked")
+	}
+
+	if renderedRef.oid == nil {
+		return fmt.Errorf("Branch %v has no commits yet", strings.TrimLeft(renderedRef.value, " "))
+	}
+
+	refName := strings.TrimLeft(renderedRef.value, " ")
+
+	if markedRefIndex := refView.markedRefIndex(refName); markedRefIndex == -1 {
+		refView.markedRefs = append(refView.markedRefs, &markedRef{
+			name:   refName,
+			oid:    renderedRef.oid,
+			hidden: hidden,
+		})
+	} else if refView.markedRefs[markedRefIndex].hidden != hidden {
+		refView.markedRefs[markedRefIndex].hidden = hidden
+	} else {
+		refView.markedRefs = append(refView.markedRefs[:markedRefIndex], refView.markedRefs[markedRefIndex+1:]...)
+	}
+
+	if len(refView.markedRefs) == 0 {
+		refView.channels.ReportStatus("No refs marked")
+	} else {
+		refView.channels.ReportStatus("Marked refs: %v", strings.Join(refView.markedRefNames(), " "))
+	}
+
+	refView.channels.UpdateDisplay()
+
+	return
+}
+
+// pinRef pins the selected branch or tag so it is displayed in the pinned group, or unpins it if it is already pinned
+func pinRef(refView *RefView, action Action) (err error) {
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+	renderedRef := renderedRefs[refView.viewPos.ActiveRowIndex()]
+
+	switch renderedRef.renderedRefType {
+	case RvPinnedRef, RvRecentBranch, RvLocalBranch, RvRemoteBranch, RvTag:
+	default:
+		return fmt.Errorf("Only branches and tags can be pinned")
+	}
+
+	refName := strings.TrimLeft(renderedRef.value, " ")
+
+	if pinnedRefIndex := refView.pinnedRefIndex(refName); pinnedRefIndex != -1 {
+		refView.pinned = append(refView.pinned[:pinnedRefIndex], refView.pinned[pinnedRefIndex+1:]...)
+		refView.channels.ReportStatus("Unpinned %v", refName)
+	} else {
+		localBranches, remoteBranches, _ := refView.repoData.Branches()
+		tags, _ := refView.repoData.LocalTags()
+
+		if len(resolvePinnedRefs([]string{refName}, localBranches, remoteBranches, tags)) == 0 {
+			return fmt.Errorf("Only branches and tags can be pinned")
+		}
+
+		refView.pinned = append(refView.pinned, refName)
+		refView.channels.ReportStatus("Pinned %v", refName)
+	}
+
+	refView.generateRenderedRefs()
+	refView.selectRenderedRef(renderedRef)
+	refView.channels.UpdateDisplay()
+
+	return
+}
+
+// setCompareBase compares branches with the selected branch, or with the HEAD branch if the selected branch is already the compare base
+func setCompareBase(refView *RefView, action Action) (err error) {
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+	renderedRef := renderedRefs[refView.viewPos.ActiveRowIndex()]
+
+	switch renderedRef.renderedRefType {
+	case RvPinnedRef, RvRecentBranch, RvLocalBranch, RvRemoteBranch:
+	default:
+		return fmt.Errorf("Only branches can be compared with")
+	}
+
+	branchName := strings.TrimLeft(renderedRef.value, " ")
+
+	if branchName == refView.compareBase {
+		refView.compareBase = ""
+	} else {
+		if refView.selectedLocalBranch() == nil && refView.selectedRemoteBranch() == nil {
+			return fmt.Errorf("Only branches can be compared with")
+		}
+
+		refView.compareBase = branchName
+	}
+
+	base, _ := refView.compareBaseBranch()
+	refView.channels.ReportStatus("Comparing branches with %v", base)
+	refView.loadRefComparisons()
+	refView.channels.UpdateDisplay()
+
+	return
+}
+
+func (refView *RefView) pinnedRefIndex(refName string) int {
+	for pinnedRefIndex, pinnedRefName := range refView.pinned {
+		if pinnedRefName == refName {
+			return pinnedRefIndex
+		}
+	}
+
+	return -1
+}
+
+// selectRenderedRef selects the row displaying the same ref in the same list as the provided row
+// after the rows have been regenerated. The nearest selectable row is selected if it is no longer displayed
+func (refView *RefView) selectRenderedRef(selectedRenderedRef *RenderedRef) {
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+
+	for rowIndex, renderedRef := range renderedRefs {
+		if renderedRef.renderedRefType == selectedRenderedRef.renderedRefType && renderedRef.value == selectedRenderedRef.value {
+			refView.viewPos.SetActiveRowIndex(uint(rowIndex))
+			return
+		}
+	}
+
+	renderedRefNum := uint(len(renderedRefs))
+	if activeRowIndex := refView.viewPos.ActiveRowIndex(); renderedRefNum > 0 && activeRowIndex >= renderedRefNum {
+		refView.viewPos.SetActiveRowIndex(renderedRefNum - 1)
+	}
+
+	if activeRowIndex := refView.viewPos.ActiveRowIndex(); activeRowIndex < renderedRefNum &&
+		!isSelectableRenderedRef(renderedRefs[activeRowIndex].renderedRefType) {
+		refView.selectPrevRef()
+	}
+}
+
+func (refView *RefView) markedRefIndex(refName string) int {
+	for markedRefIndex, markedRef := range refView.markedRefs {
+		if markedRef.name == refName {
+			return markedRefIndex
+		}
+	}
+
+	return -1
+}
+
+// markedRefPrefix returns the prefix displayed before the provided ref if it is marked
+func (refView *RefView) markedRefPrefix(renderedRef *RenderedRef) (prefix string, isMarked bool) {
+	if len(refView.markedRefs) == 0 || renderedRef.oid == nil {
+		return
+	}
+
+	markedRefIndex := refView.markedRefIndex(strings.TrimLeft(renderedRef.value, " "))
+	if markedRefIndex == -1 {
+		return
+	}
+
+	if refView.markedRefs[markedRefIndex].hidden {
+		return rvExcludedRefPrefix, true
+	}
+
+	return rvMarkedRefPrefix, true
+}
+
+// markedRefNames returns the names of the marked refs with excluded refs prefixed with ^
+func (refView *RefView) markedRefNames() (refNames []string) {
+	for _, markedRef := range refView.markedRefs {
+		if markedRef.hidden {
+			refNames = append(refNames, "^"+markedRef.name)
+		} else {
+			refNames = append(refNames, markedRef.name)
+		}
+	}
+
+	return
+}
+
+// markedRefsWalk returns the name and oid to display the union of the histories of the
+// marked refs and the selected ref (if it is not marked) excluding commits reachable from excluded refs
+func (refView *RefView) markedRefsWalk(selectedRefName string, selectedOid *Oid) (refName string, oid *Oid, err error) {
+	refNames := refView.markedRefNames()
+	var oids, hiddenOids []*Oid
+
+	for _, markedRef := range refView.markedRefs {
+		if markedRef.hidden {
+			hiddenOids = append(hiddenOids, markedRef.oid)
+		} else {
+			oids = append(oids, markedRef.oid)
+		}
+	}
+
+	if refView.markedRefIndex(selectedRefName) == -1 {
+		refNames = append(refNames, selectedRefName)
+		oids = append(oids, selectedOid)
+	}
+
+	if oid, err = refView.repoData.MultiRefOid(oids, hiddenOids); err != nil {
+		return
+	}
+
+	refName = strings.Join(refNames, " ")
+
+	return
+}
+
+// showRefGroup expands and selects the ref group specified by the view target
+// If the view target specifies a ref name then the ref in the group is selected instead
+func showRefGroup(refView *RefView, action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected view target argument")
+	}
+
+	viewTarget, ok := action.Args[0].(ViewTarget)
+	if !ok {
+		return fmt.Errorf("Expected view target argument to have type ViewTarget")
+	}
+
+	for _, refList := range refView.refLists {
+		if refList.renderedRefType == viewTarget.refGroup {
+			refList.expanded = true
+		}
+	}
+
+	refView.generateRenderedRefs()
+
+	for rowIndex, renderedRef := range refView.renderedRefs.RenderedRefs() {
+		if viewTarget.refName == "" && renderedRef.renderedRefType == viewTarget.refGroup {
+			refView.viewPos.SetActiveRowIndex(uint(rowIndex))
+			break
+		} else if viewTarget.refName != "" && renderedRef.renderedRefType != viewTarget.refGroup &&
+			renderedRef.refList != nil && renderedRef.refList.renderedRefType == viewTarget.refGroup &&
+			strings.TrimLeft(renderedRef.value, " ") == viewTarget.refName {
+			refView.viewPos.SetActiveRowIndex(uint(rowIndex))
+			break
+		}
+	}
+
+	refView.channels.UpdateDisplay()
+
+	return
+}
+
 func addRefFilter(refView *RefView, action Action) (err error) {
 	if !(len(action.Args) > 0) {
 		return fmt.Errorf("Expected filter query argument")
@@ -816,3 +1937,630 @@ func removeRefFilter(refView *RefView, action Action) (err error) {
 
 	return
 }
+
+func (refView *RefView) selectedStash() (*Stash, error) {
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+	renderedRef := renderedRefs[refView.viewPos.ActiveRowIndex()]
+
+	if renderedRef.renderedRefType != RvStash {
+		return nil, fmt.Errorf("No stash selected")
+	}
+
+	return renderedRef.stash, nil
+}
+
+func (refView *RefView) onStashesChanged(status string) {
+	refView.generateRenderedRefs()
+
+	renderedRefNum := uint(len(refView.renderedRefs.RenderedRefs()))
+	if activeRowIndex := refView.viewPos.ActiveRowIndex(); renderedRefNum > 0 && activeRowIndex >= renderedRefNum {
+		refView.viewPos.SetActiveRowIndex(renderedRefNum - 1)
+	}
+
+	refView.channels.ReportStatus("%v", status)
+	refView.channels.UpdateDisplay()
+}
+
+// findUnreachableCommits searches the repository for unreachable commits in the background
+// The search is repeated each time the unreachable commits group is expanded
+func (refView *RefView) findUnreachableCommits() {
+	if refView.unreachable.loading {
+		return
+	}
+
+	refView.unreachable.loading = true
+	refView.progress.Start(PgFindUnreachable, "Finding unreachable commits")
+
+	go func() {
+		ctx, done := refView.canceller.Start("unreachable commit search")
+		defer done()
+
+		commits, err := refView.repoData.UnreachableCommits(ctx, func(objectsExamined uint) {
+			refView.progress.Update(PgFindUnreachable, objectsExamined, 0)
+		})
+
+		refView.lock.Lock()
+		defer refView.lock.Unlock()
+
+		refView.progress.Stop(PgFindUnreachable)
+		refView.unreachable.loading = false
+
+		if ctx.Err() != nil {
+			log.Info("Unreachable commit search was cancelled")
+		} else if err != nil {
+			refView.channels.ReportError(fmt.Errorf("Unable to find unreachable commits: %v", err))
+		} else {
+			refView.unreachable.commits = commits
+			refView.channels.ReportStatus("Found %v unreachable commits", len(commits))
+		}
+
+		refView.generateRenderedRefs()
+		refView.channels.UpdateDisplay()
+	}()
+}
+
+// reloadBranches loads the current branches and regenerates the displayed refs once they have loaded
+func (refView *RefView) reloadBranches() error {
+	return refView.reloadBranchesAndSelect("")
+}
+
+// reloadBranchesAndSelect reloads branches and, if a branch name is provided,
+// selects the local branch with that name once the branches have loaded
+func (refView *RefView) reloadBranchesAndSelect(branchName string) error {
+	return refView.repoData.LoadBranches(func(localBranches, remoteBranches []*Branch) error {
+		refView.lock.Lock()
+		defer refView.lock.Unlock()
+
+		refView.loadRecentBranches()
+		refView.loadBranchDescriptions()
+		refView.generateRenderedRefs()
+		refView.loadDetachedHeadRefs()
+		refView.loadRefComparisons()
+
+		if branchName != "" {
+			refView.selectLocalBranch(branchName)
+		}
+
+		refView.channels.UpdateDisplay()
+
+		return nil
+	})
+}
+
+func createCommit(refView *RefView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected commit message argument")
+	}
+
+	message, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected commit message argument to have type string")
+	}
+
+	if err = refView.repoData.CreateCommit(message); err != nil {
+		if hookError, ok := err.(*HookError); ok {
+			refView.channels.ReportErrors(hookError.Errors())
+			return nil
+		}
+
+		return
+	}
+
+	head, branch := refView.repoData.Head()
+
+	if err = refView.reloadBranches(); err != nil {
+		return
+	}
+
+	refName := getDetachedHeadDisplayValue(refView.repoData, head)
+	if branch != nil {
+		refName = branch.name
+	}
+
+	if err = refView.notifyRefListeners(refName, head); err != nil {
+		return
+	}
+
+	if branch == nil {
+		refView.channels.ReportStatus("Created commit %v on detached HEAD. Create a branch to keep it reachable",
+			refView.repoData.ShortID(head))
+	} else {
+		refView.channels.ReportStatus("Created commit %v", refView.repoData.ShortID(head))
+	}
+
+	refView.notifier.Notify(NeCommitCreated, "Created commit %v", refView.repoData.ShortID(head))
+
+	return
+}
+
+// reloadRefs reloads HEAD and the branches after they have been modified outside of GRV
+// and displays the history of HEAD
+func reloadRefs(refView *RefView, action Action) (err error) {
+	if err = refView.repoData.LoadHead(); err != nil {
+		return
+	}
+
+	if err = refView.reloadBranches(); err != nil {
+		return
+	}
+
+	if err = refView.loadTags(); err != nil {
+		return
+	}
+
+	head, branch := refView.repoData.Head()
+	if head == nil {
+		return
+	}
+
+	refName := getDetachedHeadDisplayValue(refView.repoData, head)
+	if branch != nil {
+		refName = branch.name
+	}
+
+	return refView.notifyRefListeners(refName, head)
+}
+
+func createBranch(refView *RefView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected branch name argument")
+	}
+
+	branchName, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected branch name argument to have type string")
+	}
+
+	previousHead, previousHeadBranch := refView.repoData.Head()
+	oid := previousHead
+
+	renderedRefs := refView.renderedRefs.RenderedRefs()
+	if activeRowIndex := refView.viewPos.ActiveRowIndex(); activeRowIndex < uint(len(renderedRefs)) &&
+		renderedRefs[activeRowIndex].renderedRefType == RvUnreachableCommit {
+		oid = renderedRefs[activeRowIndex].oid
+	}
+
+	if err = refView.repoData.CreateBranch(branchName, oid); err != nil {
+		return
+	}
+
+	refView.removeUnreachableCommit(oid)
+
+	if err = refView.reloadBranches(); err != nil {
+		return
+	}
+
+	if previousHeadBranch == nil && oid == previousHead {
+		if err = refView.notifyRefListeners(branchName, oid); err != nil {
+			return
+		}
+
+		refView.channels.ReportStatus("Created branch %v at %v and attached HEAD", branchName, refView.repoData.ShortID(oid))
+	} else {
+		refView.channels.ReportStatus("Created branch %v at %v", branchName, refView.repoData.ShortID(oid))
+	}
+
+	return
+}
+
+// removeUnreachableCommit removes a commit which has become reachable from the unreachable commits
+func (refView *RefView) removeUnreachableCommit(oid *Oid) {
+	for commitIndex, commit := range refView.unreachable.commits {
+		if commit.oid == oid {
+			refView.unreachable.commits = append(refView.unreachable.commits[:commitIndex], refView.unreachable.commits[commitIndex+1:]...)
+			return
+		}
+	}
+}
+
+func (refView *RefView) remoteOptions() RemoteOptions {
+	return RemoteOptions{
+		proxyURL:  refView.config.GetString(CfHTTPProxy),
+		sslCAInfo: refView.config.GetString(CfSSLCAInfo),
+		onTransferProgress: func(progress TransferProgress) {
+			refView.progress.Update(PgFetch, progress.receivedObjects, progress.totalObjects)
+		},
+	}
+}
+
+func fetchRemotes(refView *RefView, action Action) (err error) {
+	refView.channels.ReportStatus("Fetching remotes")
+	go refView.fetch(false)
+
+	return
+}
+
+// fetch fetches all remotes and reports any remote branches which have been updated.
+// Status is only reported for background fetches if new commits were fetched
+func (refView *RefView) fetch(background bool) {
+	refView.lock.Lock()
+	if refView.fetching {
+		refView.lock.Unlock()
+		log.Debug("Fetch already in progress")
+		return
+	}
+
+	refView.fetching = true
+	_, previousRemoteBranches, _ := refView.repoData.Branches()
+	refView.progress.Start(PgFetch, "Fetching remotes")
+	refView.lock.Unlock()
+
+	ctx, done := refView.canceller.Start("fetch")
+
+	defer func() {
+		done()
+		refView.lock.Lock()
+		refView.fetching = false
+		refView.progress.Stop(PgFetch)
+		refView.lock.Unlock()
+	}()
+
+	if err := refView.repoData.FetchRemotes(ctx, refView.remoteOptions()); ctx.Err() != nil {
+		log.Info("Fetch was cancelled")
+		return
+	} else if err != nil {
+		refView.channels.ReportError(err)
+		return
+	}
+
+	if err := refView.repoData.LoadBranches(func(localBranches, remoteBranches []*Branch) error {
+		refView.lock.Lock()
+		defer refView.lock.Unlock()
+
+		refView.generateRenderedRefs()
+		refView.loadRefComparisons()
+
+		if updatedBranches := UpdatedBranches(previousRemoteBranches, remoteBranches); len(updatedBranches) > 0 {
+			refView.channels.ReportStatus("New upstream commits on %v", strings.Join(updatedBranches, ", "))
+			refView.notifier.Notify(NeFetchComplete, "New upstream commits on %v", strings.Join(updatedBranches, ", "))
+		} else if !background {
+			refView.channels.ReportStatus("Fetched remotes")
+			refView.notifier.Notify(NeFetchComplete, "Fetched remotes")
+		}
+
+		refView.channels.UpdateDisplay()
+
+		return nil
+	}); err != nil {
+		refView.channels.ReportError(err)
+	}
+
+	// Fetching may have created new tags
+	if err := refView.loadTags(); err != nil {
+		refView.channels.ReportError(err)
+	}
+}
+
+func createStash(refView *RefView, action Action) (err error) {
+	if !(len(action.Args) > 0) {
+		return fmt.Errorf("Expected stash message argument")
+	}
+
+	message, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected stash message argument to have type string")
+	}
+
+	if err = refView.repoData.CreateStash(message); err != nil {
+		return
+	}
+
+	refView.onStashesChanged("Created stash")
+
+	return
+}
+
+func applyStash(refView *RefView, action Action) (err error) {
+	stash, err := refView.selectedStash()
+	if err != nil {
+		return
+	}
+
+	if err = refView.repoData.ApplyStash(stash); err != nil {
+		return
+	}
+
+	refView.onStashesChanged(fmt.Sprintf("Applied stash@{%v}", stash.index))
+
+	return
+}
+
+func popStash(refView *RefView, action Action) (err error) {
+	stash, err := refView.selectedStash()
+	if err != nil {
+		return
+	}
+
+	if err = refView.repoData.PopStash(stash); err != nil {
+		return
+	}
+
+	refView.onStashesChanged(fmt.Sprintf("Popped stash@{%v}", stash.index))
+
+	return
+}
+
+func dropStash(refView *RefView, action Action) (err error) {
+	stash, err := refView.selectedStash()
+	if err != nil {
+		return
+	}
+
+	if confirmed := len(action.Args) > 0 && action.Args[0] == true; !confirmed {
+		refView.channels.DoAction(Action{
+			ActionType: ActionShowDialog,
+			Args: []interface{}{DialogRequest{
+				dialogType: DtYesNo,
+				title:      "Drop Stash",
+				message:    fmt.Sprintf("Drop %v?", stash),
+				action:     Action{ActionType: ActionDropStash, Args: []interface{}{true}},
+			}},
+		})
+
+		return
+	}
+
+	if err = refView.repoData.DropStash(stash); err != nil {
+		return
+	}
+
+	refView.onStashesChanged(fmt.Sprintf("Dropped stash@{%v}", stash.index))
+
+	return
+}
+
+// branchDescriptionTemplate generates the content of the file the branch description is edited in
+func branchDescriptionTemplate(branchName, description string) string {
+	return fmt.Sprintf("%v\n# Please edit the description for the branch\n#   %v\n# Lines starting with '#' will be stripped.\n",
+		description, branchName)
+}
+
+// editBranchDescription opens the description of the selected local branch in the user's editor
+func editBranchDescription(refView *RefView, action Action) (err error) {
+	branch := refView.selectedLocalBranch()
+	if branch == nil {
+		return fmt.Errorf("Select a local branch to edit its description")
+	}
+
+	file, err := ioutil.TempFile("", "grv-branch-description")
+	if err != nil {
+		return
+	}
+
+	_, err = file.WriteString(branchDescriptionTemplate(branch.name, refView.descriptions[branch.name]))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		refView.removeBranchDescriptionFile(file.Name())
+		return
+	}
+
+	branchName := branch.name
+	filePath := file.Name()
+
+	refView.channels.DoAction(Action{
+		ActionType: ActionRunEditor,
+		Args: []interface{}{EditorRequest{
+			filePath: filePath,
+			onComplete: func(err error) {
+				refView.onBranchDescriptionEdited(branchName, filePath, err)
+			},
+		}},
+	})
+
+	return
+}
+
+// onBranchDescriptionEdited stores the description written by the user in the git config
+func (refView *RefView) onBranchDescriptionEdited(branchName, filePath string, editorErr error) {
+	defer refView.removeBranchDescriptionFile(filePath)
+
+	if editorErr != nil {
+		refView.channels.ReportError(editorErr)
+		return
+	}
+
+	content, err := ioutil.ReadFile(filePath)
+	if err != nil {
+		refView.channels.ReportError(fmt.Errorf("Unable to read branch description: %v", err))
+		return
+	}
+
+	description := CleanupCommitMessage(string(content))
+
+	if err = refView.repoData.SetBranchDescription(branchName, description); err != nil {
+		refView.channels.ReportError(fmt.Errorf("Unable to set description of branch %v: %v", branchName, err))
+		return
+	}
+
+	refView.lock.Lock()
+	refView.loadBranchDescriptions()
+	refView.lock.Unlock()
+
+	if description == "" {
+		refView.channels.ReportStatus("Removed description of branch %v", branchName)
+	} else {
+		refView.channels.ReportStatus("Updated description of branch %v", branchName)
+	}
+
+	refView.channels.UpdateDisplay()
+}
+
+func (refView *RefView) removeBranchDescriptionFile(filePath string) {
+	if err := os.Remove(filePath); err != nil {
+		log.Errorf("Unable to remove temporary branch description file: %v", err)
+	}
+}
+
+// setUpstream sets the upstream of the selected local branch
+func setUpstream(refView *RefView, action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected upstream branch argument")
+	}
+
+	upstreamName, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected upstream branch argument to have type string")
+	}
+
+	branch := refView.selectedLocalBranch()
+	if branch == nil {
+		return fmt.Errorf("Select a local branch to set its upstream")
+	}
+
+	if err = refView.repoData.SetUpstream(branch, upstreamName); err != nil {
+		return fmt.Errorf("Unable to set upstream of branch %v to %v: %v", branch.name, upstreamName, err)
+	}
+
+	refView.channels.ReportStatus("Branch %v now tracks %v", branch.name, upstreamName)
+
+	return
+}
+
+func renameRef(refView *RefView, action Action) (err error) {
+	if len(action.Args) == 0 {
+		branch := refView.selectedLocalBranch()
+		if branch == nil {
+			return fmt.Errorf("Select a local branch to rename it")
+		}
+
+		refView.channels.DoAction(Action{
+			ActionType: ActionRenameRefPrompt,
+			Args:       []interface{}{branch.name},
+		})
+
+		return
+	}
+
+	if len(action.Args) < 2 {
+		return fmt.Errorf("Expected branch name and new branch name arguments")
+	}
+
+	branchName, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected branch name argument to have type string")
+	}
+
+	newBranchName, ok := action.Args[1].(string)
+	if !ok {
+		return fmt.Errorf("Expected new branch name argument to have type string")
+	}
+
+	if confirmed := len(action.Args) > 2 && action.Args[2] == true; !confirmed && IsProtectedRef(refView.config, branchName) {
+		refView.channels.DoAction(Action{
+			ActionType: ActionShowDialog,
+			Args: []interface{}{protectedRefConfirmation(branchName, fmt.Sprintf("rename it to %v", newBranchName),
+				Action{ActionType: ActionRenameRef, Args: []interface{}{branchName, newBranchName, true}})},
+		})
+
+		return
+	}
+
+	var branch *Branch
+	localBranches, _, _ := refView.repoData.Branches()
+
+	for _, localBranch := range localBranches {
+		if localBranch.name == branchName {
+			branch = localBranch
+			break
+		}
+	}
+
+	if branch == nil {
+		return fmt.Errorf("No local branch exists with name %v", branchName)
+	}
+
+	if err = refView.repoData.RenameBranch(branch, newBranchName); err != nil {
+		return fmt.Errorf("Unable to rename branch %v to %v: %v", branchName, newBranchName, err)
+	}
+
+	if err = refView.reloadBranchesAndSelect(newBranchName); err != nil {
+		return
+	}
+
+	if err = refView.notifyRefListeners(newBranchName, branch.oid); err != nil {
+		return
+	}
+
+	refView.channels.ReportStatus("Renamed branch %v to %v", branchName, newBranchName)
+
+	return
+}
+
+// trackingBranchName returns the default name of a local branch tracking the
+// provided remote branch, which is the remote branch name without the remote
+func trackingBranchName(remoteBranchName string) string {
+	if parts := strings.SplitN(remoteBranchName, "/", 2); len(parts) == 2 && parts[1] != "" {
+		return parts[1]
+	}
+
+	return remoteBranchName
+}
+
+func trackBranch(refView *RefView, action Action) (err error) {
+	if len(action.Args) == 0 {
+		remoteBranch := refView.selectedRemoteBranch()
+		if remoteBranch == nil {
+			return fmt.Errorf("Select a remote branch to create a local branch tracking it")
+		}
+
+		refView.channels.DoAction(Action{
+			ActionType: ActionTrackBranchPrompt,
+			Args:       []interface{}{remoteBranch.name, trackingBranchName(remoteBranch.name)},
+		})
+
+		return
+	}
+
+	if len(action.Args) < 2 {
+		return fmt.Errorf("Expected remote branch name and branch name arguments")
+	}
+
+	remoteBranchName, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected remote branch name argument to have type string")
+	}
+
+	branchName, ok := action.Args[1].(string)
+	if !ok {
+		return fmt.Errorf("Expected branch name argument to have type string")
+	}
+
+	var remoteBranch *Branch
+	_, remoteBranches, _ := refView.repoData.Branches()
+
+	for _, branch := range remoteBranches {
+		if branch.name == remoteBranchName {
+			remoteBranch = branch
+			break
+		}
+	}
+
+	if remoteBranch == nil {
+		return fmt.Errorf("No remote branch exists with name %v", remoteBranchName)
+	}
+
+	checkout := refView.config.GetBool(CfCheckoutTrackingBranch)
+
+	if err = refView.repoData.CreateTrackingBranch(remoteBranch, branchName, checkout); err != nil {
+		return fmt.Errorf("Unable to create branch %v tracking %v: %v", branchName, remoteBranchName, err)
+	}
+
+	if err = refView.reloadBranchesAndSelect(branchName); err != nil {
+		return
+	}
+
+	if checkout {
+		if err = refView.notifyRefListeners(branchName, remoteBranch.oid); err != nil {
+			return
+		}
+
+		refView.channels.ReportStatus("Created and checked out branch %v tracking %v", branchName, remoteBranchName)
+	} else {
+		refView.channels.ReportStatus("Created branch %v tracking %v", branchName, remoteBranchName)
+	}
+
+	return
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestDescribeDetachedHeadRefs(t *testing.T) {
+	var detachedHeadTests = []struct {
+		branches            []*Branch
+		tags                []*Tag
+		expectedDescription string
+	}{
+		{
+			expectedDescription: "not contained in any branch or tag",
+		},
+		{
+			branches:            []*Branch{{name: "master"}, {name: "origin/master"}},
+			expectedDescription: "contained in master, origin/master",
+		},
+		{
+			branches:            []*Branch{{name: "feature"}},
+			tags:                []*Tag{{name: "v1.0"}},
+			expectedDescription: "contained in feature, v1.0",
+		},
+	}
+
+	for _, detachedHeadTest := range detachedHeadTests {
+		if description := describeDetachedHeadRefs(detachedHeadTest.branches, detachedHeadTest.tags); description != detachedHeadTest.expectedDescription {
+			t.Errorf("Description does not match expected value. Expected: %v, Actual: %v", detachedHeadTest.expectedDescription, description)
+		}
+	}
+}
+
+func TestRecentBranches(t *testing.T) {
+	master := &Branch{name: "master"}
+	feature := &Branch{name: "feature"}
+	bugfix := &Branch{name: "bugfix"}
+	localBranches := []*Branch{bugfix, feature, master}
+
+	var recentBranchTests = []struct {
+		branchNames      []string
+		headBranch       *Branch
+		limit            uint
+		expectedBranches []*Branch
+	}{
+		{
+			branchNames:      []string{"feature", "bugfix", "master"},
+			headBranch:       master,
+			limit:            5,
+			expectedBranches: []*Branch{feature, bugfix},
+		},
+		{
+			branchNames:      []string{"deleted", "master", "feature"},
+			headBranch:       bugfix,
+			limit:            5,
+			expectedBranches: []*Branch{master, feature},
+		},
+		{
+			branchNames:      []string{"feature", "master", "bugfix"},
+			limit:            2,
+			expectedBranches: []*Branch{feature, master},
+		},
+		{
+			branchNames: []string{"feature"},
+			limit:       0,
+		},
+	}
+
+	for _, recentBranchTest := range recentBranchTests {
+		branches := recentBranches(recentBranchTest.branchNames, localBranches, recentBranchTest.headBranch, recentBranchTest.limit)
+
+		if len(branches) != len(recentBranchTest.expectedBranches) {
+			t.Errorf("Branch count does not match expected value. Expected: %v, Actual: %v", len(recentBranchTest.expectedBranches), len(branches))
+			continue
+		}
+
+		for branchIndex, branch := range branches {
+			if branch != recentBranchTest.expectedBranches[branchIndex] {
+				t.Errorf("Branch at index %v does not match expected value. Expected: %v, Actual: %v",
+					branchIndex, recentBranchTest.expectedBranches[branchIndex].name, branch.name)
+			}
+		}
+	}
+}
+
+func TestResolvePinnedRefs(t *testing.T) {
+	masterOid := &Oid{}
+	remoteMasterOid := &Oid{}
+	releaseOid := &Oid{}
+	tagOid := &Oid{}
+
+	localBranches := []*Branch{{name: "master", oid: masterOid}, {name: "v1.0", oid: releaseOid}}
+	remoteBranches := []*Branch{{name: "origin/master", oid: remoteMasterOid}}
+	tags := []*Tag{{name: "v1.0", oid: tagOid}}
+
+	refs := resolvePinnedRefs([]string{"origin/master", "deleted", "v1.0", "master"}, localBranches, remoteBranches, tags)
+
+	expectedRefs := []pinnedRef{
+		{name: "origin/master", oid: remoteMasterOid},
+		{name: "v1.0", oid: releaseOid},
+		{name: "master", oid: masterOid},
+	}
+
+	if len(refs) != len(expectedRefs) {
+		t.Fatalf("Pinned ref count does not match expected value. Expected: %v, Actual: %v", len(expectedRefs), len(refs))
+	}
+
+	for refIndex, ref := range refs {
+		if ref != expectedRefs[refIndex] {
+			t.Errorf("Pinned ref at index %v does not match expected value. Expected: %v, Actual: %v",
+				refIndex, expectedRefs[refIndex].name, ref.name)
+		}
+	}
+}
+
+func TestComparisonBadge(t *testing.T) {
+	refView := &RefView{
+		comparisons: &refComparisons{
+			base: "master",
+			branches: map[string]refComparison{
+				"master":         {},
+				"feature":        {ahead: 3, behind: 1},
+				"origin/release": {ahead: 0, behind: 12},
+			},
+		},
+	}
+
+	var badgeTests = []struct {
+		renderedRef   *RenderedRef
+		expectedBadge string
+	}{
+		{
+			renderedRef:   &RenderedRef{value: "   master", renderedRefType: RvLocalBranch},
+			expectedBadge: "base",
+		},
+		{
+			renderedRef:   &RenderedRef{value: "   feature", renderedRefType: RvLocalBranch},
+			expectedBadge: "+3/-1",
+		},
+		{
+			renderedRef:   &RenderedRef{value: "   feature", renderedRefType: RvRecentBranch},
+			expectedBadge: "+3/-1",
+		},
+		{
+			renderedRef:   &RenderedRef{value: "   origin/release", renderedRefType: RvRemoteBranch},
+			expectedBadge: "+0/-12",
+		},
+		{
+			renderedRef:   &RenderedRef{value: "   bugfix", renderedRefType: RvLocalBranch},
+			expectedBadge: "",
+		},
+		{
+			renderedRef:   &RenderedRef{value: "   feature", renderedRefType: RvTag},
+			expectedBadge: "",
+		},
+	}
+
+	for _, badgeTest := range badgeTests {
+		if badge := refView.comparisonBadge(badgeTest.renderedRef); badge != badgeTest.expectedBadge {
+			t.Errorf("Badge does not match expected value for %v. Expected: %v, Actual: %v", badgeTest.renderedRef.value, badgeTest.expectedBadge, badge)
+		}
+	}
+}
+
+func TestBranchDescriptionTemplateIsRemovedByCleanup(t *testing.T) {
+	var descriptionTests = []struct {
+		description         string
+		expectedDescription string
+	}{
+		{description: "", expectedDescription: ""},
+		{description: "Add support for tabs\n", expectedDescription: "Add support for tabs\n"},
+		{description: "Add support for tabs\n\nSee #42\n", expectedDescription: "Add support for tabs\n\nSee #42\n"},
+	}
+
+	for _, descriptionTest := range descriptionTests {
+		template := branchDescriptionTemplate("feature", descriptionTest.description)
+
+		if description := CleanupCommitMessage(template); description != descriptionTest.expectedDescription {
+			t.Errorf("Description does not match expected value. Expected: %q, Actual: %q", descriptionTest.expectedDescription, description)
+		}
+	}
+}
+
+func TestTrackingBranchNameRemovesRemoteName(t *testing.T) {
+	var branchNameTests = []struct {
+		remoteBranchName   string
+		expectedBranchName string
+	}{
+		{remoteBranchName: "origin/master", expectedBranchName: "master"},
+		{remoteBranchName: "origin/feature/tabs", expectedBranchName: "feature/tabs"},
+		{remoteBranchName: "master", expectedBranchName: "master"},
+		{remoteBranchName: "origin/", expectedBranchName: "origin/"},
+	}
+
+	for _, branchNameTest := range branchNameTests {
+		if branchName := trackingBranchName(branchNameTest.remoteBranchName); branchName != branchNameTest.expectedBranchName {
+			t.Errorf("Branch name does not match expected value for remote branch %v. Expected: %v, Actual: %v",
+				branchNameTest.remoteBranchName, branchNameTest.expectedBranchName, branchName)
+		}
+	}
+}
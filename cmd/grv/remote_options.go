@@ -0,0 +1,164 @@
+package main
+
+import (
+	"context"
+	"crypto/x509"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+
+	log "github.com/Sirupsen/logrus"
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+// RemoteOptions contains GRV configuration for operations on remotes
+// Empty values fall back to git configuration and environment variables
+type RemoteOptions struct {
+	proxyURL           string
+	sslCAInfo          string
+	onTransferProgress func(progress TransferProgress)
+}
+
+// ResolveProxy determines the proxy to use for the provided remote url.
+// The proxy configured in GRV takes precedence, followed by the git configuration and then the environment
+func ResolveProxy(remoteURL, grvProxy, gitProxy string, getenv func(string) string) string {
+	if grvProxy != "" {
+		return grvProxy
+	} else if gitProxy != "" {
+		return gitProxy
+	}
+
+	parsedURL, err := url.Parse(remoteURL)
+	if err != nil {
+		return ""
+	}
+
+	var envVariables []string
+
+	switch parsedURL.Scheme {
+	case "https":
+		envVariables = []string{"https_proxy", "HTTPS_PROXY", "all_proxy", "ALL_PROXY"}
+	case "http":
+		envVariables = []string{"http_proxy", "HTTP_PROXY", "all_proxy", "ALL_PROXY"}
+	default:
+		return ""
+	}
+
+	for _, envVariable := range envVariables {
+		if proxy := getenv(envVariable); proxy != "" {
+			return proxy
+		}
+	}
+
+	return ""
+}
+
+// newFetchOptions creates the options used to fetch from a remote. The proxy and CA certificates
+// are determined from the GRV configuration, the provided git configuration and the environment.
+// Transfer progress is reported to remoteOptions and the transfer is aborted if ctx is cancelled
+func newFetchOptions(ctx context.Context, config *git.Config, remoteName, remoteURL string, remoteOptions RemoteOptions) (fetchOptions *git.FetchOptions, session *credentialSession, err error) {
+	session = &credentialSession{}
+	fetchOptions = &git.FetchOptions{
+		RemoteCallbacks: session.remoteCallbacks(),
+		ProxyOptions: git.ProxyOptions{
+			Type: git.ProxyTypeAuto,
+		},
+	}
+
+	gitProxy := firstConfigValue(config, fmt.Sprintf("remote.%v.proxy", remoteName), "http.proxy")
+	if proxy := ResolveProxy(remoteURL, remoteOptions.proxyURL, gitProxy, os.Getenv); proxy != "" {
+		log.Debugf("Using proxy %v for remote %v", proxy, remoteName)
+		fetchOptions.ProxyOptions = git.ProxyOptions{
+			Type: git.ProxyTypeSpecified,
+			Url:  proxy,
+		}
+	}
+
+	caFile := remoteOptions.sslCAInfo
+	if caFile == "" {
+		caFile = firstConfigValue(config, "http.sslCAInfo")
+	}
+	if caFile == "" {
+		caFile = os.Getenv("GIT_SSL_CAINFO")
+	}
+
+	if caFile != "" {
+		var certPool *x509.CertPool
+		if certPool, err = loadCertPool(caFile); err != nil {
+			return
+		}
+
+		fetchOptions.RemoteCallbacks.CertificateCheckCallback = certificateCheckCallback(certPool)
+	}
+
+	fetchOptions.RemoteCallbacks.TransferProgressCallback = func(stats git.TransferProgress) git.ErrorCode {
+		if ctx.Err() != nil {
+			return git.ErrUser
+		}
+
+		if remoteOptions.onTransferProgress != nil {
+			remoteOptions.onTransferProgress(TransferProgress{
+				remoteName:      remoteName,
+				receivedObjects: stats.ReceivedObjects,
+				indexedObjects:  stats.IndexedObjects,
+				totalObjects:    stats.TotalObjects,
+				receivedBytes:   stats.ReceivedBytes,
+			})
+		}
+
+		return git.ErrOk
+	}
+
+	return
+}
+
+// firstConfigValue returns the first of the provided git config variables which is set
+func firstConfigValue(config *git.Config, names ...string) string {
+	if config == nil {
+		return ""
+	}
+
+	for _, name := range names {
+		if value, err := config.LookupString(name); err == nil && value != "" {
+			return value
+		}
+	}
+
+	return ""
+}
+
+// loadCertPool loads the PEM encoded certificates in the provided file
+func loadCertPool(caFile string) (*x509.CertPool, error) {
+	content, err := ioutil.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+
+	certPool := x509.NewCertPool()
+	if !certPool.AppendCertsFromPEM(content) {
+		return nil, fmt.Errorf("No certificates found in %v", caFile)
+	}
+
+	return certPool, nil
+}
+
+// certificateCheckCallback accepts certificates which could not be verified using the system
+// certificates if they can be verified using the provided certificate pool
+func certificateCheckCallback(certPool *x509.CertPool) git.CertificateCheckCallback {
+	return func(cert *git.Certificate, valid bool, hostname string) git.ErrorCode {
+		if valid || cert.Kind != git.CertificateX509 {
+			return git.ErrOk
+		}
+
+		if _, err := cert.X509.Verify(x509.VerifyOptions{
+			Roots:   certPool,
+			DNSName: hostname,
+		}); err != nil {
+			log.Errorf("Unable to verify certificate for %v: %v", hostname, err)
+			return git.ErrUser
+		}
+
+		return git.ErrOk
+	}
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestResolveProxy(t *testing.T) {
+	environment := map[string]string{
+		"https_proxy": "http://https-proxy:3128",
+		"HTTP_PROXY":  "http://http-proxy:3128",
+	}
+
+	getenv := func(name string) string {
+		return environment[name]
+	}
+
+	var proxyTests = []struct {
+		remoteURL     string
+		grvProxy      string
+		gitProxy      string
+		expectedProxy string
+	}{
+		{
+			remoteURL:     "https://github.com/rgburke/grv.git",
+			grvProxy:      "http://grv-proxy:8080",
+			gitProxy:      "http://git-proxy:8080",
+			expectedProxy: "http://grv-proxy:8080",
+		},
+		{
+			remoteURL:     "https://github.com/rgburke/grv.git",
+			gitProxy:      "http://git-proxy:8080",
+			expectedProxy: "http://git-proxy:8080",
+		},
+		{
+			remoteURL:     "https://github.com/rgburke/grv.git",
+			expectedProxy: "http://https-proxy:3128",
+		},
+		{
+			remoteURL:     "http://example.com/repo.git",
+			expectedProxy: "http://http-proxy:3128",
+		},
+		{
+			remoteURL:     "git@github.com:rgburke/grv.git",
+			expectedProxy: "",
+		},
+	}
+
+	for _, proxyTest := range proxyTests {
+		proxy := ResolveProxy(proxyTest.remoteURL, proxyTest.grvProxy, proxyTest.gitProxy, getenv)
+
+		if proxy != proxyTest.expectedProxy {
+			t.Errorf("Proxy does not match expected value for url %v. Expected: %v, Actual: %v", proxyTest.remoteURL, proxyTest.expectedProxy, proxy)
+		}
+	}
+}
@@ -1,7 +1,13 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	log "github.com/Sirupsen/logrus"
@@ -24,17 +30,62 @@ type RepoData interface {
 	LoadBranches(OnBranchesLoaded) error
 	LoadLocalTags(OnTagsLoaded) error
 	LoadCommits(*Oid, OnCommitsLoaded) error
+	MultiRefOid(oids, hiddenOids []*Oid) (*Oid, error)
+	RevisionsOid(revisions []string) (*Oid, error)
 	Head() (*Oid, *Branch)
 	Branches() (localBranches, remoteBranches []*Branch, loading bool)
 	LocalTags() (tags []*Tag, loading bool)
+	LoadStashes() error
+	Stashes() []*Stash
+	CreateStash(message string) error
+	ApplyStash(*Stash) error
+	PopStash(*Stash) error
+	DropStash(*Stash) error
 	RefsForCommit(*Commit) *CommitRefs
 	CommitSetState(*Oid) CommitSetState
+	ClearCommitSets()
 	Commits(oid *Oid, startIndex, count uint) (<-chan *Commit, error)
 	CommitByIndex(oid *Oid, index uint) (*Commit, error)
 	Commit(oid *Oid) (*Commit, error)
+	CommitByRevision(revision string) (*Commit, error)
 	AddCommitFilter(*Oid, *CommitFilter) error
 	RemoveCommitFilter(*Oid) error
-	Diff(commit *Commit) (*Diff, error)
+	DecoratedCommitFilter() *CommitFilter
+	CodeOwners(commit *Commit) (*CodeOwners, error)
+	OwnerCommitFilter(owner string) (*CommitFilter, error)
+	Diff(ctx context.Context, commit *Commit, diffOptions DiffOptions) (*Diff, error)
+	DiffCommits(ctx context.Context, fromCommit, toCommit *Commit, diffOptions DiffOptions) (*Diff, error)
+	CreateCommit(message string) error
+	CreateBranch(branchName string, oid *Oid) error
+	UnreachableCommits(ctx context.Context, onProgress func(objectsExamined uint)) ([]*Commit, error)
+	RefsContainingCommit(oid *Oid) (branches []*Branch, tags []*Tag, err error)
+	Describe(ctx context.Context, commit *Commit) (description string, found bool, err error)
+	CommitRange(fromCommit, toCommit *Commit) ([]*Commit, error)
+	OldestAutosquashTarget() (*Commit, error)
+	AutosquashRebaseCommand(commit *Commit) *exec.Cmd
+	FetchRemotes(ctx context.Context, remoteOptions RemoteOptions) error
+	TransferProgress() (progress TransferProgress, active bool)
+	Clone(ctx context.Context, url, directory string, remoteOptions RemoteOptions) error
+	LineHistory(commit *Commit, filePath string, startLine, endLine uint) ([]*LineHistoryEntry, error)
+	ShortID(oid *Oid) string
+	UpstreamStatus(branch *Branch) (*UpstreamStatus, error)
+	AheadBehind(oid, base *Oid) (ahead, behind int, err error)
+	ModifiedFileCount() (uint, error)
+	Remotes() ([]string, error)
+	RecentActivity(limit uint) ([]*ActivityEntry, error)
+	TreePaths(commit *Commit) ([]string, error)
+	CheckedOutBranches() ([]string, error)
+	Worktrees() ([]*Worktree, error)
+	BranchDescriptions(branchNames []string) (map[string]string, error)
+	SetBranchDescription(branchName, description string) error
+	Editor() string
+	Pager() string
+	FileContents(commit *Commit, filePath string) ([]byte, error)
+	Blame(commit *Commit, filePath string) ([]*BlameHunk, error)
+	WhitespaceRules() WhitespaceRules
+	SetUpstream(branch *Branch, upstreamName string) error
+	RenameBranch(branch *Branch, newBranchName string) error
+	CreateTrackingBranch(remoteBranch *Branch, branchName string, checkout bool) error
 }
 
 type commitSet interface {
@@ -272,6 +323,8 @@ type branchSet struct {
 	localBranchesList  []*Branch
 	remoteBranchesList []*Branch
 	loading            bool
+	loaderRunning      bool
+	pendingCallbacks   []OnBranchesLoaded
 	lock               sync.Mutex
 }
 
@@ -349,6 +402,50 @@ func (commitRefSet *commitRefSet) addBranchForCommit(commit *Commit, newBranch *
 	commitRefs.branches = append(commitRefs.branches, newBranch)
 }
 
+// setBranches replaces the branches of all commits with the provided branches
+// Commits which are no longer referenced by any branch or tag are removed
+func (commitRefSet *commitRefSet) setBranches(commitBranches map[*Oid][]*Branch) {
+	commitRefSet.lock.Lock()
+	defer commitRefSet.lock.Unlock()
+
+	for oid, commitRefs := range commitRefSet.commitRefs {
+		if _, ok := commitBranches[oid]; ok {
+			continue
+		}
+
+		if len(commitRefs.tags) == 0 {
+			delete(commitRefSet.commitRefs, oid)
+		} else {
+			commitRefs.branches = nil
+		}
+	}
+
+	for oid, branches := range commitBranches {
+		commitRefs, ok := commitRefSet.commitRefs[oid]
+		if !ok {
+			commitRefs = &CommitRefs{}
+			commitRefSet.commitRefs[oid] = commitRefs
+		}
+
+		commitRefs.branches = branches
+	}
+}
+
+// taggedCommits returns the tags of each commit which has at least one tag keyed by commit id
+func (commitRefSet *commitRefSet) taggedCommits() (commitTags map[string][]*Tag) {
+	commitRefSet.lock.Lock()
+	defer commitRefSet.lock.Unlock()
+
+	commitTags = make(map[string][]*Tag)
+	for oid, commitRefs := range commitRefSet.commitRefs {
+		if len(commitRefs.tags) > 0 {
+			commitTags[oid.String()] = append([]*Tag(nil), commitRefs.tags...)
+		}
+	}
+
+	return
+}
+
 func (commitRefSet *commitRefSet) refsForCommit(commit *Commit) (commitRefsCopy *CommitRefs) {
 	commitRefSet.lock.Lock()
 	defer commitRefSet.lock.Unlock()
@@ -365,9 +462,10 @@ func (commitRefSet *commitRefSet) refsForCommit(commit *Commit) (commitRefsCopy
 }
 
 type refCommitSets struct {
-	commits  map[*Oid]commitSet
-	channels *Channels
-	lock     sync.Mutex
+	commits    map[*Oid]commitSet
+	generation uint
+	channels   *Channels
+	lock       sync.Mutex
 }
 
 func newRefCommitSets(channels *Channels) *refCommitSets {
@@ -385,11 +483,35 @@ func (refCommitSets *refCommitSets) commitSet(oid *Oid) (commitSet commitSet, ex
 	return
 }
 
-func (refCommitSets *refCommitSets) setCommitSet(oid *Oid, commitSet commitSet) {
+// generationCommitSet returns the commit set for the provided oid if the commit sets
+// have not been cleared since the provided generation
+func (refCommitSets *refCommitSets) generationCommitSet(oid *Oid, generation uint) (commitSet commitSet, exists bool) {
+	refCommitSets.lock.Lock()
+	defer refCommitSets.lock.Unlock()
+
+	if generation == refCommitSets.generation {
+		commitSet, exists = refCommitSets.commits[oid]
+	}
+
+	return
+}
+
+// setCommitSet stores the commit set for the provided oid and returns the current generation
+func (refCommitSets *refCommitSets) setCommitSet(oid *Oid, commitSet commitSet) (generation uint) {
 	refCommitSets.lock.Lock()
 	defer refCommitSets.lock.Unlock()
 
 	refCommitSets.commits[oid] = commitSet
+	return refCommitSets.generation
+}
+
+// clear removes all commit sets. Commit sets still loading stop receiving commits
+func (refCommitSets *refCommitSets) clear() {
+	refCommitSets.lock.Lock()
+	defer refCommitSets.lock.Unlock()
+
+	refCommitSets.commits = make(map[*Oid]commitSet)
+	refCommitSets.generation++
 }
 
 func (refCommitSets *refCommitSets) addCommitFilter(oid *Oid, commitFilter *CommitFilter) (err error) {
@@ -447,27 +569,113 @@ func (refCommitSets *refCommitSets) removeCommitFilter(oid *Oid) (err error) {
 	return
 }
 
+// multiRefWalk is a revision walk over the history of several refs
+// Commits reachable from any of the hidden oids are excluded
+type multiRefWalk struct {
+	tips   []*Oid
+	hidden []*Oid
+}
+
+// multiRefWalks stores the revision walks over several refs
+// Each walk is identified by an oid which is unique to its tips and hidden oids
+type multiRefWalks struct {
+	oids  map[string]*Oid
+	walks map[*Oid]*multiRefWalk
+	lock  sync.Mutex
+}
+
+func newMultiRefWalks() *multiRefWalks {
+	return &multiRefWalks{
+		oids:  make(map[string]*Oid),
+		walks: make(map[*Oid]*multiRefWalk),
+	}
+}
+
+func sortedOidStrings(oids []*Oid) (oidStrings []string) {
+	for _, oid := range oids {
+		oidStrings = append(oidStrings, oid.String())
+	}
+
+	sort.Strings(oidStrings)
+
+	return
+}
+
+// key returns a value which is the same for any ordering of the tips and hidden oids
+func (walk *multiRefWalk) key() string {
+	key := strings.Join(sortedOidStrings(walk.tips), " ")
+
+	for _, hiddenOidString := range sortedOidStrings(walk.hidden) {
+		key += " ^" + hiddenOidString
+	}
+
+	return key
+}
+
+// oid returns the oid identifying the provided walk
+// newestTip is used as the underlying id so the oid resolves to the first commit of the walk
+func (multiRefWalks *multiRefWalks) oid(walk *multiRefWalk, newestTip *Oid) *Oid {
+	multiRefWalks.lock.Lock()
+	defer multiRefWalks.lock.Unlock()
+
+	key := walk.key()
+
+	if oid, ok := multiRefWalks.oids[key]; ok {
+		return oid
+	}
+
+	oid := &Oid{oid: newestTip.oid}
+	multiRefWalks.oids[key] = oid
+	multiRefWalks.walks[oid] = walk
+
+	return oid
+}
+
+// walk returns the walk identified by the provided oid
+// A ref oid is a walk of its own history
+func (multiRefWalks *multiRefWalks) walk(oid *Oid) *multiRefWalk {
+	multiRefWalks.lock.Lock()
+	defer multiRefWalks.lock.Unlock()
+
+	if walk, ok := multiRefWalks.walks[oid]; ok {
+		return walk
+	}
+
+	return &multiRefWalk{tips: []*Oid{oid}}
+}
+
 // RepositoryData implements RepoData and stores all loaded repository data
 type RepositoryData struct {
 	channels       *Channels
+	config         Config
 	repoDataLoader *RepoDataLoader
 	head           *Oid
 	headBranch     *Branch
 	branches       *branchSet
 	localTags      *tagSet
+	stashes        []*Stash
+	stashLock      sync.Mutex
 	commitRefSet   *commitRefSet
 	refCommitSets  *refCommitSets
+	multiRefWalks  *multiRefWalks
+	transfer       *TransferProgress
+	transferLock   sync.Mutex
+	codeOwners     map[string]*CodeOwners
+	codeOwnersLock sync.Mutex
 }
 
 // NewRepositoryData creates a new instance
-func NewRepositoryData(repoDataLoader *RepoDataLoader, channels *Channels) *RepositoryData {
+func NewRepositoryData(repoDataLoader *RepoDataLoader, channels *Channels, config Config) *RepositoryData {
 	return &RepositoryData{
 		channels:       channels,
+		config:         config,
 		repoDataLoader: repoDataLoader,
 		branches:       newBranchSet(),
 		localTags:      newTagSet(),
 		commitRefSet:   newCommitRefSet(),
 		refCommitSets:  newRefCommitSets(channels),
+		multiRefWalks:  newMultiRefWalks(),
+		codeOwners:     make(map[string]*CodeOwners),
 	}
 }
 
@@ -477,8 +685,8 @@ func (repoData *RepositoryData) Free() {
 }
 
 // Initialise performs setup to allow loading data from the repository
-func (repoData *RepositoryData) Initialise(repoPath string) (err error) {
-	if err = repoData.repoDataLoader.Initialise(repoPath); err != nil {
+func (repoData *RepositoryData) Initialise(repoPath, workTreePath string) (err error) {
+	if err = repoData.repoDataLoader.Initialise(repoPath, workTreePath); err != nil {
 		return
 	}
 
@@ -504,67 +712,104 @@ func (repoData *RepositoryData) LoadHead() (err error) {
 }
 
 // LoadBranches attempts to load local and remote branch refs from the repository
+// If branches are already loading they are loaded again once the current load completes,
+// so every callback receives branches loaded after it was registered
 func (repoData *RepositoryData) LoadBranches(onBranchesLoaded OnBranchesLoaded) (err error) {
 	branchSet := repoData.branches
 	branchSet.lock.Lock()
 	defer branchSet.lock.Unlock()
 
-	if branchSet.loading {
-		log.Debug("Local branches already loading")
+	branchSet.pendingCallbacks = append(branchSet.pendingCallbacks, onBranchesLoaded)
+
+	if branchSet.loaderRunning {
+		log.Debug("Local branches already loading. Branches will be reloaded once loading completes")
 		return
 	}
 
-	go func() {
-		branches, err := repoData.repoDataLoader.LoadBranches()
-		if err != nil {
-			repoData.channels.ReportError(err)
+	branchSet.loaderRunning = true
+	branchSet.loading = true
+	go repoData.loadBranches()
+
+	return
+}
+
+// loadBranches loads branches until no callbacks remain pending
+func (repoData *RepositoryData) loadBranches() {
+	branchSet := repoData.branches
+
+	for {
+		branchSet.lock.Lock()
+		if len(branchSet.pendingCallbacks) == 0 {
+			branchSet.loaderRunning = false
+			branchSet.lock.Unlock()
 			return
 		}
 
-		slice.Sort(branches, func(i, j int) bool {
-			return branches[i].name < branches[j].name
-		})
+		callbacks := branchSet.pendingCallbacks
+		branchSet.pendingCallbacks = nil
+		branchSet.loading = true
+		branchSet.lock.Unlock()
 
-		var localBranchesList []*Branch
-		var remoteBranchesList []*Branch
+		localBranchesList, remoteBranchesList, err := repoData.loadBranchLists()
+		if err != nil {
+			branchSet.lock.Lock()
+			branchSet.loading = false
+			branchSet.lock.Unlock()
 
-		for _, branch := range branches {
-			if branch.isRemote {
-				remoteBranchesList = append(remoteBranchesList, branch)
-			} else {
-				localBranchesList = append(localBranchesList, branch)
-			}
+			repoData.channels.ReportError(err)
+			continue
 		}
 
-		branchMap := make(map[*Oid]*Branch)
-		for _, branch := range branches {
-			branchMap[branch.oid] = branch
+		repoData.channels.ReportError(repoData.mapBranchesToCommits())
+
+		for _, onBranchesLoaded := range callbacks {
+			repoData.channels.ReportError(onBranchesLoaded(localBranchesList, remoteBranchesList))
 		}
+	}
+}
 
-		branchSet.lock.Lock()
-		branchSet.branches = branchMap
-		branchSet.localBranchesList = localBranchesList
-		branchSet.remoteBranchesList = remoteBranchesList
-		branchSet.loading = false
-		branchSet.lock.Unlock()
+func (repoData *RepositoryData) loadBranchLists() (localBranchesList, remoteBranchesList []*Branch, err error) {
+	branches, err := repoData.repoDataLoader.LoadBranches()
+	if err != nil {
+		return
+	}
 
-		repoData.channels.ReportError(repoData.mapBranchesToCommits())
-		repoData.channels.ReportError(onBranchesLoaded(localBranchesList, remoteBranchesList))
-	}()
+	slice.Sort(branches, func(i, j int) bool {
+		return branches[i].name < branches[j].name
+	})
 
-	branchSet.loading = true
+	for _, branch := range branches {
+		if branch.isRemote {
+			remoteBranchesList = append(remoteBranchesList, branch)
+		} else {
+			localBranchesList = append(localBranchesList, branch)
+		}
+	}
+
+	branchMap := make(map[*Oid]*Branch)
+	for _, branch := range branches {
+		branchMap[branch.oid] = branch
+	}
+
+	branchSet := repoData.branches
+	branchSet.lock.Lock()
+	branchSet.branches = branchMap
+	branchSet.localBranchesList = localBranchesList
+	branchSet.remoteBranchesList = remoteBranchesList
+	branchSet.loading = false
+	branchSet.lock.Unlock()
 
 	return
 }
 
+// mapBranchesToCommits rebuilds the branches referencing each commit from the loaded branches
 func (repoData *RepositoryData) mapBranchesToCommits() (err error) {
 	branchSet := repoData.branches
 	branchSet.lock.Lock()
 	defer branchSet.lock.Unlock()
 
-	commitRefSet := repoData.commitRefSet
-
-	branches := append(branchSet.localBranchesList, branchSet.remoteBranchesList...)
+	branches := append(append([]*Branch(nil), branchSet.localBranchesList...), branchSet.remoteBranchesList...)
+	commitBranches := make(map[*Oid][]*Branch)
 
 	for _, branch := range branches {
 		var commit *Commit
@@ -573,9 +818,11 @@ func (repoData *RepositoryData) mapBranchesToCommits() (err error) {
 			return
 		}
 
-		commitRefSet.addBranchForCommit(commit, branch)
+		commitBranches[commit.oid] = append(commitBranches[commit.oid], branch)
 	}
 
+	repoData.commitRefSet.setBranches(commitBranches)
+
 	return
 }
 
@@ -593,6 +840,10 @@ func (repoData *RepositoryData) LoadLocalTags(onTagsLoaded OnTagsLoaded) (err er
 	go func() {
 		tags, err := repoData.repoDataLoader.LocalTags()
 		if err != nil {
+			tagSet.lock.Lock()
+			tagSet.loading = false
+			tagSet.lock.Unlock()
+
 			repoData.channels.ReportError(err)
 			return
 		}
@@ -648,22 +899,26 @@ func (repoData *RepositoryData) LoadCommits(oid *Oid, onCommitsLoaded OnCommitsL
 		return
 	}
 
-	commitCh, err := repoData.repoDataLoader.Commits(oid)
+	walk := repoData.multiRefWalks.walk(oid)
+	commitCh, err := repoData.repoDataLoader.Commits(walk.tips, walk.hidden, repoData.config.GetString(CfCommitOrder))
 	if err != nil {
 		return
 	}
 
 	commitSet := newRawCommitSet()
 	commitSet.SetLoading(true)
-	repoData.refCommitSets.setCommitSet(oid, commitSet)
+	generation := repoData.refCommitSets.setCommitSet(oid, commitSet)
 
 	go func() {
 		log.Debugf("Receiving commits from RepoDataLoader for oid %v", oid)
 
 		for commit := range commitCh {
-			commitSet, ok := repoData.refCommitSets.commitSet(oid)
+			commitSet, ok := repoData.refCommitSets.generationCommitSet(oid, generation)
 			if !ok {
-				log.Errorf("Error when loading commits: No CommitSet exists for ref with id: %v", oid)
+				log.Debugf("Commit sets cleared while loading commits for oid %v", oid)
+				for range commitCh {
+				}
+
 				return
 			}
 
@@ -673,9 +928,9 @@ func (repoData *RepositoryData) LoadCommits(oid *Oid, onCommitsLoaded OnCommitsL
 			}
 		}
 
-		commitSet, ok := repoData.refCommitSets.commitSet(oid)
+		commitSet, ok := repoData.refCommitSets.generationCommitSet(oid, generation)
 		if !ok {
-			log.Errorf("No CommitSet exists for ref with id: %v", oid)
+			log.Debugf("Commit sets cleared while loading commits for oid %v", oid)
 			return
 		}
 
@@ -688,6 +943,73 @@ func (repoData *RepositoryData) LoadCommits(oid *Oid, onCommitsLoaded OnCommitsL
 	return
 }
 
+// MultiRefOid returns an oid which can be used in place of a ref oid to load and display
+// the union of the histories of oids, excluding commits reachable from hiddenOids (i.e. git log A B ^C)
+func (repoData *RepositoryData) MultiRefOid(oids, hiddenOids []*Oid) (oid *Oid, err error) {
+	walk := &multiRefWalk{}
+	var newestTip *Commit
+	oidStrings := make(map[string]bool)
+
+	for _, tip := range oids {
+		if oidStrings[tip.String()] {
+			continue
+		}
+
+		var commit *Commit
+		if commit, err = repoData.Commit(tip); err != nil {
+			return
+		}
+
+		if newestTip == nil || commit.commit.Committer().When.After(newestTip.commit.Committer().When) {
+			newestTip = commit
+		}
+
+		oidStrings[tip.String()] = true
+		walk.tips = append(walk.tips, tip)
+	}
+
+	for _, hiddenOid := range hiddenOids {
+		if !oidStrings["^"+hiddenOid.String()] {
+			oidStrings["^"+hiddenOid.String()] = true
+			walk.hidden = append(walk.hidden, hiddenOid)
+		}
+	}
+
+	switch {
+	case len(walk.tips) == 0:
+		err = fmt.Errorf("No refs to display the history of")
+	case len(walk.tips) == 1 && len(walk.hidden) == 0:
+		oid = walk.tips[0]
+	default:
+		oid = repoData.multiRefWalks.oid(walk, newestTip.oid)
+	}
+
+	return
+}
+
+// RevisionsOid returns an oid which can be used in place of a ref oid to load and display the history
+// of the provided revisions. Revisions prefixed with ^ exclude the commits reachable from them
+func (repoData *RepositoryData) RevisionsOid(revisions []string) (oid *Oid, err error) {
+	var oids, hiddenOids []*Oid
+
+	for _, revision := range revisions {
+		hidden := strings.HasPrefix(revision, "^")
+
+		var commit *Commit
+		if commit, err = repoData.CommitByRevision(strings.TrimPrefix(revision, "^")); err != nil {
+			return nil, fmt.Errorf("Unable to resolve revision %v: %v", revision, err)
+		}
+
+		if hidden {
+			hiddenOids = append(hiddenOids, commit.oid)
+		} else {
+			oids = append(oids, commit.oid)
+		}
+	}
+
+	return repoData.MultiRefOid(oids, hiddenOids)
+}
+
 // Head returns the loaded HEAD ref
 func (repoData *RepositoryData) Head() (*Oid, *Branch) {
 	return repoData.head, repoData.headBranch
@@ -718,6 +1040,447 @@ func (repoData *RepositoryData) LocalTags() (tags []*Tag, loading bool) {
 	return
 }
 
+// LoadStashes loads all stash entries from the repository
+func (repoData *RepositoryData) LoadStashes() (err error) {
+	stashes, err := repoData.repoDataLoader.LoadStashes()
+	if err != nil {
+		return
+	}
+
+	repoData.stashLock.Lock()
+	defer repoData.stashLock.Unlock()
+
+	repoData.stashes = stashes
+
+	return
+}
+
+// Stashes returns all loaded stash entries
+func (repoData *RepositoryData) Stashes() []*Stash {
+	repoData.stashLock.Lock()
+	defer repoData.stashLock.Unlock()
+
+	return repoData.stashes
+}
+
+// CreateStash stashes local changes and reloads the stash entries
+func (repoData *RepositoryData) CreateStash(message string) (err error) {
+	if err = repoData.repoDataLoader.CreateStash(message); err != nil {
+		return
+	}
+
+	return repoData.LoadStashes()
+}
+
+// ShortID returns the oid abbreviated to the configured length
+func (repoData *RepositoryData) ShortID(oid *Oid) string {
+	abbrevLength := repoData.config.GetString(CfAbbrevLength)
+
+	if abbrevLength == AbbrevLengthAuto {
+		return repoData.repoDataLoader.UniqueShortID(oid)
+	}
+
+	length, err := strconv.Atoi(abbrevLength)
+	if err != nil {
+		return oid.ShortID()
+	}
+
+	return oid.AbbreviatedID(length)
+}
+
+// FetchRemotes fetches all configured remotes
+// The progress of the transfer is recorded while the fetch is in progress
+func (repoData *RepositoryData) FetchRemotes(ctx context.Context, remoteOptions RemoteOptions) error {
+	remoteOptions, done := repoData.trackTransfer(remoteOptions)
+	defer done()
+
+	return repoData.repoDataLoader.FetchRemotes(ctx, remoteOptions)
+}
+
+// Clone clones the repository at the url into the provided directory
+// The progress of the transfer is recorded while the clone is in progress
+func (repoData *RepositoryData) Clone(ctx context.Context, url, directory string, remoteOptions RemoteOptions) error {
+	remoteOptions, done := repoData.trackTransfer(remoteOptions)
+	defer done()
+
+	return CloneRepository(ctx, url, directory, remoteOptions)
+}
+
+// trackTransfer returns remote options which record the progress of the transfer they are used for
+// done must be called once the transfer is complete
+func (repoData *RepositoryData) trackTransfer(remoteOptions RemoteOptions) (trackedRemoteOptions RemoteOptions, done func()) {
+	onTransferProgress := remoteOptions.onTransferProgress
+
+	remoteOptions.onTransferProgress = func(progress TransferProgress) {
+		repoData.transferLock.Lock()
+		repoData.transfer = &progress
+		repoData.transferLock.Unlock()
+
+		if onTransferProgress != nil {
+			onTransferProgress(progress)
+		}
+	}
+
+	done = func() {
+		repoData.transferLock.Lock()
+		repoData.transfer = nil
+		repoData.transferLock.Unlock()
+
+		repoData.channels.UpdateDisplay()
+	}
+
+	return remoteOptions, done
+}
+
+// TransferProgress returns the progress of the transfer in progress, if any
+func (repoData *RepositoryData) TransferProgress() (progress TransferProgress, active bool) {
+	repoData.transferLock.Lock()
+	defer repoData.transferLock.Unlock()
+
+	if repoData.transfer == nil {
+		return
+	}
+
+	return *repoData.transfer, true
+}
+
+// CreateCommit creates a commit from the index and reloads HEAD
+func (repoData *RepositoryData) CreateCommit(message string) (err error) {
+	if err = repoData.repoDataLoader.CreateCommit(message); err != nil {
+		return
+	}
+
+	return repoData.LoadHead()
+}
+
+// CreateBranch creates a local branch at the provided commit. If HEAD is detached at the
+// commit then it is attached to the new branch so that subsequent commits are recorded on the branch
+func (repoData *RepositoryData) CreateBranch(branchName string, oid *Oid) (err error) {
+	if oid == nil {
+		return fmt.Errorf("Cannot create a branch as no commit was specified")
+	}
+
+	if err = repoData.repoDataLoader.CreateBranch(branchName, oid); err != nil {
+		return
+	}
+
+	if head, headBranch := repoData.Head(); headBranch == nil && head == oid {
+		if err = repoData.repoDataLoader.AttachHead(branchName); err != nil {
+			return
+		}
+	}
+
+	return repoData.LoadHead()
+}
+
+// CreateTrackingBranch creates a local branch at the commit of a remote branch with the remote
+// branch as its upstream. The new branch is checked out if requested
+func (repoData *RepositoryData) CreateTrackingBranch(remoteBranch *Branch, branchName string, checkout bool) (err error) {
+	if err = repoData.repoDataLoader.CreateBranch(branchName, remoteBranch.oid); err != nil {
+		return
+	}
+
+	if err = repoData.repoDataLoader.SetUpstream(branchName, remoteBranch.name); err != nil {
+		return
+	}
+
+	if checkout {
+		if err = repoData.repoDataLoader.CheckoutBranch(branchName, remoteBranch.oid); err != nil {
+			return fmt.Errorf("Created branch %v but unable to check it out: %v", branchName, err)
+		}
+	}
+
+	return repoData.LoadHead()
+}
+
+// RenameBranch renames a local branch and reloads HEAD as it may refer to the branch
+func (repoData *RepositoryData) RenameBranch(branch *Branch, newBranchName string) (err error) {
+	if err = repoData.repoDataLoader.RenameBranch(branch.name, newBranchName); err != nil {
+		return
+	}
+
+	return repoData.LoadHead()
+}
+
+// RefsContainingCommit returns the loaded branches and tags from which the provided commit is reachable
+func (repoData *RepositoryData) RefsContainingCommit(oid *Oid) (branches []*Branch, tags []*Tag, err error) {
+	localBranches, remoteBranches, _ := repoData.Branches()
+	localTags, _ := repoData.LocalTags()
+
+	for _, branch := range append(append([]*Branch(nil), localBranches...), remoteBranches...) {
+		var contains bool
+		if contains, err = repoData.containsCommit(branch.oid, oid); err != nil {
+			return
+		} else if contains {
+			branches = append(branches, branch)
+		}
+	}
+
+	for _, tag := range localTags {
+		var contains bool
+		if contains, err = repoData.containsCommit(tag.oid, oid); err != nil {
+			return
+		} else if contains {
+			tags = append(tags, tag)
+		}
+	}
+
+	return
+}
+
+// CommitRange returns the commits reachable from toCommit and not reachable from fromCommit
+// in topological order (i.e. git log fromCommit..toCommit)
+func (repoData *RepositoryData) CommitRange(fromCommit, toCommit *Commit) (commits []*Commit, err error) {
+	commitCh, err := repoData.repoDataLoader.Commits([]*Oid{toCommit.oid}, []*Oid{fromCommit.oid}, CommitOrderTopo)
+	if err != nil {
+		return
+	}
+
+	for commit := range commitCh {
+		commits = append(commits, commit)
+	}
+
+	return
+}
+
+// autosquashTargets tracks the summaries of the commits targeted by fixup! and squash! commits
+// while the history of HEAD is walked from newest to oldest
+type autosquashTargets struct {
+	pending map[string]bool
+	found   bool
+}
+
+func newAutosquashTargets() *autosquashTargets {
+	return &autosquashTargets{
+		pending: make(map[string]bool),
+	}
+}
+
+// visit records the summary of the next commit in history and returns true if it is a pending target
+func (targets *autosquashTargets) visit(summary string) bool {
+	if targetSummary, isAutosquash := AutosquashTarget(summary); isAutosquash {
+		targets.pending[targetSummary] = true
+		return false
+	}
+
+	if targets.pending[summary] {
+		delete(targets.pending, summary)
+		targets.found = true
+		return true
+	}
+
+	return false
+}
+
+// complete returns true once every target of the visited fixup! and squash! commits has been found
+func (targets *autosquashTargets) complete() bool {
+	return targets.found && len(targets.pending) == 0
+}
+
+// OldestAutosquashTarget returns the oldest commit in the history of HEAD which a fixup! or squash! commit targets
+// An autosquash rebase must start from the parent of this commit
+func (repoData *RepositoryData) OldestAutosquashTarget() (oldestTarget *Commit, err error) {
+	targets := newAutosquashTargets()
+
+	if err = repoData.repoDataLoader.HeadHistory(func(commit *Commit) bool {
+		if targets.visit(commit.commit.Summary()) {
+			oldestTarget = commit
+		}
+
+		return !targets.complete()
+	}); err != nil {
+		return
+	}
+
+	switch {
+	case len(targets.pending) > 0:
+		var summaries []string
+		for summary := range targets.pending {
+			summaries = append(summaries, summary)
+		}
+
+		sort.Strings(summaries)
+		return nil, fmt.Errorf("Unable to find commits targeted by fixup or squash commits: %v", strings.Join(summaries, ", "))
+	case oldestTarget == nil:
+		return nil, errors.New("No fixup or squash commits found on HEAD")
+	}
+
+	return
+}
+
+// AutosquashRebaseCommand creates a command which runs an autosquash rebase from the parent of the provided commit
+func (repoData *RepositoryData) AutosquashRebaseCommand(commit *Commit) *exec.Cmd {
+	return repoData.repoDataLoader.AutosquashRebaseCommand(commit)
+}
+
+// Describe generates a description of the commit in the same form as git describe --tags
+// The nearest tagged ancestor is found by walking the history of the commit breadth first.
+// found is false if no tag is reachable within the first rdlDescribeMaxCommits commits of the history
+func (repoData *RepositoryData) Describe(ctx context.Context, commit *Commit) (description string, found bool, err error) {
+	taggedCommits := repoData.commitRefSet.taggedCommits()
+	if len(taggedCommits) == 0 {
+		return
+	}
+
+	ancestor, found, err := repoData.repoDataLoader.NearestAncestor(ctx, commit, rdlDescribeMaxCommits, func(oid *Oid) bool {
+		_, isTagged := taggedCommits[oid.String()]
+		return isTagged
+	})
+	if err != nil || !found {
+		return
+	}
+
+	var distance uint
+	if ancestor.String() != commit.oid.String() {
+		if distance, err = repoData.repoDataLoader.CommitsAhead(commit.oid, ancestor); err != nil {
+			return
+		}
+	}
+
+	return formatDescription(taggedCommits[ancestor.String()], distance, repoData.ShortID(commit.oid)), true, nil
+}
+
+// formatDescription generates a description of a commit relative to the tags of its nearest tagged ancestor
+// When the ancestor has multiple tags the tag sorting last by name is used
+func formatDescription(tags []*Tag, distance uint, shortID string) string {
+	tagName := tags[0].name
+	for _, tag := range tags[1:] {
+		if tag.name > tagName {
+			tagName = tag.name
+		}
+	}
+
+	if distance == 0 {
+		return tagName
+	}
+
+	return fmt.Sprintf("%v-%v-g%v", tagName, distance, shortID)
+}
+
+// containsCommit returns true if the commit is reachable from the ref
+// Refs which do not point to a commit are ignored
+func (repoData *RepositoryData) containsCommit(refOid, oid *Oid) (bool, error) {
+	refCommit, err := repoData.repoDataLoader.Commit(refOid)
+	if err != nil || refCommit == nil {
+		log.Debugf("Ignoring ref %v which does not point to a commit", refOid)
+		return false, nil
+	}
+
+	if refCommit.oid == oid {
+		return true, nil
+	}
+
+	return repoData.repoDataLoader.DescendantOf(refCommit.oid, oid)
+}
+
+// UnreachableCommits returns the tips of history which is not reachable from HEAD or any ref
+func (repoData *RepositoryData) UnreachableCommits(ctx context.Context, onProgress func(objectsExamined uint)) ([]*Commit, error) {
+	return repoData.repoDataLoader.UnreachableCommits(ctx, onProgress)
+}
+
+// UpstreamStatus returns how the local branch compares to its upstream branch
+func (repoData *RepositoryData) UpstreamStatus(branch *Branch) (*UpstreamStatus, error) {
+	return repoData.repoDataLoader.UpstreamStatus(branch)
+}
+
+// AheadBehind returns the number of commits reachable from oid and not base and vice versa
+func (repoData *RepositoryData) AheadBehind(oid, base *Oid) (ahead, behind int, err error) {
+	return repoData.repoDataLoader.AheadBehind(oid, base)
+}
+
+// ModifiedFileCount returns the number of files with changes in the index or working directory
+func (repoData *RepositoryData) ModifiedFileCount() (uint, error) {
+	return repoData.repoDataLoader.ModifiedFileCount()
+}
+
+// Remotes returns the names of all configured remotes
+func (repoData *RepositoryData) Remotes() ([]string, error) {
+	return repoData.repoDataLoader.Remotes()
+}
+
+// RecentActivity returns the most recent entries in the HEAD reflog
+func (repoData *RepositoryData) RecentActivity(limit uint) ([]*ActivityEntry, error) {
+	return repoData.repoDataLoader.RecentActivity(limit)
+}
+
+// CheckedOutBranches returns the names of previously checked out branches, most recent first
+func (repoData *RepositoryData) CheckedOutBranches() ([]string, error) {
+	return repoData.repoDataLoader.CheckedOutBranches()
+}
+
+// Worktrees returns the worktrees of the repository
+func (repoData *RepositoryData) Worktrees() ([]*Worktree, error) {
+	return repoData.repoDataLoader.Worktrees()
+}
+
+// BranchDescriptions returns the configured descriptions of the provided branches
+func (repoData *RepositoryData) BranchDescriptions(branchNames []string) (map[string]string, error) {
+	return repoData.repoDataLoader.BranchDescriptions(branchNames)
+}
+
+// SetBranchDescription sets or removes the description of a branch
+func (repoData *RepositoryData) SetBranchDescription(branchName, description string) error {
+	return repoData.repoDataLoader.SetBranchDescription(branchName, description)
+}
+
+// SetUpstream sets the upstream of a local branch
+func (repoData *RepositoryData) SetUpstream(branch *Branch, upstreamName string) error {
+	return repoData.repoDataLoader.SetUpstream(branch.name, upstreamName)
+}
+
+// Editor returns the command used to edit files
+func (repoData *RepositoryData) Editor() string {
+	return repoData.repoDataLoader.Editor()
+}
+
+// FileContents returns the contents of the file at the provided path in the tree of the commit
+func (repoData *RepositoryData) FileContents(commit *Commit, filePath string) ([]byte, error) {
+	return repoData.repoDataLoader.FileContents(commit, filePath)
+}
+
+// Blame determines the commit which last modified each line of the file
+func (repoData *RepositoryData) Blame(commit *Commit, filePath string) ([]*BlameHunk, error) {
+	return repoData.repoDataLoader.Blame(commit, filePath)
+}
+
+// Pager returns the command used to page output
+func (repoData *RepositoryData) Pager() string {
+	return repoData.repoDataLoader.Pager()
+}
+
+// WhitespaceRules returns the whitespace error rules configured by core.whitespace
+func (repoData *RepositoryData) WhitespaceRules() WhitespaceRules {
+	return repoData.repoDataLoader.WhitespaceRules()
+}
+
+// TreePaths returns the paths of all files in the tree of the provided commit
+func (repoData *RepositoryData) TreePaths(commit *Commit) ([]string, error) {
+	return repoData.repoDataLoader.TreePaths(commit)
+}
+
+// ApplyStash applies the provided stash to the working directory
+func (repoData *RepositoryData) ApplyStash(stash *Stash) error {
+	return repoData.repoDataLoader.ApplyStash(stash.index)
+}
+
+// PopStash applies the provided stash to the working directory, removes it and reloads the stash entries
+func (repoData *RepositoryData) PopStash(stash *Stash) (err error) {
+	if err = repoData.repoDataLoader.PopStash(stash.index); err != nil {
+		return
+	}
+
+	return repoData.LoadStashes()
+}
+
+// DropStash removes the provided stash and reloads the stash entries
+func (repoData *RepositoryData) DropStash(stash *Stash) (err error) {
+	if err = repoData.repoDataLoader.DropStash(stash.index); err != nil {
+		return
+	}
+
+	return repoData.LoadStashes()
+}
+
 // RefsForCommit returns the set of all refs that point to the provided commit
 func (repoData *RepositoryData) RefsForCommit(commit *Commit) *CommitRefs {
 	return repoData.commitRefSet.refsForCommit(commit)
@@ -735,6 +1498,11 @@ func (repoData *RepositoryData) CommitSetState(oid *Oid) CommitSetState {
 	}
 }
 
+// ClearCommitSets discards all loaded commits so they are reloaded when next requested
+func (repoData *RepositoryData) ClearCommitSets() {
+	repoData.refCommitSets.clear()
+}
+
 // Commits returns a channel from which the commit range specified can be read
 func (repoData *RepositoryData) Commits(oid *Oid, startIndex, count uint) (<-chan *Commit, error) {
 	commitSet, ok := repoData.refCommitSets.commitSet(oid)
@@ -786,6 +1554,85 @@ func (repoData *RepositoryData) Commit(oid *Oid) (*Commit, error) {
 	return repoData.repoDataLoader.Commit(oid)
 }
 
+// CodeOwners returns the parsed CODEOWNERS file in the tree of the commit or nil if there is none
+// Parsed files are cached so the owners of files can be determined while rendering
+func (repoData *RepositoryData) CodeOwners(commit *Commit) (*CodeOwners, error) {
+	blobID, contents, err := repoData.repoDataLoader.CodeOwnersFile(commit)
+	if err != nil || blobID == "" {
+		return nil, err
+	}
+
+	repoData.codeOwnersLock.Lock()
+	defer repoData.codeOwnersLock.Unlock()
+
+	codeOwners, ok := repoData.codeOwners[blobID]
+	if !ok {
+		codeOwners = ParseCodeOwners(string(contents))
+		repoData.codeOwners[blobID] = codeOwners
+	}
+
+	return codeOwners, nil
+}
+
+// OwnerCommitFilter returns a filter which matches commits modifying files owned by the provided owner
+// Ownership is determined by the CODEOWNERS file at HEAD
+func (repoData *RepositoryData) OwnerCommitFilter(owner string) (*CommitFilter, error) {
+	head, _ := repoData.Head()
+	if head == nil {
+		return nil, fmt.Errorf("Unable to determine HEAD")
+	}
+
+	headCommit, err := repoData.Commit(head)
+	if err != nil {
+		return nil, err
+	}
+
+	codeOwners, err := repoData.CodeOwners(headCommit)
+	if err != nil {
+		return nil, err
+	} else if codeOwners == nil {
+		return nil, fmt.Errorf("No CODEOWNERS file found at HEAD")
+	}
+
+	return NewCommitFilter(func(inputValue interface{}) bool {
+		commit := inputValue.(*Commit)
+
+		paths, err := repoData.repoDataLoader.ChangedPaths(commit)
+		if err != nil {
+			log.Debugf("Unable to determine paths changed by commit %v: %v", commit.oid, err)
+			return false
+		}
+
+		for _, filePath := range paths {
+			if codeOwners.IsOwnedBy(filePath, owner) {
+				return true
+			}
+		}
+
+		return false
+	}), nil
+}
+
+// CommitByRevision loads the commit the provided revision resolves to
+func (repoData *RepositoryData) CommitByRevision(revision string) (*Commit, error) {
+	return repoData.repoDataLoader.CommitByRevision(revision)
+}
+
+// DecoratedCommitFilter returns a filter which matches commits pointed to by a branch, tag or HEAD
+// Applying it displays history in the same way as git log --simplify-by-decoration
+func (repoData *RepositoryData) DecoratedCommitFilter() *CommitFilter {
+	return NewCommitFilter(func(inputValue interface{}) bool {
+		commit := inputValue.(*Commit)
+
+		if head, _ := repoData.Head(); head != nil && head.String() == commit.oid.String() {
+			return true
+		}
+
+		commitRefs := repoData.RefsForCommit(commit)
+		return len(commitRefs.branches) > 0 || len(commitRefs.tags) > 0
+	})
+}
+
 // AddCommitFilter adds the filter to the specified ref
 func (repoData *RepositoryData) AddCommitFilter(oid *Oid, commitFilter *CommitFilter) error {
 	return repoData.refCommitSets.addCommitFilter(oid, commitFilter)
@@ -797,6 +1644,16 @@ func (repoData *RepositoryData) RemoveCommitFilter(oid *Oid) error {
 }
 
 // Diff loads a diff for the specified oid
-func (repoData *RepositoryData) Diff(commit *Commit) (*Diff, error) {
-	return repoData.repoDataLoader.Diff(commit)
+func (repoData *RepositoryData) Diff(ctx context.Context, commit *Commit, diffOptions DiffOptions) (*Diff, error) {
+	return repoData.repoDataLoader.Diff(ctx, commit, diffOptions)
+}
+
+// DiffCommits loads a diff between the two provided commits
+func (repoData *RepositoryData) DiffCommits(ctx context.Context, fromCommit, toCommit *Commit, diffOptions DiffOptions) (*Diff, error) {
+	return repoData.repoDataLoader.DiffCommits(ctx, fromCommit, toCommit, diffOptions)
+}
+
+// LineHistory loads the commits which modified the provided line range of a file
+func (repoData *RepositoryData) LineHistory(commit *Commit, filePath string, startLine, endLine uint) ([]*LineHistoryEntry, error) {
+	return repoData.repoDataLoader.LineHistory(commit, filePath, startLine, endLine)
 }
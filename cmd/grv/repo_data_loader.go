@@ -2,25 +2,47 @@ package main
 
 import (
 	"bytes"
+	"container/heap"
+	"context"
 	"errors"
 	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
 	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 	git "gopkg.in/libgit2/git2go.v25"
 )
 
 const (
-	rdlCommitBufferSize = 100
-	rdlDiffStatsCols    = 80
-	rdlShortOidLen      = 7
+	rdlCommitBufferSize  = 100
+	rdlDiffStatsCols     = 80
+	rdlShortOidLen       = 7
+	rdlSubmoduleLogMax   = 50
+	rdlLineHistoryMax    = 100
+	rdlLocalBranchPrefix = "refs/heads/"
+
+	rdlCheckoutReflogPrefix = "checkout: moving from "
+
+	rdlUnreachableProgressInterval = 1000
+
+	rdlDescribeMaxCommits = 10000
+
+	rdlCeilingDirectoriesEnv = "GIT_CEILING_DIRECTORIES"
 )
 
 type instanceCache struct {
-	oids       map[string]*Oid
-	commits    map[string]*Commit
-	oidLock    sync.Mutex
-	commitLock sync.Mutex
+	oids        map[string]*Oid
+	commits     map[string]*Commit
+	shortIDs    map[string]string
+	oidLock     sync.Mutex
+	commitLock  sync.Mutex
+	shortIDLock sync.Mutex
 }
 
 // RepoDataLoader handles loading data from the repository
@@ -55,10 +77,74 @@ type Commit struct {
 	commit *git.Commit
 }
 
+// Stash contains data for a stash entry
+type Stash struct {
+	oid     *Oid
+	index   int
+	message string
+}
+
+// UpstreamStatus describes how a local branch compares to its upstream branch
+type UpstreamStatus struct {
+	name   string
+	ahead  int
+	behind int
+}
+
+// ActivityEntry is an entry in the HEAD reflog
+type ActivityEntry struct {
+	oid     *Oid
+	message string
+	when    time.Time
+}
+
+// DiffOptions controls how diffs are generated
+type DiffOptions struct {
+	detectRenames       bool
+	detectCopies        bool
+	similarityThreshold uint16
+	contextLines        uint32
+	excludePatterns     []string
+	textconv            bool
+	onFilesGenerated    DiffFilesListener
+}
+
+// DiffFilesListener is notified as the patches for the files of a diff are generated
+// The diff contains the stats and the patches generated so far and files are provided in diff order
+type DiffFilesListener func(diff *Diff, files []*DiffFile)
+
+// DiffFile contains the change status and patch for a single file in a diff
+type DiffFile struct {
+	status  git.Delta
+	oldPath string
+	newPath string
+	patch   bytes.Buffer
+}
+
 // Diff contains data for a generated diff
+// excludedFiles is the number of files omitted as they matched an exclude pattern
 type Diff struct {
-	diffText bytes.Buffer
-	stats    bytes.Buffer
+	diffText      bytes.Buffer
+	stats         bytes.Buffer
+	files         []*DiffFile
+	excludedFiles uint
+}
+
+// LineHistoryEntry is a commit which modified a traced line range along with
+// the hunks of the diff which modified the range
+type LineHistoryEntry struct {
+	commit *Commit
+	patch  bytes.Buffer
+}
+
+// BlameHunk is a range of lines in a file which were last modified by the same commit
+type BlameHunk struct {
+	oid       *Oid
+	author    string
+	email     string
+	when      time.Time
+	startLine uint
+	lineCount uint
 }
 
 // String returns the oid hash
@@ -68,10 +154,15 @@ func (oid Oid) String() string {
 
 // ShortID returns a shortened oid hash
 func (oid Oid) ShortID() (shortID string) {
+	return oid.AbbreviatedID(rdlShortOidLen)
+}
+
+// AbbreviatedID returns the oid hash shortened to the specified length
+func (oid Oid) AbbreviatedID(length int) (shortID string) {
 	id := oid.String()
 
-	if len(id) >= rdlShortOidLen {
-		shortID = id[0:rdlShortOidLen]
+	if len(id) >= length {
+		shortID = id[0:length]
 	}
 
 	return
@@ -87,13 +178,61 @@ func (tag Tag) String() string {
 	return fmt.Sprintf("%v:%v", tag.name, tag.oid)
 }
 
+// String returns stash data in a string format
+func (stash Stash) String() string {
+	return fmt.Sprintf("stash@{%v}: %v", stash.index, stash.message)
+}
+
+// StatusCode returns a single character code describing how the file changed
+func (diffFile *DiffFile) StatusCode() string {
+	switch diffFile.status {
+	case git.DeltaAdded:
+		return "A"
+	case git.DeltaDeleted:
+		return "D"
+	case git.DeltaModified:
+		return "M"
+	case git.DeltaRenamed:
+		return "R"
+	case git.DeltaCopied:
+		return "C"
+	case git.DeltaTypeChange:
+		return "T"
+	}
+
+	return "?"
+}
+
 func newInstanceCache() *instanceCache {
 	return &instanceCache{
-		oids:    make(map[string]*Oid),
-		commits: make(map[string]*Commit),
+		oids:     make(map[string]*Oid),
+		commits:  make(map[string]*Commit),
+		shortIDs: make(map[string]string),
 	}
 }
 
+func (cache *instanceCache) getShortID(oid *Oid) (shortID string, ok bool) {
+	cache.shortIDLock.Lock()
+	defer cache.shortIDLock.Unlock()
+
+	shortID, ok = cache.shortIDs[oid.String()]
+	return
+}
+
+func (cache *instanceCache) setShortID(oid *Oid, shortID string) {
+	cache.shortIDLock.Lock()
+	defer cache.shortIDLock.Unlock()
+
+	cache.shortIDs[oid.String()] = shortID
+}
+
+func (cache *instanceCache) clearShortIDs() {
+	cache.shortIDLock.Lock()
+	defer cache.shortIDLock.Unlock()
+
+	cache.shortIDs = make(map[string]string)
+}
+
 func (cache *instanceCache) getOid(rawOid *git.Oid) *Oid {
 	cache.oidLock.Lock()
 	defer cache.oidLock.Unlock()
@@ -147,14 +286,27 @@ func (repoDataLoader *RepoDataLoader) Free() {
 }
 
 // Initialise attempts to access the repository
-func (repoDataLoader *RepoDataLoader) Initialise(repoPath string) error {
+// The repository is searched for in the provided path and its parent directories, stopping
+// at any directory listed in GIT_CEILING_DIRECTORIES. libgit2 handles the path format of
+// the platform, including drive and UNC paths on Windows
+// If a work tree path is provided it is used in place of the work tree configured for the repository
+func (repoDataLoader *RepoDataLoader) Initialise(repoPath, workTreePath string) error {
 	log.Infof("Opening repository at %v", repoPath)
 
-	repo, err := git.OpenRepository(repoPath)
+	repo, err := git.OpenRepositoryExtended(repoPath, 0, os.Getenv(rdlCeilingDirectoriesEnv))
 	if err != nil {
 		return err
 	}
 
+	if workTreePath != "" {
+		log.Infof("Using work tree %v", workTreePath)
+
+		if err = repo.SetWorkdir(workTreePath, false); err != nil {
+			repo.Free()
+			return err
+		}
+	}
+
 	repoDataLoader.repo = repo
 
 	return nil
@@ -166,8 +318,17 @@ func (repoDataLoader *RepoDataLoader) Path() string {
 }
 
 // Head loads the current HEAD ref
+// If HEAD points to a branch with no commits then the returned oid is nil
 func (repoDataLoader *RepoDataLoader) Head() (oid *Oid, branch *Branch, err error) {
 	log.Debug("Loading HEAD")
+
+	unborn, err := repoDataLoader.repo.IsHeadUnborn()
+	if err != nil {
+		return
+	} else if unborn {
+		return repoDataLoader.unbornHead()
+	}
+
 	ref, err := repoDataLoader.repo.Head()
 	if err != nil {
 		return
@@ -194,6 +355,96 @@ func (repoDataLoader *RepoDataLoader) Head() (oid *Oid, branch *Branch, err erro
 	return
 }
 
+func (repoDataLoader *RepoDataLoader) unbornHead() (oid *Oid, branch *Branch, err error) {
+	ref, err := repoDataLoader.repo.References.Lookup("HEAD")
+	if err != nil {
+		return
+	}
+
+	branch = &Branch{
+		name: strings.TrimPrefix(ref.SymbolicTarget(), rdlLocalBranchPrefix),
+	}
+
+	log.Debugf("HEAD points to unborn branch %v", branch.name)
+
+	return
+}
+
+// FetchRemotes fetches all configured remotes.
+// Authentication is attempted using the ssh agent and then any configured git credential helpers.
+// The context error is returned if the context is cancelled before all remotes have been fetched
+func (repoDataLoader *RepoDataLoader) FetchRemotes(ctx context.Context, remoteOptions RemoteOptions) (err error) {
+	remoteNames, err := repoDataLoader.repo.Remotes.List()
+	if err != nil {
+		return
+	}
+
+	// Fetched objects may make existing abbreviated ids ambiguous
+	defer repoDataLoader.cache.clearShortIDs()
+
+	for _, remoteName := range remoteNames {
+		if err = repoDataLoader.fetchRemote(ctx, remoteName, remoteOptions); ctx.Err() != nil {
+			return ctx.Err()
+		} else if err != nil {
+			return fmt.Errorf("Failed to fetch %v: %v", remoteName, err)
+		}
+	}
+
+	return
+}
+
+// UniqueShortID returns the shortest abbreviation of the oid which is unambiguous in the object database
+// The abbreviation is at least as long as core.abbrev. If the object cannot be found the default
+// abbreviation is returned
+func (repoDataLoader *RepoDataLoader) UniqueShortID(oid *Oid) string {
+	if shortID, ok := repoDataLoader.cache.getShortID(oid); ok {
+		return shortID
+	}
+
+	object, err := repoDataLoader.repo.Lookup(oid.oid)
+	if err != nil {
+		log.Debugf("Unable to lookup object %v: %v", oid, err)
+		return oid.ShortID()
+	}
+	defer object.Free()
+
+	shortID, err := object.ShortId()
+	if err != nil {
+		log.Debugf("Unable to determine short id for object %v: %v", oid, err)
+		return oid.ShortID()
+	}
+
+	repoDataLoader.cache.setShortID(oid, shortID)
+
+	return shortID
+}
+
+func (repoDataLoader *RepoDataLoader) fetchRemote(ctx context.Context, remoteName string, remoteOptions RemoteOptions) (err error) {
+	log.Infof("Fetching remote %v", remoteName)
+
+	remote, err := repoDataLoader.repo.Remotes.Lookup(remoteName)
+	if err != nil {
+		return
+	}
+	defer remote.Free()
+
+	config, err := repoDataLoader.repo.Config()
+	if err != nil {
+		return
+	}
+	defer config.Free()
+
+	fetchOptions, session, err := newFetchOptions(ctx, config, remoteName, remote.Url(), remoteOptions)
+	if err != nil {
+		return
+	}
+
+	err = remote.Fetch(nil, fetchOptions, "")
+	session.complete(err)
+
+	return
+}
+
 // LoadBranches loads all local branch refs currently in the repository
 func (repoDataLoader *RepoDataLoader) LoadBranches() (branches []*Branch, err error) {
 	branchIter, err := repoDataLoader.repo.NewBranchIterator(git.BranchAll)
@@ -274,160 +525,1786 @@ func (repoDataLoader *RepoDataLoader) LocalTags() (tags []*Tag, err error) {
 	return
 }
 
-// Commits loads all commits for the provided ref and returns a channel from which the loaded commits can be read
-func (repoDataLoader *RepoDataLoader) Commits(oid *Oid) (<-chan *Commit, error) {
-	log.Debugf("Loading commits for oid %v", oid)
+// UpstreamStatus determines the number of commits the local branch is ahead and behind its upstream branch
+// nil is returned if the branch has no upstream
+func (repoDataLoader *RepoDataLoader) UpstreamStatus(branch *Branch) (upstreamStatus *UpstreamStatus, err error) {
+	rawBranch, err := repoDataLoader.repo.LookupBranch(branch.name, git.BranchLocal)
+	if err != nil {
+		return
+	}
+	defer rawBranch.Free()
 
-	revWalk, err := repoDataLoader.repo.Walk()
+	upstream, err := rawBranch.Upstream()
 	if err != nil {
-		return nil, err
+		log.Debugf("Branch %v has no upstream: %v", branch.name, err)
+		return nil, nil
 	}
+	defer upstream.Free()
 
-	revWalk.Sorting(git.SortTime)
-	if err := revWalk.Push(oid.oid); err != nil {
-		return nil, err
+	ahead, behind, err := repoDataLoader.repo.AheadBehind(branch.oid.oid, upstream.Target())
+	if err != nil {
+		return
 	}
 
-	commitCh := make(chan *Commit, rdlCommitBufferSize)
+	upstreamStatus = &UpstreamStatus{
+		name:   upstream.Shorthand(),
+		ahead:  ahead,
+		behind: behind,
+	}
 
-	go func() {
-		commitNum := 0
-		if err := revWalk.Iterate(func(commit *git.Commit) bool {
-			if repoDataLoader.channels.Exit() {
-				return false
-			}
+	return
+}
 
-			commitNum++
-			commitCh <- repoDataLoader.cache.getCommit(commit)
-			return true
-		}); err != nil {
-			log.Errorf("Error when iterating over commits for oid %v: %v", oid, err)
-		}
+// AheadBehind determines the number of commits reachable from oid which are not reachable from base
+// and the number of commits reachable from base which are not reachable from oid
+func (repoDataLoader *RepoDataLoader) AheadBehind(oid, base *Oid) (ahead, behind int, err error) {
+	return repoDataLoader.repo.AheadBehind(oid.oid, base.oid)
+}
 
-		close(commitCh)
-		revWalk.Free()
-		log.Debugf("Loaded %v commits for oid %v", commitNum, oid)
-	}()
+// SetUpstream sets the upstream of the provided local branch
+func (repoDataLoader *RepoDataLoader) SetUpstream(branchName, upstreamName string) (err error) {
+	rawBranch, err := repoDataLoader.repo.LookupBranch(branchName, git.BranchLocal)
+	if err != nil {
+		return
+	}
+	defer rawBranch.Free()
 
-	return commitCh, nil
+	return rawBranch.SetUpstream(upstreamName)
 }
 
-// Commit loads a commit for the provided oid (if it points to a commit)
-func (repoDataLoader *RepoDataLoader) Commit(oid *Oid) (commit *Commit, err error) {
-	object, err := repoDataLoader.repo.Lookup(oid.oid)
+// ModifiedFileCount returns the number of files which have changes in the index or working directory
+// Untracked files are included in the count
+func (repoDataLoader *RepoDataLoader) ModifiedFileCount() (fileNum uint, err error) {
+	if repoDataLoader.repo.IsBare() {
+		return 0, errors.New("Cannot determine the status of a bare repository")
+	}
+
+	statusList, err := repoDataLoader.repo.StatusList(&git.StatusOptions{
+		Show:  git.StatusShowIndexAndWorkdir,
+		Flags: git.StatusOptIncludeUntracked | git.StatusOptRecurseUntrackedDirs,
+	})
 	if err != nil {
-		log.Debugf("Error when attempting to lookup object with ID %v", oid)
 		return
 	}
+	defer statusList.Free()
 
-	var rawCommit *git.Commit
+	entryNum, err := statusList.EntryCount()
+	if err != nil {
+		return
+	}
 
-	switch object.Type() {
-	case git.ObjectCommit:
-		rawCommit, err = object.AsCommit()
-		if err != nil {
-			log.Debugf("Error when attempting convert object with ID %v to commit", oid)
-			return
-		}
-	case git.ObjectTag:
-		var tag *git.Tag
-		tag, err = object.AsTag()
-		if err != nil {
-			log.Debugf("Error when attempting convert object with ID %v to tag", oid)
-			return
-		}
+	return uint(entryNum), nil
+}
 
-		if tag.TargetType() != git.ObjectCommit {
-			err = fmt.Errorf("Tag with ID %v does not point to a commit", oid)
-			return
-		}
+// Remotes returns the names of all configured remotes
+func (repoDataLoader *RepoDataLoader) Remotes() ([]string, error) {
+	return repoDataLoader.repo.Remotes.List()
+}
 
-		rawCommit, err = tag.Target().AsCommit()
-		if err != nil {
-			log.Debugf("Error when attempting convert tag with ID %v to commit", oid)
-			return
-		}
-	default:
-		log.Debugf("Unable to convert object with type %v and ID %v to a commit", object.Type().String(), oid)
+// RecentActivity returns up to limit of the most recent entries in the HEAD reflog
+func (repoDataLoader *RepoDataLoader) RecentActivity(limit uint) (entries []*ActivityEntry, err error) {
+	reflog, err := repoDataLoader.repo.ReadReflog("HEAD")
+	if err != nil {
 		return
 	}
+	defer reflog.Free()
 
-	commit = repoDataLoader.cache.getCommit(rawCommit)
+	for entryIndex := uint(0); entryIndex < reflog.EntryCount() && entryIndex < limit; entryIndex++ {
+		entry := reflog.EntryByIndex(entryIndex)
+
+		activityEntry := &ActivityEntry{
+			oid:     repoDataLoader.cache.getOid(entry.New),
+			message: entry.Message,
+		}
+
+		if entry.Committer != nil {
+			activityEntry.when = entry.Committer.When
+		}
+
+		entries = append(entries, activityEntry)
+	}
 
 	return
 }
 
-// Diff generates a diff for the provided commit
-func (repoDataLoader *RepoDataLoader) Diff(commit *Commit) (diff *Diff, err error) {
-	diff = &Diff{}
+// Worktrees returns the main and linked worktrees of the repository
+// The worktree the repository was opened with is marked as current
+func (repoDataLoader *RepoDataLoader) Worktrees() ([]*Worktree, error) {
+	return readWorktrees(repoDataLoader.repo.Path(), repoDataLoader.repo.Workdir())
+}
 
-	if commit.commit.ParentCount() > 1 {
+// CheckedOutBranches returns the distinct names of branches HEAD has been moved away from by a checkout
+// Names are ordered from the most to the least recently checked out
+func (repoDataLoader *RepoDataLoader) CheckedOutBranches() (branchNames []string, err error) {
+	reflog, err := repoDataLoader.repo.ReadReflog("HEAD")
+	if err != nil {
 		return
 	}
+	defer reflog.Free()
 
-	var commitTree, parentTree *git.Tree
-	if commitTree, err = commit.commit.Tree(); err != nil {
-		return
+	var messages []string
+	for entryIndex := uint(0); entryIndex < reflog.EntryCount(); entryIndex++ {
+		messages = append(messages, reflog.EntryByIndex(entryIndex).Message)
 	}
-	defer commitTree.Free()
 
-	if commit.commit.ParentCount() > 0 {
-		if parentTree, err = commit.commit.Parent(0).Tree(); err != nil {
-			return
+	return checkedOutBranchNames(messages), nil
+}
+
+// checkedOutBranchNames extracts the distinct names of the branches checked out from in the provided reflog messages
+func checkedOutBranchNames(messages []string) (branchNames []string) {
+	seen := make(map[string]bool)
+
+	for _, message := range messages {
+		if !strings.HasPrefix(message, rdlCheckoutReflogPrefix) {
+			continue
 		}
-		defer parentTree.Free()
-	}
 
-	options, err := git.DefaultDiffOptions()
-	if err != nil {
-		return
+		move := strings.SplitN(strings.TrimPrefix(message, rdlCheckoutReflogPrefix), " to ", 2)
+		if len(move) != 2 || move[0] == "" || seen[move[0]] {
+			continue
+		}
+
+		seen[move[0]] = true
+		branchNames = append(branchNames, move[0])
 	}
 
-	commitDiff, err := repoDataLoader.repo.DiffTreeToTree(parentTree, commitTree, &options)
+	return
+}
+
+// BranchDescriptions returns the descriptions configured for the provided branches
+// Branches without a description are not included
+func (repoDataLoader *RepoDataLoader) BranchDescriptions(branchNames []string) (descriptions map[string]string, err error) {
+	config, err := repoDataLoader.repo.Config()
 	if err != nil {
 		return
 	}
-	defer func() {
-		if e := commitDiff.Free(); e != nil {
-			log.Errorf("Error when freeing commit diff: %v", e)
+	defer config.Free()
+
+	descriptions = make(map[string]string)
+
+	for _, branchName := range branchNames {
+		if description, err := config.LookupString(branchDescriptionConfigName(branchName)); err == nil && description != "" {
+			descriptions[branchName] = description
 		}
-	}()
+	}
 
-	stats, err := commitDiff.Stats()
+	return
+}
+
+// SetBranchDescription sets the description of the provided branch
+// The description is removed if it is empty
+func (repoDataLoader *RepoDataLoader) SetBranchDescription(branchName, description string) (err error) {
+	config, err := repoDataLoader.repo.Config()
 	if err != nil {
 		return
 	}
+	defer config.Free()
 
-	statsText, err := stats.String(git.DiffStatsFull, rdlDiffStatsCols)
-	if err != nil {
-		return
+	name := branchDescriptionConfigName(branchName)
+
+	if description != "" {
+		return config.SetString(name, description)
 	}
 
-	diff.stats.WriteString(statsText)
+	if err = config.Delete(name); err != nil && git.IsErrorCode(err, git.ErrNotFound) {
+		err = nil
+	}
 
-	numDeltas, err := commitDiff.NumDeltas()
+	return
+}
+
+func branchDescriptionConfigName(branchName string) string {
+	return fmt.Sprintf("branch.%v.description", branchName)
+}
+
+// Editor returns the command used to edit files
+func (repoDataLoader *RepoDataLoader) Editor() string {
+	var coreEditor string
+
+	if config, err := repoDataLoader.repo.Config(); err == nil {
+		coreEditor = firstConfigValue(config, "core.editor")
+		config.Free()
+	}
+
+	return ResolveEditor(coreEditor, os.Getenv)
+}
+
+// Pager returns the command used to page output
+func (repoDataLoader *RepoDataLoader) Pager() string {
+	var corePager string
+
+	if config, err := repoDataLoader.repo.Config(); err == nil {
+		corePager = firstConfigValue(config, "core.pager")
+		config.Free()
+	}
+
+	return ResolvePager(corePager, os.Getenv)
+}
+
+// WhitespaceRules returns the whitespace error rules configured by core.whitespace
+func (repoDataLoader *RepoDataLoader) WhitespaceRules() WhitespaceRules {
+	var coreWhitespace string
+
+	if config, err := repoDataLoader.repo.Config(); err == nil {
+		coreWhitespace = firstConfigValue(config, "core.whitespace")
+		config.Free()
+	}
+
+	return ParseWhitespaceRules(coreWhitespace)
+}
+
+// TreePaths returns the paths of all files in the tree of the provided commit
+func (repoDataLoader *RepoDataLoader) TreePaths(commit *Commit) (paths []string, err error) {
+	tree, err := commit.commit.Tree()
 	if err != nil {
 		return
 	}
+	defer tree.Free()
 
-	var patch *git.Patch
-	var patchString string
-
-	for i := 0; i < numDeltas; i++ {
-		if patch, err = commitDiff.Patch(i); err != nil {
-			return
+	err = tree.Walk(func(root string, entry *git.TreeEntry) int {
+		if entry.Type == git.ObjectBlob {
+			paths = append(paths, root+entry.Name)
 		}
 
-		if patchString, err = patch.String(); err != nil {
-			return
-		}
+		return 0
+	})
+
+	return
+}
 
-		diff.diffText.WriteString(patchString)
+// LoadStashes loads all stash entries in the repository
+func (repoDataLoader *RepoDataLoader) LoadStashes() (stashes []*Stash, err error) {
+	log.Debug("Loading stashes")
 
-		if err := patch.Free(); err != nil {
-			log.Errorf("Error when freeing patch: %v", err)
+	err = repoDataLoader.repo.Stashes.Foreach(func(index int, message string, rawOid *git.Oid) error {
+		stash := &Stash{
+			oid:     repoDataLoader.cache.getOid(rawOid),
+			index:   index,
+			message: message,
 		}
-	}
+
+		stashes = append(stashes, stash)
+		log.Debugf("Loaded stash %v", stash)
+
+		return nil
+	})
 
 	return
 }
+
+// CreateStash stashes the current state of the working directory and index
+func (repoDataLoader *RepoDataLoader) CreateStash(message string) (err error) {
+	signature, err := repoDataLoader.repo.DefaultSignature()
+	if err != nil {
+		return
+	}
+
+	if _, err = repoDataLoader.repo.Stashes.Save(signature, message, git.StashDefault); err != nil {
+		if git.IsErrorCode(err, git.ErrNotFound) {
+			err = errors.New("No local changes to stash")
+		}
+
+		return
+	}
+
+	log.Debugf("Created stash with message: %v", message)
+
+	return
+}
+
+// CreateBranch creates a local branch pointing at the provided commit
+// An error is returned if a branch with the same name already exists
+func (repoDataLoader *RepoDataLoader) CreateBranch(branchName string, oid *Oid) (err error) {
+	commit, err := repoDataLoader.repo.LookupCommit(oid.oid)
+	if err != nil {
+		return
+	}
+	defer commit.Free()
+
+	branch, err := repoDataLoader.repo.CreateBranch(branchName, commit, false)
+	if err != nil {
+		return
+	}
+	defer branch.Free()
+
+	log.Debugf("Created branch %v at %v", branchName, oid)
+
+	return
+}
+
+// RenameBranch renames a local branch. Its reflog and config section are moved
+// with it and HEAD is updated if it refers to the branch
+func (repoDataLoader *RepoDataLoader) RenameBranch(branchName, newBranchName string) (err error) {
+	rawBranch, err := repoDataLoader.repo.LookupBranch(branchName, git.BranchLocal)
+	if err != nil {
+		return
+	}
+	defer rawBranch.Free()
+
+	renamedBranch, err := rawBranch.Move(newBranchName, false)
+	if err != nil {
+		return
+	}
+	defer renamedBranch.Free()
+
+	log.Debugf("Renamed branch %v to %v", branchName, newBranchName)
+
+	return
+}
+
+// CheckoutBranch updates the working directory and index to match the provided local branch
+// and points HEAD at it. Local changes which would be overwritten cause the checkout to fail
+func (repoDataLoader *RepoDataLoader) CheckoutBranch(branchName string, oid *Oid) (err error) {
+	commit, err := repoDataLoader.repo.LookupCommit(oid.oid)
+	if err != nil {
+		return
+	}
+	defer commit.Free()
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return
+	}
+	defer tree.Free()
+
+	if err = repoDataLoader.repo.CheckoutTree(tree, &git.CheckoutOpts{Strategy: git.CheckoutSafe}); err != nil {
+		return
+	}
+
+	return repoDataLoader.AttachHead(branchName)
+}
+
+// AttachHead points HEAD at the provided local branch
+func (repoDataLoader *RepoDataLoader) AttachHead(branchName string) (err error) {
+	if err = repoDataLoader.repo.SetHead(rdlLocalBranchPrefix + branchName); err != nil {
+		return
+	}
+
+	log.Debugf("Attached HEAD to branch %v", branchName)
+
+	return
+}
+
+// DescendantOf returns true if the commit is a descendant of the ancestor commit
+func (repoDataLoader *RepoDataLoader) DescendantOf(commit, ancestor *Oid) (bool, error) {
+	return repoDataLoader.repo.DescendantOf(commit.oid, ancestor.oid)
+}
+
+// CommitParents loads the parents of the provided commit
+func (repoDataLoader *RepoDataLoader) CommitParents(commit *Commit) (parents []*Commit, err error) {
+	for parentIndex := uint(0); parentIndex < commit.commit.ParentCount(); parentIndex++ {
+		rawParent := commit.commit.Parent(parentIndex)
+		if rawParent == nil {
+			return nil, fmt.Errorf("Unable to load parent %v of commit %v", parentIndex, commit.oid)
+		}
+
+		parents = append(parents, repoDataLoader.cache.getCommit(rawParent))
+	}
+
+	return
+}
+
+// CommitsAhead returns the number of commits reachable from the commit which are not reachable from the base commit
+func (repoDataLoader *RepoDataLoader) CommitsAhead(commit, base *Oid) (uint, error) {
+	ahead, _, err := repoDataLoader.repo.AheadBehind(commit.oid, base.oid)
+	return uint(ahead), err
+}
+
+// NearestAncestor walks the history of the commit breadth first and returns the id of the first commit,
+// starting with the commit itself, for which matches returns true.
+// The walk stops if the context is cancelled or no match has been found after examining maxCommits commits.
+// Commits examined are not added to the commit cache
+func (repoDataLoader *RepoDataLoader) NearestAncestor(ctx context.Context, commit *Commit, maxCommits int, matches func(oid *Oid) bool) (ancestor *Oid, found bool, err error) {
+	visited := map[git.Oid]bool{*commit.oid.oid: true}
+	queue := []*git.Commit{commit.commit}
+
+	for examined := 0; len(queue) > 0 && examined < maxCommits; examined++ {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+
+		rawCommit := queue[0]
+		queue = queue[1:]
+
+		if oid := (&Oid{oid: rawCommit.Id()}); matches(oid) {
+			return oid, true, nil
+		}
+
+		for parentIndex := uint(0); parentIndex < rawCommit.ParentCount(); parentIndex++ {
+			parent := rawCommit.Parent(parentIndex)
+			if parent == nil {
+				return nil, false, fmt.Errorf("Unable to load parent %v of commit %v", parentIndex, rawCommit.Id())
+			}
+
+			if !visited[*parent.Id()] {
+				visited[*parent.Id()] = true
+				queue = append(queue, parent)
+			}
+		}
+	}
+
+	return
+}
+
+// HeadHistory walks the history of HEAD in topological order until onCommit returns false
+func (repoDataLoader *RepoDataLoader) HeadHistory(onCommit func(*Commit) bool) (err error) {
+	revWalk, err := repoDataLoader.repo.Walk()
+	if err != nil {
+		return
+	}
+	defer revWalk.Free()
+
+	revWalk.Sorting(git.SortTopological | git.SortTime)
+
+	if err = revWalk.PushHead(); err != nil {
+		return
+	}
+
+	return revWalk.Iterate(func(commit *git.Commit) bool {
+		return onCommit(repoDataLoader.cache.getCommit(commit))
+	})
+}
+
+// AutosquashRebaseCommand creates a command which rebases HEAD onto the parent of the provided commit,
+// squashing fixup! and squash! commits into their targets without presenting the todo list for editing.
+// The whole history of HEAD is rebased if the commit has no parents
+func (repoDataLoader *RepoDataLoader) AutosquashRebaseCommand(commit *Commit) *exec.Cmd {
+	args := []string{"rebase", "--interactive", "--autosquash"}
+
+	if commit.commit.ParentCount() == 0 {
+		args = append(args, "--root")
+	} else {
+		args = append(args, commit.oid.String()+"^")
+	}
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoDataLoader.repo.Workdir()
+	cmd.Env = append(os.Environ(),
+		"GIT_DIR="+repoDataLoader.repo.Path(),
+		"GIT_SEQUENCE_EDITOR=true",
+	)
+
+	return cmd
+}
+
+// UnreachableCommits finds commits which are not reachable from HEAD or any ref.
+// Candidate commits are gathered from the reflogs and by walking every object in the
+// object database. Only the tips of unreachable history are returned, most recent first.
+// onProgress is called periodically with the number of objects examined
+func (repoDataLoader *RepoDataLoader) UnreachableCommits(ctx context.Context, onProgress func(objectsExamined uint)) (commits []*Commit, err error) {
+	repo := repoDataLoader.repo
+
+	odb, err := repo.Odb()
+	if err != nil {
+		return
+	}
+	defer odb.Free()
+
+	candidates, err := repoDataLoader.reflogCommits(odb)
+	if err != nil {
+		return
+	}
+
+	var objectIDs []*git.Oid
+	if err = odb.ForEach(func(id *git.Oid) error {
+		objectIDs = append(objectIDs, id)
+		return ctx.Err()
+	}); err != nil {
+		return nil, err
+	}
+
+	for objectIndex, objectID := range objectIDs {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+
+		if isCommit(odb, objectID) {
+			candidates = append(candidates, objectID)
+		}
+
+		if objectIndex%rdlUnreachableProgressInterval == 0 {
+			onProgress(uint(objectIndex))
+		}
+	}
+
+	revWalk, err := repo.Walk()
+	if err != nil {
+		return
+	}
+	defer revWalk.Free()
+
+	revWalk.Sorting(git.SortTime)
+
+	for _, candidate := range candidates {
+		if err = revWalk.Push(candidate); err != nil {
+			return
+		}
+	}
+
+	if err = revWalk.HideGlob("*"); err != nil {
+		return
+	}
+
+	if unborn, _ := repo.IsHeadUnborn(); !unborn {
+		if err = revWalk.HideHead(); err != nil {
+			return
+		}
+	}
+
+	var unreachableCommits []*Commit
+	if err = revWalk.Iterate(func(commit *git.Commit) bool {
+		unreachableCommits = append(unreachableCommits, repoDataLoader.cache.getCommit(commit))
+		return ctx.Err() == nil
+	}); err != nil {
+		return
+	} else if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+
+	commits = unreachableCommitTips(unreachableCommits)
+	log.Debugf("Found %v unreachable commits with %v tips", len(unreachableCommits), len(commits))
+
+	return
+}
+
+// reflogCommits returns the commits recorded in the reflogs of HEAD and all refs
+func (repoDataLoader *RepoDataLoader) reflogCommits(odb *git.Odb) (commitIDs []*git.Oid, err error) {
+	refNames := []string{"HEAD"}
+
+	refIter, err := repoDataLoader.repo.NewReferenceIterator()
+	if err != nil {
+		return
+	}
+
+	for {
+		ref, err := refIter.Next()
+		if err != nil {
+			break
+		}
+
+		refNames = append(refNames, ref.Name())
+	}
+
+	refIter.Free()
+
+	for _, refName := range refNames {
+		reflog, err := repoDataLoader.repo.ReadReflog(refName)
+		if err != nil {
+			log.Debugf("Unable to read reflog for %v: %v", refName, err)
+			continue
+		}
+
+		for entryIndex := uint(0); entryIndex < reflog.EntryCount(); entryIndex++ {
+			entry := reflog.EntryByIndex(entryIndex)
+
+			for _, entryID := range []*git.Oid{entry.Old, entry.New} {
+				if entryID != nil && !entryID.IsZero() && isCommit(odb, entryID) {
+					commitIDs = append(commitIDs, entryID)
+				}
+			}
+		}
+
+		reflog.Free()
+	}
+
+	return
+}
+
+// isCommit returns true if the object is a commit
+// Only the object header is read so objects are not inflated to determine their type
+func isCommit(odb *git.Odb, id *git.Oid) bool {
+	_, objectType, err := odb.ReadHeader(id)
+	if err != nil {
+		return false
+	}
+
+	return objectType == git.ObjectCommit
+}
+
+// unreachableCommitTips returns the commits which are not a parent of any of the other commits
+func unreachableCommitTips(commits []*Commit) (tips []*Commit) {
+	parents := make(map[string]bool)
+
+	for _, commit := range commits {
+		for parentIndex := uint(0); parentIndex < commit.commit.ParentCount(); parentIndex++ {
+			parents[commit.commit.ParentId(parentIndex).String()] = true
+		}
+	}
+
+	for _, commit := range commits {
+		if !parents[commit.oid.String()] {
+			tips = append(tips, commit)
+		}
+	}
+
+	return
+}
+
+// CreateCommit creates a commit on HEAD from the current contents of the index.
+// The commit template and the pre-commit, prepare-commit-msg, commit-msg and post-commit hooks are honoured
+func (repoDataLoader *RepoDataLoader) CreateCommit(message string) (err error) {
+	repo := repoDataLoader.repo
+
+	if repo.IsBare() {
+		return errors.New("Cannot commit in a bare repository")
+	}
+
+	if err = repoDataLoader.runHook("pre-commit"); err != nil {
+		return
+	}
+
+	if message, err = repoDataLoader.commitMessage(message); err != nil {
+		return
+	}
+
+	index, err := repo.Index()
+	if err != nil {
+		return
+	}
+	defer index.Free()
+
+	treeOid, err := index.WriteTree()
+	if err != nil {
+		return
+	}
+
+	tree, err := repo.LookupTree(treeOid)
+	if err != nil {
+		return
+	}
+	defer tree.Free()
+
+	var parents []*git.Commit
+
+	unborn, err := repo.IsHeadUnborn()
+	if err != nil {
+		return
+	} else if !unborn {
+		var headCommit *git.Commit
+		if headCommit, err = repoDataLoader.headCommit(); err != nil {
+			return
+		}
+
+		if headCommit.TreeId().Equal(treeOid) {
+			return errors.New("No changes added to commit")
+		}
+
+		parents = append(parents, headCommit)
+	}
+
+	signature, err := repo.DefaultSignature()
+	if err != nil {
+		return
+	}
+
+	signer, err := repoDataLoader.commitSigner(signature)
+	if err != nil {
+		return
+	}
+
+	var oid *git.Oid
+	if signer == nil {
+		oid, err = repo.CreateCommit("HEAD", signature, signature, message, tree, parents...)
+	} else {
+		oid, err = repoDataLoader.createSignedCommit(signer, treeOid, parents, signature, message)
+	}
+
+	if err != nil {
+		return
+	}
+
+	log.Infof("Created commit %v", oid)
+
+	if err := repoDataLoader.runHook("post-commit"); err != nil {
+		log.Errorf("Error running post-commit hook: %v", err)
+	}
+
+	return
+}
+
+// createSignedCommit writes a signed commit object and updates HEAD to point to it
+func (repoDataLoader *RepoDataLoader) createSignedCommit(signer *CommitSigner, treeOid *git.Oid, parents []*git.Commit,
+	signature *git.Signature, message string) (oid *git.Oid, err error) {
+	var parentOids []*git.Oid
+	for _, parent := range parents {
+		parentOids = append(parentOids, parent.Id())
+	}
+
+	commitBuffer := CommitBuffer(treeOid, parentOids, signature, signature, message)
+
+	commitSignature, err := signer.Sign(commitBuffer)
+	if err != nil {
+		return
+	}
+
+	odb, err := repoDataLoader.repo.Odb()
+	if err != nil {
+		return
+	}
+	defer odb.Free()
+
+	if oid, err = odb.Write(AddCommitSignature(commitBuffer, commitSignature), git.ObjectCommit); err != nil {
+		return
+	}
+
+	head, err := repoDataLoader.repo.References.Lookup("HEAD")
+	if err != nil {
+		return
+	}
+	defer head.Free()
+
+	if head.Type() != git.ReferenceSymbolic {
+		err = repoDataLoader.repo.SetHeadDetached(oid)
+		return
+	}
+
+	summary := strings.SplitN(message, "\n", 2)[0]
+
+	ref, err := repoDataLoader.repo.References.Create(head.SymbolicTarget(), oid, true, "commit: "+summary)
+	if err != nil {
+		return
+	}
+	ref.Free()
+
+	return
+}
+
+// commitMessage applies the configured commit template and runs the commit message hooks
+func (repoDataLoader *RepoDataLoader) commitMessage(message string) (string, error) {
+	repo := repoDataLoader.repo
+
+	if config, err := repo.Config(); err == nil {
+		if templatePath, err := config.LookupString("commit.template"); err == nil && templatePath != "" {
+			if template, err := readCommitTemplate(templatePath); err != nil {
+				log.Errorf("Unable to read commit template %v: %v", templatePath, err)
+			} else {
+				message = ApplyCommitTemplate(message, template)
+			}
+		}
+
+		config.Free()
+	}
+
+	messageFile := filepath.Join(repo.Path(), "COMMIT_EDITMSG")
+	if err := ioutil.WriteFile(messageFile, []byte(message), 0644); err != nil {
+		return "", err
+	}
+
+	if err := repoDataLoader.runHook("prepare-commit-msg", messageFile, "message"); err != nil {
+		return "", err
+	}
+
+	if err := repoDataLoader.runHook("commit-msg", messageFile); err != nil {
+		return "", err
+	}
+
+	content, err := ioutil.ReadFile(messageFile)
+	if err != nil {
+		return "", err
+	}
+
+	if message = CleanupCommitMessage(string(content)); message == "" {
+		return "", errors.New("Aborting commit due to empty commit message")
+	}
+
+	return message, nil
+}
+
+func (repoDataLoader *RepoDataLoader) headCommit() (*git.Commit, error) {
+	ref, err := repoDataLoader.repo.Head()
+	if err != nil {
+		return nil, err
+	}
+	defer ref.Free()
+
+	return repoDataLoader.repo.LookupCommit(ref.Target())
+}
+
+// ApplyStash applies the stash at the provided index to the working directory
+func (repoDataLoader *RepoDataLoader) ApplyStash(index int) (err error) {
+	options, err := git.DefaultStashApplyOptions()
+	if err != nil {
+		return
+	}
+
+	return repoDataLoader.repo.Stashes.Apply(index, options)
+}
+
+// PopStash applies the stash at the provided index to the working directory and removes it if successful
+func (repoDataLoader *RepoDataLoader) PopStash(index int) (err error) {
+	options, err := git.DefaultStashApplyOptions()
+	if err != nil {
+		return
+	}
+
+	return repoDataLoader.repo.Stashes.Pop(index, options)
+}
+
+// DropStash removes the stash at the provided index
+func (repoDataLoader *RepoDataLoader) DropStash(index int) error {
+	return repoDataLoader.repo.Stashes.Drop(index)
+}
+
+// commitOrderSorting maps each commit order to the sorting applied to the revision walk
+var commitOrderSorting = map[string]git.SortType{
+	CommitOrderDate:       git.SortTime,
+	CommitOrderTopo:       git.SortTopological | git.SortTime,
+	CommitOrderAuthorDate: git.SortTopological | git.SortTime,
+	CommitOrderReverse:    git.SortTime | git.SortReverse,
+}
+
+// Commits loads all commits reachable from the provided oids and not reachable from hiddenOids
+// in the provided commit order and returns a channel from which the loaded commits can be read
+func (repoDataLoader *RepoDataLoader) Commits(oids, hiddenOids []*Oid, commitOrder string) (<-chan *Commit, error) {
+	log.Debugf("Loading commits for oids %v excluding %v in %v order", oids, hiddenOids, commitOrder)
+
+	revWalk, err := repoDataLoader.repo.Walk()
+	if err != nil {
+		return nil, err
+	}
+
+	sorting, ok := commitOrderSorting[commitOrder]
+	if !ok {
+		sorting = git.SortTime
+	}
+
+	revWalk.Sorting(sorting)
+
+	for _, oid := range oids {
+		if err := revWalk.Push(oid.oid); err != nil {
+			revWalk.Free()
+			return nil, err
+		}
+	}
+
+	for _, hiddenOid := range hiddenOids {
+		if err := revWalk.Hide(hiddenOid.oid); err != nil {
+			revWalk.Free()
+			return nil, err
+		}
+	}
+
+	commitCh := make(chan *Commit, rdlCommitBufferSize)
+
+	go func() {
+		commitNum := 0
+		var commits []*Commit
+
+		if err := revWalk.Iterate(func(commit *git.Commit) bool {
+			if repoDataLoader.channels.Exit() {
+				return false
+			}
+
+			commitNum++
+
+			// Commits can only be ordered by author date once the whole history has been walked
+			if commitOrder == CommitOrderAuthorDate {
+				commits = append(commits, repoDataLoader.cache.getCommit(commit))
+			} else {
+				commitCh <- repoDataLoader.cache.getCommit(commit)
+			}
+
+			return true
+		}); err != nil {
+			log.Errorf("Error when iterating over commits for oids %v: %v", oids, err)
+		}
+
+		for _, commit := range sortCommitsByAuthorDate(commits) {
+			commitCh <- commit
+		}
+
+		close(commitCh)
+		revWalk.Free()
+		log.Debugf("Loaded %v commits for oids %v", commitNum, oids)
+	}()
+
+	return commitCh, nil
+}
+
+// sortCommitsByAuthorDate orders commits so that no parent is shown before all of its children
+// and commits are otherwise ordered by author date, newest first (i.e. git log --author-date-order)
+func sortCommitsByAuthorDate(commits []*Commit) []*Commit {
+	commitIndexes := make(map[string]int, len(commits))
+	for commitIndex, commit := range commits {
+		commitIndexes[commit.oid.String()] = commitIndex
+	}
+
+	order := authorDateOrder(len(commits), func(commitIndex int) (parentIndexes []int) {
+		rawCommit := commits[commitIndex].commit
+
+		for parentNum := uint(0); parentNum < rawCommit.ParentCount(); parentNum++ {
+			if parentIndex, ok := commitIndexes[rawCommit.ParentId(parentNum).String()]; ok {
+				parentIndexes = append(parentIndexes, parentIndex)
+			}
+		}
+
+		return
+	}, func(commitIndex int) time.Time {
+		return commits[commitIndex].commit.Author().When
+	})
+
+	sortedCommits := make([]*Commit, 0, len(commits))
+	for _, commitIndex := range order {
+		sortedCommits = append(sortedCommits, commits[commitIndex])
+	}
+
+	return sortedCommits
+}
+
+// authorDateQueue is a max heap of commit indexes ordered by author date
+type authorDateQueue struct {
+	commitIndexes []int
+	authorTime    func(commitIndex int) time.Time
+}
+
+func (queue *authorDateQueue) Len() int {
+	return len(queue.commitIndexes)
+}
+
+func (queue *authorDateQueue) Less(i, j int) bool {
+	iTime, jTime := queue.authorTime(queue.commitIndexes[i]), queue.authorTime(queue.commitIndexes[j])
+
+	if iTime.Equal(jTime) {
+		return queue.commitIndexes[i] < queue.commitIndexes[j]
+	}
+
+	return iTime.After(jTime)
+}
+
+func (queue *authorDateQueue) Swap(i, j int) {
+	queue.commitIndexes[i], queue.commitIndexes[j] = queue.commitIndexes[j], queue.commitIndexes[i]
+}
+
+func (queue *authorDateQueue) Push(commitIndex interface{}) {
+	queue.commitIndexes = append(queue.commitIndexes, commitIndex.(int))
+}
+
+func (queue *authorDateQueue) Pop() interface{} {
+	lastIndex := len(queue.commitIndexes) - 1
+	commitIndex := queue.commitIndexes[lastIndex]
+	queue.commitIndexes = queue.commitIndexes[:lastIndex]
+
+	return commitIndex
+}
+
+// authorDateOrder returns the order in which commits should be displayed so that each commit is only
+// displayed once all of its children have been, choosing the commit with the newest author date when
+// several can be displayed. Commits with equal author dates keep the order they were provided in
+func authorDateOrder(commitNum int, parents func(commitIndex int) []int, authorTime func(commitIndex int) time.Time) (order []int) {
+	childCounts := make([]int, commitNum)
+	commitParents := make([][]int, commitNum)
+
+	for commitIndex := 0; commitIndex < commitNum; commitIndex++ {
+		commitParents[commitIndex] = parents(commitIndex)
+
+		for _, parentIndex := range commitParents[commitIndex] {
+			childCounts[parentIndex]++
+		}
+	}
+
+	queue := &authorDateQueue{authorTime: authorTime}
+
+	for commitIndex, childCount := range childCounts {
+		if childCount == 0 {
+			queue.commitIndexes = append(queue.commitIndexes, commitIndex)
+		}
+	}
+
+	heap.Init(queue)
+
+	for queue.Len() > 0 {
+		commitIndex := heap.Pop(queue).(int)
+		order = append(order, commitIndex)
+
+		for _, parentIndex := range commitParents[commitIndex] {
+			if childCounts[parentIndex]--; childCounts[parentIndex] == 0 {
+				heap.Push(queue, parentIndex)
+			}
+		}
+	}
+
+	return
+}
+
+// CommitByRevision loads the commit the provided revision (ref name, oid, etc...) resolves to
+func (repoDataLoader *RepoDataLoader) CommitByRevision(revision string) (commit *Commit, err error) {
+	object, err := repoDataLoader.repo.RevparseSingle(revision)
+	if err != nil {
+		return
+	}
+	defer object.Free()
+
+	commitObject, err := object.Peel(git.ObjectCommit)
+	if err != nil {
+		return nil, fmt.Errorf("Revision %v does not resolve to a commit", revision)
+	}
+
+	rawCommit, err := commitObject.AsCommit()
+	if err != nil {
+		return
+	}
+
+	commit = repoDataLoader.cache.getCommit(rawCommit)
+
+	return
+}
+
+// Commit loads a commit for the provided oid (if it points to a commit)
+func (repoDataLoader *RepoDataLoader) Commit(oid *Oid) (commit *Commit, err error) {
+	object, err := repoDataLoader.repo.Lookup(oid.oid)
+	if err != nil {
+		log.Debugf("Error when attempting to lookup object with ID %v", oid)
+		return
+	}
+
+	var rawCommit *git.Commit
+
+	switch object.Type() {
+	case git.ObjectCommit:
+		rawCommit, err = object.AsCommit()
+		if err != nil {
+			log.Debugf("Error when attempting convert object with ID %v to commit", oid)
+			return
+		}
+	case git.ObjectTag:
+		var tag *git.Tag
+		tag, err = object.AsTag()
+		if err != nil {
+			log.Debugf("Error when attempting convert object with ID %v to tag", oid)
+			return
+		}
+
+		if tag.TargetType() != git.ObjectCommit {
+			err = fmt.Errorf("Tag with ID %v does not point to a commit", oid)
+			return
+		}
+
+		rawCommit, err = tag.Target().AsCommit()
+		if err != nil {
+			log.Debugf("Error when attempting convert tag with ID %v to commit", oid)
+			return
+		}
+	default:
+		log.Debugf("Unable to convert object with type %v and ID %v to a commit", object.Type().String(), oid)
+		return
+	}
+
+	commit = repoDataLoader.cache.getCommit(rawCommit)
+
+	return
+}
+
+// Diff generates a diff for the provided commit
+// Generation stops and the context error is returned if the context is cancelled
+func (repoDataLoader *RepoDataLoader) Diff(ctx context.Context, commit *Commit, diffOptions DiffOptions) (diff *Diff, err error) {
+	if commit.commit.ParentCount() > 1 {
+		return &Diff{}, nil
+	}
+
+	var commitTree, parentTree *git.Tree
+	if commitTree, err = commit.commit.Tree(); err != nil {
+		return
+	}
+	defer commitTree.Free()
+
+	if commit.commit.ParentCount() > 0 {
+		if parentTree, err = commit.commit.Parent(0).Tree(); err != nil {
+			return
+		}
+		defer parentTree.Free()
+	}
+
+	return repoDataLoader.diffTrees(ctx, parentTree, commitTree, diffOptions)
+}
+
+// DiffCommits generates a diff between the trees of the two provided commits
+// Generation stops and the context error is returned if the context is cancelled
+func (repoDataLoader *RepoDataLoader) DiffCommits(ctx context.Context, fromCommit, toCommit *Commit, diffOptions DiffOptions) (diff *Diff, err error) {
+	var fromTree, toTree *git.Tree
+	if fromTree, err = fromCommit.commit.Tree(); err != nil {
+		return
+	}
+	defer fromTree.Free()
+
+	if toTree, err = toCommit.commit.Tree(); err != nil {
+		return
+	}
+	defer toTree.Free()
+
+	return repoDataLoader.diffTrees(ctx, fromTree, toTree, diffOptions)
+}
+
+func (repoDataLoader *RepoDataLoader) diffTrees(ctx context.Context, oldTree, newTree *git.Tree, diffOptions DiffOptions) (diff *Diff, err error) {
+	diff = &Diff{}
+
+	options, err := git.DefaultDiffOptions()
+	if err != nil {
+		return
+	}
+
+	options.ContextLines = diffOptions.contextLines
+
+	options.NotifyCallback = func(diffSoFar *git.Diff, delta git.DiffDelta, matchedPathspec string) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		if len(diffOptions.excludePatterns) > 0 &&
+			matchesDiffExcludePattern(diffOptions.excludePatterns, delta.OldFile.Path) &&
+			matchesDiffExcludePattern(diffOptions.excludePatterns, delta.NewFile.Path) {
+			diff.excludedFiles++
+			return git.ErrDeltaSkip
+		}
+
+		return nil
+	}
+
+	commitDiff, err := repoDataLoader.repo.DiffTreeToTree(oldTree, newTree, &options)
+	if err != nil {
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			err = ctxErr
+		}
+
+		return
+	}
+	defer func() {
+		if e := commitDiff.Free(); e != nil {
+			log.Errorf("Error when freeing commit diff: %v", e)
+		}
+	}()
+
+	if err = ctx.Err(); err != nil {
+		return
+	} else if err = findSimilar(commitDiff, diffOptions); err != nil {
+		return
+	} else if err = ctx.Err(); err != nil {
+		return
+	}
+
+	stats, err := commitDiff.Stats()
+	if err != nil {
+		return
+	}
+
+	statsText, err := stats.String(git.DiffStatsFull, rdlDiffStatsCols)
+	if err != nil {
+		return
+	}
+
+	diff.stats.WriteString(statsText)
+
+	numDeltas, err := commitDiff.NumDeltas()
+	if err != nil {
+		return
+	}
+
+	var textconvCommands map[string]string
+	if diffOptions.textconv {
+		textconvCommands = repoDataLoader.textconvCommands(commitDiff, numDeltas)
+	}
+
+	// libgit2 diff objects are not thread safe, so patches are loaded from the diff serially.
+	// Loaded patches are completed in parallel so the first files of large diffs are available quickly
+	rawDiffFiles := make([]*rawDiffFile, numDeltas)
+	rawDiffFileLoaded := make([]chan error, numDeltas)
+	for index := range rawDiffFileLoaded {
+		rawDiffFileLoaded[index] = make(chan error, 1)
+	}
+
+	loadCtx, cancelLoad := context.WithCancel(ctx)
+	loadComplete := make(chan bool)
+	defer func() {
+		cancelLoad()
+		<-loadComplete
+	}()
+
+	go func() {
+		defer close(loadComplete)
+		var err error
+
+		for index := 0; index < numDeltas; index++ {
+			if err == nil {
+				if err = loadCtx.Err(); err == nil {
+					rawDiffFiles[index], err = repoDataLoader.loadRawDiffFile(commitDiff, index, textconvCommands)
+				}
+			}
+
+			rawDiffFileLoaded[index] <- err
+		}
+	}()
+
+	workdir := repoDataLoader.repo.Workdir()
+	diffFiles := make([]*DiffFile, numDeltas)
+
+	err = RunOrderedWorkers(ctx, numDeltas, runtime.NumCPU(), func(index int) (err error) {
+		if err = <-rawDiffFileLoaded[index]; err != nil {
+			return
+		}
+
+		diffFiles[index] = completeDiffFile(rawDiffFiles[index], workdir, int(diffOptions.contextLines))
+		return
+	}, func(start, end int) {
+		for _, diffFile := range diffFiles[start:end] {
+			diff.diffText.Write(diffFile.patch.Bytes())
+		}
+
+		diff.files = diffFiles[:end]
+
+		if diffOptions.onFilesGenerated != nil {
+			diffOptions.onFilesGenerated(diff, diffFiles[start:end])
+		}
+	})
+
+	return
+}
+
+// rawDiffFile is the data loaded from libgit2 which is required to generate the patch for a delta
+// Blob contents are only loaded when the patch is converted by a textconv command or is for an image
+type rawDiffFile struct {
+	diffFile        *DiffFile
+	delta           git.DiffDelta
+	isSummary       bool
+	patch           string
+	textconvCommand string
+	imageSummary    bool
+	oldBlobContents []byte
+	newBlobContents []byte
+}
+
+// loadRawDiffFile loads the patch for the delta at the provided index along with any blob contents
+// required to complete it. Submodule and LFS summaries are generated in place of the patch.
+// This method accesses the diff and repository and so must not be called concurrently
+func (repoDataLoader *RepoDataLoader) loadRawDiffFile(commitDiff *git.Diff, index int, textconvCommands map[string]string) (rawFile *rawDiffFile, err error) {
+	delta, err := commitDiff.GetDelta(index)
+	if err != nil {
+		return
+	}
+
+	rawFile = &rawDiffFile{
+		diffFile: &DiffFile{
+			status:  delta.Status,
+			oldPath: delta.OldFile.Path,
+			newPath: delta.NewFile.Path,
+		},
+		delta: delta,
+	}
+
+	if isSubmoduleDelta(delta) {
+		repoDataLoader.writeSubmoduleSummary(&rawFile.diffFile.patch, delta)
+		rawFile.isSummary = true
+		return
+	} else if oldPointer, newPointer, isLfsDelta := repoDataLoader.lfsPointers(delta); isLfsDelta {
+		writeLfsSummary(&rawFile.diffFile.patch, delta, oldPointer, newPointer)
+		rawFile.isSummary = true
+		return
+	}
+
+	patch, err := commitDiff.Patch(index)
+	if err != nil {
+		return
+	}
+
+	defer func() {
+		if err := patch.Free(); err != nil {
+			log.Errorf("Error when freeing patch: %v", err)
+		}
+	}()
+
+	if rawFile.patch, err = patch.String(); err != nil {
+		return
+	}
+
+	rawFile.textconvCommand = textconvCommands[delta.NewFile.Path]
+	rawFile.imageSummary = isImagePath(delta.NewFile.Path) && strings.Contains(rawFile.patch, "\nBinary files ")
+
+	if rawFile.textconvCommand != "" || rawFile.imageSummary {
+		rawFile.oldBlobContents = repoDataLoader.blobContents(delta.OldFile.Oid)
+		rawFile.newBlobContents = repoDataLoader.blobContents(delta.NewFile.Oid)
+	}
+
+	return
+}
+
+// completeDiffFile generates the patch for a loaded delta
+// Files with a textconv command are diffed after their contents have been converted by the command.
+// This function does not access libgit2 and is safe to call from multiple goroutines concurrently
+func completeDiffFile(rawFile *rawDiffFile, workdir string, contextLines int) *DiffFile {
+	diffFile := rawFile.diffFile
+	if rawFile.isSummary {
+		return diffFile
+	}
+
+	patchString := rawFile.patch
+	delta := rawFile.delta
+
+	if command := rawFile.textconvCommand; command != "" {
+		if textconvPatchString, textconvErr := textconvPatch(patchString, delta, rawFile.oldBlobContents, rawFile.newBlobContents,
+			command, workdir, contextLines); textconvErr != nil {
+			log.Errorf("Unable to apply textconv to %v: %v", delta.NewFile.Path, textconvErr)
+		} else {
+			patchString = textconvPatchString
+		}
+	}
+
+	diffFile.patch.WriteString(patchString)
+
+	if rawFile.imageSummary {
+		for _, line := range imageSummary(rawFile.oldBlobContents, rawFile.newBlobContents) {
+			diffFile.patch.WriteString(line + "\n")
+		}
+	}
+
+	return diffFile
+}
+
+// blobContents returns the contents of the blob with the provided id or nil if it does not exist
+func (repoDataLoader *RepoDataLoader) blobContents(oid *git.Oid) []byte {
+	if oid == nil || oid.IsZero() {
+		return nil
+	}
+
+	blob, err := repoDataLoader.repo.LookupBlob(oid)
+	if err != nil {
+		log.Debugf("Unable to load blob %v: %v", oid, err)
+		return nil
+	}
+	defer blob.Free()
+
+	return blob.Contents()
+}
+
+// FileContents returns the contents of the file at the provided path in the tree of the commit
+func (repoDataLoader *RepoDataLoader) FileContents(commit *Commit, filePath string) ([]byte, error) {
+	entryID := treeEntryID(commit.commit, filePath)
+	if entryID == nil {
+		return nil, fmt.Errorf("File %v does not exist in commit %v", filePath, commit.oid.ShortID())
+	}
+
+	blob, err := repoDataLoader.repo.LookupBlob(entryID)
+	if err != nil {
+		return nil, err
+	}
+	defer blob.Free()
+
+	return blob.Contents(), nil
+}
+
+// CodeOwnersFile returns the id and contents of the CODEOWNERS file in the tree of the commit
+// An empty id is returned if the tree contains no CODEOWNERS file
+func (repoDataLoader *RepoDataLoader) CodeOwnersFile(commit *Commit) (blobID string, contents []byte, err error) {
+	for _, codeOwnersPath := range codeOwnersPaths {
+		entryID := treeEntryID(commit.commit, codeOwnersPath)
+		if entryID == nil {
+			continue
+		}
+
+		blob, err := repoDataLoader.repo.LookupBlob(entryID)
+		if err != nil {
+			return "", nil, err
+		}
+		defer blob.Free()
+
+		return entryID.String(), blob.Contents(), nil
+	}
+
+	return
+}
+
+// ChangedPaths returns the paths of the files modified by the commit relative to its first parent
+func (repoDataLoader *RepoDataLoader) ChangedPaths(commit *Commit) (paths []string, err error) {
+	rawCommit := commit.commit
+	var parentTree, tree *git.Tree
+
+	if rawCommit.ParentCount() > 0 {
+		if parentTree, err = rawCommit.Parent(0).Tree(); err != nil {
+			return
+		}
+		defer parentTree.Free()
+	}
+
+	if tree, err = rawCommit.Tree(); err != nil {
+		return
+	}
+	defer tree.Free()
+
+	options, err := git.DefaultDiffOptions()
+	if err != nil {
+		return
+	}
+
+	commitDiff, err := repoDataLoader.repo.DiffTreeToTree(parentTree, tree, &options)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if e := commitDiff.Free(); e != nil {
+			log.Errorf("Error when freeing commit diff: %v", e)
+		}
+	}()
+
+	numDeltas, err := commitDiff.NumDeltas()
+	if err != nil {
+		return
+	}
+
+	for deltaIndex := 0; deltaIndex < numDeltas; deltaIndex++ {
+		var delta git.DiffDelta
+		if delta, err = commitDiff.GetDelta(deltaIndex); err != nil {
+			return
+		}
+
+		paths = append(paths, delta.NewFile.Path)
+	}
+
+	return
+}
+
+// Blame determines the commit which last modified each line of the file at the provided path in the tree of the commit
+func (repoDataLoader *RepoDataLoader) Blame(commit *Commit, filePath string) (hunks []*BlameHunk, err error) {
+	options, err := git.DefaultBlameOptions()
+	if err != nil {
+		return
+	}
+
+	options.NewestCommit = commit.oid.oid
+
+	blame, err := repoDataLoader.repo.BlameFile(filePath, &options)
+	if err != nil {
+		return
+	}
+	defer blame.Free()
+
+	hunkCount := blame.HunkCount()
+
+	for hunkIndex := 0; hunkIndex < hunkCount; hunkIndex++ {
+		var rawHunk git.BlameHunk
+		if rawHunk, err = blame.HunkByIndex(hunkIndex); err != nil {
+			return
+		}
+
+		hunk := &BlameHunk{
+			oid:       &Oid{oid: rawHunk.FinalCommitId},
+			startLine: uint(rawHunk.FinalStartLineNumber),
+			lineCount: uint(rawHunk.LinesInHunk),
+		}
+
+		if signature := rawHunk.FinalSignature; signature != nil {
+			hunk.author = signature.Name
+			hunk.email = signature.Email
+			hunk.when = signature.When
+		}
+
+		hunks = append(hunks, hunk)
+	}
+
+	return
+}
+
+// LineHistory traces the line range [startLine, endLine] of the file at the provided path
+// back through the first parent history of the provided commit and returns each commit
+// which modified the range
+func (repoDataLoader *RepoDataLoader) LineHistory(commit *Commit, filePath string, startLine, endLine uint) (entries []*LineHistoryEntry, err error) {
+	if startLine == 0 || endLine < startLine {
+		return nil, fmt.Errorf("Invalid line range %v-%v", startLine, endLine)
+	}
+
+	if treeEntryID(commit.commit, filePath) == nil {
+		return nil, fmt.Errorf("File %v does not exist in commit %v", filePath, commit.oid.ShortID())
+	}
+
+	rawCommit := commit.commit
+
+	for rawCommit != nil && len(entries) < rdlLineHistoryMax {
+		var parent *git.Commit
+		if rawCommit.ParentCount() > 0 {
+			parent = rawCommit.Parent(0)
+		}
+
+		entryID := treeEntryID(rawCommit, filePath)
+		parentEntryID := treeEntryID(parent, filePath)
+
+		if parentEntryID != nil && entryID.Equal(parentEntryID) {
+			rawCommit = parent
+			continue
+		}
+
+		var patch string
+		if patch, err = repoDataLoader.filePatch(parent, rawCommit, filePath); err != nil {
+			return
+		}
+
+		header, hunks := splitPatch(patch)
+		var hunkHeaders []diffHunkHeader
+
+		for _, hunk := range hunks {
+			hunkHeader, ok := parseDiffHunkHeader(hunk)
+			if !ok {
+				return nil, fmt.Errorf("Unable to parse hunk header: %v", hunk)
+			}
+
+			hunkHeaders = append(hunkHeaders, hunkHeader)
+		}
+
+		trace := traceLineRange(hunkHeaders, startLine, endLine)
+
+		if len(trace.hunkIndexes) > 0 {
+			entry := &LineHistoryEntry{
+				commit: repoDataLoader.cache.getCommit(rawCommit),
+			}
+
+			entry.patch.WriteString(header)
+
+			for _, hunkIndex := range trace.hunkIndexes {
+				entry.patch.WriteString(hunks[hunkIndex])
+			}
+
+			entries = append(entries, entry)
+		}
+
+		if parentEntryID == nil || !trace.existsInOld {
+			break
+		}
+
+		startLine, endLine = trace.oldStartLine, trace.oldEndLine
+		rawCommit = parent
+	}
+
+	return
+}
+
+// filePatch generates the patch for a single file between a commit and its parent
+func (repoDataLoader *RepoDataLoader) filePatch(parent, rawCommit *git.Commit, filePath string) (patchString string, err error) {
+	var parentTree, tree *git.Tree
+
+	if parent != nil {
+		if parentTree, err = parent.Tree(); err != nil {
+			return
+		}
+		defer parentTree.Free()
+	}
+
+	if tree, err = rawCommit.Tree(); err != nil {
+		return
+	}
+	defer tree.Free()
+
+	options, err := git.DefaultDiffOptions()
+	if err != nil {
+		return
+	}
+
+	options.Pathspec = []string{filePath}
+	options.Flags |= git.DiffDisablePathspecMatch
+
+	commitDiff, err := repoDataLoader.repo.DiffTreeToTree(parentTree, tree, &options)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if e := commitDiff.Free(); e != nil {
+			log.Errorf("Error when freeing commit diff: %v", e)
+		}
+	}()
+
+	numDeltas, err := commitDiff.NumDeltas()
+	if err != nil || numDeltas == 0 {
+		return
+	}
+
+	patch, err := commitDiff.Patch(0)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if e := patch.Free(); e != nil {
+			log.Errorf("Error when freeing patch: %v", e)
+		}
+	}()
+
+	return patch.String()
+}
+
+func isSubmoduleDelta(delta git.DiffDelta) bool {
+	return git.Filemode(delta.OldFile.Mode) == git.FilemodeCommit || git.Filemode(delta.NewFile.Mode) == git.FilemodeCommit
+}
+
+// writeSubmoduleSummary writes the old and new commit ids of a submodule along with
+// the commits added and removed between them if the submodule is checked out.
+// As with git diff --submodule=log, added commits are prefixed with > and removed commits with <
+func (repoDataLoader *RepoDataLoader) writeSubmoduleSummary(buffer *bytes.Buffer, delta git.DiffDelta) {
+	oldOid, newOid := delta.OldFile.Oid, delta.NewFile.Oid
+	submodulePath := delta.NewFile.Path
+
+	buffer.WriteString(fmt.Sprintf("diff --git a/%v b/%v\n", delta.OldFile.Path, submodulePath))
+
+	switch {
+	case git.Filemode(delta.OldFile.Mode) != git.FilemodeCommit:
+		buffer.WriteString(fmt.Sprintf("Submodule %v added at %v\n", submodulePath, newOid))
+		return
+	case git.Filemode(delta.NewFile.Mode) != git.FilemodeCommit:
+		buffer.WriteString(fmt.Sprintf("Submodule %v removed (was %v)\n", submodulePath, oldOid))
+		return
+	}
+
+	added, removed, err := repoDataLoader.submoduleCommitSummaries(submodulePath, oldOid, newOid)
+	if err != nil {
+		log.Debugf("Unable to load commits for submodule %v: %v", submodulePath, err)
+		buffer.WriteString(fmt.Sprintf("Submodule %v %v..%v\n", submodulePath, oldOid, newOid))
+		return
+	}
+
+	buffer.WriteString(submoduleSummaryHeader(submodulePath, oldOid.String(), newOid.String(), len(added), len(removed)))
+
+	for _, summary := range removed {
+		buffer.WriteString(fmt.Sprintf("  < %v\n", summary))
+	}
+
+	for _, summary := range added {
+		buffer.WriteString(fmt.Sprintf("  > %v\n", summary))
+	}
+}
+
+// submoduleSummaryHeader describes the change of a submodule pointer. The pointer is marked
+// as rewound if commits were only removed
+func submoduleSummaryHeader(submodulePath, oldID, newID string, addedNum, removedNum int) string {
+	if addedNum == 0 && removedNum > 0 {
+		return fmt.Sprintf("Submodule %v %v..%v (rewind)\n", submodulePath, oldID, newID)
+	}
+
+	return fmt.Sprintf("Submodule %v %v..%v\n", submodulePath, oldID, newID)
+}
+
+// lfsPointers returns the LFS pointers for each side of the delta
+// A delta is only considered an LFS delta if every file present in the delta is an LFS pointer
+func (repoDataLoader *RepoDataLoader) lfsPointers(delta git.DiffDelta) (oldPointer, newPointer *LfsPointer, isLfsDelta bool) {
+	oldPointer, oldIsPointer := repoDataLoader.lfsPointer(delta.OldFile)
+	newPointer, newIsPointer := repoDataLoader.lfsPointer(delta.NewFile)
+
+	if oldPointer == nil && newPointer == nil {
+		return
+	}
+
+	isLfsDelta = oldIsPointer && newIsPointer
+
+	return
+}
+
+// lfsPointer returns true if the file is either absent or an LFS pointer
+func (repoDataLoader *RepoDataLoader) lfsPointer(diffFile git.DiffFile) (lfsPointer *LfsPointer, isPointer bool) {
+	if diffFile.Oid == nil || diffFile.Oid.IsZero() {
+		return nil, true
+	}
+
+	if diffFile.Size >= lfsPointerMaxSize {
+		return
+	}
+
+	blob, err := repoDataLoader.repo.LookupBlob(diffFile.Oid)
+	if err != nil {
+		log.Debugf("Unable to load blob %v: %v", diffFile.Oid, err)
+		return
+	}
+	defer blob.Free()
+
+	if blob.Size() >= lfsPointerMaxSize {
+		return
+	}
+
+	return ParseLfsPointer(blob.Contents())
+}
+
+// writeLfsSummary writes the change in LFS object id and size in place of the diff of the pointer files
+func writeLfsSummary(buffer *bytes.Buffer, delta git.DiffDelta, oldPointer, newPointer *LfsPointer) {
+	buffer.WriteString(fmt.Sprintf("diff --git a/%v b/%v\n", delta.OldFile.Path, delta.NewFile.Path))
+
+	switch {
+	case oldPointer == nil:
+		buffer.WriteString(fmt.Sprintf("LFS object %v added\n", delta.NewFile.Path))
+		buffer.WriteString(fmt.Sprintf("  oid  %v\n", newPointer.oid))
+		buffer.WriteString(fmt.Sprintf("  size %v bytes\n", newPointer.size))
+	case newPointer == nil:
+		buffer.WriteString(fmt.Sprintf("LFS object %v removed\n", delta.OldFile.Path))
+		buffer.WriteString(fmt.Sprintf("  oid  %v\n", oldPointer.oid))
+		buffer.WriteString(fmt.Sprintf("  size %v bytes\n", oldPointer.size))
+	default:
+		buffer.WriteString(fmt.Sprintf("LFS object %v\n", delta.NewFile.Path))
+		buffer.WriteString(fmt.Sprintf("  oid  %v → %v\n", oldPointer.oid, newPointer.oid))
+		buffer.WriteString(fmt.Sprintf("  size %v → %v bytes\n", oldPointer.size, newPointer.size))
+	}
+}
+
+// submoduleCommitSummaries returns the summaries of the commits reachable from the new commit but
+// not the old commit (added) and the commits reachable from the old commit but not the new commit (removed).
+// Only removed commits are returned when the submodule pointer is rewound to an ancestor of the old commit
+func (repoDataLoader *RepoDataLoader) submoduleCommitSummaries(submodulePath string, oldOid, newOid *git.Oid) (added, removed []string, err error) {
+	submodule, err := repoDataLoader.repo.Submodules.Lookup(submodulePath)
+	if err != nil {
+		return
+	}
+	defer submodule.Free()
+
+	submoduleRepo, err := submodule.Open()
+	if err != nil {
+		return
+	}
+	defer submoduleRepo.Free()
+
+	if added, err = submoduleLog(submoduleRepo, newOid, oldOid); err != nil {
+		return
+	}
+
+	removed, err = submoduleLog(submoduleRepo, oldOid, newOid)
+
+	return
+}
+
+// submoduleLog returns the summaries of up to rdlSubmoduleLogMax commits reachable from tip but not from hidden
+func submoduleLog(submoduleRepo *git.Repository, tip, hidden *git.Oid) (summaries []string, err error) {
+	revWalk, err := submoduleRepo.Walk()
+	if err != nil {
+		return
+	}
+	defer revWalk.Free()
+
+	revWalk.Sorting(git.SortTopological)
+
+	if err = revWalk.Push(tip); err != nil {
+		return
+	}
+
+	if err = revWalk.Hide(hidden); err != nil {
+		return
+	}
+
+	err = revWalk.Iterate(func(commit *git.Commit) bool {
+		summaries = append(summaries, fmt.Sprintf("%v %v", commit.Id().String()[0:rdlShortOidLen], commit.Summary()))
+		return len(summaries) < rdlSubmoduleLogMax
+	})
+
+	return
+}
+
+func findSimilar(commitDiff *git.Diff, diffOptions DiffOptions) (err error) {
+	if !(diffOptions.detectRenames || diffOptions.detectCopies) {
+		return
+	}
+
+	findOptions, err := git.DefaultDiffFindOptions()
+	if err != nil {
+		return
+	}
+
+	findOptions.Flags = 0
+
+	if diffOptions.detectRenames {
+		findOptions.Flags |= git.DiffFindRenames
+	}
+	if diffOptions.detectCopies {
+		findOptions.Flags |= git.DiffFindCopies
+	}
+
+	findOptions.RenameThreshold = diffOptions.similarityThreshold
+	findOptions.CopyThreshold = diffOptions.similarityThreshold
+
+	return commitDiff.FindSimilar(&findOptions)
+}
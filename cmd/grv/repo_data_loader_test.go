@@ -0,0 +1,72 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestCheckedOutBranchNames(t *testing.T) {
+	messages := []string{
+		"checkout: moving from feature to master",
+		"commit: Fix crash on startup",
+		"checkout: moving from master to feature",
+		"checkout: moving from 1a2b3c4d to master",
+		"reset: moving to HEAD~1",
+		"checkout: moving from bugfix to 1a2b3c4d",
+	}
+
+	expectedBranchNames := []string{"feature", "master", "1a2b3c4d", "bugfix"}
+
+	if branchNames := checkedOutBranchNames(messages); !reflect.DeepEqual(branchNames, expectedBranchNames) {
+		t.Errorf("Branch names do not match expected value. Expected: %v, Actual: %v", expectedBranchNames, branchNames)
+	}
+}
+
+func TestAuthorDateOrderShowsChildrenBeforeParents(t *testing.T) {
+	baseTime := time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	// 0 is a merge of 1 and 2. 1 was authored before 3 but committed after it
+	authorTimes := []time.Time{
+		baseTime.Add(5 * time.Hour),
+		baseTime.Add(1 * time.Hour),
+		baseTime.Add(4 * time.Hour),
+		baseTime.Add(3 * time.Hour),
+		baseTime,
+	}
+	commitParents := [][]int{{1, 2}, {3}, {4}, {4}, {}}
+
+	order := authorDateOrder(len(authorTimes), func(commitIndex int) []int {
+		return commitParents[commitIndex]
+	}, func(commitIndex int) time.Time {
+		return authorTimes[commitIndex]
+	})
+
+	expectedOrder := []int{0, 2, 1, 3, 4}
+
+	if !reflect.DeepEqual(order, expectedOrder) {
+		t.Errorf("Order does not match expected value. Expected: %v, Actual: %v", expectedOrder, order)
+	}
+}
+
+func TestSubmoduleSummaryHeaderMarksRewinds(t *testing.T) {
+	var headerTests = []struct {
+		addedNum       int
+		removedNum     int
+		expectedHeader string
+	}{
+		{addedNum: 2, removedNum: 0, expectedHeader: "Submodule vendor/lib 3b18e51..a9c2f1d\n"},
+		{addedNum: 0, removedNum: 2, expectedHeader: "Submodule vendor/lib 3b18e51..a9c2f1d (rewind)\n"},
+		{addedNum: 1, removedNum: 1, expectedHeader: "Submodule vendor/lib 3b18e51..a9c2f1d\n"},
+		{addedNum: 0, removedNum: 0, expectedHeader: "Submodule vendor/lib 3b18e51..a9c2f1d\n"},
+	}
+
+	for _, headerTest := range headerTests {
+		header := submoduleSummaryHeader("vendor/lib", "3b18e51", "a9c2f1d", headerTest.addedNum, headerTest.removedNum)
+
+		if header != headerTest.expectedHeader {
+			t.Errorf("Header does not match expected value for %v added and %v removed commits. Expected: %q, Actual: %q",
+				headerTest.addedNum, headerTest.removedNum, headerTest.expectedHeader, header)
+		}
+	}
+}
@@ -0,0 +1,168 @@
+package main
+
+import (
+	"testing"
+
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+func newTestOid(t *testing.T, oidString string) *Oid {
+	oid, err := git.NewOid(oidString)
+	if err != nil {
+		t.Fatalf("Unable to create oid: %v", err)
+	}
+
+	return &Oid{oid: oid}
+}
+
+func TestMultiRefWalksIdentifyWalksIndependentOfOrder(t *testing.T) {
+	first := newTestOid(t, "3b18e512dba79e4c8300dd08aeb37f8e728b8dad")
+	second := newTestOid(t, "4b825dc642cb6eb9a060e54bf8d69288fbee4904")
+	third := newTestOid(t, "5c4ee0a9b7a9e37d4dd6e0a2a5e4a8de3ab2e1f6")
+
+	multiRefWalks := newMultiRefWalks()
+	walk := &multiRefWalk{tips: []*Oid{first, second}}
+	oid := multiRefWalks.oid(walk, second)
+
+	if oid == first || oid == second {
+		t.Errorf("Expected multi ref oid to be distinct from the oids of its tips")
+	}
+
+	if oid.String() != second.String() {
+		t.Errorf("Multi ref oid does not match expected value. Expected: %v, Actual: %v", second, oid)
+	}
+
+	if reorderedOid := multiRefWalks.oid(&multiRefWalk{tips: []*Oid{second, first}}, second); reorderedOid != oid {
+		t.Errorf("Expected the same oid to be returned for reordered tips")
+	}
+
+	if otherOid := multiRefWalks.oid(&multiRefWalk{tips: []*Oid{first, third}}, third); otherOid == oid {
+		t.Errorf("Expected a different oid to be returned for a different set of tips")
+	}
+
+	if hiddenOid := multiRefWalks.oid(&multiRefWalk{tips: []*Oid{first, second}, hidden: []*Oid{third}}, second); hiddenOid == oid {
+		t.Errorf("Expected a different oid to be returned when commits are hidden")
+	}
+
+	if storedWalk := multiRefWalks.walk(oid); storedWalk != walk {
+		t.Errorf("Expected the walk identified by the oid to be returned")
+	}
+
+	if refWalk := multiRefWalks.walk(first); len(refWalk.tips) != 1 || refWalk.tips[0] != first || len(refWalk.hidden) != 0 {
+		t.Errorf("Expected a ref oid to be a walk of its own history")
+	}
+}
+
+func TestDecoratedCommitFilterMatchesCommitsPointedToByRefs(t *testing.T) {
+	repoData := NewRepositoryData(NewRepoDataLoader(nil), nil, nil)
+
+	branchCommit := &Commit{oid: newTestOid(t, "3b18e512dba79e4c8300dd08aeb37f8e728b8dad")}
+	tagCommit := &Commit{oid: newTestOid(t, "4b825dc642cb6eb9a060e54bf8d69288fbee4904")}
+	headCommit := &Commit{oid: newTestOid(t, "5c4ee0a9b7a9e37d4dd6e0a2a5e4a8de3ab2e1f6")}
+	undecoratedCommit := &Commit{oid: newTestOid(t, "6d5ff1bac8bae48e5ee7f1b3b6f5b9ef4bc3f2a7")}
+
+	repoData.commitRefSet.addBranchForCommit(branchCommit, &Branch{name: "master", oid: branchCommit.oid})
+	repoData.commitRefSet.addTagForCommit(tagCommit, &Tag{name: "v1.0", oid: tagCommit.oid})
+	repoData.head = headCommit.oid
+
+	commitFilter := repoData.DecoratedCommitFilter()
+
+	for _, commit := range []*Commit{branchCommit, tagCommit, headCommit} {
+		if !commitFilter.MatchesFilter(commit) {
+			t.Errorf("Expected decorated commit %v to match filter", commit.oid)
+		}
+	}
+
+	if commitFilter.MatchesFilter(undecoratedCommit) {
+		t.Errorf("Expected undecorated commit %v not to match filter", undecoratedCommit.oid)
+	}
+}
+
+func TestFormatDescription(t *testing.T) {
+	var descriptionTests = []struct {
+		tags                []*Tag
+		distance            uint
+		expectedDescription string
+	}{
+		{
+			tags:                []*Tag{{name: "v1.0"}},
+			expectedDescription: "v1.0",
+		},
+		{
+			tags:                []*Tag{{name: "v1.0"}},
+			distance:            3,
+			expectedDescription: "v1.0-3-gabc1234",
+		},
+		{
+			tags:                []*Tag{{name: "v1.0"}, {name: "v1.1"}, {name: "v0.9"}},
+			distance:            12,
+			expectedDescription: "v1.1-12-gabc1234",
+		},
+	}
+
+	for _, descriptionTest := range descriptionTests {
+		if description := formatDescription(descriptionTest.tags, descriptionTest.distance, "abc1234"); description != descriptionTest.expectedDescription {
+			t.Errorf("Description does not match expected value. Expected: %v, Actual: %v", descriptionTest.expectedDescription, description)
+		}
+	}
+}
+
+func TestAutosquashTargetsAreFoundInHistoryOrder(t *testing.T) {
+	history := []string{
+		"fixup! Add parser",
+		"Update docs",
+		"squash! Add lexer",
+		"Add parser",
+		"Add lexer",
+		"Initial commit",
+	}
+
+	targets := newAutosquashTargets()
+	var oldestTarget string
+
+	for _, summary := range history {
+		if targets.visit(summary) {
+			oldestTarget = summary
+		}
+
+		if targets.complete() {
+			break
+		}
+	}
+
+	if !targets.complete() {
+		t.Fatalf("Expected all autosquash targets to be found")
+	}
+
+	if expectedOldestTarget := "Add lexer"; oldestTarget != expectedOldestTarget {
+		t.Errorf("Oldest target does not match expected value. Expected: %v, Actual: %v", expectedOldestTarget, oldestTarget)
+	}
+}
+
+func TestSetBranchesRemovesStaleBranches(t *testing.T) {
+	commitRefSet := newCommitRefSet()
+
+	oldCommit := &Commit{oid: newTestOid(t, "3b18e512dba79e4c8300dd08aeb37f8e728b8dad")}
+	taggedCommit := &Commit{oid: newTestOid(t, "4b825dc642cb6eb9a060e54bf8d69288fbee4904")}
+	newCommit := &Commit{oid: newTestOid(t, "5c4ee0a9b7a9e37d4dd6e0a2a5e4a8de3ab2e1f6")}
+
+	commitRefSet.addBranchForCommit(oldCommit, &Branch{name: "master", oid: oldCommit.oid})
+	commitRefSet.addBranchForCommit(taggedCommit, &Branch{name: "feature", oid: taggedCommit.oid})
+	commitRefSet.addTagForCommit(taggedCommit, &Tag{name: "v1.0", oid: taggedCommit.oid})
+
+	commitRefSet.setBranches(map[*Oid][]*Branch{
+		newCommit.oid: {{name: "master", oid: newCommit.oid}},
+	})
+
+	if _, ok := commitRefSet.commitRefs[oldCommit.oid]; ok {
+		t.Errorf("Expected commit no longer referenced by any ref to be removed")
+	}
+
+	if commitRefs := commitRefSet.refsForCommit(taggedCommit); len(commitRefs.branches) != 0 || len(commitRefs.tags) != 1 {
+		t.Errorf("Expected tagged commit to retain only its tag. Branches: %v, Tags: %v", len(commitRefs.branches), len(commitRefs.tags))
+	}
+
+	if commitRefs := commitRefSet.refsForCommit(newCommit); len(commitRefs.branches) != 1 || commitRefs.branches[0].name != "master" {
+		t.Errorf("Expected branch master to reference the new commit")
+	}
+}
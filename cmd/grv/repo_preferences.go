@@ -0,0 +1,214 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	rpDefaultDataHomeDir = "/.local/share"
+	rpGrvDataDir         = "/grv"
+	rpRepositoriesDir    = "/repositories"
+	rpRepository         = "repository"
+	rpBranch             = "branch"
+	rpExpanded           = "expanded"
+	rpCollapsed          = "collapsed"
+	rpPinned             = "pinned"
+	rpSet                = "set"
+)
+
+// repoPreferenceVariables are the config variables whose values are remembered for each repository
+// when they are changed while GRV is running
+var repoPreferenceVariables = []ConfigVariable{
+	CfDiffContext,
+	CfDiffRenames,
+	CfDiffCopies,
+	CfDiffSimilarityThreshold,
+	CfDiffExclude,
+	CfDiffTextconv,
+	CfWhitespaceHighlight,
+	CfInvisibles,
+	CfMessagePreview,
+	CfCommitOrder,
+	CfCommitViewColumns,
+	CfLayout,
+}
+
+// RepoPreferences are the view preferences remembered for a repository.
+// They are stored outside of the grvrc file in the grv data directory so that
+// each repository can be displayed as it was when it was last viewed
+type RepoPreferences struct {
+	repoPath         string
+	branch           string
+	expandedRefLists map[string]bool
+	pinnedRefs       []string
+	variables        map[ConfigVariable]string
+}
+
+// NewRepoPreferences creates an empty set of preferences for the repository
+func NewRepoPreferences(repoPath string) *RepoPreferences {
+	return &RepoPreferences{
+		repoPath:         repoPath,
+		expandedRefLists: make(map[string]bool),
+		variables:        make(map[ConfigVariable]string),
+	}
+}
+
+// repoPreferencesDir returns the directory repository preferences are stored in
+// This is $XDG_DATA_HOME/grv/repositories or ~/.local/share/grv/repositories if XDG_DATA_HOME is not set
+func repoPreferencesDir() (dir string, ok bool) {
+	dataHomeDir, dataHomeDirSet := os.LookupEnv("XDG_DATA_HOME")
+
+	if !dataHomeDirSet || dataHomeDir == "" {
+		home, homeSet := os.LookupEnv("HOME")
+		if !homeSet {
+			return
+		}
+
+		dataHomeDir = home + rpDefaultDataHomeDir
+	}
+
+	return dataHomeDir + rpGrvDataDir + rpRepositoriesDir, true
+}
+
+// repoPreferencesPath returns the file the preferences of the repository are stored in
+// Files are named after a hash of the repository path
+func repoPreferencesPath(dir, repoPath string) string {
+	hash := sha1.Sum([]byte(repoPath))
+	return filepath.Join(dir, hex.EncodeToString(hash[:]))
+}
+
+// LoadRepoPreferences reads the preferences of the repository from the provided directory
+// Empty preferences are returned if none have been saved
+func LoadRepoPreferences(dir, repoPath string) (*RepoPreferences, error) {
+	file, err := os.Open(repoPreferencesPath(dir, repoPath))
+	if os.IsNotExist(err) {
+		return NewRepoPreferences(repoPath), nil
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return parseRepoPreferences(repoPath, file)
+}
+
+// parseRepoPreferences parses preferences consisting of lines of the form "name value"
+// Preferences saved for a different repository are ignored
+func parseRepoPreferences(repoPath string, reader io.Reader) (*RepoPreferences, error) {
+	preferences := NewRepoPreferences(repoPath)
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		fields := strings.SplitN(scanner.Text(), " ", 2)
+		if len(fields) != 2 {
+			continue
+		}
+
+		name, value := fields[0], fields[1]
+
+		switch name {
+		case rpRepository:
+			if value != repoPath {
+				return NewRepoPreferences(repoPath), nil
+			}
+		case rpBranch:
+			preferences.branch = value
+		case rpExpanded:
+			preferences.expandedRefLists[value] = true
+		case rpCollapsed:
+			preferences.expandedRefLists[value] = false
+		case rpPinned:
+			preferences.pinnedRefs = append(preferences.pinnedRefs, value)
+		case rpSet:
+			variableFields := strings.SplitN(value, " ", 2)
+			if len(variableFields) == 2 && isRepoPreferenceVariable(ConfigVariable(variableFields[0])) {
+				preferences.variables[ConfigVariable(variableFields[0])] = variableFields[1]
+			}
+		}
+	}
+
+	return preferences, scanner.Err()
+}
+
+func isRepoPreferenceVariable(configVariable ConfigVariable) bool {
+	for _, preferenceVariable := range repoPreferenceVariables {
+		if preferenceVariable == configVariable {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Save writes the preferences to the provided directory
+func (preferences *RepoPreferences) Save(dir string) (err error) {
+	if err = os.MkdirAll(dir, 0755); err != nil {
+		return
+	}
+
+	return ioutil.WriteFile(repoPreferencesPath(dir, preferences.repoPath), preferences.bytes(), 0644)
+}
+
+func (preferences *RepoPreferences) bytes() []byte {
+	var buffer bytes.Buffer
+
+	fmt.Fprintf(&buffer, "%v %v\n", rpRepository, preferences.repoPath)
+
+	if preferences.branch != "" {
+		fmt.Fprintf(&buffer, "%v %v\n", rpBranch, preferences.branch)
+	}
+
+	var refListNames []string
+	for refListName := range preferences.expandedRefLists {
+		refListNames = append(refListNames, refListName)
+	}
+
+	sort.Strings(refListNames)
+
+	for _, refListName := range refListNames {
+		if preferences.expandedRefLists[refListName] {
+			fmt.Fprintf(&buffer, "%v %v\n", rpExpanded, refListName)
+		} else {
+			fmt.Fprintf(&buffer, "%v %v\n", rpCollapsed, refListName)
+		}
+	}
+
+	for _, refName := range preferences.pinnedRefs {
+		fmt.Fprintf(&buffer, "%v %v\n", rpPinned, refName)
+	}
+
+	buffer.WriteString(preferences.ConfigCommands())
+
+	return buffer.Bytes()
+}
+
+// ConfigCommands returns the set commands which apply the remembered config variable values
+func (preferences *RepoPreferences) ConfigCommands() string {
+	var buffer bytes.Buffer
+
+	for _, configVariable := range repoPreferenceVariables {
+		if value, ok := preferences.variables[configVariable]; ok {
+			fmt.Fprintf(&buffer, "%v %v %v\n", rpSet, configVariable, value)
+		}
+	}
+
+	return buffer.String()
+}
+
+// RecordVariables remembers the values of the preference variables which were set while GRV was running
+func (preferences *RepoPreferences) RecordVariables(values []ConfigVariableValue) {
+	for _, value := range values {
+		if value.source == CvsRuntime && isRepoPreferenceVariable(value.variable) {
+			preferences.variables[value.variable] = value.value
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRepoPreferencesAreSavedAndLoaded(t *testing.T) {
+	dir, err := ioutil.TempDir("", "grv-preferences")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	repoPath := "/src/grv/.git/"
+
+	preferences := NewRepoPreferences(repoPath)
+	preferences.branch = "feature/layout"
+	preferences.expandedRefLists["Branches"] = false
+	preferences.expandedRefLists["Remote Branches"] = true
+	preferences.pinnedRefs = []string{"master", "origin/release"}
+	preferences.RecordVariables([]ConfigVariableValue{
+		{variable: CfDiffContext, value: "8", source: CvsRuntime},
+		{variable: CfLayout, value: LayoutColumns, source: CvsRuntime},
+		{variable: CfCommitViewColumns, value: `"date summary"`, source: CvsRuntime},
+		{variable: CfMessagePreview, value: "true", source: CvsGrvrc},
+		{variable: CfTheme, value: "cold", source: CvsRuntime},
+	})
+
+	if err = preferences.Save(dir); err != nil {
+		t.Fatalf("Save failed with error %v", err)
+	}
+
+	loadedPreferences, err := LoadRepoPreferences(dir, repoPath)
+	if err != nil {
+		t.Fatalf("LoadRepoPreferences failed with error %v", err)
+	}
+
+	if !reflect.DeepEqual(preferences, loadedPreferences) {
+		t.Errorf("Loaded preferences do not match saved preferences. Expected: %v, Actual: %v", preferences, loadedPreferences)
+	}
+
+	expectedCommands := "set diffContext 8\nset commitview.columns \"date summary\"\nset layout columns\n"
+	if commands := loadedPreferences.ConfigCommands(); commands != expectedCommands {
+		t.Errorf("Config commands do not match. Expected: %q, Actual: %q", expectedCommands, commands)
+	}
+}
+
+func TestRepoPreferencesAreEmptyForUnknownRepository(t *testing.T) {
+	dir, err := ioutil.TempDir("", "grv-preferences")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	preferences, err := LoadRepoPreferences(dir, "/src/grv/.git/")
+	if err != nil {
+		t.Fatalf("LoadRepoPreferences failed with error %v", err)
+	}
+
+	if !reflect.DeepEqual(preferences, NewRepoPreferences("/src/grv/.git/")) {
+		t.Errorf("Expected empty preferences but found %v", preferences)
+	}
+}
+
+func TestRepoPreferencesForDifferentRepositoryAreIgnored(t *testing.T) {
+	preferences, err := parseRepoPreferences("/src/grv/.git/", strings.NewReader("repository /src/other/.git/\nbranch master\nset diffContext 8\n"))
+	if err != nil {
+		t.Fatalf("parseRepoPreferences failed with error %v", err)
+	}
+
+	if preferences.branch != "" || len(preferences.variables) != 0 {
+		t.Errorf("Expected preferences of a different repository to be ignored but found %v", preferences)
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"regexp"
+)
+
+// The characters the scroll bar is drawn with
+const (
+	scrollBarThumbChar = '█'
+	scrollBarMarkChar  = '▪'
+)
+
+// ScrollBarMarkType is the type of a line marked in the scroll bar
+type ScrollBarMarkType int
+
+// The set of scroll bar mark types in ascending order of precedence
+const (
+	SbmNone ScrollBarMarkType = iota
+	SbmHunk
+	SbmSearchMatch
+)
+
+var scrollBarMarkThemeComponentID = map[ScrollBarMarkType]ThemeComponentID{
+	SbmHunk:        CmpAllviewScrollBarHunk,
+	SbmSearchMatch: CmpAllviewScrollBarSearchMatch,
+}
+
+// scrollBarCell is the content of a single row of the scroll bar
+type scrollBarCell struct {
+	thumb bool
+	mark  ScrollBarMarkType
+}
+
+// ScrollBar is drawn over the right border of a view. The thumb shows the position of the
+// displayed rows within all lines of the view and marks show the locations of lines of interest
+type ScrollBar struct {
+	lineNum           uint
+	viewStartRowIndex uint
+	rows              uint
+	marks             map[uint]ScrollBarMarkType
+}
+
+// NewScrollBar creates a scroll bar for a view with lineNum lines which displays rows lines from viewStartRowIndex
+func NewScrollBar(lineNum, viewStartRowIndex, rows uint, marks map[uint]ScrollBarMarkType) *ScrollBar {
+	return &ScrollBar{
+		lineNum:           lineNum,
+		viewStartRowIndex: viewStartRowIndex,
+		rows:              rows,
+		marks:             marks,
+	}
+}
+
+// cells maps the lines of the view onto a track of the provided length
+func (scrollBar *ScrollBar) cells(trackLength uint) (cells []scrollBarCell) {
+	if trackLength == 0 || scrollBar.lineNum <= scrollBar.rows {
+		return
+	}
+
+	cells = make([]scrollBarCell, trackLength)
+	lineNum := scrollBar.lineNum
+
+	thumbStart := scrollBar.viewStartRowIndex * trackLength / lineNum
+	thumbEnd := ((scrollBar.viewStartRowIndex+scrollBar.rows)*trackLength + lineNum - 1) / lineNum
+
+	if scrollBar.viewStartRowIndex+scrollBar.rows >= lineNum || thumbEnd > trackLength {
+		thumbEnd = trackLength
+	}
+
+	if thumbEnd <= thumbStart {
+		thumbEnd = thumbStart + 1
+	}
+
+	for cellIndex := thumbStart; cellIndex < thumbEnd && cellIndex < trackLength; cellIndex++ {
+		cells[cellIndex].thumb = true
+	}
+
+	for lineIndex, mark := range scrollBar.marks {
+		if lineIndex >= lineNum {
+			continue
+		}
+
+		cell := &cells[lineIndex*trackLength/lineNum]
+		if mark > cell.mark {
+			cell.mark = mark
+		}
+	}
+
+	return
+}
+
+// Render draws the scroll bar over the right border of the window if scrollbar is set
+// Nothing is drawn when all lines of the view are displayed
+func (scrollBar *ScrollBar) Render(win RenderWindow, config Config) (err error) {
+	if !config.GetBool(CfScrollBar) || config.GetBool(CfScreenReader) || win.Rows() < 3 || win.Cols() < 3 {
+		return
+	}
+
+	for cellIndex, cell := range scrollBar.cells(win.Rows() - 2) {
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(uint(cellIndex)+1, 1); err != nil {
+			return
+		}
+
+		lineBuilder.cellIndex = win.Cols() - 1
+		lineBuilder.column = lineBuilder.cellIndex + 1
+
+		if cell.mark != SbmNone {
+			lineBuilder.AppendWithStyle(scrollBarMarkThemeComponentID[cell.mark], "%c", scrollBarMarkChar)
+		} else if cell.thumb {
+			lineBuilder.AppendWithStyle(CmpAllviewScrollBarThumb, "%c", scrollBarThumbChar)
+		}
+	}
+
+	return
+}
+
+// scrollBarMarks records the marked lines of a view. As lines are often appended to a view while it
+// is loading, only new lines are examined on each update. All lines are examined again when the
+// source of the lines or the search pattern changes or the number of lines decreases
+type scrollBarMarks struct {
+	source  interface{}
+	pattern string
+	lineNum uint
+	marks   map[uint]ScrollBarMarkType
+}
+
+// Update examines the lines added since the previous update and returns the marked lines
+func (scrollBarMarks *scrollBarMarks) Update(source interface{}, pattern string, lineNum uint,
+	markLine func(lineIndex uint, searchRegex *regexp.Regexp) ScrollBarMarkType) map[uint]ScrollBarMarkType {
+	if scrollBarMarks.marks == nil || source != scrollBarMarks.source || pattern != scrollBarMarks.pattern || lineNum < scrollBarMarks.lineNum {
+		scrollBarMarks.source = source
+		scrollBarMarks.pattern = pattern
+		scrollBarMarks.lineNum = 0
+		scrollBarMarks.marks = make(map[uint]ScrollBarMarkType)
+	}
+
+	var searchRegex *regexp.Regexp
+	if pattern != "" {
+		searchRegex, _ = regexp.Compile(pattern)
+	}
+
+	for lineIndex := scrollBarMarks.lineNum; lineIndex < lineNum; lineIndex++ {
+		if mark := markLine(lineIndex, searchRegex); mark != SbmNone {
+			scrollBarMarks.marks[lineIndex] = mark
+		}
+	}
+
+	scrollBarMarks.lineNum = lineNum
+
+	return scrollBarMarks.marks
+}
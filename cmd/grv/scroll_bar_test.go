@@ -0,0 +1,104 @@
+package main
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestScrollBarIsEmptyWhenAllLinesAreDisplayed(t *testing.T) {
+	if cells := NewScrollBar(10, 0, 10, nil).cells(10); cells != nil {
+		t.Errorf("Expected no scroll bar cells but found %v", cells)
+	}
+}
+
+func TestScrollBarThumbPosition(t *testing.T) {
+	var thumbTests = []struct {
+		lineNum           uint
+		viewStartRowIndex uint
+		rows              uint
+		trackLength       uint
+		expectedThumb     []uint
+	}{
+		{lineNum: 100, viewStartRowIndex: 0, rows: 10, trackLength: 10, expectedThumb: []uint{0}},
+		{lineNum: 100, viewStartRowIndex: 45, rows: 10, trackLength: 10, expectedThumb: []uint{4, 5}},
+		{lineNum: 100, viewStartRowIndex: 90, rows: 10, trackLength: 10, expectedThumb: []uint{9}},
+		{lineNum: 20, viewStartRowIndex: 5, rows: 10, trackLength: 10, expectedThumb: []uint{2, 3, 4, 5, 6, 7}},
+		{lineNum: 10000, viewStartRowIndex: 9990, rows: 8, trackLength: 8, expectedThumb: []uint{7}},
+	}
+
+	for _, thumbTest := range thumbTests {
+		var thumb []uint
+
+		for cellIndex, cell := range NewScrollBar(thumbTest.lineNum, thumbTest.viewStartRowIndex, thumbTest.rows, nil).cells(thumbTest.trackLength) {
+			if cell.thumb {
+				thumb = append(thumb, uint(cellIndex))
+			}
+		}
+
+		if !reflect.DeepEqual(thumb, thumbTest.expectedThumb) {
+			t.Errorf("Thumb does not match expected value for view starting at %v of %v lines. Expected: %v, Actual: %v",
+				thumbTest.viewStartRowIndex, thumbTest.lineNum, thumbTest.expectedThumb, thumb)
+		}
+	}
+}
+
+func TestScrollBarMarksWithHighestPrecedenceAreDisplayed(t *testing.T) {
+	marks := map[uint]ScrollBarMarkType{
+		5:  SbmHunk,
+		52: SbmHunk,
+		55: SbmSearchMatch,
+		99: SbmSearchMatch,
+	}
+
+	cells := NewScrollBar(100, 0, 10, marks).cells(10)
+	expectedMarks := []ScrollBarMarkType{SbmHunk, SbmNone, SbmNone, SbmNone, SbmNone, SbmSearchMatch, SbmNone, SbmNone, SbmNone, SbmSearchMatch}
+
+	for cellIndex, cell := range cells {
+		if cell.mark != expectedMarks[cellIndex] {
+			t.Errorf("Mark of cell %v does not match expected value. Expected: %v, Actual: %v", cellIndex, expectedMarks[cellIndex], cell.mark)
+		}
+	}
+}
+
+func TestScrollBarMarksOnlyExamineNewLines(t *testing.T) {
+	lines := []string{"@@ -1 +1 @@", "-a", "+b", "@@ -5 +5 @@", "+b"}
+	var examined []uint
+
+	markLine := func(lineIndex uint, searchRegex *regexp.Regexp) ScrollBarMarkType {
+		examined = append(examined, lineIndex)
+
+		if searchRegex != nil && searchRegex.MatchString(lines[lineIndex]) {
+			return SbmSearchMatch
+		} else if lines[lineIndex][0] == '@' {
+			return SbmHunk
+		}
+
+		return SbmNone
+	}
+
+	source := &struct{}{}
+	var marks scrollBarMarks
+
+	marks.Update(source, "", 3, markLine)
+	result := marks.Update(source, "", 5, markLine)
+
+	if expectedExamined := []uint{0, 1, 2, 3, 4}; !reflect.DeepEqual(examined, expectedExamined) {
+		t.Errorf("Examined lines do not match expected value. Expected: %v, Actual: %v", expectedExamined, examined)
+	}
+
+	if expectedMarks := map[uint]ScrollBarMarkType{0: SbmHunk, 3: SbmHunk}; !reflect.DeepEqual(result, expectedMarks) {
+		t.Errorf("Marks do not match expected value. Expected: %v, Actual: %v", expectedMarks, result)
+	}
+
+	examined = nil
+	result = marks.Update(source, "b", 5, markLine)
+
+	if expectedExamined := []uint{0, 1, 2, 3, 4}; !reflect.DeepEqual(examined, expectedExamined) {
+		t.Errorf("Expected all lines to be examined after the search pattern changed. Expected: %v, Actual: %v", expectedExamined, examined)
+	}
+
+	if expectedMarks := map[uint]ScrollBarMarkType{0: SbmHunk, 2: SbmSearchMatch, 3: SbmHunk, 4: SbmSearchMatch}; !reflect.DeepEqual(result, expectedMarks) {
+		t.Errorf("Marks do not match expected value. Expected: %v, Actual: %v", expectedMarks, result)
+	}
+}
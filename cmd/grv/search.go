@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"runtime"
@@ -87,11 +88,17 @@ func NewSearch(direction SearchDirection, pattern string, inputProvidor SearchIn
 
 // FindNext looks for the next match starting from the line index provided
 func (search *Search) FindNext(startLineIndex uint) (matchedLineIndex uint, found bool) {
+	return search.FindNextContext(context.Background(), startLineIndex)
+}
+
+// FindNextContext looks for the next match starting from the line index provided
+// The search stops without finding a match if the context is cancelled
+func (search *Search) FindNextContext(ctx context.Context, startLineIndex uint) (matchedLineIndex uint, found bool) {
 	switch search.direction {
 	case SdForward:
-		return search.findNext(startLineIndex)
+		return search.findNext(ctx, startLineIndex)
 	case SdBackward:
-		return search.findPrev(startLineIndex)
+		return search.findPrev(ctx, startLineIndex)
 	}
 
 	panic(fmt.Sprintf("Invalid search direction: %v", search.direction))
@@ -99,17 +106,23 @@ func (search *Search) FindNext(startLineIndex uint) (matchedLineIndex uint, foun
 
 // FindPrev looks for the next match in the reverse direction starting from the line index provided
 func (search *Search) FindPrev(startLineIndex uint) (matchedLineIndex uint, found bool) {
+	return search.FindPrevContext(context.Background(), startLineIndex)
+}
+
+// FindPrevContext looks for the next match in the reverse direction starting from the line index provided
+// The search stops without finding a match if the context is cancelled
+func (search *Search) FindPrevContext(ctx context.Context, startLineIndex uint) (matchedLineIndex uint, found bool) {
 	switch search.direction {
 	case SdForward:
-		return search.findPrev(startLineIndex)
+		return search.findPrev(ctx, startLineIndex)
 	case SdBackward:
-		return search.findNext(startLineIndex)
+		return search.findNext(ctx, startLineIndex)
 	}
 
 	panic(fmt.Sprintf("Invalid search direction: %v", search.direction))
 }
 
-func (search *Search) findNext(startLineIndex uint) (matchedLineIndex uint, found bool) {
+func (search *Search) findNext(ctx context.Context, startLineIndex uint) (matchedLineIndex uint, found bool) {
 	currentLineIndex := startLineIndex + 1
 	wrapped := false
 
@@ -130,6 +143,10 @@ func (search *Search) findNext(startLineIndex uint) (matchedLineIndex uint, foun
 		currentLineIndex++
 
 		if currentLineIndex%searchMaxIterationsBeforeYeild == 0 {
+			if ctx.Err() != nil {
+				break
+			}
+
 			runtime.Gosched()
 		}
 	}
@@ -137,7 +154,7 @@ func (search *Search) findNext(startLineIndex uint) (matchedLineIndex uint, foun
 	return
 }
 
-func (search *Search) findPrev(startLineIndex uint) (matchedLineIndex uint, found bool) {
+func (search *Search) findPrev(ctx context.Context, startLineIndex uint) (matchedLineIndex uint, found bool) {
 	currentLineIndex := startLineIndex
 	wrapped := false
 
@@ -163,6 +180,10 @@ func (search *Search) findPrev(startLineIndex uint) (matchedLineIndex uint, foun
 		}
 
 		if currentLineIndex%searchMaxIterationsBeforeYeild == 0 {
+			if ctx.Err() != nil {
+				break
+			}
+
 			runtime.Gosched()
 		}
 	}
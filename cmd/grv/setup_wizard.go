@@ -0,0 +1,216 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	swGrvrcHeader = "# Generated by the GRV setup wizard"
+)
+
+// setupStep is a single choice made in the setup wizard
+type setupStep struct {
+	title        string
+	description  string
+	choices      []string
+	defaultValue string
+}
+
+// setupChoices contains the selections made in the setup wizard
+type setupChoices struct {
+	theme       string
+	keyBindings string
+	layout      string
+}
+
+// SetupWizard guides the user through creating a grvrc file on first launch
+// Each step is displayed as an input dialog. Cancelling a step writes the choices made so far
+type SetupWizard struct {
+	config    *Configuration
+	channels  *Channels
+	steps     []setupStep
+	responses []string
+	active    bool
+	lock      sync.Mutex
+}
+
+// NewSetupWizard creates a new instance
+func NewSetupWizard(config *Configuration, channels *Channels) *SetupWizard {
+	return &SetupWizard{
+		config:   config,
+		channels: channels,
+		steps: []setupStep{
+			{
+				title:        "Setup: Theme",
+				description:  "Choose a theme",
+				choices:      config.ThemeNames(),
+				defaultValue: cfThemeDefaultValue,
+			},
+			{
+				title:        "Setup: Key Bindings",
+				description:  "Choose a key binding preset",
+				choices:      []string{KeymapVim, KeymapEmacs},
+				defaultValue: cfKeymapDefaultValue,
+			},
+			{
+				title:        "Setup: Layout",
+				description:  "Choose whether the Commit View and Diff View are stacked or displayed in columns",
+				choices:      []string{LayoutStacked, LayoutColumns},
+				defaultValue: LayoutStacked,
+			},
+		},
+	}
+}
+
+// Start displays the first step of the wizard
+func (setupWizard *SetupWizard) Start() {
+	log.Info("Starting setup wizard")
+
+	setupWizard.lock.Lock()
+	defer setupWizard.lock.Unlock()
+
+	setupWizard.responses = nil
+	setupWizard.active = true
+	setupWizard.showStep(fmt.Sprintf("No grvrc was found. Setup will create %v.", setupWizard.config.GrvrcPath()))
+}
+
+// HandleAction processes the response to the current step of the wizard
+// The response is the only argument of the action. If no response is present the step was cancelled
+func (setupWizard *SetupWizard) HandleAction(action Action) (err error) {
+	setupWizard.lock.Lock()
+	defer setupWizard.lock.Unlock()
+
+	if !setupWizard.active {
+		return
+	}
+
+	if len(action.Args) == 0 {
+		log.Info("Setup wizard cancelled")
+		return setupWizard.complete()
+	}
+
+	response, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected response argument to have type string")
+	}
+
+	step := setupWizard.steps[len(setupWizard.responses)]
+	response = strings.TrimSpace(response)
+
+	if !containsChoice(step.choices, response) {
+		setupWizard.showStep(fmt.Sprintf("%v is not a valid choice.", response))
+		return
+	}
+
+	setupWizard.responses = append(setupWizard.responses, response)
+
+	if len(setupWizard.responses) < len(setupWizard.steps) {
+		setupWizard.showStep("")
+		return
+	}
+
+	return setupWizard.complete()
+}
+
+// showStep displays a dialog for the next unanswered step
+func (setupWizard *SetupWizard) showStep(preamble string) {
+	step := setupWizard.steps[len(setupWizard.responses)]
+	message := fmt.Sprintf("%v: %v", step.description, strings.Join(step.choices, ", "))
+
+	if preamble != "" {
+		message = preamble + "\n" + message
+	}
+
+	setupWizard.channels.DoAction(Action{
+		ActionType: ActionShowDialog,
+		Args: []interface{}{DialogRequest{
+			dialogType:   DtInput,
+			title:        step.title,
+			message:      message,
+			initialInput: step.defaultValue,
+			action:       Action{ActionType: ActionSetupWizard},
+			cancelAction: Action{ActionType: ActionSetupWizard},
+		}},
+	})
+}
+
+// complete writes the choices made to the grvrc file and applies them
+func (setupWizard *SetupWizard) complete() (err error) {
+	setupWizard.active = false
+
+	var choices setupChoices
+	choiceFields := []*string{&choices.theme, &choices.keyBindings, &choices.layout}
+
+	for index, response := range setupWizard.responses {
+		*choiceFields[index] = response
+	}
+
+	grvrc := generateGrvrc(choices)
+	grvrcPath := setupWizard.config.GrvrcPath()
+
+	if err = writeNewFile(grvrcPath, grvrc); err != nil {
+		return fmt.Errorf("Unable to write %v: %v", grvrcPath, err)
+	}
+
+	log.Infof("Setup wizard wrote %v", grvrcPath)
+
+	if errs := setupWizard.config.LoadFile(grvrcPath); len(errs) > 0 {
+		setupWizard.channels.ReportErrors(errs)
+	}
+
+	setupWizard.channels.ReportStatus("Wrote %v", grvrcPath)
+
+	return
+}
+
+// generateGrvrc generates grvrc content which applies the provided choices
+// Choices which have not been made are omitted
+func generateGrvrc(choices setupChoices) string {
+	var buffer bytes.Buffer
+	buffer.WriteString(swGrvrcHeader + "\n")
+
+	if choices.theme != "" {
+		fmt.Fprintf(&buffer, "set %v %v\n", CfTheme, configValueString(choices.theme))
+	}
+
+	if choices.keyBindings != "" {
+		fmt.Fprintf(&buffer, "set %v %v\n", CfKeymap, choices.keyBindings)
+	}
+
+	if choices.layout != "" {
+		fmt.Fprintf(&buffer, "set %v %v\n", CfLayout, choices.layout)
+	}
+
+	return buffer.String()
+}
+
+// writeNewFile writes the content to a file which must not already exist
+func writeNewFile(filePath, content string) (err error) {
+	file, err := os.OpenFile(filePath, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if err != nil {
+		return
+	}
+
+	if _, err = file.WriteString(content); err != nil {
+		file.Close()
+		return
+	}
+
+	return file.Close()
+}
+
+func containsChoice(choices []string, choice string) bool {
+	for _, existingChoice := range choices {
+		if existingChoice == choice {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestGenerateGrvrc(t *testing.T) {
+	var grvrcTests = []struct {
+		choices       setupChoices
+		expectedGrvrc string
+	}{
+		{
+			choices:       setupChoices{},
+			expectedGrvrc: swGrvrcHeader + "\n",
+		},
+		{
+			choices: setupChoices{
+				theme:       "cold",
+				keyBindings: KeymapEmacs,
+				layout:      LayoutColumns,
+			},
+			expectedGrvrc: swGrvrcHeader + "\nset theme cold\nset keymap emacs\nset layout columns\n",
+		},
+		{
+			choices: setupChoices{
+				theme: "my theme",
+			},
+			expectedGrvrc: swGrvrcHeader + "\nset theme \"my theme\"\n",
+		},
+	}
+
+	for _, grvrcTest := range grvrcTests {
+		grvrc := generateGrvrc(grvrcTest.choices)
+
+		if grvrc != grvrcTest.expectedGrvrc {
+			t.Errorf("Generated grvrc does not match expected value. Expected: %q, Actual: %q", grvrcTest.expectedGrvrc, grvrc)
+		}
+	}
+}
+
+func TestGeneratedGrvrcIsValid(t *testing.T) {
+	for keyBindings := range keymapBindings {
+		config := NewConfiguration(NewKeyBindingManager(), nil)
+		grvrc := generateGrvrc(setupChoices{
+			theme:       cfColdThemeName,
+			keyBindings: keyBindings,
+			layout:      LayoutColumns,
+		})
+
+		if errs := config.Evaluate(grvrc); len(errs) > 0 {
+			t.Errorf("Generated grvrc for %v key bindings produced errors: %v", keyBindings, errs)
+		}
+
+		if config.GetString(CfKeymap) != keyBindings {
+			t.Errorf("Keymap does not match expected value. Expected: %v, Actual: %v", keyBindings, config.GetString(CfKeymap))
+		}
+
+		if config.GetString(CfLayout) != LayoutColumns {
+			t.Errorf("Layout does not match expected value. Expected: %v, Actual: %v", LayoutColumns, config.GetString(CfLayout))
+		}
+	}
+}
@@ -2,6 +2,8 @@ package main
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
 	"sync"
 	"unicode/utf8"
 
@@ -14,6 +16,14 @@ const (
 	SearchPromptText        = "/"
 	ReverseSearchPromptText = "?"
 	FilterPromptText        = "query: "
+	StashPromptText         = "stash message: "
+	CommitPromptText        = "commit message: "
+	BranchPromptText        = "branch name: "
+	FuzzyFindPromptText     = "find: "
+	UpstreamPromptText      = "upstream: "
+	RenameRefPromptText     = "rename to: "
+	TrackBranchPromptText   = "tracking branch: "
+	OwnerFilterPromptText   = "owner: "
 )
 
 type promptType int
@@ -23,6 +33,15 @@ const (
 	ptCommand
 	ptSearch
 	ptFilter
+	ptStash
+	ptCommit
+	ptBranch
+	ptFuzzyFind
+	ptUpstream
+	ptRenameRef
+	ptTrackBranch
+	ptOwnerFilter
+	ptDialog
 )
 
 // StatusBarView manages the display of the status bar
@@ -68,6 +87,24 @@ func (statusBarView *StatusBarView) HandleAction(action Action) (err error) {
 		statusBarView.showSearchPrompt(ReverseSearchPromptText, ActionReverseSearch)
 	case ActionFilterPrompt:
 		statusBarView.showFilterPrompt()
+	case ActionStashPrompt:
+		statusBarView.showStashPrompt()
+	case ActionCommitPrompt:
+		statusBarView.showCommitPrompt()
+	case ActionBranchPrompt:
+		statusBarView.showBranchPrompt()
+	case ActionFuzzyFind:
+		statusBarView.showFuzzyFindPrompt()
+	case ActionUpstreamPrompt:
+		statusBarView.showUpstreamPrompt()
+	case ActionRenameRefPrompt:
+		err = statusBarView.showRenameRefPrompt(action)
+	case ActionTrackBranchPrompt:
+		err = statusBarView.showTrackBranchPrompt(action)
+	case ActionOwnerFilterPrompt:
+		statusBarView.showOwnerFilterPrompt()
+	case ActionShowDialog:
+		err = statusBarView.showDialogPrompt(action)
 	case ActionShowStatus:
 		statusBarView.lock.Lock()
 		defer statusBarView.lock.Unlock()
@@ -91,8 +128,17 @@ func (statusBarView *StatusBarView) HandleAction(action Action) (err error) {
 func (statusBarView *StatusBarView) showCommandPrompt() {
 	statusBarView.promptType = ptCommand
 	input := Prompt(PromptText)
-	errors := statusBarView.config.Evaluate(input)
-	statusBarView.channels.ReportErrors(errors)
+
+	if lineNumber, err := strconv.ParseUint(strings.TrimSpace(input), 10, 32); err == nil {
+		statusBarView.channels.DoAction(Action{
+			ActionType: ActionGotoLine,
+			Args:       []interface{}{uint(lineNumber)},
+		})
+	} else {
+		errors := statusBarView.config.Evaluate(input)
+		statusBarView.channels.ReportErrors(errors)
+	}
+
 	statusBarView.promptType = ptNone
 }
 
@@ -128,6 +174,208 @@ func (statusBarView *StatusBarView) showFilterPrompt() {
 	statusBarView.promptType = ptNone
 }
 
+func (statusBarView *StatusBarView) showStashPrompt() {
+	statusBarView.promptType = ptStash
+	input := Prompt(StashPromptText)
+
+	if input != "" {
+		statusBarView.channels.DoAction(Action{
+			ActionType: ActionCreateStash,
+			Args:       []interface{}{input},
+		})
+	}
+
+	statusBarView.promptType = ptNone
+}
+
+func (statusBarView *StatusBarView) showCommitPrompt() {
+	statusBarView.promptType = ptCommit
+	input := Prompt(CommitPromptText)
+
+	if input != "" {
+		action := Action{
+			ActionType: ActionCreateCommit,
+			Args:       []interface{}{input},
+		}
+
+		if violations := NewCommitLinter(statusBarView.config).Lint(input); len(violations) > 0 {
+			action = Action{
+				ActionType: ActionShowDialog,
+				Args: []interface{}{DialogRequest{
+					dialogType: DtYesNo,
+					title:      "Commit Message Lint",
+					message:    commitLintMessage(violations) + "\n\nCommit anyway?",
+					action:     action,
+				}},
+			}
+		}
+
+		statusBarView.channels.DoAction(action)
+	}
+
+	statusBarView.promptType = ptNone
+}
+
+func (statusBarView *StatusBarView) showBranchPrompt() {
+	statusBarView.promptType = ptBranch
+	input := Prompt(BranchPromptText)
+
+	if input != "" {
+		statusBarView.channels.DoAction(Action{
+			ActionType: ActionCreateBranch,
+			Args:       []interface{}{input},
+		})
+	}
+
+	statusBarView.promptType = ptNone
+}
+
+func (statusBarView *StatusBarView) showFuzzyFindPrompt() {
+	statusBarView.promptType = ptFuzzyFind
+	input := Prompt(FuzzyFindPromptText)
+
+	if input != "" {
+		statusBarView.channels.DoAction(Action{
+			ActionType: ActionFuzzyFindSelect,
+			Args:       []interface{}{input},
+		})
+	}
+
+	statusBarView.promptType = ptNone
+}
+
+func (statusBarView *StatusBarView) showUpstreamPrompt() {
+	statusBarView.promptType = ptUpstream
+
+	_, remoteBranches, _ := statusBarView.repoData.Branches()
+	var remoteBranchNames []string
+	for _, remoteBranch := range remoteBranches {
+		remoteBranchNames = append(remoteBranchNames, remoteBranch.name)
+	}
+
+	input := PromptWithCompletions(UpstreamPromptText, remoteBranchNames)
+
+	if input != "" {
+		statusBarView.channels.DoAction(Action{
+			ActionType: ActionSetUpstream,
+			Args:       []interface{}{input},
+		})
+	}
+
+	statusBarView.promptType = ptNone
+}
+
+func (statusBarView *StatusBarView) showOwnerFilterPrompt() {
+	statusBarView.promptType = ptOwnerFilter
+
+	var owners []string
+	if head, _ := statusBarView.repoData.Head(); head != nil {
+		if headCommit, err := statusBarView.repoData.Commit(head); err == nil {
+			if codeOwners, err := statusBarView.repoData.CodeOwners(headCommit); err == nil {
+				owners = codeOwners.AllOwners()
+			}
+		}
+	}
+
+	input := strings.TrimSpace(PromptWithCompletions(OwnerFilterPromptText, owners))
+
+	if input != "" {
+		statusBarView.channels.DoAction(Action{
+			ActionType: ActionFilterOwner,
+			Args:       []interface{}{input},
+		})
+	}
+
+	statusBarView.promptType = ptNone
+}
+
+func (statusBarView *StatusBarView) showRenameRefPrompt(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected ref name argument")
+	}
+
+	refName, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected ref name argument to have type string")
+	}
+
+	statusBarView.promptType = ptRenameRef
+	input := PromptWithInitialInput(RenameRefPromptText, refName)
+
+	if input != "" && input != refName {
+		statusBarView.channels.DoAction(Action{
+			ActionType: ActionRenameRef,
+			Args:       []interface{}{refName, input},
+		})
+	}
+
+	statusBarView.promptType = ptNone
+
+	return
+}
+
+func (statusBarView *StatusBarView) showTrackBranchPrompt(action Action) (err error) {
+	if len(action.Args) < 2 {
+		return fmt.Errorf("Expected remote branch name and branch name arguments")
+	}
+
+	remoteBranchName, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected remote branch name argument to have type string")
+	}
+
+	branchName, ok := action.Args[1].(string)
+	if !ok {
+		return fmt.Errorf("Expected branch name argument to have type string")
+	}
+
+	statusBarView.promptType = ptTrackBranch
+	input := PromptWithInitialInput(TrackBranchPromptText, branchName)
+
+	if input != "" {
+		statusBarView.channels.DoAction(Action{
+			ActionType: ActionTrackBranch,
+			Args:       []interface{}{remoteBranchName, input},
+		})
+	}
+
+	statusBarView.promptType = ptNone
+
+	return
+}
+
+// showDialogPrompt reads the response to a dialog. The input is displayed by the
+// dialog rather than the status bar
+func (statusBarView *StatusBarView) showDialogPrompt(action Action) (err error) {
+	request, err := dialogRequestArg(action)
+	if err != nil {
+		return
+	}
+
+	statusBarView.promptType = ptDialog
+
+	var input string
+	switch request.dialogType {
+	case DtYesNo:
+		input = PromptSingleCharacter("")
+	case DtInput:
+		input = PromptWithInitialInput("", request.initialInput)
+	default:
+		input = Prompt("")
+	}
+
+	if responseAction, confirmed := dialogResponseAction(request, input); confirmed {
+		statusBarView.channels.DoAction(responseAction)
+	} else {
+		statusBarView.channels.ReportStatus("%v cancelled", request.title)
+		statusBarView.channels.DoAction(request.cancelAction)
+	}
+
+	statusBarView.promptType = ptNone
+
+	return
+}
+
 // OnActiveChange updates the active state of this view
 func (statusBarView *StatusBarView) OnActiveChange(active bool) {
 	statusBarView.lock.Lock()
@@ -153,11 +401,11 @@ func (statusBarView *StatusBarView) Render(win RenderWindow) (err error) {
 		return
 	}
 
-	if statusBarView.active {
+	if statusBarView.active && statusBarView.promptType != ptDialog {
 		promptText, promptInput, promptPoint := PromptState()
 		lineBuilder.Append("%v%v", promptText, promptInput)
 		bytes := 0
-		characters := len(promptText)
+		characters := int(StringWidth(promptText))
 
 		for _, char := range promptInput {
 			bytes += utf8.RuneLen(char)
@@ -171,13 +419,56 @@ func (statusBarView *StatusBarView) Render(win RenderWindow) (err error) {
 
 		err = win.SetCursor(0, uint(characters))
 	} else {
-		lineBuilder.Append(" %v", statusBarView.pendingStatus)
+		statusline := statusBarView.statusline()
+		lineBuilder.Append(" %v", statusline)
 		win.ApplyStyle(CmpStatusbarviewNormal)
+
+		if progress, active := statusBarView.repoData.TransferProgress(); active {
+			statusBarView.renderTransferProgress(lineBuilder, win.Cols(), StringWidth(statusline)+1, progress)
+		}
 	}
 
 	return
 }
 
+// renderTransferProgress draws a progress bar for the transfer in progress at the right of the status bar
+func (statusBarView *StatusBarView) renderTransferProgress(lineBuilder *LineBuilder, cols, usedCols uint, progress TransferProgress) {
+	progressBar := TransferProgressBar(progress, pgBarWidth)
+	progressBarWidth := StringWidth(progressBar) + 1
+
+	padding := uint(1)
+	if cols > usedCols+progressBarWidth+padding {
+		padding = cols - usedCols - progressBarWidth
+	}
+
+	lineBuilder.AppendWithStyle(CmpStatusbarviewNormal, "%v", strings.Repeat(" ", int(padding))).
+		AppendWithStyle(CmpStatusbarviewProgressBar, "%v", progressBar)
+}
+
+// statusline expands the configured statusline using values from the repository
+// and the views in the active view hierarchy
+func (statusBarView *StatusBarView) statusline() string {
+	values := StatuslineValues{
+		"status": statusBarView.pendingStatus,
+	}
+
+	format := statusBarView.config.GetString(CfStatusline)
+	repositoryStatuslineValues(statusBarView.repoData, values)
+
+	// Comparing the branch with its upstream requires a revision walk, so it is only performed when used
+	if strings.Contains(format, "%ahead") || strings.Contains(format, "%behind") || strings.Contains(format, "%upstream") {
+		upstreamStatuslineValues(statusBarView.repoData, values)
+	}
+
+	for _, view := range statusBarView.rootView.ActiveViewHierarchy() {
+		if statuslineProvider, ok := view.(StatuslineProvider); ok {
+			statuslineProvider.StatuslineValues(values)
+		}
+	}
+
+	return ExpandStatusline(format, values)
+}
+
 // RenderStatusBar does nothing
 func (statusBarView *StatusBarView) RenderStatusBar(lineBuilder *LineBuilder) (err error) {
 	return
@@ -194,6 +485,28 @@ func (statusBarView *StatusBarView) RenderHelpBar(lineBuilder *LineBuilder) (err
 		message = "Enter a regex pattern"
 	case ptFilter:
 		message = "Enter a filter query"
+	case ptStash:
+		message = "Enter a stash message"
+	case ptCommit:
+		message = "Enter a commit message"
+
+		if head, headBranch := statusBarView.repoData.Head(); head != nil && headBranch == nil {
+			message = "HEAD is detached: the commit will not be on any branch. Enter a commit message"
+		}
+	case ptBranch:
+		message = "Enter a branch name"
+	case ptFuzzyFind:
+		message = "Enter a ref, commit or file to find"
+	case ptUpstream:
+		message = "Enter the upstream branch (<Tab> completes remote branches)"
+	case ptRenameRef:
+		message = "Enter the new branch name"
+	case ptDialog:
+		message = "Respond to the dialog or press <Enter> to cancel"
+	case ptTrackBranch:
+		message = "Enter the name of the local branch which will track the remote branch"
+	case ptOwnerFilter:
+		message = "Enter a CODEOWNERS owner to filter commits by (<Tab> completes owners)"
 	}
 
 	if message != "" {
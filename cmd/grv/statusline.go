@@ -0,0 +1,118 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// StatuslineValues maps placeholder names to the values they expand to
+type StatuslineValues map[string]string
+
+// StatuslineProvider is implemented by views which supply values for statusline and footer placeholders
+type StatuslineProvider interface {
+	StatuslineValues(values StatuslineValues)
+}
+
+// ExpandStatusline replaces each %placeholder in the format string with its value
+// Placeholders without a value are removed and %% produces a literal %
+func ExpandStatusline(format string, values StatuslineValues) string {
+	var buffer bytes.Buffer
+	chars := []rune(format)
+
+	for i := 0; i < len(chars); i++ {
+		if chars[i] != '%' {
+			buffer.WriteRune(chars[i])
+			continue
+		}
+
+		if i+1 < len(chars) && chars[i+1] == '%' {
+			buffer.WriteRune('%')
+			i++
+			continue
+		}
+
+		nameEnd := i + 1
+		for nameEnd < len(chars) && unicode.IsLetter(chars[nameEnd]) {
+			nameEnd++
+		}
+
+		if nameEnd == i+1 {
+			buffer.WriteRune('%')
+			continue
+		}
+
+		buffer.WriteString(values[string(chars[i+1:nameEnd])])
+		i = nameEnd - 1
+	}
+
+	return buffer.String()
+}
+
+// expandFooter expands a footer format string removing any surrounding whitespace
+// left by placeholders which have no value
+func expandFooter(format string, values StatuslineValues) string {
+	return strings.TrimSpace(ExpandStatusline(format, values))
+}
+
+// repositoryStatuslineValues adds placeholder values describing the repository state
+func repositoryStatuslineValues(repoData RepoData, values StatuslineValues) {
+	repoPath := strings.TrimSuffix(filepath.Clean(repoData.Path()), string(filepath.Separator)+".git")
+	values["repo"] = filepath.Base(repoPath)
+
+	head, branch := repoData.Head()
+	if head != nil {
+		values["head"] = repoData.ShortID(head)
+	}
+
+	if branch != nil {
+		values["branch"] = branch.name
+	} else if head != nil {
+		values["branch"] = getDetachedHeadDisplayValue(repoData, head)
+	}
+}
+
+// upstreamStatuslineValues adds placeholder values comparing the checked out branch to its upstream branch
+func upstreamStatuslineValues(repoData RepoData, values StatuslineValues) {
+	_, branch := repoData.Head()
+	if branch == nil {
+		return
+	}
+
+	upstreamStatus, err := repoData.UpstreamStatus(branch)
+	if err != nil {
+		log.Debugf("Unable to determine upstream status of branch %v: %v", branch.name, err)
+		return
+	} else if upstreamStatus == nil {
+		return
+	}
+
+	values["upstream"] = upstreamStatus.name
+	values["ahead"] = fmt.Sprintf("%v", upstreamStatus.ahead)
+	values["behind"] = fmt.Sprintf("%v", upstreamStatus.behind)
+}
+
+// positionStatuslineValues adds placeholder values describing the selected item in a view
+func positionStatuslineValues(itemName string, selected, total uint, values StatuslineValues) {
+	values["line"] = fmt.Sprintf("%v", selected)
+	values["lines"] = fmt.Sprintf("%v", total)
+	values["position"] = fmt.Sprintf("%v %v of %v", itemName, selected, total)
+}
+
+// filtersText describes the number of filters applied
+func filtersText(filtersApplied uint) string {
+	if filtersApplied == 0 {
+		return ""
+	}
+
+	plural := ""
+	if filtersApplied > 1 {
+		plural = "s"
+	}
+
+	return fmt.Sprintf("(%v filter%v applied)", filtersApplied, plural)
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestExpandStatusline(t *testing.T) {
+	values := StatuslineValues{
+		"repo":    "grv",
+		"branch":  "master",
+		"filters": "(1 filter applied)",
+	}
+
+	var statuslineTests = []struct {
+		format           string
+		expectedExpanded string
+	}{
+		{
+			format:           "%repo %branch %filters",
+			expectedExpanded: "grv master (1 filter applied)",
+		},
+		{
+			format:           "[%branch]",
+			expectedExpanded: "[master]",
+		},
+		{
+			format:           "%unknown%branch",
+			expectedExpanded: "master",
+		},
+		{
+			format:           "100%% %",
+			expectedExpanded: "100% %",
+		},
+		{
+			format:           "% branch",
+			expectedExpanded: "% branch",
+		},
+	}
+
+	for _, statuslineTest := range statuslineTests {
+		expanded := ExpandStatusline(statuslineTest.format, values)
+
+		if expanded != statuslineTest.expectedExpanded {
+			t.Errorf("Expanded statusline does not match expected value for format %v. Expected: %v, Actual: %v", statuslineTest.format, statuslineTest.expectedExpanded, expanded)
+		}
+	}
+}
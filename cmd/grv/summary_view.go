@@ -0,0 +1,381 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+const (
+	svRecentActivityMax = 10
+	svDateFormat        = "2006-01-02 15:04"
+)
+
+type summaryViewHandler func(*SummaryView, Action) error
+
+// summaryItem is a line displayed in the summary view
+// Items with a target can be selected to display the target in its view
+type summaryItem struct {
+	label    string
+	value    string
+	isHeader bool
+	target   *ViewTarget
+}
+
+// repositorySummary contains the values displayed in the summary view
+type repositorySummary struct {
+	path          string
+	head          *Oid
+	headName      string
+	hasBranch     bool
+	lastCommit    string
+	upstream      *UpstreamStatus
+	modifiedFiles string
+	stashNum      int
+	remotes       []string
+	activity      []*ActivityEntry
+	shortID       func(*Oid) string
+}
+
+// SummaryView displays an overview of the repository state
+type SummaryView struct {
+	channels      *Channels
+	repoData      RepoData
+	config        Config
+	items         []*summaryItem
+	active        bool
+	viewPos       ViewPos
+	viewDimension ViewDimension
+	handlers      map[ActionType]summaryViewHandler
+	lock          sync.Mutex
+}
+
+// NewSummaryView creates a new instance
+func NewSummaryView(repoData RepoData, channels *Channels, config Config) *SummaryView {
+	return &SummaryView{
+		channels: channels,
+		repoData: repoData,
+		config:   config,
+		viewPos:  NewViewPosition(),
+		handlers: map[ActionType]summaryViewHandler{
+			ActionPrevLine:  moveUpSummaryItem,
+			ActionNextLine:  moveDownSummaryItem,
+			ActionFirstLine: moveToFirstSummaryItem,
+			ActionLastLine:  moveToLastSummaryItem,
+			ActionSelect:    selectSummaryItem,
+		},
+	}
+}
+
+// Initialise loads HEAD so that it can be summarised
+// The summary itself is generated whenever the view becomes active
+func (summaryView *SummaryView) Initialise() (err error) {
+	log.Info("Initialising SummaryView")
+	return summaryView.repoData.LoadHead()
+}
+
+// Render generates and writes the summary view to the provided window
+func (summaryView *SummaryView) Render(win RenderWindow) (err error) {
+	log.Debug("Rendering SummaryView")
+	summaryView.lock.Lock()
+	defer summaryView.lock.Unlock()
+
+	summaryView.viewDimension = win.ViewDimensions()
+
+	itemNum := uint(len(summaryView.items))
+	rows := win.Rows() - 2
+	viewPos := summaryView.viewPos
+	viewPos.DetermineViewStartRow(rows, itemNum, uint(summaryView.config.GetInt(CfScrollOff)))
+	itemIndex := viewPos.ViewStartRowIndex()
+	startColumn := viewPos.ViewStartColumn()
+	labelWidth := summaryLabelWidth(summaryView.items)
+
+	for winRowIndex := uint(0); winRowIndex < rows && itemIndex < itemNum; winRowIndex++ {
+		item := summaryView.items[itemIndex]
+
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(winRowIndex+1, startColumn); err != nil {
+			return
+		}
+
+		switch {
+		case item.isHeader:
+			lineBuilder.AppendWithStyle(CmpSummaryviewHeader, " %v", item.label)
+		case item.label != "":
+			lineBuilder.AppendWithStyle(CmpSummaryviewLabel, " %-*v ", labelWidth+1, item.label+":").
+				Append("%v", item.value)
+		default:
+			lineBuilder.Append("   %v", item.value)
+		}
+
+		itemIndex++
+	}
+
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, summaryView.active); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	return win.SetTitle(CmpSummaryviewTitle, "%v", NewTitleBuilder("Summary"))
+}
+
+// summaryLabelWidth returns the width of the longest label
+func summaryLabelWidth(items []*summaryItem) (width int) {
+	for _, item := range items {
+		if !item.isHeader && len(item.label) > width {
+			width = len(item.label)
+		}
+	}
+
+	return
+}
+
+// RenderStatusBar does nothing
+func (summaryView *SummaryView) RenderStatusBar(lineBuilder *LineBuilder) (err error) {
+	return
+}
+
+// RenderHelpBar generates key binding help info for the summary view
+func (summaryView *SummaryView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(summaryView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionSelect, message: "Open"},
+		{action: ActionNextTab, message: "Next Tab"},
+	})
+
+	return
+}
+
+// HandleKeyPress does nothing
+func (summaryView *SummaryView) HandleKeyPress(keystring string) (err error) {
+	log.Debugf("SummaryView handling key %v - NOP", keystring)
+	return
+}
+
+// HandleAction checks if the summary view supports the provided action and executes it if so
+func (summaryView *SummaryView) HandleAction(action Action) (err error) {
+	log.Debugf("SummaryView handling action %v", action)
+	summaryView.lock.Lock()
+	defer summaryView.lock.Unlock()
+
+	if handler, ok := summaryView.handlers[action.ActionType]; ok {
+		err = handler(summaryView, action)
+	}
+
+	return
+}
+
+// OnActiveChange updates whether the summary view is active and regenerates the summary when it becomes active
+func (summaryView *SummaryView) OnActiveChange(active bool) {
+	log.Debugf("SummaryView active: %v", active)
+	summaryView.lock.Lock()
+	defer summaryView.lock.Unlock()
+
+	if active && !summaryView.active {
+		go summaryView.loadSummary()
+	}
+
+	summaryView.active = active
+}
+
+// ViewID returns the view ID of the summary view
+func (summaryView *SummaryView) ViewID() ViewID {
+	return ViewSummary
+}
+
+// loadSummary gathers the repository state and regenerates the displayed summary
+func (summaryView *SummaryView) loadSummary() {
+	items := generateSummaryItems(summaryView.repositorySummary())
+
+	summaryView.lock.Lock()
+	defer summaryView.lock.Unlock()
+
+	summaryView.items = items
+
+	if itemNum := uint(len(items)); itemNum > 0 && summaryView.viewPos.ActiveRowIndex() >= itemNum {
+		summaryView.viewPos.SetActiveRowIndex(itemNum - 1)
+	}
+
+	summaryView.channels.UpdateDisplay()
+}
+
+func (summaryView *SummaryView) repositorySummary() *repositorySummary {
+	repoData := summaryView.repoData
+	head, headBranch := repoData.Head()
+
+	summary := &repositorySummary{
+		path:     repoData.Path(),
+		head:     head,
+		stashNum: len(repoData.Stashes()),
+		shortID:  repoData.ShortID,
+	}
+
+	if headBranch != nil {
+		summary.headName = headBranch.name
+		summary.hasBranch = true
+	} else if head != nil {
+		summary.headName = getDetachedHeadDisplayValue(repoData, head)
+	}
+
+	if head != nil {
+		if commit, err := repoData.Commit(head); err != nil {
+			log.Errorf("Unable to load HEAD commit: %v", err)
+		} else {
+			author := commit.commit.Author()
+			summary.lastCommit = fmt.Sprintf("%v %v (%v, %v)", repoData.ShortID(head), commit.commit.Summary(),
+				author.Name, author.When.Format(svDateFormat))
+		}
+
+		if headBranch != nil {
+			upstream, err := repoData.UpstreamStatus(headBranch)
+			if err != nil {
+				log.Errorf("Unable to determine upstream status of %v: %v", headBranch.name, err)
+			}
+
+			summary.upstream = upstream
+		}
+	}
+
+	if modifiedFiles, err := repoData.ModifiedFileCount(); err != nil {
+		log.Errorf("Unable to determine modified files: %v", err)
+		summary.modifiedFiles = "Unavailable"
+	} else if modifiedFiles == 0 {
+		summary.modifiedFiles = "None"
+	} else {
+		summary.modifiedFiles = fmt.Sprintf("%v", modifiedFiles)
+	}
+
+	remotes, err := repoData.Remotes()
+	if err != nil {
+		log.Errorf("Unable to load remotes: %v", err)
+	}
+	summary.remotes = remotes
+
+	activity, err := repoData.RecentActivity(svRecentActivityMax)
+	if err != nil {
+		log.Errorf("Unable to load recent activity: %v", err)
+	}
+	summary.activity = activity
+
+	return summary
+}
+
+// generateSummaryItems creates the lines displayed in the summary view
+func generateSummaryItems(summary *repositorySummary) (items []*summaryItem) {
+	branchesTarget := &ViewTarget{viewID: ViewRef, refGroup: RvLocalBranchGroup}
+	remoteBranchesTarget := &ViewTarget{viewID: ViewRef, refGroup: RvRemoteBranchGroup}
+
+	items = append(items, &summaryItem{label: "Repository", value: summary.path})
+
+	if summary.headName != "" {
+		items = append(items, &summaryItem{label: "Branch", value: summary.headName, target: branchesTarget})
+	}
+
+	if summary.hasBranch {
+		upstream := "None"
+		if summary.upstream != nil {
+			upstream = fmt.Sprintf("%v (%v)", summary.upstream.name, describeUpstreamStatus(summary.upstream))
+		}
+
+		items = append(items, &summaryItem{label: "Upstream", value: upstream, target: remoteBranchesTarget})
+	}
+
+	if summary.lastCommit != "" {
+		items = append(items, &summaryItem{
+			label:  "Last Commit",
+			value:  summary.lastCommit,
+			target: &ViewTarget{viewID: ViewCommit, refName: summary.headName, oid: summary.head},
+		})
+	}
+
+	remotes := "None"
+	if len(summary.remotes) > 0 {
+		remotes = strings.Join(summary.remotes, ", ")
+	}
+
+	items = append(items,
+		&summaryItem{label: "Modified Files", value: summary.modifiedFiles},
+		&summaryItem{label: "Stashes", value: fmt.Sprintf("%v", summary.stashNum), target: &ViewTarget{viewID: ViewRef, refGroup: RvStashGroup}},
+		&summaryItem{label: "Remotes", value: remotes, target: remoteBranchesTarget},
+	)
+
+	if len(summary.activity) > 0 {
+		items = append(items, &summaryItem{}, &summaryItem{label: "Recent Activity", isHeader: true})
+
+		for _, entry := range summary.activity {
+			shortID := summary.shortID(entry.oid)
+
+			items = append(items, &summaryItem{
+				value:  fmt.Sprintf("%v %v %v", entry.when.Format(svDateFormat), shortID, entry.message),
+				target: &ViewTarget{viewID: ViewCommit, refName: shortID, oid: entry.oid},
+			})
+		}
+	}
+
+	return
+}
+
+// describeUpstreamStatus describes the number of commits a branch is ahead and behind its upstream
+func describeUpstreamStatus(upstream *UpstreamStatus) string {
+	switch {
+	case upstream.ahead == 0 && upstream.behind == 0:
+		return "up to date"
+	case upstream.behind == 0:
+		return fmt.Sprintf("%v ahead", upstream.ahead)
+	case upstream.ahead == 0:
+		return fmt.Sprintf("%v behind", upstream.behind)
+	default:
+		return fmt.Sprintf("%v ahead, %v behind", upstream.ahead, upstream.behind)
+	}
+}
+
+func moveUpSummaryItem(summaryView *SummaryView, action Action) (err error) {
+	if summaryView.viewPos.MoveLinesUp(action.RepeatCount()) {
+		summaryView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownSummaryItem(summaryView *SummaryView, action Action) (err error) {
+	if summaryView.viewPos.MoveLinesDown(action.RepeatCount(), uint(len(summaryView.items))) {
+		summaryView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToFirstSummaryItem(summaryView *SummaryView, action Action) (err error) {
+	if summaryView.viewPos.MoveToFirstLine() {
+		summaryView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToLastSummaryItem(summaryView *SummaryView, action Action) (err error) {
+	if summaryView.viewPos.MoveToLastLine(uint(len(summaryView.items))) {
+		summaryView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+// selectSummaryItem displays the view corresponding to the selected item
+func selectSummaryItem(summaryView *SummaryView, action Action) (err error) {
+	activeRowIndex := summaryView.viewPos.ActiveRowIndex()
+	if activeRowIndex >= uint(len(summaryView.items)) {
+		return
+	}
+
+	if item := summaryView.items[activeRowIndex]; item.target != nil {
+		summaryView.channels.DoAction(Action{
+			ActionType: ActionShowView,
+			Args:       []interface{}{*item.target},
+		})
+	}
+
+	return
+}
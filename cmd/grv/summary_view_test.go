@@ -0,0 +1,86 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDescribeUpstreamStatus(t *testing.T) {
+	var upstreamTests = []struct {
+		upstream            *UpstreamStatus
+		expectedDescription string
+	}{
+		{upstream: &UpstreamStatus{}, expectedDescription: "up to date"},
+		{upstream: &UpstreamStatus{ahead: 2}, expectedDescription: "2 ahead"},
+		{upstream: &UpstreamStatus{behind: 3}, expectedDescription: "3 behind"},
+		{upstream: &UpstreamStatus{ahead: 1, behind: 4}, expectedDescription: "1 ahead, 4 behind"},
+	}
+
+	for _, upstreamTest := range upstreamTests {
+		if description := describeUpstreamStatus(upstreamTest.upstream); description != upstreamTest.expectedDescription {
+			t.Errorf("Description does not match expected value. Expected: %v, Actual: %v", upstreamTest.expectedDescription, description)
+		}
+	}
+}
+
+func TestGenerateSummaryItems(t *testing.T) {
+	head := &Oid{}
+	summary := &repositorySummary{
+		path:          "/src/grv",
+		head:          head,
+		headName:      "master",
+		hasBranch:     true,
+		lastCommit:    "abc1234 Fix bug (Jane Doe, 2017-05-01 10:00)",
+		upstream:      &UpstreamStatus{name: "origin/master", ahead: 1},
+		modifiedFiles: "None",
+		stashNum:      2,
+		remotes:       []string{"origin", "upstream"},
+		activity: []*ActivityEntry{
+			{
+				oid:     head,
+				message: "commit: Fix bug",
+				when:    time.Date(2017, 5, 1, 10, 0, 0, 0, time.UTC),
+			},
+		},
+		shortID: func(*Oid) string { return "abc1234" },
+	}
+
+	var expectedItems = []struct {
+		label        string
+		value        string
+		targetViewID ViewID
+		hasTarget    bool
+	}{
+		{label: "Repository", value: "/src/grv"},
+		{label: "Branch", value: "master", targetViewID: ViewRef, hasTarget: true},
+		{label: "Upstream", value: "origin/master (1 ahead)", targetViewID: ViewRef, hasTarget: true},
+		{label: "Last Commit", value: "abc1234 Fix bug (Jane Doe, 2017-05-01 10:00)", targetViewID: ViewCommit, hasTarget: true},
+		{label: "Modified Files", value: "None"},
+		{label: "Stashes", value: "2", targetViewID: ViewRef, hasTarget: true},
+		{label: "Remotes", value: "origin, upstream", targetViewID: ViewRef, hasTarget: true},
+		{},
+		{label: "Recent Activity"},
+		{value: "2017-05-01 10:00 abc1234 commit: Fix bug", targetViewID: ViewCommit, hasTarget: true},
+	}
+
+	items := generateSummaryItems(summary)
+
+	if len(items) != len(expectedItems) {
+		t.Fatalf("Item count does not match expected value. Expected: %v, Actual: %v", len(expectedItems), len(items))
+	}
+
+	for itemIndex, expectedItem := range expectedItems {
+		item := items[itemIndex]
+
+		if item.label != expectedItem.label || item.value != expectedItem.value {
+			t.Errorf("Item %v does not match expected value. Expected: %v: %v, Actual: %v: %v", itemIndex,
+				expectedItem.label, expectedItem.value, item.label, item.value)
+		}
+
+		if hasTarget := item.target != nil; hasTarget != expectedItem.hasTarget {
+			t.Errorf("Item %v target presence does not match expected value. Expected: %v, Actual: %v", itemIndex, expectedItem.hasTarget, hasTarget)
+		} else if hasTarget && item.target.viewID != expectedItem.targetViewID {
+			t.Errorf("Item %v target view does not match expected value. Expected: %v, Actual: %v", itemIndex, expectedItem.targetViewID, item.target.viewID)
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package main
+
+import (
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+type syntaxTokenType int
+
+// The set of token types highlighted in files
+const (
+	sttNone syntaxTokenType = iota
+	sttKeyword
+	sttString
+	sttComment
+	sttNumber
+)
+
+var syntaxTokenThemeComponentID = map[syntaxTokenType]ThemeComponentID{
+	sttNone:    CmpFileviewLine,
+	sttKeyword: CmpFileviewKeyword,
+	sttString:  CmpFileviewString,
+	sttComment: CmpFileviewComment,
+	sttNumber:  CmpFileviewNumber,
+}
+
+// syntaxToken is a run of text in a line which is highlighted in the same way
+type syntaxToken struct {
+	text      string
+	tokenType syntaxTokenType
+}
+
+// syntaxLanguage describes the lexical elements of a language in enough detail to highlight
+// keywords, strings, comments and numbers. indentFolding is set for languages whose blocks are
+// determined by indentation rather than braces
+type syntaxLanguage struct {
+	keywords          map[string]bool
+	lineComments      []string
+	blockCommentStart string
+	blockCommentEnd   string
+	stringDelimiters  string
+	indentFolding     bool
+}
+
+func newKeywordSet(keywords string) map[string]bool {
+	keywordSet := make(map[string]bool)
+
+	for _, keyword := range strings.Fields(keywords) {
+		keywordSet[keyword] = true
+	}
+
+	return keywordSet
+}
+
+var (
+	goLanguage = &syntaxLanguage{
+		keywords: newKeywordSet(`break case chan const continue default defer else fallthrough for func go goto if
+			import interface map package range return select struct switch type var nil true false`),
+		lineComments:      []string{"//"},
+		blockCommentStart: "/*",
+		blockCommentEnd:   "*/",
+		stringDelimiters:  "\"'`",
+	}
+	cLanguage = &syntaxLanguage{
+		keywords: newKeywordSet(`auto break case char class const continue default delete do double else enum extern
+			float for goto if inline int long namespace new private protected public register return short signed sizeof
+			static struct switch template this typedef union unsigned virtual void volatile while true false nullptr NULL`),
+		lineComments:      []string{"//"},
+		blockCommentStart: "/*",
+		blockCommentEnd:   "*/",
+		stringDelimiters:  "\"'",
+	}
+	javaLanguage = &syntaxLanguage{
+		keywords: newKeywordSet(`abstract boolean break byte case catch char class const continue default do double
+			else enum extends final finally float for if implements import instanceof int interface long new package
+			private protected public return short static super switch synchronized this throw throws try void while
+			true false null`),
+		lineComments:      []string{"//"},
+		blockCommentStart: "/*",
+		blockCommentEnd:   "*/",
+		stringDelimiters:  "\"'",
+	}
+	javaScriptLanguage = &syntaxLanguage{
+		keywords: newKeywordSet(`async await break case catch class const continue default delete do else export
+			extends finally for from function if import in instanceof interface let new of return static super switch
+			this throw try type typeof var void while yield true false null undefined`),
+		lineComments:      []string{"//"},
+		blockCommentStart: "/*",
+		blockCommentEnd:   "*/",
+		stringDelimiters:  "\"'`",
+	}
+	rustLanguage = &syntaxLanguage{
+		keywords: newKeywordSet(`as async await break const continue crate else enum extern fn for if impl in let
+			loop match mod move mut pub ref return self Self static struct super trait type unsafe use where while
+			true false`),
+		lineComments:      []string{"//"},
+		blockCommentStart: "/*",
+		blockCommentEnd:   "*/",
+		stringDelimiters:  "\"",
+	}
+	pythonLanguage = &syntaxLanguage{
+		keywords: newKeywordSet(`and as assert async await break class continue def del elif else except finally for
+			from global if import in is lambda nonlocal not or pass raise return try while with yield True False None`),
+		lineComments:     []string{"#"},
+		stringDelimiters: "\"'",
+		indentFolding:    true,
+	}
+	shellLanguage = &syntaxLanguage{
+		keywords:         newKeywordSet(`case do done elif else esac export fi for function if in local return then until while`),
+		lineComments:     []string{"#"},
+		stringDelimiters: "\"'",
+	}
+)
+
+var syntaxLanguageExtensions = map[string]*syntaxLanguage{
+	".go":   goLanguage,
+	".c":    cLanguage,
+	".h":    cLanguage,
+	".cc":   cLanguage,
+	".cpp":  cLanguage,
+	".hpp":  cLanguage,
+	".java": javaLanguage,
+	".js":   javaScriptLanguage,
+	".jsx":  javaScriptLanguage,
+	".ts":   javaScriptLanguage,
+	".tsx":  javaScriptLanguage,
+	".rs":   rustLanguage,
+	".py":   pythonLanguage,
+	".sh":   shellLanguage,
+	".bash": shellLanguage,
+}
+
+// syntaxLanguageForPath returns the language of the file based on its extension or nil if it is not recognised
+func syntaxLanguageForPath(filePath string) *syntaxLanguage {
+	return syntaxLanguageExtensions[strings.ToLower(path.Ext(filePath))]
+}
+
+// highlightLines splits each line into tokens of the provided language
+// Block comments spanning multiple lines are tracked. If no language is provided each line is a single token
+func highlightLines(language *syntaxLanguage, lines []string) (tokens [][]syntaxToken) {
+	inBlockComment := false
+
+	for _, line := range lines {
+		var lineTokens []syntaxToken
+
+		if language == nil {
+			lineTokens = []syntaxToken{{text: line}}
+		} else {
+			lineTokens, inBlockComment = language.highlightLine(line, inBlockComment)
+		}
+
+		tokens = append(tokens, lineTokens)
+	}
+
+	return
+}
+
+// highlightLine splits the line into tokens and returns whether the line ends inside a block comment
+func (language *syntaxLanguage) highlightLine(line string, inBlockComment bool) (tokens []syntaxToken, endsInBlockComment bool) {
+	appendToken := func(text string, tokenType syntaxTokenType) {
+		if lastIndex := len(tokens) - 1; lastIndex >= 0 && tokens[lastIndex].tokenType == tokenType {
+			tokens[lastIndex].text += text
+		} else {
+			tokens = append(tokens, syntaxToken{text: text, tokenType: tokenType})
+		}
+	}
+
+	for index := 0; index < len(line); {
+		rest := line[index:]
+
+		if inBlockComment {
+			endIndex := strings.Index(rest, language.blockCommentEnd)
+			if endIndex == -1 {
+				appendToken(rest, sttComment)
+				break
+			}
+
+			endIndex += len(language.blockCommentEnd)
+			appendToken(rest[:endIndex], sttComment)
+			index += endIndex
+			inBlockComment = false
+			continue
+		}
+
+		if language.blockCommentStart != "" && strings.HasPrefix(rest, language.blockCommentStart) {
+			appendToken(language.blockCommentStart, sttComment)
+			index += len(language.blockCommentStart)
+			inBlockComment = true
+			continue
+		}
+
+		if language.isLineComment(rest) {
+			appendToken(rest, sttComment)
+			break
+		}
+
+		char, width := utf8.DecodeRuneInString(rest)
+
+		switch {
+		case strings.ContainsRune(language.stringDelimiters, char):
+			length := stringLiteralLength(rest, char)
+			appendToken(rest[:length], sttString)
+			index += length
+		case unicode.IsDigit(char):
+			length := identifierLength(rest)
+			appendToken(rest[:length], sttNumber)
+			index += length
+		case isSyntaxIdentifierChar(char):
+			length := identifierLength(rest)
+			tokenType := sttNone
+			if language.keywords[rest[:length]] {
+				tokenType = sttKeyword
+			}
+
+			appendToken(rest[:length], tokenType)
+			index += length
+		default:
+			appendToken(rest[:width], sttNone)
+			index += width
+		}
+	}
+
+	return tokens, inBlockComment
+}
+
+func (language *syntaxLanguage) isLineComment(text string) bool {
+	for _, lineComment := range language.lineComments {
+		if strings.HasPrefix(text, lineComment) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// stringLiteralLength returns the length of the string literal at the start of the text
+// Strings which are not terminated extend to the end of the line
+func stringLiteralLength(text string, delimiter rune) int {
+	for index := 1; index < len(text); index++ {
+		switch rune(text[index]) {
+		case '\\':
+			index++
+		case delimiter:
+			return index + 1
+		}
+	}
+
+	return len(text)
+}
+
+// identifierLength returns the length of the identifier or number at the start of the text
+func identifierLength(text string) (length int) {
+	for length < len(text) {
+		char, width := utf8.DecodeRuneInString(text[length:])
+		if !isSyntaxIdentifierChar(char) && !(char == '.' && length > 0 && unicode.IsDigit(rune(text[0]))) {
+			break
+		}
+
+		length += width
+	}
+
+	return
+}
+
+func isSyntaxIdentifierChar(char rune) bool {
+	return char == '_' || unicode.IsLetter(char) || unicode.IsDigit(char)
+}
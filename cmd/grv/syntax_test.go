@@ -0,0 +1,91 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestHighlightLines(t *testing.T) {
+	var highlightTests = []struct {
+		filePath       string
+		lines          []string
+		expectedTokens [][]syntaxToken
+	}{
+		{
+			filePath: "main.go",
+			lines:    []string{`return "a\"b", 42 // done`},
+			expectedTokens: [][]syntaxToken{
+				{
+					{text: "return", tokenType: sttKeyword},
+					{text: " "},
+					{text: `"a\"b"`, tokenType: sttString},
+					{text: ", "},
+					{text: "42", tokenType: sttNumber},
+					{text: " "},
+					{text: "// done", tokenType: sttComment},
+				},
+			},
+		},
+		{
+			filePath: "lib.c",
+			lines:    []string{"int x; /* start", "middle", "end */ x"},
+			expectedTokens: [][]syntaxToken{
+				{
+					{text: "int", tokenType: sttKeyword},
+					{text: " x; "},
+					{text: "/* start", tokenType: sttComment},
+				},
+				{
+					{text: "middle", tokenType: sttComment},
+				},
+				{
+					{text: "end */", tokenType: sttComment},
+					{text: " x"},
+				},
+			},
+		},
+		{
+			filePath: "script.py",
+			lines:    []string{"def returns(x): # 1.5"},
+			expectedTokens: [][]syntaxToken{
+				{
+					{text: "def", tokenType: sttKeyword},
+					{text: " returns(x): "},
+					{text: "# 1.5", tokenType: sttComment},
+				},
+			},
+		},
+		{
+			filePath: "README",
+			lines:    []string{"if 1"},
+			expectedTokens: [][]syntaxToken{
+				{
+					{text: "if 1"},
+				},
+			},
+		},
+	}
+
+	for _, highlightTest := range highlightTests {
+		tokens := highlightLines(syntaxLanguageForPath(highlightTest.filePath), highlightTest.lines)
+
+		if !reflect.DeepEqual(highlightTest.expectedTokens, tokens) {
+			t.Errorf("Tokens do not match expected value for file %v. Expected: %v, Actual: %v",
+				highlightTest.filePath, highlightTest.expectedTokens, tokens)
+		}
+	}
+}
+
+func TestUnterminatedStringExtendsToEndOfLine(t *testing.T) {
+	tokens := highlightLines(syntaxLanguageForPath("main.js"), []string{`x = 'abc`})
+	expectedTokens := [][]syntaxToken{
+		{
+			{text: "x = "},
+			{text: "'abc", tokenType: sttString},
+		},
+	}
+
+	if !reflect.DeepEqual(expectedTokens, tokens) {
+		t.Errorf("Tokens do not match expected value. Expected: %v, Actual: %v", expectedTokens, tokens)
+	}
+}
@@ -0,0 +1,314 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math/rand"
+	"os"
+	"strings"
+	"time"
+
+	log "github.com/Sirupsen/logrus"
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+const (
+	srDefaultCommitNum      = 1000
+	srDefaultBranchNum      = 50
+	srDefaultTagNum         = 50
+	srDefaultFileNum        = 100
+	srDefaultFileLines      = 50
+	srDefaultFilesPerCommit = 3
+	srRandomSeed            = 1
+	srSignatureName         = "GRV Synthetic"
+	srSignatureEmail        = "synthetic@grv.invalid"
+	srCommitInterval        = time.Hour
+	srProgressRefreshRate   = 100 * time.Millisecond
+	srBranchRefFormat       = "refs/heads/branch%04d"
+	srTagRefFormat          = "refs/tags/v%04d"
+	srFilePathFormat        = "file%04d.txt"
+)
+
+var srStartTime = time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+// SyntheticRepoOptions describes the size of a generated repository
+type SyntheticRepoOptions struct {
+	commitNum      int
+	branchNum      int
+	tagNum         int
+	fileNum        int
+	fileLines      int
+	filesPerCommit int
+}
+
+// DefaultSyntheticRepoOptions returns the options used when no size is specified
+func DefaultSyntheticRepoOptions() SyntheticRepoOptions {
+	return SyntheticRepoOptions{
+		commitNum:      srDefaultCommitNum,
+		branchNum:      srDefaultBranchNum,
+		tagNum:         srDefaultTagNum,
+		fileNum:        srDefaultFileNum,
+		fileLines:      srDefaultFileLines,
+		filesPerCommit: srDefaultFilesPerCommit,
+	}
+}
+
+func (options SyntheticRepoOptions) validate() error {
+	switch {
+	case options.commitNum < 1:
+		return fmt.Errorf("The number of commits must be at least 1")
+	case options.branchNum < 0 || options.tagNum < 0:
+		return fmt.Errorf("The number of branches and tags cannot be negative")
+	case options.fileNum < 1 || options.fileLines < 1:
+		return fmt.Errorf("The number of files and lines per file must be at least 1")
+	case options.filesPerCommit < 1 || options.filesPerCommit > options.fileNum:
+		return fmt.Errorf("The number of files modified per commit must be between 1 and %v", options.fileNum)
+	}
+
+	return nil
+}
+
+// newSyntheticRandom returns a random number generator which produces the same sequence on every run
+func newSyntheticRandom() *rand.Rand {
+	return rand.New(rand.NewSource(srRandomSeed))
+}
+
+// syntheticFile is the current content of a file in a generated repository
+type syntheticFile struct {
+	path  string
+	lines []string
+}
+
+func newSyntheticFiles(fileNum, fileLines int) (files []*syntheticFile) {
+	for fileIndex := 0; fileIndex < fileNum; fileIndex++ {
+		file := &syntheticFile{
+			path: fmt.Sprintf(srFilePathFormat, fileIndex),
+		}
+
+		for lineIndex := 0; lineIndex < fileLines; lineIndex++ {
+			file.lines = append(file.lines, fmt.Sprintf("File %v line %v", fileIndex, lineIndex))
+		}
+
+		files = append(files, file)
+	}
+
+	return
+}
+
+// modify replaces a line of the file and occasionally adds a new line
+func (file *syntheticFile) modify(commitIndex int, random *rand.Rand) {
+	lineIndex := random.Intn(len(file.lines))
+	file.lines[lineIndex] = fmt.Sprintf("Line %v modified by commit %v", lineIndex, commitIndex)
+
+	if random.Intn(4) == 0 {
+		file.lines = append(file.lines, fmt.Sprintf("Line added by commit %v", commitIndex))
+	}
+}
+
+func (file *syntheticFile) content() []byte {
+	return []byte(strings.Join(file.lines, "\n") + "\n")
+}
+
+// syntheticRefCommitIndex returns the index of the commit a ref points to
+// so that refs are spread evenly through the history
+func syntheticRefCommitIndex(refIndex, refNum, commitNum int) int {
+	return (refIndex * commitNum) / refNum
+}
+
+// syntheticRepoGenerator creates the commits of a generated repository
+type syntheticRepoGenerator struct {
+	repo    *git.Repository
+	options SyntheticRepoOptions
+	random  *rand.Rand
+	files   []*syntheticFile
+	tree    *git.Tree
+	parent  *git.Commit
+	oids    []*git.Oid
+}
+
+// GenerateSyntheticRepo creates a repository at the provided path containing generated commits, branches and tags
+// The same options always produce the same history so performance can be compared between runs.
+// onCommitCreated is called with the number of commits created so far (if not nil)
+func GenerateSyntheticRepo(path string, options SyntheticRepoOptions, onCommitCreated func(commitNum int)) (err error) {
+	if err = options.validate(); err != nil {
+		return
+	}
+
+	log.Infof("Generating synthetic repository at %v with options %+v", path, options)
+
+	repo, err := git.InitRepository(path, false)
+	if err != nil {
+		return
+	}
+	defer repo.Free()
+
+	generator := &syntheticRepoGenerator{
+		repo:    repo,
+		options: options,
+		random:  newSyntheticRandom(),
+		files:   newSyntheticFiles(options.fileNum, options.fileLines),
+	}
+	defer generator.free()
+
+	for commitIndex := 0; commitIndex < options.commitNum; commitIndex++ {
+		if err = generator.createCommit(commitIndex); err != nil {
+			return
+		}
+
+		if onCommitCreated != nil {
+			onCommitCreated(commitIndex + 1)
+		}
+	}
+
+	if err = generator.createRefs(srBranchRefFormat, options.branchNum); err != nil {
+		return
+	}
+
+	return generator.createRefs(srTagRefFormat, options.tagNum)
+}
+
+// createCommit modifies files and commits them on top of the previous commit
+// The first commit adds all files
+func (generator *syntheticRepoGenerator) createCommit(commitIndex int) (err error) {
+	var builder *git.TreeBuilder
+	var modifiedFiles []*syntheticFile
+
+	if generator.tree == nil {
+		builder, err = generator.repo.TreeBuilder()
+		modifiedFiles = generator.files
+	} else {
+		builder, err = generator.repo.TreeBuilderFromTree(generator.tree)
+
+		for _, fileIndex := range generator.random.Perm(len(generator.files))[:generator.options.filesPerCommit] {
+			file := generator.files[fileIndex]
+			file.modify(commitIndex, generator.random)
+			modifiedFiles = append(modifiedFiles, file)
+		}
+	}
+
+	if err != nil {
+		return
+	}
+	defer builder.Free()
+
+	for _, file := range modifiedFiles {
+		var blobOid *git.Oid
+		if blobOid, err = generator.repo.CreateBlobFromBuffer(file.content()); err != nil {
+			return
+		}
+
+		if err = builder.Insert(file.path, blobOid, git.FilemodeBlob); err != nil {
+			return
+		}
+	}
+
+	treeOid, err := builder.Write()
+	if err != nil {
+		return
+	}
+
+	tree, err := generator.repo.LookupTree(treeOid)
+	if err != nil {
+		return
+	}
+
+	signature := &git.Signature{
+		Name:  srSignatureName,
+		Email: srSignatureEmail,
+		When:  srStartTime.Add(time.Duration(commitIndex) * srCommitInterval),
+	}
+
+	message := fmt.Sprintf("Synthetic commit %v\n\nModified %v files", commitIndex, len(modifiedFiles))
+
+	var parents []*git.Commit
+	if generator.parent != nil {
+		parents = append(parents, generator.parent)
+	}
+
+	oid, err := generator.repo.CreateCommit("HEAD", signature, signature, message, tree, parents...)
+	if err != nil {
+		tree.Free()
+		return
+	}
+
+	commit, err := generator.repo.LookupCommit(oid)
+	if err != nil {
+		tree.Free()
+		return
+	}
+
+	generator.free()
+	generator.tree = tree
+	generator.parent = commit
+	generator.oids = append(generator.oids, oid)
+
+	return
+}
+
+// createRefs creates refNum refs spread evenly through the generated commits
+func (generator *syntheticRepoGenerator) createRefs(refFormat string, refNum int) (err error) {
+	for refIndex := 0; refIndex < refNum; refIndex++ {
+		oid := generator.oids[syntheticRefCommitIndex(refIndex, refNum, len(generator.oids))]
+
+		var ref *git.Reference
+		if ref, err = generator.repo.References.Create(fmt.Sprintf(refFormat, refIndex), oid, true, ""); err != nil {
+			return
+		}
+
+		ref.Free()
+	}
+
+	return
+}
+
+func (generator *syntheticRepoGenerator) free() {
+	if generator.tree != nil {
+		generator.tree.Free()
+		generator.tree = nil
+	}
+
+	if generator.parent != nil {
+		generator.parent.Free()
+		generator.parent = nil
+	}
+}
+
+// GenerateSyntheticRepoFromCommandLine generates a repository using the provided command line arguments
+// The repository can be used to reproduce performance issues without sharing a real repository
+func GenerateSyntheticRepoFromCommandLine(args []string) (err error) {
+	options := DefaultSyntheticRepoOptions()
+	flagSet := flag.NewFlagSet(mnSyntheticRepoCommand, flag.ContinueOnError)
+	flagSet.IntVar(&options.commitNum, "commits", options.commitNum, "Number of commits")
+	flagSet.IntVar(&options.branchNum, "branches", options.branchNum, "Number of branches")
+	flagSet.IntVar(&options.tagNum, "tags", options.tagNum, "Number of tags")
+	flagSet.IntVar(&options.fileNum, "files", options.fileNum, "Number of files")
+	flagSet.IntVar(&options.fileLines, "lines", options.fileLines, "Initial number of lines in each file")
+	flagSet.IntVar(&options.filesPerCommit, "filesPerCommit", options.filesPerCommit, "Number of files modified by each commit")
+	flagSet.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %v %v [options] <directory>\n", os.Args[0], mnSyntheticRepoCommand)
+		flagSet.PrintDefaults()
+	}
+
+	if err = flagSet.Parse(args); err != nil {
+		return
+	} else if flagSet.NArg() != 1 {
+		flagSet.Usage()
+		return fmt.Errorf("Expected a single directory argument")
+	}
+
+	directory := flagSet.Arg(0)
+	var lastUpdate time.Time
+
+	err = GenerateSyntheticRepo(directory, options, func(commitNum int) {
+		if time.Since(lastUpdate) >= srProgressRefreshRate || commitNum == options.commitNum {
+			fmt.Fprintf(os.Stderr, "\rGenerating %v: %v/%v commits", directory, commitNum, options.commitNum)
+			lastUpdate = time.Now()
+		}
+	})
+
+	if !lastUpdate.IsZero() {
+		fmt.Fprintln(os.Stderr)
+	}
+
+	return
+}
@@ -0,0 +1,391 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSyntheticRepoOptionsValidation(t *testing.T) {
+	var validationTests = []struct {
+		modify      func(*SyntheticRepoOptions)
+		expectError bool
+	}{
+		{modify: func(options *SyntheticRepoOptions) {}},
+		{modify: func(options *SyntheticRepoOptions) { options.branchNum, options.tagNum = 0, 0 }},
+		{modify: func(options *SyntheticRepoOptions) { options.commitNum = 0 }, expectError: true},
+		{modify: func(options *SyntheticRepoOptions) { options.tagNum = -1 }, expectError: true},
+		{modify: func(options *SyntheticRepoOptions) { options.fileLines = 0 }, expectError: true},
+		{modify: func(options *SyntheticRepoOptions) { options.filesPerCommit = options.fileNum + 1 }, expectError: true},
+	}
+
+	for testIndex, validationTest := range validationTests {
+		options := DefaultSyntheticRepoOptions()
+		validationTest.modify(&options)
+
+		if err := options.validate(); (err != nil) != validationTest.expectError {
+			t.Errorf("Validation result for test %v does not match expected value. Expected error: %v, Actual error: %v",
+				testIndex, validationTest.expectError, err)
+		}
+	}
+}
+
+func TestSyntheticRefCommitIndexSpreadsRefsThroughHistory(t *testing.T) {
+	var refCommitIndexTests = []struct {
+		refIndex            int
+		refNum              int
+		commitNum           int
+		expectedCommitIndex int
+	}{
+		{refIndex: 0, refNum: 4, commitNum: 100, expectedCommitIndex: 0},
+		{refIndex: 1, refNum: 4, commitNum: 100, expectedCommitIndex: 25},
+		{refIndex: 3, refNum: 4, commitNum: 100, expectedCommitIndex: 75},
+		{refIndex: 9, refNum: 10, commitNum: 3, expectedCommitIndex: 2},
+	}
+
+	for _, refCommitIndexTest := range refCommitIndexTests {
+		commitIndex := syntheticRefCommitIndex(refCommitIndexTest.refIndex, refCommitIndexTest.refNum, refCommitIndexTest.commitNum)
+
+		if commitIndex != refCommitIndexTest.expectedCommitIndex {
+			t.Errorf("Commit index does not match expected value. Expected: %v, Actual: %v",
+				refCommitIndexTest.expectedCommitIndex, commitIndex)
+		}
+	}
+}
+
+func TestSyntheticFileModificationIsDeterministic(t *testing.T) {
+	generateContent := func() string {
+		generator := &syntheticRepoGenerator{
+			random: newSyntheticRandom(),
+			files:  newSyntheticFiles(2, 10),
+		}
+
+		for commitIndex := 0; commitIndex < 20; commitIndex++ {
+			generator.files[commitIndex%2].modify(commitIndex, generator.random)
+		}
+
+		return string(generator.files[0].content()) + string(generator.files[1].content())
+	}
+
+	if firstContent, secondContent := generateContent(), generateContent(); firstContent != secondContent {
+		t.Errorf("Generated file content differs between runs")
+	}
+}
+
+// benchmarkRepo is a generated repository opened for use in benchmarks and tests
+type benchmarkRepo struct {
+	dir      string
+	channels *Channels
+	config   *Configuration
+	repoData *RepositoryData
+}
+
+func newBenchmarkRepo(tb testing.TB, options SyntheticRepoOptions) *benchmarkRepo {
+	dir, err := ioutil.TempDir("", "grv-benchmark")
+	if err != nil {
+		tb.Fatalf("Unable to create temporary directory: %v", err)
+	}
+
+	if err = GenerateSyntheticRepo(dir, options, nil); err != nil {
+		os.RemoveAll(dir)
+		tb.Fatalf("Unable to generate repository: %v", err)
+	}
+
+	channels := gRVChannels{
+		exitCh:    make(chan bool),
+		actionCh:  make(chan Action, grvActionBufferSize),
+		displayCh: make(chan bool, grvDisplayBufferSize),
+		errorCh:   make(chan error, grvErrorBufferSize),
+	}.Channels()
+
+	config := NewConfiguration(NewKeyBindingManager(), channels)
+	repoData := NewRepositoryData(NewRepoDataLoader(channels), channels, config)
+
+	if err = repoData.Initialise(dir, ""); err != nil {
+		os.RemoveAll(dir)
+		tb.Fatalf("Unable to open repository: %v", err)
+	}
+
+	if err = repoData.LoadHead(); err != nil {
+		tb.Fatalf("Unable to load HEAD: %v", err)
+	}
+
+	return &benchmarkRepo{
+		dir:      dir,
+		channels: channels,
+		config:   config,
+		repoData: repoData,
+	}
+}
+
+func (benchmarkRepo *benchmarkRepo) free() {
+	benchmarkRepo.repoData.Free()
+	os.RemoveAll(benchmarkRepo.dir)
+}
+
+func (benchmarkRepo *benchmarkRepo) loadRefs(tb testing.TB) {
+	branchesLoaded := make(chan bool, 1)
+	tagsLoaded := make(chan bool, 1)
+
+	if err := benchmarkRepo.repoData.LoadBranches(func(localBranches, remoteBranches []*Branch) error {
+		branchesLoaded <- true
+		return nil
+	}); err != nil {
+		tb.Fatalf("Unable to load branches: %v", err)
+	}
+
+	if err := benchmarkRepo.repoData.LoadLocalTags(func(tags []*Tag) error {
+		tagsLoaded <- true
+		return nil
+	}); err != nil {
+		tb.Fatalf("Unable to load tags: %v", err)
+	}
+
+	<-branchesLoaded
+	<-tagsLoaded
+}
+
+func (benchmarkRepo *benchmarkRepo) headCommit(tb testing.TB) *Commit {
+	head, _ := benchmarkRepo.repoData.Head()
+
+	commit, err := benchmarkRepo.repoData.Commit(head)
+	if err != nil {
+		tb.Fatalf("Unable to load HEAD commit: %v", err)
+	}
+
+	return commit
+}
+
+func TestLoadBranchesCallsCallbacksRegisteredWhileLoading(t *testing.T) {
+	options := DefaultSyntheticRepoOptions()
+	options.commitNum = 10
+
+	benchmarkRepo := newBenchmarkRepo(t, options)
+	defer benchmarkRepo.free()
+
+	loadBranches := func(callbackNum int) {
+		callbackCh := make(chan bool, callbackNum)
+
+		for callbackIndex := 0; callbackIndex < callbackNum; callbackIndex++ {
+			if err := benchmarkRepo.repoData.LoadBranches(func(localBranches, remoteBranches []*Branch) error {
+				callbackCh <- true
+				return nil
+			}); err != nil {
+				t.Fatalf("Unable to load branches: %v", err)
+			}
+		}
+
+		for callbackIndex := 0; callbackIndex < callbackNum; callbackIndex++ {
+			select {
+			case <-callbackCh:
+			case <-time.After(10 * time.Second):
+				t.Fatalf("Only %v of %v branch load callbacks were called", callbackIndex, callbackNum)
+			}
+		}
+	}
+
+	loadBranches(3)
+	loadBranches(1)
+}
+
+func TestDiffGeneratesFilePatchesInOrder(t *testing.T) {
+	options := DefaultSyntheticRepoOptions()
+	options.commitNum = 2
+	options.fileNum = 200
+	options.filesPerCommit = 200
+
+	benchmarkRepo := newBenchmarkRepo(t, options)
+	defer benchmarkRepo.free()
+	commit := benchmarkRepo.headCommit(t)
+
+	var generatedFiles []*DiffFile
+	diff, err := benchmarkRepo.repoData.Diff(context.Background(), commit, DiffOptions{
+		contextLines: 3,
+		onFilesGenerated: func(diff *Diff, files []*DiffFile) {
+			generatedFiles = append(generatedFiles, files...)
+		},
+	})
+	if err != nil {
+		t.Fatalf("Unable to generate diff: %v", err)
+	}
+
+	if len(diff.files) == 0 || len(generatedFiles) != len(diff.files) {
+		t.Fatalf("Generated file count does not match expected value. Expected: %v, Actual: %v", len(diff.files), len(generatedFiles))
+	}
+
+	var diffText bytes.Buffer
+	for fileIndex, diffFile := range diff.files {
+		if generatedFiles[fileIndex] != diffFile {
+			t.Errorf("File %v was not generated in order", diffFile.newPath)
+		}
+
+		if fileIndex > 0 && diff.files[fileIndex-1].newPath >= diffFile.newPath {
+			t.Errorf("File %v is not ordered after %v", diffFile.newPath, diff.files[fileIndex-1].newPath)
+		}
+
+		if expectedPrefix := "diff --git a/" + diffFile.oldPath; !strings.HasPrefix(diffFile.patch.String(), expectedPrefix) {
+			t.Errorf("Patch for file %v does not start with %v", diffFile.newPath, expectedPrefix)
+		}
+
+		diffText.Write(diffFile.patch.Bytes())
+	}
+
+	if diffText.String() != diff.diffText.String() {
+		t.Errorf("Diff text does not match the patches of the diff files")
+	}
+}
+
+func TestDiffStopsWhenCancelled(t *testing.T) {
+	options := DefaultSyntheticRepoOptions()
+	options.commitNum = 2
+
+	benchmarkRepo := newBenchmarkRepo(t, options)
+	defer benchmarkRepo.free()
+	commit := benchmarkRepo.headCommit(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := benchmarkRepo.repoData.Diff(ctx, commit, DiffOptions{}); err != context.Canceled {
+		t.Errorf("Error does not match expected value. Expected: %v, Actual: %v", context.Canceled, err)
+	}
+}
+
+func TestDescribeFindsNearestTag(t *testing.T) {
+	options := DefaultSyntheticRepoOptions()
+	options.commitNum = 100
+	options.branchNum = 0
+	options.tagNum = 4
+
+	benchmarkRepo := newBenchmarkRepo(t, options)
+	defer benchmarkRepo.free()
+	benchmarkRepo.loadRefs(t)
+	commit := benchmarkRepo.headCommit(t)
+
+	description, found, err := benchmarkRepo.repoData.Describe(context.Background(), commit)
+	if err != nil {
+		t.Fatalf("Unable to describe commit: %v", err)
+	} else if !found {
+		t.Fatalf("Expected a tag to describe commit %v", commit.oid)
+	}
+
+	if !strings.HasPrefix(description, "v") {
+		t.Errorf("Expected description %v to start with a tag name", description)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, _, err := benchmarkRepo.repoData.Describe(ctx, commit); err != context.Canceled {
+		t.Errorf("Error does not match expected value. Expected: %v, Actual: %v", context.Canceled, err)
+	}
+}
+
+func BenchmarkRefViewGenerateRenderedRefs(b *testing.B) {
+	options := DefaultSyntheticRepoOptions()
+	options.commitNum = 100
+	options.branchNum = 2000
+	options.tagNum = 2000
+
+	benchmarkRepo := newBenchmarkRepo(b, options)
+	defer benchmarkRepo.free()
+	benchmarkRepo.loadRefs(b)
+
+	refView := NewRefView(benchmarkRepo.repoData, benchmarkRepo.channels, benchmarkRepo.config, NewOperationCanceller())
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		refView.generateRenderedRefs()
+	}
+}
+
+func BenchmarkLoadCommits(b *testing.B) {
+	options := DefaultSyntheticRepoOptions()
+	options.commitNum = 10000
+
+	benchmarkRepo := newBenchmarkRepo(b, options)
+	defer benchmarkRepo.free()
+	head, _ := benchmarkRepo.repoData.Head()
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		commitCh, err := benchmarkRepo.repoData.repoDataLoader.Commits([]*Oid{head}, nil, CommitOrderDate)
+		if err != nil {
+			b.Fatalf("Unable to load commits: %v", err)
+		}
+
+		commitNum := 0
+		for range commitCh {
+			commitNum++
+		}
+
+		if commitNum != options.commitNum {
+			b.Fatalf("Loaded commit count does not match expected value. Expected: %v, Actual: %v", options.commitNum, commitNum)
+		}
+	}
+}
+
+func newBenchmarkDiffOptions() SyntheticRepoOptions {
+	options := DefaultSyntheticRepoOptions()
+	options.commitNum = 10
+	options.fileNum = 500
+	options.fileLines = 200
+	options.filesPerCommit = 500
+
+	return options
+}
+
+func BenchmarkGenerateDiffLines(b *testing.B) {
+	benchmarkRepo := newBenchmarkRepo(b, newBenchmarkDiffOptions())
+	defer benchmarkRepo.free()
+
+	diffView := NewDiffView(benchmarkRepo.repoData, benchmarkRepo.channels, benchmarkRepo.config, NewOperationCanceller())
+	commit := benchmarkRepo.headCommit(b)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		if _, err := diffView.generateDiffLines(context.Background(), commit, func([]*diffLineData) {}); err != nil {
+			b.Fatalf("Unable to generate diff: %v", err)
+		}
+	}
+}
+
+func BenchmarkDiffViewRender(b *testing.B) {
+	benchmarkRepo := newBenchmarkRepo(b, newBenchmarkDiffOptions())
+	defer benchmarkRepo.free()
+
+	diffView := NewDiffView(benchmarkRepo.repoData, benchmarkRepo.channels, benchmarkRepo.config, NewOperationCanceller())
+	commit := benchmarkRepo.headCommit(b)
+
+	lines, err := diffView.generateDiffLines(context.Background(), commit, func([]*diffLineData) {})
+	if err != nil {
+		b.Fatalf("Unable to generate diff: %v", err)
+	}
+
+	diffView.activeCommit = commit
+	diffView.commitDiffs[commit] = &diffLines{
+		lines:   lines,
+		viewPos: diffView.viewPos,
+	}
+
+	win := NewWindow("diffView", benchmarkRepo.config)
+	win.Resize(ViewDimension{rows: 60, cols: 200})
+	lineNum := uint(len(lines))
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		diffView.viewPos.SetActiveRowIndex(uint(i*37) % lineNum)
+
+		if err := diffView.Render(win); err != nil {
+			b.Fatalf("Unable to render diff: %v", err)
+		}
+	}
+}
@@ -7,7 +7,8 @@ import (
 )
 
 const (
-	tfSeparator = " "
+	tfSeparator        = " "
+	tfTruncationMarker = "…"
 )
 
 type tableCellText struct {
@@ -21,18 +22,41 @@ type tableCell struct {
 
 // TableFormatter renders provided data in a tabular layout
 type TableFormatter struct {
-	config       Config
-	maxColWidths []uint
-	cells        [][]tableCell
+	config              Config
+	maxColWidths        []uint
+	colWidthLimits      []uint
+	cells               [][]tableCell
+	lineNumberGutter    *LineNumberGutter
+	gutterStartRowIndex uint
 }
 
 // NewTableFormatter creates a new instance of the table formatter supporting the specified number of columns
 func NewTableFormatter(cols uint) *TableFormatter {
 	return &TableFormatter{
-		maxColWidths: make([]uint, cols),
+		maxColWidths:   make([]uint, cols),
+		colWidthLimits: make([]uint, cols),
 	}
 }
 
+// SetColWidthLimit sets the maximum width of the specified column
+// Text which exceeds the limit is truncated. A limit of 0 means the column width is unlimited
+func (tableFormatter *TableFormatter) SetColWidthLimit(colIndex, widthLimit uint) (err error) {
+	if colIndex >= uint(len(tableFormatter.colWidthLimits)) {
+		return fmt.Errorf("Invalid colIndex (%v) for cols (%v)", colIndex, len(tableFormatter.colWidthLimits))
+	}
+
+	tableFormatter.colWidthLimits[colIndex] = widthLimit
+
+	return
+}
+
+// SetLineNumberGutter sets the line numbers to display before each row
+// The first row displayed corresponds to the line at startRowIndex
+func (tableFormatter *TableFormatter) SetLineNumberGutter(lineNumberGutter *LineNumberGutter, startRowIndex uint) {
+	tableFormatter.lineNumberGutter = lineNumberGutter
+	tableFormatter.gutterStartRowIndex = startRowIndex
+}
+
 // Rows returns the number of rows in the table formatter
 func (tableFormatter *TableFormatter) Rows() uint {
 	return uint(len(tableFormatter.cells))
@@ -157,6 +181,10 @@ func (tableFormatter *TableFormatter) Render(win RenderWindow, viewStartColumn u
 			return
 		}
 
+		if tableFormatter.lineNumberGutter != nil {
+			tableFormatter.lineNumberGutter.Render(lineBuilder, tableFormatter.gutterStartRowIndex+uint(rowIndex))
+		}
+
 		if border {
 			lineBuilder.Append(" ")
 		}
@@ -221,6 +249,10 @@ func (tableFormatter *TableFormatter) determineMaxColWidths(border bool) {
 		}
 
 		for rowIndex := range tableFormatter.cells {
+			if widthLimit := tableFormatter.colWidthLimits[colIndex]; widthLimit > 0 {
+				tableFormatter.truncateCell(rowIndex, colIndex, column, widthLimit)
+			}
+
 			width := tableFormatter.textWidth(rowIndex, colIndex, column)
 
 			if width > tableFormatter.maxColWidths[colIndex] {
@@ -231,6 +263,53 @@ func (tableFormatter *TableFormatter) determineMaxColWidths(border bool) {
 
 }
 
+// truncateCell shortens the text of a cell wider than widthLimit and appends a truncation marker
+func (tableFormatter *TableFormatter) truncateCell(rowIndex, colIndex int, column, widthLimit uint) {
+	if tableFormatter.textWidth(rowIndex, colIndex, column) <= widthLimit {
+		return
+	}
+
+	tableCell := &tableFormatter.cells[rowIndex][colIndex]
+	var truncatedEntries []tableCellText
+	width := uint(0)
+
+	for _, textEntry := range tableCell.textEntries {
+		var buf bytes.Buffer
+		truncated := false
+
+		for _, codePoint := range textEntry.text {
+			codePointWidth := uint(0)
+			for _, renderedCodePoint := range DetermineRenderedCodePoint(codePoint, column+width, tableFormatter.config) {
+				codePointWidth += renderedCodePoint.width
+			}
+
+			if width+codePointWidth >= widthLimit {
+				truncated = true
+				break
+			}
+
+			buf.WriteRune(codePoint)
+			width += codePointWidth
+		}
+
+		truncatedEntries = append(truncatedEntries, tableCellText{
+			text:             buf.String(),
+			themeComponentID: textEntry.themeComponentID,
+		})
+
+		if truncated {
+			truncatedEntries = append(truncatedEntries, tableCellText{
+				text:             tfTruncationMarker,
+				themeComponentID: textEntry.themeComponentID,
+			})
+
+			break
+		}
+	}
+
+	tableCell.textEntries = truncatedEntries
+}
+
 func (tableFormatter *TableFormatter) textWidth(rowIndex, colIndex int, column uint) (width uint) {
 	textEntries := tableFormatter.cells[rowIndex][colIndex].textEntries
 
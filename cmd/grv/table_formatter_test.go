@@ -0,0 +1,44 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestColumnsWiderThanTheirLimitAreTruncated(t *testing.T) {
+	tableFormatter := NewTableFormatter(2)
+	tableFormatter.Resize(2)
+
+	if err := tableFormatter.SetColWidthLimit(0, 6); err != nil {
+		t.Fatalf("Unable to set column width limit: %v", err)
+	}
+
+	tableFormatter.SetCell(0, 0, "Short")
+	tableFormatter.AppendToCellWithStyle(1, 0, CmpCommitviewTag, "<v1>")
+	tableFormatter.AppendToCellWithStyle(1, 0, CmpCommitviewSummary, " Summary")
+	tableFormatter.SetCell(0, 1, "Unlimited column text")
+
+	if err := tableFormatter.PadCells(false); err != nil {
+		t.Fatalf("Unable to pad cells: %v", err)
+	}
+
+	var rowTests = []struct {
+		rowIndex          uint
+		expectedRowString string
+	}{
+		{rowIndex: 0, expectedRowString: "Short  Unlimited column text "},
+		{rowIndex: 1, expectedRowString: "<v1> … " + strings.Repeat(" ", 21) + " "},
+	}
+
+	for _, rowTest := range rowTests {
+		if rowString, err := tableFormatter.RowString(rowTest.rowIndex); err != nil {
+			t.Errorf("Unable to get row %v: %v", rowTest.rowIndex, err)
+		} else if rowString != rowTest.expectedRowString {
+			t.Errorf("Row %v does not match expected value. Expected: %q, Actual: %q", rowTest.rowIndex, rowTest.expectedRowString, rowString)
+		}
+	}
+
+	if err := tableFormatter.SetColWidthLimit(2, 10); err == nil {
+		t.Errorf("Expected error when setting limit for invalid column")
+	}
+}
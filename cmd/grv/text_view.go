@@ -0,0 +1,185 @@
+package main
+
+import (
+	"sync"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+type textViewHandler func(*TextView, Action) error
+
+// TextView displays read only text such as a generated changelog
+type TextView struct {
+	channels      *Channels
+	config        Config
+	title         string
+	lines         []string
+	active        bool
+	viewPos       ViewPos
+	viewDimension ViewDimension
+	handlers      map[ActionType]textViewHandler
+	lock          sync.Mutex
+}
+
+// NewTextView creates a new instance
+func NewTextView(channels *Channels, config Config) *TextView {
+	return &TextView{
+		channels: channels,
+		config:   config,
+		viewPos:  NewViewPosition(),
+		handlers: map[ActionType]textViewHandler{
+			ActionPrevLine:  moveUpTextLine,
+			ActionNextLine:  moveDownTextLine,
+			ActionFirstLine: moveToFirstTextLine,
+			ActionLastLine:  moveToLastTextLine,
+		},
+	}
+}
+
+// Initialise does nothing
+func (textView *TextView) Initialise() (err error) {
+	log.Info("Initialising TextView")
+	return
+}
+
+// SetText replaces the displayed text and moves to the first line
+func (textView *TextView) SetText(title string, lines []string) {
+	textView.lock.Lock()
+	defer textView.lock.Unlock()
+
+	textView.title = title
+	textView.lines = lines
+	textView.viewPos = NewViewPosition()
+
+	textView.channels.UpdateDisplay()
+}
+
+// UpdateText replaces the displayed text while keeping the selected line
+func (textView *TextView) UpdateText(title string, lines []string) {
+	textView.lock.Lock()
+	defer textView.lock.Unlock()
+
+	textView.title = title
+	textView.lines = lines
+
+	if lineNum := uint(len(lines)); textView.viewPos.ActiveRowIndex() >= lineNum {
+		textView.viewPos.MoveToLastLine(lineNum)
+	}
+
+	textView.channels.UpdateDisplay()
+}
+
+// Render generates and writes the text view to the provided window
+func (textView *TextView) Render(win RenderWindow) (err error) {
+	log.Debug("Rendering TextView")
+	textView.lock.Lock()
+	defer textView.lock.Unlock()
+
+	textView.viewDimension = win.ViewDimensions()
+
+	lineNum := uint(len(textView.lines))
+	rows := win.Rows() - 2
+	viewPos := textView.viewPos
+	viewPos.DetermineViewStartRow(rows, lineNum, uint(textView.config.GetInt(CfScrollOff)))
+	lineIndex := viewPos.ViewStartRowIndex()
+	startColumn := viewPos.ViewStartColumn()
+
+	for winRowIndex := uint(0); winRowIndex < rows && lineIndex < lineNum; winRowIndex++ {
+		var lineBuilder *LineBuilder
+		if lineBuilder, err = win.LineBuilder(winRowIndex+1, startColumn); err != nil {
+			return
+		}
+
+		lineBuilder.AppendWithStyle(CmpTextviewLine, " ").AppendANSI(CmpTextviewLine, textView.lines[lineIndex])
+		lineIndex++
+	}
+
+	if err = win.SetSelectedRow(viewPos.SelectedRowIndex()+1, textView.active); err != nil {
+		return
+	}
+
+	win.DrawBorder()
+
+	return win.SetTitle(CmpTextviewTitle, "%v", NewTitleBuilder(textView.title).
+		Detail("Line %v of %v", viewPos.ActiveRowIndex()+1, lineNum))
+}
+
+// RenderStatusBar does nothing
+func (textView *TextView) RenderStatusBar(lineBuilder *LineBuilder) (err error) {
+	return
+}
+
+// RenderHelpBar generates key binding help info for the text view
+func (textView *TextView) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
+	RenderKeyBindingHelp(textView.ViewID(), lineBuilder, []ActionMessage{
+		{action: ActionPrompt, message: "Command Prompt"},
+		{action: ActionNextTab, message: "Next Tab"},
+	})
+
+	return
+}
+
+// HandleKeyPress does nothing
+func (textView *TextView) HandleKeyPress(keystring string) (err error) {
+	log.Debugf("TextView handling key %v - NOP", keystring)
+	return
+}
+
+// HandleAction checks if the text view supports the provided action and executes it if so
+func (textView *TextView) HandleAction(action Action) (err error) {
+	log.Debugf("TextView handling action %v", action)
+	textView.lock.Lock()
+	defer textView.lock.Unlock()
+
+	if handler, ok := textView.handlers[action.ActionType]; ok {
+		err = handler(textView, action)
+	}
+
+	return
+}
+
+// OnActiveChange updates whether the text view is active
+func (textView *TextView) OnActiveChange(active bool) {
+	log.Debugf("TextView active: %v", active)
+	textView.lock.Lock()
+	defer textView.lock.Unlock()
+
+	textView.active = active
+}
+
+// ViewID returns the view ID of the text view
+func (textView *TextView) ViewID() ViewID {
+	return ViewText
+}
+
+func moveUpTextLine(textView *TextView, action Action) (err error) {
+	if textView.viewPos.MoveLinesUp(action.RepeatCount()) {
+		textView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveDownTextLine(textView *TextView, action Action) (err error) {
+	if textView.viewPos.MoveLinesDown(action.RepeatCount(), uint(len(textView.lines))) {
+		textView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToFirstTextLine(textView *TextView, action Action) (err error) {
+	if textView.viewPos.MoveToFirstLine() {
+		textView.channels.UpdateDisplay()
+	}
+
+	return
+}
+
+func moveToLastTextLine(textView *TextView, action Action) (err error) {
+	if textView.viewPos.MoveToLastLine(uint(len(textView.lines))) {
+		textView.channels.UpdateDisplay()
+	}
+
+	return
+}
@@ -0,0 +1,309 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	log "github.com/Sirupsen/logrus"
+	git "gopkg.in/libgit2/git2go.v25"
+)
+
+const (
+	tcConfigPrefix = "diff."
+	tcConfigSuffix = ".textconv"
+	tcDevNull      = "/dev/null"
+)
+
+// textconvDrivers returns the textconv command configured for each diff driver
+func (repoDataLoader *RepoDataLoader) textconvDrivers() (drivers map[string]string) {
+	drivers = make(map[string]string)
+
+	config, err := repoDataLoader.repo.Config()
+	if err != nil {
+		log.Debugf("Unable to load config: %v", err)
+		return
+	}
+	defer config.Free()
+
+	iterator, err := config.NewIteratorGlob(`^diff\..+\.textconv$`)
+	if err != nil {
+		log.Debugf("Unable to iterate over textconv config: %v", err)
+		return
+	}
+	defer iterator.Free()
+
+	for {
+		entry, err := iterator.Next()
+		if err != nil {
+			break
+		}
+
+		driver := strings.TrimSuffix(strings.TrimPrefix(entry.Name, tcConfigPrefix), tcConfigSuffix)
+		if command := strings.TrimSpace(entry.Value); command != "" {
+			drivers[driver] = command
+		} else {
+			delete(drivers, driver)
+		}
+	}
+
+	return
+}
+
+// textconvCommands returns the textconv command to apply to each file of the diff
+// The diff driver of each file is determined by its diff attribute, as for git diff
+func (repoDataLoader *RepoDataLoader) textconvCommands(commitDiff *git.Diff, numDeltas int) map[string]string {
+	drivers := repoDataLoader.textconvDrivers()
+	if len(drivers) == 0 {
+		return nil
+	}
+
+	var paths []string
+	for index := 0; index < numDeltas; index++ {
+		delta, err := commitDiff.GetDelta(index)
+		if err != nil {
+			log.Debugf("Unable to load delta %v: %v", index, err)
+			continue
+		}
+
+		if isSubmoduleDelta(delta) {
+			continue
+		}
+
+		paths = append(paths, delta.NewFile.Path)
+	}
+
+	if len(paths) == 0 {
+		return nil
+	}
+
+	attributes, err := repoDataLoader.diffAttributes(paths)
+	if err != nil {
+		log.Errorf("Unable to determine diff attributes: %v", err)
+		return nil
+	}
+
+	commands := make(map[string]string)
+	for filePath, driver := range attributes {
+		if command, ok := drivers[driver]; ok {
+			commands[filePath] = command
+		}
+	}
+
+	return commands
+}
+
+// diffAttributes returns the diff driver named by the diff attribute of each path
+// Paths which do not name a diff driver are omitted
+func (repoDataLoader *RepoDataLoader) diffAttributes(paths []string) (attributes map[string]string, err error) {
+	cmd := exec.Command("git", "check-attr", "-z", "--stdin", "diff")
+	cmd.Dir = repoDataLoader.repo.Workdir()
+	if cmd.Dir == "" {
+		cmd.Dir = repoDataLoader.repo.Path()
+	}
+
+	cmd.Env = append(os.Environ(), "GIT_DIR="+repoDataLoader.repo.Path())
+	cmd.Stdin = strings.NewReader(strings.Join(paths, "\x00") + "\x00")
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("git check-attr failed: %v %v", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return parseDiffAttributes(output), nil
+}
+
+// parseDiffAttributes parses the output of git check-attr -z which consists of
+// NUL separated path, attribute and value triples
+func parseDiffAttributes(output []byte) (attributes map[string]string) {
+	attributes = make(map[string]string)
+	fields := strings.Split(string(output), "\x00")
+
+	for index := 0; index+2 < len(fields); index += 3 {
+		filePath, value := fields[index], fields[index+2]
+
+		switch value {
+		case "unspecified", "unset", "set", "":
+			continue
+		}
+
+		attributes[filePath] = value
+	}
+
+	return
+}
+
+// textconvPatch replaces the content of a patch with a diff of the old and new versions
+// of the file after they have been converted to text by the textconv command.
+// The header lines of the patch are retained
+func textconvPatch(patchString string, delta git.DiffDelta, oldContents, newContents []byte, command, workdir string, contextLines int) (string, error) {
+	oldText, err := textconvBlob(delta.OldFile.Oid, oldContents, command, workdir)
+	if err != nil {
+		return "", err
+	}
+
+	newText, err := textconvBlob(delta.NewFile.Oid, newContents, command, workdir)
+	if err != nil {
+		return "", err
+	}
+
+	output, err := diffTexts(oldText, newText, workdir, contextLines)
+	if err != nil {
+		return "", err
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(patchHeader(patchString))
+
+	if output == "" {
+		return buffer.String(), nil
+	}
+
+	oldPath, newPath := "a/"+delta.OldFile.Path, "b/"+delta.NewFile.Path
+	if delta.Status == git.DeltaAdded {
+		oldPath = tcDevNull
+	} else if delta.Status == git.DeltaDeleted {
+		newPath = tcDevNull
+	}
+
+	if hunks := diffOutputHunks(output); hunks != "" {
+		buffer.WriteString(fmt.Sprintf("--- %v\n+++ %v\n", oldPath, newPath))
+		buffer.WriteString(hunks)
+	} else {
+		buffer.WriteString(fmt.Sprintf("Binary files %v and %v differ\n", oldPath, newPath))
+	}
+
+	return buffer.String(), nil
+}
+
+// diffTexts generates the diff of the old and new text using git diff --no-index so the
+// hunks are the same as those generated by git diff --textconv. The output is empty if the texts are the same
+func diffTexts(oldText, newText, workdir string, contextLines int) (output string, err error) {
+	if oldText == newText {
+		return
+	}
+
+	oldFile, err := writeTempFile("grv-textconv-old-", []byte(oldText))
+	if err != nil {
+		return
+	}
+	defer removeTempFile(oldFile)
+
+	newFile, err := writeTempFile("grv-textconv-new-", []byte(newText))
+	if err != nil {
+		return
+	}
+	defer removeTempFile(newFile)
+
+	cmd := exec.Command("git", "diff", "--no-index", "--no-color", "--no-ext-diff",
+		fmt.Sprintf("-U%v", contextLines), "--", oldFile, newFile)
+	cmd.Dir = workdir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	// git diff --no-index exits with status 1 when the files differ
+	outputBytes, err := cmd.Output()
+	if exitErr, ok := err.(*exec.ExitError); ok {
+		if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.ExitStatus() == 1 {
+			err = nil
+		}
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("Unable to diff textconv output: %v %v", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return string(outputBytes), nil
+}
+
+// diffOutputHunks returns the hunks of the output of git diff
+// The result is empty if the output contains no hunks, as is the case for binary files
+func diffOutputHunks(output string) string {
+	if strings.HasPrefix(output, "@@") {
+		return output
+	}
+
+	if index := strings.Index(output, "\n@@"); index != -1 {
+		return output[index+1:]
+	}
+
+	return ""
+}
+
+// textconvBlob converts the contents of a blob to text using the textconv command
+// As with git, the command is run by the shell with the path of a temporary file containing the blob appended
+func textconvBlob(oid *git.Oid, contents []byte, command, workdir string) (text string, err error) {
+	if oid == nil || oid.IsZero() {
+		return
+	}
+
+	fileName, err := writeTempFile("grv-textconv-", contents)
+	if err != nil {
+		return
+	}
+	defer removeTempFile(fileName)
+
+	cmd := exec.Command("sh", "-c", command+` "$@"`, command, fileName)
+	cmd.Dir = workdir
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("textconv command \"%v\" failed: %v %v", command, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return string(output), nil
+}
+
+// writeTempFile writes the contents to a new temporary file and returns its name
+func writeTempFile(prefix string, contents []byte) (fileName string, err error) {
+	file, err := ioutil.TempFile("", prefix)
+	if err != nil {
+		return
+	}
+
+	_, err = file.Write(contents)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		removeTempFile(file.Name())
+		return
+	}
+
+	return file.Name(), nil
+}
+
+func removeTempFile(fileName string) {
+	if err := os.Remove(fileName); err != nil {
+		log.Errorf("Unable to remove %v: %v", fileName, err)
+	}
+}
+
+// patchHeader returns the lines of a patch which precede the old and new file names,
+// binary file notice or first hunk
+func patchHeader(patchString string) string {
+	var buffer bytes.Buffer
+
+	for _, line := range strings.SplitAfter(patchString, "\n") {
+		if strings.HasPrefix(line, "--- ") || strings.HasPrefix(line, "@@") ||
+			strings.HasPrefix(line, "Binary files ") || strings.HasPrefix(line, "GIT binary patch") {
+			break
+		}
+
+		buffer.WriteString(line)
+	}
+
+	return buffer.String()
+}
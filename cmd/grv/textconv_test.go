@@ -0,0 +1,106 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseDiffAttributes(t *testing.T) {
+	output := []byte("doc/a.pdf\x00diff\x00pdf\x00main.go\x00diff\x00unspecified\x00image.png\x00diff\x00unset\x00notes.txt\x00diff\x00set\x00")
+
+	expectedAttributes := map[string]string{
+		"doc/a.pdf": "pdf",
+	}
+
+	if attributes := parseDiffAttributes(output); !reflect.DeepEqual(attributes, expectedAttributes) {
+		t.Errorf("Attributes do not match expected value. Expected: %v, Actual: %v", expectedAttributes, attributes)
+	}
+}
+
+func TestPatchHeader(t *testing.T) {
+	var headerTests = []struct {
+		patch          string
+		expectedHeader string
+	}{
+		{
+			patch:          "diff --git a/a.pdf b/a.pdf\nindex 1234567..89abcde 100644\nBinary files a/a.pdf and b/a.pdf differ\n",
+			expectedHeader: "diff --git a/a.pdf b/a.pdf\nindex 1234567..89abcde 100644\n",
+		},
+		{
+			patch:          "diff --git a/a.txt b/a.txt\nnew file mode 100644\nindex 0000000..89abcde\n--- /dev/null\n+++ b/a.txt\n@@ -0,0 +1 @@\n+a\n",
+			expectedHeader: "diff --git a/a.txt b/a.txt\nnew file mode 100644\nindex 0000000..89abcde\n",
+		},
+	}
+
+	for _, headerTest := range headerTests {
+		if header := patchHeader(headerTest.patch); header != headerTest.expectedHeader {
+			t.Errorf("Header does not match expected value. Expected: %q, Actual: %q", headerTest.expectedHeader, header)
+		}
+	}
+}
+
+func TestDiffOutputHunks(t *testing.T) {
+	var hunkTests = []struct {
+		output        string
+		expectedHunks string
+	}{
+		{
+			output:        "diff --git a/old b/new\nindex 1234567..89abcde 100644\n--- a/old\n+++ b/new\n@@ -1 +1 @@\n-a\n+b\n",
+			expectedHunks: "@@ -1 +1 @@\n-a\n+b\n",
+		},
+		{
+			output:        "@@ -0,0 +1 @@\n+a\n",
+			expectedHunks: "@@ -0,0 +1 @@\n+a\n",
+		},
+		{
+			output:        "diff --git a/old b/new\nindex 1234567..89abcde 100644\nBinary files a/old and b/new differ\n",
+			expectedHunks: "",
+		},
+	}
+
+	for _, hunkTest := range hunkTests {
+		if hunks := diffOutputHunks(hunkTest.output); hunks != hunkTest.expectedHunks {
+			t.Errorf("Hunks do not match expected value. Expected: %q, Actual: %q", hunkTest.expectedHunks, hunks)
+		}
+	}
+}
+
+func TestDiffTextsGeneratesGitDiffHunks(t *testing.T) {
+	var diffTests = []struct {
+		oldText       string
+		newText       string
+		contextLines  int
+		expectedHunks string
+	}{
+		{
+			oldText:       "a\nb\nc\n",
+			newText:       "a\nb\nc\n",
+			contextLines:  3,
+			expectedHunks: "",
+		},
+		{
+			oldText:       "",
+			newText:       "a\nb\n",
+			contextLines:  3,
+			expectedHunks: "@@ -0,0 +1,2 @@\n+a\n+b\n",
+		},
+		{
+			oldText:       "a\nb\nc\n",
+			newText:       "a\nx\nc\n",
+			contextLines:  1,
+			expectedHunks: "@@ -1,3 +1,3 @@\n a\n-b\n+x\n c\n",
+		},
+	}
+
+	for _, diffTest := range diffTests {
+		output, err := diffTexts(diffTest.oldText, diffTest.newText, "", diffTest.contextLines)
+		if err != nil {
+			t.Errorf("Unexpected error: %v", err)
+			continue
+		}
+
+		if hunks := diffOutputHunks(output); hunks != diffTest.expectedHunks {
+			t.Errorf("Hunks do not match expected value. Expected: %q, Actual: %q", diffTest.expectedHunks, hunks)
+		}
+	}
+}
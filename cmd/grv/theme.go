@@ -9,6 +9,27 @@ const (
 	CmpNone ThemeComponentID = iota
 
 	CmpAllviewSearchMatch
+	CmpAllviewLineNumber
+	CmpAllviewInvisible
+	CmpAllviewScrollBarThumb
+	CmpAllviewScrollBarHunk
+	CmpAllviewScrollBarSearchMatch
+	CmpAllviewAnsiBlack
+	CmpAllviewAnsiRed
+	CmpAllviewAnsiGreen
+	CmpAllviewAnsiYellow
+	CmpAllviewAnsiBlue
+	CmpAllviewAnsiMagenta
+	CmpAllviewAnsiCyan
+	CmpAllviewAnsiWhite
+	CmpAllviewAnsiBlackBackground
+	CmpAllviewAnsiRedBackground
+	CmpAllviewAnsiGreenBackground
+	CmpAllviewAnsiYellowBackground
+	CmpAllviewAnsiBlueBackground
+	CmpAllviewAnsiMagentaBackground
+	CmpAllviewAnsiCyanBackground
+	CmpAllviewAnsiWhiteBackground
 
 	CmpRefviewTitle
 	CmpRefviewFooter
@@ -18,6 +39,15 @@ const (
 	CmpRefviewRemoteBranch
 	CmpRefviewTagsHeader
 	CmpRefviewTag
+	CmpRefviewStashesHeader
+	CmpRefviewStash
+	CmpRefviewUnreachableCommitsHeader
+	CmpRefviewUnreachableCommit
+	CmpRefviewRecentBranchesHeader
+	CmpRefviewRecentBranch
+	CmpRefviewPinnedRefsHeader
+	CmpRefviewPinnedRef
+	CmpRefviewComparison
 
 	CmpCommitviewTitle
 	CmpCommitviewFooter
@@ -25,6 +55,9 @@ const (
 	CmpCommitviewDate
 	CmpCommitviewAuthor
 	CmpCommitviewSummary
+	CmpCommitviewConventionalType
+	CmpCommitviewConventionalScope
+	CmpCommitviewConventionalBreaking
 	CmpCommitviewTag
 	CmpCommitviewLocalBranch
 	CmpCommitviewRemoteBranch
@@ -34,17 +67,24 @@ const (
 	CmpDiffviewDifflineDiffCommitAuthorDate
 	CmpDiffviewDifflineDiffCommitCommitter
 	CmpDiffviewDifflineDiffCommitCommitterDate
+	CmpDiffviewDifflineDiffCommitDescribe
+	CmpDiffviewDifflineDiffCommitTrailer
+	CmpDiffviewDifflineDiffCommitLint
 	CmpDiffviewDifflineDiffCommitSummary
 	CmpDiffviewDifflineDiffStatsFile
 	CmpDiffviewDifflineGitDiffHeader
 	CmpDiffviewDifflineGitDiffExtendedHeader
+	CmpDiffviewDifflineModeChange
 	CmpDiffviewDifflineUnifiedDiffHeader
 	CmpDiffviewDifflineHunkStart
 	CmpDiffviewDifflineHunkHeader
 	CmpDiffviewDifflineLineAdded
 	CmpDiffviewDifflineLineRemoved
+	CmpDiffviewDifflineWhitespaceError
+	CmpDiffviewSelection
 
 	CmpStatusbarviewNormal
+	CmpStatusbarviewProgressBar
 
 	CmpHelpbarviewSpecial
 	CmpHelpbarviewNormal
@@ -53,6 +93,47 @@ const (
 	CmpErrorViewFooter
 	CmpErrorViewErrors
 
+	CmpSummaryviewTitle
+	CmpSummaryviewLabel
+	CmpSummaryviewHeader
+
+	CmpFuzzyfinderviewTitle
+	CmpFuzzyfinderviewFooter
+	CmpFuzzyfinderviewType
+
+	CmpDialogviewTitle
+	CmpDialogviewFooter
+	CmpDialogviewMessage
+	CmpDialogviewInput
+
+	CmpConfigvariablesviewTitle
+	CmpConfigvariablesviewName
+	CmpConfigvariablesviewValue
+	CmpConfigvariablesviewSource
+
+	CmpLogviewTitle
+	CmpLogviewTime
+	CmpLogviewLevel
+	CmpLogviewFile
+	CmpLogviewMessage
+	CmpLogviewFields
+
+	CmpTextviewTitle
+	CmpTextviewLine
+
+	CmpFileviewTitle
+	CmpFileviewLine
+	CmpFileviewKeyword
+	CmpFileviewString
+	CmpFileviewComment
+	CmpFileviewNumber
+	CmpFileviewFold
+	CmpFileviewBlame
+
+	CmpQuickfixviewTitle
+	CmpQuickfixviewLocation
+	CmpQuickfixviewLine
+
 	CmpCount
 )
 
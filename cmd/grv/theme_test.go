@@ -78,3 +78,61 @@ func TestGetAllComponentsDefaultsComponentsNotSet(t *testing.T) {
 		checkThemeComponent(expectedThemeComponent, actualThemeComponent, t)
 	}
 }
+
+func TestMonochromeAttributesDistinguishDiffLines(t *testing.T) {
+	added := monochromeAttributes[CmpDiffviewDifflineLineAdded]
+	removed := monochromeAttributes[CmpDiffviewDifflineLineRemoved]
+	normal := monochromeAttributes[CmpDiffviewDifflineNormal]
+
+	if added == removed || added == normal || removed == normal {
+		t.Errorf("Expected distinct attributes for added, removed and normal diff lines. Added: %v, Removed: %v, Normal: %v", added, removed, normal)
+	}
+}
+
+func TestColorBlindThemesDoNotUseRedOrGreen(t *testing.T) {
+	for themeName, theme := range map[string]Theme{
+		cfDeuteranopiaThemeName: NewDeuteranopiaTheme(),
+		cfProtanopiaThemeName:   NewProtanopiaTheme(),
+	} {
+		for themeComponentID, themeComponent := range theme.GetAllComponents() {
+			for _, color := range []ThemeColor{themeComponent.bgcolor, themeComponent.fgcolor} {
+				if color == ColorRed || color == ColorGreen {
+					t.Errorf("Theme %v uses red or green for component %v", themeName, themeComponentID)
+				}
+			}
+		}
+
+		added := theme.GetComponent(CmpDiffviewDifflineLineAdded)
+		removed := theme.GetComponent(CmpDiffviewDifflineLineRemoved)
+
+		if added == removed {
+			t.Errorf("Theme %v displays added and removed diff lines with the same colors: %v", themeName, added)
+		}
+	}
+}
+
+func TestRemappedThemeKeepsTextReadableOnLightBackgrounds(t *testing.T) {
+	theme := NewTheme()
+	*theme.CreateOrGetComponent(CmpErrorViewErrors) = ThemeComponent{
+		bgcolor: ColorRed,
+		fgcolor: ColorWhite,
+	}
+
+	remappedTheme := newRemappedTheme(theme, map[ThemeColor]ThemeColor{ColorRed: ColorYellow})
+	expectedThemeComponent := ThemeComponent{
+		bgcolor: ColorYellow,
+		fgcolor: ColorBlack,
+	}
+
+	checkThemeComponent(expectedThemeComponent, remappedTheme.GetComponent(CmpErrorViewErrors), t)
+}
+
+func TestBuiltinThemesAreAvailable(t *testing.T) {
+	themes := NewBuiltinThemes()
+
+	for _, themeName := range []string{cfThemeDefaultValue, cfColdThemeName, cfDeuteranopiaThemeName, cfProtanopiaThemeName} {
+		if _, ok := themes[themeName]; !ok {
+			t.Errorf("Expected built-in theme %v to be available", themeName)
+		}
+	}
+}
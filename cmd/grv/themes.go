@@ -1,13 +1,106 @@
 package main
 
+import (
+	gc "github.com/rthornton128/goncurses"
+)
+
+// monochromeAttributes are used in place of theme colors on terminals which do not support color
+// Components not listed are displayed without any attributes
+var monochromeAttributes = map[ThemeComponentID]gc.Char{
+	CmpAllviewSearchMatch: gc.A_BOLD | gc.A_UNDERLINE,
+	CmpAllviewInvisible:   gc.A_DIM,
+
+	CmpAllviewScrollBarHunk:        gc.A_BOLD,
+	CmpAllviewScrollBarSearchMatch: gc.A_BOLD | gc.A_UNDERLINE,
+
+	CmpRefviewTitle:                    gc.A_BOLD,
+	CmpRefviewLocalBranchesHeader:      gc.A_BOLD,
+	CmpRefviewRemoteBranchesHeader:     gc.A_BOLD,
+	CmpRefviewTagsHeader:               gc.A_BOLD,
+	CmpRefviewStashesHeader:            gc.A_BOLD,
+	CmpRefviewUnreachableCommitsHeader: gc.A_BOLD,
+	CmpRefviewRecentBranchesHeader:     gc.A_BOLD,
+	CmpRefviewPinnedRefsHeader:         gc.A_BOLD,
+
+	CmpCommitviewTitle:                gc.A_BOLD,
+	CmpCommitviewTag:                  gc.A_BOLD,
+	CmpCommitviewLocalBranch:          gc.A_BOLD,
+	CmpCommitviewRemoteBranch:         gc.A_BOLD,
+	CmpCommitviewConventionalBreaking: gc.A_BOLD,
+
+	CmpDiffviewDifflineGitDiffHeader:         gc.A_BOLD,
+	CmpDiffviewDifflineGitDiffExtendedHeader: gc.A_BOLD,
+	CmpDiffviewDifflineModeChange:            gc.A_BOLD,
+	CmpDiffviewDifflineWhitespaceError:       gc.A_REVERSE,
+	CmpDiffviewSelection:                     gc.A_STANDOUT,
+	CmpDiffviewDifflineUnifiedDiffHeader:     gc.A_BOLD,
+	CmpDiffviewDifflineHunkStart:             gc.A_BOLD,
+	CmpDiffviewDifflineLineAdded:             gc.A_BOLD,
+	CmpDiffviewDifflineLineRemoved:           gc.A_UNDERLINE,
+	CmpDiffviewDifflineDiffCommitLint:        gc.A_BOLD,
+
+	CmpStatusbarviewNormal:      gc.A_REVERSE,
+	CmpStatusbarviewProgressBar: gc.A_BOLD,
+
+	CmpHelpbarviewSpecial: gc.A_BOLD,
+
+	CmpErrorViewTitle:  gc.A_BOLD,
+	CmpErrorViewErrors: gc.A_BOLD,
+
+	CmpSummaryviewTitle:  gc.A_BOLD,
+	CmpSummaryviewHeader: gc.A_BOLD | gc.A_UNDERLINE,
+
+	CmpFuzzyfinderviewTitle: gc.A_BOLD,
+	CmpFuzzyfinderviewType:  gc.A_BOLD,
+
+	CmpDialogviewTitle: gc.A_BOLD,
+	CmpDialogviewInput: gc.A_UNDERLINE,
+
+	CmpConfigvariablesviewTitle: gc.A_BOLD,
+	CmpConfigvariablesviewName:  gc.A_BOLD,
+
+	CmpLogviewTitle: gc.A_BOLD,
+	CmpLogviewLevel: gc.A_BOLD,
+
+	CmpTextviewTitle: gc.A_BOLD,
+
+	CmpFileviewTitle:   gc.A_BOLD,
+	CmpFileviewKeyword: gc.A_BOLD,
+	CmpFileviewComment: gc.A_DIM,
+	CmpFileviewFold:    gc.A_UNDERLINE,
+
+	CmpQuickfixviewTitle:    gc.A_BOLD,
+	CmpQuickfixviewLocation: gc.A_BOLD,
+}
+
 // NewDefaultTheme creates the default theme of grv
 func NewDefaultTheme() MutableTheme {
-	return &ThemeComponents{
+	theme := &ThemeComponents{
 		components: map[ThemeComponentID]*ThemeComponent{
 			CmpAllviewSearchMatch: {
 				bgcolor: ColorYellow,
 				fgcolor: ColorNone,
 			},
+			CmpAllviewLineNumber: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
+			CmpAllviewInvisible: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpAllviewScrollBarThumb: {
+				bgcolor: ColorNone,
+				fgcolor: ColorWhite,
+			},
+			CmpAllviewScrollBarHunk: {
+				bgcolor: ColorNone,
+				fgcolor: ColorMagenta,
+			},
+			CmpAllviewScrollBarSearchMatch: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
 			CmpCommitviewTitle: {
 				bgcolor: ColorNone,
 				fgcolor: ColorCyan,
@@ -32,6 +125,18 @@ func NewDefaultTheme() MutableTheme {
 				bgcolor: ColorNone,
 				fgcolor: ColorNone,
 			},
+			CmpCommitviewConventionalType: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
+			CmpCommitviewConventionalScope: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpCommitviewConventionalBreaking: {
+				bgcolor: ColorNone,
+				fgcolor: ColorRed,
+			},
 			CmpCommitviewTag: {
 				bgcolor: ColorNone,
 				fgcolor: ColorRed,
@@ -64,6 +169,18 @@ func NewDefaultTheme() MutableTheme {
 				bgcolor: ColorNone,
 				fgcolor: ColorYellow,
 			},
+			CmpDiffviewDifflineDiffCommitDescribe: {
+				bgcolor: ColorNone,
+				fgcolor: ColorGreen,
+			},
+			CmpDiffviewDifflineDiffCommitTrailer: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpDiffviewDifflineDiffCommitLint: {
+				bgcolor: ColorNone,
+				fgcolor: ColorRed,
+			},
 			CmpDiffviewDifflineDiffCommitSummary: {
 				bgcolor: ColorNone,
 				fgcolor: ColorNone,
@@ -80,6 +197,10 @@ func NewDefaultTheme() MutableTheme {
 				bgcolor: ColorNone,
 				fgcolor: ColorBlue,
 			},
+			CmpDiffviewDifflineModeChange: {
+				bgcolor: ColorNone,
+				fgcolor: ColorMagenta,
+			},
 			CmpDiffviewDifflineUnifiedDiffHeader: {
 				bgcolor: ColorNone,
 				fgcolor: ColorYellow,
@@ -100,6 +221,14 @@ func NewDefaultTheme() MutableTheme {
 				bgcolor: ColorNone,
 				fgcolor: ColorRed,
 			},
+			CmpDiffviewDifflineWhitespaceError: {
+				bgcolor: ColorRed,
+				fgcolor: ColorNone,
+			},
+			CmpDiffviewSelection: {
+				bgcolor: ColorBlue,
+				fgcolor: ColorWhite,
+			},
 			CmpRefviewTitle: {
 				bgcolor: ColorNone,
 				fgcolor: ColorCyan,
@@ -132,10 +261,50 @@ func NewDefaultTheme() MutableTheme {
 				bgcolor: ColorNone,
 				fgcolor: ColorNone,
 			},
+			CmpRefviewStashesHeader: {
+				bgcolor: ColorNone,
+				fgcolor: ColorMagenta,
+			},
+			CmpRefviewStash: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpRefviewUnreachableCommitsHeader: {
+				bgcolor: ColorNone,
+				fgcolor: ColorMagenta,
+			},
+			CmpRefviewUnreachableCommit: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpRefviewRecentBranchesHeader: {
+				bgcolor: ColorNone,
+				fgcolor: ColorMagenta,
+			},
+			CmpRefviewRecentBranch: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpRefviewPinnedRefsHeader: {
+				bgcolor: ColorNone,
+				fgcolor: ColorMagenta,
+			},
+			CmpRefviewPinnedRef: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpRefviewComparison: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
 			CmpStatusbarviewNormal: {
 				bgcolor: ColorBlue,
 				fgcolor: ColorYellow,
 			},
+			CmpStatusbarviewProgressBar: {
+				bgcolor: ColorBlue,
+				fgcolor: ColorGreen,
+			},
 			CmpHelpbarviewSpecial: {
 				bgcolor: ColorNone,
 				fgcolor: ColorMagenta,
@@ -156,18 +325,174 @@ func NewDefaultTheme() MutableTheme {
 				bgcolor: ColorRed,
 				fgcolor: ColorWhite,
 			},
+			CmpSummaryviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpSummaryviewLabel: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
+			CmpSummaryviewHeader: {
+				bgcolor: ColorNone,
+				fgcolor: ColorMagenta,
+			},
+			CmpFuzzyfinderviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpFuzzyfinderviewFooter: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpFuzzyfinderviewType: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
+			CmpDialogviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpDialogviewFooter: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpDialogviewMessage: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpDialogviewInput: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
+			CmpConfigvariablesviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpConfigvariablesviewName: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
+			CmpConfigvariablesviewValue: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpConfigvariablesviewSource: {
+				bgcolor: ColorNone,
+				fgcolor: ColorMagenta,
+			},
+			CmpLogviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpLogviewTime: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpLogviewLevel: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
+			CmpLogviewFile: {
+				bgcolor: ColorNone,
+				fgcolor: ColorMagenta,
+			},
+			CmpLogviewMessage: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpLogviewFields: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpTextviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpTextviewLine: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpFileviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpFileviewLine: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpFileviewKeyword: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
+			CmpFileviewString: {
+				bgcolor: ColorNone,
+				fgcolor: ColorGreen,
+			},
+			CmpFileviewComment: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpFileviewNumber: {
+				bgcolor: ColorNone,
+				fgcolor: ColorMagenta,
+			},
+			CmpFileviewFold: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpFileviewBlame: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
+			CmpQuickfixviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpQuickfixviewLocation: {
+				bgcolor: ColorNone,
+				fgcolor: ColorGreen,
+			},
+			CmpQuickfixviewLine: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
 		},
 	}
+
+	addANSIThemeComponents(theme)
+
+	return theme
 }
 
 // NewColdTheme creates the cold theme of grv
 func NewColdTheme() MutableTheme {
-	return &ThemeComponents{
+	theme := &ThemeComponents{
 		components: map[ThemeComponentID]*ThemeComponent{
 			CmpAllviewSearchMatch: {
 				bgcolor: ColorYellow,
 				fgcolor: ColorNone,
 			},
+			CmpAllviewLineNumber: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
+			CmpAllviewInvisible: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpAllviewScrollBarThumb: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpAllviewScrollBarHunk: {
+				bgcolor: ColorNone,
+				fgcolor: ColorMagenta,
+			},
+			CmpAllviewScrollBarSearchMatch: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
 			CmpCommitviewTitle: {
 				bgcolor: ColorNone,
 				fgcolor: ColorBlue,
@@ -192,6 +517,18 @@ func NewColdTheme() MutableTheme {
 				bgcolor: ColorNone,
 				fgcolor: ColorNone,
 			},
+			CmpCommitviewConventionalType: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpCommitviewConventionalScope: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpCommitviewConventionalBreaking: {
+				bgcolor: ColorNone,
+				fgcolor: ColorMagenta,
+			},
 			CmpCommitviewTag: {
 				bgcolor: ColorNone,
 				fgcolor: ColorMagenta,
@@ -224,6 +561,18 @@ func NewColdTheme() MutableTheme {
 				bgcolor: ColorNone,
 				fgcolor: ColorCyan,
 			},
+			CmpDiffviewDifflineDiffCommitDescribe: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpDiffviewDifflineDiffCommitTrailer: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpDiffviewDifflineDiffCommitLint: {
+				bgcolor: ColorNone,
+				fgcolor: ColorRed,
+			},
 			CmpDiffviewDifflineDiffCommitSummary: {
 				bgcolor: ColorNone,
 				fgcolor: ColorMagenta,
@@ -240,6 +589,10 @@ func NewColdTheme() MutableTheme {
 				bgcolor: ColorNone,
 				fgcolor: ColorNone,
 			},
+			CmpDiffviewDifflineModeChange: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
 			CmpDiffviewDifflineUnifiedDiffHeader: {
 				bgcolor: ColorNone,
 				fgcolor: ColorNone,
@@ -260,6 +613,14 @@ func NewColdTheme() MutableTheme {
 				bgcolor: ColorNone,
 				fgcolor: ColorRed,
 			},
+			CmpDiffviewDifflineWhitespaceError: {
+				bgcolor: ColorRed,
+				fgcolor: ColorNone,
+			},
+			CmpDiffviewSelection: {
+				bgcolor: ColorCyan,
+				fgcolor: ColorBlack,
+			},
 			CmpRefviewTitle: {
 				bgcolor: ColorNone,
 				fgcolor: ColorBlue,
@@ -292,10 +653,50 @@ func NewColdTheme() MutableTheme {
 				bgcolor: ColorNone,
 				fgcolor: ColorNone,
 			},
+			CmpRefviewStashesHeader: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpRefviewStash: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpRefviewUnreachableCommitsHeader: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpRefviewUnreachableCommit: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpRefviewRecentBranchesHeader: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpRefviewRecentBranch: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpRefviewPinnedRefsHeader: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpRefviewPinnedRef: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpRefviewComparison: {
+				bgcolor: ColorNone,
+				fgcolor: ColorYellow,
+			},
 			CmpStatusbarviewNormal: {
 				bgcolor: ColorCyan,
 				fgcolor: ColorWhite,
 			},
+			CmpStatusbarviewProgressBar: {
+				bgcolor: ColorCyan,
+				fgcolor: ColorBlue,
+			},
 			CmpHelpbarviewSpecial: {
 				bgcolor: ColorNone,
 				fgcolor: ColorCyan,
@@ -316,6 +717,218 @@ func NewColdTheme() MutableTheme {
 				bgcolor: ColorRed,
 				fgcolor: ColorWhite,
 			},
+			CmpSummaryviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpSummaryviewLabel: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpSummaryviewHeader: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpFuzzyfinderviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpFuzzyfinderviewFooter: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpFuzzyfinderviewType: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpDialogviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpDialogviewFooter: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpDialogviewMessage: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpDialogviewInput: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpConfigvariablesviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpConfigvariablesviewName: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpConfigvariablesviewValue: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpConfigvariablesviewSource: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpLogviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpLogviewTime: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpLogviewLevel: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpLogviewFile: {
+				bgcolor: ColorNone,
+				fgcolor: ColorGreen,
+			},
+			CmpLogviewMessage: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpLogviewFields: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpTextviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpTextviewLine: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpFileviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpFileviewLine: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
+			CmpFileviewKeyword: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpFileviewString: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpFileviewComment: {
+				bgcolor: ColorNone,
+				fgcolor: ColorWhite,
+			},
+			CmpFileviewNumber: {
+				bgcolor: ColorNone,
+				fgcolor: ColorMagenta,
+			},
+			CmpFileviewFold: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpFileviewBlame: {
+				bgcolor: ColorNone,
+				fgcolor: ColorMagenta,
+			},
+			CmpQuickfixviewTitle: {
+				bgcolor: ColorNone,
+				fgcolor: ColorBlue,
+			},
+			CmpQuickfixviewLocation: {
+				bgcolor: ColorNone,
+				fgcolor: ColorCyan,
+			},
+			CmpQuickfixviewLine: {
+				bgcolor: ColorNone,
+				fgcolor: ColorNone,
+			},
 		},
 	}
+
+	addANSIThemeComponents(theme)
+
+	return theme
+}
+
+// builtinThemes contains the functions which create each built-in theme keyed by theme name
+var builtinThemes = map[string]func() MutableTheme{
+	cfThemeDefaultValue:     NewDefaultTheme,
+	cfColdThemeName:         NewColdTheme,
+	cfDeuteranopiaThemeName: NewDeuteranopiaTheme,
+	cfProtanopiaThemeName:   NewProtanopiaTheme,
+}
+
+// NewBuiltinThemes creates an instance of each built-in theme keyed by theme name
+func NewBuiltinThemes() map[string]MutableTheme {
+	themes := make(map[string]MutableTheme, len(builtinThemes))
+
+	for themeName, newTheme := range builtinThemes {
+		themes[themeName] = newTheme()
+	}
+
+	return themes
+}
+
+// deuteranopiaColors replaces red and green, which are hard to tell apart with deuteranopia,
+// so that added and removed diff lines are displayed in blue and yellow
+var deuteranopiaColors = map[ThemeColor]ThemeColor{
+	ColorGreen:  ColorBlue,
+	ColorRed:    ColorYellow,
+	ColorYellow: ColorWhite,
+	ColorBlue:   ColorCyan,
+}
+
+// protanopiaColors replaces red, which appears dark with protanopia, and green
+// so that added and removed diff lines are displayed in cyan and yellow
+var protanopiaColors = map[ThemeColor]ThemeColor{
+	ColorGreen:   ColorCyan,
+	ColorRed:     ColorYellow,
+	ColorYellow:  ColorWhite,
+	ColorCyan:    ColorBlue,
+	ColorMagenta: ColorWhite,
+}
+
+// NewDeuteranopiaTheme creates a variant of the default theme for users with deuteranopia
+func NewDeuteranopiaTheme() MutableTheme {
+	return newRemappedTheme(NewDefaultTheme(), deuteranopiaColors)
+}
+
+// NewProtanopiaTheme creates a variant of the default theme for users with protanopia
+func NewProtanopiaTheme() MutableTheme {
+	return newRemappedTheme(NewDefaultTheme(), protanopiaColors)
+}
+
+// newRemappedTheme creates a copy of the provided theme with colors replaced using the provided mapping
+// Components which would have white or identical text on a light background are displayed with black text instead
+func newRemappedTheme(theme Theme, colorMapping map[ThemeColor]ThemeColor) MutableTheme {
+	remapColor := func(color ThemeColor) ThemeColor {
+		if mappedColor, ok := colorMapping[color]; ok {
+			return mappedColor
+		}
+
+		return color
+	}
+
+	remappedTheme := NewTheme()
+
+	for themeComponentID, themeComponent := range theme.GetAllComponents() {
+		remappedComponent := remappedTheme.CreateOrGetComponent(themeComponentID)
+		remappedComponent.bgcolor = remapColor(themeComponent.bgcolor)
+		remappedComponent.fgcolor = remapColor(themeComponent.fgcolor)
+
+		lightBackground := remappedComponent.bgcolor == ColorYellow || remappedComponent.bgcolor == ColorWhite
+
+		if lightBackground && (remappedComponent.fgcolor == ColorWhite || remappedComponent.fgcolor == remappedComponent.bgcolor) {
+			remappedComponent.fgcolor = ColorBlack
+		}
+	}
+
+	return remappedTheme
 }
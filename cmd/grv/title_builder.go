@@ -0,0 +1,58 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+const (
+	tbBreadcrumbSeparator = " › "
+)
+
+// TitleBuilder constructs a window title from a series of breadcrumbs describing the context
+// of the view, optionally followed by details displayed in parentheses
+type TitleBuilder struct {
+	breadcrumbs []string
+	details     []string
+}
+
+// NewTitleBuilder creates a new title builder with the provided root breadcrumb
+func NewTitleBuilder(root string) *TitleBuilder {
+	return &TitleBuilder{
+		breadcrumbs: []string{root},
+	}
+}
+
+// Breadcrumb appends a breadcrumb to the title
+// Empty breadcrumbs are ignored
+func (titleBuilder *TitleBuilder) Breadcrumb(format string, args ...interface{}) *TitleBuilder {
+	if breadcrumb := fmt.Sprintf(format, args...); breadcrumb != "" {
+		titleBuilder.breadcrumbs = append(titleBuilder.breadcrumbs, breadcrumb)
+	}
+
+	return titleBuilder
+}
+
+// Detail adds information which is displayed in parentheses after the breadcrumbs
+// Empty details are ignored
+func (titleBuilder *TitleBuilder) Detail(format string, args ...interface{}) *TitleBuilder {
+	if detail := fmt.Sprintf(format, args...); detail != "" {
+		titleBuilder.details = append(titleBuilder.details, detail)
+	}
+
+	return titleBuilder
+}
+
+// String returns the constructed title
+func (titleBuilder *TitleBuilder) String() string {
+	var buffer bytes.Buffer
+
+	buffer.WriteString(strings.Join(titleBuilder.breadcrumbs, tbBreadcrumbSeparator))
+
+	if len(titleBuilder.details) > 0 {
+		buffer.WriteString(fmt.Sprintf(" (%v)", strings.Join(titleBuilder.details, ", ")))
+	}
+
+	return buffer.String()
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestTitleBuilder(t *testing.T) {
+	var titleTests = []struct {
+		titleBuilder  *TitleBuilder
+		expectedTitle string
+	}{
+		{
+			titleBuilder:  NewTitleBuilder("Refs"),
+			expectedTitle: "Refs",
+		},
+		{
+			titleBuilder:  NewTitleBuilder("Refs").Breadcrumb("Remote Branches").Breadcrumb("origin"),
+			expectedTitle: "Refs › Remote Branches › origin",
+		},
+		{
+			titleBuilder:  NewTitleBuilder("Commits").Breadcrumb("feature/x").Detail("filtered: %v", "authorname=me"),
+			expectedTitle: "Commits › feature/x (filtered: authorname=me)",
+		},
+		{
+			titleBuilder:  NewTitleBuilder("Diff").Breadcrumb("").Breadcrumb("a1b2c3d").Detail("").Detail("1 of 2").Detail("loading"),
+			expectedTitle: "Diff › a1b2c3d (1 of 2, loading)",
+		},
+	}
+
+	for _, titleTest := range titleTests {
+		if title := titleTest.titleBuilder.String(); title != titleTest.expectedTitle {
+			t.Errorf("Title does not match expected value. Expected: %v, Actual: %v", titleTest.expectedTitle, title)
+		}
+	}
+}
@@ -26,6 +26,7 @@ import "C"
 import (
 	"errors"
 	"os"
+	"os/exec"
 	"sync"
 	"syscall"
 	"time"
@@ -39,6 +40,9 @@ const (
 	// UINoKey is the value returned when there was no user input available
 	UINoKey         = -1
 	inputNoWinSleep = 50 * time.Millisecond
+
+	uiBracketedPasteEnable  = "\x1b[?2004h"
+	uiBracketedPasteDisable = "\x1b[?2004l"
 )
 
 // Key is a raw code received from ncurses
@@ -59,6 +63,8 @@ type UI interface {
 	Update([]*Window) error
 	Suspend()
 	Resume() error
+	RunExternalProgram(cmd *exec.Cmd) error
+	GraphicsProtocol() GraphicsProtocol
 	Free()
 }
 
@@ -93,6 +99,10 @@ type NCursesUI struct {
 	config  Config
 	colors  map[ThemeColor]int16
 	pipe    signalPipe
+	// externalProgramActive is set while an external program has control of the terminal
+	externalProgramActive bool
+	// colorsSupported is false on monochrome terminals, in which case attributes are used in place of theme colors
+	colorsSupported bool
 }
 
 // NewNCursesDisplay creates a new NCursesUI instance
@@ -134,6 +144,7 @@ func (ui *NCursesUI) free() {
 	ui.windows = make(map[*Window]*nCursesWindow)
 
 	log.Info("Ending NCurses")
+	setBracketedPasteMode(false)
 	gc.End()
 }
 
@@ -175,7 +186,9 @@ func (ui *NCursesUI) initialiseNCurses() (err error) {
 
 	ui.stdscr = &nCursesWindow{Window: stdscr}
 
-	if gc.HasColors() {
+	ui.colorsSupported = gc.HasColors()
+
+	if ui.colorsSupported {
 		if e := gc.StartColor(); e != nil {
 			log.Errorf("Error calling StartColor: %v", e)
 		}
@@ -186,6 +199,8 @@ func (ui *NCursesUI) initialiseNCurses() (err error) {
 
 		theme := ui.config.GetTheme()
 		ui.initialiseColorPairsFromTheme(theme)
+	} else {
+		log.Info("Terminal does not support colors. Using monochrome attributes")
 	}
 
 	gc.Echo(false)
@@ -199,27 +214,105 @@ func (ui *NCursesUI) initialiseNCurses() (err error) {
 		return
 	}
 
+	setBracketedPasteMode(true)
+
 	return
 }
 
+// setBracketedPasteMode enables or disables bracketed paste in the terminal.
+// When enabled pasted text is surrounded by escape sequences so that it can
+// be distinguished from key presses
+func setBracketedPasteMode(enabled bool) {
+	sequence := uiBracketedPasteDisable
+	if enabled {
+		sequence = uiBracketedPasteEnable
+	}
+
+	if _, err := os.Stdout.WriteString(sequence); err != nil {
+		log.Errorf("Unable to set bracketed paste mode: %v", err)
+	}
+}
+
 // Suspend ends ncurses to leave the terminal in the correct state when
-// GRV is suspended
+// GRV is suspended. Nothing is done while an external program is running
+// as the external program is responsible for the terminal
 func (ui *NCursesUI) Suspend() {
 	ui.lock.Lock()
 	defer ui.lock.Unlock()
 
+	if ui.externalProgramActive {
+		return
+	}
+
+	setBracketedPasteMode(false)
 	gc.End()
 }
 
-// Resume reinitialises ncurses
+// Resume reinitialises ncurses and redraws the entire display
+// Nothing is done while an external program is running as the display
+// is reinitialised when it exits
 func (ui *NCursesUI) Resume() (err error) {
 	ui.lock.Lock()
 	defer ui.lock.Unlock()
 
+	if ui.externalProgramActive {
+		return
+	}
+
+	ui.stdscr.Clear()
 	ui.stdscr.Refresh()
+
 	return ui.resize()
 }
 
+// RunExternalProgram ends ncurses while the provided command runs in the terminal
+// and reinitialises the display once it has exited
+func (ui *NCursesUI) RunExternalProgram(cmd *exec.Cmd) (err error) {
+	ui.lock.Lock()
+	setBracketedPasteMode(false)
+	gc.End()
+	ui.externalProgramActive = true
+	ui.lock.Unlock()
+
+	if cmd.Stdin == nil {
+		cmd.Stdin = os.Stdin
+	}
+
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+
+	err = cmd.Run()
+
+	ui.lock.Lock()
+	defer ui.lock.Unlock()
+
+	ui.externalProgramActive = false
+	ui.stdscr.Refresh()
+
+	if resizeErr := ui.resize(); err == nil {
+		err = resizeErr
+	}
+
+	return
+}
+
+// GraphicsProtocol returns the protocol images are displayed with
+// The protocol is detected from the terminal environment unless it has been set explicitly
+func (ui *NCursesUI) GraphicsProtocol() GraphicsProtocol {
+	if protocol, ok := graphicsProtocolNames[ui.config.GetString(CfGraphics)]; ok {
+		return protocol
+	}
+
+	return DetectGraphicsProtocol(os.Getenv)
+}
+
+func (ui *NCursesUI) isExternalProgramActive() bool {
+	ui.lock.Lock()
+	defer ui.lock.Unlock()
+
+	return ui.externalProgramActive
+}
+
 // Resize determines the current terminal dimensions reinitialises NCurses
 func (ui *NCursesUI) Resize() (err error) {
 	ui.lock.Lock()
@@ -300,7 +393,7 @@ func (ui *NCursesUI) createAndUpdateWindows(wins []*Window) (err error) {
 			nwin.Resize(0, 0)
 			nwin.NoutRefresh()
 			nwin.setHidden(true)
-			log.Debugf("Hiding NCurses window %v - %v:%v", win.ID())
+			log.Debugf("Hiding NCurses window %v", win.ID())
 		}
 	}
 
@@ -341,7 +434,7 @@ func (ui *NCursesUI) drawWindows(wins []*Window) (err error) {
 
 	for _, win := range wins {
 		if nwin, ok := ui.windows[win]; ok {
-			drawWindow(win, nwin)
+			drawWindow(win, nwin, !ui.colorsSupported)
 
 			if win.IsCursorSet() {
 				cursorWin = win
@@ -367,7 +460,9 @@ func (ui *NCursesUI) drawWindows(wins []*Window) (err error) {
 	return
 }
 
-func drawWindow(win *Window, nwin *nCursesWindow) {
+// drawWindow writes the contents of the window to the ncurses window
+// When monochrome is true theme components are displayed using attributes rather than colors
+func drawWindow(win *Window, nwin *nCursesWindow, monochrome bool) {
 	log.Debugf("Drawing window %v", win.ID())
 
 	for rowIndex := uint(0); rowIndex < win.rows; rowIndex++ {
@@ -380,7 +475,7 @@ func drawWindow(win *Window, nwin *nCursesWindow) {
 			if cell.style.acsChar != 0 {
 				nwin.AddChar(cell.style.acsChar)
 			} else if cell.codePoints.Len() > 0 {
-				attr := cell.style.attr | gc.ColorPair(int16(cell.style.themeComponentID))
+				attr := cell.style.attr | cellStyleAttributes(cell.style.themeComponentID, monochrome)
 				if err := nwin.AttrOn(attr); err != nil {
 					log.Errorf("Error when attempting to set AttrOn with %v: %v", attr, err)
 				}
@@ -426,7 +521,7 @@ func (ui *NCursesUI) GetInput(force bool) (key Key, err error) {
 				}
 
 				return
-			case fdIsset(stdinFd, rfds) && !ReadLineActive():
+			case fdIsset(stdinFd, rfds) && !ReadLineActive() && !ui.isExternalProgramActive():
 				break OuterLoop
 			}
 		}
@@ -476,7 +571,9 @@ func (ui *NCursesUI) onConfigVariableChange(configVariable ConfigVariable) {
 	ui.lock.Lock()
 	defer ui.lock.Unlock()
 
-	ui.initialiseColorPairsFromTheme(theme)
+	if ui.colorsSupported {
+		ui.initialiseColorPairsFromTheme(theme)
+	}
 }
 
 func (ui *NCursesUI) initialiseColorPairsFromTheme(theme Theme) {
@@ -490,6 +587,14 @@ func (ui *NCursesUI) initialiseColorPairsFromTheme(theme Theme) {
 	}
 }
 
+func cellStyleAttributes(themeComponentID ThemeComponentID, monochrome bool) gc.Char {
+	if monochrome {
+		return monochromeAttributes[themeComponentID]
+	}
+
+	return gc.ColorPair(int16(themeComponentID))
+}
+
 func fdZero(set *syscall.FdSet) {
 	C.grv_FD_ZERO(unsafe.Pointer(set))
 }
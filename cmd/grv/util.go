@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"strings"
 
 	rw "github.com/mattn/go-runewidth"
 )
@@ -15,6 +16,15 @@ func Min(x, y uint) uint {
 	return y
 }
 
+// Max returns the maximum value of the supplied arguments
+func Max(x, y uint) uint {
+	if x > y {
+		return x
+	}
+
+	return y
+}
+
 // Abs returns the absolute value of an int as a uint
 func Abs(x int) uint {
 	if x < 0 {
@@ -39,6 +49,15 @@ func RuneWidth(codePoint rune) int {
 	return rw.RuneWidth(codePoint)
 }
 
+// StringWidth returns the number of columns required to display the string
+func StringWidth(str string) (width uint) {
+	for _, char := range str {
+		width += uint(RuneWidth(char))
+	}
+
+	return
+}
+
 // NonPrintableCharString converts a control character into a string representation
 func NonPrintableCharString(codePoint rune) string {
 	if IsNonPrintableCharacter(codePoint) {
@@ -51,3 +70,9 @@ func NonPrintableCharString(codePoint rune) string {
 
 	return string(codePoint)
 }
+
+// FirstLine returns the first line of the string without its line ending
+// Both LF and CRLF line endings are recognised
+func FirstLine(str string) string {
+	return strings.TrimSuffix(strings.SplitN(str, "\n", 2)[0], "\r")
+}
@@ -36,6 +36,38 @@ func TestMin(t *testing.T) {
 	}
 }
 
+func TestMax(t *testing.T) {
+	var maxTests = []struct {
+		arg1           uint
+		arg2           uint
+		expectedResult uint
+	}{
+		{
+			arg1:           1,
+			arg2:           2,
+			expectedResult: 2,
+		},
+		{
+			arg1:           5,
+			arg2:           4,
+			expectedResult: 5,
+		},
+		{
+			arg1:           5,
+			arg2:           5,
+			expectedResult: 5,
+		},
+	}
+
+	for _, maxTest := range maxTests {
+		actualResult := Max(maxTest.arg1, maxTest.arg2)
+
+		if actualResult != maxTest.expectedResult {
+			t.Errorf("Max return arg does not match expected arg. Expected: %v, Actual: %v", maxTest.expectedResult, actualResult)
+		}
+	}
+}
+
 func TestAbs(t *testing.T) {
 	var absTests = []struct {
 		arg            int
@@ -159,3 +191,35 @@ func TestNonPrintableCharString(t *testing.T) {
 		}
 	}
 }
+
+func TestFirstLine(t *testing.T) {
+	var firstLineTests = []struct {
+		arg            string
+		expectedResult string
+	}{
+		{
+			arg:            "",
+			expectedResult: "",
+		},
+		{
+			arg:            "single line",
+			expectedResult: "single line",
+		},
+		{
+			arg:            "first line\nsecond line\n",
+			expectedResult: "first line",
+		},
+		{
+			arg:            "first line\r\nsecond line\r\n",
+			expectedResult: "first line",
+		},
+	}
+
+	for _, firstLineTest := range firstLineTests {
+		actualResult := FirstLine(firstLineTest.arg)
+
+		if actualResult != firstLineTest.expectedResult {
+			t.Errorf("FirstLine return value does not match expected value. Expected: %q, Actual: %q", firstLineTest.expectedResult, actualResult)
+		}
+	}
+}
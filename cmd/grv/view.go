@@ -2,13 +2,23 @@ package main
 
 import (
 	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
 	"sync"
+	"time"
 
 	log "github.com/Sirupsen/logrus"
 )
 
 const (
-	viewMinActiveViewRows = 6
+	viewMinActiveViewRows  = 6
+	viewFuzzyFinderMinRows = 5
+	viewMinRows            = viewMinActiveViewRows + 2
+	viewMinCols            = 30
+	viewSummaryViewPos     = 0
+	viewHistoryViewPos     = 1
+	viewDebugOverlayTitle  = "Render Timings"
 )
 
 // ViewID is an ID assigned to each view in grv
@@ -26,6 +36,14 @@ const (
 	ViewStatusBar
 	ViewHelpBar
 	ViewError
+	ViewSummary
+	ViewFuzzyFinder
+	ViewDialog
+	ViewConfigVariables
+	ViewLog
+	ViewText
+	ViewFile
+	ViewQuickfix
 )
 
 // AbstractView exposes common functionality amongst all views
@@ -58,6 +76,18 @@ type RootView interface {
 	ActiveViewIDHierarchy() []ViewID
 }
 
+// ViewTarget identifies a location in a child view of the history view to display
+// For the ref view the ref group is selected and for the commit view the commits for the oid are loaded
+// The commit view will additionally select the commit and filter by the path when they are set
+type ViewTarget struct {
+	viewID   ViewID
+	refGroup RenderedRefType
+	refName  string
+	oid      *Oid
+	commit   *Commit
+	path     string
+}
+
 // ViewDimension describes the size of a view
 type ViewDimension struct {
 	rows uint
@@ -72,29 +102,69 @@ func (viewDimension ViewDimension) String() string {
 // View is the top level view in grv
 // All views in grv are children of this view
 type View struct {
-	views         []WindowViewCollection
-	activeViewPos uint
-	statusView    WindowViewCollection
-	channels      *Channels
-	promptActive  bool
-	errorView     *ErrorView
-	errorViewWin  *Window
-	errors        []error
-	lock          sync.Mutex
+	views               []WindowViewCollection
+	activeViewPos       uint
+	repoData            RepoData
+	config              ConfigSetter
+	worktreeViews       map[string]uint
+	worktreeRepos       []*RepositoryData
+	configVariables     *ConfigVariablesView
+	configViewPos       uint
+	logView             *LogView
+	logViewPos          uint
+	textView            *TextView
+	textViewPos         uint
+	fileView            *FileView
+	fileViewPos         uint
+	ownershipDone       func()
+	ownershipGeneration uint
+	statusView          WindowViewCollection
+	channels            *Channels
+	promptActive        bool
+	errorView           *ErrorView
+	errorViewWin        *Window
+	errors              []error
+	fuzzyFinderView     *FuzzyFinderView
+	fuzzyFinderWin      *Window
+	fuzzyFinding        bool
+	dialogView          *DialogView
+	dialogWin           *Window
+	showingDialog       bool
+	tooSmallWin         *Window
+	debugOverlayWin     *Window
+	announcedLine       string
+	canceller           *OperationCanceller
+	lock                sync.Mutex
 }
 
 // NewView creates a new instance
 func NewView(repoData RepoData, channels *Channels, config ConfigSetter) (view *View) {
+	canceller := NewOperationCanceller()
+	historyView := NewHistoryView(repoData, channels, config, canceller)
+
 	view = &View{
 		views: []WindowViewCollection{
-			NewHistoryView(repoData, channels, config),
+			NewContainerView(NewSummaryView(repoData, channels, config), NewWindow("summaryView", config)),
+			historyView,
 		},
-		channels: channels,
+		repoData:      repoData,
+		config:        config,
+		worktreeViews: make(map[string]uint),
+		channels:      channels,
+		canceller:     canceller,
 	}
 
 	view.statusView = NewStatusView(view, repoData, channels, config)
 	view.errorView = NewErrorView()
 	view.errorViewWin = NewWindow("errorView", config)
+	view.fuzzyFinderView = NewFuzzyFinderView(repoData, channels, historyView)
+	view.fuzzyFinderWin = NewWindow("fuzzyFinderView", config)
+	view.dialogView = NewDialogView()
+	view.dialogWin = NewWindow("dialogView", config)
+	view.tooSmallWin = NewWindow("terminalTooSmall", config)
+	view.debugOverlayWin = NewWindow("debugOverlay", config)
+
+	config.AddOnAnyChangeListener(view)
 
 	return
 }
@@ -109,16 +179,39 @@ func (view *View) Initialise() (err error) {
 
 	view.OnActiveChange(true)
 
+	if worktrees, worktreeErr := view.repoData.Worktrees(); worktreeErr != nil {
+		log.Debugf("Unable to read worktrees: %v", worktreeErr)
+	} else {
+		for _, worktree := range worktrees {
+			if worktree.current {
+				view.worktreeViews[worktree.path] = viewHistoryViewPos
+
+				if historyView, ok := view.views[viewHistoryViewPos].(*HistoryView); ok {
+					historyView.SetWorktree(worktree.name)
+				}
+			}
+		}
+	}
+
 	return
 }
 
+// Free releases the repositories opened for worktrees
+func (view *View) Free() {
+	for _, repoData := range view.worktreeRepos {
+		repoData.Free()
+	}
+}
+
 // Render generates all windows to be drawn to the UI
 func (view *View) Render(viewDimension ViewDimension) (wins []*Window, err error) {
 	log.Debug("Rendering View")
+	renderStart := time.Now()
 
-	if viewDimension.rows < 3 {
-		log.Errorf("Terminal is not large enough to render GRV")
-		return
+	if viewDimension.rows < viewMinRows || viewDimension.cols < viewMinCols {
+		log.Debugf("Terminal is not large enough to render GRV: %v", viewDimension)
+		renderTerminalTooSmall(view.tooSmallWin, viewDimension)
+		return []*Window{view.tooSmallWin}, nil
 	}
 
 	activeViewDim := viewDimension
@@ -136,6 +229,8 @@ func (view *View) Render(viewDimension ViewDimension) (wins []*Window, err error
 
 	view.lock.Lock()
 	childView := view.views[view.activeViewPos]
+	fuzzyFinding := view.fuzzyFinding
+	showingDialog := view.showingDialog
 	view.lock.Unlock()
 
 	activeViewWins, err := childView.Render(activeViewDim)
@@ -143,11 +238,19 @@ func (view *View) Render(viewDimension ViewDimension) (wins []*Window, err error
 		return
 	}
 
+	if view.config.GetBool(CfScreenReader) && !fuzzyFinding && !showingDialog {
+		view.announceSelectedLine(activeViewWins)
+	}
+
+	statusViewRenderStart := time.Now()
+
 	statusViewWins, err := view.statusView.Render(statusViewDim)
 	if err != nil {
 		return
 	}
 
+	renderTimings.Record("statusView", statusViewRenderStart)
+
 	for _, win := range statusViewWins {
 		win.OffsetPosition(int(activeViewDim.rows+errorViewDim.rows), 0)
 	}
@@ -155,12 +258,115 @@ func (view *View) Render(viewDimension ViewDimension) (wins []*Window, err error
 	wins = append(activeViewWins, statusViewWins...)
 
 	if errorViewDim.rows > 0 {
-		wins, err = view.renderErrorView(wins, errorViewDim, activeViewDim)
+		if wins, err = view.renderErrorView(wins, errorViewDim, activeViewDim); err != nil {
+			return
+		}
+	}
+
+	if fuzzyFinding {
+		wins, err = view.renderFuzzyFinderView(wins, activeViewDim)
+	} else if showingDialog {
+		wins, err = view.renderDialogView(wins, activeViewDim)
+	}
+
+	if err != nil {
+		return
+	}
+
+	renderTimings.Record(pfTotalRenderTiming, renderStart)
+
+	if view.config.GetBool(CfDebugOverlay) {
+		wins = view.renderDebugOverlay(wins, activeViewDim)
 	}
 
 	return wins, err
 }
 
+// announceSelectedLine displays the selected line of the active window in the status bar when it changes
+// so that selection changes are read out by screen readers
+func (view *View) announceSelectedLine(wins []*Window) {
+	for _, win := range wins {
+		line, isSelected := win.SelectedLine()
+		if !isSelected {
+			continue
+		}
+
+		if line != view.announcedLine {
+			view.announcedLine = line
+			// The status is reported in the background as the action channel may be full while rendering
+			go view.channels.ReportStatus("%v", line)
+		}
+
+		return
+	}
+}
+
+// renderDebugOverlay displays the time taken to render each window in the top right corner of the active view
+// The overlay is omitted if there is not enough space to display it
+func (view *View) renderDebugOverlay(wins []*Window, activeViewDim ViewDimension) []*Window {
+	timings := renderTimings.Timings()
+
+	var nameWidth uint
+	for _, timing := range timings {
+		nameWidth = Max(nameWidth, StringWidth(timing.name))
+	}
+
+	overlayDim := ViewDimension{
+		rows: uint(len(timings)) + 2,
+		cols: Max(nameWidth+15, StringWidth(viewDebugOverlayTitle)+6),
+	}
+
+	if overlayDim.rows > activeViewDim.rows || overlayDim.cols > activeViewDim.cols {
+		return wins
+	}
+
+	win := view.debugOverlayWin
+	win.Resize(overlayDim)
+	win.Clear()
+	win.SetPosition(0, activeViewDim.cols-overlayDim.cols)
+
+	for rowIndex, timing := range timings {
+		if err := win.SetRow(uint(rowIndex)+1, 1, CmpNone, " %-*v %10v", int(nameWidth), timing.name, timing.duration.Round(time.Microsecond)); err != nil {
+			log.Errorf("Unable to render debug overlay: %v", err)
+			return wins
+		}
+	}
+
+	win.DrawBorder()
+
+	if err := win.SetTitle(CmpNone, "%v", viewDebugOverlayTitle); err != nil {
+		log.Errorf("Unable to render debug overlay title: %v", err)
+	}
+
+	return append(wins, win)
+}
+
+// renderTerminalTooSmall fills the window with a message explaining the terminal is too small to display GRV
+// Each line of the message is centred and lines which do not fit are omitted
+func renderTerminalTooSmall(win *Window, viewDimension ViewDimension) {
+	win.Resize(viewDimension)
+	win.Clear()
+	win.SetPosition(0, 0)
+
+	lines := []string{
+		"Terminal too small",
+		fmt.Sprintf("Minimum: %vx%v", viewMinCols, viewMinRows),
+		fmt.Sprintf("Current: %vx%v", viewDimension.cols, viewDimension.rows),
+	}
+
+	lineNum := Min(uint(len(lines)), viewDimension.rows)
+	startRow := (viewDimension.rows - lineNum) / 2
+
+	for lineIndex := uint(0); lineIndex < lineNum; lineIndex++ {
+		line := lines[lineIndex]
+		startCol := (viewDimension.cols - Min(StringWidth(line), viewDimension.cols)) / 2
+
+		if lineBuilder, err := win.LineBuilder(startRow+lineIndex, 1); err == nil {
+			lineBuilder.Append("%*v%v", int(startCol), "", line)
+		}
+	}
+}
+
 func (view *View) determineErrorViewDimensions(errorViewDim, activeViewDim *ViewDimension) {
 	view.errorView.SetErrors(view.errors)
 	view.errors = nil
@@ -196,6 +402,52 @@ func (view *View) renderErrorView(wins []*Window, errorViewDim, activeViewDim Vi
 	return
 }
 
+// renderFuzzyFinderView draws the fuzzy finder centred over the active view
+func (view *View) renderFuzzyFinderView(wins []*Window, activeViewDim ViewDimension) (allWins []*Window, err error) {
+	fuzzyFinderViewDim := ViewDimension{
+		rows: activeViewDim.rows * 2 / 3,
+		cols: activeViewDim.cols * 3 / 4,
+	}
+
+	if fuzzyFinderViewDim.rows < viewFuzzyFinderMinRows {
+		log.Errorf("Unable to display fuzzy finder, not enough space")
+		return wins, nil
+	}
+
+	view.fuzzyFinderWin.Resize(fuzzyFinderViewDim)
+	view.fuzzyFinderWin.Clear()
+	view.fuzzyFinderWin.SetPosition((activeViewDim.rows-fuzzyFinderViewDim.rows)/2, (activeViewDim.cols-fuzzyFinderViewDim.cols)/2)
+
+	_, pattern, _ := PromptState()
+
+	if err = view.fuzzyFinderView.Render(view.fuzzyFinderWin, pattern); err != nil {
+		return
+	}
+
+	allWins = append(wins, view.fuzzyFinderWin)
+
+	return
+}
+
+// renderDialogView draws the dialog centred over the active view
+func (view *View) renderDialogView(wins []*Window, activeViewDim ViewDimension) (allWins []*Window, err error) {
+	dialogViewDim := view.dialogView.DialogDimensions(activeViewDim)
+
+	view.dialogWin.Resize(dialogViewDim)
+	view.dialogWin.Clear()
+	view.dialogWin.SetPosition((activeViewDim.rows-dialogViewDim.rows)/2, (activeViewDim.cols-dialogViewDim.cols)/2)
+
+	_, input, point := PromptState()
+
+	if err = view.dialogView.Render(view.dialogWin, input, point); err != nil {
+		return
+	}
+
+	allWins = append(wins, view.dialogWin)
+
+	return
+}
+
 // RenderStatusBar does nothing
 func (view *View) RenderStatusBar(lineBuilder *LineBuilder) (err error) {
 	return
@@ -210,6 +462,7 @@ func (view *View) RenderHelpBar(lineBuilder *LineBuilder) (err error) {
 	if !promptActive {
 		RenderKeyBindingHelp(view.ViewID(), lineBuilder, []ActionMessage{
 			{action: ActionPrompt, message: "Command Prompt"},
+			{action: ActionNextTab, message: "Next Tab"},
 		})
 	}
 
@@ -228,17 +481,594 @@ func (view *View) HandleAction(action Action) (err error) {
 	log.Debugf("View handling action %v", action)
 
 	switch action.ActionType {
-	case ActionPrompt, ActionSearchPrompt, ActionReverseSearchPrompt, ActionFilterPrompt:
+	case ActionPrompt, ActionSearchPrompt, ActionReverseSearchPrompt, ActionFilterPrompt, ActionStashPrompt, ActionCommitPrompt,
+		ActionBranchPrompt, ActionUpstreamPrompt, ActionRenameRefPrompt,
+		ActionTrackBranchPrompt, ActionOwnerFilterPrompt:
 		err = view.prompt(action)
 		return
 	case ActionShowStatus:
 		err = view.statusView.HandleAction(action)
 		return
+	case ActionFuzzyFind:
+		err = view.fuzzyFind(action)
+		return
+	case ActionFuzzyFindSelect:
+		err = view.selectFuzzyFinderMatch(action)
+		return
+	case ActionShowDialog:
+		err = view.showDialog(action)
+		return
+	case ActionCancel:
+		view.cancelOperation()
+		return
+	case ActionNextTab:
+		view.lock.Lock()
+		viewPos := (view.activeViewPos + 1) % uint(len(view.views))
+		view.lock.Unlock()
+
+		view.setActiveViewPos(viewPos)
+		return
+	case ActionPrevTab:
+		view.lock.Lock()
+		viewPos := (view.activeViewPos + uint(len(view.views)) - 1) % uint(len(view.views))
+		view.lock.Unlock()
+
+		view.setActiveViewPos(viewPos)
+		return
+	case ActionOpenWorktree:
+		err = view.openWorktree(action)
+		return
+	case ActionShowConfigVariables:
+		view.showConfigVariables()
+		return
+	case ActionShowLog:
+		view.showLog()
+		return
+	case ActionRestoreSession:
+		err = view.restoreSession(action)
+		return
+	case ActionLogRevisions:
+		err = view.logRevisions(action)
+		return
+	case ActionCycleCommitOrder:
+		view.cycleCommitOrder()
+		return
+	case ActionToggleMessagePreview:
+		view.toggleMessagePreview()
+		return
+	case ActionToggleInvisibles:
+		view.toggleInvisibles()
+		return
+	case ActionToggleViewLayout:
+		view.toggleViewLayout()
+		return
+	case ActionIncreaseDiffContext:
+		view.changeDiffContext(int(action.RepeatCount()))
+		return
+	case ActionDecreaseDiffContext:
+		view.changeDiffContext(-int(action.RepeatCount()))
+		return
+	case ActionPipeText:
+		err = view.pipeText(action)
+		return
+	case ActionSelectContainingRef:
+		err = view.selectContainingRef(action)
+		return
+	case ActionChangelog:
+		err = view.changelog(action)
+		return
+	case ActionShowFile:
+		err = view.showFile(action)
+		return
+	case ActionShowOwnership:
+		err = view.showOwnership(action)
+		return
+	case ActionShowView, ActionGotoCommit, ActionShowLineHistory, ActionShowQuickfix, ActionOpenQuickfix,
+		ActionCloseQuickfix, ActionQuickfixNext, ActionQuickfixPrev, ActionJumpToQuickfixEntry:
+		view.setActiveViewPos(viewHistoryViewPos)
 	}
 
 	return view.ActiveView().HandleAction(action)
 }
 
+// setActiveViewPos makes the child view at the provided position active
+func (view *View) setActiveViewPos(viewPos uint) {
+	view.lock.Lock()
+	previousView := view.views[view.activeViewPos]
+	view.activeViewPos = viewPos
+	activeView := view.views[viewPos]
+	view.lock.Unlock()
+
+	if previousView != activeView {
+		previousView.OnActiveChange(false)
+		activeView.OnActiveChange(true)
+	}
+
+	view.channels.UpdateDisplay()
+}
+
+// openWorktree switches to the tab displaying the worktree with the provided name or path
+// If no tab displays the worktree then a new history view is created for it
+func (view *View) openWorktree(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected worktree argument")
+	}
+
+	nameOrPath, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected worktree argument to have type string")
+	}
+
+	worktrees, err := view.repoData.Worktrees()
+	if err != nil {
+		return
+	}
+
+	worktree, err := findWorktree(worktrees, nameOrPath)
+	if err != nil {
+		return
+	}
+
+	view.lock.Lock()
+	viewPos, exists := view.worktreeViews[worktree.path]
+	view.lock.Unlock()
+
+	if !exists {
+		if viewPos, err = view.addWorktreeView(worktree); err != nil {
+			return
+		}
+	}
+
+	view.setActiveViewPos(viewPos)
+	view.channels.ReportStatus("Showing worktree %v at %v", worktree.name, worktree.path)
+
+	return
+}
+
+// addWorktreeView opens the worktree as a separate repository so it has its own HEAD and index
+// and displays it in a new history view
+func (view *View) addWorktreeView(worktree *Worktree) (viewPos uint, err error) {
+	repoData := NewRepositoryData(NewRepoDataLoader(view.channels), view.channels, view.config)
+	if err = repoData.Initialise(worktree.path, ""); err != nil {
+		return
+	}
+
+	historyView := NewHistoryView(repoData, view.channels, view.config, view.canceller)
+	historyView.SetWorktree(worktree.name)
+
+	if err = historyView.Initialise(); err != nil {
+		repoData.Free()
+		return
+	}
+
+	view.lock.Lock()
+	defer view.lock.Unlock()
+
+	view.views = append(view.views, historyView)
+	view.worktreeRepos = append(view.worktreeRepos, repoData)
+	viewPos = uint(len(view.views) - 1)
+	view.worktreeViews[worktree.path] = viewPos
+
+	log.Infof("Opened worktree %v at %v", worktree.name, worktree.path)
+
+	return
+}
+
+// showConfigVariables switches to the tab listing config variables
+// The tab is created the first time it is shown
+func (view *View) showConfigVariables() {
+	view.lock.Lock()
+	configVariables := view.configVariables
+	created := configVariables == nil
+
+	if created {
+		configVariables = NewConfigVariablesView(view.channels, view.config)
+		view.configVariables = configVariables
+		view.views = append(view.views, NewContainerView(configVariables, NewWindow("configVariablesView", view.config)))
+		view.configViewPos = uint(len(view.views) - 1)
+	}
+
+	viewPos := view.configViewPos
+	view.lock.Unlock()
+
+	if !created {
+		configVariables.LoadVariables()
+	}
+
+	view.setActiveViewPos(viewPos)
+}
+
+// SelectedCommit returns the ref and commit selected in the history view of the repository
+func (view *View) SelectedCommit() (refName string, ref *Oid, commit *Commit) {
+	view.lock.Lock()
+	historyView, ok := view.views[viewHistoryViewPos].(*HistoryView)
+	view.lock.Unlock()
+
+	if !ok {
+		return
+	}
+
+	return historyView.SelectedCommit()
+}
+
+// ExpandedRefLists returns whether each list of refs in the history view is expanded
+func (view *View) ExpandedRefLists() map[string]bool {
+	view.lock.Lock()
+	historyView, ok := view.views[viewHistoryViewPos].(*HistoryView)
+	view.lock.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	return historyView.ExpandedRefLists()
+}
+
+// SetExpandedRefLists expands or collapses the named lists of refs in the history view
+func (view *View) SetExpandedRefLists(expandedRefLists map[string]bool) {
+	view.lock.Lock()
+	historyView, ok := view.views[viewHistoryViewPos].(*HistoryView)
+	view.lock.Unlock()
+
+	if ok {
+		historyView.SetExpandedRefLists(expandedRefLists)
+	}
+}
+
+// PinnedRefs returns the names of the refs pinned in the history view
+func (view *View) PinnedRefs() []string {
+	view.lock.Lock()
+	historyView, ok := view.views[viewHistoryViewPos].(*HistoryView)
+	view.lock.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	return historyView.PinnedRefs()
+}
+
+// SetPinnedRefs replaces the refs pinned in the history view
+func (view *View) SetPinnedRefs(refNames []string) {
+	view.lock.Lock()
+	historyView, ok := view.views[viewHistoryViewPos].(*HistoryView)
+	view.lock.Unlock()
+
+	if ok {
+		historyView.SetPinnedRefs(refNames)
+	}
+}
+
+// restoreSession selects the ref and commit recorded in the session provided as the action argument
+func (view *View) restoreSession(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected session argument")
+	}
+
+	session, ok := action.Args[0].(SessionState)
+	if !ok {
+		return fmt.Errorf("Expected session argument to have type SessionState")
+	}
+
+	if session.refName == "" {
+		return
+	}
+
+	refOid, err := view.repoData.RevisionsOid(strings.Fields(session.refName))
+	if err != nil {
+		return fmt.Errorf("Unable to restore ref %v: %v", session.refName, err)
+	}
+
+	viewTarget := ViewTarget{viewID: ViewCommit, refName: session.refName, oid: refOid}
+
+	if session.commitID != "" {
+		if viewTarget.commit, err = view.repoData.CommitByRevision(session.commitID); err != nil {
+			return fmt.Errorf("Unable to restore commit %v: %v", session.commitID, err)
+		}
+	}
+
+	view.channels.DoAction(Action{ActionType: ActionShowView, Args: []interface{}{viewTarget}})
+
+	return
+}
+
+// logRevisions displays the history of the revisions provided as action arguments in the commit view
+// Commits reachable from revisions prefixed with ^ are excluded
+func (view *View) logRevisions(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected revision arguments")
+	}
+
+	var revisions []string
+	for _, arg := range action.Args {
+		revision, ok := arg.(string)
+		if !ok {
+			return fmt.Errorf("Expected revision arguments to have type string")
+		}
+
+		revisions = append(revisions, revision)
+	}
+
+	oid, err := view.repoData.RevisionsOid(revisions)
+	if err != nil {
+		return
+	}
+
+	viewTarget := ViewTarget{viewID: ViewCommit, refName: strings.Join(revisions, " "), oid: oid}
+	view.channels.DoAction(Action{ActionType: ActionShowView, Args: []interface{}{viewTarget}})
+
+	return
+}
+
+// selectContainingRef shows the ref chosen by number from the list of refs containing a commit
+func (view *View) selectContainingRef(action Action) (err error) {
+	if len(action.Args) < 2 {
+		return fmt.Errorf("Expected ref targets and ref number arguments")
+	}
+
+	targets, ok := action.Args[0].([]ViewTarget)
+	if !ok {
+		return fmt.Errorf("Expected ref targets argument to have type []ViewTarget")
+	}
+
+	input, ok := action.Args[1].(string)
+	if !ok {
+		return fmt.Errorf("Expected ref number argument to have type string")
+	}
+
+	refNum, err := strconv.Atoi(strings.TrimSpace(input))
+	if err != nil || refNum < 1 || refNum > len(targets) {
+		return fmt.Errorf("Invalid ref number: %v", input)
+	}
+
+	return view.HandleAction(Action{
+		ActionType: ActionShowView,
+		Args:       []interface{}{targets[refNum-1]},
+	})
+}
+
+// cycleCommitOrder displays commits in the next commit order
+func (view *View) cycleCommitOrder() {
+	commitOrder := nextCommitOrder(view.config.GetString(CfCommitOrder))
+
+	if errors := view.config.Evaluate(fmt.Sprintf("set %v %v", CfCommitOrder, commitOrder)); errors != nil {
+		view.channels.ReportErrors(errors)
+		return
+	}
+
+	view.channels.ReportStatus("Displaying commits in %v order", commitOrder)
+}
+
+// toggleMessagePreview switches the diff view between displaying diffs and commit messages
+func (view *View) toggleMessagePreview() {
+	messagePreview := !view.config.GetBool(CfMessagePreview)
+
+	if errors := view.config.Evaluate(fmt.Sprintf("set %v %v", CfMessagePreview, messagePreview)); errors != nil {
+		view.channels.ReportErrors(errors)
+		return
+	}
+
+	if messagePreview {
+		view.channels.ReportStatus("Displaying commit messages in the diff view")
+	} else {
+		view.channels.ReportStatus("Displaying diffs in the diff view")
+	}
+}
+
+// toggleInvisibles switches between displaying tabs, trailing spaces and non-breaking spaces
+// as placeholder characters and displaying them as they are
+func (view *View) toggleInvisibles() {
+	invisibles := !view.config.GetBool(CfInvisibles)
+
+	if errors := view.config.Evaluate(fmt.Sprintf("set %v %v", CfInvisibles, invisibles)); errors != nil {
+		view.channels.ReportErrors(errors)
+		return
+	}
+
+	if invisibles {
+		view.channels.ReportStatus("Displaying invisible characters")
+	} else {
+		view.channels.ReportStatus("Hiding invisible characters")
+	}
+
+	view.channels.UpdateDisplay()
+}
+
+// toggleViewLayout switches the history view between the stacked and columns layouts
+func (view *View) toggleViewLayout() {
+	layout := LayoutColumns
+	if view.config.GetString(CfLayout) == LayoutColumns {
+		layout = LayoutStacked
+	}
+
+	if errors := view.config.Evaluate(fmt.Sprintf("set %v %v", CfLayout, layout)); errors != nil {
+		view.channels.ReportErrors(errors)
+	}
+}
+
+// changeDiffContext adjusts the number of context lines displayed around changes in diffs
+func (view *View) changeDiffContext(delta int) {
+	diffContext := view.config.GetInt(CfDiffContext) + delta
+	if diffContext < 0 {
+		diffContext = 0
+	}
+
+	if diffContext == view.config.GetInt(CfDiffContext) {
+		return
+	}
+
+	if errors := view.config.Evaluate(fmt.Sprintf("set %v %v", CfDiffContext, diffContext)); errors != nil {
+		view.channels.ReportErrors(errors)
+		return
+	}
+
+	view.channels.ReportStatus("Displaying %v lines of context in diffs", diffContext)
+}
+
+// pipeText runs a command in the terminal with the provided text on its standard input
+func (view *View) pipeText(action Action) (err error) {
+	if len(action.Args) < 2 {
+		return fmt.Errorf("Expected text and command arguments")
+	}
+
+	text, textOk := action.Args[0].(string)
+	command, commandOk := action.Args[1].(string)
+	if !textOk || !commandOk {
+		return fmt.Errorf("Expected text and command arguments to have type string")
+	}
+
+	view.channels.DoAction(Action{
+		ActionType: ActionRunCommand,
+		Args: []interface{}{CommandRequest{
+			cmd:         yankCommand(command, text),
+			description: command,
+			onComplete: func(err error) {
+				if err != nil {
+					view.channels.ReportError(err)
+				} else {
+					view.channels.ReportStatus("Piped text to %v", command)
+				}
+			},
+		}},
+	})
+
+	return
+}
+
+// showLog switches to the tab listing recent log entries
+// The tab is created the first time it is shown
+func (view *View) showLog() {
+	view.lock.Lock()
+
+	if view.logView == nil {
+		view.logView = NewLogView(view.channels, view.config, logEntries)
+		view.views = append(view.views, NewContainerView(view.logView, NewWindow("logView", view.config)))
+		view.logViewPos = uint(len(view.views) - 1)
+	}
+
+	viewPos := view.logViewPos
+	view.lock.Unlock()
+
+	view.setActiveViewPos(viewPos)
+}
+
+// showText switches to the tab displaying read only text and replaces its content
+// The tab is created the first time it is shown
+func (view *View) showText(title string, lines []string) {
+	view.lock.Lock()
+
+	if view.textView == nil {
+		view.textView = NewTextView(view.channels, view.config)
+		view.views = append(view.views, NewContainerView(view.textView, NewWindow("textView", view.config)))
+		view.textViewPos = uint(len(view.views) - 1)
+	}
+
+	textView := view.textView
+	viewPos := view.textViewPos
+	view.lock.Unlock()
+
+	textView.SetText(title, lines)
+	view.setActiveViewPos(viewPos)
+}
+
+// updateText replaces the content of the text tab without switching to it
+func (view *View) updateText(title string, lines []string) {
+	view.lock.Lock()
+	textView := view.textView
+	view.lock.Unlock()
+
+	if textView != nil {
+		textView.UpdateText(title, lines)
+	}
+}
+
+// showFile switches to the tab displaying file contents and loads the requested file
+// The tab is created the first time a file is shown
+func (view *View) showFile(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected FileRequest argument")
+	}
+
+	request, ok := action.Args[0].(FileRequest)
+	if !ok {
+		return fmt.Errorf("Expected argument of type FileRequest but found type %T", action.Args[0])
+	}
+
+	view.lock.Lock()
+
+	if view.fileView == nil {
+		view.fileView = NewFileView(view.repoData, view.channels, view.config)
+		view.views = append(view.views, NewContainerView(view.fileView, NewWindow("fileView", view.config)))
+		view.fileViewPos = uint(len(view.views) - 1)
+	}
+
+	fileView := view.fileView
+	viewPos := view.fileViewPos
+	view.lock.Unlock()
+
+	fileView.LoadFile(request)
+	view.setActiveViewPos(viewPos)
+
+	return
+}
+
+// changelog generates a changelog for the revisions provided as action arguments
+// The changelog is written to a file if one is provided, otherwise it is displayed in a new tab
+func (view *View) changelog(action Action) (err error) {
+	if len(action.Args) < 4 {
+		return fmt.Errorf("Expected from revision, to revision, grouping and file arguments")
+	}
+
+	var args []string
+	for _, arg := range action.Args[:4] {
+		value, ok := arg.(string)
+		if !ok {
+			return fmt.Errorf("Expected changelog arguments to have type string")
+		}
+
+		args = append(args, value)
+	}
+
+	fromRevision, toRevision, groupBy, file := args[0], args[1], args[2], args[3]
+	view.channels.ReportStatus("Generating changelog from %v to %v", fromRevision, toRevision)
+
+	go func() {
+		changelog, err := GenerateChangelog(view.repoData, fromRevision, toRevision, groupBy)
+		if err != nil {
+			view.channels.ReportError(err)
+			return
+		}
+
+		if file == "" {
+			view.showText(fmt.Sprintf("Changelog %v..%v", fromRevision, toRevision), strings.Split(strings.TrimSuffix(changelog, "\n"), "\n"))
+			return
+		}
+
+		if err := ioutil.WriteFile(file, []byte(changelog), 0644); err != nil {
+			view.channels.ReportError(fmt.Errorf("Unable to write changelog to %v: %v", file, err))
+			return
+		}
+
+		view.channels.ReportStatus("Changelog written to %v", file)
+	}()
+
+	return
+}
+
+// onConfigVariableChange redraws all views so they reflect the new config value
+func (view *View) onConfigVariableChange(configVariable ConfigVariable) {
+	view.channels.UpdateDisplay()
+}
+
+// cancelOperation cancels the most recently started long running operation
+func (view *View) cancelOperation() {
+	if description, cancelled := view.canceller.CancelLatest(); cancelled {
+		view.channels.ReportStatus("Cancelled %v", description)
+	} else {
+		view.channels.ReportStatus("No operation to cancel")
+	}
+}
+
 // OnActiveChange updates the active state of the currently active child view
 func (view *View) OnActiveChange(active bool) {
 	log.Debugf("View active %v", active)
@@ -311,6 +1141,68 @@ func (view *View) prompt(action Action) (err error) {
 	return
 }
 
+// fuzzyFind displays the fuzzy finder while the user enters a pattern
+func (view *View) fuzzyFind(action Action) (err error) {
+	view.fuzzyFinderView.LoadCandidates()
+
+	view.lock.Lock()
+	view.fuzzyFinding = true
+	view.lock.Unlock()
+
+	err = view.prompt(action)
+
+	view.lock.Lock()
+	view.fuzzyFinding = false
+	view.lock.Unlock()
+
+	return
+}
+
+// showDialog displays a dialog over the active view while the user responds to it
+func (view *View) showDialog(action Action) (err error) {
+	request, err := dialogRequestArg(action)
+	if err != nil {
+		return
+	}
+
+	view.dialogView.SetRequest(request)
+
+	view.lock.Lock()
+	view.showingDialog = true
+	view.lock.Unlock()
+
+	err = view.prompt(action)
+
+	view.lock.Lock()
+	view.showingDialog = false
+	view.lock.Unlock()
+
+	return
+}
+
+// selectFuzzyFinderMatch shows the best match for the entered pattern in its view
+func (view *View) selectFuzzyFinderMatch(action Action) (err error) {
+	if len(action.Args) == 0 {
+		return fmt.Errorf("Expected pattern argument")
+	}
+
+	pattern, ok := action.Args[0].(string)
+	if !ok {
+		return fmt.Errorf("Expected pattern argument to have type string")
+	}
+
+	viewTarget, found := view.fuzzyFinderView.BestMatch(pattern)
+	if !found {
+		view.channels.ReportStatus("No match found for %v", pattern)
+		return
+	}
+
+	return view.HandleAction(Action{
+		ActionType: ActionShowView,
+		Args:       []interface{}{viewTarget},
+	})
+}
+
 // SetErrors sets errors to be displayed in the error view
 func (view *View) SetErrors(errors []error) {
 	view.errors = errors
@@ -7,11 +7,18 @@ type ViewPos interface {
 	ViewStartRowIndex() uint
 	ViewStartColumn() uint
 	SelectedRowIndex() uint
-	DetermineViewStartRow(viewRows, rows uint)
+	DetermineViewStartRow(viewRows, rows, scrollOff uint)
 	MoveLineDown(rows uint) (changed bool)
 	MoveLineUp() (changed bool)
+	MoveLinesDown(lines, rows uint) (changed bool)
+	MoveLinesUp(lines uint) (changed bool)
 	MovePageDown(pageRows, rows uint) (changed bool)
 	MovePageUp(pageRows uint) (changed bool)
+	MoveHalfPageDown(pageRows, rows uint) (changed bool)
+	MoveHalfPageUp(pageRows uint) (changed bool)
+	CenterActiveRow(pageRows uint) (changed bool)
+	ScrollActiveRowToTop() (changed bool)
+	ScrollActiveRowToBottom(pageRows uint) (changed bool)
 	MovePageRight(cols uint)
 	MovePageLeft(cols uint) (changed bool)
 	MoveToFirstLine() (changed bool)
@@ -60,14 +67,27 @@ func (viewPos *ViewPosition) SelectedRowIndex() uint {
 }
 
 // DetermineViewStartRow determines the row the view should start displaying from based on the current cursor position
-func (viewPos *ViewPosition) DetermineViewStartRow(viewRows, rows uint) {
+// Where possible scrollOff rows are kept visible above and below the cursor
+func (viewPos *ViewPosition) DetermineViewStartRow(viewRows, rows, scrollOff uint) {
 	if rows > 0 && viewPos.activeRowIndex >= rows {
 		viewPos.activeRowIndex = rows - 1
 	}
 
-	if viewPos.viewStartRowIndex > viewPos.activeRowIndex {
-		viewPos.viewStartRowIndex = viewPos.activeRowIndex
-	} else if rowDiff := viewPos.activeRowIndex - viewPos.viewStartRowIndex; rowDiff >= viewRows {
+	if viewRows > 0 {
+		scrollOff = Min(scrollOff, (viewRows-1)/2)
+	} else {
+		scrollOff = 0
+	}
+
+	firstVisibleRowIndex := viewPos.activeRowIndex - Min(scrollOff, viewPos.activeRowIndex)
+	lastVisibleRowIndex := viewPos.activeRowIndex + scrollOff
+	if rows > 0 && lastVisibleRowIndex >= rows {
+		lastVisibleRowIndex = rows - 1
+	}
+
+	if viewPos.viewStartRowIndex > firstVisibleRowIndex {
+		viewPos.viewStartRowIndex = firstVisibleRowIndex
+	} else if rowDiff := lastVisibleRowIndex - viewPos.viewStartRowIndex; rowDiff >= viewRows {
 		viewPos.viewStartRowIndex += (rowDiff - viewRows) + 1
 	} else if visibleRows := rows - (viewPos.viewStartRowIndex + 1); visibleRows < viewRows && viewPos.viewStartRowIndex > 0 {
 		viewPos.viewStartRowIndex -= Min(viewPos.viewStartRowIndex, (viewRows-visibleRows)-1)
@@ -76,18 +96,28 @@ func (viewPos *ViewPosition) DetermineViewStartRow(viewRows, rows uint) {
 
 // MoveLineDown moves the cursor down one line
 func (viewPos *ViewPosition) MoveLineDown(rows uint) (changed bool) {
+	return viewPos.MoveLinesDown(1, rows)
+}
+
+// MoveLineUp moves the cursor up one line
+func (viewPos *ViewPosition) MoveLineUp() (changed bool) {
+	return viewPos.MoveLinesUp(1)
+}
+
+// MoveLinesDown moves the cursor down the specified number of lines, stopping at the last line
+func (viewPos *ViewPosition) MoveLinesDown(lines, rows uint) (changed bool) {
 	if viewPos.activeRowIndex+1 < rows {
-		viewPos.activeRowIndex++
+		viewPos.activeRowIndex += Min(lines, rows-(viewPos.activeRowIndex+1))
 		changed = true
 	}
 
 	return
 }
 
-// MoveLineUp moves the cursor up one line
-func (viewPos *ViewPosition) MoveLineUp() (changed bool) {
+// MoveLinesUp moves the cursor up the specified number of lines, stopping at the first line
+func (viewPos *ViewPosition) MoveLinesUp(lines uint) (changed bool) {
 	if viewPos.activeRowIndex > 0 {
-		viewPos.activeRowIndex--
+		viewPos.activeRowIndex -= Min(lines, viewPos.activeRowIndex)
 		changed = true
 	}
 
@@ -116,6 +146,66 @@ func (viewPos *ViewPosition) MovePageUp(pageRows uint) (changed bool) {
 	return
 }
 
+// MoveHalfPageDown moves the cursor and display down half a page
+func (viewPos *ViewPosition) MoveHalfPageDown(pageRows, rows uint) (changed bool) {
+	if viewPos.activeRowIndex+1 < rows {
+		lines := Min(halfPageRows(pageRows), rows-(viewPos.activeRowIndex+1))
+		viewPos.activeRowIndex += lines
+		viewPos.viewStartRowIndex += lines
+		changed = true
+	}
+
+	return
+}
+
+// MoveHalfPageUp moves the cursor and display up half a page
+func (viewPos *ViewPosition) MoveHalfPageUp(pageRows uint) (changed bool) {
+	if viewPos.activeRowIndex > 0 {
+		lines := Min(halfPageRows(pageRows), viewPos.activeRowIndex)
+		viewPos.activeRowIndex -= lines
+		viewPos.viewStartRowIndex -= Min(lines, viewPos.viewStartRowIndex)
+		changed = true
+	}
+
+	return
+}
+
+func halfPageRows(pageRows uint) uint {
+	if pageRows < 2 {
+		return 1
+	}
+
+	return pageRows / 2
+}
+
+// CenterActiveRow scrolls the display so that the cursor is in the middle of the page
+func (viewPos *ViewPosition) CenterActiveRow(pageRows uint) (changed bool) {
+	return viewPos.setViewStartRowIndex(viewPos.activeRowIndex - Min(pageRows/2, viewPos.activeRowIndex))
+}
+
+// ScrollActiveRowToTop scrolls the display so that the cursor is on the first row of the page
+func (viewPos *ViewPosition) ScrollActiveRowToTop() (changed bool) {
+	return viewPos.setViewStartRowIndex(viewPos.activeRowIndex)
+}
+
+// ScrollActiveRowToBottom scrolls the display so that the cursor is on the last row of the page
+func (viewPos *ViewPosition) ScrollActiveRowToBottom(pageRows uint) (changed bool) {
+	if pageRows == 0 {
+		return
+	}
+
+	return viewPos.setViewStartRowIndex(viewPos.activeRowIndex - Min(pageRows-1, viewPos.activeRowIndex))
+}
+
+func (viewPos *ViewPosition) setViewStartRowIndex(viewStartRowIndex uint) (changed bool) {
+	if viewPos.viewStartRowIndex != viewStartRowIndex {
+		viewPos.viewStartRowIndex = viewStartRowIndex
+		changed = true
+	}
+
+	return
+}
+
 // MovePageRight scrolls the view right a page (half the available view width)
 func (viewPos *ViewPosition) MovePageRight(cols uint) {
 	halfPage := cols / 2
@@ -77,6 +77,96 @@ func TestMoveLineUpDoesNotDecrementActiveRowIndexIfOnFirstRow(t *testing.T) {
 	checkViewPosResult(false, result, t)
 }
 
+func TestMoveLinesDownStopsAtLastRow(t *testing.T) {
+	expected := newViewPos(4, 0, 1)
+
+	actual := newViewPos(1, 0, 1)
+	result := actual.MoveLinesDown(10, 5)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestMoveLinesUpStopsAtFirstRow(t *testing.T) {
+	expected := newViewPos(0, 0, 1)
+
+	actual := newViewPos(3, 0, 1)
+	result := actual.MoveLinesUp(10)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestMoveHalfPageDownUpdatesActiveRowIndexAndViewStartRowIndex(t *testing.T) {
+	expected := newViewPos(15, 10, 1)
+
+	actual := newViewPos(10, 5, 1)
+	result := actual.MoveHalfPageDown(10, 100)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestMoveHalfPageDownStopsAtLastRow(t *testing.T) {
+	expected := newViewPos(11, 7, 1)
+
+	actual := newViewPos(10, 6, 1)
+	result := actual.MoveHalfPageDown(10, 12)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestMoveHalfPageUpUpdatesActiveRowIndexAndViewStartRowIndex(t *testing.T) {
+	expected := newViewPos(5, 0, 1)
+
+	actual := newViewPos(10, 3, 1)
+	result := actual.MoveHalfPageUp(10)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestCenterActiveRowUpdatesViewStartRowIndex(t *testing.T) {
+	expected := newViewPos(20, 15, 1)
+
+	actual := newViewPos(20, 18, 1)
+	result := actual.CenterActiveRow(10)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestCenterActiveRowDoesNotScrollBeforeFirstRow(t *testing.T) {
+	expected := newViewPos(2, 0, 1)
+
+	actual := newViewPos(2, 0, 1)
+	result := actual.CenterActiveRow(10)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(false, result, t)
+}
+
+func TestScrollActiveRowToTopUpdatesViewStartRowIndex(t *testing.T) {
+	expected := newViewPos(20, 20, 1)
+
+	actual := newViewPos(20, 15, 1)
+	result := actual.ScrollActiveRowToTop()
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
+func TestScrollActiveRowToBottomUpdatesViewStartRowIndex(t *testing.T) {
+	expected := newViewPos(20, 11, 1)
+
+	actual := newViewPos(20, 20, 1)
+	result := actual.ScrollActiveRowToBottom(10)
+
+	checkViewPos(expected, actual, t)
+	checkViewPosResult(true, result, t)
+}
+
 func TestMovePageDownUpdatesActiveRowIndexAndViewStartRowIndex(t *testing.T) {
 	expected := newViewPos(7, 7, 1)
 
@@ -220,7 +310,7 @@ func TestDetermineViewStartRowSetsViewStartRowIndexToActiveRowIndexIfGreater(t *
 	expected := newViewPos(5, 5, 1)
 
 	actual := newViewPos(5, 9, 1)
-	actual.DetermineViewStartRow(10, 20)
+	actual.DetermineViewStartRow(10, 20, 0)
 
 	checkViewPos(expected, actual, t)
 }
@@ -229,7 +319,7 @@ func TestDetermineViewStartRowIncreasesViewStartRowIndexSoThatActiveRowIndexIsVi
 	expected := newViewPos(15, 6, 1)
 
 	actual := newViewPos(15, 2, 1)
-	actual.DetermineViewStartRow(10, 20)
+	actual.DetermineViewStartRow(10, 20, 0)
 
 	checkViewPos(expected, actual, t)
 }
@@ -238,7 +328,7 @@ func TestDetermineViewStartRowDecreasesViewStartRowIndexSoThatAsManyRowsAreVisib
 	expected := newViewPos(15, 10, 1)
 
 	actual := newViewPos(15, 13, 1)
-	actual.DetermineViewStartRow(10, 20)
+	actual.DetermineViewStartRow(10, 20, 0)
 
 	checkViewPos(expected, actual, t)
 }
@@ -247,7 +337,34 @@ func TestDetermineViewStartRowDecreasesActiveRowIndexIfItIsGreaterThanTheTotalNu
 	expected := newViewPos(19, 10, 1)
 
 	actual := newViewPos(20, 10, 1)
-	actual.DetermineViewStartRow(10, 20)
+	actual.DetermineViewStartRow(10, 20, 0)
+
+	checkViewPos(expected, actual, t)
+}
+
+func TestDetermineViewStartRowKeepsScrollOffRowsVisibleBelowActiveRowIndex(t *testing.T) {
+	expected := newViewPos(12, 6, 1)
+
+	actual := newViewPos(12, 2, 1)
+	actual.DetermineViewStartRow(10, 20, 3)
+
+	checkViewPos(expected, actual, t)
+}
+
+func TestDetermineViewStartRowKeepsScrollOffRowsVisibleAboveActiveRowIndex(t *testing.T) {
+	expected := newViewPos(8, 5, 1)
+
+	actual := newViewPos(8, 7, 1)
+	actual.DetermineViewStartRow(10, 20, 3)
+
+	checkViewPos(expected, actual, t)
+}
+
+func TestDetermineViewStartRowDoesNotApplyScrollOffBeyondLastRow(t *testing.T) {
+	expected := newViewPos(19, 10, 1)
+
+	actual := newViewPos(19, 10, 1)
+	actual.DetermineViewStartRow(10, 20, 3)
 
 	checkViewPos(expected, actual, t)
 }
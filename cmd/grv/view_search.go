@@ -18,15 +18,17 @@ type ViewSearch struct {
 	search               *Search
 	searchableView       SearchableView
 	channels             *Channels
+	canceller            *OperationCanceller
 	lastSearchFoundMatch bool
 	lock                 sync.Mutex
 }
 
 // NewViewSearch creates a new instance
-func NewViewSearch(searchableView SearchableView, channels *Channels) *ViewSearch {
+func NewViewSearch(searchableView SearchableView, channels *Channels, canceller *OperationCanceller) *ViewSearch {
 	return &ViewSearch{
 		searchableView: searchableView,
 		channels:       channels,
+		canceller:      canceller,
 	}
 }
 
@@ -94,8 +96,17 @@ func (viewSearch *ViewSearch) findNextMatch() (err error) {
 	log.Debugf("Searching for next occurrence of pattern %v starting from row index :%v",
 		pattern, viewPos.ActiveRowIndex())
 
+	search := viewSearch.search
+	ctx, done := viewSearch.canceller.Start("search")
+
 	go func() {
-		matchLineIndex, found := viewSearch.search.FindNext(viewPos.ActiveRowIndex())
+		defer done()
+		matchLineIndex, found := search.FindNextContext(ctx, viewPos.ActiveRowIndex())
+
+		if ctx.Err() != nil {
+			log.Debugf("Search for pattern %v was cancelled", pattern)
+			return
+		}
 
 		viewSearch.lock.Lock()
 		viewSearch.lastSearchFoundMatch = found
@@ -124,8 +135,17 @@ func (viewSearch *ViewSearch) findPrevMatch() (err error) {
 	log.Debugf("Searching for previous occurrence of pattern %v starting from row index :%v",
 		pattern, viewPos.ActiveRowIndex())
 
+	search := viewSearch.search
+	ctx, done := viewSearch.canceller.Start("search")
+
 	go func() {
-		matchLineIndex, found := viewSearch.search.FindPrev(viewPos.ActiveRowIndex())
+		defer done()
+		matchLineIndex, found := search.FindPrevContext(ctx, viewPos.ActiveRowIndex())
+
+		if ctx.Err() != nil {
+			log.Debugf("Search for pattern %v was cancelled", pattern)
+			return
+		}
 
 		viewSearch.lock.Lock()
 		viewSearch.lastSearchFoundMatch = found
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTerminalTooSmallMessageIsCentred(t *testing.T) {
+	win := NewWindow("terminalTooSmall", NewConfiguration(NewKeyBindingManager(), nil))
+	renderTerminalTooSmall(win, ViewDimension{rows: 5, cols: 22})
+
+	expectedLines := []string{
+		"",
+		"Terminal too small",
+		"Minimum: 30x8",
+		"Current: 22x5",
+		"",
+	}
+
+	for lineIndex, expectedLine := range expectedLines {
+		line := win.lines[lineIndex].String()
+
+		if strings.TrimSpace(line) != expectedLine {
+			t.Errorf("Line %v does not match expected value. Expected: %q, Actual: %q", lineIndex, expectedLine, line)
+		}
+	}
+
+	if line := win.lines[1].String(); !strings.HasPrefix(line, "  Terminal") {
+		t.Errorf("Expected message to be centred but found %q", line)
+	}
+}
+
+func TestTerminalTooSmallMessageIsTruncatedToFit(t *testing.T) {
+	win := NewWindow("terminalTooSmall", NewConfiguration(NewKeyBindingManager(), nil))
+
+	for _, viewDimension := range []ViewDimension{{rows: 0, cols: 0}, {rows: 1, cols: 1}, {rows: 2, cols: 8}} {
+		renderTerminalTooSmall(win, viewDimension)
+
+		if win.Rows() != viewDimension.rows || win.Cols() != viewDimension.cols {
+			t.Errorf("Window dimensions do not match expected value. Expected: %v, Actual: %v", viewDimension, win.ViewDimensions())
+		}
+	}
+
+	if line := win.lines[0].String(); line != "Terminal" {
+		t.Errorf("Line does not match expected value. Expected: %q, Actual: %q", "Terminal", line)
+	}
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"strings"
+)
+
+// WhitespaceRules are the core.whitespace rules used to detect whitespace errors in added lines
+type WhitespaceRules struct {
+	blankAtEOL     bool
+	spaceBeforeTab bool
+	crAtEOL        bool
+}
+
+// ParseWhitespaceRules parses a comma separated core.whitespace value.
+// Rules prefixed with - are disabled and rules which are not listed retain their git default
+func ParseWhitespaceRules(value string) WhitespaceRules {
+	rules := WhitespaceRules{
+		blankAtEOL:     true,
+		spaceBeforeTab: true,
+	}
+
+	for _, rule := range strings.Split(value, ",") {
+		rule = strings.TrimSpace(rule)
+		enabled := !strings.HasPrefix(rule, "-")
+		rule = strings.TrimPrefix(rule, "-")
+
+		switch rule {
+		case "blank-at-eol", "trailing-space":
+			rules.blankAtEOL = enabled
+		case "space-before-tab":
+			rules.spaceBeforeTab = enabled
+		case "cr-at-eol":
+			rules.crAtEOL = enabled
+		}
+	}
+
+	return rules
+}
+
+// WhitespaceErrors returns whether each byte of the content of an added line is part of a whitespace error.
+// Trailing whitespace includes a carriage return at the end of the line unless cr-at-eol is set
+func (rules WhitespaceRules) WhitespaceErrors(content string) (errors []bool, found bool) {
+	errors = make([]bool, len(content))
+	end := len(content)
+
+	if rules.crAtEOL && strings.HasSuffix(content, "\r") {
+		end--
+	}
+
+	if rules.blankAtEOL {
+		for index := end - 1; index >= 0 && isWhitespace(content[index]); index-- {
+			errors[index] = true
+			found = true
+		}
+	}
+
+	if rules.spaceBeforeTab {
+		indentEnd := 0
+		for indentEnd < end && (content[indentEnd] == ' ' || content[indentEnd] == '\t') {
+			indentEnd++
+		}
+
+		if lastTabIndex := strings.LastIndex(content[:indentEnd], "\t"); lastTabIndex != -1 {
+			if firstSpaceIndex := strings.Index(content[:lastTabIndex], " "); firstSpaceIndex != -1 {
+				for index := firstSpaceIndex; index < lastTabIndex; index++ {
+					errors[index] = true
+				}
+
+				found = true
+			}
+		}
+	}
+
+	return
+}
+
+func isWhitespace(char byte) bool {
+	return char == ' ' || char == '\t' || char == '\r' || char == '\v' || char == '\f'
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseWhitespaceRules(t *testing.T) {
+	var parseTests = []struct {
+		value         string
+		expectedRules WhitespaceRules
+	}{
+		{value: "", expectedRules: WhitespaceRules{blankAtEOL: true, spaceBeforeTab: true}},
+		{value: "cr-at-eol", expectedRules: WhitespaceRules{blankAtEOL: true, spaceBeforeTab: true, crAtEOL: true}},
+		{value: "-trailing-space, -space-before-tab", expectedRules: WhitespaceRules{}},
+		{value: "-blank-at-eol,tab-in-indent", expectedRules: WhitespaceRules{spaceBeforeTab: true}},
+	}
+
+	for _, parseTest := range parseTests {
+		if rules := ParseWhitespaceRules(parseTest.value); rules != parseTest.expectedRules {
+			t.Errorf("Whitespace rules do not match expected value for %q. Expected: %+v, Actual: %+v",
+				parseTest.value, parseTest.expectedRules, rules)
+		}
+	}
+}
+
+func TestWhitespaceErrors(t *testing.T) {
+	var whitespaceTests = []struct {
+		rules          WhitespaceRules
+		content        string
+		expectedErrors string
+	}{
+		{rules: ParseWhitespaceRules(""), content: "\treturn nil", expectedErrors: "           "},
+		{rules: ParseWhitespaceRules(""), content: "return nil \t", expectedErrors: "          xx"},
+		{rules: ParseWhitespaceRules(""), content: "  \treturn", expectedErrors: "xx       "},
+		{rules: ParseWhitespaceRules(""), content: "\t  return", expectedErrors: "         "},
+		{rules: ParseWhitespaceRules(""), content: "return\r", expectedErrors: "      x"},
+		{rules: ParseWhitespaceRules("cr-at-eol"), content: "return\r", expectedErrors: "       "},
+		{rules: ParseWhitespaceRules("cr-at-eol"), content: "return \r", expectedErrors: "      x "},
+		{rules: ParseWhitespaceRules("-blank-at-eol"), content: "return  ", expectedErrors: "        "},
+		{rules: ParseWhitespaceRules("-space-before-tab"), content: " \treturn", expectedErrors: "        "},
+	}
+
+	for _, whitespaceTest := range whitespaceTests {
+		whitespaceErrors, found := whitespaceTest.rules.WhitespaceErrors(whitespaceTest.content)
+
+		var errors []byte
+		for _, whitespaceError := range whitespaceErrors {
+			if whitespaceError {
+				errors = append(errors, 'x')
+			} else {
+				errors = append(errors, ' ')
+			}
+		}
+
+		if string(errors) != whitespaceTest.expectedErrors {
+			t.Errorf("Whitespace errors do not match expected value for %q. Expected: %q, Actual: %q",
+				whitespaceTest.content, whitespaceTest.expectedErrors, string(errors))
+		}
+
+		if expectedFound := strings.Contains(whitespaceTest.expectedErrors, "x"); found != expectedFound {
+			t.Errorf("Whitespace error found does not match expected value for %q. Expected: %v, Actual: %v",
+				whitespaceTest.content, expectedFound, found)
+		}
+	}
+}
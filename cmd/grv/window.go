@@ -3,6 +3,7 @@ package main
 import (
 	"bytes"
 	"fmt"
+	"strings"
 	"unicode"
 
 	log "github.com/Sirupsen/logrus"
@@ -10,6 +11,25 @@ import (
 	gc "github.com/rthornton128/goncurses"
 )
 
+// asciiFallbacks maps the non-ASCII characters grv displays to the
+// single width characters used in their place when borderchars is ascii
+var asciiFallbacks = map[rune]rune{
+	'→': '>',
+	'…': '~',
+	'›': '>',
+	'·': '.',
+	'␣': '_',
+	'█': '#',
+	'▪': '*',
+}
+
+// The characters displayed in place of invisible characters when invisibles is set
+const (
+	invisibleTabChar           = '→'
+	invisibleTrailingSpaceChar = '·'
+	invisibleNonBreakingChar   = '␣'
+)
+
 // RenderWindow represents a window that will be drawn to the display
 type RenderWindow interface {
 	ID() string
@@ -40,11 +60,13 @@ type line struct {
 
 // LineBuilder provides a way of drawing a single line to a window
 type LineBuilder struct {
-	line        *line
-	cellIndex   uint
-	column      uint
-	startColumn uint
-	config      Config
+	line               *line
+	cellIndex          uint
+	column             uint
+	startColumn        uint
+	config             Config
+	invisibles         bool
+	trailingSpaceCells []uint
 }
 
 type cellStyle struct {
@@ -65,15 +87,16 @@ type cursor struct {
 
 // Window implements the RenderWindow interface and contains all rendered data
 type Window struct {
-	id       string
-	rows     uint
-	cols     uint
-	lines    []*line
-	startRow uint
-	startCol uint
-	border   bool
-	config   Config
-	cursor   *cursor
+	id          string
+	rows        uint
+	cols        uint
+	lines       []*line
+	startRow    uint
+	startCol    uint
+	border      bool
+	config      Config
+	cursor      *cursor
+	selectedRow *uint
 }
 
 func newLine(cols uint) *line {
@@ -120,6 +143,11 @@ func (lineBuilder *LineBuilder) AppendWithStyle(themeComponentID ThemeComponentI
 
 	for _, codePoint := range str {
 		renderedCodePoints := DetermineRenderedCodePoint(codePoint, lineBuilder.column, lineBuilder.config)
+		codePointThemeComponentID := themeComponentID
+
+		if lineBuilder.invisibles {
+			renderedCodePoints, codePointThemeComponentID = lineBuilder.renderInvisible(codePoint, renderedCodePoints, themeComponentID)
+		}
 
 		for _, renderedCodePoint := range renderedCodePoints {
 			if lineBuilder.cellIndex > uint(len(line.cells)) {
@@ -127,10 +155,10 @@ func (lineBuilder *LineBuilder) AppendWithStyle(themeComponentID ThemeComponentI
 			}
 
 			if renderedCodePoint.width > 1 {
-				lineBuilder.setCellAndAdvanceIndex(renderedCodePoint.codePoint, renderedCodePoint.width, themeComponentID)
+				lineBuilder.setCellAndAdvanceIndex(renderedCodePoint.codePoint, renderedCodePoint.width, codePointThemeComponentID)
 				lineBuilder.Clear(renderedCodePoint.width - 1)
 			} else if renderedCodePoint.width > 0 {
-				lineBuilder.setCellAndAdvanceIndex(renderedCodePoint.codePoint, renderedCodePoint.width, themeComponentID)
+				lineBuilder.setCellAndAdvanceIndex(renderedCodePoint.codePoint, renderedCodePoint.width, codePointThemeComponentID)
 			} else {
 				lineBuilder.appendToPreviousCell(renderedCodePoint.codePoint)
 			}
@@ -140,6 +168,73 @@ func (lineBuilder *LineBuilder) AppendWithStyle(themeComponentID ThemeComponentI
 	return lineBuilder
 }
 
+// renderInvisible replaces the rendered representation of tabs and non-breaking spaces with placeholder characters
+// The cells of spaces are recorded so they can be marked if no other characters follow them
+func (lineBuilder *LineBuilder) renderInvisible(codePoint rune, renderedCodePoints []RenderedCodePoint,
+	themeComponentID ThemeComponentID) ([]RenderedCodePoint, ThemeComponentID) {
+	switch {
+	case codePoint == ' ':
+		if lineBuilder.column >= lineBuilder.startColumn && lineBuilder.cellIndex < uint(len(lineBuilder.line.cells)) {
+			lineBuilder.trailingSpaceCells = append(lineBuilder.trailingSpaceCells, lineBuilder.cellIndex)
+		}
+	case codePoint == '\t':
+		if len(renderedCodePoints) > 0 {
+			renderedCodePoints[0].codePoint = lineBuilder.invisibleChar(invisibleTabChar)
+		}
+
+		return renderedCodePoints, CmpAllviewInvisible
+	case isNonBreakingSpace(codePoint):
+		lineBuilder.trailingSpaceCells = nil
+
+		return []RenderedCodePoint{{
+			width:     1,
+			codePoint: lineBuilder.invisibleChar(invisibleNonBreakingChar),
+		}}, CmpAllviewInvisible
+	default:
+		lineBuilder.trailingSpaceCells = nil
+	}
+
+	return renderedCodePoints, themeComponentID
+}
+
+func (lineBuilder *LineBuilder) invisibleChar(codePoint rune) rune {
+	if asciiCodePoint, hasFallback := asciiFallbacks[codePoint]; hasFallback && lineBuilder.config.GetString(CfBorderChars) == BorderCharsASCII {
+		return asciiCodePoint
+	}
+
+	return codePoint
+}
+
+func isNonBreakingSpace(codePoint rune) bool {
+	return codePoint == '\u00a0' || codePoint == '\u2007' || codePoint == '\u202f'
+}
+
+// StartInvisibles displays tabs, trailing spaces and non-breaking spaces in the text appended
+// until EndInvisibles is called as placeholder characters if invisibles is set
+func (lineBuilder *LineBuilder) StartInvisibles() *LineBuilder {
+	lineBuilder.invisibles = lineBuilder.config != nil && lineBuilder.config.GetBool(CfInvisibles)
+	lineBuilder.trailingSpaceCells = nil
+
+	return lineBuilder
+}
+
+// EndInvisibles marks the spaces at the end of the text appended since StartInvisibles was called
+func (lineBuilder *LineBuilder) EndInvisibles() *LineBuilder {
+	if lineBuilder.invisibles {
+		for _, cellIndex := range lineBuilder.trailingSpaceCells {
+			cell := lineBuilder.line.cells[cellIndex]
+			cell.codePoints.Reset()
+			cell.codePoints.WriteRune(lineBuilder.invisibleChar(invisibleTrailingSpaceChar))
+			cell.style.themeComponentID = CmpAllviewInvisible
+		}
+	}
+
+	lineBuilder.invisibles = false
+	lineBuilder.trailingSpaceCells = nil
+
+	return lineBuilder
+}
+
 func (lineBuilder *LineBuilder) setCellAndAdvanceIndex(codePoint rune, width uint, themeComponentID ThemeComponentID) {
 	line := lineBuilder.line
 
@@ -157,6 +252,17 @@ func (lineBuilder *LineBuilder) setCellAndAdvanceIndex(codePoint rune, width uin
 	}
 }
 
+// AppendGutter adds text which is displayed regardless of the start column of the line builder
+// Text appended afterwards is displayed after the gutter and offset by the start column as normal
+func (lineBuilder *LineBuilder) AppendGutter(themeComponentID ThemeComponentID, format string, args ...interface{}) *LineBuilder {
+	startColumn := lineBuilder.startColumn
+	lineBuilder.startColumn = 1
+	lineBuilder.AppendWithStyle(themeComponentID, format, args...)
+	lineBuilder.startColumn = startColumn + lineBuilder.column - 1
+
+	return lineBuilder
+}
+
 // Clear resets the next cellNum cells in the line
 func (lineBuilder *LineBuilder) Clear(cellNum uint) {
 	line := lineBuilder.line
@@ -264,6 +370,7 @@ func (win *Window) Clear() {
 	}
 
 	win.cursor = nil
+	win.selectedRow = nil
 	win.border = false
 }
 
@@ -298,6 +405,19 @@ func (win *Window) SetSelectedRow(rowIndex uint, active bool) error {
 		return fmt.Errorf("SetSelectedRow: Invalid row index: %v >= %v rows", rowIndex, win.rows)
 	}
 
+	// Screen readers follow the cursor rather than highlighting, so the cursor is placed on the selected row
+	if win.screenReaderEnabled() {
+		if active {
+			win.selectedRow = &rowIndex
+
+			if win.cursor == nil {
+				win.cursor = &cursor{row: rowIndex}
+			}
+		}
+
+		return nil
+	}
+
 	var attr gc.Char = gc.A_REVERSE
 
 	if !active {
@@ -314,6 +434,19 @@ func (win *Window) SetSelectedRow(rowIndex uint, active bool) error {
 	return nil
 }
 
+// SelectedLine returns the text of the selected row if the window is active and has a selected row
+func (win *Window) SelectedLine() (line string, isSelected bool) {
+	if win.selectedRow == nil {
+		return
+	}
+
+	return strings.TrimSpace(win.Line(*win.selectedRow)), true
+}
+
+func (win *Window) screenReaderEnabled() bool {
+	return win.config != nil && win.config.GetBool(CfScreenReader)
+}
+
 // IsCursorSet returns true if a cursor position has been set
 func (win *Window) IsCursorSet() bool {
 	return win.cursor != nil
@@ -387,9 +520,18 @@ func (win *Window) setHeader(rowIndex uint, rightJustified bool, themeComponentI
 }
 
 // DrawBorder draws a line of a single cells width around the edge of the window
+// Borders are left blank for screen readers, which would otherwise read out the box-drawing characters
 func (win *Window) DrawBorder() {
 	if win.rows < 3 || win.cols < 3 {
 		return
+	} else if win.screenReaderEnabled() {
+		win.border = true
+		return
+	}
+
+	if win.config.GetString(CfBorderChars) == BorderCharsASCII {
+		win.drawASCIIBorder()
+		return
 	}
 
 	firstLine := win.lines[0]
@@ -419,6 +561,34 @@ func (win *Window) DrawBorder() {
 	win.border = true
 }
 
+// drawASCIIBorder draws the border using characters which can be displayed
+// by terminals and fonts without box-drawing support
+func (win *Window) drawASCIIBorder() {
+	setBorderChar := func(cell *cell, codePoint rune) {
+		cell.codePoints.Reset()
+		cell.codePoints.WriteRune(codePoint)
+	}
+
+	for _, rowIndex := range []uint{0, win.rows - 1} {
+		line := win.lines[rowIndex]
+		setBorderChar(line.cells[0], '+')
+
+		for i := uint(1); i < win.cols-1; i++ {
+			setBorderChar(line.cells[i], '-')
+		}
+
+		setBorderChar(line.cells[win.cols-1], '+')
+	}
+
+	for i := uint(1); i < win.rows-1; i++ {
+		line := win.lines[i]
+		setBorderChar(line.cells[0], '|')
+		setBorderChar(line.cells[win.cols-1], '|')
+	}
+
+	win.border = true
+}
+
 // ApplyStyle sets a single style for all cells in the window
 func (win *Window) ApplyStyle(themeComponentID ThemeComponentID) {
 	for _, line := range win.lines {
@@ -454,6 +624,11 @@ func DetermineRenderedCodePoint(codePoint rune, column uint, config Config) (ren
 				codePoint: codePoint,
 			})
 		}
+	} else if asciiCodePoint, hasFallback := asciiFallbacks[codePoint]; hasFallback && config != nil && config.GetString(CfBorderChars) == BorderCharsASCII {
+		renderedCodePoints = append(renderedCodePoints, RenderedCodePoint{
+			width:     1,
+			codePoint: asciiCodePoint,
+		})
 	} else {
 		renderedCodePoints = append(renderedCodePoints, RenderedCodePoint{
 			width:     uint(rw.RuneWidth(codePoint)),
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestScreenReaderModeUsesCursorForSelection(t *testing.T) {
+	config := NewConfiguration(NewKeyBindingManager(), nil)
+	if errs := config.Evaluate("set screenreader true"); errs != nil {
+		t.Fatalf("Unable to enable screen reader mode: %v", errs)
+	}
+
+	win := NewWindow("screenReader", config)
+	win.Resize(ViewDimension{rows: 5, cols: 20})
+	win.Clear()
+
+	if err := win.SetRow(2, 1, CmpNone, " Selected line"); err != nil {
+		t.Fatalf("Unable to set row: %v", err)
+	}
+
+	if err := win.SetSelectedRow(2, true); err != nil {
+		t.Fatalf("Unable to set selected row: %v", err)
+	}
+
+	win.DrawBorder()
+
+	for lineIndex, line := range win.lines {
+		for cellIndex, cell := range line.cells {
+			if cell.style.acsChar != 0 {
+				t.Errorf("Unexpected box-drawing character at row %v column %v", lineIndex, cellIndex)
+			}
+
+			if cell.style.attr != 0 {
+				t.Errorf("Unexpected attribute at row %v column %v", lineIndex, cellIndex)
+			}
+		}
+	}
+
+	if !win.IsCursorSet() || win.cursor.row != 2 {
+		t.Errorf("Expected cursor to be placed on the selected row")
+	}
+
+	if line, isSelected := win.SelectedLine(); !isSelected || line != "Selected line" {
+		t.Errorf("Selected line does not match expected value. Expected: %q, Actual: %q", "Selected line", line)
+	}
+}
+
+func TestInactiveWindowHasNoSelectedLineInScreenReaderMode(t *testing.T) {
+	config := NewConfiguration(NewKeyBindingManager(), nil)
+	if errs := config.Evaluate("set screenreader true"); errs != nil {
+		t.Fatalf("Unable to enable screen reader mode: %v", errs)
+	}
+
+	win := NewWindow("screenReader", config)
+	win.Resize(ViewDimension{rows: 5, cols: 20})
+	win.Clear()
+
+	if err := win.SetSelectedRow(1, false); err != nil {
+		t.Fatalf("Unable to set selected row: %v", err)
+	}
+
+	if _, isSelected := win.SelectedLine(); isSelected {
+		t.Errorf("Expected inactive window to have no selected line")
+	}
+
+	if win.IsCursorSet() {
+		t.Errorf("Expected inactive window to have no cursor")
+	}
+}
+
+func TestASCIIBorderCharsAreUsedForBordersAndMarkers(t *testing.T) {
+	config := NewConfiguration(NewKeyBindingManager(), nil)
+	if errs := config.Evaluate("set borderchars ascii"); errs != nil {
+		t.Fatalf("Unable to set ascii border chars: %v", errs)
+	}
+
+	win := NewWindow("asciiBorder", config)
+	win.Resize(ViewDimension{rows: 3, cols: 10})
+	win.Clear()
+
+	if err := win.SetRow(1, 1, CmpNone, " a → b…"); err != nil {
+		t.Fatalf("Unable to set row: %v", err)
+	}
+
+	win.DrawBorder()
+
+	expectedLines := []string{"+--------+", "|a > b~  |", "+--------+"}
+
+	for lineIndex, expectedLine := range expectedLines {
+		if line := win.lines[lineIndex].String(); line != expectedLine {
+			t.Errorf("Line %v does not match expected value. Expected: %q, Actual: %q", lineIndex, expectedLine, line)
+		}
+
+		for cellIndex, cell := range win.lines[lineIndex].cells {
+			if cell.style.acsChar != 0 {
+				t.Errorf("Unexpected box-drawing character at row %v column %v", lineIndex, cellIndex)
+			}
+		}
+	}
+}
+
+func TestInvisibleCharactersAreDisplayedWhenInvisiblesIsSet(t *testing.T) {
+	config := NewConfiguration(NewKeyBindingManager(), nil)
+	if errs := config.Evaluate("set invisibles true"); errs != nil {
+		t.Fatalf("Unable to set invisibles: %v", errs)
+	}
+
+	if errs := config.Evaluate("set tabwidth 4"); errs != nil {
+		t.Fatalf("Unable to set tab width: %v", errs)
+	}
+
+	win := NewWindow("invisibles", config)
+	win.Resize(ViewDimension{rows: 1, cols: 16})
+	win.Clear()
+
+	lineBuilder, err := win.LineBuilder(0, 1)
+	if err != nil {
+		t.Fatalf("Unable to create line builder: %v", err)
+	}
+
+	lineBuilder.Append("+ ").StartInvisibles().Append("\ta b c  ").EndInvisibles().Append("  ")
+
+	expectedLine := "+ → a b␣c··     "
+	if line := win.lines[0].String(); line != expectedLine {
+		t.Errorf("Line does not match expected value. Expected: %q, Actual: %q", expectedLine, line)
+	}
+
+	expectedInvisibleCells := map[int]bool{2: true, 3: true, 7: true, 9: true, 10: true}
+	for cellIndex, cell := range win.lines[0].cells {
+		if isInvisible := cell.style.themeComponentID == CmpAllviewInvisible; isInvisible != expectedInvisibleCells[cellIndex] {
+			t.Errorf("Invisible style of cell %v does not match expected value. Expected: %v, Actual: %v",
+				cellIndex, expectedInvisibleCells[cellIndex], isInvisible)
+		}
+	}
+}
+
+func TestInvisibleCharactersAreNotDisplayedByDefault(t *testing.T) {
+	config := NewConfiguration(NewKeyBindingManager(), nil)
+
+	win := NewWindow("invisibles", config)
+	win.Resize(ViewDimension{rows: 1, cols: 12})
+	win.Clear()
+
+	lineBuilder, err := win.LineBuilder(0, 1)
+	if err != nil {
+		t.Fatalf("Unable to create line builder: %v", err)
+	}
+
+	lineBuilder.StartInvisibles().Append("\ta\u00a0 ").EndInvisibles()
+
+	expectedLine := "        a\u00a0  "
+	if line := win.lines[0].String(); line != expectedLine {
+		t.Errorf("Line does not match expected value. Expected: %q, Actual: %q", expectedLine, line)
+	}
+}
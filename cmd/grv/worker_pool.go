@@ -0,0 +1,100 @@
+package main
+
+import (
+	"context"
+	"sync"
+)
+
+// workerPoolResult is the outcome of processing a single item
+type workerPoolResult struct {
+	index int
+	err   error
+}
+
+// RunOrderedWorkers calls process for each index in [0, itemNum) using up to workerNum goroutines.
+// onProcessed is called on the calling goroutine with each range [start, end) of items which have been
+// processed after all items before them, so results can be consumed in order as they become available.
+// No further items are processed once process returns an error or the context is cancelled
+// and the first error (or the context error) is returned
+func RunOrderedWorkers(ctx context.Context, itemNum, workerNum int, process func(index int) error, onProcessed func(start, end int)) (err error) {
+	if itemNum <= 0 {
+		return
+	}
+
+	if workerNum > itemNum {
+		workerNum = itemNum
+	} else if workerNum < 1 {
+		workerNum = 1
+	}
+
+	indexCh := make(chan int)
+	resultCh := make(chan workerPoolResult)
+	stopCh := make(chan bool)
+
+	go func() {
+		defer close(indexCh)
+
+		for index := 0; index < itemNum; index++ {
+			select {
+			case indexCh <- index:
+			case <-stopCh:
+				return
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	var waitGroup sync.WaitGroup
+	waitGroup.Add(workerNum)
+
+	for worker := 0; worker < workerNum; worker++ {
+		go func() {
+			defer waitGroup.Done()
+
+			for index := range indexCh {
+				resultCh <- workerPoolResult{index: index, err: process(index)}
+			}
+		}()
+	}
+
+	go func() {
+		waitGroup.Wait()
+		close(resultCh)
+	}()
+
+	processed := make([]bool, itemNum)
+	nextIndex := 0
+
+	for result := range resultCh {
+		if err != nil {
+			continue
+		}
+
+		if err = result.err; err == nil {
+			err = ctx.Err()
+		}
+
+		if err != nil {
+			close(stopCh)
+			continue
+		}
+
+		processed[result.index] = true
+		startIndex := nextIndex
+
+		for nextIndex < itemNum && processed[nextIndex] {
+			nextIndex++
+		}
+
+		if nextIndex > startIndex && onProcessed != nil {
+			onProcessed(startIndex, nextIndex)
+		}
+	}
+
+	if err == nil && nextIndex < itemNum {
+		err = ctx.Err()
+	}
+
+	return
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRunOrderedWorkersProcessesItemsInOrder(t *testing.T) {
+	itemNum := 20
+	results := make([]int, itemNum)
+	var processedOrder []int
+
+	err := RunOrderedWorkers(context.Background(), itemNum, 4, func(index int) error {
+		time.Sleep(time.Duration(itemNum-index) * time.Millisecond)
+		results[index] = index * index
+		return nil
+	}, func(start, end int) {
+		for index := start; index < end; index++ {
+			if results[index] != index*index {
+				t.Errorf("Item %v was made available before it was processed", index)
+			}
+
+			processedOrder = append(processedOrder, index)
+		}
+	})
+
+	if err != nil {
+		t.Errorf("RunOrderedWorkers failed with error %v", err)
+	}
+
+	var expectedOrder []int
+	for index := 0; index < itemNum; index++ {
+		expectedOrder = append(expectedOrder, index)
+	}
+
+	if fmt.Sprint(processedOrder) != fmt.Sprint(expectedOrder) {
+		t.Errorf("Processed order does not match expected value. Expected: %v, Actual: %v", expectedOrder, processedOrder)
+	}
+}
+
+func TestRunOrderedWorkersStopsAfterError(t *testing.T) {
+	expectedErr := errors.New("Processing failed")
+	var processedEnd int
+
+	err := RunOrderedWorkers(context.Background(), 10, 1, func(index int) error {
+		if index == 3 {
+			return expectedErr
+		}
+
+		return nil
+	}, func(start, end int) {
+		processedEnd = end
+	})
+
+	if err != expectedErr {
+		t.Errorf("Error does not match expected value. Expected: %v, Actual: %v", expectedErr, err)
+	}
+
+	if processedEnd != 3 {
+		t.Errorf("Items after the error were made available. Expected end: 3, Actual end: %v", processedEnd)
+	}
+}
+
+func TestRunOrderedWorkersWithNoItems(t *testing.T) {
+	err := RunOrderedWorkers(context.Background(), 0, 4, func(index int) error {
+		t.Errorf("Unexpected call to process item %v", index)
+		return nil
+	}, nil)
+
+	if err != nil {
+		t.Errorf("RunOrderedWorkers failed with error %v", err)
+	}
+}
+
+func TestRunOrderedWorkersStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var processedEnd int
+
+	err := RunOrderedWorkers(ctx, 100, 2, func(index int) error {
+		if index == 5 {
+			cancel()
+		}
+
+		return nil
+	}, func(start, end int) {
+		processedEnd = end
+	})
+
+	if err != context.Canceled {
+		t.Errorf("Error does not match expected value. Expected: %v, Actual: %v", context.Canceled, err)
+	}
+
+	if processedEnd >= 100 {
+		t.Errorf("Expected processing to stop once the context was cancelled")
+	}
+}
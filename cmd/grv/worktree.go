@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	wtCommonDirFile    = "commondir"
+	wtGitDirFile       = "gitdir"
+	wtWorktreesDir     = "worktrees"
+	wtDefaultGitDir    = ".git"
+	wtMainWorktreeName = "main"
+)
+
+// Worktree is a working tree attached to the repository
+type Worktree struct {
+	name    string
+	path    string
+	current bool
+}
+
+// readWorktrees finds the main worktree and the linked worktrees of the repository with the provided git directory.
+// The worktree with the provided working directory is marked as current
+func readWorktrees(gitDir, workDir string) (worktrees []*Worktree, err error) {
+	commonDir, err := worktreeCommonDir(gitDir)
+	if err != nil {
+		return
+	}
+
+	workDir = filepath.Clean(workDir)
+
+	if filepath.Base(commonDir) == wtDefaultGitDir {
+		mainPath := filepath.Dir(commonDir)
+		worktrees = append(worktrees, &Worktree{
+			name:    wtMainWorktreeName,
+			path:    mainPath,
+			current: mainPath == workDir,
+		})
+	}
+
+	entries, err := ioutil.ReadDir(filepath.Join(commonDir, wtWorktreesDir))
+	if os.IsNotExist(err) {
+		return worktrees, nil
+	} else if err != nil {
+		return
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+
+		content, readErr := ioutil.ReadFile(filepath.Join(commonDir, wtWorktreesDir, entry.Name(), wtGitDirFile))
+		if readErr != nil {
+			continue
+		}
+
+		// gitdir contains the path of the .git file in the worktree
+		path := filepath.Dir(strings.TrimSpace(string(content)))
+		worktrees = append(worktrees, &Worktree{
+			name:    entry.Name(),
+			path:    path,
+			current: path == workDir,
+		})
+	}
+
+	return
+}
+
+// worktreeCommonDir returns the git directory shared by all worktrees
+// Linked worktrees record the path of the common directory relative to their own git directory
+func worktreeCommonDir(gitDir string) (string, error) {
+	gitDir = filepath.Clean(gitDir)
+
+	content, err := ioutil.ReadFile(filepath.Join(gitDir, wtCommonDirFile))
+	if os.IsNotExist(err) {
+		return gitDir, nil
+	} else if err != nil {
+		return "", err
+	}
+
+	commonDir := strings.TrimSpace(string(content))
+	if !filepath.IsAbs(commonDir) {
+		commonDir = filepath.Join(gitDir, commonDir)
+	}
+
+	return filepath.Clean(commonDir), nil
+}
+
+// findWorktree returns the worktree with the provided name or path
+func findWorktree(worktrees []*Worktree, nameOrPath string) (*Worktree, error) {
+	path, _ := filepath.Abs(nameOrPath)
+
+	for _, worktree := range worktrees {
+		if worktree.name == nameOrPath || worktree.path == path {
+			return worktree, nil
+		}
+	}
+
+	var names []string
+	for _, worktree := range worktrees {
+		names = append(names, worktree.name)
+	}
+
+	return nil, fmt.Errorf("No worktree named %v. Worktrees: %v", nameOrPath, strings.Join(names, ", "))
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func createLinkedWorktree(t *testing.T, commonDir, name, path string) {
+	adminDir := filepath.Join(commonDir, wtWorktreesDir, name)
+
+	if err := os.MkdirAll(adminDir, 0755); err != nil {
+		t.Fatalf("Unable to create directory: %v", err)
+	}
+
+	files := map[string]string{
+		filepath.Join(adminDir, wtGitDirFile):    filepath.Join(path, wtDefaultGitDir) + "\n",
+		filepath.Join(adminDir, wtCommonDirFile): "../..\n",
+	}
+
+	for file, content := range files {
+		if err := ioutil.WriteFile(file, []byte(content), 0644); err != nil {
+			t.Fatalf("Unable to write file: %v", err)
+		}
+	}
+}
+
+func TestWorktreesAreReadFromTheCommonDirectory(t *testing.T) {
+	dir, err := ioutil.TempDir("", "grv-worktree")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	mainPath := filepath.Join(dir, "repo")
+	commonDir := filepath.Join(mainPath, wtDefaultGitDir)
+	featurePath := filepath.Join(dir, "feature")
+
+	createLinkedWorktree(t, commonDir, "feature", featurePath)
+
+	var worktreeTests = []struct {
+		gitDir          string
+		workDir         string
+		currentWorktree string
+	}{
+		{
+			gitDir:          commonDir + "/",
+			workDir:         mainPath + "/",
+			currentWorktree: wtMainWorktreeName,
+		},
+		{
+			gitDir:          filepath.Join(commonDir, wtWorktreesDir, "feature"),
+			workDir:         featurePath,
+			currentWorktree: "feature",
+		},
+	}
+
+	for _, worktreeTest := range worktreeTests {
+		worktrees, err := readWorktrees(worktreeTest.gitDir, worktreeTest.workDir)
+		if err != nil {
+			t.Errorf("readWorktrees failed with error %v", err)
+			continue
+		}
+
+		if len(worktrees) != 2 {
+			t.Errorf("Expected 2 worktrees but found %v", len(worktrees))
+			continue
+		}
+
+		expectedWorktrees := []Worktree{
+			{name: wtMainWorktreeName, path: mainPath, current: worktreeTest.currentWorktree == wtMainWorktreeName},
+			{name: "feature", path: featurePath, current: worktreeTest.currentWorktree == "feature"},
+		}
+
+		for index, expectedWorktree := range expectedWorktrees {
+			if *worktrees[index] != expectedWorktree {
+				t.Errorf("Worktree does not match expected value. Expected %v, Actual %v", expectedWorktree, *worktrees[index])
+			}
+		}
+	}
+}
+
+func TestRepositoryWithNoLinkedWorktreesHasOnlyMainWorktree(t *testing.T) {
+	dir, err := ioutil.TempDir("", "grv-worktree")
+	if err != nil {
+		t.Fatalf("Unable to create temporary directory: %v", err)
+	}
+	defer os.RemoveAll(dir)
+
+	commonDir := filepath.Join(dir, wtDefaultGitDir)
+	if err = os.Mkdir(commonDir, 0755); err != nil {
+		t.Fatalf("Unable to create directory: %v", err)
+	}
+
+	worktrees, err := readWorktrees(commonDir, dir)
+	if err != nil {
+		t.Fatalf("readWorktrees failed with error %v", err)
+	}
+
+	expectedWorktree := Worktree{name: wtMainWorktreeName, path: dir, current: true}
+
+	if len(worktrees) != 1 || *worktrees[0] != expectedWorktree {
+		t.Errorf("Worktrees do not match expected value. Expected [%v], Actual %v", expectedWorktree, worktrees)
+	}
+}
+
+func TestFindWorktreeMatchesNameOrPath(t *testing.T) {
+	worktrees := []*Worktree{
+		{name: wtMainWorktreeName, path: "/src/grv"},
+		{name: "feature", path: "/src/grv-feature"},
+	}
+
+	var findTests = []struct {
+		nameOrPath       string
+		expectedWorktree *Worktree
+	}{
+		{nameOrPath: "feature", expectedWorktree: worktrees[1]},
+		{nameOrPath: "/src/grv", expectedWorktree: worktrees[0]},
+		{nameOrPath: "/src/grv-feature/", expectedWorktree: worktrees[1]},
+		{nameOrPath: "missing", expectedWorktree: nil},
+	}
+
+	for _, findTest := range findTests {
+		worktree, err := findWorktree(worktrees, findTest.nameOrPath)
+
+		if findTest.expectedWorktree == nil {
+			if err == nil {
+				t.Errorf("Expected error for %v but found worktree %v", findTest.nameOrPath, worktree)
+			}
+		} else if err != nil {
+			t.Errorf("findWorktree failed with error %v", err)
+		} else if worktree != findTest.expectedWorktree {
+			t.Errorf("Worktree does not match expected value. Expected %v, Actual %v", findTest.expectedWorktree, worktree)
+		}
+	}
+}
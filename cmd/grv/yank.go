@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	log "github.com/Sirupsen/logrus"
+)
+
+// clipboardCommands are tried in order when no yank command has been configured
+var clipboardCommands = []string{
+	"pbcopy",
+	"wl-copy",
+	"xclip -selection clipboard",
+	"xsel --clipboard --input",
+	"clip.exe",
+}
+
+// Yank copies the text to the clipboard by piping it to the configured yank command.
+// If no yank command is configured then the first clipboard command available is used
+func Yank(config Config, text string) (err error) {
+	command := config.GetString(CfYankCommand)
+
+	if command == "" {
+		if command = findClipboardCommand(exec.LookPath); command == "" {
+			return fmt.Errorf("No clipboard command found. Set %v to yank text", CfYankCommand)
+		}
+	}
+
+	log.Debugf("Yanking %q using command: %v", text, command)
+
+	cmd := yankCommand(command, text)
+	if output, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("Yank command failed: %v: %s", err, output)
+	}
+
+	return
+}
+
+// findClipboardCommand returns the first clipboard command whose program can be found
+func findClipboardCommand(lookPath func(string) (string, error)) string {
+	for _, command := range clipboardCommands {
+		if _, err := lookPath(strings.Fields(command)[0]); err == nil {
+			return command
+		}
+	}
+
+	return ""
+}
+
+// yankCommand creates a shell command which receives the text on its standard input
+// It is also used to pipe text to commands run in the terminal
+func yankCommand(command, text string) *exec.Cmd {
+	cmd := exec.Command("sh", "-c", command)
+	cmd.Stdin = strings.NewReader(text)
+
+	return cmd
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestYankCommandReceivesTextOnStandardInput(t *testing.T) {
+	output, err := yankCommand("cat", "v1.0-3-gabc1234").Output()
+	if err != nil {
+		t.Fatalf("Yank command failed: %v", err)
+	}
+
+	expectedOutput := "v1.0-3-gabc1234"
+
+	if string(output) != expectedOutput {
+		t.Errorf("Yank command output does not match expected value. Expected: %v, Actual: %v", expectedOutput, string(output))
+	}
+}
+
+func TestFindClipboardCommandReturnsFirstAvailableCommand(t *testing.T) {
+	lookPath := func(program string) (string, error) {
+		if program == "xclip" || program == "xsel" {
+			return "/usr/bin/" + program, nil
+		}
+
+		return "", fmt.Errorf("%v not found", program)
+	}
+
+	expectedCommand := "xclip -selection clipboard"
+
+	if command := findClipboardCommand(lookPath); command != expectedCommand {
+		t.Errorf("Clipboard command does not match expected value. Expected: %v, Actual: %v", expectedCommand, command)
+	}
+}
+
+func TestFindClipboardCommandReturnsEmptyStringWhenNoneAvailable(t *testing.T) {
+	lookPath := func(program string) (string, error) {
+		return "", fmt.Errorf("%v not found", program)
+	}
+
+	if command := findClipboardCommand(lookPath); command != "" {
+		t.Errorf("Expected no clipboard command to be found but found: %v", command)
+	}
+}